@@ -5,13 +5,12 @@ package agent
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 
+	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
@@ -37,12 +36,7 @@ func AddReplicationEntriesToPgHbaConf(confs []*idl.AddReplicationEntriesRequest_
 		go func(conf *idl.AddReplicationEntriesRequest_Entry) {
 			defer wg.Done()
 
-			var lines strings.Builder
-			lines.WriteString(fmt.Sprintf("host replication %s samehost trust\n", conf.GetUser()))
-			for _, mirrorHostAddr := range conf.GetHostAddrs() {
-				lines.WriteString(fmt.Sprintf("host all %s %s trust\n", conf.GetUser(), mirrorHostAddr))
-				lines.WriteString(fmt.Sprintf("host replication %s %s trust\n", conf.GetUser(), mirrorHostAddr))
-			}
+			lines := greenplum.ReplicationHBAEntries(conf.GetUser(), conf.GetHostAddrs())
 
 			file, err := os.OpenFile(filepath.Join(conf.GetDataDir(), "pg_hba.conf"), os.O_APPEND|os.O_WRONLY, 0600)
 			if err != nil {
@@ -55,7 +49,7 @@ func AddReplicationEntriesToPgHbaConf(confs []*idl.AddReplicationEntriesRequest_
 				}
 			}()
 
-			_, err = file.WriteString(lines.String())
+			_, err = file.WriteString(lines)
 			if err != nil {
 				errs <- err
 			}
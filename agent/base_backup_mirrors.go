@@ -0,0 +1,94 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+var pgBasebackupCommand = exec.Command
+
+func (s *Server) BaseBackupMirrors(ctx context.Context, req *idl.BaseBackupMirrorsRequest) (*idl.BaseBackupMirrorsReply, error) {
+	log.Print("starting pg_basebackup of mirrors")
+
+	ctx, done := s.startWork(ctx)
+	defer done()
+
+	err := baseBackupMirrors(ctx, req.GetTargetBinDir(), req.GetBackups())
+	if err != nil {
+		return &idl.BaseBackupMirrorsReply{}, err
+	}
+
+	return &idl.BaseBackupMirrorsReply{}, nil
+}
+
+// baseBackupMirrors creates each mirror from its primary. Existing contents of
+// the mirror data directory are overwritten so that a failed attempt can be
+// retried.
+func baseBackupMirrors(ctx context.Context, binDir string, backups []*idl.BaseBackupMirrorsRequest_BaseBackup) error {
+	var wg sync.WaitGroup
+	errs := make(chan error, len(backups))
+
+	for _, backup := range backups {
+		backup := backup
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			cmd := pgBasebackupCommand(filepath.Join(binDir, "pg_basebackup"),
+				"--checkpoint", "fast",
+				"--pgdata", backup.GetMirrorDataDir(),
+				"--host", backup.GetPrimaryHost(),
+				"--port", strconv.Itoa(int(backup.GetPrimaryPort())),
+				"--slot", "internal_wal_replication_slot",
+				"-X", "stream",
+				"--write-recovery-conf",
+				"--force-overwrite",
+				"--target-gp-dbid", strconv.Itoa(int(backup.GetMirrorDbId())),
+				"--exclude", "./db_dumps",
+				"--exclude", "./promote",
+			)
+
+			log.Printf("Executing: %q", cmd.String())
+			output, err := runCombinedOutput(ctx, cmd)
+			if err != nil {
+				errs <- fmt.Errorf("pg_basebackup of %q failed with %q: %w", backup.GetMirrorDataDir(), output, err)
+				return
+			}
+
+			log.Printf("completed pg_basebackup of %q", backup.GetMirrorDataDir())
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	var err error
+	for e := range errs {
+		err = errorlist.Append(err, e)
+	}
+
+	return err
+}
+
+func runCombinedOutput(ctx context.Context, cmd *exec.Cmd) (string, error) {
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := utils.RunContext(ctx, cmd)
+	return output.String(), err
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func TestBaseBackupMirrors(t *testing.T) {
+	testlog.SetupTestLogger()
+	agentServer := agent.New()
+
+	t.Run("runs pg_basebackup for each mirror", func(t *testing.T) {
+		agent.SetPgBasebackupCommand(exectest.NewCommandWithVerifier(agent.Success, func(utility string, args ...string) {
+			expectedUtility := "/usr/local/greenplum-db-target/bin/pg_basebackup"
+			if utility != expectedUtility {
+				t.Errorf("got utility %q want %q", utility, expectedUtility)
+			}
+
+			expected := []string{
+				"--checkpoint", "fast",
+				"--pgdata", "/data/dbfast_mirror1/seg1",
+				"--host", "sdw1",
+				"--port", "50434",
+				"--slot", "internal_wal_replication_slot",
+				"-X", "stream",
+				"--write-recovery-conf",
+				"--force-overwrite",
+				"--target-gp-dbid", "4",
+				"--exclude", "./db_dumps",
+				"--exclude", "./promote",
+			}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+		defer agent.ResetPgBasebackupCommand()
+
+		req := &idl.BaseBackupMirrorsRequest{
+			TargetBinDir: "/usr/local/greenplum-db-target/bin",
+			Backups: []*idl.BaseBackupMirrorsRequest_BaseBackup{{
+				MirrorDataDir: "/data/dbfast_mirror1/seg1",
+				MirrorDbId:    4,
+				PrimaryHost:   "sdw1",
+				PrimaryPort:   50434,
+			}},
+		}
+
+		_, err := agentServer.BaseBackupMirrors(context.Background(), req)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("returns an error for each failed mirror", func(t *testing.T) {
+		agent.SetPgBasebackupCommand(exectest.NewCommand(agent.FailedMain))
+		defer agent.ResetPgBasebackupCommand()
+
+		req := &idl.BaseBackupMirrorsRequest{
+			TargetBinDir: "/usr/local/greenplum-db-target/bin",
+			Backups: []*idl.BaseBackupMirrorsRequest_BaseBackup{
+				{MirrorDataDir: "/data/dbfast_mirror1/seg1", MirrorDbId: 4, PrimaryHost: "sdw1", PrimaryPort: 50434},
+				{MirrorDataDir: "/data/dbfast_mirror2/seg2", MirrorDbId: 6, PrimaryHost: "sdw2", PrimaryPort: 50436},
+			},
+		}
+
+		_, err := agentServer.BaseBackupMirrors(context.Background(), req)
+
+		var errs errorlist.Errors
+		if !errors.As(err, &errs) {
+			t.Fatalf("got error %#v, want type %T", err, errs)
+		}
+
+		if len(errs) != 2 {
+			t.Errorf("got %d errors want 2", len(errs))
+		}
+
+		for _, err := range errs {
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) {
+				t.Errorf("got error %#v want %T", err, exitErr)
+			}
+		}
+	})
+}
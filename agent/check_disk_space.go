@@ -15,7 +15,19 @@ import (
 func (s *Server) CheckDiskSpace(ctx context.Context, in *idl.CheckSegmentDiskSpaceRequest) (*idl.CheckDiskSpaceReply, error) {
 	log.Printf("starting %s", idl.Substep_check_disk_space)
 
-	usage, err := disk.CheckUsage(step.DevNullStream, disk.Local, in.GetDiskFreeRatio(), in.GetDirs()...)
+	sizes, err := disk.MeasureDirs(in.GetDirs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements := sizes.Requirements(in.GetMode())
+	if in.GetBackupDir() != "" {
+		// The backup directory holds the copy of the upgraded coordinator
+		// used to upgrade the primaries.
+		requirements = append(requirements, disk.Requirement{Path: in.GetBackupDir(), Bytes: in.GetCoordinatorCopyBytes()})
+	}
+
+	usage, err := disk.CheckUsage(step.DevNullStream, disk.Local, requirements...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,95 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+var watchdogInterval = 10 * time.Second
+
+// WatchDiskSpace periodically checks the filesystems holding the requested
+// directories and streams a reply whenever a filesystem changes level. Once a
+// filesystem drops below the abort threshold any running pg_upgrade or rsync is
+// terminated before the filesystem fills up.
+func (s *Server) WatchDiskSpace(in *idl.WatchDiskSpaceRequest, stream idl.Agent_WatchDiskSpaceServer) error {
+	log.Printf("starting disk space watchdog for %q", in.GetDirs())
+
+	warn := disk.Threshold{Ratio: in.GetWarnFreeRatio(), KB: in.GetWarnFreeKB()}
+	abort := disk.Threshold{Ratio: in.GetAbortFreeRatio(), KB: in.GetAbortFreeKB()}
+
+	levels := make(map[string]idl.WatchDiskSpaceReply_Level)
+	ticker := time.NewTicker(watchdogInterval)
+	defer ticker.Stop()
+
+	for {
+		statuses, err := disk.FilesystemStatus(disk.Local, in.GetDirs()...)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			status.Level = disk.Level(status, warn, abort)
+
+			if levels[status.GetFs()] != status.GetLevel() {
+				levels[status.GetFs()] = status.GetLevel()
+
+				log.Printf("disk space %s: %s", status.GetLevel(), disk.DescribeStatus(status))
+				if err := stream.Send(status); err != nil {
+					return xerrors.Errorf("sending disk space status: %w", err)
+				}
+			}
+
+			if status.GetLevel() == idl.WatchDiskSpaceReply_abort {
+				// Abort on every check rather than only on a level change to
+				// catch work that started after the first abort.
+				s.abortWork(xerrors.Errorf("%w: %s", disk.ErrLowDiskSpace, disk.DescribeStatus(status)))
+			}
+		}
+
+		select {
+		case <-stream.Context().Done():
+			log.Print("stopping disk space watchdog")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// startWork returns a context which is cancelled when the watchdog aborts
+// work. The returned function must be called once the work is finished.
+func (s *Server) startWork(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	s.workMutex.Lock()
+	defer s.workMutex.Unlock()
+
+	id := s.nextWork
+	s.nextWork++
+	s.work[id] = cancel
+
+	return ctx, func() {
+		s.workMutex.Lock()
+		defer s.workMutex.Unlock()
+
+		delete(s.work, id)
+		cancel(nil)
+	}
+}
+
+func (s *Server) abortWork(cause error) {
+	s.workMutex.Lock()
+	defer s.workMutex.Unlock()
+
+	for _, cancel := range s.work {
+		cancel(cause)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+func TestWatchDiskSpace(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	agent.SetWatchdogInterval(time.Millisecond)
+	defer agent.ResetWatchdogInterval()
+
+	dir := t.TempDir()
+
+	t.Run("sends the status only when the level changes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sends := 0
+		stream := mock_idl.NewMockAgent_WatchDiskSpaceServer(ctrl)
+		stream.EXPECT().Context().Return(ctx).AnyTimes()
+		stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(reply *idl.WatchDiskSpaceReply) error {
+			sends++
+			if reply.GetLevel() != idl.WatchDiskSpaceReply_ok {
+				t.Errorf("got level %s want %s", reply.GetLevel(), idl.WatchDiskSpaceReply_ok)
+			}
+
+			if reply.GetTotal() == 0 {
+				t.Errorf("expected total space to be reported")
+			}
+
+			// let the watchdog check a few more times before stopping
+			time.AfterFunc(20*time.Millisecond, cancel)
+			return nil
+		})
+
+		server := agent.New()
+		err := server.WatchDiskSpace(&idl.WatchDiskSpaceRequest{Dirs: []string{dir, dir}}, stream)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+
+		if sends != 1 {
+			t.Errorf("got %d sends want 1", sends)
+		}
+	})
+
+	t.Run("aborts running work when below the abort threshold", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := agent.New()
+		work, done := agent.StartWork(server, context.Background())
+		defer done()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream := mock_idl.NewMockAgent_WatchDiskSpaceServer(ctrl)
+		stream.EXPECT().Context().Return(ctx).AnyTimes()
+		stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(reply *idl.WatchDiskSpaceReply) error {
+			if reply.GetLevel() != idl.WatchDiskSpaceReply_abort {
+				t.Errorf("got level %s want %s", reply.GetLevel(), idl.WatchDiskSpaceReply_abort)
+			}
+
+			return nil
+		})
+
+		go func() {
+			<-work.Done()
+			cancel()
+		}()
+
+		// A free ratio above one forces the abort level regardless of the
+		// space available on the test machine.
+		err := server.WatchDiskSpace(&idl.WatchDiskSpaceRequest{Dirs: []string{dir}, WarnFreeRatio: 2, AbortFreeRatio: 1.5}, stream)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+
+		if !errors.Is(context.Cause(work), disk.ErrLowDiskSpace) {
+			t.Errorf("got cause %#v want %#v", context.Cause(work), disk.ErrLowDiskSpace)
+		}
+	})
+
+	t.Run("cancels work once it is done", func(t *testing.T) {
+		server := agent.New()
+		work, done := agent.StartWork(server, context.Background())
+		done()
+
+		if context.Cause(work) != context.Canceled {
+			t.Errorf("got cause %#v want %#v", context.Cause(work), context.Canceled)
+		}
+	})
+
+	t.Run("errors when sending fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("connection closed")
+		stream := mock_idl.NewMockAgent_WatchDiskSpaceServer(ctrl)
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+		stream.EXPECT().Send(gomock.Any()).Return(expected)
+
+		server := agent.New()
+		err := server.WatchDiskSpace(&idl.WatchDiskSpaceRequest{Dirs: []string{dir}}, stream)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
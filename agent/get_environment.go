@@ -0,0 +1,18 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"log"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/environment"
+)
+
+func (s *Server) GetEnvironment(ctx context.Context, in *idl.GetEnvironmentRequest) (*idl.GetEnvironmentReply, error) {
+	log.Printf("starting %s", idl.Substep_check_environment)
+
+	return &idl.GetEnvironmentReply{Report: environment.Collect(in.GetGphomes()...)}, nil
+}
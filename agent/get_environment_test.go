@@ -0,0 +1,37 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils/environment"
+)
+
+func TestGetEnvironment(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	environment.SetExecCommand(exectest.NewCommand(agent.Success))
+	defer environment.ResetExecCommand()
+
+	server := agent.New()
+	reply, err := server.GetEnvironment(context.Background(), &idl.GetEnvironmentRequest{Gphomes: []string{"/usr/local/greenplum-db-source"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	gphomes := reply.GetReport().GetGphomes()
+	if len(gphomes) != 1 || gphomes[0].GetPath() != "/usr/local/greenplum-db-source" || gphomes[0].GetReachable() {
+		t.Errorf("got gphomes %v", gphomes)
+	}
+
+	if reply.GetReport().GetUnixNano() == 0 {
+		t.Error("expected the report to include the agent's time")
+	}
+}
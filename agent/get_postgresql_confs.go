@@ -0,0 +1,37 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"log"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func (s *Server) GetPostgresqlConfs(ctx context.Context, in *idl.GetPostgresqlConfsRequest) (*idl.GetPostgresqlConfsReply, error) {
+	log.Printf("starting %s", idl.Substep_migrate_source_settings)
+
+	var confs []*idl.GetPostgresqlConfsReply_PostgresqlConf
+	var err error
+	for _, dataDir := range in.GetDataDirs() {
+		settings, rErr := greenplum.ReadPostgresqlConf(dataDir)
+		if rErr != nil {
+			err = errorlist.Append(err, xerrors.Errorf("read postgresql.conf in %q: %w", dataDir, rErr))
+			continue
+		}
+
+		confs = append(confs, &idl.GetPostgresqlConfsReply_PostgresqlConf{DataDir: dataDir, Settings: settings})
+	}
+
+	if err != nil {
+		return &idl.GetPostgresqlConfsReply{}, err
+	}
+
+	return &idl.GetPostgresqlConfsReply{Confs: confs}, nil
+}
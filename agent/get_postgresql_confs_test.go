@@ -0,0 +1,51 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestGetPostgresqlConfs(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	server := agent.New()
+
+	t.Run("returns the settings of each data directory", func(t *testing.T) {
+		dir := t.TempDir()
+		testutils.MustWriteToFile(t, filepath.Join(dir, "postgresql.conf"), "gp_vmem_protect_limit = 8192\n")
+
+		reply, err := server.GetPostgresqlConfs(context.Background(), &idl.GetPostgresqlConfsRequest{DataDirs: []string{dir}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		confs := reply.GetConfs()
+		if len(confs) != 1 || confs[0].GetDataDir() != dir {
+			t.Fatalf("got confs %v", confs)
+		}
+
+		expected := map[string]string{"gp_vmem_protect_limit": "8192"}
+		if !reflect.DeepEqual(confs[0].GetSettings(), expected) {
+			t.Errorf("got settings %v want %v", confs[0].GetSettings(), expected)
+		}
+	})
+
+	t.Run("errors when postgresql.conf cannot be read", func(t *testing.T) {
+		_, err := server.GetPostgresqlConfs(context.Background(), &idl.GetPostgresqlConfsRequest{DataDirs: []string{t.TempDir()}})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got error %#v want not exist", err)
+		}
+	})
+}
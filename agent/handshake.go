@@ -0,0 +1,20 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func (s *Server) Handshake(ctx context.Context, in *idl.HandshakeRequest) (*idl.HandshakeReply, error) {
+	return &idl.HandshakeReply{
+		Version:     s.Version,
+		Commit:      s.Commit,
+		IdlRevision: idl.Revision(),
+		StateDir:    utils.GetStateDir(),
+	}, nil
+}
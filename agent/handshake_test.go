@@ -0,0 +1,36 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestHandshake(t *testing.T) {
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	server := agent.New()
+	server.Version = "1.2.3"
+	server.Commit = "5889c19"
+
+	reply, err := server.Handshake(context.Background(), &idl.HandshakeRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	expected := &idl.HandshakeReply{Version: "1.2.3", Commit: "5889c19", IdlRevision: idl.Revision(), StateDir: stateDir}
+	if !reflect.DeepEqual(reply, expected) {
+		t.Errorf("got %v want %v", reply, expected)
+	}
+}
@@ -4,7 +4,10 @@
 package agent
 
 import (
+	"context"
 	"os"
+	"os/exec"
+	"time"
 
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 )
@@ -31,3 +34,23 @@ func init() {
 		FailedRsync,
 	)
 }
+
+func SetWatchdogInterval(interval time.Duration) {
+	watchdogInterval = interval
+}
+
+func ResetWatchdogInterval() {
+	watchdogInterval = 10 * time.Second
+}
+
+func SetPgBasebackupCommand(command exectest.Command) {
+	pgBasebackupCommand = command
+}
+
+func ResetPgBasebackupCommand() {
+	pgBasebackupCommand = exec.Command
+}
+
+func StartWork(s *Server, ctx context.Context) (context.Context, func()) {
+	return s.startWork(ctx)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func (s *Server) GetPgHbaConfs(ctx context.Context, in *idl.GetPgHbaConfsRequest) (*idl.GetPgHbaConfsReply, error) {
+	log.Print("starting get pg_hba.conf")
+
+	var confs []*idl.PgHbaConf
+	var err error
+	for _, dataDir := range in.GetDataDirs() {
+		contents, rErr := utils.System.ReadFile(filepath.Join(dataDir, "pg_hba.conf"))
+		if rErr != nil {
+			err = errorlist.Append(err, xerrors.Errorf("read pg_hba.conf in %q: %w", dataDir, rErr))
+			continue
+		}
+
+		confs = append(confs, &idl.PgHbaConf{DataDir: dataDir, Contents: string(contents)})
+	}
+
+	if err != nil {
+		return &idl.GetPgHbaConfsReply{}, err
+	}
+
+	return &idl.GetPgHbaConfsReply{Confs: confs}, nil
+}
+
+func (s *Server) WritePgHbaConfs(ctx context.Context, in *idl.WritePgHbaConfsRequest) (*idl.WritePgHbaConfsReply, error) {
+	log.Printf("starting %s", idl.Substep_migrate_pg_hba)
+
+	var err error
+	for _, conf := range in.GetConfs() {
+		wErr := utils.AtomicallyWrite(filepath.Join(conf.GetDataDir(), "pg_hba.conf"), []byte(conf.GetContents()))
+		if wErr != nil {
+			err = errorlist.Append(err, xerrors.Errorf("write pg_hba.conf in %q: %w", conf.GetDataDir(), wErr))
+		}
+	}
+
+	if err != nil {
+		return &idl.WritePgHbaConfsReply{}, err
+	}
+
+	return &idl.WritePgHbaConfsReply{}, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/agent"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestGetPgHbaConfs(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	server := agent.New()
+
+	t.Run("returns pg_hba.conf of each data directory", func(t *testing.T) {
+		dir := t.TempDir()
+		testutils.MustWriteToFile(t, filepath.Join(dir, "pg_hba.conf"), "local all gpadmin ident\n")
+
+		reply, err := server.GetPgHbaConfs(context.Background(), &idl.GetPgHbaConfsRequest{DataDirs: []string{dir}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		confs := reply.GetConfs()
+		if len(confs) != 1 || confs[0].GetDataDir() != dir || confs[0].GetContents() != "local all gpadmin ident\n" {
+			t.Errorf("got confs %v", confs)
+		}
+	})
+
+	t.Run("errors when pg_hba.conf cannot be read", func(t *testing.T) {
+		_, err := server.GetPgHbaConfs(context.Background(), &idl.GetPgHbaConfsRequest{DataDirs: []string{t.TempDir()}})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got error %#v want not exist", err)
+		}
+	})
+}
+
+func TestWritePgHbaConfs(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	server := agent.New()
+
+	t.Run("replaces pg_hba.conf of each data directory", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "pg_hba.conf")
+		testutils.MustWriteToFile(t, path, "local all gpadmin ident\n")
+
+		contents := "local all gpadmin ident\nhost all all 10.0.0.0/8 md5\n"
+		_, err := server.WritePgHbaConfs(context.Background(), &idl.WritePgHbaConfsRequest{Confs: []*idl.PgHbaConf{{DataDir: dir, Contents: contents}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		actual := testutils.MustReadFile(t, path)
+		if actual != contents {
+			t.Errorf("got %q want %q", actual, contents)
+		}
+	})
+
+	t.Run("errors when pg_hba.conf cannot be written", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "does-not-exist")
+
+		_, err := server.WritePgHbaConfs(context.Background(), &idl.WritePgHbaConfsRequest{Confs: []*idl.PgHbaConf{{DataDir: dir}}})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got error %#v want not exist", err)
+		}
+	})
+}
@@ -13,13 +13,19 @@ import (
 )
 
 var RenameDirectories = upgrade.RenameDirectories
+var MoveDirectory = upgrade.MoveDirectory
 
 func (s *Server) RenameDirectories(ctx context.Context, in *idl.RenameDirectoriesRequest) (*idl.RenameDirectoriesReply, error) {
 	log.Printf("starting %s", idl.Substep_update_data_directories)
 
 	var mErr error
 	for _, dir := range in.GetDirs() {
-		err := RenameDirectories(dir.GetSource(), dir.GetTarget())
+		rename := RenameDirectories
+		if dir.GetMoveOnly() {
+			rename = MoveDirectory
+		}
+
+		err := rename(dir.GetSource(), dir.GetTarget())
 		if err != nil {
 			mErr = errorlist.Append(mErr, err)
 		}
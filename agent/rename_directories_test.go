@@ -6,11 +6,13 @@ package agent_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/greenplum-db/gpupgrade/agent"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/upgrade"
 )
 
 func TestRenameDirectories(t *testing.T) {
@@ -29,4 +31,38 @@ func TestRenameDirectories(t *testing.T) {
 			t.Errorf("returned error %#v, want %#v", err, expected)
 		}
 	})
+
+	t.Run("moves directories marked as move only", func(t *testing.T) {
+		var renamed, moved []string
+		agent.RenameDirectories = func(source, target string) error {
+			renamed = append(renamed, source)
+			return nil
+		}
+		agent.MoveDirectory = func(source, target string) error {
+			moved = append(moved, source)
+			return nil
+		}
+		defer func() {
+			agent.RenameDirectories = upgrade.RenameDirectories
+			agent.MoveDirectory = upgrade.MoveDirectory
+		}()
+
+		dirs := []*idl.RenameDirectories{
+			{Source: "/data/dbfast1/seg1", Target: "/data/dbfast1/seg.AAAAAAAAAAA.1"},
+			{Source: "/data/dbfast_mirror2/seg.AAAAAAAAAAA.2", Target: "/data/dbfast_mirror2/seg2", MoveOnly: true},
+		}
+
+		_, err := agentServer.RenameDirectories(context.Background(), &idl.RenameDirectoriesRequest{Dirs: dirs})
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		if !reflect.DeepEqual(renamed, []string{"/data/dbfast1/seg1"}) {
+			t.Errorf("renamed %q", renamed)
+		}
+
+		if !reflect.DeepEqual(moved, []string{"/data/dbfast_mirror2/seg.AAAAAAAAAAA.2"}) {
+			t.Errorf("moved %q", moved)
+		}
+	})
 }
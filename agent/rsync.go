@@ -32,7 +32,10 @@ func (s *Server) RsyncDataDirectories(ctx context.Context, in *idl.RsyncRequest)
 		return &idl.RsyncReply{}, mErr
 	}
 
-	return &idl.RsyncReply{}, rsyncRequestDirs(in)
+	ctx, done := s.startWork(ctx)
+	defer done()
+
+	return &idl.RsyncReply{}, rsyncRequestDirs(ctx, in)
 }
 
 func (s *Server) RsyncTablespaceDirectories(ctx context.Context, in *idl.RsyncRequest) (*idl.RsyncReply, error) {
@@ -48,10 +51,13 @@ func (s *Server) RsyncTablespaceDirectories(ctx context.Context, in *idl.RsyncRe
 		}
 	}
 
-	return &idl.RsyncReply{}, rsyncRequestDirs(in)
+	ctx, done := s.startWork(ctx)
+	defer done()
+
+	return &idl.RsyncReply{}, rsyncRequestDirs(ctx, in)
 }
 
-func rsyncRequestDirs(in *idl.RsyncRequest) error {
+func rsyncRequestDirs(ctx context.Context, in *idl.RsyncRequest) error {
 	hostname, err := os.Hostname()
 	if err != nil {
 		return err
@@ -73,6 +79,7 @@ func rsyncRequestDirs(in *idl.RsyncRequest) error {
 				rsync.WithDestination(opts.GetDestination()),
 				rsync.WithOptions(opts.GetOptions()...),
 				rsync.WithExcludedFiles(opts.GetExcludedFiles()...),
+				rsync.WithContext(ctx),
 			}
 			err := rsync.Rsync(opts...)
 			if err != nil {
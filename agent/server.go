@@ -22,15 +22,27 @@ import (
 )
 
 type Server struct {
+	// Version and Commit identify the running agent binary and are reported
+	// to the hub during the handshake.
+	Version string
+	Commit  string
+
 	mutex       sync.Mutex
 	gRPCserver  *grpc.Server
 	listener    net.Listener
 	stoppedChan chan struct{}
+
+	// work holds the cancel functions of long running RPCs such as
+	// pg_upgrade and rsync so the disk space watchdog can abort them.
+	workMutex sync.Mutex
+	work      map[int]context.CancelCauseFunc
+	nextWork  int
 }
 
 func New() *Server {
 	return &Server{
 		stoppedChan: make(chan struct{}, 1),
+		work:        make(map[int]context.CancelCauseFunc),
 	}
 }
 
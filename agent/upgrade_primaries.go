@@ -25,7 +25,10 @@ import (
 func (s *Server) UpgradePrimaries(ctx context.Context, req *idl.UpgradePrimariesRequest) (*idl.UpgradePrimariesReply, error) {
 	log.Printf("starting %s", req.GetAction())
 
-	err := upgradePrimariesInParallel(req.GetOpts())
+	ctx, done := s.startWork(ctx)
+	defer done()
+
+	err := upgradePrimariesInParallel(ctx, req.GetOpts())
 	if err != nil {
 		return &idl.UpgradePrimariesReply{}, err
 	}
@@ -33,7 +36,7 @@ func (s *Server) UpgradePrimaries(ctx context.Context, req *idl.UpgradePrimaries
 	return &idl.UpgradePrimariesReply{}, nil
 }
 
-func upgradePrimariesInParallel(opts []*idl.PgOptions) error {
+func upgradePrimariesInParallel(ctx context.Context, opts []*idl.PgOptions) error {
 	host, err := utils.System.Hostname()
 	if err != nil {
 		return err
@@ -47,7 +50,7 @@ func upgradePrimariesInParallel(opts []*idl.PgOptions) error {
 		go func(host string, opt *idl.PgOptions) {
 			defer wg.Done()
 
-			errs <- upgradePrimarySegment(host, opt)
+			errs <- upgradePrimarySegment(ctx, host, opt)
 		}(host, opt)
 	}
 
@@ -61,32 +64,40 @@ func upgradePrimariesInParallel(opts []*idl.PgOptions) error {
 	return err
 }
 
-func upgradePrimarySegment(host string, opt *idl.PgOptions) error {
+func upgradePrimarySegment(ctx context.Context, host string, opt *idl.PgOptions) error {
 	if opt.GetAction() != idl.PgOptions_check {
-		err := restoreBackup(opt.GetBackupDir(), opt.GetNewDataDir())
+		err := restoreBackup(ctx, opt.GetBackupDir(), opt.GetNewDataDir())
 		if err != nil {
 			return xerrors.Errorf("restore backup of upgraded master data directory on host %s for content id %d: %w", host, opt.GetContentID(), err)
 		}
 
-		err = RestoreTablespaces(opt.GetBackupDir(), opt.GetTablespaces(), opt.GetOldDBID(), opt.GetNewDataDir())
+		err = RestoreTablespaces(ctx, opt.GetBackupDir(), opt.GetTablespaces(), opt.GetOldDBID(), opt.GetNewDataDir())
 		if err != nil {
 			return xerrors.Errorf("restore tablespace on host %s for content id %d: %w", host, opt.GetContentID(), err)
 		}
 	}
 
-	err := upgrade.Run(io.Discard, io.Discard, opt)
+	err := upgrade.Run(ctx, io.Discard, io.Discard, opt)
 	if err != nil {
 		return xerrors.Errorf("%s primary on host %s with content %d: %w", opt.GetAction(), host, opt.GetContentID(), err)
 	}
 
+	if opt.GetAction() != idl.PgOptions_check {
+		err = upgrade.RelocateTablespaces(ctx, opt.GetNewDataDir(), opt.GetTablespaceRelocations())
+		if err != nil {
+			return xerrors.Errorf("relocate tablespaces on host %s for content id %d: %w", host, opt.GetContentID(), err)
+		}
+	}
+
 	return nil
 }
 
-func restoreBackup(backupDir string, newDataDir string) error {
+func restoreBackup(ctx context.Context, backupDir string, newDataDir string) error {
 	options := []rsync.Option{
 		rsync.WithSources(utils.GetCoordinatorPostUpgradeBackupDir(backupDir) + string(os.PathSeparator)),
 		rsync.WithDestination(newDataDir),
 		rsync.WithOptions("--archive", "--delete"),
+		rsync.WithContext(ctx),
 		rsync.WithExcludedFiles(
 			"internal.auto.conf",
 			"postgresql.conf",
@@ -100,7 +111,7 @@ func restoreBackup(backupDir string, newDataDir string) error {
 	return rsync.Rsync(options...)
 }
 
-func RestoreTablespaces(backupDir string, tablespaces map[int32]*idl.TablespaceInfo, oldDBID string, newDataDir string) error {
+func RestoreTablespaces(ctx context.Context, backupDir string, tablespaces map[int32]*idl.TablespaceInfo, oldDBID string, newDataDir string) error {
 	dbid, err := strconv.Atoi(oldDBID)
 	if err != nil {
 		return err
@@ -118,6 +129,7 @@ func RestoreTablespaces(backupDir string, tablespaces map[int32]*idl.TablespaceI
 			rsync.WithSources(sourceDir),
 			rsync.WithDestination(targetDir),
 			rsync.WithOptions("--archive", "--delete"),
+			rsync.WithContext(ctx),
 		}
 
 		if err := rsync.Rsync(options...); err != nil {
@@ -125,27 +137,10 @@ func RestoreTablespaces(backupDir string, tablespaces map[int32]*idl.TablespaceI
 		}
 
 		symLinkName := fmt.Sprintf("%s/pg_tblspc/%s", newDataDir, strconv.Itoa(int(oid)))
-		if err := recreateSymlink(targetDir, symLinkName); err != nil {
+		if err := upgrade.RecreateSymlink(targetDir, symLinkName); err != nil {
 			return xerrors.Errorf("recreate symbolic link: %w", err)
 		}
 	}
 
 	return nil
 }
-
-func recreateSymlink(sourceDir, symLinkName string) error {
-	_, err := utils.System.Lstat(symLinkName)
-	if err == nil {
-		if err := utils.System.Remove(symLinkName); err != nil {
-			return xerrors.Errorf("unlink %q: %w", symLinkName, err)
-		}
-	} else if !os.IsNotExist(err) {
-		return xerrors.Errorf("stat symbolic link %q: %w", symLinkName, err)
-	}
-
-	if err := utils.System.Symlink(sourceDir, symLinkName); err != nil {
-		return xerrors.Errorf("create symbolic link %q to directory %q: %w", symLinkName, sourceDir, err)
-	}
-
-	return nil
-}
@@ -0,0 +1,460 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit records who ran which gpupgrade command, the step and substep
+// transitions, and the destructive requests made to agents. Each entry includes
+// the hash of the previous entry such that modifying, removing, or reordering
+// entries is detected by Verify. Hashes are keyed by a secret kept in a
+// separate file from the log so the chain cannot be recomputed after editing
+// the log, and the last entry is anchored in a head file so removing trailing
+// entries is detected as well.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const FileName = "audit.log"
+
+// KeyFileName is the secret keying the hashes of the audit log. It is kept
+// next to the audit log rather than in it.
+const KeyFileName = "audit.key"
+
+// HeadFileName records the number of entries and the hash of the last entry of
+// the audit log.
+const HeadFileName = FileName + ".head"
+
+type Event string
+
+const (
+	Invocation        Event = "invocation"
+	Outcome           Event = "outcome"
+	StepTransition    Event = "step"
+	SubstepTransition Event = "substep"
+	AgentRequest      Event = "agent_request"
+)
+
+type Entry struct {
+	Time  time.Time `json:"time"`
+	Event Event     `json:"event"`
+	User  string    `json:"user"`
+	Host  string    `json:"host"`
+	PID   int       `json:"pid"`
+
+	// Invocation
+	Command      string            `json:"command,omitempty"`
+	Args         []string          `json:"args,omitempty"`
+	Flags        map[string]string `json:"flags,omitempty"`
+	ConfigFile   string            `json:"config_file,omitempty"`
+	ConfigSHA256 string            `json:"config_sha256,omitempty"`
+	Config       map[string]string `json:"config,omitempty"`
+
+	// Transitions
+	Step    string `json:"step,omitempty"`
+	Substep string `json:"substep,omitempty"`
+	Status  string `json:"status,omitempty"`
+
+	// Agent requests
+	AgentHost string `json:"agent_host,omitempty"`
+	Method    string `json:"method,omitempty"`
+	Request   string `json:"request,omitempty"`
+
+	Error string `json:"error,omitempty"`
+
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+var (
+	mutex        sync.Mutex
+	archivedPath string
+	invoked      bool
+	pending      *Entry
+)
+
+// Path returns the audit log in the state directory, or its archived location
+// once Archive has been called.
+func Path() string {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return path()
+}
+
+func path() string {
+	if archivedPath != "" {
+		return archivedPath
+	}
+
+	return filepath.Join(utils.GetStateDir(), FileName)
+}
+
+// Record appends the entry to the audit log. Nothing is recorded when the state
+// directory does not exist since there is no upgrade to audit.
+func Record(entry Entry) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return record(entry)
+}
+
+func record(entry Entry) error {
+	if archivedPath == "" {
+		if _, err := os.Stat(utils.GetStateDir()); errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+	}
+
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	if currentUser, err := utils.System.Current(); err == nil {
+		entry.User = currentUser.Username
+	}
+
+	entry.Host, _ = utils.System.Hostname()
+	entry.PID = os.Getpid()
+
+	file, err := os.OpenFile(path(), os.O_APPEND|os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return xerrors.Errorf("audit log: %w", err)
+	}
+	defer file.Close()
+
+	// The CLI and hub both append to the audit log, so lock it to ensure
+	// each entry chains from the latest one.
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		return xerrors.Errorf("lock audit log: %w", err)
+	}
+	defer syscall.Flock(int(file.Fd()), syscall.LOCK_UN) //nolint
+
+	dir := filepath.Dir(path())
+	key, err := loadKey(dir)
+	if err != nil {
+		return err
+	}
+
+	current, err := readHead(dir, file)
+	if err != nil {
+		return err
+	}
+
+	entry.PrevHash = current.Hash
+	entry, line, err := seal(key, entry)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(line); err != nil {
+		return xerrors.Errorf("write audit log: %w", err)
+	}
+
+	return writeHead(dir, head{Entries: current.Entries + 1, Hash: entry.Hash})
+}
+
+// seal sets the entry's hash over the previous hash and the entry's contents
+// and returns the entry along with it as a line of JSON.
+func seal(key []byte, entry Entry) (Entry, []byte, error) {
+	entry.Hash = ""
+	contents, err := json.Marshal(entry)
+	if err != nil {
+		return Entry{}, nil, xerrors.Errorf("marshal audit entry: %w", err)
+	}
+
+	entry.Hash = hash(key, entry.PrevHash, contents)
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return Entry{}, nil, xerrors.Errorf("marshal audit entry: %w", err)
+	}
+
+	return entry, append(line, '\n'), nil
+}
+
+func hash(key []byte, prevHash string, contents []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(prevHash + "\n"))
+	mac.Write(contents)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// loadKey returns the key of the audit log in dir creating it if needed.
+// Callers must hold the audit log's lock.
+func loadKey(dir string) ([]byte, error) {
+	keyPath := filepath.Join(dir, KeyFileName)
+
+	key, err := os.ReadFile(keyPath)
+	if err == nil {
+		return hex.DecodeString(strings.TrimSpace(string(key)))
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, xerrors.Errorf("read audit key: %w", err)
+	}
+
+	key = make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return nil, xerrors.Errorf("generate audit key: %w", err)
+	}
+
+	if err := os.WriteFile(keyPath, []byte(hex.EncodeToString(key)+"\n"), 0400); err != nil {
+		return nil, xerrors.Errorf("write audit key: %w", err)
+	}
+
+	return key, nil
+}
+
+// head anchors the end of the audit log such that removing trailing entries is
+// detected, and such that appending does not need to read the whole log.
+type head struct {
+	Entries int    `json:"entries"`
+	Hash    string `json:"hash"`
+}
+
+// readHead returns the head of the audit log falling back to reading the log
+// when there is no head file.
+func readHead(dir string, log io.Reader) (head, error) {
+	contents, err := os.ReadFile(filepath.Join(dir, HeadFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return scanHead(log)
+	}
+
+	if err != nil {
+		return head{}, xerrors.Errorf("read audit head: %w", err)
+	}
+
+	var h head
+	if err := json.Unmarshal(contents, &h); err != nil {
+		return head{}, xerrors.Errorf("parse audit head: %w", err)
+	}
+
+	return h, nil
+}
+
+func scanHead(log io.Reader) (head, error) {
+	contents, err := io.ReadAll(log)
+	if err != nil {
+		return head{}, xerrors.Errorf("read audit log: %w", err)
+	}
+
+	contents = bytes.TrimRight(contents, "\n")
+	if len(contents) == 0 {
+		return head{}, nil
+	}
+
+	last := contents[bytes.LastIndexByte(contents, '\n')+1:]
+
+	var entry Entry
+	if err := json.Unmarshal(last, &entry); err != nil {
+		return head{}, xerrors.Errorf("parse last audit entry: %w", err)
+	}
+
+	return head{Entries: bytes.Count(contents, []byte("\n")) + 1, Hash: entry.Hash}, nil
+}
+
+// writeHead atomically replaces the head file. Callers must hold the audit
+// log's lock.
+func writeHead(dir string, h head) error {
+	contents, err := json.Marshal(h)
+	if err != nil {
+		return xerrors.Errorf("marshal audit head: %w", err)
+	}
+
+	headPath := filepath.Join(dir, HeadFileName)
+	if err := utils.AtomicallyWrite(headPath, append(contents, '\n')); err != nil {
+		return xerrors.Errorf("write audit head: %w", err)
+	}
+
+	return nil
+}
+
+// Verify checks that no entries of the audit log have been modified, removed,
+// or reordered. The audit log's key and head are read from the same directory
+// as the audit log.
+func Verify(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Lock the audit log such that an entry being appended is not mistaken for
+	// tampering.
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_SH); err != nil {
+		return xerrors.Errorf("lock audit log: %w", err)
+	}
+	defer syscall.Flock(int(file.Fd()), syscall.LOCK_UN) //nolint
+
+	dir := filepath.Dir(path)
+	key, err := os.ReadFile(filepath.Join(dir, KeyFileName))
+	if err != nil {
+		return xerrors.Errorf("read audit key: %w", err)
+	}
+
+	key, err = hex.DecodeString(strings.TrimSpace(string(key)))
+	if err != nil {
+		return xerrors.Errorf("parse audit key: %w", err)
+	}
+
+	expected, err := readHead(dir, strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+
+	prevHash := ""
+	entries := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return xerrors.Errorf("audit log %q line %d: %w", path, lineNum, err)
+		}
+
+		if entry.PrevHash != prevHash {
+			return xerrors.Errorf("audit log %q line %d does not follow the previous entry", path, lineNum)
+		}
+
+		_, line, err := seal(key, entry)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(bytes.TrimSuffix(line, []byte("\n")), scanner.Bytes()) {
+			return xerrors.Errorf("audit log %q line %d has been modified", path, lineNum)
+		}
+
+		prevHash = entry.Hash
+		entries++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if entries != expected.Entries || prevHash != expected.Hash {
+		return xerrors.Errorf("audit log %q has %d entries but its head records %d. Entries have been removed or appended.", path, entries, expected.Entries)
+	}
+
+	return nil
+}
+
+// Redact hides the values of parameters which may contain credentials such as
+// webhook URLs with tokens.
+func Redact(name string, value string) string {
+	name = strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+	for _, secret := range []string{"notification_webhooks", "notification_command", "password", "secret", "token"} {
+		if strings.Contains(name, secret) && value != "" {
+			return "<redacted>"
+		}
+	}
+
+	return value
+}
+
+// RecordInvocation records the invocation of a gpupgrade command. When the
+// state directory does not exist yet, such as before initialize creates it,
+// the invocation is recorded by RecordOutcome instead.
+func RecordInvocation(entry Entry) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	invoked = true
+	entry.Event = Invocation
+	entry.Time = time.Now()
+
+	if _, err := os.Stat(utils.GetStateDir()); errors.Is(err, fs.ErrNotExist) && archivedPath == "" {
+		pending = &entry
+		return nil
+	}
+
+	return record(entry)
+}
+
+// RecordOutcome records whether the command recorded by RecordInvocation
+// succeeded.
+func RecordOutcome(err error) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if !invoked {
+		return nil
+	}
+
+	if pending != nil {
+		if rErr := record(*pending); rErr != nil {
+			return rErr
+		}
+		pending = nil
+	}
+
+	entry := Entry{Event: Outcome, Status: "complete"}
+	if err != nil {
+		entry.Status = "failed"
+		entry.Error = err.Error()
+	}
+
+	return record(entry)
+}
+
+// Archive moves the audit log to dir such that it is kept with the archived
+// logs once the state directory is deleted. Later entries from this process
+// are appended to the archived audit log.
+func Archive(dir string) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	archive := filepath.Join(dir, FileName)
+	if archivedPath == archive {
+		return nil
+	}
+
+	if _, err := os.Stat(path()); errors.Is(err, fs.ErrNotExist) {
+		archivedPath = archive
+		return nil
+	}
+
+	// Keep the key and head with the audit log so it can still be verified.
+	for _, name := range []string{KeyFileName, HeadFileName, FileName} {
+		source := filepath.Join(filepath.Dir(path()), name)
+		if _, err := os.Stat(source); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err := utils.Move(source, filepath.Join(dir, name)); err != nil {
+			return xerrors.Errorf("archive audit log: %w", err)
+		}
+	}
+
+	archivedPath = archive
+	return nil
+}
+
+// XXX: for internal testing only
+func Reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	archivedPath = ""
+	invoked = false
+	pending = nil
+}
@@ -0,0 +1,402 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/audit"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func entries(t *testing.T, path string) []audit.Entry {
+	t.Helper()
+
+	var result []audit.Entry
+	for _, line := range strings.Split(strings.TrimSpace(testutils.MustReadFile(t, path)), "\n") {
+		var entry audit.Entry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("unmarshal %q: %v", line, err)
+		}
+
+		result = append(result, entry)
+	}
+
+	return result
+}
+
+// removeAuditLog removes the audit log along with its key and head.
+func removeAuditLog(t *testing.T, dir string) {
+	t.Helper()
+
+	for _, name := range []string{audit.FileName, audit.KeyFileName, audit.HeadFileName} {
+		testutils.MustRemoveAll(t, filepath.Join(dir, name))
+	}
+}
+
+func TestRecord(t *testing.T) {
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	path := filepath.Join(stateDir, audit.FileName)
+
+	t.Run("appends entries chained to the previous entry", func(t *testing.T) {
+		defer removeAuditLog(t, stateDir)
+
+		utils.System.Current = func() (*user.User, error) {
+			return &user.User{Username: "gpadmin"}, nil
+		}
+		utils.System.Hostname = func() (string, error) {
+			return "cdw", nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := audit.Record(audit.Entry{Event: audit.StepTransition, Step: "execute", Status: "running"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = audit.Record(audit.Entry{Event: audit.SubstepTransition, Step: "execute", Substep: "upgrade_master", Status: "complete"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result := entries(t, path)
+		if len(result) != 2 {
+			t.Fatalf("got %d entries want 2", len(result))
+		}
+
+		first, second := result[0], result[1]
+		if first.User != "gpadmin" || first.Host != "cdw" || first.PID != os.Getpid() || first.Time.IsZero() {
+			t.Errorf("got entry %+v", first)
+		}
+
+		if first.PrevHash != "" || first.Hash == "" || second.PrevHash != first.Hash {
+			t.Errorf("expected the second entry to be chained to the first, got %+v and %+v", first, second)
+		}
+
+		if err := audit.Verify(path); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("chains entries from concurrent writers", func(t *testing.T) {
+		defer removeAuditLog(t, stateDir)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := audit.Record(audit.Entry{Event: audit.AgentRequest}); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(entries(t, path)) != 20 {
+			t.Errorf("expected 20 entries")
+		}
+
+		if err := audit.Verify(path); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("does nothing when the state directory does not exist", func(t *testing.T) {
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", filepath.Join(stateDir, "does-not-exist"))
+		defer resetEnv()
+
+		err := audit.Record(audit.Entry{Event: audit.StepTransition})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("appends from the head without reading the audit log", func(t *testing.T) {
+		defer removeAuditLog(t, stateDir)
+
+		if err := audit.Record(audit.Entry{Event: audit.StepTransition}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		first := entries(t, path)[0]
+		testutils.MustWriteToFile(t, path, "not json\n")
+
+		if err := audit.Record(audit.Entry{Event: audit.StepTransition}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(testutils.MustReadFile(t, path)), "\n")
+		var last audit.Entry
+		if err := json.Unmarshal([]byte(lines[len(lines)-1]), &last); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if last.PrevHash != first.Hash {
+			t.Errorf("got previous hash %q want %q", last.PrevHash, first.Hash)
+		}
+	})
+
+	t.Run("errors when the last entry cannot be parsed", func(t *testing.T) {
+		defer removeAuditLog(t, stateDir)
+
+		testutils.MustWriteToFile(t, path, "not json\n")
+
+		err := audit.Record(audit.Entry{Event: audit.StepTransition})
+		if err == nil || !strings.Contains(err.Error(), "parse last audit entry") {
+			t.Errorf("got error %v want a parse error", err)
+		}
+	})
+}
+
+// rehash recomputes the chain of hashes as someone editing the audit log
+// without its key would.
+func rehash(t *testing.T, lines []string) []string {
+	t.Helper()
+
+	prevHash := ""
+	var result []string
+	for _, line := range lines {
+		var entry audit.Entry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("unmarshal %q: %v", line, err)
+		}
+
+		entry.PrevHash = prevHash
+		entry.Hash = ""
+		contents, err := json.Marshal(entry)
+		if err != nil {
+			t.Fatalf("marshal %+v: %v", entry, err)
+		}
+
+		sum := sha256.Sum256(append([]byte(prevHash+"\n"), contents...))
+		entry.Hash = hex.EncodeToString(sum[:])
+		contents, err = json.Marshal(entry)
+		if err != nil {
+			t.Fatalf("marshal %+v: %v", entry, err)
+		}
+
+		result = append(result, string(contents)+"\n")
+		prevHash = entry.Hash
+	}
+
+	return result
+}
+
+func TestVerify(t *testing.T) {
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	path := filepath.Join(stateDir, audit.FileName)
+
+	write := func(t *testing.T) []string {
+		t.Helper()
+
+		for _, status := range []string{"running", "failed", "running"} {
+			err := audit.Record(audit.Entry{Event: audit.StepTransition, Step: "initialize", Status: status})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		return strings.SplitAfter(strings.TrimSpace(testutils.MustReadFile(t, path)), "\n")
+	}
+
+	cases := []struct {
+		name     string
+		tamper   func(lines []string) []string
+		expected string
+	}{
+		{
+			name: "modified",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], `"failed"`, `"complete"`, 1)
+				return lines
+			},
+			expected: "line 2 has been modified",
+		},
+		{
+			name: "removed",
+			tamper: func(lines []string) []string {
+				return append(lines[:1], lines[2:]...)
+			},
+			expected: "line 2 does not follow the previous entry",
+		},
+		{
+			name: "removed from the end",
+			tamper: func(lines []string) []string {
+				return lines[:2]
+			},
+			expected: "has 2 entries but its head records 3",
+		},
+		{
+			name: "rewritten without the key",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], `"failed"`, `"complete"`, 1)
+				return rehash(t, lines)
+			},
+			expected: "line 1 has been modified",
+		},
+		{
+			name: "reordered",
+			tamper: func(lines []string) []string {
+				return []string{lines[1], lines[0], lines[2]}
+			},
+			expected: "line 1 does not follow the previous entry",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run("detects entries that were "+c.name, func(t *testing.T) {
+			defer removeAuditLog(t, stateDir)
+
+			lines := write(t)
+			lines[len(lines)-1] += "\n"
+			testutils.MustWriteToFile(t, path, strings.Join(c.tamper(lines), ""))
+
+			err := audit.Verify(path)
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("got error %v want %q", err, c.expected)
+			}
+		})
+	}
+}
+
+func TestInvocation(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	stateDir := filepath.Join(dir, ".gpupgrade")
+	archiveDir := filepath.Join(dir, "archive")
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	t.Run("records the invocation with the outcome when the state directory is created by the command", func(t *testing.T) {
+		defer audit.Reset()
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		err := audit.RecordInvocation(audit.Entry{Command: "gpupgrade initialize", Args: []string{"initialize", "--file", "config"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := os.Mkdir(stateDir, 0700); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = audit.RecordOutcome(errors.New("permission denied"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result := entries(t, filepath.Join(stateDir, audit.FileName))
+		if len(result) != 2 {
+			t.Fatalf("got %d entries want 2", len(result))
+		}
+
+		if result[0].Event != audit.Invocation || result[0].Command != "gpupgrade initialize" {
+			t.Errorf("got entry %+v", result[0])
+		}
+
+		if result[1].Event != audit.Outcome || result[1].Status != "failed" || result[1].Error != "permission denied" {
+			t.Errorf("got entry %+v", result[1])
+		}
+	})
+
+	t.Run("does not record an outcome without an invocation", func(t *testing.T) {
+		defer audit.Reset()
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		if err := os.Mkdir(stateDir, 0700); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := audit.RecordOutcome(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := os.Stat(filepath.Join(stateDir, audit.FileName)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected no audit log, got %v", err)
+		}
+	})
+
+	t.Run("records later entries to the archived audit log", func(t *testing.T) {
+		defer audit.Reset()
+		defer testutils.MustRemoveAll(t, stateDir)
+		defer testutils.MustRemoveAll(t, archiveDir)
+
+		for _, d := range []string{stateDir, archiveDir} {
+			if err := os.Mkdir(d, 0700); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if err := audit.RecordInvocation(audit.Entry{Command: "gpupgrade finalize"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := audit.Archive(archiveDir); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		testutils.MustRemoveAll(t, stateDir)
+
+		if err := audit.RecordOutcome(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		archived := filepath.Join(archiveDir, audit.FileName)
+		if audit.Path() != archived {
+			t.Errorf("got path %q want %q", audit.Path(), archived)
+		}
+
+		result := entries(t, archived)
+		if len(result) != 2 || result[1].Event != audit.Outcome || result[1].Status != "complete" {
+			t.Errorf("got entries %+v", result)
+		}
+
+		if err := audit.Verify(archived); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestRedact(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "notification_webhooks", value: "https://hooks.example.com/T0/B0/secret", expected: "<redacted>"},
+		{name: "notification-command", value: "curl -H 'Authorization: token'", expected: "<redacted>"},
+		{name: "db_password", value: "changeme", expected: "<redacted>"},
+		{name: "source_gphome", value: "/usr/local/gpdb5", expected: "/usr/local/gpdb5"},
+		{name: "notification_webhooks", value: "", expected: ""},
+	}
+
+	for _, c := range cases {
+		if actual := audit.Redact(c.name, c.value); actual != c.expected {
+			t.Errorf("Redact(%q, %q) = %q want %q", c.name, c.value, actual, c.expected)
+		}
+	}
+}
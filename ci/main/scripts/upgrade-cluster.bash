@@ -31,7 +31,7 @@ time ssh -n cdw "
               --source-master-port $PGPORT \
               --mode $MODE \
               --temp-port-range 6020-6040 \
-              --disk-free-ratio 0
+              --skip-disk-space-check
 
     gpupgrade execute --non-interactive --skip-pg-upgrade-checks
     gpupgrade finalize --non-interactive
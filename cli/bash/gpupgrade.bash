@@ -721,10 +721,14 @@ _gpupgrade_initialize()
     two_word_flags+=("--agent-port")
     local_nonpersistent_flags+=("--agent-port")
     local_nonpersistent_flags+=("--agent-port=")
-    flags+=("--disk-free-ratio=")
-    two_word_flags+=("--disk-free-ratio")
-    local_nonpersistent_flags+=("--disk-free-ratio")
-    local_nonpersistent_flags+=("--disk-free-ratio=")
+    flags+=("--disk-abort-threshold=")
+    two_word_flags+=("--disk-abort-threshold")
+    local_nonpersistent_flags+=("--disk-abort-threshold")
+    local_nonpersistent_flags+=("--disk-abort-threshold=")
+    flags+=("--disk-warn-threshold=")
+    two_word_flags+=("--disk-warn-threshold")
+    local_nonpersistent_flags+=("--disk-warn-threshold")
+    local_nonpersistent_flags+=("--disk-warn-threshold=")
     flags+=("--dynamic-library-path=")
     two_word_flags+=("--dynamic-library-path")
     local_nonpersistent_flags+=("--dynamic-library-path")
@@ -753,6 +757,8 @@ _gpupgrade_initialize()
     local_nonpersistent_flags+=("--pg-upgrade-jobs=")
     flags+=("--pg-upgrade-verbose")
     local_nonpersistent_flags+=("--pg-upgrade-verbose")
+    flags+=("--skip-disk-space-check")
+    local_nonpersistent_flags+=("--skip-disk-space-check")
     flags+=("--source-gphome=")
     two_word_flags+=("--source-gphome")
     local_nonpersistent_flags+=("--source-gphome")
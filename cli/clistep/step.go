@@ -5,10 +5,14 @@ package clistep
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -16,6 +20,7 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/fault"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/substeps"
@@ -59,7 +64,7 @@ func NewStep(currentStep idl.Step, stepName string, stepStore StepStore, substep
 	}, nil
 }
 
-func Begin(currentStep idl.Step, verbose bool, nonInteractive bool, confirmationText string) (*Step, error) {
+func Begin(currentStep idl.Step, verbose bool, nonInteractive bool, answers *commanders.Answers, confirmationText string) (*Step, error) {
 	// NOTE: only use streams within the substeps since they do not write to
 	// stdout/stderr when verbose is false. Thus, for general output write to
 	// stdout as usual such that it appears when verbose is not set.
@@ -83,7 +88,7 @@ func Begin(currentStep idl.Step, verbose bool, nonInteractive bool, confirmation
 		fmt.Print(confirmationText)
 
 		prompt := fmt.Sprintf("Continue with gpupgrade %s?  Yy|Nn: ", currentStep)
-		err := Prompt(utils.StdinReader, prompt)
+		err := Confirm(answers, currentStep, utils.StdinReader, prompt)
 		if err != nil {
 			return &Step{}, err
 		}
@@ -112,17 +117,27 @@ func (s *Step) Err() error {
 	return s.err
 }
 
-func (s *Step) RunHubSubstep(f func(streams step.OutStreams) error) {
+// RunHubSubstep runs hub substeps with a context that is canceled when the
+// user interrupts gpupgrade. Canceling the context asks the hub to cancel the
+// running step which in turn stops the running substep on the hub and agents.
+func (s *Step) RunHubSubstep(f func(ctx context.Context, streams step.OutStreams) error) {
 	if s.err != nil {
 		return
 	}
 
-	err := f(s.streams)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := f(ctx, s.streams)
 	if err != nil {
 		if errors.Is(err, step.Skip) {
 			return
 		}
 
+		if ctx.Err() != nil {
+			err = xerrors.Errorf("%s interrupted: %w", s.stepName, err)
+		}
+
 		s.err = err
 	}
 }
@@ -202,7 +217,7 @@ func (s *Step) run(substep idl.Substep, f func(streams step.OutStreams) error, a
 		return
 	}
 
-	err = f(s.streams)
+	err = s.runWithFaults(substep, f)
 	if err != nil {
 		status := idl.Status_failed
 
@@ -229,6 +244,28 @@ func (s *Step) run(substep idl.Substep, f func(streams step.OutStreams) error, a
 	}
 }
 
+// runWithFaults runs f between the faults injected before and after the
+// substep as the hub does for its substeps.
+func (s *Step) runWithFaults(substep idl.Substep, f func(streams step.OutStreams) error) error {
+	if err := checkFault(fault.SubstepPoint(s.step, substep, fault.Before)); err != nil {
+		return err
+	}
+
+	if err := f(s.streams); err != nil {
+		return err
+	}
+
+	return checkFault(fault.SubstepPoint(s.step, substep, fault.After))
+}
+
+// checkFault blocks on a hung fault until the user interrupts gpupgrade.
+func checkFault(point fault.Point) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return fault.Check(ctx, point)
+}
+
 func (s *Step) DisableStore() {
 	s.stepStore = nil
 	s.substepStore = nil
@@ -320,6 +357,48 @@ func (s *Step) printDuration(operation string, duration string) error {
 	return err
 }
 
+// Confirm answers whether to continue with the step from the answers file, or
+// prompts when there is none.
+func Confirm(answers *commanders.Answers, currentStep idl.Step, reader *bufio.Reader, prompt string) error {
+	if answers == nil {
+		return Prompt(reader, prompt)
+	}
+
+	proceed, err := answers.ContinueStep(currentStep)
+	if err != nil {
+		return err
+	}
+
+	return answer(prompt, proceed)
+}
+
+// ConfirmAfterScripts prompts to continue with the step after applying its
+// data migration scripts. It is answered separately from Confirm in the
+// answers file.
+func ConfirmAfterScripts(answers *commanders.Answers, currentStep idl.Step, reader *bufio.Reader, prompt string) error {
+	if answers == nil {
+		return Prompt(reader, prompt)
+	}
+
+	proceed, err := answers.ContinueAfterScripts(currentStep)
+	if err != nil {
+		return err
+	}
+
+	return answer(prompt, proceed)
+}
+
+func answer(prompt string, proceed bool) error {
+	fmt.Println()
+	if !proceed {
+		fmt.Printf("%sn\n\nCanceling...", prompt)
+		return step.Quit
+	}
+
+	fmt.Printf("%sy\n\nProceeding with upgrade\n", prompt)
+	return nil
+}
+
 func Prompt(reader *bufio.Reader, prompt string) error {
 	fmt.Println()
 	for {
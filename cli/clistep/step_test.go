@@ -5,15 +5,21 @@ package clistep_test
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"syscall"
 	"testing"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/greenplum-db/gpupgrade/cli/clistep"
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/fault"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/substeps"
@@ -38,7 +44,7 @@ func TestSubstep(t *testing.T) {
 
 		d := BufferStandardDescriptors(t)
 
-		st, err := clistep.Begin(idl.Step_initialize, false, true, "")
+		st, err := clistep.Begin(idl.Step_initialize, false, true, nil, "")
 		if err != nil {
 			d.Close()
 			t.Errorf("unexpected err %#v", err)
@@ -87,7 +93,7 @@ func TestSubstep(t *testing.T) {
 		}
 
 		expected := step.Skip
-		st.RunHubSubstep(func(streams step.OutStreams) error {
+		st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
 			return expected
 		})
 
@@ -236,6 +242,36 @@ func TestSubstep(t *testing.T) {
 		}
 	})
 
+	t.Run("fails a CLI substep at an injected fault", func(t *testing.T) {
+		resetEnv := testutils.SetEnv(t, fault.EnvVar, "1")
+		defer resetEnv()
+
+		for _, when := range []fault.When{fault.Before, fault.After} {
+			point := fault.SubstepPoint(idl.Step_initialize, idl.Substep_start_hub, when)
+			fault.Inject(point, fault.Fail)
+
+			st, err := clistep.NewStep(idl.Step_initialize, idl.Step_initialize.String(), &MockStepStore{}, &MockSubstepStore{}, step.NewLogStdStreams(false), false)
+			if err != nil {
+				t.Errorf("unexpected err %#v", err)
+			}
+
+			called := false
+			st.Run(idl.Substep_start_hub, func(streams step.OutStreams) error {
+				called = true
+				return nil
+			})
+			fault.Reset()
+
+			if !errors.Is(st.Err(), fault.ErrInjected) {
+				t.Errorf("got error %#v want %#v", st.Err(), fault.ErrInjected)
+			}
+
+			if called != (when == fault.After) {
+				t.Errorf("substep called %t when failing %s it", called, when)
+			}
+		}
+	})
+
 	t.Run("substeps are not run when a hub substep errors", func(t *testing.T) {
 		st, err := clistep.NewStep(idl.Step_initialize, idl.Step_initialize.String(), &MockStepStore{}, &MockSubstepStore{}, step.NewLogStdStreams(false), false)
 		if err != nil {
@@ -243,7 +279,7 @@ func TestSubstep(t *testing.T) {
 		}
 
 		err = errors.New("error")
-		st.RunHubSubstep(func(streams step.OutStreams) error {
+		st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
 			return err
 		})
 
@@ -253,7 +289,7 @@ func TestSubstep(t *testing.T) {
 			return nil
 		})
 
-		st.RunHubSubstep(func(streams step.OutStreams) error {
+		st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
 			ran = true
 			return nil
 		})
@@ -272,6 +308,37 @@ func TestSubstep(t *testing.T) {
 		}
 	})
 
+	t.Run("hub substeps are canceled when the user interrupts gpupgrade", func(t *testing.T) {
+		st, err := clistep.NewStep(idl.Step_execute, "Execute", &MockStepStore{}, &MockSubstepStore{}, step.NewLogStdStreams(false), false)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
+			if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			<-ctx.Done()
+			return status.Error(codes.Canceled, context.Canceled.Error())
+		})
+
+		err = st.Complete("")
+		var nextActionsErr utils.NextActionErr
+		if !errors.As(err, &nextActionsErr) {
+			t.Fatalf("got %T, want %T", err, nextActionsErr)
+		}
+
+		if !strings.Contains(nextActionsErr.Err.Error(), "Execute interrupted") {
+			t.Errorf("expected error %q to contain %q", nextActionsErr.Err, "Execute interrupted")
+		}
+
+		expected := `run "gpupgrade execute" again`
+		if !strings.Contains(nextActionsErr.NextAction, expected) {
+			t.Errorf("expected next action %q to contain %q", nextActionsErr.NextAction, expected)
+		}
+	})
+
 	t.Run("cli substeps are printed to stdout and stderr in verbose mode", func(t *testing.T) {
 		d := BufferStandardDescriptors(t)
 
@@ -414,7 +481,7 @@ func TestSubstep(t *testing.T) {
 		})
 
 		ran := false
-		st.RunHubSubstep(func(streams step.OutStreams) error {
+		st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
 			ran = true
 			return nil
 		})
@@ -437,7 +504,7 @@ func TestSubstep(t *testing.T) {
 		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", "/does/not/exist")
 		defer resetEnv()
 
-		_, err := clistep.Begin(idl.Step_initialize, false, true, "")
+		_, err := clistep.Begin(idl.Step_initialize, false, true, nil, "")
 		var nextActionsErr utils.NextActionErr
 		if !errors.As(err, &nextActionsErr) {
 			t.Errorf("got %T, want %T", err, nextActionsErr)
@@ -455,7 +522,7 @@ func TestSubstep(t *testing.T) {
 		}
 
 		nextAction := "re-run gpupgrade"
-		st.RunHubSubstep(func(streams step.OutStreams) error {
+		st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
 			return utils.NewNextActionErr(errors.New("oops"), nextAction)
 		})
 
@@ -554,7 +621,7 @@ func TestStepStatus(t *testing.T) {
 	}
 
 	t.Run("when a step is created its status is set to running", func(t *testing.T) {
-		_, err := clistep.Begin(idl.Step_initialize, false, true, "")
+		_, err := clistep.Begin(idl.Step_initialize, false, true, nil, "")
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -571,7 +638,7 @@ func TestStepStatus(t *testing.T) {
 	})
 
 	t.Run("when the step store is disabled step.Complete does not update the status", func(t *testing.T) {
-		st, err := clistep.Begin(idl.Step_initialize, false, true, "")
+		st, err := clistep.Begin(idl.Step_initialize, false, true, nil, "")
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -595,12 +662,12 @@ func TestStepStatus(t *testing.T) {
 	})
 
 	t.Run("when a hub substep fails it sets the step status to failed", func(t *testing.T) {
-		st, err := clistep.Begin(idl.Step_initialize, false, true, "")
+		st, err := clistep.Begin(idl.Step_initialize, false, true, nil, "")
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
 
-		st.RunHubSubstep(func(streams step.OutStreams) error {
+		st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
 			return errors.New("oops")
 		})
 
@@ -622,7 +689,7 @@ func TestStepStatus(t *testing.T) {
 	})
 
 	t.Run("when a cli substep fails it sets the step status to failed", func(t *testing.T) {
-		st, err := clistep.Begin(idl.Step_initialize, false, true, "")
+		st, err := clistep.Begin(idl.Step_initialize, false, true, nil, "")
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -651,7 +718,7 @@ func TestStepStatus(t *testing.T) {
 	t.Run("confirmation text is not printed when a step is invalid", func(t *testing.T) {
 		d := BufferStandardDescriptors(t)
 
-		_, err := clistep.Begin(idl.Step_execute, false, true, "confirmation text")
+		_, err := clistep.Begin(idl.Step_execute, false, true, nil, "confirmation text")
 		var nextActionsErr utils.NextActionErr
 		if !errors.As(err, &nextActionsErr) {
 			d.Close()
@@ -675,7 +742,7 @@ func TestStepStatus(t *testing.T) {
 
 		d := BufferStandardDescriptors(t)
 
-		_, err = clistep.Begin(idl.Step_initialize, false, false, "confirmation text")
+		_, err = clistep.Begin(idl.Step_initialize, false, false, nil, "confirmation text")
 		if err != nil {
 			t.Errorf("NewStep returned error: %#v", err)
 		}
@@ -704,7 +771,7 @@ Initialize in progress.
 	t.Run("confirmation text is not printed in non-interactive mode", func(t *testing.T) {
 		d := BufferStandardDescriptors(t)
 
-		_, err := clistep.Begin(idl.Step_initialize, false, true, "confirmation text")
+		_, err := clistep.Begin(idl.Step_initialize, false, true, nil, "confirmation text")
 		if err != nil {
 			t.Errorf("NewStep returned error: %#v", err)
 		}
@@ -759,6 +826,60 @@ func TestPrompt(t *testing.T) {
 	})
 }
 
+func TestConfirm(t *testing.T) {
+	prompt := fmt.Sprintf("Continue with gpupgrade %s?  Yy|Nn: ", idl.Step_execute)
+
+	t.Run("prompts when there is no answers file", func(t *testing.T) {
+		reader := bufio.NewReader(strings.NewReader("n\n"))
+		err := clistep.Confirm(nil, idl.Step_execute, reader, prompt)
+		if !errors.Is(err, step.Quit) {
+			t.Errorf("got error %#v want %#v", err, step.Quit)
+		}
+	})
+
+	t.Run("uses the answers file without reading input", func(t *testing.T) {
+		answers := &commanders.Answers{Continue: map[string]bool{"execute": true, "revert": false}}
+
+		err := clistep.Confirm(answers, idl.Step_execute, nil, prompt)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		err = clistep.Confirm(answers, idl.Step_revert, nil, prompt)
+		if !errors.Is(err, step.Quit) {
+			t.Errorf("got error %#v want %#v", err, step.Quit)
+		}
+	})
+
+	t.Run("errors when the answers file does not answer the step", func(t *testing.T) {
+		err := clistep.Confirm(&commanders.Answers{}, idl.Step_finalize, nil, prompt)
+		if err == nil || errors.Is(err, step.Quit) {
+			t.Errorf("got error %#v want a missing answer error", err)
+		}
+	})
+	t.Run("answers the prompt after the data migration scripts separately", func(t *testing.T) {
+		answers := &commanders.Answers{
+			Continue:     map[string]bool{"initialize": true},
+			AfterScripts: map[string]bool{"initialize": false},
+		}
+
+		err := clistep.Confirm(answers, idl.Step_initialize, nil, prompt)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		err = clistep.ConfirmAfterScripts(answers, idl.Step_initialize, nil, prompt)
+		if !errors.Is(err, step.Quit) {
+			t.Errorf("got error %#v want %#v", err, step.Quit)
+		}
+
+		err = clistep.ConfirmAfterScripts(&commanders.Answers{Continue: map[string]bool{"initialize": true}}, idl.Step_initialize, nil, prompt)
+		if err == nil || !strings.Contains(err.Error(), "continue_after_scripts.initialize") {
+			t.Errorf("got error %#v want a missing answer error", err)
+		}
+	})
+}
+
 type MockStepStore struct {
 	Status   idl.Status
 	WriteErr error
@@ -0,0 +1,221 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// ConfirmationSteps are the steps which prompt to continue before starting.
+var ConfirmationSteps = []idl.Step{idl.Step_initialize, idl.Step_execute, idl.Step_finalize, idl.Step_revert}
+
+// ScriptConfirmationSteps are the steps which prompt again to continue after
+// applying their data migration scripts.
+var ScriptConfirmationSteps = []idl.Step{idl.Step_initialize}
+
+// Answers are the decisions for each prompt read from an answers file such that
+// gpupgrade runs unattended. The answers file is JSON only. For example:
+//
+//	{
+//	  "continue": {"initialize": true, "execute": true, "finalize": true},
+//	  "continue_after_scripts": {"initialize": true},
+//	  "archive_scripts": true,
+//	  "scripts": {
+//	    "stats": "all",
+//	    "initialize": ["parent_partitions_with_seg_entries", "gphdfs_user_roles"],
+//	    "finalize": "none"
+//	  },
+//	  "analyze_target_cluster": false
+//	}
+//
+// A prompt without an answer is an error rather than falling back to prompting
+// which would hang an unattended upgrade.
+type Answers struct {
+	Continue             map[string]bool            `json:"continue"`
+	AfterScripts         map[string]bool            `json:"continue_after_scripts"`
+	ArchiveScripts       *bool                      `json:"archive_scripts"`
+	Scripts              map[string]ScriptSelection `json:"scripts"`
+	AnalyzeTargetCluster *bool                      `json:"analyze_target_cluster"`
+
+	path string
+}
+
+// ScriptSelection is either "all", "none", or a list of script names.
+type ScriptSelection struct {
+	All   bool
+	Names []string
+}
+
+func (s *ScriptSelection) UnmarshalJSON(data []byte) error {
+	var keyword string
+	if err := json.Unmarshal(data, &keyword); err == nil {
+		switch keyword {
+		case "all":
+			*s = ScriptSelection{All: true}
+		case "none":
+			*s = ScriptSelection{}
+		default:
+			return xerrors.Errorf(`invalid script selection %q. Expected "all", "none", or a list of script names`, keyword)
+		}
+
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return xerrors.Errorf(`invalid script selection %s. Expected "all", "none", or a list of script names`, data)
+	}
+
+	*s = ScriptSelection{Names: names}
+	return nil
+}
+
+func LoadAnswers(path string) (*Answers, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return nil, xerrors.Errorf("answers file %q must be JSON. YAML is not supported.", path)
+	}
+
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		return nil, xerrors.Errorf("read answers file: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.DisallowUnknownFields()
+
+	answers := &Answers{path: path}
+	if err := decoder.Decode(answers); err != nil {
+		return nil, xerrors.Errorf("parse answers file %q: %w", path, err)
+	}
+
+	for name := range answers.Continue {
+		if !containsStep(ConfirmationSteps, name) {
+			return nil, xerrors.Errorf("invalid step %q in answers file %q. Expected one of %s.", name, path, ConfirmationSteps)
+		}
+	}
+
+	for name := range answers.AfterScripts {
+		if !containsStep(ScriptConfirmationSteps, name) {
+			return nil, xerrors.Errorf("invalid step %q in answers file %q. Expected one of %s.", name, path, ScriptConfirmationSteps)
+		}
+	}
+
+	for name := range answers.Scripts {
+		if !containsStep(MigrationScriptPhases, name) {
+			return nil, xerrors.Errorf("invalid phase %q in answers file %q. Expected one of %s.", name, path, MigrationScriptPhases)
+		}
+	}
+
+	return answers, nil
+}
+
+func containsStep(steps []idl.Step, name string) bool {
+	for _, step := range steps {
+		if step.String() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ContinueStep answers whether to continue with the step.
+func (a *Answers) ContinueStep(step idl.Step) (bool, error) {
+	answer, ok := a.Continue[step.String()]
+	if !ok {
+		return false, a.missing(fmt.Sprintf("continue.%s", step))
+	}
+
+	return answer, nil
+}
+
+// ContinueAfterScripts answers whether to continue with the step after
+// applying its data migration scripts.
+func (a *Answers) ContinueAfterScripts(step idl.Step) (bool, error) {
+	answer, ok := a.AfterScripts[step.String()]
+	if !ok {
+		return false, a.missing(fmt.Sprintf("continue_after_scripts.%s", step))
+	}
+
+	return answer, nil
+}
+
+// Archive answers whether to archive and re-generate previously generated
+// data migration scripts.
+func (a *Answers) Archive() (bool, error) {
+	if a.ArchiveScripts == nil {
+		return false, a.missing("archive_scripts")
+	}
+
+	return *a.ArchiveScripts, nil
+}
+
+// Analyze answers whether to create optimizer statistics on the target cluster
+// during finalize.
+func (a *Answers) Analyze() (bool, error) {
+	if a.AnalyzeTargetCluster == nil {
+		return false, a.missing("analyze_target_cluster")
+	}
+
+	return *a.AnalyzeTargetCluster, nil
+}
+
+// SelectScripts returns the scripts to apply for the phase. Every selected
+// script must be one of the generated scripts.
+func (a *Answers) SelectScripts(phase idl.Step, allScripts Scripts) (Scripts, error) {
+	selection, ok := a.Scripts[phase.String()]
+	if !ok {
+		return nil, a.missing(fmt.Sprintf("scripts.%s", phase))
+	}
+
+	if selection.All {
+		return allScripts, nil
+	}
+
+	var selected Scripts
+	var unknown []string
+	for _, name := range selection.Names {
+		script, ok := findScriptByName(allScripts, name)
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+
+		selected = append(selected, script)
+	}
+
+	if len(unknown) > 0 {
+		available := allScripts.Names()
+		sort.Strings(available)
+		return nil, xerrors.Errorf("answers file %q selects %q data migration scripts which were not generated: %s. Available scripts: %s.",
+			a.path, phase, strings.Join(unknown, ", "), strings.Join(available, ", "))
+	}
+
+	return selected, nil
+}
+
+func findScriptByName(scripts Scripts, name string) (Script, bool) {
+	for _, script := range scripts {
+		if script.Name == name {
+			return script, true
+		}
+	}
+
+	return Script{}, false
+}
+
+func (a *Answers) missing(key string) error {
+	return xerrors.Errorf("answers file %q is missing an answer for %q", a.path, key)
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestLoadAnswers(t *testing.T) {
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	path := filepath.Join(dir, "answers.json")
+
+	t.Run("loads the answer to each prompt", func(t *testing.T) {
+		testutils.MustWriteToFile(t, path, `{
+  "continue": {"initialize": true, "finalize": false},
+  "continue_after_scripts": {"initialize": false},
+  "archive_scripts": true,
+  "scripts": {
+    "stats": "all",
+    "initialize": ["gphdfs_user_roles", "partitioned_tables_indexes"],
+    "finalize": "none"
+  },
+  "analyze_target_cluster": false
+}`)
+
+		answers, err := commanders.LoadAnswers(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		proceed, err := answers.ContinueStep(idl.Step_initialize)
+		if err != nil || !proceed {
+			t.Errorf("got %t, %v want true", proceed, err)
+		}
+
+		proceed, err = answers.ContinueStep(idl.Step_finalize)
+		if err != nil || proceed {
+			t.Errorf("got %t, %v want false", proceed, err)
+		}
+
+		proceed, err = answers.ContinueAfterScripts(idl.Step_initialize)
+		if err != nil || proceed {
+			t.Errorf("got %t, %v want false", proceed, err)
+		}
+
+		archive, err := answers.Archive()
+		if err != nil || !archive {
+			t.Errorf("got %t, %v want true", archive, err)
+		}
+
+		analyze, err := answers.Analyze()
+		if err != nil || analyze {
+			t.Errorf("got %t, %v want false", analyze, err)
+		}
+
+		expected := map[string]commanders.ScriptSelection{
+			"stats":      {All: true},
+			"initialize": {Names: []string{"gphdfs_user_roles", "partitioned_tables_indexes"}},
+			"finalize":   {},
+		}
+		if !reflect.DeepEqual(answers.Scripts, expected) {
+			t.Errorf("got %v want %v", answers.Scripts, expected)
+		}
+	})
+
+	t.Run("errors when the answers file does not exist", func(t *testing.T) {
+		_, err := commanders.LoadAnswers(filepath.Join(dir, "does-not-exist.json"))
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got error %#v want %#v", err, os.ErrNotExist)
+		}
+	})
+
+	t.Run("errors on a YAML answers file", func(t *testing.T) {
+		yamlPath := filepath.Join(dir, "answers.yaml")
+		testutils.MustWriteToFile(t, yamlPath, "continue:\n  initialize: true\n")
+
+		_, err := commanders.LoadAnswers(yamlPath)
+		expected := "must be JSON"
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error %v to contain %q", err, expected)
+		}
+	})
+
+	errCases := []struct {
+		name     string
+		contents string
+		expected string
+	}{
+		{name: "unknown fields", contents: `{"continue_all": true}`, expected: `unknown field "continue_all"`},
+		{name: "an invalid script selection", contents: `{"scripts": {"initialize": "some"}}`, expected: `invalid script selection "some"`},
+		{name: "an unknown phase", contents: `{"scripts": {"execute": "all"}}`, expected: `invalid phase "execute"`},
+		{name: "an unknown step", contents: `{"continue": {"upgrade": true}}`, expected: `invalid step "upgrade"`},
+		{name: "a step without scripts to confirm", contents: `{"continue_after_scripts": {"execute": true}}`, expected: `invalid step "execute"`},
+	}
+
+	for _, c := range errCases {
+		t.Run("errors on "+c.name, func(t *testing.T) {
+			testutils.MustWriteToFile(t, path, c.contents)
+
+			_, err := commanders.LoadAnswers(path)
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("expected error %v to contain %q", err, c.expected)
+			}
+		})
+	}
+}
+
+func TestAnswers(t *testing.T) {
+	allScripts := commanders.Scripts{
+		{Num: 1, Name: "gphdfs_user_roles"},
+		{Num: 2, Name: "partitioned_tables_indexes"},
+	}
+
+	t.Run("selects all scripts", func(t *testing.T) {
+		answers := &commanders.Answers{Scripts: map[string]commanders.ScriptSelection{"stats": {All: true}}}
+
+		selected, err := answers.SelectScripts(idl.Step_stats, allScripts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(selected, allScripts) {
+			t.Errorf("got %v want %v", selected, allScripts)
+		}
+	})
+
+	t.Run("selects scripts by name", func(t *testing.T) {
+		answers := &commanders.Answers{Scripts: map[string]commanders.ScriptSelection{
+			"initialize": {Names: []string{"partitioned_tables_indexes"}},
+		}}
+
+		selected, err := answers.SelectScripts(idl.Step_initialize, allScripts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := commanders.Scripts{{Num: 2, Name: "partitioned_tables_indexes"}}
+		if !reflect.DeepEqual(selected, expected) {
+			t.Errorf("got %v want %v", selected, expected)
+		}
+	})
+
+	t.Run("errors when selecting scripts that were not generated", func(t *testing.T) {
+		answers := &commanders.Answers{Scripts: map[string]commanders.ScriptSelection{
+			"initialize": {Names: []string{"partitioned_tables_indexes", "tables_using_tsquery_type"}},
+		}}
+
+		_, err := answers.SelectScripts(idl.Step_initialize, allScripts)
+		expected := "not generated: tables_using_tsquery_type. Available scripts: gphdfs_user_roles, partitioned_tables_indexes."
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error %v to contain %q", err, expected)
+		}
+	})
+
+	t.Run("errors when a prompt has no answer rather than prompting", func(t *testing.T) {
+		answers := &commanders.Answers{}
+
+		_, err := answers.ContinueStep(idl.Step_execute)
+		if err == nil || !strings.Contains(err.Error(), `"continue.execute"`) {
+			t.Errorf("got error %v want a missing continue.execute answer", err)
+		}
+
+		_, err = answers.SelectScripts(idl.Step_revert, allScripts)
+		if err == nil || !strings.Contains(err.Error(), `"scripts.revert"`) {
+			t.Errorf("got error %v want a missing scripts.revert answer", err)
+		}
+
+		_, err = answers.Analyze()
+		if err == nil || !strings.Contains(err.Error(), `"analyze_target_cluster"`) {
+			t.Errorf("got error %v want a missing analyze_target_cluster answer", err)
+		}
+	})
+}
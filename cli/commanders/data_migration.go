@@ -4,14 +4,19 @@
 package commanders
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/utils"
 )
 
 var MigrationScriptPhases = []idl.Step{idl.Step_initialize, idl.Step_finalize, idl.Step_revert, idl.Step_stats}
@@ -26,11 +31,13 @@ func ResetPsqlCommand() {
 	psqlCommand = exec.Command
 }
 
-func executeSQLCommand(gphome string, port int, database string, sql string) ([]byte, error) {
-	cmd := psqlCommand(filepath.Join(gphome, "bin", "psql"), "--no-psqlrc", "--quiet",
+func executeSQLCommand(gphome string, port int, database string, sql string, args ...string) ([]byte, error) {
+	args = append([]string{"--no-psqlrc", "--quiet",
 		"-d", database,
 		"-p", strconv.Itoa(port),
-		"-c", sql)
+		"-c", sql}, args...)
+
+	cmd := psqlCommand(filepath.Join(gphome, "bin", "psql"), args...)
 	cmd.Env = []string{}
 
 	log.Printf("Executing: %q", cmd.String())
@@ -53,6 +60,12 @@ func ResetPsqlFileCommand() {
 }
 
 func ApplySQLFile(gphome string, port int, database string, path string, args ...string) ([]byte, error) {
+	return applySQL(gphome, port, database, path, nil, args...)
+}
+
+// applySQL executes the SQL file at path. When stdin is set the SQL is read
+// from stdin instead, and path should be "-".
+func applySQL(gphome string, port int, database string, path string, stdin io.Reader, args ...string) ([]byte, error) {
 	args = append(args,
 		"--no-psqlrc", "--quiet",
 		"-d", database,
@@ -61,6 +74,7 @@ func ApplySQLFile(gphome string, port int, database string, path string, args ..
 
 	cmd := psqlFileCommand(filepath.Join(gphome, "bin", "psql"), args...)
 	cmd.Env = []string{}
+	cmd.Stdin = stdin
 
 	log.Printf("Executing: %q", cmd.String())
 	output, err := cmd.CombinedOutput()
@@ -71,6 +85,108 @@ func ApplySQLFile(gphome string, port int, database string, path string, args ..
 	return output, nil
 }
 
+// TransactionMode controls whether a data migration script is wrapped in a
+// transaction when applied.
+type TransactionMode int
+
+const (
+	NonTransactional TransactionMode = iota
+	Transactional                    // commit each script as a single transaction
+	DryRun                           // always roll back each script's transaction
+)
+
+func (m TransactionMode) String() string {
+	switch m {
+	case Transactional:
+		return "transactional"
+	case DryRun:
+		return "dry-run"
+	default:
+		return "non-transactional"
+	}
+}
+
+// nonTransactionalPattern matches statements which cannot run inside a
+// transaction block, as well as reconnecting to another database which would
+// abandon the transaction.
+var nonTransactionalPattern = regexp.MustCompile(`(?im)^[ \t]*(` +
+	`(?:CREATE|DROP)\s+(?:UNIQUE\s+)?INDEX\s+CONCURRENTLY\b|` +
+	`REINDEX\b.*\bCONCURRENTLY\b|` +
+	`VACUUM\b|` +
+	`(?:CREATE|DROP)\s+(?:DATABASE|TABLESPACE)\b|` +
+	`ALTER\s+SYSTEM\b|` +
+	`ALTER\s+TYPE\b.*\bADD\s+VALUE\b|` +
+	`\\c(?:onnect)?\s` +
+	`).*$`)
+
+// SplitConnect returns the database a generated script connects to using its
+// leading \c meta-command along with the remainder of the script.
+func SplitConnect(contents []byte) (string, []byte) {
+	trimmed := bytes.TrimLeft(contents, " \t\r\n")
+	if !bytes.HasPrefix(trimmed, []byte(`\c `)) {
+		return "", contents
+	}
+
+	line, rest, _ := bytes.Cut(trimmed, []byte("\n"))
+	database := strings.TrimSpace(strings.TrimPrefix(string(line), `\c `))
+	return unquoteIdentifier(database), rest
+}
+
+// NonTransactionalStatement returns the first statement of a script that cannot
+// run inside a transaction, or an empty string if there is none. The leading
+// \c meta-command of generated scripts is allowed.
+func NonTransactionalStatement(contents []byte) string {
+	_, body := SplitConnect(contents)
+	return strings.TrimSpace(string(nonTransactionalPattern.Find(body)))
+}
+
+// NonTransactionalScriptError is returned when applying a script in a
+// transaction that cannot run in one.
+type NonTransactionalScriptError struct {
+	Path      string
+	Statement string
+}
+
+func (e *NonTransactionalScriptError) Error() string {
+	return fmt.Sprintf("script %q cannot run in a transaction due to %q", e.Path, e.Statement)
+}
+
+// ApplySQLFileInTransaction applies a generated script within a single
+// transaction which is committed, or rolled back for a dry run. Since a
+// generated script connects to its database using a leading \c meta-command,
+// the script is applied directly to that database.
+func ApplySQLFileInTransaction(gphome string, port int, database string, path string, mode TransactionMode, args ...string) ([]byte, error) {
+	if mode == NonTransactional {
+		return ApplySQLFile(gphome, port, database, path, args...)
+	}
+
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if statement := NonTransactionalStatement(contents); statement != "" {
+		return nil, &NonTransactionalScriptError{Path: path, Statement: statement}
+	}
+
+	connectDatabase, body := SplitConnect(contents)
+	if connectDatabase != "" {
+		database = connectDatabase
+	}
+
+	end := "COMMIT;\n"
+	if mode == DryRun {
+		end = "ROLLBACK;\n"
+	}
+
+	var script bytes.Buffer
+	script.WriteString("BEGIN;\n")
+	script.Write(body)
+	script.WriteString("\n" + end)
+
+	return applySQL(gphome, port, database, "-", &script, args...)
+}
+
 var bashCommand = exec.Command
 
 func SetBashCommand(command exectest.Command) {
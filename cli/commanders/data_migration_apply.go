@@ -26,7 +26,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-func ApplyDataMigrationScripts(streams step.OutStreams, nonInteractive bool, gphome string, port int, logDir string, currentScriptDirFS fs.FS, currentScriptDir string, phase idl.Step) error {
+func ApplyDataMigrationScripts(streams step.OutStreams, nonInteractive bool, answers *Answers, gphome string, port int, logDir string, currentScriptDirFS fs.FS, currentScriptDir string, phase idl.Step, mode TransactionMode) error {
 	_, err := currentScriptDirFS.Open(phase.String())
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
@@ -46,7 +46,7 @@ func ApplyDataMigrationScripts(streams step.OutStreams, nonInteractive bool, gph
 		return err
 	}
 
-	scriptDirsToRun, err := ApplyDataMigrationScriptsPrompt(nonInteractive, utils.StdinReader, currentScriptDir, currentScriptDirFS, phase)
+	scriptDirsToRun, err := ApplyDataMigrationScriptsPrompt(nonInteractive, answers, utils.StdinReader, currentScriptDir, currentScriptDirFS, phase)
 	if err != nil {
 		if errors.Is(err, step.Skip) {
 			return nil
@@ -55,6 +55,20 @@ func ApplyDataMigrationScripts(streams step.OutStreams, nonInteractive bool, gph
 		return err
 	}
 
+	if mode != NonTransactional {
+		nonTransactionalScripts, fErr := FindNonTransactionalScripts(scriptDirsToRun, mode)
+		if fErr != nil {
+			return fErr
+		}
+
+		if len(nonTransactionalScripts) > 0 {
+			_, err = fmt.Fprintf(streams.Stdout(), "\nThe following scripts cannot run in a transaction:\n%s", nonTransactionalScripts)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	outputPath := filepath.Join(logDir, "apply_"+phase.String()+".log")
 	file, err := utils.System.OpenFile(outputPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
@@ -72,7 +86,7 @@ func ApplyDataMigrationScripts(streams step.OutStreams, nonInteractive bool, gph
 	errChan := make(chan error, len(scriptDirsToRun))
 	outputChan := make(chan []byte, len(scriptDirsToRun))
 
-	_, err = fmt.Fprintf(streams.Stdout(), "\nApplying data migration scripts...\n")
+	_, err = fmt.Fprintf(streams.Stdout(), "\nApplying data migration scripts in %s mode...\n", mode)
 	if err != nil {
 		return err
 	}
@@ -94,7 +108,7 @@ func ApplyDataMigrationScripts(streams step.OutStreams, nonInteractive bool, gph
 		go func(gphome string, port int, scriptDir string, bar *mpb.Bar) {
 			defer wg.Done()
 
-			output, aErr := ApplyDataMigrationScriptSubDir(gphome, port, utils.System.DirFS(scriptDir), scriptDir, bar)
+			output, aErr := ApplyDataMigrationScriptSubDir(gphome, port, mode, utils.System.DirFS(scriptDir), scriptDir, bar)
 			if aErr != nil {
 				errChan <- aErr
 				bar.Abort(false)
@@ -128,6 +142,10 @@ func ApplyDataMigrationScripts(streams step.OutStreams, nonInteractive bool, gph
 		}
 	}
 
+	if mode == DryRun {
+		fmt.Printf("\nDry run complete. All changes made by the %q data migration scripts were rolled back.\n", phase)
+	}
+
 	if phase == idl.Step_stats {
 		fmt.Print(color.YellowString("\nTo receive an upgrade time estimate send the stats output:\n%s\n", utils.Bold.Sprint(filepath.Join(logDir, "apply_"+phase.String()+".log"))))
 	}
@@ -149,7 +167,7 @@ func countScripts(entries []fs.DirEntry) int {
 	return numScripts
 }
 
-func ApplyDataMigrationScriptSubDir(gphome string, port int, scriptDirFS fs.FS, scriptDir string, bar *mpb.Bar) ([]byte, error) {
+func ApplyDataMigrationScriptSubDir(gphome string, port int, mode TransactionMode, scriptDirFS fs.FS, scriptDir string, bar *mpb.Bar) ([]byte, error) {
 	entries, err := utils.System.ReadDirFS(scriptDirFS, ".")
 	if err != nil {
 		return nil, err
@@ -166,7 +184,28 @@ func ApplyDataMigrationScriptSubDir(gphome string, port int, scriptDirFS fs.FS,
 		}
 
 		log.Printf("  %s\n", entry.Name())
-		output, err := ApplySQLFile(gphome, port, "postgres", filepath.Join(scriptDir, entry.Name()), "-v", "ON_ERROR_STOP=1", "--echo-queries")
+		path := filepath.Join(scriptDir, entry.Name())
+
+		scriptMode := mode
+		if mode != NonTransactional {
+			contents, err := utils.System.ReadFileFS(scriptDirFS, entry.Name())
+			if err != nil {
+				return nil, err
+			}
+
+			if statement := NonTransactionalStatement(contents); statement != "" {
+				if mode == DryRun {
+					log.Printf("Skipping %q during dry run since it cannot run in a transaction due to %q", path, statement)
+					bar.Increment()
+					continue
+				}
+
+				log.Printf("Applying %q without a transaction due to %q", path, statement)
+				scriptMode = NonTransactional
+			}
+		}
+
+		output, err := ApplySQLFileInTransaction(gphome, port, "postgres", path, scriptMode, "-v", "ON_ERROR_STOP=1", "--echo-queries")
 		if err != nil {
 			return nil, err
 		}
@@ -179,22 +218,38 @@ func ApplyDataMigrationScriptSubDir(gphome string, port int, scriptDirFS fs.FS,
 	return outputs, nil
 }
 
-func ApplyDataMigrationScriptsPrompt(nonInteractive bool, reader *bufio.Reader, currentScriptDir string, currentScriptDirFS fs.FS, phase idl.Step) ([]string, error) {
-	entries, err := utils.System.ReadDirFS(currentScriptDirFS, phase.String())
+func ApplyDataMigrationScriptsPrompt(nonInteractive bool, answers *Answers, reader *bufio.Reader, currentScriptDir string, currentScriptDirFS fs.FS, phase idl.Step) ([]string, error) {
+	allScripts, err := GetScripts(currentScriptDirFS, phase)
 	if err != nil {
 		return nil, err
 	}
 
-	var allScripts Scripts
-	for i, script := range entries {
-		allScripts = append(allScripts, Script{Num: uint64(i), Name: script.Name()})
-	}
-
 	fmt.Println()
 	fmt.Println()
 	fmt.Printf(`Scripts to apply:
 %s`, allScripts.Description())
 
+	if answers != nil {
+		selected, err := answers.SelectScripts(phase, allScripts)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(selected) == 0 {
+			fmt.Printf("\nProceeding with 'none' of the %s data migration scripts.\n", phase)
+			return nil, step.Skip
+		}
+
+		fmt.Printf("\nApplying the %q data migration scripts:\n\n%s\n", phase, selected)
+
+		var scriptDirs []string
+		for _, name := range selected.Names() {
+			scriptDirs = append(scriptDirs, filepath.Join(currentScriptDir, phase.String(), name))
+		}
+
+		return scriptDirs, nil
+	}
+
 	for {
 		var input = "a"
 		if !nonInteractive {
@@ -267,16 +322,11 @@ Select: `, phase, warning)
 }
 
 func SelectDataMigrationScriptsPrompt(reader *bufio.Reader, currentScriptDir string, currentScriptDirFS fs.FS, phase idl.Step) ([]string, error) {
-	entries, err := utils.System.ReadDirFS(currentScriptDirFS, phase.String())
+	allScripts, err := GetScripts(currentScriptDirFS, phase)
 	if err != nil {
 		return nil, err
 	}
 
-	var allScripts Scripts
-	for i, script := range entries {
-		allScripts = append(allScripts, Script{Num: uint64(i), Name: script.Name()})
-	}
-
 	for {
 		fmt.Printf("\nSelect scripts to apply separated by commas such as 1, 3. Or [q]uit?\n\n%s\nSelect: ", allScripts)
 		input, err := reader.ReadString('\n')
@@ -350,3 +400,59 @@ func ParseSelection(input string, allScripts Scripts) (Scripts, error) {
 
 	return selectedScripts, nil
 }
+
+// NonTransactionalScript is a script which cannot be applied in a transaction.
+type NonTransactionalScript struct {
+	Path   string
+	Reason string
+}
+
+type NonTransactionalScripts []NonTransactionalScript
+
+func (scripts NonTransactionalScripts) String() string {
+	var output string
+	for _, script := range scripts {
+		output += fmt.Sprintf("  %s\n  - %s\n\n", script.Path, script.Reason)
+	}
+	return output
+}
+
+// FindNonTransactionalScripts returns the scripts in the script directories
+// which cannot run in a transaction. These are SQL scripts with statements such
+// as CREATE INDEX CONCURRENTLY, and bash scripts. The reason of each script
+// describes how it is handled in the given mode.
+func FindNonTransactionalScripts(scriptDirs []string, mode TransactionMode) (NonTransactionalScripts, error) {
+	action := "is applied without a transaction"
+	if mode == DryRun {
+		action = "is skipped during a dry run"
+	}
+
+	var scripts NonTransactionalScripts
+	for _, scriptDir := range scriptDirs {
+		scriptDirFS := utils.System.DirFS(scriptDir)
+		entries, err := utils.System.ReadDirFS(scriptDirFS, ".")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(scriptDir, entry.Name())
+
+			switch filepath.Ext(entry.Name()) {
+			case ".sh", ".bash":
+				scripts = append(scripts, NonTransactionalScript{Path: path, Reason: "bash scripts are not applied"})
+			case ".sql":
+				contents, err := utils.System.ReadFileFS(scriptDirFS, entry.Name())
+				if err != nil {
+					return nil, err
+				}
+
+				if statement := NonTransactionalStatement(contents); statement != "" {
+					scripts = append(scripts, NonTransactionalScript{Path: path, Reason: fmt.Sprintf("contains %q and %s", statement, action)})
+				}
+			}
+		}
+	}
+
+	return scripts, nil
+}
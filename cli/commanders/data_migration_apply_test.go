@@ -41,7 +41,7 @@ func TestApplyDataMigrationScripts(t *testing.T) {
 	}
 
 	t.Run("returns when there are no scripts to apply", func(t *testing.T) {
-		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, "", 0, logDir, currentDirFS, "", idl.Step_revert)
+		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, nil, "", 0, logDir, currentDirFS, "", idl.Step_revert, commanders.NonTransactional)
 		if err != nil {
 			t.Fatalf("unexpected error %#v", err)
 		}
@@ -66,7 +66,7 @@ func TestApplyDataMigrationScripts(t *testing.T) {
 		commanders.SetPsqlFileCommand(exectest.NewCommand(SuccessScript))
 		defer commanders.ResetPsqlFileCommand()
 
-		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, "", 0, logDir, currentDirFS, currentScriptDir, idl.Step_stats)
+		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, nil, "", 0, logDir, currentDirFS, currentScriptDir, idl.Step_stats, commanders.NonTransactional)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -90,7 +90,7 @@ func TestApplyDataMigrationScripts(t *testing.T) {
 		resetStdin := testutils.SetStdin(t, "n\n")
 		defer resetStdin()
 
-		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, "", 0, logDir, currentDirFS, currentScriptDir, idl.Step_stats)
+		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, nil, "", 0, logDir, currentDirFS, currentScriptDir, idl.Step_stats, commanders.NonTransactional)
 		if err != nil {
 			t.Fatalf("unexpected error %#v", err)
 		}
@@ -103,7 +103,7 @@ func TestApplyDataMigrationScripts(t *testing.T) {
 		}
 		defer utils.ResetSystemFunctions()
 
-		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, "", 0, logDir, currentDirFS, currentScriptDir, idl.Step_stats)
+		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, nil, "", 0, logDir, currentDirFS, currentScriptDir, idl.Step_stats, commanders.NonTransactional)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v want %#v", err, expected)
 		}
@@ -129,7 +129,7 @@ func TestApplyDataMigrationScripts(t *testing.T) {
 		resetStdin := testutils.SetStdin(t, "a\n")
 		defer resetStdin()
 
-		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, "", 0, logDir, currentDirFS, currentScriptDir, idl.Step_stats)
+		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, nil, "", 0, logDir, currentDirFS, currentScriptDir, idl.Step_stats, commanders.NonTransactional)
 		var exitError *exec.ExitError
 		if !errors.As(err, &exitError) {
 			t.Errorf("got %T, want %T", err, exitError)
@@ -153,7 +153,7 @@ func TestApplyDataMigrationScripts(t *testing.T) {
 		resetStdin := testutils.SetStdin(t, "a\n")
 		defer resetStdin()
 
-		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, "", 0, logDir, currentDirFS, currentScriptDir, idl.Step_stats)
+		err := commanders.ApplyDataMigrationScripts(step.DevNullStream, false, nil, "", 0, logDir, currentDirFS, currentScriptDir, idl.Step_stats, commanders.NonTransactional)
 		if !errors.Is(err, os.ErrPermission) {
 			t.Errorf("got error %#v want %#v", err, os.ErrPermission)
 		}
@@ -171,7 +171,7 @@ func TestApplyDataMigrationScriptSubDir(t *testing.T) {
 		}
 		defer utils.ResetSystemFunctions()
 
-		output, err := commanders.ApplyDataMigrationScriptSubDir("", 0, fstest.MapFS{}, scriptSubDir, bar)
+		output, err := commanders.ApplyDataMigrationScriptSubDir("", 0, commanders.NonTransactional, fstest.MapFS{}, scriptSubDir, bar)
 		if !errors.Is(err, os.ErrPermission) {
 			t.Errorf("got error %#v want %#v", err, os.ErrPermission)
 		}
@@ -182,7 +182,7 @@ func TestApplyDataMigrationScriptSubDir(t *testing.T) {
 	})
 
 	t.Run("errors when no directories are in the current script directory", func(t *testing.T) {
-		output, err := commanders.ApplyDataMigrationScriptSubDir("", 0, fstest.MapFS{}, scriptSubDir, bar)
+		output, err := commanders.ApplyDataMigrationScriptSubDir("", 0, commanders.NonTransactional, fstest.MapFS{}, scriptSubDir, bar)
 		expected := fmt.Sprintf("No SQL files found in %q.", scriptSubDir)
 		if !strings.Contains(err.Error(), expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -203,7 +203,7 @@ func TestApplyDataMigrationScriptSubDir(t *testing.T) {
 			"drop_postgres_indexes.bash":                                  {},
 		}
 
-		output, err := commanders.ApplyDataMigrationScriptSubDir("", 0, fsys, scriptSubDir, bar)
+		output, err := commanders.ApplyDataMigrationScriptSubDir("", 0, commanders.NonTransactional, fsys, scriptSubDir, bar)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -221,7 +221,7 @@ func TestApplyDataMigrationScriptSubDir(t *testing.T) {
 			"migration_postgres_gen_drop_constraint_2_primary_unique.sql": {},
 		}
 
-		output, err := commanders.ApplyDataMigrationScriptSubDir("", 0, fsys, scriptSubDir, bar)
+		output, err := commanders.ApplyDataMigrationScriptSubDir("", 0, commanders.NonTransactional, fsys, scriptSubDir, bar)
 		var exitError *exec.ExitError
 		if !errors.As(err, &exitError) {
 			t.Errorf("got %T, want %T", err, exitError)
@@ -231,6 +231,112 @@ func TestApplyDataMigrationScriptSubDir(t *testing.T) {
 			t.Error("expected nil output")
 		}
 	})
+
+	t.Run("skips scripts that cannot run in a transaction during a dry run", func(t *testing.T) {
+		var applied []string
+		commanders.SetPsqlFileCommand(exectest.NewCommandWithVerifier(Success, func(utility string, args ...string) {
+			applied = append(applied, args[len(args)-1])
+		}))
+		defer commanders.ResetPsqlFileCommand()
+
+		utils.System.ReadFile = func(filename string) ([]byte, error) {
+			return []byte("\\c postgres\nDROP INDEX public.idx;\n"), nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		fsys := fstest.MapFS{
+			"migration_postgres_drop_indexes.sql":     {Data: []byte("\\c postgres\nDROP INDEX public.idx;\n")},
+			"migration_postgres_recreate_indexes.sql": {Data: []byte("\\c postgres\nCREATE INDEX CONCURRENTLY idx ON public.t (a);\n")},
+		}
+
+		_, err := commanders.ApplyDataMigrationScriptSubDir("", 0, commanders.DryRun, fsys, scriptSubDir, bar)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		expected := []string{"-"}
+		if !reflect.DeepEqual(applied, expected) {
+			t.Errorf("got applied %q want %q", applied, expected)
+		}
+	})
+
+	t.Run("applies scripts that cannot run in a transaction without one in transactional mode", func(t *testing.T) {
+		var applied []string
+		commanders.SetPsqlFileCommand(exectest.NewCommandWithVerifier(Success, func(utility string, args ...string) {
+			applied = append(applied, args[len(args)-1])
+		}))
+		defer commanders.ResetPsqlFileCommand()
+
+		fsys := fstest.MapFS{
+			"migration_postgres_recreate_indexes.sql": {Data: []byte("\\c postgres\nCREATE INDEX CONCURRENTLY idx ON public.t (a);\n")},
+		}
+
+		_, err := commanders.ApplyDataMigrationScriptSubDir("", 0, commanders.Transactional, fsys, scriptSubDir, bar)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		expected := []string{filepath.Join(scriptSubDir, "migration_postgres_recreate_indexes.sql")}
+		if !reflect.DeepEqual(applied, expected) {
+			t.Errorf("got applied %q want %q", applied, expected)
+		}
+	})
+}
+
+func TestFindNonTransactionalScripts(t *testing.T) {
+	utils.System.DirFS = func(dir string) fs.FS {
+		return fstest.MapFS{
+			"migration_postgres_drop_indexes.sql":     {Data: []byte("\\c postgres\nDROP INDEX public.idx;\n")},
+			"migration_postgres_recreate_indexes.sql": {Data: []byte("\\c postgres\nCREATE INDEX CONCURRENTLY idx ON public.t (a);\n")},
+			"drop_postgres_indexes.bash":              {},
+		}
+	}
+	defer utils.ResetSystemFunctions()
+
+	cases := []struct {
+		mode     commanders.TransactionMode
+		expected commanders.NonTransactionalScripts
+	}{
+		{
+			mode: commanders.Transactional,
+			expected: commanders.NonTransactionalScripts{
+				{Path: "/scripts/drop_postgres_indexes.bash", Reason: "bash scripts are not applied"},
+				{Path: "/scripts/migration_postgres_recreate_indexes.sql", Reason: `contains "CREATE INDEX CONCURRENTLY idx ON public.t (a);" and is applied without a transaction`},
+			},
+		},
+		{
+			mode: commanders.DryRun,
+			expected: commanders.NonTransactionalScripts{
+				{Path: "/scripts/drop_postgres_indexes.bash", Reason: "bash scripts are not applied"},
+				{Path: "/scripts/migration_postgres_recreate_indexes.sql", Reason: `contains "CREATE INDEX CONCURRENTLY idx ON public.t (a);" and is skipped during a dry run`},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("finds bash scripts and sql scripts that cannot run in a transaction in mode %d", c.mode), func(t *testing.T) {
+			scripts, err := commanders.FindNonTransactionalScripts([]string{"/scripts"}, c.mode)
+			if err != nil {
+				t.Fatalf("unexpected err %#v", err)
+			}
+
+			if !reflect.DeepEqual(scripts, c.expected) {
+				t.Errorf("got %+v want %+v", scripts, c.expected)
+			}
+		})
+	}
+
+	t.Run("errors when failing to read a script directory", func(t *testing.T) {
+		utils.System.ReadDirFS = func(fsys fs.FS, name string) ([]fs.DirEntry, error) {
+			return nil, os.ErrPermission
+		}
+		defer utils.ResetSystemFunctions()
+
+		_, err := commanders.FindNonTransactionalScripts([]string{"/scripts"}, commanders.Transactional)
+		if !errors.Is(err, os.ErrPermission) {
+			t.Errorf("got error %#v want %#v", err, os.ErrPermission)
+		}
+	})
 }
 
 func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
@@ -249,7 +355,7 @@ func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
 
 	t.Run("errors when failing to read input", func(t *testing.T) {
 		reader := bufio.NewReader(strings.NewReader(""))
-		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, reader, currentScriptDir, fsys, phase)
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, nil, reader, currentScriptDir, fsys, phase)
 		expected := io.EOF
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -262,7 +368,7 @@ func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
 
 	t.Run("applies all scripts when user selects 'a'll", func(t *testing.T) {
 		reader := bufio.NewReader(strings.NewReader("a\n"))
-		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, reader, currentScriptDir, fsys, phase)
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, nil, reader, currentScriptDir, fsys, phase)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -275,7 +381,7 @@ func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
 
 	t.Run("errors when applies all scripts fails to read phase directory in current generated script directory", func(t *testing.T) {
 		reader := bufio.NewReader(strings.NewReader("a\n"))
-		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, reader, currentScriptDir, fsys, idl.Step_unknown_step)
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, nil, reader, currentScriptDir, fsys, idl.Step_unknown_step)
 		var expected *os.PathError
 		if !errors.As(err, &expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -289,7 +395,7 @@ func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
 	t.Run("does not prompt and applies all scripts when in non-interactive mode", func(t *testing.T) {
 		d := BufferStandardDescriptors(t)
 
-		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(true, nil, currentScriptDir, fsys, phase)
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(true, nil, nil, currentScriptDir, fsys, phase)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -318,7 +424,7 @@ func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
 		d := BufferStandardDescriptors(t)
 
 		reader := bufio.NewReader(strings.NewReader("s\nb\n"))
-		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, reader, currentScriptDir, fsys, phase)
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, nil, reader, currentScriptDir, fsys, phase)
 		if !errors.Is(err, io.EOF) {
 			t.Errorf("got error %#v, want %#v", err, io.EOF)
 		}
@@ -363,7 +469,7 @@ func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
 
 	t.Run("returns skip error when user selects 'n'one", func(t *testing.T) {
 		reader := bufio.NewReader(strings.NewReader("n\n"))
-		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, reader, currentScriptDir, fsys, phase)
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, nil, reader, currentScriptDir, fsys, phase)
 		expected := step.Skip
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -376,7 +482,7 @@ func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
 
 	t.Run("returns canceled error when user selects 'q'uit", func(t *testing.T) {
 		reader := bufio.NewReader(strings.NewReader("q\n"))
-		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, reader, currentScriptDir, fsys, phase)
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, nil, reader, currentScriptDir, fsys, phase)
 		expected := step.Quit
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -391,7 +497,7 @@ func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
 		d := BufferStandardDescriptors(t)
 
 		reader := bufio.NewReader(strings.NewReader("b\nq\n"))
-		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, reader, currentScriptDir, fsys, phase)
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, nil, reader, currentScriptDir, fsys, phase)
 		if !errors.Is(err, step.Quit) {
 			t.Errorf("got error %#v, want %#v", err, step.Quit)
 		}
@@ -445,7 +551,7 @@ func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
 		d := BufferStandardDescriptors(t)
 
 		reader := bufio.NewReader(strings.NewReader("q\n"))
-		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, reader, currentScriptDir, fsys, phase)
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, nil, reader, currentScriptDir, fsys, phase)
 		if !errors.Is(err, step.Quit) {
 			t.Errorf("got error %#v, want %#v", err, step.Quit)
 		}
@@ -499,7 +605,7 @@ func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
 		d := BufferStandardDescriptors(t)
 
 		reader := bufio.NewReader(strings.NewReader("q\n"))
-		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, reader, currentScriptDir, fsys, phase)
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, nil, reader, currentScriptDir, fsys, phase)
 		if !errors.Is(err, step.Quit) {
 			t.Errorf("got error %#v, want %#v", err, step.Quit)
 		}
@@ -532,6 +638,51 @@ func TestApplyDataMigrationScriptsPrompt(t *testing.T) {
 			t.Logf("expected: %#v", expected)
 		}
 	})
+
+	t.Run("applies the scripts selected in the answers file without prompting", func(t *testing.T) {
+		answers := &commanders.Answers{Scripts: map[string]commanders.ScriptSelection{
+			phase.String(): {Names: []string{"unique_primary_foreign_key_constraint"}},
+		}}
+
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, answers, nil, currentScriptDir, fsys, phase)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		expectedScriptDirs := []string{"/home/gpupgrade/data-migration/current/initialize/unique_primary_foreign_key_constraint"}
+		if !reflect.DeepEqual(actualScriptDirs, expectedScriptDirs) {
+			t.Errorf("got %s, want %s", actualScriptDirs, expectedScriptDirs)
+		}
+	})
+
+	t.Run("returns skip error when the answers file selects none of the scripts", func(t *testing.T) {
+		answers := &commanders.Answers{Scripts: map[string]commanders.ScriptSelection{phase.String(): {}}}
+
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, answers, nil, currentScriptDir, fsys, phase)
+		if !errors.Is(err, step.Skip) {
+			t.Errorf("got error %#v, want %#v", err, step.Skip)
+		}
+
+		if actualScriptDirs != nil {
+			t.Error("expected nil script directories")
+		}
+	})
+
+	t.Run("errors when the answers file selects scripts which were not generated", func(t *testing.T) {
+		answers := &commanders.Answers{Scripts: map[string]commanders.ScriptSelection{
+			phase.String(): {Names: []string{"gphdfs_user_roles"}},
+		}}
+
+		actualScriptDirs, err := commanders.ApplyDataMigrationScriptsPrompt(false, answers, nil, currentScriptDir, fsys, phase)
+		expected := "Available scripts: parent_partitions_with_seg_entries, unique_primary_foreign_key_constraint."
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error %v to contain %q", err, expected)
+		}
+
+		if actualScriptDirs != nil {
+			t.Error("expected nil script directories")
+		}
+	})
 }
 
 func TestSelectDataMigrationScriptsPrompt(t *testing.T) {
@@ -0,0 +1,142 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// ScriptDescriptionFile is an optional file in a custom seed script directory
+// describing what its scripts do. It is copied alongside the generated scripts
+// and shown when prompting which scripts to apply.
+const ScriptDescriptionFile = "description"
+
+// ValidateCustomSeedDirs ensures custom seed directories are laid out like the
+// built-in seed scripts. That is, they only contain phases the built-in seed
+// scripts have, and their script directories do not collide with those of the
+// built-in seed scripts or other custom seed directories.
+func ValidateCustomSeedDirs(seedDirFS fs.FS, customSeedDirs []string) error {
+	if len(customSeedDirs) == 0 {
+		return nil
+	}
+
+	phases := make(map[string]bool)
+	owners := make(map[string]string)
+	for _, phase := range MigrationScriptPhases {
+		scriptDirs, err := utils.System.ReadDirFS(seedDirFS, phase.String())
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return err
+		}
+
+		phases[phase.String()] = true
+		for _, scriptDir := range scriptDirs {
+			owners[filepath.Join(phase.String(), scriptDir.Name())] = "the built-in seed scripts"
+		}
+	}
+
+	var errs error
+	for _, customSeedDir := range customSeedDirs {
+		customSeedDirFS := utils.System.DirFS(customSeedDir)
+
+		phaseEntries, err := utils.System.ReadDirFS(customSeedDirFS, ".")
+		if err != nil {
+			return fmt.Errorf("reading custom seed directory %q: %w", customSeedDir, err)
+		}
+
+		for _, phaseEntry := range phaseEntries {
+			if !phaseEntry.IsDir() {
+				continue
+			}
+
+			if !phases[phaseEntry.Name()] {
+				errs = errorlist.Append(errs, fmt.Errorf("Custom seed directory %q contains phase %q which is not found in the built-in seed scripts. Expected one of %s.",
+					customSeedDir, phaseEntry.Name(), MigrationScriptPhases))
+				continue
+			}
+
+			scriptDirs, err := utils.System.ReadDirFS(customSeedDirFS, phaseEntry.Name())
+			if err != nil {
+				return err
+			}
+
+			for _, scriptDir := range scriptDirs {
+				if !scriptDir.IsDir() {
+					errs = errorlist.Append(errs, fmt.Errorf("Custom seed directory %q contains file %q. Expected only script directories under the %q phase.",
+						customSeedDir, scriptDir.Name(), phaseEntry.Name()))
+					continue
+				}
+
+				key := filepath.Join(phaseEntry.Name(), scriptDir.Name())
+				if owner, ok := owners[key]; ok {
+					errs = errorlist.Append(errs, fmt.Errorf("Custom seed directory %q contains %q script directory %q which collides with %s.",
+						customSeedDir, phaseEntry.Name(), scriptDir.Name(), owner))
+					continue
+				}
+
+				owners[key] = fmt.Sprintf("custom seed directory %q", customSeedDir)
+			}
+		}
+	}
+
+	return errs
+}
+
+// WriteCustomScriptDescriptions copies the description of each custom script
+// directory next to its generated scripts. Script directories for which no
+// scripts were generated are skipped.
+func WriteCustomScriptDescriptions(customSeedDirs []string, outputDir string) error {
+	for _, customSeedDir := range customSeedDirs {
+		customSeedDirFS := utils.System.DirFS(customSeedDir)
+
+		for _, phase := range MigrationScriptPhases {
+			scriptDirs, err := utils.System.ReadDirFS(customSeedDirFS, phase.String())
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+
+				return err
+			}
+
+			for _, scriptDir := range scriptDirs {
+				description, err := utils.System.ReadFileFS(customSeedDirFS, filepath.Join(phase.String(), scriptDir.Name(), ScriptDescriptionFile))
+				if err != nil {
+					if errors.Is(err, fs.ErrNotExist) {
+						continue
+					}
+
+					return err
+				}
+
+				outputPath := filepath.Join(outputDir, "current", phase.String(), scriptDir.Name())
+				exist, err := upgrade.PathExist(outputPath)
+				if err != nil {
+					return err
+				}
+
+				if !exist {
+					continue
+				}
+
+				err = utils.System.WriteFile(filepath.Join(outputPath, ScriptDescriptionFile), description, 0644)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
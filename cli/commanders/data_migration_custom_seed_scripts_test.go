@@ -0,0 +1,255 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func TestValidateCustomSeedDirs(t *testing.T) {
+	seedDirFS := fstest.MapFS{
+		idl.Step_initialize.String():                                            {Mode: os.ModeDir},
+		filepath.Join(idl.Step_initialize.String(), "gphdfs_user_roles"):        {Mode: os.ModeDir},
+		idl.Step_finalize.String():                                              {Mode: os.ModeDir},
+		filepath.Join(idl.Step_finalize.String(), "partitioned_tables_indexes"): {Mode: os.ModeDir},
+	}
+
+	t.Run("succeeds when there are no custom seed directories", func(t *testing.T) {
+		err := commanders.ValidateCustomSeedDirs(fstest.MapFS{}, nil)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("succeeds when custom script directories are unique", func(t *testing.T) {
+		utils.System.DirFS = func(dir string) fs.FS {
+			return fstest.MapFS{
+				"README":                     {},
+				idl.Step_initialize.String(): {Mode: os.ModeDir},
+				filepath.Join(idl.Step_initialize.String(), "drop_"+filepath.Base(dir)): {Mode: os.ModeDir},
+			}
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := commanders.ValidateCustomSeedDirs(seedDirFS, []string{"/custom/one", "/custom/two"})
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("errors when a custom script directory collides with the built-in seed scripts", func(t *testing.T) {
+		utils.System.DirFS = func(dir string) fs.FS {
+			return fstest.MapFS{
+				idl.Step_finalize.String(): {Mode: os.ModeDir},
+				filepath.Join(idl.Step_finalize.String(), "partitioned_tables_indexes"): {Mode: os.ModeDir},
+			}
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := commanders.ValidateCustomSeedDirs(seedDirFS, []string{"/custom"})
+		expected := `Custom seed directory "/custom" contains "finalize" script directory "partitioned_tables_indexes" which collides with the built-in seed scripts.`
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v, want %q", err, expected)
+		}
+	})
+
+	t.Run("errors when custom script directories collide with each other", func(t *testing.T) {
+		utils.System.DirFS = func(dir string) fs.FS {
+			return fstest.MapFS{
+				idl.Step_revert.String():                                  {Mode: os.ModeDir},
+				filepath.Join(idl.Step_revert.String(), "recreate_views"): {Mode: os.ModeDir},
+			}
+		}
+		defer utils.ResetSystemFunctions()
+
+		seedDirFS := fstest.MapFS{
+			idl.Step_revert.String(): {Mode: os.ModeDir},
+		}
+
+		err := commanders.ValidateCustomSeedDirs(seedDirFS, []string{"/custom/one", "/custom/two"})
+		expected := `which collides with custom seed directory "/custom/one"`
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("got error %v, want it to contain %q", err, expected)
+		}
+	})
+
+	t.Run("errors when a custom seed directory contains phases not in the built-in seed scripts", func(t *testing.T) {
+		utils.System.DirFS = func(dir string) fs.FS {
+			return fstest.MapFS{
+				idl.Step_execute.String():                               {Mode: os.ModeDir},
+				filepath.Join(idl.Step_execute.String(), "some_script"): {Mode: os.ModeDir},
+				idl.Step_stats.String():                                 {Mode: os.ModeDir},
+				filepath.Join(idl.Step_stats.String(), "some_stats"):    {Mode: os.ModeDir},
+			}
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := commanders.ValidateCustomSeedDirs(seedDirFS, []string{"/custom"})
+		var errs errorlist.Errors
+		if !errors.As(err, &errs) {
+			t.Fatalf("got error %#v, want type %T", err, errs)
+		}
+
+		if len(errs) != 2 {
+			t.Fatalf("got %d errors, want 2", len(errs))
+		}
+
+		for i, phase := range []idl.Step{idl.Step_execute, idl.Step_stats} {
+			expected := `contains phase "` + phase.String() + `" which is not found in the built-in seed scripts`
+			if !strings.Contains(errs[i].Error(), expected) {
+				t.Errorf("got error %q, want it to contain %q", errs[i], expected)
+			}
+		}
+	})
+
+	t.Run("errors when a custom phase contains files rather than script directories", func(t *testing.T) {
+		utils.System.DirFS = func(dir string) fs.FS {
+			return fstest.MapFS{
+				idl.Step_initialize.String():                              {Mode: os.ModeDir},
+				filepath.Join(idl.Step_initialize.String(), "script.sql"): {},
+			}
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := commanders.ValidateCustomSeedDirs(seedDirFS, []string{"/custom"})
+		expected := `Expected only script directories under the "initialize" phase.`
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("got error %v, want it to contain %q", err, expected)
+		}
+	})
+
+	t.Run("errors when failing to read a custom seed directory", func(t *testing.T) {
+		utils.System.DirFS = func(dir string) fs.FS {
+			return fstest.MapFS{}
+		}
+		utils.System.ReadDirFS = func(fsys fs.FS, name string) ([]fs.DirEntry, error) {
+			if name == "." {
+				return nil, os.ErrPermission
+			}
+
+			return fs.ReadDir(fsys, name)
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := commanders.ValidateCustomSeedDirs(seedDirFS, []string{"/custom"})
+		if !errors.Is(err, os.ErrPermission) {
+			t.Errorf("got error %#v, want %#v", err, os.ErrPermission)
+		}
+	})
+}
+
+func TestWriteCustomScriptDescriptions(t *testing.T) {
+	t.Run("writes descriptions only for script directories with generated scripts", func(t *testing.T) {
+		outputDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, outputDir)
+
+		testutils.MustCreateDir(t, filepath.Join(outputDir, "current", idl.Step_initialize.String(), "drop_views"))
+
+		utils.System.DirFS = func(dir string) fs.FS {
+			return fstest.MapFS{
+				filepath.Join(idl.Step_initialize.String(), "drop_views", commanders.ScriptDescriptionFile): {Data: []byte("Drops views\n")},
+				filepath.Join(idl.Step_initialize.String(), "drop_views", "gen_drop_views.sql"):             {},
+				filepath.Join(idl.Step_initialize.String(), "drop_rules", commanders.ScriptDescriptionFile): {Data: []byte("Drops rules\n")},
+				filepath.Join(idl.Step_finalize.String(), "recreate_views", "gen_recreate_views.sql"):       {},
+			}
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := commanders.WriteCustomScriptDescriptions([]string{"/custom"}, outputDir)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+
+		description := testutils.MustReadFile(t, filepath.Join(outputDir, "current", idl.Step_initialize.String(), "drop_views", commanders.ScriptDescriptionFile))
+		if description != "Drops views\n" {
+			t.Errorf("got description %q, want %q", description, "Drops views\n")
+		}
+
+		testutils.PathMustNotExist(t, filepath.Join(outputDir, "current", idl.Step_initialize.String(), "drop_rules"))
+		testutils.PathMustNotExist(t, filepath.Join(outputDir, "current", idl.Step_finalize.String(), "recreate_views", commanders.ScriptDescriptionFile))
+	})
+
+	t.Run("errors when failing to write the description", func(t *testing.T) {
+		outputDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, outputDir)
+
+		testutils.MustCreateDir(t, filepath.Join(outputDir, "current", idl.Step_initialize.String(), "drop_views"))
+
+		utils.System.DirFS = func(dir string) fs.FS {
+			return fstest.MapFS{
+				filepath.Join(idl.Step_initialize.String(), "drop_views", commanders.ScriptDescriptionFile): {Data: []byte("Drops views\n")},
+			}
+		}
+		utils.System.WriteFile = func(filename string, data []byte, perm os.FileMode) error {
+			return os.ErrPermission
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := commanders.WriteCustomScriptDescriptions([]string{"/custom"}, outputDir)
+		if !errors.Is(err, os.ErrPermission) {
+			t.Errorf("got error %#v, want %#v", err, os.ErrPermission)
+		}
+	})
+}
+
+func TestGetScripts(t *testing.T) {
+	phase := idl.Step_initialize
+
+	t.Run("uses the custom description when present", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			phase.String(): {Mode: os.ModeDir},
+			filepath.Join(phase.String(), "drop_views", commanders.ScriptDescriptionFile):    {Data: []byte("  Drops views\n")},
+			filepath.Join(phase.String(), "drop_views", "migration_postgres_drop_views.sql"): {},
+			filepath.Join(phase.String(), "gphdfs_user_roles"):                               {Mode: os.ModeDir},
+		}
+
+		scripts, err := commanders.GetScripts(fsys, phase)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := commanders.Scripts{
+			{Num: 0, Name: "drop_views", Description: "Drops views"},
+			{Num: 1, Name: "gphdfs_user_roles"},
+		}
+		if !reflect.DeepEqual(scripts, expected) {
+			t.Errorf("got %v, want %v", scripts, expected)
+		}
+
+		description := scripts.Description()
+		expectedDescription := "  drop_views\n  - Drops views\n\n  gphdfs_user_roles\n  - Alters gphdfs user role to not create external tables\n\n"
+		if description != expectedDescription {
+			t.Errorf("got description %q, want %q", description, expectedDescription)
+		}
+	})
+
+	t.Run("errors when failing to read the description", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			filepath.Join(phase.String(), "drop_views"): {Mode: os.ModeDir},
+		}
+
+		utils.System.ReadFileFS = func(fsys fs.FS, name string) ([]byte, error) {
+			return nil, os.ErrPermission
+		}
+		defer utils.ResetSystemFunctions()
+
+		_, err := commanders.GetScripts(fsys, phase)
+		if !errors.Is(err, os.ErrPermission) {
+			t.Errorf("got error %#v, want %#v", err, os.ErrPermission)
+		}
+	})
+}
@@ -30,7 +30,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-func GenerateDataMigrationScripts(streams step.OutStreams, nonInteractive bool, gphome string, port int, seedDir string, outputDir string, outputDirFS fs.FS) error {
+func GenerateDataMigrationScripts(streams step.OutStreams, nonInteractive bool, answers *Answers, gphome string, port int, seedDir string, customSeedDirs []string, outputDir string, outputDirFS fs.FS) error {
 	version, err := greenplum.Version(gphome)
 	if err != nil {
 		return err
@@ -48,6 +48,11 @@ func GenerateDataMigrationScripts(streams step.OutStreams, nonInteractive bool,
 		return fmt.Errorf("failed to find seed scripts for Greenplum version %s under %q", version, seedDir)
 	}
 
+	err = ValidateCustomSeedDirs(utils.System.DirFS(seedDir), customSeedDirs)
+	if err != nil {
+		return err
+	}
+
 	db, err := bootstrapConnectionFunc(idl.ClusterDestination_source, gphome, port)
 	if err != nil {
 		return err
@@ -63,7 +68,7 @@ func GenerateDataMigrationScripts(streams step.OutStreams, nonInteractive bool,
 		return err
 	}
 
-	err = ArchiveDataMigrationScriptsPrompt(streams, nonInteractive, utils.StdinReader, outputDirFS, outputDir)
+	err = ArchiveDataMigrationScriptsPrompt(streams, nonInteractive, answers, utils.StdinReader, outputDirFS, outputDir)
 	if err != nil {
 		if errors.Is(err, step.Skip) {
 			return nil
@@ -72,7 +77,12 @@ func GenerateDataMigrationScripts(streams step.OutStreams, nonInteractive bool,
 		return err
 	}
 
-	databases, err := GetDatabases(db, utils.System.DirFS(seedDir))
+	var customSeedDirFSs []fs.FS
+	for _, customSeedDir := range customSeedDirs {
+		customSeedDirFSs = append(customSeedDirFSs, utils.System.DirFS(customSeedDir))
+	}
+
+	databases, err := GetDatabases(db, utils.System.DirFS(seedDir), customSeedDirFSs...)
 	if err != nil {
 		return err
 	}
@@ -93,17 +103,17 @@ func GenerateDataMigrationScripts(streams step.OutStreams, nonInteractive bool,
 			mpb.PrependDecorators(decor.Name("  "+database.Datname, decor.WCSyncSpaceR)),
 			mpb.AppendDecorators(decor.NewPercentage("%d")))
 
-		go func(streams step.OutStreams, database DatabaseInfo, gphome string, port int, seedDir string, outputDir string, bar *mpb.Bar) {
+		go func(streams step.OutStreams, database DatabaseInfo, gphome string, port int, seedDir string, customSeedDirs []string, outputDir string, bar *mpb.Bar) {
 			defer wg.Done()
 
-			err = GenerateScriptsPerDatabase(streams, database, gphome, port, seedDir, outputDir, bar)
-			if err != nil {
-				errChan <- err
+			gErr := GenerateScriptsPerDatabase(streams, database, gphome, port, seedDir, customSeedDirs, outputDir, bar)
+			if gErr != nil {
+				errChan <- gErr
 				bar.Abort(false)
 				return
 			}
 
-		}(streams, database, gphome, port, seedDir, outputDir, bar)
+		}(streams, database, gphome, port, seedDir, customSeedDirs, outputDir, bar)
 	}
 
 	progressBar.Wait()
@@ -119,12 +129,33 @@ func GenerateDataMigrationScripts(streams step.OutStreams, nonInteractive bool,
 		return errs
 	}
 
+	err = WriteCustomScriptDescriptions(customSeedDirs, outputDir)
+	if err != nil {
+		return err
+	}
+
+	currentDir := filepath.Join(outputDir, "current")
+	report, err := ParseImpact(utils.System.DirFS(currentDir))
+	if err != nil {
+		return err
+	}
+
+	err = report.EstimateWork(gphome, port)
+	if err != nil {
+		return err
+	}
+
+	err = WriteImpactReport(report, currentDir)
+	if err != nil {
+		return err
+	}
+
 	logDir, err := utils.GetLogDir()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("\nGenerated scripts:%s\nLogs: %s\n\n", utils.Bold.Sprint(filepath.Join(outputDir, "current")), utils.Bold.Sprint(logDir))
+	fmt.Printf("\nGenerated scripts:%s\nImpact report: %s\nLogs: %s\n\n", utils.Bold.Sprint(currentDir), utils.Bold.Sprint(filepath.Join(currentDir, ImpactReportName+".md")), utils.Bold.Sprint(logDir))
 
 	return nil
 }
@@ -141,7 +172,7 @@ func ResetBootstrapConnectionFunction() {
 	bootstrapConnectionFunc = connection.Bootstrap
 }
 
-func ArchiveDataMigrationScriptsPrompt(streams step.OutStreams, nonInteractive bool, reader *bufio.Reader, outputDirFS fs.FS, outputDir string) error {
+func ArchiveDataMigrationScriptsPrompt(streams step.OutStreams, nonInteractive bool, answers *Answers, reader *bufio.Reader, outputDirFS fs.FS, outputDir string) error {
 	outputDirEntries, err := utils.System.ReadDirFS(outputDirFS, ".")
 	if err != nil {
 		return err
@@ -184,7 +215,16 @@ scripts are outdated. The generator will need to be re-run
 to detect the newly added objects.`, currentDirModTime.Format(time.RFC1123Z), utils.Bold.Sprint(currentDir))
 
 		input := "a"
-		if !nonInteractive {
+		if answers != nil {
+			archive, aErr := answers.Archive()
+			if aErr != nil {
+				return aErr
+			}
+
+			if !archive {
+				input = "c"
+			}
+		} else if !nonInteractive {
 			fmt.Println()
 			fmt.Printf(`
   [a]rchive and re-generate scripts
@@ -238,7 +278,7 @@ Select: `)
 	}
 }
 
-func GenerateScriptsPerDatabase(streams step.OutStreams, database DatabaseInfo, gphome string, port int, seedDir string, outputDir string, bar *mpb.Bar) error {
+func GenerateScriptsPerDatabase(streams step.OutStreams, database DatabaseInfo, gphome string, port int, seedDir string, customSeedDirs []string, outputDir string, bar *mpb.Bar) error {
 	output, err := executeSQLCommand(gphome, port, database.Datname, `CREATE LANGUAGE plpythonu;`)
 	if err != nil && !strings.Contains(err.Error(), "already exists") {
 		return err
@@ -273,15 +313,35 @@ func GenerateScriptsPerDatabase(streams step.OutStreams, database DatabaseInfo,
 			return fErr
 		}
 
-		go func(phase idl.Step, database DatabaseInfo, gphome string, port int, seedDir string, outputDir string, bar *mpb.Bar) {
+		go func(phase idl.Step, database DatabaseInfo, gphome string, port int, seedDir string, customSeedDirs []string, outputDir string, bar *mpb.Bar) {
 			defer wg.Done()
 
-			err = GenerateScriptsPerPhase(phase, database, gphome, port, seedDir, utils.System.DirFS(seedDir), outputDir, bar)
-			if err != nil {
-				errChan <- err
+			gErr := GenerateScriptsPerPhase(phase, database, gphome, port, seedDir, utils.System.DirFS(seedDir), outputDir, bar)
+			if gErr != nil {
+				errChan <- gErr
 				return
 			}
-		}(phase, database, gphome, port, seedDir, outputDir, bar)
+
+			// Custom seed directories need not provide scripts for every phase.
+			for _, customSeedDir := range customSeedDirs {
+				customSeedDirFS := utils.System.DirFS(customSeedDir)
+				exist, pErr := upgrade.PathExistInFS(customSeedDirFS, phase.String())
+				if pErr != nil {
+					errChan <- pErr
+					return
+				}
+
+				if !exist {
+					continue
+				}
+
+				gErr = GenerateScriptsPerPhase(phase, database, gphome, port, customSeedDir, customSeedDirFS, outputDir, bar)
+				if gErr != nil {
+					errChan <- gErr
+					return
+				}
+			}
+		}(phase, database, gphome, port, seedDir, customSeedDirs, outputDir, bar)
 	}
 
 	wg.Wait()
@@ -327,7 +387,7 @@ func GenerateScriptsPerPhase(phase idl.Step, database DatabaseInfo, gphome strin
 		}
 
 		for _, script := range scripts {
-			if isGlobalScript(script.Name(), database.Datname) {
+			if script.Name() == ScriptDescriptionFile || isGlobalScript(script.Name(), database.Datname) {
 				continue
 			}
 
@@ -389,7 +449,7 @@ type DatabaseInfo struct {
 	NumSeedScripts int
 }
 
-func GetDatabases(db *sql.DB, seedDirFS fs.FS) ([]DatabaseInfo, error) {
+func GetDatabases(db *sql.DB, seedDirFS fs.FS, customSeedDirFSs ...fs.FS) ([]DatabaseInfo, error) {
 	rows, err := db.Query(`SELECT datname, quote_ident(datname) AS quoted_datname FROM pg_database WHERE datname != 'template0';`)
 	if err != nil {
 		return nil, err
@@ -404,12 +464,14 @@ func GetDatabases(db *sql.DB, seedDirFS fs.FS) ([]DatabaseInfo, error) {
 			return nil, xerrors.Errorf("pg_database: %w", err)
 		}
 
-		numSeedScripts, cErr := countSeedScripts(database.Datname, seedDirFS)
-		if cErr != nil {
-			return nil, cErr
-		}
+		for _, fsys := range append([]fs.FS{seedDirFS}, customSeedDirFSs...) {
+			numSeedScripts, cErr := countSeedScripts(database.Datname, fsys)
+			if cErr != nil {
+				return nil, cErr
+			}
 
-		database.NumSeedScripts = numSeedScripts
+			database.NumSeedScripts += numSeedScripts
+		}
 
 		databases = append(databases, database)
 	}
@@ -447,7 +509,7 @@ func countSeedScripts(database string, seedDirFS fs.FS) (int, error) {
 			}
 
 			for _, seedScript := range seedScripts {
-				if isGlobalScript(seedScript.Name(), database) {
+				if seedScript.Name() == ScriptDescriptionFile || isGlobalScript(seedScript.Name(), database) {
 					continue
 				}
 
@@ -53,7 +53,7 @@ func TestGenerateDataMigrationScripts(t *testing.T) {
 		}
 		defer utils.ResetSystemFunctions()
 
-		err := commanders.GenerateDataMigrationScripts(step.DevNullStream, false, "", 0, "", "", fstest.MapFS{})
+		err := commanders.GenerateDataMigrationScripts(step.DevNullStream, false, nil, "", 0, "", nil, "", fstest.MapFS{})
 		if !errors.Is(err, os.ErrPermission) {
 			t.Errorf("got error %#v want %#v", err, os.ErrPermission)
 		}
@@ -70,7 +70,7 @@ func TestGenerateDataMigrationScripts(t *testing.T) {
 
 		outputDirFS := fstest.MapFS{"current": {Mode: os.ModeDir}}
 
-		err := commanders.GenerateDataMigrationScripts(step.DevNullStream, false, "", 0, "", "", outputDirFS)
+		err := commanders.GenerateDataMigrationScripts(step.DevNullStream, false, nil, "", 0, "", nil, "", outputDirFS)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -88,7 +88,7 @@ func TestGenerateDataMigrationScripts(t *testing.T) {
 		}
 		defer utils.ResetSystemFunctions()
 
-		err := commanders.GenerateDataMigrationScripts(step.DevNullStream, false, "", 0, "", "", fstest.MapFS{})
+		err := commanders.GenerateDataMigrationScripts(step.DevNullStream, false, nil, "", 0, "", nil, "", fstest.MapFS{})
 		if !errors.Is(err, expected) {
 			t.Errorf("got %v want %v", err, expected)
 		}
@@ -100,7 +100,7 @@ func TestGenerateDataMigrationScripts(t *testing.T) {
 		}
 		defer utils.ResetSystemFunctions()
 
-		err := commanders.GenerateDataMigrationScripts(step.DevNullStream, false, "", 0, "", "", fstest.MapFS{})
+		err := commanders.GenerateDataMigrationScripts(step.DevNullStream, false, nil, "", 0, "", nil, "", fstest.MapFS{})
 		expected := "invalid port"
 		if !strings.Contains(err.Error(), expected) {
 			t.Errorf("got %+v, want %+v", err, expected)
@@ -120,14 +120,14 @@ func TestArchiveDataMigrationScriptsPrompt(t *testing.T) {
 		}
 		defer utils.ResetSystemFunctions()
 
-		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, nil, fsys, "")
+		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, nil, nil, fsys, "")
 		if !errors.Is(err, expected) {
 			t.Errorf("got %v want %v", err, expected)
 		}
 	})
 
 	t.Run("returns if scripts are 'not' already generated and there is nothing to archive", func(t *testing.T) {
-		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, nil, fstest.MapFS{}, "")
+		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, nil, nil, fstest.MapFS{}, "")
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -135,7 +135,7 @@ func TestArchiveDataMigrationScriptsPrompt(t *testing.T) {
 
 	t.Run("errors when failing to read input", func(t *testing.T) {
 		reader := bufio.NewReader(strings.NewReader(""))
-		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, reader, fsys, "")
+		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, nil, reader, fsys, "")
 		expected := io.EOF
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -149,7 +149,7 @@ func TestArchiveDataMigrationScriptsPrompt(t *testing.T) {
 		testutils.MustCreateDir(t, filepath.Join(outputDir, "current"))
 
 		reader := bufio.NewReader(strings.NewReader("a\n"))
-		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, reader, fsys, outputDir)
+		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, nil, reader, fsys, outputDir)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -166,7 +166,7 @@ func TestArchiveDataMigrationScriptsPrompt(t *testing.T) {
 		defer utils.ResetSystemFunctions()
 
 		reader := bufio.NewReader(strings.NewReader("a\n"))
-		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, reader, fsys, "")
+		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, nil, reader, fsys, "")
 		if !errors.Is(err, os.ErrPermission) {
 			t.Errorf("got error %#v want %#v", err, os.ErrPermission)
 		}
@@ -179,7 +179,7 @@ func TestArchiveDataMigrationScriptsPrompt(t *testing.T) {
 		defer utils.ResetSystemFunctions()
 
 		reader := bufio.NewReader(strings.NewReader("a\n"))
-		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, reader, fsys, "")
+		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, nil, reader, fsys, "")
 		var exitError *exec.ExitError
 		if !errors.As(err, &exitError) {
 			t.Errorf("got %T, want %T", err, exitError)
@@ -188,7 +188,7 @@ func TestArchiveDataMigrationScriptsPrompt(t *testing.T) {
 
 	t.Run("returns skip error when user selects 'c'ontinue", func(t *testing.T) {
 		reader := bufio.NewReader(strings.NewReader("c\n"))
-		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, reader, fsys, "")
+		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, nil, reader, fsys, "")
 		expected := step.Skip
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -197,7 +197,7 @@ func TestArchiveDataMigrationScriptsPrompt(t *testing.T) {
 
 	t.Run("returns canceled error when user selects 'q'uit", func(t *testing.T) {
 		reader := bufio.NewReader(strings.NewReader("q\n"))
-		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, reader, fsys, "")
+		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, nil, reader, fsys, "")
 		expected := step.Quit
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -208,7 +208,7 @@ func TestArchiveDataMigrationScriptsPrompt(t *testing.T) {
 		d := BufferStandardDescriptors(t)
 
 		reader := bufio.NewReader(strings.NewReader("b\nq\n"))
-		err := commanders.ArchiveDataMigrationScriptsPrompt(step.StdStreams, false, reader, fsys, "")
+		err := commanders.ArchiveDataMigrationScriptsPrompt(step.StdStreams, false, nil, reader, fsys, "")
 		if !errors.Is(err, step.Quit) {
 			t.Errorf("got error %#v, want %#v", err, step.Quit)
 		}
@@ -232,6 +232,24 @@ func TestArchiveDataMigrationScriptsPrompt(t *testing.T) {
 			t.Errorf("got %d matches, want 2", matches)
 		}
 	})
+
+	t.Run("answers whether to archive from the answers file", func(t *testing.T) {
+		archive := false
+		answers := &commanders.Answers{ArchiveScripts: &archive}
+
+		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, answers, nil, fsys, "")
+		if !errors.Is(err, step.Skip) {
+			t.Errorf("got error %#v, want %#v", err, step.Skip)
+		}
+	})
+
+	t.Run("errors when the answers file does not answer whether to archive", func(t *testing.T) {
+		err := commanders.ArchiveDataMigrationScriptsPrompt(step.DevNullStream, false, &commanders.Answers{}, nil, fsys, "")
+		expected := `missing an answer for "archive_scripts"`
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error %v to contain %q", err, expected)
+		}
+	})
 }
 
 func TestGenerateScriptsPerDatabase(t *testing.T) {
@@ -307,7 +325,7 @@ func TestGenerateScriptsPerDatabase(t *testing.T) {
 		}
 		defer utils.ResetSystemFunctions()
 
-		err = commanders.GenerateDataMigrationScripts(step.DevNullStream, true, "/usr/local/gpdb5", 0, "", outputDir, fstest.MapFS{})
+		err = commanders.GenerateDataMigrationScripts(step.DevNullStream, true, nil, "/usr/local/gpdb5", 0, "", nil, outputDir, fstest.MapFS{})
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -340,7 +358,7 @@ func TestGenerateScriptsPerDatabase(t *testing.T) {
 		commanders.SetPsqlCommand(exectest.NewCommand(FailedMain))
 		defer commanders.ResetPsqlCommand()
 
-		err = commanders.GenerateDataMigrationScripts(step.DevNullStream, false, "", 0, "", "", fstest.MapFS{})
+		err = commanders.GenerateDataMigrationScripts(step.DevNullStream, false, nil, "", 0, "", nil, "", fstest.MapFS{})
 		var exitError *exec.ExitError
 		if !errors.As(err, &exitError) {
 			t.Errorf("got %T, want %T", err, exitError)
@@ -377,7 +395,7 @@ func TestGenerateScriptsPerDatabase(t *testing.T) {
 		commanders.SetPsqlFileCommand(exectest.NewCommand(FailedMain))
 		defer commanders.ResetPsqlFileCommand()
 
-		err = commanders.GenerateDataMigrationScripts(step.DevNullStream, false, "", 0, "", "", fstest.MapFS{})
+		err = commanders.GenerateDataMigrationScripts(step.DevNullStream, false, nil, "", 0, "", nil, "", fstest.MapFS{})
 		var exitError *exec.ExitError
 		if !errors.As(err, &exitError) {
 			t.Errorf("got %T, want %T", err, exitError)
@@ -414,7 +432,7 @@ func TestGenerateScriptsPerDatabase(t *testing.T) {
 		commanders.SetPsqlFileCommand(exectest.NewCommand(Success))
 		defer commanders.ResetPsqlFileCommand()
 
-		err = commanders.GenerateDataMigrationScripts(step.DevNullStream, false, "", 0, "", "", fstest.MapFS{})
+		err = commanders.GenerateDataMigrationScripts(step.DevNullStream, false, nil, "", 0, "", nil, "", fstest.MapFS{})
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("got error %#v, want type %T", err, errs)
@@ -508,6 +526,45 @@ func TestGenerateScriptsPerPhase(t *testing.T) {
 		}
 	})
 
+	t.Run("does not generate scripts for or count the description of custom seed scripts", func(t *testing.T) {
+		commanders.SetPsqlFileCommand(exectest.NewCommand(SuccessScript))
+		defer commanders.ResetPsqlFileCommand()
+
+		utils.System.MkdirAll = func(path string, perm os.FileMode) error {
+			return nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		var written []string
+		utils.System.WriteFile = func(filename string, data []byte, perm os.FileMode) error {
+			written = append(written, filename)
+			return nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		fsys := fstest.MapFS{
+			phase.String(): {Mode: os.ModeDir},
+			filepath.Join(phase.String(), "custom_checks"):                                   {Mode: os.ModeDir},
+			filepath.Join(phase.String(), "custom_checks", commanders.ScriptDescriptionFile): {Data: []byte("Checks for custom objects.\n")},
+			filepath.Join(phase.String(), "custom_checks", "gen_custom_checks.sql"):          {},
+		}
+
+		bar := mpb.New().AddBar(10)
+		err := commanders.GenerateScriptsPerPhase(phase, database, gphome, port, seedDir, fsys, outputDir, bar)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+
+		expected := []string{filepath.Join(outputDir, "current", phase.String(), "custom_checks", "migration_postgres_gen_custom_checks.sql")}
+		if !reflect.DeepEqual(written, expected) {
+			t.Errorf("got written files %q want %q", written, expected)
+		}
+
+		if bar.Current() != 1 {
+			t.Errorf("got progress %d want %d", bar.Current(), 1)
+		}
+	})
+
 	t.Run("errors when failing to execute SQL script", func(t *testing.T) {
 		commanders.SetPsqlFileCommand(exectest.NewCommand(FailedMain))
 		defer commanders.ResetPsqlFileCommand()
@@ -824,6 +881,29 @@ func TestGetDatabases(t *testing.T) {
 		}
 	})
 
+	t.Run("includes scripts from custom seed directories", func(t *testing.T) {
+		expectPgDatabaseToReturn(mock).WillReturnRows(sqlmock.NewRows([]string{"datname", "quoted_datname"}).
+			AddRow("postgres", "postgres"))
+
+		customSeedDirFS := fstest.MapFS{
+			idl.Step_finalize.String():                                                                {Mode: os.ModeDir},
+			filepath.Join(idl.Step_finalize.String(), "drop_views"):                                   {Mode: os.ModeDir},
+			filepath.Join(idl.Step_finalize.String(), "drop_views", "gen_drop_views.sql"):             {},
+			filepath.Join(idl.Step_finalize.String(), "drop_views", "gen_drop_views.header"):          {},
+			filepath.Join(idl.Step_finalize.String(), "drop_views", commanders.ScriptDescriptionFile): {Data: []byte("Drops views\n")},
+		}
+
+		databases, err := commanders.GetDatabases(db, seedDirFS, customSeedDirFS)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+
+		expected := []commanders.DatabaseInfo{{QuotedDatname: "postgres", Datname: "postgres", NumSeedScripts: 4}}
+		if !reflect.DeepEqual(databases, expected) {
+			t.Errorf("got %v, want %v", databases, expected)
+		}
+	})
+
 	t.Run("errors when failing to query", func(t *testing.T) {
 		expected := os.ErrPermission
 		expectPgDatabaseToReturn(mock).WillReturnError(expected)
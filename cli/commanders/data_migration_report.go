@@ -0,0 +1,452 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+const ImpactReportName = "impact_report"
+
+type ObjectType string
+
+const (
+	Tables         ObjectType = "tables"
+	Indexes        ObjectType = "indexes"
+	Constraints    ObjectType = "constraints"
+	Views          ObjectType = "views"
+	Roles          ObjectType = "roles"
+	ExternalTables ObjectType = "external_tables"
+)
+
+var ObjectTypes = []ObjectType{Tables, Indexes, Constraints, Views, Roles, ExternalTables}
+
+// ImpactSummary describes the objects affected by the generated data
+// migration scripts of a single phase in a single database.
+type ImpactSummary struct {
+	Phase    string             `json:"phase"`
+	Database string             `json:"database"`
+	Objects  map[ObjectType]int `json:"objects"`
+	Scripts  []string           `json:"scripts"`
+
+	// IndexRebuildBytes and TableRewriteBytes estimate the amount of work
+	// needed to apply the scripts using the current size of the indexes that
+	// are re-created and the tables whose columns are altered.
+	IndexRebuildBytes uint64 `json:"index_rebuild_bytes"`
+	TableRewriteBytes uint64 `json:"table_rewrite_bytes"`
+
+	objects         map[ObjectType]map[string]bool
+	rebuiltIndexes  map[string]bool
+	rewrittenTables map[string]bool
+}
+
+type ImpactReport struct {
+	Summaries []*ImpactSummary `json:"summaries"`
+}
+
+// identifier matches a possibly schema qualified and quoted identifier as
+// output by quote_ident.
+const identifier = `((?:"(?:[^"]|"")+"|[^\s.;(),"]+)(?:\."(?:[^"]|"")+"|\.[^\s.;(),"]+)?)`
+
+type statementPattern struct {
+	objectType ObjectType
+	regex      *regexp.Regexp
+}
+
+// The order matters as the first matching pattern classifies the statement.
+var statementPatterns = []statementPattern{
+	{ExternalTables, regexp.MustCompile(`(?i)^DROP\s+EXTERNAL\s+(?:WEB\s+)?TABLE\s+(?:IF\s+EXISTS\s+)?` + identifier)},
+	{ExternalTables, regexp.MustCompile(`(?i)^CREATE\s+(?:READABLE\s+|WRITABLE\s+)?EXTERNAL\s+(?:WEB\s+)?TABLE\s+` + identifier)},
+	{Indexes, regexp.MustCompile(`(?i)^DROP\s+INDEX\s+(?:IF\s+EXISTS\s+)?` + identifier)},
+	{Constraints, regexp.MustCompile(`(?i)^ALTER\s+TABLE\s+(?:ONLY\s+)?` + identifier + `\s+(?:DROP|ADD)\s+CONSTRAINT\s+` + identifier)},
+	{Views, regexp.MustCompile(`(?i)^DROP\s+VIEW\s+(?:IF\s+EXISTS\s+)?` + identifier)},
+	{Views, regexp.MustCompile(`(?i)^CREATE\s+(?:OR\s+REPLACE\s+)?VIEW\s+` + identifier)},
+	{Roles, regexp.MustCompile(`(?i)^ALTER\s+ROLE\s+` + identifier)},
+	{Tables, regexp.MustCompile(`(?i)^ALTER\s+TABLE\s+(?:ONLY\s+)?` + identifier)},
+	{Tables, regexp.MustCompile(`(?i)^DELETE\s+FROM\s+(?:ONLY\s+)?` + identifier)},
+}
+
+var createIndexPattern = regexp.MustCompile(`(?i)^CREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?` + identifier + `\s+ON\s+(?:ONLY\s+)?` + identifier)
+var alterColumnTypePattern = regexp.MustCompile(`(?i)^ALTER\s+TABLE\s+(?:ONLY\s+)?` + identifier + `\s+ALTER\s+COLUMN\s+.*\s+TYPE\s+`)
+
+// ParseImpact parses the generated data migration scripts and summarizes the
+// affected objects per phase and database.
+func ParseImpact(currentScriptDirFS fs.FS) (*ImpactReport, error) {
+	summaries := make(map[string]*ImpactSummary)
+
+	for _, phase := range MigrationScriptPhases {
+		scriptDirs, err := utils.System.ReadDirFS(currentScriptDirFS, phase.String())
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		for _, scriptDir := range scriptDirs {
+			scripts, err := utils.System.ReadDirFS(currentScriptDirFS, filepath.Join(phase.String(), scriptDir.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			for _, script := range scripts {
+				if filepath.Ext(script.Name()) != ".sql" {
+					continue
+				}
+
+				contents, err := utils.System.ReadFileFS(currentScriptDirFS, filepath.Join(phase.String(), scriptDir.Name(), script.Name()))
+				if err != nil {
+					return nil, err
+				}
+
+				database, _ := SplitConnect(contents)
+				key := phase.String() + "/" + database
+				summary, ok := summaries[key]
+				if !ok {
+					summary = newImpactSummary(phase.String(), database)
+					summaries[key] = summary
+				}
+
+				summary.addScript(scriptDir.Name())
+				summary.parse(contents)
+			}
+		}
+	}
+
+	report := &ImpactReport{}
+	for _, summary := range summaries {
+		summary.count()
+		report.Summaries = append(report.Summaries, summary)
+	}
+
+	report.sort()
+	return report, nil
+}
+
+func newImpactSummary(phase string, database string) *ImpactSummary {
+	summary := &ImpactSummary{
+		Phase:           phase,
+		Database:        database,
+		Objects:         make(map[ObjectType]int),
+		objects:         make(map[ObjectType]map[string]bool),
+		rebuiltIndexes:  make(map[string]bool),
+		rewrittenTables: make(map[string]bool),
+	}
+
+	for _, objectType := range ObjectTypes {
+		summary.objects[objectType] = make(map[string]bool)
+	}
+
+	return summary
+}
+
+func (s *ImpactSummary) addScript(scriptDir string) {
+	for _, script := range s.Scripts {
+		if script == scriptDir {
+			return
+		}
+	}
+
+	s.Scripts = append(s.Scripts, scriptDir)
+}
+
+func (s *ImpactSummary) parse(contents []byte) {
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "--") || strings.HasPrefix(line, `\`) {
+			continue
+		}
+
+		if matches := createIndexPattern.FindStringSubmatch(line); matches != nil {
+			index := qualifyIndex(matches[1], matches[2])
+			s.objects[Indexes][index] = true
+			s.rebuiltIndexes[index] = true
+			continue
+		}
+
+		if matches := alterColumnTypePattern.FindStringSubmatch(line); matches != nil {
+			s.rewrittenTables[matches[1]] = true
+		}
+
+		for _, pattern := range statementPatterns {
+			matches := pattern.regex.FindStringSubmatch(line)
+			if matches == nil {
+				continue
+			}
+
+			name := matches[1]
+			if pattern.objectType == Constraints {
+				name = matches[1] + "." + matches[2]
+			}
+
+			s.objects[pattern.objectType][name] = true
+			break
+		}
+	}
+}
+
+// Count returns the number of affected objects of the given type.
+func (s *ImpactSummary) Count(objectType string) int {
+	return s.Objects[ObjectType(objectType)]
+}
+
+func (s *ImpactSummary) count() {
+	for objectType, names := range s.objects {
+		s.Objects[objectType] = len(names)
+	}
+
+	sort.Strings(s.Scripts)
+}
+
+// qualifyIndex schema qualifies an index created on a table since CREATE INDEX
+// places the index in the schema of its table.
+func qualifyIndex(index string, table string) string {
+	schema, _ := splitQualifiedName(table)
+	if schema == "" || strings.Contains(index, ".") {
+		return index
+	}
+
+	return schema + "." + index
+}
+
+// splitQualifiedName splits a quoted and qualified name into its schema and
+// name, ignoring periods within quotes.
+func splitQualifiedName(name string) (string, string) {
+	quoted := false
+	for i, r := range name {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == '.' && !quoted:
+			return name[:i], name[i+1:]
+		}
+	}
+
+	return "", name
+}
+
+// relationValues returns a VALUES list of the schema and name of each relation
+// as stored in the catalog. Unqualified relations have a NULL schema.
+func relationValues(relations map[string]bool) string {
+	values := make(map[string]bool)
+	for relation := range relations {
+		schema, name := splitQualifiedName(relation)
+
+		schemaValue := "NULL::text"
+		if schema != "" {
+			schemaValue = quoteLiteral(normalizeIdentifier(schema)) + "::text"
+		}
+
+		values["("+schemaValue+", "+quoteLiteral(normalizeIdentifier(name))+"::text)"] = true
+	}
+
+	var sorted []string
+	for value := range values {
+		sorted = append(sorted, value)
+	}
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, ", ")
+}
+
+// normalizeIdentifier returns an identifier as stored in the catalog by
+// removing quotes from quoted identifiers and folding unquoted ones to lower
+// case.
+func normalizeIdentifier(name string) string {
+	if strings.HasPrefix(name, `"`) {
+		return unquoteIdentifier(name)
+	}
+
+	return strings.ToLower(name)
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func unquoteIdentifier(name string) string {
+	if len(name) >= 2 && strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`) {
+		return strings.ReplaceAll(name[1:len(name)-1], `""`, `"`)
+	}
+
+	return name
+}
+
+func (r *ImpactReport) sort() {
+	phaseOrder := make(map[string]int)
+	for i, phase := range MigrationScriptPhases {
+		phaseOrder[phase.String()] = i
+	}
+
+	sort.Slice(r.Summaries, func(i, j int) bool {
+		if r.Summaries[i].Phase != r.Summaries[j].Phase {
+			return phaseOrder[r.Summaries[i].Phase] < phaseOrder[r.Summaries[j].Phase]
+		}
+
+		return r.Summaries[i].Database < r.Summaries[j].Database
+	})
+}
+
+// EstimateWork sums the current size of the indexes which are re-created and
+// the tables which are rewritten by the scripts. The relations are looked up in
+// the source cluster while they still exist, that is before applying any
+// scripts.
+func (r *ImpactReport) EstimateWork(gphome string, port int) error {
+	for _, summary := range r.Summaries {
+		var err error
+		summary.IndexRebuildBytes, err = relationSizes(gphome, port, summary.Database, summary.rebuiltIndexes)
+		if err != nil {
+			return err
+		}
+
+		summary.TableRewriteBytes, err = relationSizes(gphome, port, summary.Database, summary.rewrittenTables)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func relationSizes(gphome string, port int, database string, relations map[string]bool) (uint64, error) {
+	if len(relations) == 0 {
+		return 0, nil
+	}
+
+	// Relations are matched by oid so that a relation named differently by
+	// several statements, such as with and without its schema, is only
+	// counted once. Unqualified names are resolved using the search path.
+	query := fmt.Sprintf(`SELECT COALESCE(SUM(pg_relation_size(oid)), 0) FROM pg_class WHERE oid IN (
+SELECT c.oid FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN (VALUES %s) AS r(nspname, relname) ON c.relname = r.relname
+AND (n.nspname = r.nspname OR (r.nspname IS NULL AND pg_table_is_visible(c.oid))));`, relationValues(relations))
+
+	output, err := executeSQLCommand(gphome, port, database, query, "--no-align", "--tuples-only")
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := strconv.ParseUint(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing relation size for database %q: %w", database, err)
+	}
+
+	return size, nil
+}
+
+// WriteImpactReport writes the report as JSON, Markdown, and HTML next to the
+// generated scripts.
+func WriteImpactReport(report *ImpactReport, currentScriptDir string) error {
+	contents, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = utils.System.WriteFile(filepath.Join(currentScriptDir, ImpactReportName+".json"), contents, 0644)
+	if err != nil {
+		return err
+	}
+
+	err = utils.System.WriteFile(filepath.Join(currentScriptDir, ImpactReportName+".md"), []byte(report.Markdown()), 0644)
+	if err != nil {
+		return err
+	}
+
+	html, err := report.HTML()
+	if err != nil {
+		return err
+	}
+
+	return utils.System.WriteFile(filepath.Join(currentScriptDir, ImpactReportName+".html"), []byte(html), 0644)
+}
+
+func formatSize(bytes uint64) string {
+	return disk.FormatBytes(bytes / 1000)
+}
+
+func (r *ImpactReport) Markdown() string {
+	var output strings.Builder
+	output.WriteString("# Data Migration Impact Report\n")
+
+	if len(r.Summaries) == 0 {
+		output.WriteString("\nNo objects are affected by the generated data migration scripts.\n")
+		return output.String()
+	}
+
+	phase := ""
+	for _, summary := range r.Summaries {
+		if summary.Phase != phase {
+			phase = summary.Phase
+			output.WriteString("\n## " + phase + "\n\n")
+			output.WriteString("| Database | Tables | Indexes | Constraints | Views | Roles | External Tables | Index Rebuild Size | Table Rewrite Size | Scripts |\n")
+			output.WriteString("|---|---|---|---|---|---|---|---|---|---|\n")
+		}
+
+		fmt.Fprintf(&output, "| %s | %d | %d | %d | %d | %d | %d | %s | %s | %s |\n",
+			summary.Database,
+			summary.Objects[Tables],
+			summary.Objects[Indexes],
+			summary.Objects[Constraints],
+			summary.Objects[Views],
+			summary.Objects[Roles],
+			summary.Objects[ExternalTables],
+			formatSize(summary.IndexRebuildBytes),
+			formatSize(summary.TableRewriteBytes),
+			strings.Join(summary.Scripts, ", "))
+	}
+
+	return output.String()
+}
+
+var impactReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"size": formatSize,
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Data Migration Impact Report</title>
+</head>
+<body>
+<h1>Data Migration Impact Report</h1>
+{{- if not .Summaries}}
+<p>No objects are affected by the generated data migration scripts.</p>
+{{- else}}
+<table border="1">
+<tr><th>Phase</th><th>Database</th><th>Tables</th><th>Indexes</th><th>Constraints</th><th>Views</th><th>Roles</th><th>External Tables</th><th>Index Rebuild Size</th><th>Table Rewrite Size</th><th>Scripts</th></tr>
+{{- range .Summaries}}
+<tr><td>{{.Phase}}</td><td>{{.Database}}</td><td>{{.Count "tables"}}</td><td>{{.Count "indexes"}}</td><td>{{.Count "constraints"}}</td><td>{{.Count "views"}}</td><td>{{.Count "roles"}}</td><td>{{.Count "external_tables"}}</td><td>{{size .IndexRebuildBytes}}</td><td>{{size .TableRewriteBytes}}</td><td>{{join .Scripts ", "}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+func (r *ImpactReport) HTML() (string, error) {
+	var output bytes.Buffer
+	err := impactReportTemplate.Execute(&output, r)
+	if err != nil {
+		return "", err
+	}
+
+	return output.String(), nil
+}
@@ -0,0 +1,282 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+)
+
+func RelationSize() {
+	os.Stdout.WriteString("2000000\n")
+}
+
+func init() {
+	exectest.RegisterMains(
+		RelationSize,
+	)
+}
+
+func generatedScriptsFS() fstest.MapFS {
+	initialize := idl.Step_initialize.String()
+	finalize := idl.Step_finalize.String()
+
+	return fstest.MapFS{
+		filepath.Join(initialize, "unique_primary_foreign_key_constraint", "migration_postgres_gen_drop_constraint_1_fk.sql"): {Data: []byte(`\c postgres
+ALTER TABLE public.orders DROP CONSTRAINT orders_fk;
+ALTER TABLE public.orders DROP CONSTRAINT orders_fk;
+ALTER TABLE "My Schema"."line.items" DROP CONSTRAINT items_fk CASCADE;
+`)},
+		filepath.Join(initialize, "tables_using_tsquery_type", "migration_postgres_gen_drop_depr_built_in_type_dependent_views.sql"): {Data: []byte(`\c postgres
+DROP VIEW public.v1;
+DROP VIEW public.v2;
+`)},
+		filepath.Join(initialize, "tables_using_tsquery_type", "migration_postgres_gen_fix_tsquery_to_text.sql"): {Data: []byte(`\c postgres
+DROP INDEX IF EXISTS public.tsquery_idx;
+ALTER TABLE public.queries ALTER COLUMN q TYPE TEXT;
+`)},
+		filepath.Join(initialize, "gphdfs_external_tables", "migration_postgres_gen_drop_external_tables.sql"): {Data: []byte(`\c postgres
+DROP EXTERNAL TABLE public.ext;
+`)},
+		filepath.Join(initialize, "gphdfs_user_roles", "migration_postgres_gen_alter_gphdfs_roles.sql"): {Data: []byte(`\c postgres
+-- alter gphdfs roles
+ALTER ROLE gpadmin NOCREATEEXTTABLE(protocol='gphdfs',type='readable');
+`)},
+		filepath.Join(initialize, "tables_using_tsquery_type", `migration_"my db"_gen_fix_tsquery_to_text.sql`): {Data: []byte(`\c "my db"
+ALTER TABLE ONLY public.t ALTER COLUMN c TYPE TEXT;
+`)},
+		filepath.Join(finalize, "partitioned_tables_indexes", "migration_postgres_recreate_partition_indexes_step_1.sql"): {Data: []byte(`\c postgres
+CREATE INDEX idx1 ON public.sales USING btree (id);
+CREATE UNIQUE INDEX idx2 ON public.sales_1_prt_1 USING btree (id);
+`)},
+		filepath.Join(finalize, "partitioned_tables_indexes", "description"): {Data: []byte("ignored")},
+	}
+}
+
+func TestParseImpact(t *testing.T) {
+	t.Run("summarizes affected objects per phase and database", func(t *testing.T) {
+		report, err := commanders.ParseImpact(generatedScriptsFS())
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(report.Summaries) != 3 {
+			t.Fatalf("got %d summaries want 3", len(report.Summaries))
+		}
+
+		cases := []struct {
+			phase    string
+			database string
+			objects  map[commanders.ObjectType]int
+			scripts  []string
+		}{
+			{
+				phase:    "initialize",
+				database: "my db",
+				objects:  map[commanders.ObjectType]int{commanders.Tables: 1},
+				scripts:  []string{"tables_using_tsquery_type"},
+			},
+			{
+				phase:    "initialize",
+				database: "postgres",
+				objects: map[commanders.ObjectType]int{
+					commanders.Tables:         1,
+					commanders.Indexes:        1,
+					commanders.Constraints:    2,
+					commanders.Views:          2,
+					commanders.Roles:          1,
+					commanders.ExternalTables: 1,
+				},
+				scripts: []string{"gphdfs_external_tables", "gphdfs_user_roles", "tables_using_tsquery_type", "unique_primary_foreign_key_constraint"},
+			},
+			{
+				phase:    "finalize",
+				database: "postgres",
+				objects:  map[commanders.ObjectType]int{commanders.Indexes: 2},
+				scripts:  []string{"partitioned_tables_indexes"},
+			},
+		}
+
+		for i, c := range cases {
+			summary := report.Summaries[i]
+			if summary.Phase != c.phase || summary.Database != c.database {
+				t.Errorf("got summary for %q %q want %q %q", summary.Phase, summary.Database, c.phase, c.database)
+			}
+
+			for _, objectType := range commanders.ObjectTypes {
+				if summary.Objects[objectType] != c.objects[objectType] {
+					t.Errorf("%s %s: got %d %s want %d", c.phase, c.database, summary.Objects[objectType], objectType, c.objects[objectType])
+				}
+			}
+
+			if !reflect.DeepEqual(summary.Scripts, c.scripts) {
+				t.Errorf("got scripts %q want %q", summary.Scripts, c.scripts)
+			}
+		}
+	})
+
+	t.Run("returns an empty report when there are no generated scripts", func(t *testing.T) {
+		report, err := commanders.ParseImpact(fstest.MapFS{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(report.Summaries) != 0 {
+			t.Errorf("got %d summaries want 0", len(report.Summaries))
+		}
+
+		expected := "# Data Migration Impact Report\n\nNo objects are affected by the generated data migration scripts.\n"
+		if report.Markdown() != expected {
+			t.Errorf("got %q want %q", report.Markdown(), expected)
+		}
+	})
+}
+
+func TestEstimateWork(t *testing.T) {
+	t.Run("queries the size of re-created indexes and rewritten tables", func(t *testing.T) {
+		report, err := commanders.ParseImpact(generatedScriptsFS())
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		var queries []string
+		commanders.SetPsqlCommand(exectest.NewCommandWithVerifier(RelationSize, func(utility string, args ...string) {
+			expected := []string{"--no-align", "--tuples-only"}
+			if !reflect.DeepEqual(args[8:], expected) {
+				t.Errorf("got args %q want %q", args[8:], expected)
+			}
+
+			queries = append(queries, args[3]+": "+args[7])
+		}))
+		defer commanders.ResetPsqlCommand()
+
+		err = report.EstimateWork("/usr/local/gpdb6", 5432)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(queries) != 3 {
+			t.Fatalf("got %d queries want 3: %q", len(queries), queries)
+		}
+
+		expected := []string{
+			`my db: ` + `('public'::text, 't'::text)`,
+			`postgres: ` + `('public'::text, 'queries'::text)`,
+			`postgres: ` + `('public'::text, 'idx1'::text), ('public'::text, 'idx2'::text)`,
+		}
+		for i, query := range queries {
+			database, names, _ := strings.Cut(expected[i], ": ")
+			if !strings.HasPrefix(query, database+": ") || !strings.Contains(query, "VALUES "+names+")") {
+				t.Errorf("got query %q want it to be for %q with %q", query, database, names)
+			}
+		}
+
+		finalize := report.Summaries[2]
+		if finalize.IndexRebuildBytes != 2000000 || finalize.TableRewriteBytes != 0 {
+			t.Errorf("got index %d table %d bytes want 2000000 and 0", finalize.IndexRebuildBytes, finalize.TableRewriteBytes)
+		}
+
+		markdown := report.Markdown()
+		expectedRow := "| postgres | 0 | 2 | 0 | 0 | 0 | 0 | 2 MB | 0 KB | partitioned_tables_indexes |"
+		if !strings.Contains(markdown, expectedRow) {
+			t.Errorf("expected markdown %q to contain %q", markdown, expectedRow)
+		}
+	})
+
+	t.Run("normalizes unqualified and quoted names of relations", func(t *testing.T) {
+		report, err := commanders.ParseImpact(fstest.MapFS{
+			filepath.Join(idl.Step_initialize.String(), "tables_using_tsquery_type", "migration_postgres_gen_fix_tsquery_to_text.sql"): {Data: []byte(`\c postgres
+ALTER TABLE queries ALTER COLUMN q TYPE TEXT;
+ALTER TABLE Public.Queries ALTER COLUMN r TYPE TEXT;
+ALTER TABLE "public"."queries" ALTER COLUMN s TYPE TEXT;
+ALTER TABLE "My Schema"."It's" ALTER COLUMN t TYPE TEXT;
+`)},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		var query string
+		commanders.SetPsqlCommand(exectest.NewCommandWithVerifier(RelationSize, func(utility string, args ...string) {
+			query = args[7]
+		}))
+		defer commanders.ResetPsqlCommand()
+
+		err = report.EstimateWork("/usr/local/gpdb6", 5432)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := `VALUES ('My Schema'::text, 'It''s'::text), ('public'::text, 'queries'::text), (NULL::text, 'queries'::text))`
+		if !strings.Contains(query, expected) {
+			t.Errorf("expected query %q to contain %q", query, expected)
+		}
+	})
+
+	t.Run("errors when failing to query relation sizes", func(t *testing.T) {
+		report, err := commanders.ParseImpact(generatedScriptsFS())
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		commanders.SetPsqlCommand(exectest.NewCommand(FailedMain))
+		defer commanders.ResetPsqlCommand()
+
+		err = report.EstimateWork("/usr/local/gpdb6", 5432)
+		var exitError *exec.ExitError
+		if !errors.As(err, &exitError) {
+			t.Errorf("got %T, want %T", err, exitError)
+		}
+	})
+}
+
+func TestWriteImpactReport(t *testing.T) {
+	t.Run("writes json, markdown, and html reports", func(t *testing.T) {
+		dir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, dir)
+
+		report, err := commanders.ParseImpact(generatedScriptsFS())
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = commanders.WriteImpactReport(report, dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		var decoded commanders.ImpactReport
+		contents := testutils.MustReadFile(t, filepath.Join(dir, commanders.ImpactReportName+".json"))
+		if err := json.Unmarshal([]byte(contents), &decoded); err != nil {
+			t.Fatalf("unmarshal report: %v", err)
+		}
+
+		if len(decoded.Summaries) != 3 || decoded.Summaries[1].Objects[commanders.Constraints] != 2 {
+			t.Errorf("got decoded report %+v", decoded)
+		}
+
+		markdown := testutils.MustReadFile(t, filepath.Join(dir, commanders.ImpactReportName+".md"))
+		if !strings.Contains(markdown, "## initialize") || !strings.Contains(markdown, "## finalize") {
+			t.Errorf("expected markdown to contain each phase, got %q", markdown)
+		}
+
+		html := testutils.MustReadFile(t, filepath.Join(dir, commanders.ImpactReportName+".html"))
+		expected := "<tr><td>initialize</td><td>my db</td><td>1</td><td>0</td><td>0</td><td>0</td><td>0</td><td>0</td>"
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected html %q to contain %q", html, expected)
+		}
+	})
+}
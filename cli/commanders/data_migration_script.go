@@ -4,8 +4,15 @@
 package commanders
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"sort"
+	"strings"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
 )
 
 var scriptDescription = map[string]string{
@@ -20,8 +27,9 @@ var scriptDescription = map[string]string{
 }
 
 type Script struct {
-	Num  uint64
-	Name string
+	Num         uint64
+	Name        string
+	Description string // overrides the built-in description for custom scripts
 }
 
 type Scripts []Script
@@ -72,7 +80,33 @@ func (scripts Scripts) Description() string {
 
 	var output string
 	for _, script := range scripts {
-		output += fmt.Sprintf("  %s\n  - %s\n\n", script.Name, scriptDescription[script.Name])
+		description := script.Description
+		if description == "" {
+			description = scriptDescription[script.Name]
+		}
+
+		output += fmt.Sprintf("  %s\n  - %s\n\n", script.Name, description)
 	}
 	return output
 }
+
+// GetScripts returns the generated script directories for a phase along with
+// any description written for custom scripts.
+func GetScripts(currentScriptDirFS fs.FS, phase idl.Step) (Scripts, error) {
+	entries, err := utils.System.ReadDirFS(currentScriptDirFS, phase.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var scripts Scripts
+	for i, entry := range entries {
+		description, err := utils.System.ReadFileFS(currentScriptDirFS, filepath.Join(phase.String(), entry.Name(), ScriptDescriptionFile))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		scripts = append(scripts, Script{Num: uint64(i), Name: entry.Name(), Description: strings.TrimSpace(string(description))})
+	}
+
+	return scripts, nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func EchoStdin() {
+	_, err := io.Copy(os.Stdout, os.Stdin)
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	exectest.RegisterMains(
+		EchoStdin,
+	)
+}
+
+func TestSplitConnect(t *testing.T) {
+	cases := []struct {
+		name     string
+		contents string
+		database string
+		body     string
+	}{
+		{"returns the database and remaining script", "\\c postgres\nDROP VIEW v;\n", "postgres", "DROP VIEW v;\n"},
+		{"unquotes the database", "\n\\c \"my \"\"db\"\"\"\nDROP VIEW v;\n", `my "db"`, "DROP VIEW v;\n"},
+		{"returns the script when there is no leading connect", "DROP VIEW v;\n\\c postgres\n", "", "DROP VIEW v;\n\\c postgres\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			database, body := commanders.SplitConnect([]byte(c.contents))
+			if database != c.database {
+				t.Errorf("got database %q want %q", database, c.database)
+			}
+
+			if string(body) != c.body {
+				t.Errorf("got body %q want %q", body, c.body)
+			}
+		})
+	}
+}
+
+func TestNonTransactionalStatement(t *testing.T) {
+	cases := []struct {
+		name     string
+		contents string
+		expected string
+	}{
+		{"allows transactional statements", "\\c postgres\nDROP INDEX public.idx;\nCREATE INDEX idx ON public.t USING btree (a);\n", ""},
+		{"detects create index concurrently", "\\c postgres\nCREATE UNIQUE INDEX CONCURRENTLY idx ON public.t (a);\n", "CREATE UNIQUE INDEX CONCURRENTLY idx ON public.t (a);"},
+		{"detects drop index concurrently", "drop index concurrently public.idx;\n", "drop index concurrently public.idx;"},
+		{"detects reindex concurrently", "REINDEX INDEX CONCURRENTLY public.idx;\n", "REINDEX INDEX CONCURRENTLY public.idx;"},
+		{"detects vacuum", "\\c postgres\n  VACUUM FREEZE public.t;\n", "VACUUM FREEZE public.t;"},
+		{"detects create database", "CREATE DATABASE foo;\n", "CREATE DATABASE foo;"},
+		{"detects reconnecting to another database", "\\c postgres\nDROP VIEW v;\n\\c template1\nDROP VIEW v;\n", `\c template1`},
+		{"ignores keywords within statements", "\\c postgres\nCOMMENT ON TABLE t IS 'VACUUM';\n", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			statement := commanders.NonTransactionalStatement([]byte(c.contents))
+			if statement != c.expected {
+				t.Errorf("got %q want %q", statement, c.expected)
+			}
+		})
+	}
+}
+
+func TestApplySQLFileInTransaction(t *testing.T) {
+	path := "/home/gpupgrade/data-migration/current/initialize/views/migration_db_drop_views.sql"
+
+	t.Run("applies the file directly when not transactional", func(t *testing.T) {
+		commanders.SetPsqlFileCommand(exectest.NewCommandWithVerifier(Success, func(utility string, args ...string) {
+			expected := []string{"--no-psqlrc", "--quiet", "-d", "postgres", "-p", "5432", "-f", path}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+		defer commanders.ResetPsqlFileCommand()
+
+		_, err := commanders.ApplySQLFileInTransaction("/usr/local/gpdb6", 5432, "postgres", path, commanders.NonTransactional)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+	})
+
+	for _, c := range []struct {
+		mode commanders.TransactionMode
+		end  string
+	}{
+		{commanders.Transactional, "COMMIT;\n"},
+		{commanders.DryRun, "ROLLBACK;\n"},
+	} {
+		t.Run("wraps the script in a transaction for "+c.mode.String()+" mode", func(t *testing.T) {
+			utils.System.ReadFile = func(filename string) ([]byte, error) {
+				if filename != path {
+					t.Errorf("got filename %q want %q", filename, path)
+				}
+
+				return []byte("\\c \"my db\"\nDROP VIEW public.v;\n"), nil
+			}
+			defer utils.ResetSystemFunctions()
+
+			commanders.SetPsqlFileCommand(exectest.NewCommandWithVerifier(EchoStdin, func(utility string, args ...string) {
+				expected := []string{"-v", "ON_ERROR_STOP=1", "--no-psqlrc", "--quiet", "-d", "my db", "-p", "5432", "-f", "-"}
+				if !reflect.DeepEqual(args, expected) {
+					t.Errorf("got args %q want %q", args, expected)
+				}
+			}))
+			defer commanders.ResetPsqlFileCommand()
+
+			output, err := commanders.ApplySQLFileInTransaction("/usr/local/gpdb6", 5432, "postgres", path, c.mode, "-v", "ON_ERROR_STOP=1")
+			if err != nil {
+				t.Errorf("unexpected error: %#v", err)
+			}
+
+			expected := "BEGIN;\nDROP VIEW public.v;\n\n" + c.end
+			if string(output) != expected {
+				t.Errorf("got %q want %q", output, expected)
+			}
+		})
+	}
+
+	t.Run("errors when the script cannot run in a transaction", func(t *testing.T) {
+		utils.System.ReadFile = func(filename string) ([]byte, error) {
+			return []byte("\\c postgres\nCREATE INDEX CONCURRENTLY idx ON t (a);\n"), nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		_, err := commanders.ApplySQLFileInTransaction("", 0, "postgres", path, commanders.Transactional)
+		var nonTransactionalErr *commanders.NonTransactionalScriptError
+		if !errors.As(err, &nonTransactionalErr) {
+			t.Fatalf("got error %#v want %T", err, nonTransactionalErr)
+		}
+
+		if nonTransactionalErr.Statement != "CREATE INDEX CONCURRENTLY idx ON t (a);" {
+			t.Errorf("got statement %q", nonTransactionalErr.Statement)
+		}
+	})
+
+	t.Run("errors when failing to read the script", func(t *testing.T) {
+		utils.System.ReadFile = func(filename string) ([]byte, error) {
+			return nil, os.ErrPermission
+		}
+		defer utils.ResetSystemFunctions()
+
+		_, err := commanders.ApplySQLFileInTransaction("", 0, "postgres", path, commanders.DryRun)
+		if !errors.Is(err, os.ErrPermission) {
+			t.Errorf("got error %#v want %#v", err, os.ErrPermission)
+		}
+	})
+}
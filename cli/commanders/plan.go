@@ -0,0 +1,56 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+// Plan prints the changes finalize makes to the target cluster.
+func Plan(ctx context.Context, client idl.CliToHubClient, out io.Writer) error {
+	reply, err := client.Plan(ctx, &idl.PlanRequest{})
+	if err != nil {
+		return err
+	}
+
+	if err := printPgHbaPreviews(out, reply.GetPgHbaPreviews()); err != nil {
+		return err
+	}
+
+	switch method := reply.GetMirrorUpgradeMethod(); method {
+	case idl.MirrorUpgradeMethod_unknown_mirror_upgrade_method:
+		return nil
+	case idl.MirrorUpgradeMethod_rsync:
+		_, err = fmt.Fprintln(out, "\nFinalize upgrades the mirrors and standby using rsync.")
+	default:
+		_, err = fmt.Fprintf(out, "\nFinalize upgrades the mirrors using %s and the standby using gpinitstandby.\n", method)
+	}
+
+	return err
+}
+
+func printPgHbaPreviews(out io.Writer, previews []*idl.PlanReply_PgHbaPreview) error {
+	if len(previews) == 0 {
+		_, err := fmt.Fprintln(out, "Finalize makes no changes to pg_hba.conf.")
+		return err
+	}
+
+	fmt.Fprintln(out, "Finalize makes the following changes to pg_hba.conf:")
+	for _, preview := range previews {
+		fmt.Fprintf(out, "\n%s:%s\n", preview.GetHostname(), preview.GetDataDir())
+		for _, line := range preview.GetDiff() {
+			fmt.Fprintf(out, "  %s\n", line)
+		}
+
+		for _, rule := range preview.GetUnmigratable() {
+			fmt.Fprintf(out, "  not migrated: %s\n", rule)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+)
+
+func TestPlan(t *testing.T) {
+	t.Run("prints the pg_hba.conf changes of each segment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		client := mock_idl.NewMockCliToHubClient(ctrl)
+		client.EXPECT().Plan(gomock.Any(), &idl.PlanRequest{}).Return(&idl.PlanReply{
+			PgHbaPreviews: []*idl.PlanReply_PgHbaPreview{{
+				Hostname:     "sdw1",
+				DataDir:      "/data/dbfast1/seg0",
+				Diff:         []string{"+ host all all 10.0.0.0/8 md5"},
+				Unmigratable: []string{`"host all all 10.2.0.0/16 krb5": removed`},
+			}},
+		}, nil)
+
+		out := new(bytes.Buffer)
+		err := commanders.Plan(context.Background(), client, out)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := `Finalize makes the following changes to pg_hba.conf:
+
+sdw1:/data/dbfast1/seg0
+  + host all all 10.0.0.0/8 md5
+  not migrated: "host all all 10.2.0.0/16 krb5": removed
+`
+		if out.String() != expected {
+			t.Errorf("got %q want %q", out.String(), expected)
+		}
+	})
+
+	t.Run("reports when there are no changes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		client := mock_idl.NewMockCliToHubClient(ctrl)
+		client.EXPECT().Plan(gomock.Any(), gomock.Any()).Return(&idl.PlanReply{}, nil)
+
+		out := new(bytes.Buffer)
+		err := commanders.Plan(context.Background(), client, out)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if out.String() != "Finalize makes no changes to pg_hba.conf.\n" {
+			t.Errorf("got %q", out.String())
+		}
+	})
+
+	t.Run("prints the mirror upgrade method", func(t *testing.T) {
+		cases := []struct {
+			method   idl.MirrorUpgradeMethod
+			expected string
+		}{
+			{method: idl.MirrorUpgradeMethod_rsync, expected: "\nFinalize upgrades the mirrors and standby using rsync.\n"},
+			{method: idl.MirrorUpgradeMethod_pg_basebackup, expected: "\nFinalize upgrades the mirrors using pg_basebackup and the standby using gpinitstandby.\n"},
+		}
+
+		for _, c := range cases {
+			ctrl := gomock.NewController(t)
+
+			client := mock_idl.NewMockCliToHubClient(ctrl)
+			client.EXPECT().Plan(gomock.Any(), gomock.Any()).Return(&idl.PlanReply{MirrorUpgradeMethod: c.method}, nil)
+
+			out := new(bytes.Buffer)
+			err := commanders.Plan(context.Background(), client, out)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := "Finalize makes no changes to pg_hba.conf.\n" + c.expected
+			if out.String() != expected {
+				t.Errorf("got %q want %q", out.String(), expected)
+			}
+
+			ctrl.Finish()
+		}
+	})
+
+	t.Run("errors when the hub fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		client := mock_idl.NewMockCliToHubClient(ctrl)
+		client.EXPECT().Plan(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		err := commanders.Plan(context.Background(), client, new(bytes.Buffer))
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
@@ -31,7 +31,9 @@ var indicators = map[idl.Status]string{
 	idl.Status_quit:     "[QUIT]",
 }
 
-func Initialize(client idl.CliToHubClient, request *idl.InitializeRequest, verbose bool) (err error) {
+func Initialize(ctx context.Context, client idl.CliToHubClient, request *idl.InitializeRequest, verbose bool) (err error) {
+	defer cancelOnDone(ctx, client)()
+
 	stream, err := client.Initialize(context.Background(), request)
 	if err != nil {
 		return err
@@ -45,7 +47,9 @@ func Initialize(client idl.CliToHubClient, request *idl.InitializeRequest, verbo
 	return nil
 }
 
-func InitializeCreateCluster(client idl.CliToHubClient, request *idl.InitializeCreateClusterRequest, verbose bool) (*idl.InitializeResponse, error) {
+func InitializeCreateCluster(ctx context.Context, client idl.CliToHubClient, request *idl.InitializeCreateClusterRequest, verbose bool) (*idl.InitializeResponse, error) {
+	defer cancelOnDone(ctx, client)()
+
 	stream, err := client.InitializeCreateCluster(context.Background(), request)
 	if err != nil {
 		return &idl.InitializeResponse{}, err
@@ -64,7 +68,9 @@ func InitializeCreateCluster(client idl.CliToHubClient, request *idl.InitializeC
 	return initializeResponse, nil
 }
 
-func Execute(client idl.CliToHubClient, request *idl.ExecuteRequest, verbose bool) (*idl.ExecuteResponse, error) {
+func Execute(ctx context.Context, client idl.CliToHubClient, request *idl.ExecuteRequest, verbose bool) (*idl.ExecuteResponse, error) {
+	defer cancelOnDone(ctx, client)()
+
 	stream, err := client.Execute(context.Background(), request)
 	if err != nil {
 		return &idl.ExecuteResponse{}, err
@@ -83,7 +89,9 @@ func Execute(client idl.CliToHubClient, request *idl.ExecuteRequest, verbose boo
 	return executeResponse, nil
 }
 
-func Finalize(client idl.CliToHubClient, verbose bool) (*idl.FinalizeResponse, error) {
+func Finalize(ctx context.Context, client idl.CliToHubClient, verbose bool) (*idl.FinalizeResponse, error) {
+	defer cancelOnDone(ctx, client)()
+
 	stream, err := client.Finalize(context.Background(), &idl.FinalizeRequest{})
 	if err != nil {
 		return &idl.FinalizeResponse{}, err
@@ -102,7 +110,9 @@ func Finalize(client idl.CliToHubClient, verbose bool) (*idl.FinalizeResponse, e
 	return finalizeResponse, nil
 }
 
-func Revert(client idl.CliToHubClient, verbose bool) (*idl.RevertResponse, error) {
+func Revert(ctx context.Context, client idl.CliToHubClient, verbose bool) (*idl.RevertResponse, error) {
+	defer cancelOnDone(ctx, client)()
+
 	stream, err := client.Revert(context.Background(), &idl.RevertRequest{})
 	if err != nil {
 		return &idl.RevertResponse{}, err
@@ -121,6 +131,32 @@ func Revert(client idl.CliToHubClient, verbose bool) (*idl.RevertResponse, error
 	return revertResponse, nil
 }
 
+// cancelOnDone asks the hub to cancel the running step once ctx is done, such
+// as when the user interrupts the CLI. The stream itself is opened without ctx
+// so that a CLI which merely disconnects leaves the step running on the hub,
+// and so that an interrupted CLI still receives the step's final status.
+func cancelOnDone(ctx context.Context, client idl.CliToHubClient) (stop func()) {
+	done := make(chan struct{})
+	exited := make(chan struct{})
+
+	go func() {
+		defer close(exited)
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			if _, err := client.Cancel(context.Background(), &idl.CancelRequest{}); err != nil {
+				log.Printf("canceling step: %v", err)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-exited
+	}
+}
+
 func UILoop(stream receiver, verbose bool) (*idl.Response, error) {
 	var response *idl.Response
 	var lastStep idl.Substep
@@ -4,18 +4,23 @@
 package commanders_test
 
 import (
+	"context"
 	"errors"
 	"io"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/blang/semver/v4"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
 	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/utils"
 )
@@ -395,3 +400,68 @@ func TestFormatStatus(t *testing.T) {
 		}
 	})
 }
+
+func TestExecute(t *testing.T) {
+	t.Run("asks the hub to cancel the step when interrupted rather than canceling the stream", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		canceled := make(chan struct{})
+		stream := mock_idl.NewMockCliToHub_ExecuteClient(ctrl)
+		stream.EXPECT().Recv().DoAndReturn(func() (*idl.Message, error) {
+			cancel()
+			<-canceled
+			return nil, status.Error(codes.Canceled, "context canceled")
+		})
+
+		var streamCtx context.Context
+		client := mock_idl.NewMockCliToHubClient(ctrl)
+		client.EXPECT().Execute(gomock.Any(), &idl.ExecuteRequest{}).DoAndReturn(
+			func(ctx context.Context, _ *idl.ExecuteRequest, _ ...grpc.CallOption) (idl.CliToHub_ExecuteClient, error) {
+				streamCtx = ctx
+				return stream, nil
+			})
+		client.EXPECT().Cancel(gomock.Any(), &idl.CancelRequest{}).DoAndReturn(
+			func(context.Context, *idl.CancelRequest, ...grpc.CallOption) (*idl.CancelReply, error) {
+				close(canceled)
+				return &idl.CancelReply{Canceled: true}, nil
+			})
+
+		_, err := commanders.Execute(ctx, client, &idl.ExecuteRequest{}, false)
+		if err == nil {
+			t.Errorf("expected an error, got nil")
+		}
+
+		if streamCtx.Err() != nil {
+			t.Errorf("stream context was canceled: %v", streamCtx.Err())
+		}
+	})
+
+	t.Run("does not cancel the step when it completes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		stream := mock_idl.NewMockCliToHub_ExecuteClient(ctrl)
+		stream.EXPECT().Recv().Return(&idl.Message{Contents: &idl.Message_Response{Response: &idl.Response{
+			Contents: &idl.Response_ExecuteResponse{ExecuteResponse: &idl.ExecuteResponse{}},
+		}}}, nil)
+		stream.EXPECT().Recv().Return(nil, io.EOF)
+
+		client := mock_idl.NewMockCliToHubClient(ctrl)
+		client.EXPECT().Execute(gomock.Any(), &idl.ExecuteRequest{}).Return(stream, nil)
+		client.EXPECT().Cancel(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := commanders.Execute(ctx, client, &idl.ExecuteRequest{}, false)
+		if err != nil {
+			t.Errorf("unexpected error %+v", err)
+		}
+
+		// Interrupting after the step completes must not cancel the next step.
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+	})
+}
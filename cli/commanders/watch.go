@@ -0,0 +1,203 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+// clearScreen moves the cursor to the top left and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+const watchRefreshInterval = 250 * time.Millisecond
+
+// Dashboard is the state rendered by "gpupgrade watch".
+type Dashboard struct {
+	step     idl.Step
+	substeps []*idl.SubstepStatus
+	agents   map[string]*idl.AgentActivity
+	logLines []string
+	partial  string
+	maxLines int
+}
+
+func NewDashboard(maxLines int) *Dashboard {
+	return &Dashboard{
+		agents:   make(map[string]*idl.AgentActivity),
+		maxLines: maxLines,
+	}
+}
+
+func (d *Dashboard) Update(reply *idl.SubscribeReply) {
+	switch x := reply.GetContents().(type) {
+	case *idl.SubscribeReply_Step:
+		// A new step replaces the previous one.
+		*d = *NewDashboard(d.maxLines)
+		d.step = x.Step
+
+	case *idl.SubscribeReply_AgentActivity:
+		d.agents[x.AgentActivity.GetHost()] = x.AgentActivity
+
+	case *idl.SubscribeReply_Message:
+		switch msg := x.Message.GetContents().(type) {
+		case *idl.Message_Status:
+			d.updateSubstep(msg.Status)
+		case *idl.Message_Chunk:
+			d.appendOutput(string(msg.Chunk.GetBuffer()))
+		}
+	}
+}
+
+func (d *Dashboard) updateSubstep(status *idl.SubstepStatus) {
+	for i, substep := range d.substeps {
+		if substep.GetStep() == status.GetStep() {
+			d.substeps[i] = status
+			return
+		}
+	}
+
+	d.substeps = append(d.substeps, status)
+}
+
+func (d *Dashboard) appendOutput(output string) {
+	lines := strings.Split(d.partial+output, "\n")
+	d.partial = lines[len(lines)-1]
+
+	for _, line := range lines[:len(lines)-1] {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		d.logLines = append(d.logLines, line)
+	}
+
+	if len(d.logLines) > d.maxLines {
+		d.logLines = d.logLines[len(d.logLines)-d.maxLines:]
+	}
+}
+
+func (d *Dashboard) Render(w io.Writer) {
+	if d.step == idl.Step_unknown_step {
+		fmt.Fprintln(w, "Waiting for a gpupgrade step to start...")
+		return
+	}
+
+	fmt.Fprintf(w, "gpupgrade %s\n\n", d.step)
+
+	fmt.Fprintln(w, "Substeps")
+	for _, substep := range d.substeps {
+		fmt.Fprintln(w, FormatStatus(substep))
+	}
+
+	fmt.Fprintln(w, "\nAgents")
+	hosts := make([]string, 0, len(d.agents))
+	for host := range d.agents {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, host := range hosts {
+		activity := d.agents[host]
+		fmt.Fprintf(t, "%s\t%s\t%s\n", host, path.Base(activity.GetMethod()), describeActivity(activity))
+	}
+	t.Flush()
+
+	fmt.Fprintln(w, "\nLog")
+	for _, line := range d.logLines {
+		fmt.Fprintln(w, line)
+	}
+}
+
+func describeActivity(activity *idl.AgentActivity) string {
+	switch {
+	case activity.GetRunning():
+		return "running"
+	case activity.GetError() != "":
+		return "failed: " + activity.GetError()
+	default:
+		return "done"
+	}
+}
+
+// Watch renders the progress of the hub's current step until the context is
+// canceled. Detaching does not affect the step.
+func Watch(ctx context.Context, client idl.CliToHubClient, out io.Writer, logLines int) error {
+	stream, err := client.Subscribe(ctx, &idl.SubscribeRequest{})
+	if err != nil {
+		return err
+	}
+
+	var mutex sync.Mutex
+	dashboard := NewDashboard(logLines)
+	changed := true
+
+	done := make(chan error, 1)
+	go func() {
+		for {
+			reply, err := stream.Recv()
+			if err != nil {
+				done <- err
+				return
+			}
+
+			mutex.Lock()
+			dashboard.Update(reply)
+			changed = true
+			mutex.Unlock()
+		}
+	}()
+
+	render := func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if !changed {
+			return
+		}
+
+		fmt.Fprint(out, clearScreen)
+		dashboard.Render(out)
+		fmt.Fprintf(out, "\nUpdated at %s. Press Ctrl-C to detach.\n", time.Now().Format(time.TimeOnly))
+		changed = false
+	}
+
+	ticker := time.NewTicker(watchRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			render()
+
+		case err := <-done:
+			render()
+
+			if ctx.Err() != nil || status.Code(err) == codes.Canceled {
+				return nil
+			}
+
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
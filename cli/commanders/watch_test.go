@@ -0,0 +1,146 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commanders_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+)
+
+func stepReply(step idl.Step) *idl.SubscribeReply {
+	return &idl.SubscribeReply{Contents: &idl.SubscribeReply_Step{Step: step}}
+}
+
+func statusReply(substep idl.Substep, status idl.Status) *idl.SubscribeReply {
+	return &idl.SubscribeReply{Contents: &idl.SubscribeReply_Message{Message: &idl.Message{
+		Contents: &idl.Message_Status{Status: &idl.SubstepStatus{Step: substep, Status: status}},
+	}}}
+}
+
+func outputReply(output string) *idl.SubscribeReply {
+	return &idl.SubscribeReply{Contents: &idl.SubscribeReply_Message{Message: &idl.Message{
+		Contents: &idl.Message_Chunk{Chunk: &idl.Chunk{Buffer: []byte(output), Type: idl.Chunk_stdout}},
+	}}}
+}
+
+func agentReply(host string, running bool, err string) *idl.SubscribeReply {
+	return &idl.SubscribeReply{Contents: &idl.SubscribeReply_AgentActivity{AgentActivity: &idl.AgentActivity{
+		Host: host, Method: "/idl.Agent/UpgradePrimaries", Running: running, Error: err,
+	}}}
+}
+
+func TestDashboard(t *testing.T) {
+	t.Run("renders the substeps, agents, and recent output", func(t *testing.T) {
+		dashboard := commanders.NewDashboard(2)
+		for _, reply := range []*idl.SubscribeReply{
+			stepReply(idl.Step_execute),
+			statusReply(idl.Substep_upgrade_master, idl.Status_running),
+			statusReply(idl.Substep_upgrade_master, idl.Status_complete),
+			statusReply(idl.Substep_upgrade_primaries, idl.Status_running),
+			agentReply("sdw2", false, "pg_upgrade failed"),
+			agentReply("sdw1", true, ""),
+			outputReply("first line\nsecond "),
+			outputReply("line\nthird line\n"),
+		} {
+			dashboard.Update(reply)
+		}
+
+		var out bytes.Buffer
+		dashboard.Render(&out)
+
+		expected := "gpupgrade execute\n\n" +
+			"Substeps\n" +
+			commanders.Format("Upgrading master...", idl.Status_complete) + "\n" +
+			commanders.Format("Upgrading primary segments...", idl.Status_running) + "\n" +
+			"\nAgents\n" +
+			"sdw1  UpgradePrimaries  running\n" +
+			"sdw2  UpgradePrimaries  failed: pg_upgrade failed\n" +
+			"\nLog\n" +
+			"second line\n" +
+			"third line\n"
+		if out.String() != expected {
+			t.Errorf("got %q want %q", out.String(), expected)
+		}
+	})
+
+	t.Run("starts over when a new step begins", func(t *testing.T) {
+		dashboard := commanders.NewDashboard(10)
+		dashboard.Update(stepReply(idl.Step_execute))
+		dashboard.Update(statusReply(idl.Substep_upgrade_master, idl.Status_complete))
+		dashboard.Update(stepReply(idl.Step_revert))
+
+		var out bytes.Buffer
+		dashboard.Render(&out)
+
+		expected := "gpupgrade revert\n\nSubsteps\n\nAgents\n\nLog\n"
+		if out.String() != expected {
+			t.Errorf("got %q want %q", out.String(), expected)
+		}
+	})
+
+	t.Run("waits for a step to start", func(t *testing.T) {
+		var out bytes.Buffer
+		commanders.NewDashboard(10).Render(&out)
+
+		expected := "Waiting for a gpupgrade step to start...\n"
+		if out.String() != expected {
+			t.Errorf("got %q want %q", out.String(), expected)
+		}
+	})
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("renders events until the stream ends", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stream := mock_idl.NewMockCliToHub_SubscribeClient(ctrl)
+		gomock.InOrder(
+			stream.EXPECT().Recv().Return(stepReply(idl.Step_finalize), nil),
+			stream.EXPECT().Recv().Return(statusReply(idl.Substep_upgrade_mirrors, idl.Status_running), nil),
+			stream.EXPECT().Recv().Return(nil, io.EOF),
+		)
+
+		client := mock_idl.NewMockCliToHubClient(ctrl)
+		client.EXPECT().Subscribe(gomock.Any(), &idl.SubscribeRequest{}).Return(stream, nil)
+
+		var out bytes.Buffer
+		err := commanders.Watch(context.Background(), client, &out, 10)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		expected := commanders.Format("Upgrading mirror segments...", idl.Status_running)
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected output %q to contain %q", out.String(), expected)
+		}
+	})
+
+	t.Run("returns errors from the hub", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("connection reset")
+		stream := mock_idl.NewMockCliToHub_SubscribeClient(ctrl)
+		stream.EXPECT().Recv().Return(nil, expected)
+
+		client := mock_idl.NewMockCliToHubClient(ctrl)
+		client.EXPECT().Subscribe(gomock.Any(), gomock.Any()).Return(stream, nil)
+
+		err := commanders.Watch(context.Background(), client, io.Discard, 10)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
@@ -29,6 +29,8 @@ func Agent() *cobra.Command {
 			defer logger.WritePanics()
 
 			agentServer := agent.New()
+			agentServer.Version = Version
+			agentServer.Commit = Commit
 
 			// blocking call
 			return agentServer.Start(agentPort, stateDir, shouldDaemonize)
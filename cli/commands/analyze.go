@@ -0,0 +1,110 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/statistics"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+type analyzeFlags struct {
+	jobs      int
+	priority  string
+	tableList string
+	inStages  bool
+}
+
+func (a *analyzeFlags) register(flags *pflag.FlagSet, prefix string) {
+	flags.IntVar(&a.jobs, prefix+"jobs", 4, "number of databases to analyze in parallel")
+	flags.StringVar(&a.priority, prefix+"priority", string(statistics.Size), `order to analyze tables within a database. Either "size", "usage", or "list"`)
+	flags.StringVar(&a.tableList, prefix+"table-list", "", `file of database.schema.table names to analyze first when the priority is "list"`)
+	flags.BoolVar(&a.inStages, prefix+"in-stages", false, "quickly create minimal statistics on every table before refining them in two more passes")
+}
+
+func (a *analyzeFlags) options() (statistics.Options, error) {
+	priority, err := statistics.ParsePriority(a.priority)
+	if err != nil {
+		return statistics.Options{}, err
+	}
+
+	logDir, err := utils.GetLogDir()
+	if err != nil {
+		return statistics.Options{}, err
+	}
+
+	tableList := a.tableList
+	if tableList != "" {
+		tableList = filepath.Clean(tableList)
+	}
+
+	return statistics.Options{
+		Jobs:       a.jobs,
+		Priority:   priority,
+		TableList:  tableList,
+		InStages:   a.inStages,
+		LedgerFile: filepath.Join(logDir, statistics.LedgerFileName),
+	}, nil
+}
+
+func clusterConnector(cluster *greenplum.Cluster, options ...greenplum.Option) statistics.Connector {
+	return func(database string) (*sql.DB, error) {
+		return sql.Open("pgx", cluster.Connection(append(options, greenplum.Database(database))...))
+	}
+}
+
+func analyze() *cobra.Command {
+	var gphome string
+	var port int
+	var flags analyzeFlags
+
+	cmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "creates optimizer statistics on the upgraded cluster",
+		Long:  AnalyzeHelp,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := flags.options()
+			if err != nil {
+				return err
+			}
+
+			cluster, err := greenplum.NewCluster([]greenplum.SegConfig{})
+			if err != nil {
+				return err
+			}
+
+			cluster.Destination = idl.ClusterDestination_target
+			cluster.Version, err = greenplum.Version(filepath.Clean(gphome))
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return statistics.Analyze(ctx, os.Stdout, clusterConnector(&cluster, greenplum.Port(port)), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&gphome, "gphome", "", "path to the Greenplum installation")
+	cmd.Flags().IntVar(&port, "port", 0, "master port for Greenplum cluster")
+	flags.register(cmd.Flags(), "")
+	cmd.MarkFlagRequired("gphome") //nolint
+	cmd.MarkFlagRequired("port")   //nolint
+
+	return addHelpToCommand(cmd, AnalyzeHelp)
+}
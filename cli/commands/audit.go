@@ -0,0 +1,50 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gpupgrade/audit"
+)
+
+func auditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "subcommands to inspect the audit log of the upgrade",
+		Long:  AuditHelp,
+	}
+
+	cmd.AddCommand(auditVerify())
+	return addHelpToCommand(cmd, AuditHelp)
+}
+
+func auditVerify() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "checks that the audit log has not been tampered with",
+		Long:  AuditVerifyHelp,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if file == "" {
+				file = audit.Path()
+			}
+
+			if err := audit.Verify(file); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The audit log %q is intact.\n", file)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "the audit log to verify. Defaults to the one in the state directory.")
+	return addHelpToCommand(cmd, AuditVerifyHelp)
+}
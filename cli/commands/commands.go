@@ -31,7 +31,9 @@ package commands
  */
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"log"
@@ -46,6 +48,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/greenplum-db/gpupgrade/audit"
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
 	"github.com/greenplum-db/gpupgrade/config"
 	"github.com/greenplum-db/gpupgrade/idl"
@@ -59,7 +62,8 @@ func BuildRootCommand() *cobra.Command {
 	var format string
 
 	root := &cobra.Command{
-		Use: "gpupgrade",
+		Use:               "gpupgrade",
+		PersistentPreRunE: recordInvocation,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if shouldPrintVersion {
 				printVersion(format)
@@ -82,6 +86,11 @@ func BuildRootCommand() *cobra.Command {
 	root.AddCommand(execute())
 	root.AddCommand(finalize())
 	root.AddCommand(revert())
+	root.AddCommand(watch())
+	root.AddCommand(diff())
+	root.AddCommand(analyze())
+	root.AddCommand(plan())
+	root.AddCommand(auditCmd())
 	root.AddCommand(restartServices)
 	root.AddCommand(killServices)
 	root.AddCommand(Agent())
@@ -93,6 +102,70 @@ func BuildRootCommand() *cobra.Command {
 	return addHelpToCommand(root, GlobalHelp)
 }
 
+// recordInvocation audits who ran the command along with its flags and the
+// contents and hash of the config file with secrets redacted. The hub and agent
+// commands are started by gpupgrade rather than users and are not recorded.
+// Failing to record the invocation only warns rather than blocking the command.
+func recordInvocation(cmd *cobra.Command, args []string) error {
+	if cmd.Hidden {
+		return nil
+	}
+
+	entry := audit.Entry{Command: cmd.CommandPath(), Args: redactArgs(cmd, os.Args[1:]), Flags: make(map[string]string)}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		entry.Flags[flag.Name] = audit.Redact(flag.Name, flag.Value.String())
+	})
+
+	if file := entry.Flags["file"]; file != "" {
+		entry.ConfigFile = file
+		// Errors reading the config file are reported by the command itself.
+		if contents, err := utils.System.ReadFile(file); err == nil {
+			entry.ConfigSHA256 = fmt.Sprintf("%x", sha256.Sum256(contents))
+
+			if params, err := parseParams(bytes.NewReader(contents)); err == nil {
+				entry.Config = make(map[string]string)
+				for name, value := range params {
+					entry.Config[name] = audit.Redact(name, value)
+				}
+			}
+		}
+	}
+
+	err := audit.RecordInvocation(entry)
+	if err != nil {
+		log.Printf("Warning: recording invocation in the audit log: %v", err)
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: recording invocation in the audit log: %v\n", err)
+	}
+
+	return nil
+}
+
+// redactArgs redacts the values of flags which may contain secrets from the
+// command line arguments.
+func redactArgs(cmd *cobra.Command, args []string) []string {
+	redacted := make([]string, len(args))
+	copy(redacted, args)
+
+	for i := 0; i < len(redacted); i++ {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(redacted[i], "-"), "=")
+		if !strings.HasPrefix(redacted[i], "--") || cmd.Flags().Lookup(name) == nil {
+			continue
+		}
+
+		if hasValue {
+			redacted[i] = "--" + name + "=" + audit.Redact(name, value)
+			continue
+		}
+
+		if i+1 < len(redacted) && audit.Redact(name, redacted[i+1]) != redacted[i+1] {
+			redacted[i+1] = audit.Redact(name, redacted[i+1])
+			i++
+		}
+	}
+
+	return redacted
+}
+
 //////////////////////////// Commands //////////////////////////////////////////
 
 var configCmd = &cobra.Command{
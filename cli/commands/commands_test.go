@@ -4,8 +4,17 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gpupgrade/audit"
 	"github.com/greenplum-db/gpupgrade/config"
 	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/testutils"
@@ -74,3 +83,85 @@ func TestGetHubPort(t *testing.T) {
 	})
 
 }
+
+func TestRecordInvocation(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	newCommand := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "initialize"}
+		cmd.Flags().String("file", "", "")
+		cmd.Flags().String("notification-webhooks", "", "")
+		return cmd
+	}
+
+	t.Run("records the config file contents and flags with secrets redacted", func(t *testing.T) {
+		defer audit.Reset()
+		defer testutils.MustRemoveAll(t, audit.Path())
+
+		configFile := filepath.Join(stateDir, "gpupgrade_config")
+		testutils.MustWriteToFile(t, configFile, `
+source_gphome = /usr/local/gpdb5
+notification_webhooks = https://hooks.example.com/T0/B0/secret
+`)
+
+		args := os.Args
+		os.Args = []string{"gpupgrade", "initialize", "--file", configFile, "--notification-webhooks", "https://hooks.example.com/T1/B1/secret"}
+		defer func() { os.Args = args }()
+
+		cmd := newCommand()
+		if err := cmd.ParseFlags(os.Args[2:]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := recordInvocation(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		contents := testutils.MustReadFile(t, audit.Path())
+		if strings.Contains(contents, "secret") {
+			t.Errorf("expected audit log %q to redact the webhooks", contents)
+		}
+
+		var entry audit.Entry
+		if err := json.Unmarshal([]byte(contents), &entry); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := map[string]string{"source_gphome": "/usr/local/gpdb5", "notification_webhooks": "<redacted>"}
+		if !reflect.DeepEqual(entry.Config, expected) {
+			t.Errorf("got config %v want %v", entry.Config, expected)
+		}
+
+		if entry.Flags["notification-webhooks"] != "<redacted>" || entry.ConfigSHA256 == "" {
+			t.Errorf("got entry %+v", entry)
+		}
+	})
+
+	t.Run("warns rather than failing the command when the invocation cannot be recorded", func(t *testing.T) {
+		defer audit.Reset()
+		defer testutils.MustRemoveAll(t, audit.Path())
+
+		// Make the audit log a directory such that it cannot be opened.
+		if err := os.Mkdir(audit.Path(), 0700); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		cmd := newCommand()
+		stderr := new(bytes.Buffer)
+		cmd.SetErr(stderr)
+
+		if err := recordInvocation(cmd, nil); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if !strings.Contains(stderr.String(), "Warning: recording invocation in the audit log") {
+			t.Errorf("expected stderr %q to contain a warning", stderr.String())
+		}
+	})
+}
@@ -61,8 +61,8 @@ To use the upgraded cluster:
 If you have not already, execute the “%s” data migration scripts with
 "gpupgrade apply --gphome %s --port %d --input-dir %s --phase %s"
 
-If you postponed creating optimizer statistics run
-"vacuumdb --all --analyze-in-stages"`
+If you postponed creating optimizer statistics or they were interrupted run
+"gpupgrade analyze --gphome %s --port %d"`
 
 var RevertCompletedText = `
 The source cluster is now running version %s.
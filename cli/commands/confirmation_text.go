@@ -13,17 +13,30 @@ You are about to initialize a major-version upgrade of Greenplum.
 gpupgrade log files can be found on all hosts in %s
 
 gpupgrade initialize will use these values from %s
-source_master_port:   %d
-source_gphome:        %s
-target_gphome:        %s
-mode:                 %s
-disk_free_ratio:      %.1f
-pg_upgrade_jobs:      %d
-use_hba_hostnames:    %t
-dynamic_library_path: %s
-temp_port_range:      %s
-hub_port:             %d
-agent_port:           %d
+source_master_port:    %d
+source_gphome:         %s
+target_gphome:         %s
+mode:                  %s
+skip_disk_space_check: %t
+pg_upgrade_jobs:       %d
+use_hba_hostnames:     %t
+dynamic_library_path:  %s
+temp_port_range:       %s
+hub_port:              %d
+agent_port:            %d
+substep_timeouts:      %s
+notification_webhooks: %s
+notification_command:  %s
+notification_events:   %s
+notification_retries:  %d
+tablespace_mapping:    %s
+address_family:        %s
+mirror_upgrade_method: %s
+mirror_layout:         %s
+add_mirrors:           %s
+add_standby:           %s
+disk_warn_threshold:   %s
+disk_abort_threshold:  %s
 
 You will still have the opportunity to revert the cluster to its original state 
 after this step.
@@ -18,9 +18,11 @@ import (
 
 func dataMigrationGenerate() *cobra.Command {
 	var nonInteractive bool
+	var answersFile string
 	var gphome string
 	var port int
 	var seedDir string
+	var customSeedDirs []string
 	var outputDir string
 
 	logDir, err := utils.GetLogDir()
@@ -37,28 +39,42 @@ func dataMigrationGenerate() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			outputDir = filepath.Clean(outputDir)
 			seedDir = filepath.Clean(seedDir)
-			return commanders.GenerateDataMigrationScripts(step.StdStreams, nonInteractive, filepath.Clean(gphome), port, seedDir, outputDir, utils.System.DirFS(outputDir))
+			for i, dir := range customSeedDirs {
+				customSeedDirs[i] = filepath.Clean(dir)
+			}
+
+			answers, err := parseAnswersFile(answersFile)
+			if err != nil {
+				return err
+			}
+
+			return commanders.GenerateDataMigrationScripts(step.StdStreams, nonInteractive, answers, filepath.Clean(gphome), port, seedDir, customSeedDirs, outputDir, utils.System.DirFS(outputDir))
 		},
 	}
 
 	dataMigrationGenerator.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt to proceed")
 	dataMigrationGenerator.Flags().MarkHidden("non-interactive") //nolint
+	dataMigrationGenerator.Flags().StringVar(&answersFile, "answers-file", "", "JSON file answering each prompt such that the command runs unattended")
 	dataMigrationGenerator.Flags().StringVar(&gphome, "gphome", "", "path to the Greenplum installation")
 	dataMigrationGenerator.Flags().IntVar(&port, "port", 0, "master port for Greenplum cluster")
 	dataMigrationGenerator.Flags().StringVar(&outputDir, "output-dir", outputDir, "output path to the current generated data migration SQL files. Defaults to $HOME/gpAdminLogs/gpupgrade/data-migration-scripts")
 	// seed-dir is a hidden flag used for internal testing.
 	dataMigrationGenerator.Flags().StringVar(&seedDir, "seed-dir", utils.GetDataMigrationSeedDir(), "path to the seed scripts")
 	dataMigrationGenerator.Flags().MarkHidden("seed-dir") //nolint
+	dataMigrationGenerator.Flags().StringSliceVar(&customSeedDirs, "custom-seed-dir", nil, "path to custom seed scripts laid out like the built-in seed scripts. Can be specified multiple times")
 
 	return addHelpToCommand(dataMigrationGenerator, generateHelp)
 }
 
 func dataMigrationApply() *cobra.Command {
 	var nonInteractive bool
+	var answersFile string
 	var gphome string
 	var port int
 	var inputDir string
 	var phase string
+	var transactional bool
+	var dryRun bool
 
 	logDir, err := utils.GetLogDir()
 	if err != nil {
@@ -77,8 +93,22 @@ func dataMigrationApply() *cobra.Command {
 				return err
 			}
 
+			mode := commanders.NonTransactional
+			if transactional {
+				mode = commanders.Transactional
+			}
+
+			if dryRun {
+				mode = commanders.DryRun
+			}
+
+			answers, err := parseAnswersFile(answersFile)
+			if err != nil {
+				return err
+			}
+
 			currentDir := filepath.Join(filepath.Clean(inputDir), "current")
-			err = commanders.ApplyDataMigrationScripts(step.StdStreams, nonInteractive, filepath.Clean(gphome), port, logDir, utils.System.DirFS(currentDir), currentDir, parsedPhase)
+			err = commanders.ApplyDataMigrationScripts(step.StdStreams, nonInteractive, answers, filepath.Clean(gphome), port, logDir, utils.System.DirFS(currentDir), currentDir, parsedPhase, mode)
 			if err != nil {
 				return err
 			}
@@ -89,14 +119,28 @@ func dataMigrationApply() *cobra.Command {
 
 	dataMigrationExecutor.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt to proceed")
 	dataMigrationExecutor.Flags().MarkHidden("non-interactive") //nolint
+	dataMigrationExecutor.Flags().StringVar(&answersFile, "answers-file", "", "JSON file answering each prompt such that the command runs unattended")
 	dataMigrationExecutor.Flags().StringVar(&gphome, "gphome", "", "path to the Greenplum installation")
 	dataMigrationExecutor.Flags().IntVar(&port, "port", 0, "master port for Greenplum cluster")
 	dataMigrationExecutor.Flags().StringVar(&inputDir, "input-dir", inputDir, "path to the generated data migration SQL files. Defaults to $HOME/gpAdminLogs/gpupgrade/data-migration-scripts")
 	dataMigrationExecutor.Flags().StringVar(&phase, "phase", "", `data migration phase. Either "pre-initialize", "post-finalize", "post-revert", or "stats".`)
+	dataMigrationExecutor.Flags().BoolVar(&transactional, "transactional", false, "apply each script in a single transaction")
+	dataMigrationExecutor.Flags().BoolVar(&dryRun, "dry-run", false, "apply each script in a single transaction that is always rolled back")
+	dataMigrationExecutor.MarkFlagsMutuallyExclusive("transactional", "dry-run")
 
 	return addHelpToCommand(dataMigrationExecutor, applyHelp)
 }
 
+// parseAnswersFile returns no answers when the answers file is not set such
+// that the user is prompted instead.
+func parseAnswersFile(path string) (*commanders.Answers, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	return commanders.LoadAnswers(filepath.Clean(path))
+}
+
 func parsePhase(input string) (idl.Step, error) {
 	inputPhase := idl.Step_value[strings.TrimSpace(input)]
 
@@ -0,0 +1,89 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+func diff() *cobra.Command {
+	var configFile string
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "compares the upgraded target cluster against the source cluster",
+		Long:  DiffHelp,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			cmd.SilenceUsage = true
+
+			if configFile == "" {
+				configFile = config.GetConfigFile()
+			}
+
+			conf, err := config.ReadFrom(configFile)
+			if err != nil {
+				return xerrors.Errorf("read configuration: %w", err)
+			}
+
+			db, err := sql.Open("pgx", conf.Target.Connection())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if cErr := db.Close(); cErr != nil {
+					err = errorlist.Append(err, cErr)
+				}
+			}()
+
+			drift, err := greenplum.DiffTarget(db, conf.Target, conf.Source, conf.Intermediate, conf.UpgradeID, hub.ArchivedSourceConfFile(conf.Intermediate), conf.TablespaceMappings)
+			if err != nil {
+				return err
+			}
+
+			if len(drift) == 0 {
+				fmt.Println("The target cluster matches the source cluster.")
+				return nil
+			}
+
+			printTopologyDrift(os.Stdout, drift)
+			return xerrors.Errorf("found %d differences between the target and source clusters", len(drift))
+		},
+	}
+
+	cmd.Flags().StringVar(&configFile, "config", "", "configuration file of the upgrade. Defaults to the one in the state directory.")
+
+	return addHelpToCommand(cmd, DiffHelp)
+}
+
+func printTopologyDrift(w io.Writer, drift []string) {
+	fmt.Fprintln(w, "The target cluster differs from the source cluster:")
+	for _, difference := range drift {
+		fmt.Fprintf(w, "  - %s\n", difference)
+	}
+	fmt.Fprintln(w)
+}
+
+// archiveConfig copies the configuration into dir so the target cluster can
+// still be compared with "gpupgrade diff" after the state directory is gone.
+func archiveConfig(dir string) error {
+	contents, err := utils.System.ReadFile(config.GetConfigFile())
+	if err != nil {
+		return err
+	}
+
+	return utils.System.WriteFile(filepath.Join(dir, config.ConfigFileName), contents, 0600)
+}
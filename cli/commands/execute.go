@@ -4,6 +4,7 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -18,6 +19,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/utils"
 )
 
@@ -26,6 +28,7 @@ func execute() *cobra.Command {
 	var pgUpgradeVerbose bool
 	var skipPgUpgradeChecks bool
 	var nonInteractive bool
+	var answersFile string
 	var parentBackupDirs string
 
 	cmd := &cobra.Command{
@@ -57,9 +60,14 @@ func execute() *cobra.Command {
 
 			confirmationText := fmt.Sprintf(executeConfirmationText, revertWarning,
 				cases.Title(language.English).String(idl.Step_execute.String()),
-				executeSubsteps, logdir)
+				substeps.Steps[idl.Step_execute], logdir)
 
-			st, err := clistep.Begin(idl.Step_execute, verbose, nonInteractive, confirmationText)
+			answers, err := parseAnswersFile(answersFile)
+			if err != nil {
+				return err
+			}
+
+			st, err := clistep.Begin(idl.Step_execute, verbose, nonInteractive, answers, confirmationText)
 			if err != nil {
 				if errors.Is(err, step.Quit) {
 					// If user cancels don't return an error to main to avoid
@@ -70,7 +78,7 @@ func execute() *cobra.Command {
 			}
 
 			intermediate := &greenplum.Cluster{}
-			st.RunHubSubstep(func(streams step.OutStreams) error {
+			st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
 				client, err := connectToHub()
 				if err != nil {
 					return err
@@ -81,7 +89,7 @@ func execute() *cobra.Command {
 					SkipPgUpgradeChecks: skipPgUpgradeChecks,
 					ParentBackupDirs:    parentBackupDirs,
 				}
-				response, err = commanders.Execute(client, request, verbose)
+				response, err = commanders.Execute(ctx, client, request, verbose)
 				if err != nil {
 					return err
 				}
@@ -107,6 +115,7 @@ func execute() *cobra.Command {
 	cmd.Flags().MarkHidden("skip-pg-upgrade-checks") //nolint
 	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt for confirmation to proceed")
 	cmd.Flags().MarkHidden("non-interactive") //nolint
+	cmd.Flags().StringVar(&answersFile, "answers-file", "", "JSON file answering each prompt such that the command runs unattended")
 	cmd.Flags().StringVar(&parentBackupDirs, "parent-backup-dirs", "", "parent directories on each host to internally store the backup of the coordinator data directory and user defined coordinator tablespaces."+
 		"Defaults to the parent directory of each primary data directory on each primary host."+
 		"To specify a single directory across all hosts set a single directory such as /dir."+
@@ -4,19 +4,25 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
+	"github.com/greenplum-db/gpupgrade/audit"
 	"github.com/greenplum-db/gpupgrade/cli/clistep"
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
+	"github.com/greenplum-db/gpupgrade/config"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/statistics"
 	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
 )
@@ -24,6 +30,8 @@ import (
 func finalize() *cobra.Command {
 	var verbose bool
 	var nonInteractive bool
+	var answersFile string
+	var analyzeFlags analyzeFlags
 
 	cmd := &cobra.Command{
 		Use:   "finalize",
@@ -32,6 +40,11 @@ func finalize() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			var response *idl.FinalizeResponse
 
+			analyzeOptions, err := analyzeFlags.options()
+			if err != nil {
+				return err
+			}
+
 			logdir, err := utils.GetLogDir()
 			if err != nil {
 				return err
@@ -39,9 +52,14 @@ func finalize() *cobra.Command {
 
 			confirmationText := fmt.Sprintf(finalizeConfirmationText,
 				cases.Title(language.English).String(idl.Step_finalize.String()),
-				finalizeSubsteps, logdir)
+				substeps.Steps[idl.Step_finalize], logdir)
+
+			answers, err := parseAnswersFile(answersFile)
+			if err != nil {
+				return err
+			}
 
-			st, err := clistep.Begin(idl.Step_finalize, verbose, nonInteractive, confirmationText)
+			st, err := clistep.Begin(idl.Step_finalize, verbose, nonInteractive, answers, confirmationText)
 			if err != nil {
 				if errors.Is(err, step.Quit) {
 					// If user cancels don't return an error to main to avoid
@@ -52,13 +70,13 @@ func finalize() *cobra.Command {
 			}
 
 			target := &greenplum.Cluster{}
-			st.RunHubSubstep(func(streams step.OutStreams) error {
+			st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
 				client, err := connectToHub()
 				if err != nil {
 					return err
 				}
 
-				response, err = commanders.Finalize(client, verbose)
+				response, err = commanders.Finalize(ctx, client, verbose)
 				if err != nil {
 					return err
 				}
@@ -81,17 +99,26 @@ func finalize() *cobra.Command {
 				}
 
 				currentDir := filepath.Join(response.GetLogArchiveDirectory(), "data-migration-scripts", "current")
-				return commanders.ApplyDataMigrationScripts(streams, nonInteractive, target.GPHome, target.CoordinatorPort(),
-					response.GetLogArchiveDirectory(), utils.System.DirFS(currentDir), currentDir, idl.Step_finalize)
+				return commanders.ApplyDataMigrationScripts(streams, nonInteractive, answers, target.GPHome, target.CoordinatorPort(),
+					response.GetLogArchiveDirectory(), utils.System.DirFS(currentDir), currentDir, idl.Step_finalize, commanders.NonTransactional)
 			})
 
 			st.Run(idl.Substep_analyze_target_cluster, func(streams step.OutStreams) error {
-				if !nonInteractive {
+				if answers != nil {
+					analyze, err := answers.Analyze()
+					if err != nil {
+						return err
+					}
+
+					if !analyze {
+						return nil
+					}
+				} else if !nonInteractive {
 					fmt.Println()
 					fmt.Println(`
 It is strongly recommended to create optimizer statistics to ensure performant operations. 
 However, this could take quite awhile and you may need your cluster now.
-If you postpone creating statistics then after the upgrade run "vacuumdb --all --analyze-in-stages".`)
+If you postpone creating statistics then after the upgrade run "gpupgrade analyze".`)
 					fmt.Println()
 
 					prompt := "Create optimizer statistics now?  Yy|Nn: "
@@ -104,7 +131,7 @@ If you postpone creating statistics then after the upgrade run "vacuumdb --all -
 					}
 				}
 
-				return target.RunGreenplumCmd(streams, "vacuumdb", "--all", "--analyze-only")
+				return statistics.Analyze(step.Context(streams), streams.Stdout(), clusterConnector(target), analyzeOptions)
 			})
 
 			st.Run(idl.Substep_delete_master_statedir, func(streams step.OutStreams) error {
@@ -112,9 +139,36 @@ If you postpone creating statistics then after the upgrade run "vacuumdb --all -
 				// Disable the store so the step framework does not try to write
 				// to a non-existent status file.
 				st.DisableStore()
+
+				// Keep the audit log with the archived logs.
+				err := audit.Archive(response.GetLogArchiveDirectory())
+				if err != nil {
+					return err
+				}
+
+				err = archiveConfig(response.GetLogArchiveDirectory())
+				if err != nil {
+					return err
+				}
+
 				return upgrade.DeleteDirectories([]string{utils.GetStateDir()}, upgrade.StateDirectoryFiles, streams)
 			})
 
+			if rules := response.GetUnmigratablePgHbaRules(); len(rules) > 0 {
+				fmt.Println()
+				fmt.Println("The following source cluster pg_hba.conf rules were not carried over to the target cluster:")
+				for _, rule := range rules {
+					fmt.Printf("  - %s\n", rule)
+				}
+			}
+
+			if drift := response.GetTopologyDrift(); len(drift) > 0 {
+				fmt.Println()
+				printTopologyDrift(os.Stdout, drift)
+				fmt.Printf("After resolving the differences re-check with \"gpupgrade diff --config %s\"\n",
+					filepath.Join(response.GetLogArchiveDirectory(), config.ConfigFileName))
+			}
+
 			return st.Complete(fmt.Sprintf(FinalizeCompletedText,
 				target.Version,
 				fmt.Sprintf("%s.<contentID>%s", response.GetUpgradeID(), upgrade.OldSuffix),
@@ -127,6 +181,7 @@ If you postpone creating statistics then after the upgrade run "vacuumdb --all -
 				target.CoordinatorPort(),
 				idl.Step_finalize,
 				target.GPHome, target.CoordinatorPort(), filepath.Join(response.GetLogArchiveDirectory(), "data-migration-scripts"), idl.Step_finalize,
+				target.GPHome, target.CoordinatorPort(),
 			))
 		},
 	}
@@ -134,5 +189,7 @@ If you postpone creating statistics then after the upgrade run "vacuumdb --all -
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "print the output stream from all substeps")
 	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt for confirmation to proceed")
 	cmd.Flags().MarkHidden("non-interactive") //nolint
+	cmd.Flags().StringVar(&answersFile, "answers-file", "", "JSON file answering each prompt such that the command runs unattended")
+	analyzeFlags.register(cmd.Flags(), "analyze-")
 	return addHelpToCommand(cmd, FinalizeHelp)
 }
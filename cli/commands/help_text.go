@@ -16,10 +16,6 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils"
 )
 
-var initializeSubsteps substeps.Substeps
-var executeSubsteps substeps.Substeps
-var finalizeSubsteps substeps.Substeps
-var revertSubsteps substeps.Substeps
 var InitializeHelp string
 var ExecuteHelp string
 var FinalizeHelp string
@@ -33,81 +29,10 @@ func init() {
 		panic(fmt.Sprintf("failed to get log directory: %v", err))
 	}
 
-	initializeSubsteps = substeps.Substeps{
-		idl.Substep_verify_gpdb_versions,
-		idl.Substep_saving_source_cluster_config,
-		idl.Substep_start_hub,
-		idl.Substep_generate_data_migration_scripts,
-		idl.Substep_execute_stats_data_migration_scripts,
-		idl.Substep_execute_initialize_data_migration_scripts,
-		idl.Substep_verify_gpupgrade_is_installed_across_all_hosts,
-		idl.Substep_start_agents,
-		idl.Substep_check_environment,
-		idl.Substep_create_backupdirs,
-		idl.Substep_check_disk_space,
-		idl.Substep_generate_target_config,
-		idl.Substep_init_target_cluster,
-		idl.Substep_setting_dynamic_library_path_on_target_cluster,
-		idl.Substep_shutdown_target_cluster,
-		idl.Substep_backup_target_master,
-		idl.Substep_initialize_wait_for_cluster_to_be_ready,
-		idl.Substep_check_upgrade,
-	}
-
-	executeSubsteps = substeps.Substeps{
-		idl.Substep_ensure_gpupgrade_agents_are_running,
-		idl.Substep_check_active_connections_on_source_cluster,
-		idl.Substep_wait_for_cluster_to_be_ready_before_upgrade_master,
-		idl.Substep_shutdown_source_cluster,
-		idl.Substep_upgrade_master,
-		idl.Substep_copy_master,
-		idl.Substep_upgrade_primaries,
-		idl.Substep_start_target_cluster,
-	}
-
-	finalizeSubsteps = substeps.Substeps{
-		idl.Substep_ensure_gpupgrade_agents_are_running,
-		idl.Substep_check_active_connections_on_target_cluster,
-		idl.Substep_upgrade_mirrors,
-		idl.Substep_upgrade_standby,
-		idl.Substep_wait_for_cluster_to_be_ready_after_adding_mirrors_and_standby,
-		idl.Substep_shutdown_target_cluster,
-		idl.Substep_update_target_catalog,
-		idl.Substep_update_data_directories,
-		idl.Substep_update_target_conf_files,
-		idl.Substep_start_target_cluster,
-		idl.Substep_wait_for_cluster_to_be_ready_after_updating_catalog,
-		idl.Substep_archive_log_directories,
-		idl.Substep_delete_backupdir,
-		idl.Substep_delete_segment_statedirs,
-		idl.Substep_stop_hub_and_agents,
-		idl.Substep_execute_finalize_data_migration_scripts,
-		idl.Substep_analyze_target_cluster,
-		idl.Substep_delete_master_statedir,
-	}
-
-	revertSubsteps = substeps.Substeps{
-		idl.Substep_ensure_gpupgrade_agents_are_running,
-		idl.Substep_check_active_connections_on_target_cluster,
-		idl.Substep_shutdown_target_cluster,
-		idl.Substep_delete_target_cluster_datadirs,
-		idl.Substep_delete_tablespaces,
-		idl.Substep_restore_pgcontrol,
-		idl.Substep_restore_source_cluster,
-		idl.Substep_start_source_cluster,
-		idl.Substep_recoverseg_source_cluster,
-		idl.Substep_archive_log_directories,
-		idl.Substep_delete_backupdir,
-		idl.Substep_delete_segment_statedirs,
-		idl.Substep_stop_hub_and_agents,
-		idl.Substep_execute_revert_data_migration_scripts,
-		idl.Substep_delete_master_statedir,
-	}
-
-	InitializeHelp = fmt.Sprintf(initializeHelpText, cases.Title(language.English).String(idl.Step_initialize.String()), initializeSubsteps, logDir)
-	ExecuteHelp = fmt.Sprintf(executeHelpText, cases.Title(language.English).String(idl.Step_execute.String()), executeSubsteps, logDir)
-	FinalizeHelp = fmt.Sprintf(finalizeHelpText, cases.Title(language.English).String(idl.Step_finalize.String()), finalizeSubsteps, logDir)
-	RevertHelp = fmt.Sprintf(revertHelpText, cases.Title(language.English).String(idl.Step_revert.String()), revertSubsteps, logDir)
+	InitializeHelp = fmt.Sprintf(initializeHelpText, cases.Title(language.English).String(idl.Step_initialize.String()), substeps.Steps[idl.Step_initialize], logDir)
+	ExecuteHelp = fmt.Sprintf(executeHelpText, cases.Title(language.English).String(idl.Step_execute.String()), substeps.Steps[idl.Step_execute], logDir)
+	FinalizeHelp = fmt.Sprintf(finalizeHelpText, cases.Title(language.English).String(idl.Step_finalize.String()), substeps.Steps[idl.Step_finalize], logDir)
+	RevertHelp = fmt.Sprintf(revertHelpText, cases.Title(language.English).String(idl.Step_revert.String()), substeps.Steps[idl.Step_revert], logDir)
 	GlobalHelp = fmt.Sprintf(globalHelpText, logDir)
 
 	Help = map[idl.Step]string{
@@ -138,6 +63,8 @@ Optional Flags:
   -h, --help                 displays help output for initialize
   -v, --verbose              outputs detailed logs for initialize
       --pg-upgrade-verbose   execute pg_upgrade with verbose internal logging. Requires the verbose flag.
+      --answers-file         JSON file answering each prompt such that initialize runs unattended.
+                             See "gpupgrade generate --help" for the format.
 
 gpupgrade log files can be found on all hosts in %s
 `
@@ -161,6 +88,7 @@ Optional Flags:
                              master data directory and user defined master tablespaces. Defaults to the 
                              parent directory of the master data directory such as /data given 
                              /data/master/gpseg-1.
+      --answers-file         JSON file answering each prompt such that execute runs unattended.
 
 gpupgrade log files can be found on all hosts in %s
 `
@@ -177,8 +105,12 @@ Usage: gpupgrade finalize
 
 Optional Flags:
 
-  -h, --help      displays help output for finalize
-  -v, --verbose   outputs detailed logs for finalize
+  -h, --help           displays help output for finalize
+  -v, --verbose        outputs detailed logs for finalize
+      --answers-file   JSON file answering each prompt such that finalize runs unattended.
+      --analyze-jobs, --analyze-priority, --analyze-table-list, --analyze-in-stages
+                       control how optimizer statistics are created. See
+                       "gpupgrade analyze --help".
 
 NOTE: After running finalize, you must execute data migration scripts. 
 Refer to documentation for instructions.
@@ -196,8 +128,9 @@ Usage: gpupgrade revert
 
 Optional Flags:
 
-  -h, --help      displays help output for revert
-  -v, --verbose   outputs detailed logs for revert
+  -h, --help           displays help output for revert
+  -v, --verbose        outputs detailed logs for revert
+      --answers-file   JSON file answering each prompt such that revert runs unattended.
 
 NOTE: After running revert, you must execute data migration scripts. 
 Refer to documentation for instructions.
@@ -223,8 +156,30 @@ Required Flags:
 
 Optional Flags:
 
-  --output-dir    output path to the current generated data migration SQL files. 
-                  Defaults to $HOME/gpAdminLogs/gpupgrade/data-migration-scripts
+  --output-dir       output path to the current generated data migration SQL files. 
+                     Defaults to $HOME/gpAdminLogs/gpupgrade/data-migration-scripts
+  --custom-seed-dir  path to custom seed scripts which are generated alongside the 
+                     built-in seed scripts. The directory is laid out by phase such as
+                     <custom-seed-dir>/initialize/<script_dir>/<script>.sql with an 
+                     optional <script_dir>/description file. Can be specified multiple times.
+  --answers-file     JSON file answering each prompt such that the data migration 
+                     commands and steps run unattended. Only JSON is supported. Every 
+                     prompt encountered must be answered. Scripts are selected by name
+                     per phase and must have been generated. Initialize prompts again
+                     after applying its scripts which is answered by 
+                     "continue_after_scripts". For example:
+
+                       {
+                         "continue": {"initialize": true, "execute": true},
+                         "continue_after_scripts": {"initialize": true},
+                         "archive_scripts": true,
+                         "scripts": {
+                           "stats": "all",
+                           "initialize": ["gphdfs_user_roles"],
+                           "finalize": "none"
+                         },
+                         "analyze_target_cluster": true
+                       }
 `
 const applyHelp = `
 Applies data migration SQL scripts to resolve catalog inconsistencies between 
@@ -243,9 +198,127 @@ Required Flags:
 
 Optional Flags:
 
-  --input-dir    path to the generated data migration SQL files. 
-                 Defaults to $HOME/gpAdminLogs/gpupgrade/data-migration-scripts
+  --input-dir      path to the generated data migration SQL files. 
+                   Defaults to $HOME/gpAdminLogs/gpupgrade/data-migration-scripts
+  --transactional  apply each script in a single transaction such that a failing
+                   script leaves no partial changes. Scripts which cannot run in a 
+                   transaction such as CREATE INDEX CONCURRENTLY are reported and 
+                   applied without one.
+  --dry-run        apply each script in a single transaction that is always rolled 
+                   back to verify the scripts succeed. Scripts which cannot run in a
+                   transaction are reported and skipped. Cannot be combined with
+                   --transactional.
+  --answers-file   JSON file selecting the scripts to apply by name per phase 
+                   without prompting. See "gpupgrade generate --help" for the format.
+`
+const WatchHelp = `
+Follows the progress of the current gpupgrade step. Shows the status of each
+substep, the latest request to the agent on each host, and the most recent
+output. The progress of the step so far is replayed on attaching.
+
+Detaching with Ctrl-C does not affect the running step. Multiple terminals may
+watch at the same time.
+
+Usage: gpupgrade watch
+
+Optional Flags:
+
+  -h, --help        displays help output for watch
+      --log-lines   number of recent output lines to display. Defaults to 15.
+`
+
+const AnalyzeHelp = `
+Creates optimizer statistics on the upgraded cluster. Finalize runs this when
+creating statistics is accepted. Databases are analyzed in parallel and the
+tables within each database in priority order.
+
+Each analyzed table is recorded in the ledger analyze_ledger.json in the
+gpupgrade log directory. An interrupted run resumes where it stopped when run
+again. The ledger is removed once every database has been analyzed.
+
+Usage: gpupgrade analyze --gphome "$GPHOME" --port "$PGPORT"
+
+Required Flags:
+
+  --gphome       path to the Greenplum installation
+  --port         master port for Greenplum cluster
+
+Optional Flags:
+
+  -h, --help         displays help output for analyze
+      --jobs         number of databases to analyze in parallel. Defaults to 4.
+      --priority     order to analyze tables within a database. Either "size" 
+                     for the largest tables first, "usage" for the most scanned
+                     tables first, or "list" for the tables in --table-list 
+                     first followed by the rest by size. Defaults to "size".
+      --table-list   file of database.schema.table names, one per line, to 
+                     analyze first when the priority is "list".
+      --in-stages    quickly create minimal statistics on every table before 
+                     refining them in two more passes, similar to
+                     "vacuumdb --analyze-in-stages".
+`
+
+const DiffHelp = `
+Compares the upgraded target cluster against the source cluster it was upgraded
+from. Reports differences in the segment configuration, such as a segment left
+on a temporary port or a data directory still carrying the upgrade ID, along
+with moved tablespaces and changed coordinator settings.
+
+Finalize runs the same comparison. Since finalize deletes the state directory
+it archives the configuration with the logs for use with --config.
+
+Usage: gpupgrade diff [--config <path/to/config.json>]
+
+Optional Flags:
+
+  -h, --help        displays help output for diff
+      --config      configuration file of the upgrade. Defaults to the one in
+                    the state directory.
 `
+
+const PlanHelp = `
+Previews the changes finalize makes to the target cluster without making them.
+Run after "gpupgrade initialize" while the hub is running.
+
+Shows the changes to pg_hba.conf on the master and primary segments as the
+client access rules of the source cluster are translated for the target
+version and merged with the entries the target cluster requires. Lines prefixed
+with "-" are removed and those with "+" are added. Rules that cannot be carried
+over are listed with the reason. The standby and mirrors receive the same rules
+once finalize creates them.
+
+Usage: gpupgrade plan
+
+Optional Flags:
+
+  -h, --help        displays help output for plan
+`
+
+const AuditHelp = `
+Inspects the audit log recording who ran each gpupgrade command, the step and
+substep transitions, and the destructive requests made to the agents.
+
+Usage: gpupgrade audit verify [--file <path/to/audit.log>]
+
+Optional Flags:
+
+  -h, --help        displays help output for audit
+`
+
+const AuditVerifyHelp = `
+Checks that no entries of the audit log have been modified, removed, or
+reordered. The audit log is verified using the audit.key and audit.log.head
+files next to it. Finalize and revert archive all three with the logs.
+
+Usage: gpupgrade audit verify [--file <path/to/audit.log>]
+
+Optional Flags:
+
+  -h, --help        displays help output for audit verify
+      --file        the audit log to verify. Defaults to the one in the state
+                    directory.
+`
+
 const ConfigHelp = `
 The config subcommand allows one to view configuration parameters only after 
 initialize has started. It is useful for starting or connecting to the 
@@ -291,6 +364,18 @@ Optional Commands:
 
   apply           applies data migration SQL scripts
 
+  watch           follows the progress of the current step from any
+                  terminal on the master host
+
+  diff            compares the upgraded target cluster against the source
+                  cluster
+
+  analyze         creates optimizer statistics on the upgraded cluster
+
+  plan            previews the changes finalize makes to the target cluster
+
+  audit verify    checks that the audit log has not been tampered with
+
   config show     shows configuration parameters. 
                   One can only view the configuration parameters only 
                   after initialize has started. The config subcommand is
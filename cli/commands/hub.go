@@ -53,6 +53,8 @@ func Hub() *cobra.Command {
 			}
 
 			hubServer := hub.New(conf)
+			hubServer.Version = Version
+			hubServer.Commit = Commit
 			return hubServer.Start(conf.HubPort, shouldDaemonize)
 		},
 	}
@@ -4,6 +4,7 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -23,20 +24,25 @@ import (
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/greenplum/connection"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/notify"
 	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
 func initialize() *cobra.Command {
 	var file string
 	var nonInteractive bool
+	var answersFile string
 	var sourceGPHome, targetGPHome string
 	var sourcePort int
 	var hubPort int
 	var agentPort int
 	var parentBackupDirs string
+	var skipDiskSpaceCheck bool
 	var diskFreeRatio float64
 	var stopBeforeClusterCreation bool
 	var verbose bool
@@ -49,6 +55,19 @@ func initialize() *cobra.Command {
 	var useHbaHostnames bool
 	var dynamicLibraryPath string
 	var dataMigrationSeedDir string
+	var substepTimeouts string
+	var notificationWebhooks []string
+	var notificationCommand string
+	var notificationEvents string
+	var notificationRetries int
+	var tablespaceMapping string
+	var diskWarnThreshold string
+	var diskAbortThreshold string
+	var addressFamily string
+	var mirrorUpgradeMethod string
+	var mirrorLayout string
+	var addMirrors string
+	var addStandby string
 
 	subInit := &cobra.Command{
 		Use:   "initialize",
@@ -71,12 +90,12 @@ func initialize() *cobra.Command {
 			}
 
 			// If the file flag is set ensure no other flags are set except
-			// optionally verbose, pg-upgrade-verbose, and non-interactive.
+			// optionally verbose, pg-upgrade-verbose, non-interactive, and answers-file.
 			if cmd.Flag("file").Changed {
 				var err error
 				cmd.Flags().Visit(func(flag *pflag.Flag) {
-					if flag.Name != "file" && flag.Name != "verbose" && flag.Name != "pg-upgrade-verbose" && flag.Name != "non-interactive" {
-						err = errors.New("The file flag cannot be used with any other flag except verbose and non-interactive.")
+					if flag.Name != "file" && flag.Name != "verbose" && flag.Name != "pg-upgrade-verbose" && flag.Name != "non-interactive" && flag.Name != "answers-file" {
+						err = errors.New("The file flag cannot be used with any other flag except verbose, non-interactive, and answers-file.")
 					}
 				})
 				return err
@@ -127,20 +146,70 @@ func initialize() *cobra.Command {
 				return err
 			}
 
-			// if diskFreeRatio is not explicitly set, use defaults
-			if !cmd.Flag("disk-free-ratio").Changed {
-				diskFreeRatio = 0.2
-				if mode == idl.Mode_copy {
-					diskFreeRatio = 0.6
-				}
+			timeouts, err := step.ParseTimeouts(substepTimeouts)
+			if err != nil {
+				return err
 			}
 
-			if diskFreeRatio < 0.0 || diskFreeRatio > 1.0 {
-				// Match Cobra's option-error format.
-				return fmt.Errorf(
-					`invalid argument %g for "--disk-free-ratio" flag: value must be between 0.0 and 1.0`,
-					diskFreeRatio,
-				)
+			events, err := notify.ParseEvents(notificationEvents)
+			if err != nil {
+				return err
+			}
+
+			if notificationRetries < 0 {
+				return fmt.Errorf("expected notification_retries to be at least 0 got %d", notificationRetries)
+			}
+
+			notifications := notify.Config{
+				Webhooks: notificationWebhooks,
+				Command:  notificationCommand,
+				Events:   events,
+				Retries:  notificationRetries,
+			}
+
+			tablespaceMappings, err := greenplum.ParseTablespaceMappings(tablespaceMapping)
+			if err != nil {
+				return err
+			}
+
+			diskThresholds := disk.Thresholds{}
+			diskThresholds.Warn, err = disk.ParseThreshold(diskWarnThreshold)
+			if err != nil {
+				return err
+			}
+
+			diskThresholds.Abort, err = disk.ParseThreshold(diskAbortThreshold)
+			if err != nil {
+				return err
+			}
+
+			parsedAddressFamily, err := utils.ParseAddressFamily(addressFamily)
+			if err != nil {
+				return err
+			}
+
+			parsedMirrorUpgradeMethod, err := parseMirrorUpgradeMethod(mirrorUpgradeMethod)
+			if err != nil {
+				return err
+			}
+
+			parsedMirrorLayout, err := greenplum.ParseMirrorLayout(mirrorLayout)
+			if err != nil {
+				return err
+			}
+
+			parsedAddMirrors, err := greenplum.ParseMirrorLayout(addMirrors)
+			if err != nil {
+				return err
+			}
+
+			var parsedAddStandby *greenplum.MirrorPlacement
+			if strings.TrimSpace(addStandby) != "" {
+				placement, err := greenplum.ParseMirrorPlacement(addStandby)
+				if err != nil {
+					return err
+				}
+				parsedAddStandby = &placement
 			}
 
 			logdir, err := utils.GetLogDir()
@@ -167,10 +236,17 @@ func initialize() *cobra.Command {
 
 			confirmationText := fmt.Sprintf(initializeConfirmationText,
 				cases.Title(language.English).String(idl.Step_initialize.String()),
-				initializeSubsteps, logdir, configPath,
-				sourcePort, sourceGPHome, targetGPHome, mode, diskFreeRatio, pgUpgradeJobs, useHbaHostnames, dynamicLibraryPath, ports, hubPort, agentPort)
+				substeps.Steps[idl.Step_initialize], logdir, configPath,
+				sourcePort, sourceGPHome, targetGPHome, mode, skipDiskSpaceCheck, pgUpgradeJobs, useHbaHostnames, dynamicLibraryPath, ports, hubPort, agentPort, timeouts,
+				strings.Join(notificationWebhooks, ","), notificationCommand, events, notificationRetries, tablespaceMapping, parsedAddressFamily, parsedMirrorUpgradeMethod, parsedMirrorLayout, parsedAddMirrors, addStandby,
+				diskThresholds.Warn, diskThresholds.Abort)
+
+			answers, err := parseAnswersFile(answersFile)
+			if err != nil {
+				return err
+			}
 
-			st, err := clistep.Begin(idl.Step_initialize, verbose, nonInteractive, confirmationText)
+			st, err := clistep.Begin(idl.Step_initialize, verbose, nonInteractive, answers, confirmationText)
 			if err != nil {
 				return err
 			}
@@ -199,7 +275,7 @@ func initialize() *cobra.Command {
 					filepath.Clean(sourceGPHome),
 					filepath.Clean(targetGPHome),
 					mode, useHbaHostnames, parsedPorts, pgUpgradeJobs,
-					parentBackupDirs,
+					parentBackupDirs, timeouts, notifications, tablespaceMappings, parsedAddressFamily, parsedMirrorUpgradeMethod, parsedMirrorLayout, parsedAddMirrors, parsedAddStandby, diskThresholds,
 				)
 				if err != nil {
 					return err
@@ -222,7 +298,7 @@ func initialize() *cobra.Command {
 					return nil
 				}
 
-				return commanders.GenerateDataMigrationScripts(streams, nonInteractive, sourceGPHome, sourcePort, filepath.Clean(dataMigrationSeedDir), generatedScriptsOutputDir, utils.System.DirFS(generatedScriptsOutputDir))
+				return commanders.GenerateDataMigrationScripts(streams, nonInteractive, answers, sourceGPHome, sourcePort, filepath.Clean(dataMigrationSeedDir), nil, generatedScriptsOutputDir, utils.System.DirFS(generatedScriptsOutputDir))
 			})
 
 			st.AlwaysRun(idl.Substep_execute_stats_data_migration_scripts, func(streams step.OutStreams) error {
@@ -231,7 +307,7 @@ func initialize() *cobra.Command {
 				}
 
 				currentDir := filepath.Join(generatedScriptsOutputDir, "current")
-				return commanders.ApplyDataMigrationScripts(streams, nonInteractive, sourceGPHome, sourcePort, logdir, utils.System.DirFS(currentDir), currentDir, idl.Step_stats)
+				return commanders.ApplyDataMigrationScripts(streams, nonInteractive, answers, sourceGPHome, sourcePort, logdir, utils.System.DirFS(currentDir), currentDir, idl.Step_stats, commanders.NonTransactional)
 			})
 
 			st.AlwaysRun(idl.Substep_execute_initialize_data_migration_scripts, func(streams step.OutStreams) error {
@@ -240,28 +316,28 @@ func initialize() *cobra.Command {
 				}
 
 				currentDir := filepath.Join(filepath.Clean(generatedScriptsOutputDir), "current")
-				err = commanders.ApplyDataMigrationScripts(streams, nonInteractive, sourceGPHome, sourcePort,
-					logdir, utils.System.DirFS(currentDir), currentDir, idl.Step_initialize)
+				err = commanders.ApplyDataMigrationScripts(streams, nonInteractive, answers, sourceGPHome, sourcePort,
+					logdir, utils.System.DirFS(currentDir), currentDir, idl.Step_initialize, commanders.NonTransactional)
 				if err != nil {
 					return err
 				}
 
 				prompt := fmt.Sprintf("Continue with gpupgrade %s?  Yy|Nn: ", idl.Step_initialize)
-				return clistep.Prompt(utils.StdinReader, prompt)
+				return clistep.ConfirmAfterScripts(answers, idl.Step_initialize, utils.StdinReader, prompt)
 			})
 
 			var client idl.CliToHubClient
-			st.RunHubSubstep(func(streams step.OutStreams) error {
+			st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
 				client, err = connectToHub()
 				if err != nil {
 					return err
 				}
 
 				request := &idl.InitializeRequest{
-					DiskFreeRatio:    diskFreeRatio,
-					ParentBackupDirs: parentBackupDirs,
+					SkipDiskSpaceCheck: skipDiskSpaceCheck,
+					ParentBackupDirs:   parentBackupDirs,
 				}
-				err = commanders.Initialize(client, request, verbose)
+				err = commanders.Initialize(ctx, client, request, verbose)
 				if err != nil {
 					return err
 				}
@@ -270,7 +346,7 @@ func initialize() *cobra.Command {
 			})
 
 			var response *idl.InitializeResponse
-			st.RunHubSubstep(func(streams step.OutStreams) error {
+			st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
 				if stopBeforeClusterCreation {
 					return step.Skip
 				}
@@ -280,7 +356,7 @@ func initialize() *cobra.Command {
 					PgUpgradeVerbose:    pgUpgradeVerbose,
 					SkipPgUpgradeChecks: skipPgUpgradeChecks,
 				}
-				response, err = commanders.InitializeCreateCluster(client, request, verbose)
+				response, err = commanders.InitializeCreateCluster(ctx, client, request, verbose)
 				if err != nil {
 					return err
 				}
@@ -288,6 +364,14 @@ func initialize() *cobra.Command {
 				return nil
 			})
 
+			if settings := response.GetUnmigratableSettings(); len(settings) > 0 {
+				fmt.Println()
+				fmt.Println("The following source cluster settings were not carried over to the target cluster:")
+				for _, setting := range settings {
+					fmt.Printf("  - %s\n", setting)
+				}
+			}
+
 			revertWarning := ""
 			if !response.GetHasAllMirrorsAndStandby() && mode == idl.Mode_link {
 				revertWarning = revertWarningText
@@ -305,6 +389,7 @@ func initialize() *cobra.Command {
 	subInit.Flags().StringVarP(&file, "file", "f", "", "the configuration file to use")
 	subInit.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt for confirmation to proceed")
 	subInit.Flags().MarkHidden("non-interactive") //nolint
+	subInit.Flags().StringVar(&answersFile, "answers-file", "", "JSON file answering each prompt such that the command runs unattended")
 	subInit.Flags().IntVar(&sourcePort, "source-master-port", 0, "master port for source gpdb cluster")
 	subInit.Flags().StringVar(&sourceGPHome, "source-gphome", "", "path for the source Greenplum installation")
 	subInit.Flags().StringVar(&targetGPHome, "target-gphome", "", "path for the target Greenplum installation")
@@ -313,12 +398,27 @@ func initialize() *cobra.Command {
 		"Defaults to the parent directory of each primary data directory on each primary host."+
 		"To specify a single directory across all hosts set /dir."+
 		"To specify different directories for each host use the form \"host1:/dir1,host2:/dir2,host3:/dir3\" where the first host must be the coordinator.")
-	subInit.Flags().Float64Var(&diskFreeRatio, "disk-free-ratio", 0.60, "percentage of disk space that must be available (from 0.0 - 1.0)")
+	subInit.Flags().BoolVar(&skipDiskSpaceCheck, "skip-disk-space-check", false, "skip checking that each host has enough disk space for the upgrade based on the size of the cluster")
+	subInit.Flags().Float64Var(&diskFreeRatio, "disk-free-ratio", 0, "percentage of disk space that must be available (from 0.0 - 1.0)")
+	subInit.Flags().MarkDeprecated("disk-free-ratio", "the required disk space is now estimated from the size of the cluster. Use --skip-disk-space-check to skip the check.") //nolint
 	subInit.Flags().BoolVar(&useHbaHostnames, "use-hba-hostnames", false, "use hostnames in pg_hba.conf")
 	subInit.Flags().StringVar(&dynamicLibraryPath, "dynamic-library-path", upgrade.DefaultDynamicLibraryPath, "sets the dynamic_library_path GUC to correctly find extensions installed outside their default location. Defaults to '$dynamic_library_path'.")
 	subInit.Flags().StringVar(&ports, "temp-port-range", "50432-65535", "set of ports to use when initializing the target cluster")
 	subInit.Flags().IntVar(&hubPort, "hub-port", upgrade.DefaultHubPort, "the port gpupgrade hub uses to listen for commands on")
 	subInit.Flags().IntVar(&agentPort, "agent-port", upgrade.DefaultAgentPort, "the port gpupgrade agent uses to listen for commands on")
+	subInit.Flags().StringVar(&substepTimeouts, "substep-timeouts", "", "overrides how long substeps may run before they are canceled in the form \"substep=duration,...\" such as \"start_target_cluster=2h\". A duration of 0 disables the timeout.")
+	subInit.Flags().StringSliceVar(&notificationWebhooks, "notification-webhooks", nil, "webhook URLs the hub POSTs step and substep events to as JSON")
+	subInit.Flags().StringVar(&notificationCommand, "notification-command", "", "command the hub runs on step and substep events with the JSON event on stdin")
+	subInit.Flags().StringVar(&notificationEvents, "notification-events", "", fmt.Sprintf("comma separated events to notify of. Either %s. Defaults to %s.", notify.AllEvents, notify.DefaultEvents))
+	subInit.Flags().IntVar(&notificationRetries, "notification-retries", 3, "times to retry failed notifications")
+	subInit.Flags().StringVar(&diskWarnThreshold, "disk-warn-threshold", disk.DefaultThresholds.Warn.String(), "free space of a filesystem below which execute and finalize warn, either as a percentage of the space and inodes such as 10% or as a size such as 50GB")
+	subInit.Flags().StringVar(&diskAbortThreshold, "disk-abort-threshold", disk.DefaultThresholds.Abort.String(), "free space of a filesystem below which execute and finalize stop pg_upgrade and rsync, either as a percentage of the space and inodes such as 2% or as a size such as 10GB")
+	subInit.Flags().StringVar(&tablespaceMapping, "tablespace-mapping", "", "relocates user defined tablespaces in copy mode in the form \"[host:]old_location=new_location,...\" such as \"/data/tblspc=/ssd/tblspc\"")
+	subInit.Flags().StringVar(&addressFamily, "address-family", string(utils.AnyAddressFamily), "IP versions used to reach the hosts and in pg_hba.conf. Either any, ipv4, or ipv6.")
+	subInit.Flags().StringVar(&mirrorUpgradeMethod, "mirror-upgrade-method", idl.MirrorUpgradeMethod_auto.String(), "how finalize creates the target mirrors and standby. Either auto, rsync, gpaddmirrors, or pg_basebackup. auto uses rsync in link mode and gpaddmirrors in copy mode.")
+	subInit.Flags().StringVar(&mirrorLayout, "mirror-layout", "", "re-lays out the target mirrors either by policy of group or spread, or by placements in the form \"content=host:port:datadir,...\"")
+	subInit.Flags().StringVar(&addMirrors, "add-mirrors", "", "adds mirrors to a source cluster without them either by policy in the form \"group|spread:port:datadir\" or by placements in the form \"content=host:port:datadir,...\"")
+	subInit.Flags().StringVar(&addStandby, "add-standby", "", "adds a standby to a source cluster without one in the form \"host:port:datadir\"")
 	subInit.Flags().BoolVar(&stopBeforeClusterCreation, "stop-before-cluster-creation", false, "only run up to pre-init")
 	subInit.Flags().MarkHidden("stop-before-cluster-creation") //nolint
 	subInit.Flags().BoolVar(&skipVersionCheck, "skip-version-check", false, "disable source and target version check")
@@ -389,6 +489,22 @@ func parseMode(input string) (idl.Mode, error) {
 	return idl.Mode_unknown_mode, fmt.Errorf("Invalid input %q. Please specify either %s.", input, strings.Join(choices, ", "))
 }
 
+// parseMirrorUpgradeMethod parses the mirror upgrade method flag returning an
+// error if it is not a valid choice.
+func parseMirrorUpgradeMethod(input string) (idl.MirrorUpgradeMethod, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if method, ok := idl.MirrorUpgradeMethod_value[input]; ok && method != int32(idl.MirrorUpgradeMethod_unknown_mirror_upgrade_method) {
+		return idl.MirrorUpgradeMethod(method), nil
+	}
+
+	var choices []string
+	for i := int32(idl.MirrorUpgradeMethod_auto); i < int32(len(idl.MirrorUpgradeMethod_name)); i++ {
+		choices = append(choices, idl.MirrorUpgradeMethod(i).String())
+	}
+
+	return idl.MirrorUpgradeMethod_unknown_mirror_upgrade_method, fmt.Errorf("Invalid mirror upgrade method %q. Please specify either %s.", input, strings.Join(choices, ", "))
+}
+
 func addFlags(cmd *cobra.Command, flags map[string]string) error {
 	for name, value := range flags {
 		flag := cmd.Flag(name)
@@ -405,6 +521,10 @@ func addFlags(cmd *cobra.Command, flags map[string]string) error {
 			return xerrors.Errorf("set %q to %q: %w", name, value, err)
 		}
 
+		if flag.Deprecated != "" {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Configuration parameter %q has been deprecated, %s\n", strings.ReplaceAll(name, "-", "_"), flag.Deprecated)
+		}
+
 		cmd.Flag(name).Changed = true
 	}
 
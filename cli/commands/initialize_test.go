@@ -4,8 +4,10 @@
 package commands
 
 import (
+	"bytes"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -136,6 +138,45 @@ func TestParseMode(t *testing.T) {
 	}
 }
 
+func TestParseMirrorUpgradeMethod(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected idl.MirrorUpgradeMethod
+	}{
+		{input: "auto", expected: idl.MirrorUpgradeMethod_auto},
+		{input: "rsync", expected: idl.MirrorUpgradeMethod_rsync},
+		{input: "GPADDMIRRORS", expected: idl.MirrorUpgradeMethod_gpaddmirrors},
+		{input: " pg_basebackup ", expected: idl.MirrorUpgradeMethod_pg_basebackup},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			method, err := parseMirrorUpgradeMethod(c.input)
+			if err != nil {
+				t.Errorf("unexpected error %#v", err)
+			}
+
+			if method != c.expected {
+				t.Errorf("got %s want %s", method, c.expected)
+			}
+		})
+	}
+
+	t.Run("errors on invalid methods", func(t *testing.T) {
+		for _, input := range []string{"", "unknown_mirror_upgrade_method", "gprecoverseg", "2"} {
+			method, err := parseMirrorUpgradeMethod(input)
+			expected := `Invalid mirror upgrade method "` + strings.TrimSpace(input) + `". Please specify either auto, rsync, gpaddmirrors, pg_basebackup.`
+			if err == nil || err.Error() != expected {
+				t.Errorf("got error %v want %q", err, expected)
+			}
+
+			if method != idl.MirrorUpgradeMethod_unknown_mirror_upgrade_method {
+				t.Errorf("got method %s want %s", method, idl.MirrorUpgradeMethod_unknown_mirror_upgrade_method)
+			}
+		}
+	})
+}
+
 func TestAddFlags(t *testing.T) {
 	t.Run("sets flags to correct value and marks them as changed", func(t *testing.T) {
 		var name string
@@ -198,6 +239,26 @@ func TestAddFlags(t *testing.T) {
 		}
 	})
 
+	t.Run("warns when adding deprecated parameters", func(t *testing.T) {
+		var ratio float64
+		cmd := cobra.Command{}
+		cmd.Flags().Float64Var(&ratio, "disk-free-ratio", 0, "")
+		cmd.Flags().MarkDeprecated("disk-free-ratio", "it is no longer used.") //nolint
+
+		stderr := new(bytes.Buffer)
+		cmd.SetErr(stderr)
+
+		err := addFlags(&cmd, map[string]string{"disk-free-ratio": "0.6"})
+		if err != nil {
+			t.Errorf("addFlags returned error %+v", err)
+		}
+
+		expected := `Configuration parameter "disk_free_ratio" has been deprecated, it is no longer used.` + "\n"
+		if stderr.String() != expected {
+			t.Errorf("got %q want %q", stderr.String(), expected)
+		}
+	})
+
 	t.Run("errors when adding unknown parameter", func(t *testing.T) {
 		flags := map[string]string{
 			"unknown": "value",
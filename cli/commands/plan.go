@@ -0,0 +1,33 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+)
+
+func plan() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "previews the changes finalize makes to the target cluster",
+		Long:  PlanHelp,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			client, err := connectToHub()
+			if err != nil {
+				return err
+			}
+
+			return commanders.Plan(context.Background(), client, os.Stdout)
+		},
+	}
+
+	return addHelpToCommand(cmd, PlanHelp)
+}
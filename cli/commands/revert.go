@@ -4,6 +4,7 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -12,11 +13,13 @@ import (
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
+	"github.com/greenplum-db/gpupgrade/audit"
 	"github.com/greenplum-db/gpupgrade/cli/clistep"
 	"github.com/greenplum-db/gpupgrade/cli/commanders"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
 )
@@ -24,6 +27,7 @@ import (
 func revert() *cobra.Command {
 	var verbose bool
 	var nonInteractive bool
+	var answersFile string
 
 	cmd := &cobra.Command{
 		Use:   "revert",
@@ -39,9 +43,14 @@ func revert() *cobra.Command {
 
 			confirmationText := fmt.Sprintf(revertConfirmationText,
 				cases.Title(language.English).String(idl.Step_revert.String()),
-				revertSubsteps, logdir)
+				substeps.Steps[idl.Step_revert], logdir)
 
-			st, err := clistep.Begin(idl.Step_revert, verbose, nonInteractive, confirmationText)
+			answers, err := parseAnswersFile(answersFile)
+			if err != nil {
+				return err
+			}
+
+			st, err := clistep.Begin(idl.Step_revert, verbose, nonInteractive, answers, confirmationText)
 			if err != nil {
 				if errors.Is(err, step.Quit) {
 					// If user cancels don't return an error to main to avoid
@@ -52,13 +61,13 @@ func revert() *cobra.Command {
 			}
 
 			source := &greenplum.Cluster{}
-			st.RunHubSubstep(func(streams step.OutStreams) error {
+			st.RunHubSubstep(func(ctx context.Context, streams step.OutStreams) error {
 				client, err := connectToHub()
 				if err != nil {
 					return err
 				}
 
-				response, err = commanders.Revert(client, verbose)
+				response, err = commanders.Revert(ctx, client, verbose)
 				if err != nil {
 					return err
 				}
@@ -81,7 +90,7 @@ func revert() *cobra.Command {
 				}
 
 				currentDir := filepath.Join(response.GetLogArchiveDirectory(), "data-migration-scripts", "current")
-				return commanders.ApplyDataMigrationScripts(streams, nonInteractive, source.GPHome, source.CoordinatorPort(), response.GetLogArchiveDirectory(), utils.System.DirFS(currentDir), currentDir, idl.Step_revert)
+				return commanders.ApplyDataMigrationScripts(streams, nonInteractive, answers, source.GPHome, source.CoordinatorPort(), response.GetLogArchiveDirectory(), utils.System.DirFS(currentDir), currentDir, idl.Step_revert, commanders.NonTransactional)
 			})
 
 			st.Run(idl.Substep_delete_master_statedir, func(streams step.OutStreams) error {
@@ -89,6 +98,13 @@ func revert() *cobra.Command {
 				// Disable the store so the step framework does not try to write
 				// to a non-existent status file.
 				st.DisableStore()
+
+				// Keep the audit log with the archived logs.
+				err := audit.Archive(response.GetLogArchiveDirectory())
+				if err != nil {
+					return err
+				}
+
 				return upgrade.DeleteDirectories([]string{utils.GetStateDir()}, upgrade.StateDirectoryFiles, streams)
 			})
 
@@ -104,6 +120,7 @@ func revert() *cobra.Command {
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "print the output stream from all substeps")
 	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "do not prompt for confirmation to proceed")
 	cmd.Flags().MarkHidden("non-interactive") //nolint
+	cmd.Flags().StringVar(&answersFile, "answers-file", "", "JSON file answering each prompt such that the command runs unattended")
 
 	return addHelpToCommand(cmd, RevertHelp)
 }
@@ -0,0 +1,42 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gpupgrade/cli/commanders"
+)
+
+func watch() *cobra.Command {
+	var logLines int
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "follow the progress of the current gpupgrade step",
+		Long:  WatchHelp,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			client, err := connectToHub()
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return commanders.Watch(ctx, client, os.Stdout, logLines)
+		},
+	}
+
+	cmd.Flags().IntVar(&logLines, "log-lines", 15, "number of recent output lines to display")
+
+	return addHelpToCommand(cmd, WatchHelp)
+}
@@ -11,6 +11,9 @@ import (
 	"runtime/debug"
 	"strings"
 
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/audit"
 	"github.com/greenplum-db/gpupgrade/cli/commands"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/utils"
@@ -30,6 +33,14 @@ func main() {
 	root.SilenceUsage = true
 
 	err := root.Execute()
+	if aErr := audit.RecordOutcome(err); aErr != nil {
+		if err == nil {
+			err = xerrors.Errorf("recording outcome: %w", aErr)
+		} else {
+			log.Printf("recording outcome: %+v", aErr)
+		}
+	}
+
 	if err != nil && err != daemon.ErrSuccessfullyDaemonized {
 		if strings.HasPrefix(err.Error(), "unknown flag") {
 			cmd := strings.TrimSpace(os.Args[1])
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"os"
@@ -15,8 +16,11 @@ import (
 	"github.com/greenplum-db/gpupgrade/config/backupdir"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/notify"
+	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
 )
 
 const ConfigFileName = "config.json"
@@ -53,8 +57,35 @@ type Config struct {
 	AgentPort       int
 	Mode            idl.Mode
 	UseHbaHostnames bool
-	UpgradeID       string
-	PgUpgradeJobs   uint
+	// AddressFamily selects the IP versions used to reach hosts and to write
+	// their addresses into pg_hba.conf.
+	AddressFamily utils.AddressFamily
+	// MirrorUpgradeMethod is how finalize creates the mirrors and standby.
+	MirrorUpgradeMethod idl.MirrorUpgradeMethod
+
+	UpgradeID     string
+	PgUpgradeJobs uint
+
+	// SubstepTimeouts overrides step.DefaultTimeouts.
+	SubstepTimeouts step.Timeouts
+
+	// Notifications configures the webhooks and command the hub notifies of
+	// step and substep events.
+	Notifications notify.Config
+
+	// TablespaceMappings relocates user defined tablespaces of the target
+	// cluster in copy mode.
+	TablespaceMappings greenplum.TablespaceMappings
+
+	// DiskThresholds are the free space below which the disk space watchdog
+	// warns and aborts during execute and finalize.
+	DiskThresholds disk.Thresholds
+
+	// UnmigratableSettings and UnmigratablePgHbaRules are reported at the end
+	// of initialize and finalize. They are saved since re-running the step
+	// skips the completed substep that found them.
+	UnmigratableSettings   []string
+	UnmigratablePgHbaRules []string
 }
 
 func (conf *Config) Write() error {
@@ -67,12 +98,19 @@ func (conf *Config) Write() error {
 }
 
 func Read() (*Config, error) {
-	contents, err := os.ReadFile(GetConfigFile())
+	return ReadFrom(GetConfigFile())
+}
+
+// ReadFrom reads a configuration file such as one archived by finalize.
+func ReadFrom(path string) (*Config, error) {
+	contents, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	conf := &Config{}
+	// Default fields missing from configuration files written by older
+	// versions.
+	conf := &Config{DiskThresholds: disk.DefaultThresholds}
 	err = json.Unmarshal(contents, &conf)
 	if err != nil {
 		return nil, xerrors.Errorf("unmarshal configuration file: %w", err)
@@ -85,14 +123,14 @@ func GetConfigFile() string {
 	return filepath.Join(utils.GetStateDir(), ConfigFileName)
 }
 
-func Create(db *sql.DB, hubPort int, agentPort int, sourceGPHome string, targetGPHome string, mode idl.Mode, useHbaHostnames bool, ports []int, pgUpgradeJobs uint, parentBackupDirs string) (Config, error) {
+func Create(db *sql.DB, hubPort int, agentPort int, sourceGPHome string, targetGPHome string, mode idl.Mode, useHbaHostnames bool, ports []int, pgUpgradeJobs uint, parentBackupDirs string, substepTimeouts step.Timeouts, notifications notify.Config, tablespaceMappings greenplum.TablespaceMappings, addressFamily utils.AddressFamily, mirrorUpgradeMethod idl.MirrorUpgradeMethod, mirrorLayout greenplum.MirrorLayout, addMirrors greenplum.MirrorLayout, addStandby *greenplum.MirrorPlacement, diskThresholds disk.Thresholds) (Config, error) {
 	source, err := greenplum.ClusterFromDB(db, sourceGPHome, idl.ClusterDestination_source)
 	if err != nil {
 		return Config{}, xerrors.Errorf("retrieve source configuration: %w", err)
 	}
 
 	// Ensure segments are up, synchronized, and in their preferred role before proceeding.
-	err = greenplum.WaitForSegments(db, 5*time.Minute, &source)
+	err = greenplum.WaitForSegments(context.Background(), db, 5*time.Minute, &source)
 	if err != nil {
 		return Config{}, err
 	}
@@ -107,8 +145,13 @@ func Create(db *sql.DB, hubPort int, agentPort int, sourceGPHome string, targetG
 	config.AgentPort = agentPort
 	config.Mode = mode
 	config.UseHbaHostnames = useHbaHostnames
+	config.AddressFamily = addressFamily
+	config.MirrorUpgradeMethod = mirrorUpgradeMethod
 	config.UpgradeID = upgrade.NewID()
 	config.PgUpgradeJobs = pgUpgradeJobs
+	config.SubstepTimeouts = substepTimeouts
+	config.Notifications = notifications
+	config.DiskThresholds = diskThresholds
 	config.BackupDirs, err = backupdir.ParseParentBackupDirs(parentBackupDirs, source)
 	if err != nil {
 		return Config{}, err
@@ -122,7 +165,37 @@ func Create(db *sql.DB, hubPort int, agentPort int, sourceGPHome string, targetG
 	config.Target.GPHome = targetGPHome
 	config.Target.Version = targetVersion
 
-	config.Intermediate, err = GenerateIntermediateCluster(config.Source, ports, config.UpgradeID, config.Target.Version, config.Target.GPHome)
+	if !mirrorLayout.IsEmpty() || !addMirrors.IsEmpty() {
+		config.MirrorUpgradeMethod, err = relayoutMirrorUpgradeMethod(mirrorUpgradeMethod)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	// rsync copies the mirror and standby tablespaces from their source
+	// locations rather than the relocated ones of the primaries.
+	if len(tablespaceMappings) > 0 && config.MirrorUpgradeMethod == idl.MirrorUpgradeMethod_rsync {
+		return Config{}, xerrors.Errorf("tablespace_mapping cannot be used with mirror_upgrade_method %s", config.MirrorUpgradeMethod)
+	}
+
+	if !mirrorLayout.IsEmpty() {
+		config.Target.Mirrors, err = mirrorLayout.Mirrors(config.Source)
+		if err != nil {
+			return Config{}, xerrors.Errorf("mirror_layout: %w", err)
+		}
+	}
+
+	// The mirrors and standby added to a source cluster without them are
+	// generated with the rest of the intermediate cluster and created during
+	// finalize. Revert is unaffected since it only restores the source.
+	if !addMirrors.IsEmpty() || addStandby != nil {
+		config.Target.Mirrors, err = greenplum.AddMirrors(config.Source, config.Target.Mirrors, addMirrors, addStandby)
+		if err != nil {
+			return Config{}, xerrors.Errorf("add_mirrors: %w", err)
+		}
+	}
+
+	config.Intermediate, err = GenerateIntermediateCluster(config.Target, ports, config.UpgradeID, config.Target.Version, config.Target.GPHome)
 	if err != nil {
 		return Config{}, err
 	}
@@ -138,5 +211,25 @@ func Create(db *sql.DB, hubPort int, agentPort int, sourceGPHome string, targetG
 		}
 	}
 
+	err = tablespaceMappings.Validate(config.Source, mode)
+	if err != nil {
+		return Config{}, xerrors.Errorf("tablespace_mapping: %w", err)
+	}
+	config.TablespaceMappings = tablespaceMappings
+
 	return config, nil
 }
+
+// relayoutMirrorUpgradeMethod returns the method used to create re-laid out or
+// added mirrors. rsync requires the mirrors to stay on the source mirror
+// hosts.
+func relayoutMirrorUpgradeMethod(method idl.MirrorUpgradeMethod) (idl.MirrorUpgradeMethod, error) {
+	switch method {
+	case idl.MirrorUpgradeMethod_rsync:
+		return method, xerrors.Errorf("mirror_layout and add_mirrors cannot be used with mirror_upgrade_method %s", method)
+	case idl.MirrorUpgradeMethod_auto, idl.MirrorUpgradeMethod_unknown_mirror_upgrade_method:
+		return idl.MirrorUpgradeMethod_gpaddmirrors, nil
+	default:
+		return method, nil
+	}
+}
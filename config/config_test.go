@@ -5,6 +5,7 @@ package config_test
 
 import (
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -15,8 +16,11 @@ import (
 	"github.com/greenplum-db/gpupgrade/config"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/notify"
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
 )
 
 func TestConfig(t *testing.T) {
@@ -29,6 +33,9 @@ func TestConfig(t *testing.T) {
 		AgentPort:    54321,
 		Mode:         idl.Mode_copy,
 		UpgradeID:    "ABC123",
+		// Saved such that re-running initialize and finalize still reports them.
+		UnmigratableSettings:   []string{"gp_external_enable_exec: removed in the target version"},
+		UnmigratablePgHbaRules: []string{"host all all 0.0.0.0/0 password: unsupported authentication method"},
 	}
 
 	t.Run("save configuration contents to disk and load it back", func(t *testing.T) {
@@ -51,6 +58,25 @@ func TestConfig(t *testing.T) {
 			t.Errorf("wrote config %#v but wanted %#v", actual, conf)
 		}
 	})
+
+	t.Run("defaults the disk thresholds of configuration files without them", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+		defer resetEnv()
+
+		testutils.MustWriteToFile(t, config.GetConfigFile(), `{"HubPort": 12345}`)
+
+		actual, err := config.Read()
+		if err != nil {
+			t.Errorf("loading config: %+v", err)
+		}
+
+		if actual.DiskThresholds != disk.DefaultThresholds {
+			t.Errorf("got disk thresholds %+v want %+v", actual.DiskThresholds, disk.DefaultThresholds)
+		}
+	})
 }
 
 func TestCreate(t *testing.T) {
@@ -100,7 +126,7 @@ func TestCreate(t *testing.T) {
 			expectPgStatReplicationToReturn(mock)
 			expectPgTablespace(mock)
 
-			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs)
+			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, nil, notify.Config{}, nil, utils.AnyAddressFamily, idl.MirrorUpgradeMethod_auto, greenplum.MirrorLayout{}, greenplum.MirrorLayout{}, nil, disk.DefaultThresholds)
 			if err != nil {
 				t.Fatalf("unexpected error %#v", err)
 			}
@@ -126,7 +152,7 @@ func TestCreate(t *testing.T) {
 			expectPgStatReplicationToReturn(mock)
 			expectPgTablespace(mock)
 
-			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs)
+			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, nil, notify.Config{}, nil, utils.AnyAddressFamily, idl.MirrorUpgradeMethod_auto, greenplum.MirrorLayout{}, greenplum.MirrorLayout{}, nil, disk.DefaultThresholds)
 			if err != nil {
 				t.Fatalf("unexpected error %#v", err)
 			}
@@ -151,7 +177,7 @@ func TestCreate(t *testing.T) {
 			expectPgStatReplicationToReturn(mock)
 			expectPgTablespace(mock)
 
-			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs)
+			conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, nil, notify.Config{}, nil, utils.AnyAddressFamily, idl.MirrorUpgradeMethod_auto, greenplum.MirrorLayout{}, greenplum.MirrorLayout{}, nil, disk.DefaultThresholds)
 			if err != nil {
 				t.Fatalf("unexpected error %#v", err)
 			}
@@ -168,7 +194,7 @@ func TestCreate(t *testing.T) {
 		expectPgStatReplicationToReturn(mock)
 		expectPgTablespace(mock)
 
-		conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs)
+		conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, nil, notify.Config{}, nil, utils.AnyAddressFamily, idl.MirrorUpgradeMethod_auto, greenplum.MirrorLayout{}, greenplum.MirrorLayout{}, nil, disk.DefaultThresholds)
 		if err != nil {
 			t.Fatalf("unexpected error %#v", err)
 		}
@@ -218,6 +244,111 @@ func TestCreate(t *testing.T) {
 			t.Errorf("expected non-empty UpgradeID")
 		}
 	})
+
+	t.Run("re-lays out the target and intermediate mirrors", func(t *testing.T) {
+		expectGpSegmentConfigurationToReturnCluster(mock, source)
+		expectGpSegmentConfigurationCount(mock, source)
+		expectPgStatReplicationToReturn(mock)
+		expectPgTablespace(mock)
+
+		layout := greenplum.MirrorLayout{Placements: map[int]greenplum.MirrorPlacement{
+			0: {Hostname: "sdw2", Port: 26000, DataDir: "/data/mirror/seg1"},
+		}}
+
+		conf, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, nil, notify.Config{}, nil, utils.AnyAddressFamily, idl.MirrorUpgradeMethod_auto, layout, greenplum.MirrorLayout{}, nil, disk.DefaultThresholds)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if conf.MirrorUpgradeMethod != idl.MirrorUpgradeMethod_gpaddmirrors {
+			t.Errorf("got %s want %s", conf.MirrorUpgradeMethod, idl.MirrorUpgradeMethod_gpaddmirrors)
+		}
+
+		expected := greenplum.SegConfig{DbID: 4, ContentID: 0, Hostname: "sdw2", Address: "sdw2", DataDir: "/data/mirror/seg1", Port: 26000, Role: greenplum.MirrorRole}
+		if !reflect.DeepEqual(conf.Target.Mirrors[0], expected) {
+			t.Errorf("got target mirror %+v want %+v", conf.Target.Mirrors[0], expected)
+		}
+
+		if !reflect.DeepEqual(conf.Source.Mirrors[0], source.Mirrors[0]) {
+			t.Errorf("got source mirror %+v want %+v", conf.Source.Mirrors[0], source.Mirrors[0])
+		}
+
+		intermediateMirror := conf.Intermediate.Mirrors[0]
+		if intermediateMirror.Hostname != "sdw2" || filepath.Dir(intermediateMirror.DataDir) != "/data/mirror" {
+			t.Errorf("got intermediate mirror %+v want it on sdw2 within /data/mirror", intermediateMirror)
+		}
+	})
+
+	t.Run("adds mirrors and a standby to a source cluster without them", func(t *testing.T) {
+		unmirrored := MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25433, Role: greenplum.PrimaryRole},
+			{DbID: 3, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Port: 25434, Role: greenplum.PrimaryRole},
+		})
+		unmirrored.GPHome = source.GPHome
+
+		expectGpSegmentConfigurationToReturnCluster(mock, unmirrored)
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM gp_segment_configuration`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		expectPgTablespace(mock)
+
+		addMirrors := greenplum.MirrorLayout{Policy: greenplum.GroupMirrors, Port: 26000, DataDir: "/data/mirror"}
+		addStandby := &greenplum.MirrorPlacement{Hostname: "standby", Port: 15432, DataDir: "/data/standby"}
+
+		conf, err := config.Create(db, hubPort, agentPort, unmirrored.GPHome, targetGPHome, idl.Mode_copy, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, nil, notify.Config{}, nil, utils.AnyAddressFamily, idl.MirrorUpgradeMethod_auto, greenplum.MirrorLayout{}, addMirrors, addStandby, disk.DefaultThresholds)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if conf.Source.HasMirrors() || conf.Source.HasStandby() {
+			t.Errorf("expected the source cluster to not have mirrors or a standby")
+		}
+
+		expected := greenplum.ContentToSegConfig{
+			-1: {DbID: 6, ContentID: -1, Hostname: "standby", Address: "standby", DataDir: "/data/standby", Port: 15432, Role: greenplum.MirrorRole},
+			0:  {DbID: 4, ContentID: 0, Hostname: "sdw2", Address: "sdw2", DataDir: "/data/mirror/seg1", Port: 26000, Role: greenplum.MirrorRole},
+			1:  {DbID: 5, ContentID: 1, Hostname: "sdw1", Address: "sdw1", DataDir: "/data/mirror/seg2", Port: 26000, Role: greenplum.MirrorRole},
+		}
+		if !reflect.DeepEqual(conf.Target.Mirrors, expected) {
+			t.Errorf("got target mirrors %+v want %+v", conf.Target.Mirrors, expected)
+		}
+
+		if !conf.Intermediate.HasMirrors() || !conf.Intermediate.HasStandby() {
+			t.Errorf("expected the intermediate cluster to have mirrors and a standby")
+		}
+
+		if conf.MirrorUpgradeMethod != idl.MirrorUpgradeMethod_gpaddmirrors {
+			t.Errorf("got %s want %s", conf.MirrorUpgradeMethod, idl.MirrorUpgradeMethod_gpaddmirrors)
+		}
+	})
+
+	t.Run("errors when re-laying out mirrors upgraded with rsync", func(t *testing.T) {
+		expectGpSegmentConfigurationToReturnCluster(mock, source)
+		expectGpSegmentConfigurationCount(mock, source)
+		expectPgStatReplicationToReturn(mock)
+
+		layout := greenplum.MirrorLayout{Policy: greenplum.SpreadMirrors}
+
+		_, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, mode, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, nil, notify.Config{}, nil, utils.AnyAddressFamily, idl.MirrorUpgradeMethod_rsync, layout, greenplum.MirrorLayout{}, nil, disk.DefaultThresholds)
+		expected := "mirror_layout and add_mirrors cannot be used with mirror_upgrade_method rsync"
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+
+	t.Run("errors when relocating tablespaces of mirrors upgraded with rsync", func(t *testing.T) {
+		expectGpSegmentConfigurationToReturnCluster(mock, source)
+		expectGpSegmentConfigurationCount(mock, source)
+		expectPgStatReplicationToReturn(mock)
+
+		mappings := greenplum.TablespaceMappings{{OldLocation: "/data/tablespaces", NewLocation: "/ssd/tablespaces"}}
+
+		_, err := config.Create(db, hubPort, agentPort, source.GPHome, targetGPHome, idl.Mode_copy, useHbaHostnames, ports, pgUpgradeJobs, parentBackupDirs, nil, notify.Config{}, mappings, utils.AnyAddressFamily, idl.MirrorUpgradeMethod_rsync, greenplum.MirrorLayout{}, greenplum.MirrorLayout{}, nil, disk.DefaultThresholds)
+		expected := "tablespace_mapping cannot be used with mirror_upgrade_method rsync"
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
 }
 
 func expectGpSegmentConfigurationToReturnCluster(mock sqlmock.Sqlmock, cluster *greenplum.Cluster) {
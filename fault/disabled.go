@@ -0,0 +1,8 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !faultinjection
+
+package fault
+
+const enabledByBuildTag = false
@@ -0,0 +1,289 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fault injects failures at named points such that tests can exercise
+// the failure and revert paths. Faults can make a substep fail before or after
+// it runs, make an agent request fail or hang on a given host, or make a
+// utility such as pg_upgrade, rsync, or gpstart exit non-zero or hang.
+//
+// Injection is disabled unless gpupgrade is built with the faultinjection tag
+// or GPUPGRADE_FAULT_INJECTION is set in the environment. When enabled, faults
+// are registered in-process with Inject or are read from the faults file in
+// the state directory of each host, which allows acceptance tests to inject
+// faults into the hub and agent processes. Each line of the file is a point
+// followed by an action such as:
+//
+//	substep/execute/upgrade_primaries/before fail
+//	rpc/RenameDirectories/sdw1 hang
+//	command/rsync fail
+package fault
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const (
+	EnvVar   = "GPUPGRADE_FAULT_INJECTION"
+	FileName = "faults"
+)
+
+var ErrInjected = errors.New("injected fault")
+
+// Point names where a fault is injected. Use SubstepPoint, RPCPoint, and
+// CommandPoint to construct them.
+type Point string
+
+// validate returns an error when the point is not one constructed by
+// SubstepPoint, RPCPoint, or CommandPoint such as when a step or substep is
+// misspelled. Otherwise the fault would silently never be injected.
+func (p Point) validate() error {
+	parts := strings.Split(string(p), "/")
+	for _, part := range parts {
+		if part == "" {
+			return fmt.Errorf("invalid point %q: empty name", p)
+		}
+	}
+
+	switch parts[0] {
+	case "substep":
+		if len(parts) != 4 {
+			return fmt.Errorf("invalid point %q: expected \"substep/<step>/<substep>/<when>\"", p)
+		}
+
+		if _, ok := idl.Step_value[parts[1]]; !ok {
+			return fmt.Errorf("invalid point %q: unknown step %q", p, parts[1])
+		}
+
+		if _, ok := idl.Substep_value[parts[2]]; !ok {
+			return fmt.Errorf("invalid point %q: unknown substep %q", p, parts[2])
+		}
+
+		if when := When(parts[3]); when != Before && when != After {
+			return fmt.Errorf("invalid point %q: expected %q or %q but got %q", p, Before, After, when)
+		}
+	case "rpc":
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid point %q: expected \"rpc/<method>/<host>\"", p)
+		}
+	case "command":
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid point %q: expected \"command/<utility>\"", p)
+		}
+	default:
+		return fmt.Errorf("invalid point %q: unknown kind %q. Expected one of substep, rpc, or command", p, parts[0])
+	}
+
+	return nil
+}
+
+type When string
+
+const (
+	Before When = "before"
+	After  When = "after"
+)
+
+// AnyHost matches requests made to the agent on every host.
+const AnyHost = "*"
+
+func SubstepPoint(step idl.Step, substep idl.Substep, when When) Point {
+	return Point(fmt.Sprintf("substep/%s/%s/%s", step, substep, when))
+}
+
+func RPCPoint(method string, host string) Point {
+	return Point(fmt.Sprintf("rpc/%s/%s", method, host))
+}
+
+func CommandPoint(utility string) Point {
+	return Point(fmt.Sprintf("command/%s", utility))
+}
+
+type Action string
+
+const (
+	// Fail returns ErrInjected from the point. For commands the utility is
+	// replaced by one that exits non-zero.
+	Fail Action = "fail"
+
+	// Hang blocks until the context of the point is done. For commands the
+	// utility is replaced by one that runs until it is terminated.
+	Hang Action = "hang"
+)
+
+type Faults map[Point]Action
+
+func (f Faults) String() string {
+	var lines []string
+	for point, action := range f {
+		lines = append(lines, fmt.Sprintf("%s %s", point, action))
+	}
+
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+var (
+	mu       sync.Mutex
+	injected = make(Faults)
+)
+
+// Enabled returns whether faults are injected. It is true when built with the
+// faultinjection tag or when GPUPGRADE_FAULT_INJECTION is set.
+func Enabled() bool {
+	return enabledByBuildTag || os.Getenv(EnvVar) != ""
+}
+
+// Inject registers a fault in this process. It has no effect unless injection
+// is enabled.
+func Inject(point Point, action Action) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	injected[point] = action
+}
+
+// Reset removes the faults registered in this process.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	injected = make(Faults)
+}
+
+// WriteFile writes the faults to the faults file in stateDir replacing any
+// existing faults.
+func WriteFile(stateDir string, faults Faults) error {
+	path := filepath.Join(stateDir, FileName)
+	if err := os.WriteFile(path, []byte(faults.String()+"\n"), 0600); err != nil {
+		return xerrors.Errorf("write faults file: %w", err)
+	}
+
+	return nil
+}
+
+// ReadFile returns the faults in the faults file in stateDir. A missing file
+// has no faults.
+func ReadFile(stateDir string) (Faults, error) {
+	path := filepath.Join(stateDir, FileName)
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Faults{}, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("read faults file: %w", err)
+	}
+	defer file.Close()
+
+	faults := make(Faults)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, xerrors.Errorf("parse faults file %q: expected \"point action\" but got %q", path, line)
+		}
+
+		action := Action(fields[1])
+		if action != Fail && action != Hang {
+			return nil, xerrors.Errorf("parse faults file %q: unknown action %q", path, action)
+		}
+
+		point := Point(fields[0])
+		if err := point.validate(); err != nil {
+			return nil, xerrors.Errorf("parse faults file %q: %w", path, err)
+		}
+
+		faults[point] = action
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("read faults file: %w", err)
+	}
+
+	return faults, nil
+}
+
+// lookup returns the action injected at point. Faults registered in-process
+// take precedence over those in the faults file. The file is read on every
+// lookup so that faults can be changed without restarting the hub and agents.
+func lookup(point Point) (Action, bool, error) {
+	if !Enabled() {
+		return "", false, nil
+	}
+
+	mu.Lock()
+	action, ok := injected[point]
+	mu.Unlock()
+	if ok {
+		return action, true, nil
+	}
+
+	faults, err := ReadFile(utils.GetStateDir())
+	if err != nil {
+		return "", false, err
+	}
+
+	action, ok = faults[point]
+	return action, ok, nil
+}
+
+// Check returns ErrInjected when a fault fails point. When the fault hangs
+// point it blocks until ctx is done and returns its cause.
+func Check(ctx context.Context, point Point) error {
+	action, ok, err := lookup(point)
+	if err != nil || !ok {
+		return err
+	}
+
+	switch action {
+	case Hang:
+		<-ctx.Done()
+		return fmt.Errorf("%s: %w: %w", point, ErrInjected, context.Cause(ctx))
+	default:
+		return xerrors.Errorf("%s: %w", point, ErrInjected)
+	}
+}
+
+// Command returns cmd unless a fault is injected for utility in which case
+// it returns a command that exits non-zero or runs until terminated in its
+// place. The replacement writes to the streams of cmd, and is run the same way
+// such that callers observe a real *exec.ExitError.
+func Command(utility string, cmd *exec.Cmd) (*exec.Cmd, error) {
+	point := CommandPoint(utility)
+	action, ok, err := lookup(point)
+	if err != nil || !ok {
+		return cmd, err
+	}
+
+	script := fmt.Sprintf("echo '%s: %s' >&2; exit 1", point, ErrInjected)
+	if action == Hang {
+		script = "while :; do sleep 1; done"
+	}
+
+	replacement := exec.Command("sh", "-c", script)
+	replacement.Dir = cmd.Dir
+	replacement.Stdin = cmd.Stdin
+	replacement.Stdout = cmd.Stdout
+	replacement.Stderr = cmd.Stderr
+	replacement.SysProcAttr = cmd.SysProcAttr
+
+	return replacement, nil
+}
@@ -0,0 +1,382 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package fault_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/greenplum-db/gpupgrade/fault"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestPoints(t *testing.T) {
+	cases := []struct {
+		name     string
+		point    fault.Point
+		expected fault.Point
+	}{
+		{"substep", fault.SubstepPoint(idl.Step_execute, idl.Substep_upgrade_primaries, fault.Before), "substep/execute/upgrade_primaries/before"},
+		{"rpc", fault.RPCPoint("RenameDirectories", "sdw1"), "rpc/RenameDirectories/sdw1"},
+		{"command", fault.CommandPoint("rsync"), "command/rsync"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.point != c.expected {
+				t.Errorf("got %q want %q", c.point, c.expected)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	point := fault.SubstepPoint(idl.Step_execute, idl.Substep_upgrade_primaries, fault.Before)
+
+	t.Run("returns nil when no fault is injected", func(t *testing.T) {
+		setup(t)
+
+		err := fault.Check(context.Background(), point)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("does not inject faults when disabled", func(t *testing.T) {
+		if fault.Enabled() {
+			t.Skip("fault injection is enabled by the build tag")
+		}
+
+		stateDir := setup(t)
+		if err := os.Unsetenv(fault.EnvVar); err != nil {
+			t.Fatal(err)
+		}
+
+		fault.Inject(point, fault.Fail)
+		err := fault.WriteFile(stateDir, fault.Faults{point: fault.Fail})
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		err = fault.Check(context.Background(), point)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("fails a point injected in-process", func(t *testing.T) {
+		setup(t)
+
+		fault.Inject(point, fault.Fail)
+
+		err := fault.Check(context.Background(), point)
+		if !errors.Is(err, fault.ErrInjected) {
+			t.Errorf("got error %#v want %#v", err, fault.ErrInjected)
+		}
+
+		if !strings.Contains(err.Error(), string(point)) {
+			t.Errorf("expected error %q to contain %q", err, point)
+		}
+	})
+
+	t.Run("fails a point injected from the faults file", func(t *testing.T) {
+		stateDir := setup(t)
+
+		err := fault.WriteFile(stateDir, fault.Faults{point: fault.Fail})
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		err = fault.Check(context.Background(), point)
+		if !errors.Is(err, fault.ErrInjected) {
+			t.Errorf("got error %#v want %#v", err, fault.ErrInjected)
+		}
+	})
+
+	t.Run("hangs a point until its context is done", func(t *testing.T) {
+		setup(t)
+
+		fault.Inject(point, fault.Hang)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := fault.Check(ctx, point)
+		if !errors.Is(err, fault.ErrInjected) {
+			t.Errorf("got error %#v want %#v", err, fault.ErrInjected)
+		}
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got error %#v want %#v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("returns an error when the faults file is invalid", func(t *testing.T) {
+		stateDir := setup(t)
+
+		testutils.MustWriteToFile(t, filepath.Join(stateDir, fault.FileName), "command/rsync explode\n")
+
+		err := fault.Check(context.Background(), point)
+		if err == nil || errors.Is(err, fault.ErrInjected) {
+			t.Errorf("expected a parse error but got %#v", err)
+		}
+	})
+}
+
+func TestReadFile(t *testing.T) {
+	t.Run("round trips the faults written", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		expected := fault.Faults{
+			fault.SubstepPoint(idl.Step_finalize, idl.Substep_upgrade_mirrors, fault.After): fault.Fail,
+			fault.RPCPoint("RenameDirectories", "sdw1"):                                     fault.Hang,
+			fault.CommandPoint("pg_upgrade"):                                                fault.Fail,
+		}
+
+		err := fault.WriteFile(stateDir, expected)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		faults, err := fault.ReadFile(stateDir)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if !reflect.DeepEqual(faults, expected) {
+			t.Errorf("got %v want %v", faults, expected)
+		}
+	})
+
+	t.Run("skips blank lines and comments", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		testutils.MustWriteToFile(t, filepath.Join(stateDir, fault.FileName), "# injected by test\n\ncommand/rsync fail\n")
+
+		faults, err := fault.ReadFile(stateDir)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := fault.Faults{fault.CommandPoint("rsync"): fault.Fail}
+		if !reflect.DeepEqual(faults, expected) {
+			t.Errorf("got %v want %v", faults, expected)
+		}
+	})
+
+	t.Run("returns no faults when the file does not exist", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		faults, err := fault.ReadFile(stateDir)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if len(faults) != 0 {
+			t.Errorf("expected no faults but got %v", faults)
+		}
+	})
+
+	t.Run("errors when a line is malformed", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		testutils.MustWriteToFile(t, filepath.Join(stateDir, fault.FileName), "command/rsync\n")
+
+		_, err := fault.ReadFile(stateDir)
+		expected := `expected "point action" but got "command/rsync"`
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q but got %v", expected, err)
+		}
+	})
+
+	t.Run("errors when a point is invalid", func(t *testing.T) {
+		cases := []struct {
+			point    string
+			expected string
+		}{
+			{"substep/exectue/upgrade_primaries/before", `unknown step "exectue"`},
+			{"substep/execute/upgrade_primary/before", `unknown substep "upgrade_primary"`},
+			{"substep/execute/upgrade_primaries/during", `expected "before" or "after" but got "during"`},
+			{"substep/execute/upgrade_primaries", `expected "substep/<step>/<substep>/<when>"`},
+			{"rpc/RenameDirectories", `expected "rpc/<method>/<host>"`},
+			{"command/", `empty name`},
+			{"commands/rsync", `unknown kind "commands"`},
+		}
+
+		for _, c := range cases {
+			t.Run(c.point, func(t *testing.T) {
+				stateDir := testutils.GetTempDir(t, "")
+				defer testutils.MustRemoveAll(t, stateDir)
+
+				testutils.MustWriteToFile(t, filepath.Join(stateDir, fault.FileName), c.point+" fail\n")
+
+				_, err := fault.ReadFile(stateDir)
+				if err == nil || !strings.Contains(err.Error(), c.expected) {
+					t.Errorf("expected error to contain %q but got %v", c.expected, err)
+				}
+			})
+		}
+	})
+}
+
+func TestCommand(t *testing.T) {
+	t.Run("returns the command when no fault is injected", func(t *testing.T) {
+		setup(t)
+
+		cmd := exec.Command("true")
+		actual, err := fault.Command("rsync", cmd)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if actual != cmd {
+			t.Errorf("got %v want %v", actual, cmd)
+		}
+	})
+
+	t.Run("replaces a failing command with one that exits non-zero", func(t *testing.T) {
+		setup(t)
+
+		fault.Inject(fault.CommandPoint("rsync"), fault.Fail)
+
+		stderr := new(bytes.Buffer)
+		cmd := exec.Command("true")
+		cmd.Stderr = stderr
+
+		cmd, err := fault.Command("rsync", cmd)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		err = cmd.Run()
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			t.Errorf("got error %#v want exit code 1", err)
+		}
+
+		expected := "command/rsync: injected fault"
+		if !strings.Contains(stderr.String(), expected) {
+			t.Errorf("expected stderr %q to contain %q", stderr.String(), expected)
+		}
+	})
+
+	t.Run("replaces a hanging command with one that runs until killed", func(t *testing.T) {
+		setup(t)
+
+		fault.Inject(fault.CommandPoint("gpstart"), fault.Hang)
+
+		cmd, err := fault.Command("gpstart", exec.Command("true"))
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if err := cmd.Start(); err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+
+		select {
+		case err := <-done:
+			t.Fatalf("expected command to hang but it exited with %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		if err := cmd.Process.Kill(); err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+		<-done
+	})
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	invoked := false
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		invoked = true
+		return nil
+	}
+
+	cases := []struct {
+		name  string
+		point fault.Point
+	}{
+		{"fails requests to the given host", fault.RPCPoint("RenameDirectories", "sdw1")},
+		{"fails requests to any host", fault.RPCPoint("RenameDirectories", fault.AnyHost)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setup(t)
+			invoked = false
+
+			fault.Inject(c.point, fault.Fail)
+
+			interceptor := fault.UnaryClientInterceptor("sdw1")
+			err := interceptor(context.Background(), "/idl.Agent/RenameDirectories", nil, nil, nil, invoker)
+			if !errors.Is(err, fault.ErrInjected) {
+				t.Errorf("got error %#v want %#v", err, fault.ErrInjected)
+			}
+
+			if invoked {
+				t.Error("expected request to not be made")
+			}
+		})
+	}
+
+	t.Run("makes requests to other hosts and methods", func(t *testing.T) {
+		setup(t)
+
+		fault.Inject(fault.RPCPoint("RenameDirectories", "sdw1"), fault.Fail)
+
+		for _, call := range []struct{ host, method string }{
+			{"sdw2", "/idl.Agent/RenameDirectories"},
+			{"sdw1", "/idl.Agent/DeleteStateDirectory"},
+		} {
+			invoked = false
+
+			interceptor := fault.UnaryClientInterceptor(call.host)
+			err := interceptor(context.Background(), call.method, nil, nil, nil, invoker)
+			if err != nil {
+				t.Errorf("unexpected error %#v", err)
+			}
+
+			if !invoked {
+				t.Errorf("expected %s on %s to be made", call.method, call.host)
+			}
+		}
+	})
+}
+
+// setup enables fault injection with an empty state directory and removes any
+// faults injected in-process once the test completes.
+func setup(t *testing.T) string {
+	t.Helper()
+
+	stateDir := testutils.GetTempDir(t, "")
+	t.Cleanup(func() {
+		fault.Reset()
+		testutils.MustRemoveAll(t, stateDir)
+	})
+
+	t.Setenv("GPUPGRADE_HOME", stateDir)
+	t.Setenv(fault.EnvVar, "1")
+
+	return stateDir
+}
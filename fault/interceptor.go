@@ -0,0 +1,27 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package fault
+
+import (
+	"context"
+	"path"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryClientInterceptor fails or hangs requests made to the agent on host
+// when a fault is injected for the request on that host or on AnyHost. Hung
+// requests return once their context is done.
+func UnaryClientInterceptor(host string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		name := path.Base(method)
+		for _, point := range []Point{RPCPoint(name, host), RPCPoint(name, AnyHost)} {
+			if err := Check(ctx, point); err != nil {
+				return err
+			}
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
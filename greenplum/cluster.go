@@ -5,6 +5,7 @@ package greenplum
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/gob"
 	"fmt"
@@ -20,10 +21,12 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/xerrors"
 
+	"github.com/greenplum-db/gpupgrade/fault"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
@@ -424,8 +427,13 @@ func (c *Cluster) runGreenplumCommand(streams step.OutStreams, utility string, a
 	cmd.Stdout = streams.Stdout()
 	cmd.Stderr = streams.Stderr()
 
+	cmd, err := fault.Command(utility, cmd)
+	if err != nil {
+		return err
+	}
+
 	log.Printf("Executing: %q", cmd.String())
-	return cmd.Run()
+	return utils.RunContext(step.Context(streams), cmd)
 }
 
 func (c *Cluster) RunCmd(streams step.OutStreams, command string, args ...string) error {
@@ -468,7 +476,7 @@ func (c *Cluster) CheckActiveConnections(streams step.OutStreams) error {
 
 // WaitForClusterToBeReady waits until the timeout for all segments to be up,
 // in their preferred role, and synchronized.
-func (c *Cluster) WaitForClusterToBeReady() error {
+func (c *Cluster) WaitForClusterToBeReady(ctx context.Context) error {
 	db, err := sql.Open("pgx", c.Connection())
 	if err != nil {
 		return err
@@ -479,7 +487,7 @@ func (c *Cluster) WaitForClusterToBeReady() error {
 		}
 	}()
 
-	return WaitForSegments(db, 5*time.Minute, c)
+	return WaitForSegments(ctx, db, 5*time.Minute, c)
 }
 
 func GetCoordinatorSegPrefix(datadir string) (string, error) {
@@ -0,0 +1,336 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// ManagedGUCs are set by gpinitsystem or gpupgrade for each segment and are
+// never carried over from the source cluster.
+var ManagedGUCs = map[string]bool{
+	"port":                       true,
+	"listen_addresses":           true,
+	"data_directory":             true,
+	"config_file":                true,
+	"hba_file":                   true,
+	"ident_file":                 true,
+	"external_pid_file":          true,
+	"include":                    true,
+	"include_dir":                true,
+	"include_if_exists":          true,
+	"gp_dbid":                    true,
+	"gp_contentid":               true,
+	"gp_num_contents_in_cluster": true,
+	"synchronous_standby_names":  true,
+	"primary_conninfo":           true,
+	// set by the dynamic_library_path initialize option
+	"dynamic_library_path": true,
+}
+
+// GUCChange describes how a GUC of one major version translates to the next.
+// A GUC without a change carries over as is.
+type GUCChange struct {
+	// Rename is the name of the GUC in the next major version.
+	Rename string
+	// Removed is why the GUC no longer exists in the next major version.
+	Removed string
+	// Convert translates the value, such as for a change in unit.
+	Convert func(value string) (string, error)
+}
+
+// GUCChanges is keyed by the source major version and GUC name. It covers the
+// changes from the major version to the next.
+var GUCChanges = map[uint64]map[string]GUCChange{
+	5: {
+		"unix_socket_directory":            {Rename: "unix_socket_directories"},
+		"replication_timeout":              {Rename: "wal_sender_timeout"},
+		"gp_workfile_compress_algorithm":   {Rename: "gp_workfile_compression", Convert: convertWorkfileCompression},
+		"add_missing_from":                 {Removed: "removed in PostgreSQL 9.0"},
+		"regex_flavor":                     {Removed: "removed in PostgreSQL 9.0"},
+		"max_fsm_pages":                    {Removed: "the free space map is managed automatically since PostgreSQL 8.4"},
+		"max_fsm_relations":                {Removed: "the free space map is managed automatically since PostgreSQL 8.4"},
+		"custom_variable_classes":          {Removed: "removed in PostgreSQL 9.2"},
+		"silent_mode":                      {Removed: "removed in PostgreSQL 9.2"},
+		"gp_fts_probe_threadcount":         {Removed: "removed with the Greenplum 6 fault tolerance service"},
+		"gp_workfile_checksumming":         {Removed: "removed in Greenplum 6"},
+		"gp_backup_directio":               {Removed: "removed in Greenplum 6"},
+		"gp_backup_directio_read_chunk_mb": {Removed: "removed in Greenplum 6"},
+		"gp_email_smtp_server":             {Removed: "email alerts were removed in Greenplum 6"},
+		"gp_email_smtp_userid":             {Removed: "email alerts were removed in Greenplum 6"},
+		"gp_email_smtp_password":           {Removed: "email alerts were removed in Greenplum 6"},
+		"gp_email_from":                    {Removed: "email alerts were removed in Greenplum 6"},
+		"gp_email_to":                      {Removed: "email alerts were removed in Greenplum 6"},
+		"gp_snmp_community":                {Removed: "SNMP alerts were removed in Greenplum 6"},
+		"gp_snmp_monitor_address":          {Removed: "SNMP alerts were removed in Greenplum 6"},
+		"gp_snmp_use_inform_or_trap":       {Removed: "SNMP alerts were removed in Greenplum 6"},
+	},
+	6: {
+		"checkpoint_segments":     {Rename: "max_wal_size", Convert: convertCheckpointSegments},
+		"ssl_renegotiation_limit": {Removed: "removed in PostgreSQL 9.5"},
+		"sql_inheritance":         {Removed: "removed in PostgreSQL 10"},
+		"default_with_oids":       {Removed: "tables with OIDs were removed in PostgreSQL 12"},
+		"max_appendonly_tables":   {Removed: "removed in Greenplum 7"},
+	},
+}
+
+// convertWorkfileCompression turns the 5X compression algorithm into the 6X
+// boolean as 6X only supports zstd.
+func convertWorkfileCompression(value string) (string, error) {
+	if strings.EqualFold(value, "none") {
+		return "off", nil
+	}
+
+	return "on", nil
+}
+
+// convertCheckpointSegments follows the PostgreSQL 9.5 release notes of
+// max_wal_size = (3 * checkpoint_segments) * 16MB.
+func convertCheckpointSegments(value string) (string, error) {
+	segments, err := strconv.Atoi(value)
+	if err != nil {
+		return "", xerrors.Errorf("expected checkpoint_segments to be an integer got %q", value)
+	}
+
+	return fmt.Sprintf("%dMB", 3*segments*16), nil
+}
+
+// GUC is a source setting translated for the target cluster. The coordinator
+// and segment values differ when CoordinatorValue is set.
+type GUC struct {
+	SourceName       string
+	Name             string
+	Value            string
+	CoordinatorValue string
+	CoordinatorOnly  bool
+}
+
+// UnmigratableGUC is a source setting that is not carried over to the target.
+type UnmigratableGUC struct {
+	Name   string
+	Value  string
+	Reason string
+}
+
+func (u UnmigratableGUC) String() string {
+	return fmt.Sprintf("%s = '%s': %s", u.Name, u.Value, u.Reason)
+}
+
+// TranslateGUC translates a setting across each major version from source to
+// target. It returns a reason when the setting cannot be carried over.
+func TranslateGUC(name string, value string, sourceMajor uint64, targetMajor uint64) (string, string, string, error) {
+	for major := sourceMajor; major < targetMajor; major++ {
+		change, ok := GUCChanges[major][name]
+		if !ok {
+			continue
+		}
+
+		if change.Removed != "" {
+			return "", "", change.Removed, nil
+		}
+
+		if change.Convert != nil {
+			var err error
+			value, err = change.Convert(value)
+			if err != nil {
+				return "", "", "", err
+			}
+		}
+
+		if change.Rename != "" {
+			name = change.Rename
+		}
+	}
+
+	return name, value, "", nil
+}
+
+// PlanGUCMigration translates the settings of the source coordinator and
+// segment postgresql.conf files for the target version. Segment settings must
+// agree across segments since gpconfig sets a single segment value.
+func PlanGUCMigration(coordinator map[string]string, segments map[int]map[string]string, sourceMajor uint64, targetMajor uint64) ([]GUC, []UnmigratableGUC, error) {
+	names := make(map[string]bool)
+	for name := range coordinator {
+		names[name] = true
+	}
+
+	for _, settings := range segments {
+		for name := range settings {
+			names[name] = true
+		}
+	}
+
+	var sorted []string
+	for name := range names {
+		if !ManagedGUCs[name] {
+			sorted = append(sorted, name)
+		}
+	}
+	sort.Strings(sorted)
+
+	var contents []int
+	for content := range segments {
+		contents = append(contents, content)
+	}
+	sort.Ints(contents)
+
+	var gucs []GUC
+	var unmigratable []UnmigratableGUC
+	for _, name := range sorted {
+		coordinatorValue, onCoordinator := coordinator[name]
+
+		segmentValue, onSegments, agree := segmentGUCValue(name, segments, contents)
+		if !agree {
+			unmigratable = append(unmigratable, UnmigratableGUC{Name: name, Value: segmentValue, Reason: "the value differs between segments"})
+			continue
+		}
+
+		if !onCoordinator {
+			unmigratable = append(unmigratable, UnmigratableGUC{Name: name, Value: segmentValue, Reason: "set on the segments but not the coordinator"})
+			continue
+		}
+
+		targetName, targetCoordinatorValue, reason, err := TranslateGUC(name, coordinatorValue, sourceMajor, targetMajor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if reason != "" {
+			unmigratable = append(unmigratable, UnmigratableGUC{Name: name, Value: coordinatorValue, Reason: reason})
+			continue
+		}
+
+		guc := GUC{SourceName: name, Name: targetName, Value: targetCoordinatorValue}
+		if !onSegments {
+			guc.CoordinatorOnly = true
+			gucs = append(gucs, guc)
+			continue
+		}
+
+		if segmentValue != coordinatorValue {
+			_, targetSegmentValue, _, err := TranslateGUC(name, segmentValue, sourceMajor, targetMajor)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			guc.Value = targetSegmentValue
+			guc.CoordinatorValue = targetCoordinatorValue
+		}
+
+		gucs = append(gucs, guc)
+	}
+
+	return gucs, unmigratable, nil
+}
+
+func segmentGUCValue(name string, segments map[int]map[string]string, contents []int) (value string, found bool, agree bool) {
+	for _, content := range contents {
+		segmentValue, ok := segments[content][name]
+		if !ok {
+			if found {
+				return value, true, false
+			}
+			continue
+		}
+
+		if found && segmentValue != value {
+			return value, true, false
+		}
+
+		if !found && content != contents[0] {
+			// an earlier segment did not set it
+			return segmentValue, true, false
+		}
+
+		value, found = segmentValue, true
+	}
+
+	return value, found, true
+}
+
+// MissingLibraries returns the libraries of a shared_preload_libraries value
+// that are not installed in gphome. Since the setting is carried over as is,
+// a library missing from the target would keep the target from starting.
+func MissingLibraries(gphome string, libraries string) []string {
+	var missing []string
+	for _, library := range strings.Split(libraries, ",") {
+		library = strings.Trim(strings.TrimSpace(library), `"`)
+		if library == "" {
+			continue
+		}
+
+		path := strings.TrimPrefix(library, "$libdir/")
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(gphome, "lib", "postgresql", path)
+		}
+
+		// Like PostgreSQL try the name as is before adding the suffix.
+		if !libraryExists(path) && !libraryExists(path+".so") {
+			missing = append(missing, library)
+		}
+	}
+
+	return missing
+}
+
+func libraryExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// ReadPostgresqlConf returns the settings of postgresql.conf in dataDir
+// overridden by those of postgresql.auto.conf, which only exists from 6X on.
+func ReadPostgresqlConf(dataDir string) (map[string]string, error) {
+	settings, err := ReadConfFile(filepath.Join(dataDir, "postgresql.conf"))
+	if err != nil {
+		return nil, err
+	}
+
+	auto, err := ReadConfFile(filepath.Join(dataDir, "postgresql.auto.conf"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	for name, value := range auto {
+		settings[name] = value
+	}
+
+	return settings, nil
+}
+
+// CurrentSettings returns the current value of every setting the cluster
+// supports.
+func CurrentSettings(db *sql.DB) (map[string]string, error) {
+	rows, err := db.Query(`SELECT name, current_setting(name) FROM pg_settings`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying pg_settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := make(map[string]string)
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+
+		settings[name] = value
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
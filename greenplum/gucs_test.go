@@ -0,0 +1,191 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestTranslateGUC(t *testing.T) {
+	cases := []struct {
+		name          string
+		value         string
+		sourceMajor   uint64
+		targetMajor   uint64
+		expectedName  string
+		expectedValue string
+		reason        string
+	}{
+		{name: "statement_mem", value: "250MB", sourceMajor: 5, targetMajor: 6, expectedName: "statement_mem", expectedValue: "250MB"},
+		{name: "unix_socket_directory", value: "/tmp", sourceMajor: 5, targetMajor: 6, expectedName: "unix_socket_directories", expectedValue: "/tmp"},
+		{name: "gp_workfile_compress_algorithm", value: "zlib", sourceMajor: 5, targetMajor: 6, expectedName: "gp_workfile_compression", expectedValue: "on"},
+		{name: "gp_workfile_compress_algorithm", value: "none", sourceMajor: 5, targetMajor: 6, expectedName: "gp_workfile_compression", expectedValue: "off"},
+		{name: "max_fsm_pages", value: "200000", sourceMajor: 5, targetMajor: 6, reason: "the free space map is managed automatically since PostgreSQL 8.4"},
+		{name: "checkpoint_segments", value: "8", sourceMajor: 6, targetMajor: 7, expectedName: "max_wal_size", expectedValue: "384MB"},
+		{name: "checkpoint_segments", value: "8", sourceMajor: 5, targetMajor: 6, expectedName: "checkpoint_segments", expectedValue: "8"},
+		{name: "default_with_oids", value: "off", sourceMajor: 6, targetMajor: 7, reason: "tables with OIDs were removed in PostgreSQL 12"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name+"="+c.value, func(t *testing.T) {
+			name, value, reason, err := greenplum.TranslateGUC(c.name, c.value, c.sourceMajor, c.targetMajor)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if name != c.expectedName || value != c.expectedValue || reason != c.reason {
+				t.Errorf("got %q, %q, %q want %q, %q, %q", name, value, reason, c.expectedName, c.expectedValue, c.reason)
+			}
+		})
+	}
+
+	t.Run("errors when a value cannot be converted", func(t *testing.T) {
+		_, _, _, err := greenplum.TranslateGUC("checkpoint_segments", "many", 6, 7)
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestPlanGUCMigration(t *testing.T) {
+	t.Run("plans the settings to carry over and reports the rest", func(t *testing.T) {
+		coordinator := map[string]string{
+			"port":                  "5432",
+			"gp_vmem_protect_limit": "8192",
+			"statement_mem":         "250MB",
+			"log_statement":         "ddl",
+			"max_fsm_pages":         "200000",
+			"unix_socket_directory": "/tmp",
+		}
+
+		segments := map[int]map[string]string{
+			0: {"port": "25432", "gp_vmem_protect_limit": "16384", "statement_mem": "250MB", "gp_interconnect_queue_depth": "8", "max_fsm_pages": "200000", "unix_socket_directory": "/tmp", "work_mem": "64MB"},
+			1: {"port": "25433", "gp_vmem_protect_limit": "16384", "statement_mem": "250MB", "gp_interconnect_queue_depth": "16", "max_fsm_pages": "200000", "unix_socket_directory": "/tmp", "work_mem": "64MB"},
+		}
+
+		gucs, unmigratable, err := greenplum.PlanGUCMigration(coordinator, segments, 5, 6)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []greenplum.GUC{
+			{SourceName: "gp_vmem_protect_limit", Name: "gp_vmem_protect_limit", Value: "16384", CoordinatorValue: "8192"},
+			{SourceName: "log_statement", Name: "log_statement", Value: "ddl", CoordinatorOnly: true},
+			{SourceName: "statement_mem", Name: "statement_mem", Value: "250MB"},
+			{SourceName: "unix_socket_directory", Name: "unix_socket_directories", Value: "/tmp"},
+		}
+		if !reflect.DeepEqual(gucs, expected) {
+			t.Errorf("got gucs %+v want %+v", gucs, expected)
+		}
+
+		expectedUnmigratable := []greenplum.UnmigratableGUC{
+			{Name: "gp_interconnect_queue_depth", Value: "8", Reason: "the value differs between segments"},
+			{Name: "max_fsm_pages", Value: "200000", Reason: "the free space map is managed automatically since PostgreSQL 8.4"},
+			{Name: "work_mem", Value: "64MB", Reason: "set on the segments but not the coordinator"},
+		}
+		if !reflect.DeepEqual(unmigratable, expectedUnmigratable) {
+			t.Errorf("got unmigratable %+v want %+v", unmigratable, expectedUnmigratable)
+		}
+	})
+
+	t.Run("reports settings missing from some segments", func(t *testing.T) {
+		coordinator := map[string]string{"work_mem": "64MB"}
+		segments := map[int]map[string]string{
+			0: {},
+			1: {"work_mem": "64MB"},
+		}
+
+		gucs, unmigratable, err := greenplum.PlanGUCMigration(coordinator, segments, 6, 7)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(gucs) != 0 || len(unmigratable) != 1 || unmigratable[0].Reason != "the value differs between segments" {
+			t.Errorf("got gucs %+v and unmigratable %+v", gucs, unmigratable)
+		}
+	})
+}
+
+func TestMissingLibraries(t *testing.T) {
+	gphome := t.TempDir()
+	libdir := filepath.Join(gphome, "lib", "postgresql")
+	if err := os.MkdirAll(libdir, 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutils.MustWriteToFile(t, filepath.Join(libdir, "metrics_collector.so"), "")
+	testutils.MustWriteToFile(t, filepath.Join(libdir, "auto_explain.so"), "")
+
+	cases := []struct {
+		libraries string
+		expected  []string
+	}{
+		{libraries: "", expected: nil},
+		{libraries: "metrics_collector, $libdir/auto_explain.so", expected: nil},
+		{libraries: `"auto_explain",pg_stat_statements`, expected: []string{"pg_stat_statements"}},
+		{libraries: "/usr/local/greenplum-db-source/lib/postgresql/diskquota.so,metrics_collector", expected: []string{"/usr/local/greenplum-db-source/lib/postgresql/diskquota.so"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.libraries, func(t *testing.T) {
+			missing := greenplum.MissingLibraries(gphome, c.libraries)
+			if !reflect.DeepEqual(missing, c.expected) {
+				t.Errorf("got %q want %q", missing, c.expected)
+			}
+		})
+	}
+}
+
+func TestReadPostgresqlConf(t *testing.T) {
+	t.Run("overrides postgresql.conf with postgresql.auto.conf", func(t *testing.T) {
+		dir := t.TempDir()
+		testutils.MustWriteToFile(t, filepath.Join(dir, "postgresql.conf"), "work_mem = 32MB\nstatement_mem = 125MB\n")
+		testutils.MustWriteToFile(t, filepath.Join(dir, "postgresql.auto.conf"), "work_mem = '64MB'\n")
+
+		settings, err := greenplum.ReadPostgresqlConf(dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := map[string]string{"work_mem": "64MB", "statement_mem": "125MB"}
+		if !reflect.DeepEqual(settings, expected) {
+			t.Errorf("got %v want %v", settings, expected)
+		}
+	})
+
+	t.Run("errors when postgresql.conf does not exist", func(t *testing.T) {
+		_, err := greenplum.ReadPostgresqlConf(t.TempDir())
+		if !os.IsNotExist(err) {
+			t.Errorf("got error %v want not exist", err)
+		}
+	})
+}
+
+func TestCurrentSettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("couldn't create sqlmock: %v", err)
+	}
+	defer testutils.FinishMock(mock, t)
+
+	mock.ExpectQuery("SELECT name, current_setting").WillReturnRows(
+		sqlmock.NewRows([]string{"name", "current_setting"}).AddRow("work_mem", "32MB").AddRow("statement_mem", "125MB"))
+
+	settings, err := greenplum.CurrentSettings(db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"work_mem": "32MB", "statement_mem": "125MB"}
+	if !reflect.DeepEqual(settings, expected) {
+		t.Errorf("got %v want %v", settings, expected)
+	}
+}
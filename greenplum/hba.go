@@ -0,0 +1,327 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// HBAMigrationMarker separates the entries required by the target cluster from
+// the rules migrated from the source cluster. Everything after it is replaced
+// when the rules are migrated again.
+const HBAMigrationMarker = "# Rules migrated from the source cluster by gpupgrade"
+
+// ReplicationHBAEntries returns the pg_hba.conf entries allowing user to
+// connect and replicate from the local host and each of hostAddrs, such as
+// those of a mirror or the standby.
+func ReplicationHBAEntries(user string, hostAddrs []string) string {
+	var lines strings.Builder
+	lines.WriteString(fmt.Sprintf("host replication %s samehost trust\n", user))
+	for _, hostAddr := range hostAddrs {
+		lines.WriteString(fmt.Sprintf("host all %s %s trust\n", user, hostAddr))
+		lines.WriteString(fmt.Sprintf("host replication %s %s trust\n", user, hostAddr))
+	}
+
+	return lines.String()
+}
+
+// HBARecord is a record of pg_hba.conf. Mask is only set when the address and
+// mask are separate fields.
+type HBARecord struct {
+	Type     string
+	Database string
+	User     string
+	Address  string
+	Mask     string
+	Method   string
+	Options  []string
+}
+
+func (r HBARecord) String() string {
+	fields := []string{r.Type, r.Database, r.User}
+	if r.Type != "local" {
+		fields = append(fields, r.Address)
+		if r.Mask != "" {
+			fields = append(fields, r.Mask)
+		}
+	}
+
+	fields = append(fields, r.Method)
+	fields = append(fields, r.Options...)
+	return strings.Join(fields, " ")
+}
+
+// ParseHBARecord parses a single non-comment line of pg_hba.conf.
+func ParseHBARecord(line string) (HBARecord, error) {
+	fields := hbaFields(line)
+	if len(fields) < 4 {
+		return HBARecord{}, xerrors.Errorf("expected at least 4 fields in pg_hba.conf record %q", line)
+	}
+
+	record := HBARecord{Type: fields[0], Database: fields[1], User: fields[2]}
+	switch record.Type {
+	case "local":
+		record.Method = fields[3]
+		record.Options = fields[4:]
+	case "host", "hostssl", "hostnossl":
+		if len(fields) < 5 {
+			return HBARecord{}, xerrors.Errorf("expected an address and method in pg_hba.conf record %q", line)
+		}
+
+		record.Address = fields[3]
+		method := 4
+		if !strings.Contains(record.Address, "/") && net.ParseIP(record.Address) != nil {
+			if len(fields) < 6 {
+				return HBARecord{}, xerrors.Errorf("expected a mask and method in pg_hba.conf record %q", line)
+			}
+
+			record.Mask = fields[4]
+			method = 5
+		}
+
+		record.Method = fields[method]
+		record.Options = fields[method+1:]
+	default:
+		return HBARecord{}, xerrors.Errorf("unrecognized connection type %q in pg_hba.conf record %q", record.Type, line)
+	}
+
+	return record, nil
+}
+
+// hbaFields splits a pg_hba.conf line on whitespace up to any comment keeping
+// double quoted fields intact.
+func hbaFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	quoted := false
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			field.WriteRune(r)
+		case r == '#' && !quoted:
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+			}
+			return fields
+		case (r == ' ' || r == '\t') && !quoted:
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteRune(r)
+		}
+	}
+
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+
+	return fields
+}
+
+// HBAChanges is keyed by the source major version. Each translates a record to
+// the next major version in place, returning a reason when it cannot be
+// carried over.
+var HBAChanges = map[uint64]func(record *HBARecord) string{
+	5: translateHBAFrom5,
+	6: translateHBAFrom6,
+}
+
+// translateHBAFrom5 handles the authentication options changing from a single
+// positional option to name=value options in PostgreSQL 8.4.
+func translateHBAFrom5(record *HBARecord) string {
+	// a quoted option such as an ldap URL may itself contain an equals sign
+	positional := len(record.Options) == 1 &&
+		(strings.HasPrefix(record.Options[0], `"`) || !strings.Contains(record.Options[0], "="))
+
+	switch record.Method {
+	case "crypt":
+		return "the crypt authentication method was removed in PostgreSQL 8.4"
+	case "krb5":
+		return "the krb5 authentication method was removed in PostgreSQL 9.4, use gss instead"
+	case "ident":
+		if positional {
+			if record.Options[0] == "sameuser" {
+				record.Options = nil
+			} else {
+				record.Options = []string{"map=" + record.Options[0]}
+			}
+		}
+	case "pam":
+		if positional {
+			record.Options = []string{"pamservice=" + record.Options[0]}
+		}
+	case "ldap":
+		if positional {
+			return "the ldap URL must be rewritten as name=value options since PostgreSQL 8.4"
+		}
+	}
+
+	return ""
+}
+
+// translateHBAFrom6 spells out clientcert=1 as PostgreSQL 12 does. md5 is kept
+// rather than moving to scram-sha-256 as pg_upgrade preserves the md5 password
+// hashes, which scram-sha-256 rejects, while md5 also accepts SCRAM verifiers.
+func translateHBAFrom6(record *HBARecord) string {
+	for i, option := range record.Options {
+		if option == "clientcert=1" {
+			record.Options[i] = "clientcert=verify-ca"
+		}
+	}
+
+	return ""
+}
+
+// TranslateHBARecord translates a record across each major version from source
+// to target. It returns a reason when the record cannot be carried over.
+func TranslateHBARecord(record HBARecord, sourceMajor uint64, targetMajor uint64) (HBARecord, string) {
+	record.Options = append([]string(nil), record.Options...)
+
+	for major := sourceMajor; major < targetMajor; major++ {
+		translate, ok := HBAChanges[major]
+		if !ok {
+			continue
+		}
+
+		if reason := translate(&record); reason != "" {
+			return HBARecord{}, reason
+		}
+	}
+
+	return record, ""
+}
+
+// UnmigratableHBARule is a source pg_hba.conf rule that is not carried over to
+// the target.
+type UnmigratableHBARule struct {
+	Rule   string
+	Reason string
+}
+
+func (u UnmigratableHBARule) String() string {
+	return fmt.Sprintf("%q: %s", u.Rule, u.Reason)
+}
+
+// MergeHBA returns the target pg_hba.conf with the source rules translated and
+// appended after HBAMigrationMarker. Target entries such as those written by
+// gpinitsystem and for replication are kept first so gpupgrade and the mirrors
+// can still connect, while target records copied from the source are replaced
+// by their translation.
+func MergeHBA(target string, source string, sourceMajor uint64, targetMajor uint64) (string, []UnmigratableHBARule) {
+	target, _, _ = strings.Cut(target, HBAMigrationMarker)
+
+	sourceRecords := make(map[string]bool)
+	for _, line := range strings.Split(source, "\n") {
+		if fields := hbaFields(line); len(fields) > 0 {
+			sourceRecords[strings.Join(fields, " ")] = true
+		}
+	}
+
+	var lines []string
+	kept := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimRight(target, "\n"), "\n") {
+		fields := hbaFields(line)
+		if len(fields) > 0 {
+			normalized := strings.Join(fields, " ")
+			if sourceRecords[normalized] {
+				continue
+			}
+
+			kept[normalized] = true
+		}
+
+		lines = append(lines, line)
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	lines = append(lines, "", HBAMigrationMarker)
+
+	var unmigratable []UnmigratableHBARule
+	for _, line := range strings.Split(source, "\n") {
+		if len(hbaFields(line)) == 0 {
+			continue
+		}
+
+		rule := strings.TrimSpace(line)
+		record, err := ParseHBARecord(line)
+		if err != nil {
+			unmigratable = append(unmigratable, UnmigratableHBARule{Rule: rule, Reason: err.Error()})
+			continue
+		}
+
+		translated, reason := TranslateHBARecord(record, sourceMajor, targetMajor)
+		if reason != "" {
+			unmigratable = append(unmigratable, UnmigratableHBARule{Rule: rule, Reason: reason})
+			continue
+		}
+
+		normalized := strings.Join(hbaFields(translated.String()), " ")
+		if kept[normalized] {
+			continue
+		}
+		kept[normalized] = true
+
+		lines = append(lines, translated.String())
+	}
+
+	for _, rule := range unmigratable {
+		lines = append(lines, fmt.Sprintf("# not migrated: %s (%s)", rule.Rule, rule.Reason))
+	}
+
+	return strings.Join(lines, "\n") + "\n", unmigratable
+}
+
+// DiffLines returns the lines removed from before prefixed with "- " and those
+// added in after prefixed with "+ " in order of appearance.
+func DiffLines(before string, after string) []string {
+	a := strings.Split(strings.TrimRight(before, "\n"), "\n")
+	b := strings.Split(strings.TrimRight(after, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, "- "+a[i])
+			i++
+		default:
+			diff = append(diff, "+ "+b[j])
+			j++
+		}
+	}
+
+	return diff
+}
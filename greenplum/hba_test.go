@@ -0,0 +1,199 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+)
+
+func TestReplicationHBAEntries(t *testing.T) {
+	entries := greenplum.ReplicationHBAEntries("gpadmin", []string{"10.0.0.1/32", "fd00::1/128"})
+	expected := `host replication gpadmin samehost trust
+host all gpadmin 10.0.0.1/32 trust
+host replication gpadmin 10.0.0.1/32 trust
+host all gpadmin fd00::1/128 trust
+host replication gpadmin fd00::1/128 trust
+`
+	if entries != expected {
+		t.Errorf("got %q want %q", entries, expected)
+	}
+}
+
+func TestParseHBARecord(t *testing.T) {
+	cases := []struct {
+		name     string
+		line     string
+		expected greenplum.HBARecord
+	}{
+		{
+			name:     "local",
+			line:     "local all gpadmin ident",
+			expected: greenplum.HBARecord{Type: "local", Database: "all", User: "gpadmin", Method: "ident", Options: []string{}},
+		},
+		{
+			name:     "host with CIDR address and options",
+			line:     "hostssl\tsales  +analysts 10.0.0.0/8  cert clientcert=1 # analysts",
+			expected: greenplum.HBARecord{Type: "hostssl", Database: "sales", User: "+analysts", Address: "10.0.0.0/8", Method: "cert", Options: []string{"clientcert=1"}},
+		},
+		{
+			name:     "host with separate mask",
+			line:     "host all all 192.168.0.0 255.255.0.0 md5",
+			expected: greenplum.HBARecord{Type: "host", Database: "all", User: "all", Address: "192.168.0.0", Mask: "255.255.0.0", Method: "md5", Options: []string{}},
+		},
+		{
+			name:     "quoted fields",
+			line:     `host all all sdw1 ldap ldapserver=ldap.example.com ldapprefix="cn=# " ldapsuffix=", dc=example"`,
+			expected: greenplum.HBARecord{Type: "host", Database: "all", User: "all", Address: "sdw1", Method: "ldap", Options: []string{"ldapserver=ldap.example.com", `ldapprefix="cn=# "`, `ldapsuffix=", dc=example"`}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			record, err := greenplum.ParseHBARecord(c.line)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(record, c.expected) {
+				t.Errorf("got %#v want %#v", record, c.expected)
+			}
+		})
+	}
+
+	t.Run("errors on invalid records", func(t *testing.T) {
+		for _, line := range []string{"local all gpadmin", "host all all 10.0.0.1 md5", "hostgssenc all all 10.0.0.0/8 md5"} {
+			_, err := greenplum.ParseHBARecord(line)
+			if err == nil {
+				t.Errorf("expected an error for %q", line)
+			}
+		}
+	})
+}
+
+func TestTranslateHBARecord(t *testing.T) {
+	cases := []struct {
+		name        string
+		line        string
+		sourceMajor uint64
+		targetMajor uint64
+		expected    string
+		reason      string
+	}{
+		{name: "keeps records without changes", line: "host all all 10.0.0.0/8 md5", sourceMajor: 6, targetMajor: 7, expected: "host all all 10.0.0.0/8 md5"},
+		{name: "drops ident sameuser", line: "local all all ident sameuser", sourceMajor: 5, targetMajor: 6, expected: "local all all ident"},
+		{name: "names the ident map", line: "host all all 10.0.0.0/8 ident admins", sourceMajor: 5, targetMajor: 6, expected: "host all all 10.0.0.0/8 ident map=admins"},
+		{name: "names the pam service", line: "host all all 10.0.0.0/8 pam gpdb", sourceMajor: 5, targetMajor: 6, expected: "host all all 10.0.0.0/8 pam pamservice=gpdb"},
+		{name: "spells out clientcert", line: "hostssl all all 10.0.0.0/8 md5 clientcert=1", sourceMajor: 6, targetMajor: 7, expected: "hostssl all all 10.0.0.0/8 md5 clientcert=verify-ca"},
+		{name: "translates across several major versions", line: "host all all 10.0.0.0/8 ident admins", sourceMajor: 5, targetMajor: 7, expected: "host all all 10.0.0.0/8 ident map=admins"},
+		{name: "rejects crypt", line: "host all all 10.0.0.0/8 crypt", sourceMajor: 5, targetMajor: 6, reason: "the crypt authentication method was removed in PostgreSQL 8.4"},
+		{name: "rejects krb5", line: "host all all 10.0.0.0/8 krb5", sourceMajor: 5, targetMajor: 7, reason: "the krb5 authentication method was removed in PostgreSQL 9.4, use gss instead"},
+		{name: "rejects ldap URLs", line: `host all all 10.0.0.0/8 ldap "ldap://ldap.example.com/dc=example;cn=;"`, sourceMajor: 5, targetMajor: 6, reason: "the ldap URL must be rewritten as name=value options since PostgreSQL 8.4"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			record, err := greenplum.ParseHBARecord(c.line)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			translated, reason := greenplum.TranslateHBARecord(record, c.sourceMajor, c.targetMajor)
+			if reason != c.reason {
+				t.Errorf("got reason %q want %q", reason, c.reason)
+			}
+
+			if c.reason == "" && translated.String() != c.expected {
+				t.Errorf("got %q want %q", translated.String(), c.expected)
+			}
+		})
+	}
+
+	t.Run("does not modify the original record", func(t *testing.T) {
+		record, err := greenplum.ParseHBARecord("hostssl all all 10.0.0.0/8 md5 clientcert=1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		greenplum.TranslateHBARecord(record, 6, 7)
+		if record.Options[0] != "clientcert=1" {
+			t.Errorf("expected the original options to be unchanged got %q", record.Options)
+		}
+	})
+}
+
+func TestMergeHBA(t *testing.T) {
+	target := `# generated by gpinitsystem
+local    all         gpadmin         ident
+host     all         gpadmin         127.0.0.1/28    trust
+host all gpadmin 10.0.0.2/32 trust
+host replication gpadmin 10.0.0.2/32 trust
+`
+
+	source := `local    all         gpadmin         ident
+host     all         gpadmin         127.0.0.1/28    trust
+host all gpadmin 10.0.0.1/32 trust
+hostssl sales analyst 10.1.0.0/16 md5 clientcert=1
+host all all 10.2.0.0/16 krb5
+`
+
+	expected := `# generated by gpinitsystem
+host all gpadmin 10.0.0.2/32 trust
+host replication gpadmin 10.0.0.2/32 trust
+
+` + greenplum.HBAMigrationMarker + `
+local all gpadmin ident
+host all gpadmin 127.0.0.1/28 trust
+host all gpadmin 10.0.0.1/32 trust
+hostssl sales analyst 10.1.0.0/16 md5 clientcert=verify-ca
+# not migrated: host all all 10.2.0.0/16 krb5 (the krb5 authentication method was removed in PostgreSQL 9.4, use gss instead)
+`
+
+	t.Run("keeps the required target entries and appends the translated source rules", func(t *testing.T) {
+		merged, unmigratable := greenplum.MergeHBA(target, source, 5, 7)
+		if merged != expected {
+			t.Errorf("got\n%s\nwant\n%s", merged, expected)
+		}
+
+		expectedUnmigratable := []greenplum.UnmigratableHBARule{{
+			Rule:   "host all all 10.2.0.0/16 krb5",
+			Reason: "the krb5 authentication method was removed in PostgreSQL 9.4, use gss instead",
+		}}
+		if !reflect.DeepEqual(unmigratable, expectedUnmigratable) {
+			t.Errorf("got %v want %v", unmigratable, expectedUnmigratable)
+		}
+	})
+
+	t.Run("merging again is idempotent", func(t *testing.T) {
+		merged, _ := greenplum.MergeHBA(expected, source, 5, 7)
+		if merged != expected {
+			t.Errorf("got\n%s\nwant\n%s", merged, expected)
+		}
+	})
+
+	t.Run("does not duplicate source rules already in the target", func(t *testing.T) {
+		merged, _ := greenplum.MergeHBA(target, "host replication gpadmin 10.0.0.2/32 trust\n", 6, 7)
+		if strings.Count(merged, "host replication gpadmin 10.0.0.2/32 trust") != 1 {
+			t.Errorf("expected a single replication entry in\n%s", merged)
+		}
+	})
+}
+
+func TestDiffLines(t *testing.T) {
+	before := "a\nb\nc\n"
+	after := "a\nc\nd\n"
+
+	diff := greenplum.DiffLines(before, after)
+	expected := []string{"- b", "+ d"}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("got %q want %q", diff, expected)
+	}
+
+	if diff := greenplum.DiffLines(before, before); len(diff) != 0 {
+		t.Errorf("expected no differences got %q", diff)
+	}
+}
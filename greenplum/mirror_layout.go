@@ -0,0 +1,492 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+type MirrorLayoutPolicy string
+
+const (
+	// GroupMirrors places all mirrors of the primaries on a host onto the next
+	// host.
+	GroupMirrors MirrorLayoutPolicy = "group"
+
+	// SpreadMirrors places each mirror of the primaries on a host onto a
+	// different host.
+	SpreadMirrors MirrorLayoutPolicy = "spread"
+)
+
+// MirrorPlacement is the location of the mirror of a content.
+type MirrorPlacement struct {
+	Hostname string
+	Port     int
+	DataDir  string
+}
+
+// MirrorLayout lays out the target cluster's mirrors either by a policy
+// matching that of gpaddmirrors or by explicit placements keyed by content.
+// Contents without a placement keep their existing mirror. Policy placed
+// mirrors use Port as the first port on each host and are placed within
+// DataDir when set.
+type MirrorLayout struct {
+	Policy     MirrorLayoutPolicy
+	Port       int
+	DataDir    string
+	Placements map[int]MirrorPlacement
+}
+
+// ParseMirrorLayout parses either a policy optionally followed by the first
+// port and parent data directory of the mirrors such as "spread" or
+// "spread:7000:/data/mirror", or a comma separated list of
+// content=host:port:datadir placements such as
+// "0=sdw2:7000:/data/mirror/gpseg0,1=sdw1:7000:/data/mirror/gpseg1". IPv6
+// addresses must be enclosed in brackets.
+func ParseMirrorLayout(input string) (MirrorLayout, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return MirrorLayout{}, nil
+	}
+
+	if !strings.Contains(input, "=") {
+		return parsePolicy(input)
+	}
+
+	placements := make(map[int]MirrorPlacement)
+	for _, entry := range strings.Split(input, ",") {
+		entry = strings.TrimSpace(entry)
+
+		contentStr, location, ok := strings.Cut(entry, "=")
+		if !ok {
+			return MirrorLayout{}, xerrors.Errorf("expected content=host:port:datadir but got %q", entry)
+		}
+
+		content, err := strconv.Atoi(strings.TrimSpace(contentStr))
+		if err != nil || content < 0 {
+			return MirrorLayout{}, xerrors.Errorf("expected a segment content id but got %q", contentStr)
+		}
+
+		if _, ok := placements[content]; ok {
+			return MirrorLayout{}, xerrors.Errorf("content %d is placed more than once", content)
+		}
+
+		placement, err := ParseMirrorPlacement(location)
+		if err != nil {
+			return MirrorLayout{}, xerrors.Errorf("content %d: %w", content, err)
+		}
+
+		placements[content] = placement
+	}
+
+	return MirrorLayout{Placements: placements}, nil
+}
+
+func parsePolicy(input string) (MirrorLayout, error) {
+	policy, base, hasBase := strings.Cut(input, ":")
+
+	layout := MirrorLayout{Policy: MirrorLayoutPolicy(policy)}
+	if layout.Policy != GroupMirrors && layout.Policy != SpreadMirrors {
+		return MirrorLayout{}, xerrors.Errorf("expected mirror layout of %q, %q, or content=host:port:datadir placements but got %q", GroupMirrors, SpreadMirrors, input)
+	}
+
+	if !hasBase {
+		return layout, nil
+	}
+
+	portStr, dataDir, ok := strings.Cut(base, ":")
+	if !ok || !filepath.IsAbs(dataDir) {
+		return MirrorLayout{}, xerrors.Errorf("expected policy:port:datadir with an absolute datadir but got %q", input)
+	}
+
+	port, err := parsePort(portStr)
+	if err != nil {
+		return MirrorLayout{}, err
+	}
+
+	layout.Port = port
+	layout.DataDir = filepath.Clean(dataDir)
+	return layout, nil
+}
+
+// ParseMirrorPlacement parses a host:port:datadir location. IPv6 addresses
+// must be enclosed in brackets.
+func ParseMirrorPlacement(input string) (MirrorPlacement, error) {
+	input = strings.TrimSpace(input)
+
+	i := strings.Index(input, ":/")
+	if i < 0 {
+		return MirrorPlacement{}, xerrors.Errorf("expected host:port:datadir with an absolute datadir but got %q", input)
+	}
+
+	host, portStr, err := net.SplitHostPort(input[:i])
+	if err != nil || host == "" {
+		return MirrorPlacement{}, xerrors.Errorf("expected host:port:datadir but got %q", input)
+	}
+
+	port, err := parsePort(portStr)
+	if err != nil {
+		return MirrorPlacement{}, err
+	}
+
+	return MirrorPlacement{
+		Hostname: host,
+		Port:     port,
+		DataDir:  filepath.Clean(input[i+1:]),
+	}, nil
+}
+
+func parsePort(input string) (int, error) {
+	port, err := strconv.Atoi(input)
+	if err != nil || port <= 0 || port > 65535 {
+		return 0, xerrors.Errorf("expected a port between 1 and 65535 but got %q", input)
+	}
+
+	return port, nil
+}
+
+func (l MirrorLayout) IsEmpty() bool {
+	return l.Policy == "" && len(l.Placements) == 0
+}
+
+func (l MirrorLayout) String() string {
+	if l.Policy != "" && l.DataDir != "" {
+		return fmt.Sprintf("%s:%d:%s", l.Policy, l.Port, l.DataDir)
+	}
+
+	if l.Policy != "" {
+		return string(l.Policy)
+	}
+
+	var contents []int
+	for content := range l.Placements {
+		contents = append(contents, content)
+	}
+	sort.Ints(contents)
+
+	var entries []string
+	for _, content := range contents {
+		p := l.Placements[content]
+		entries = append(entries, fmt.Sprintf("%d=%s:%s", content, net.JoinHostPort(p.Hostname, strconv.Itoa(p.Port)), p.DataDir))
+	}
+
+	return strings.Join(entries, ",")
+}
+
+// Mirrors returns the mirrors of the source cluster, including any standby,
+// re-laid out and validated against the source hosts. Mirrors keep their dbid
+// and, when placed by a policy, their data directory. Policy placed mirrors
+// are given consecutive ports per host starting at the lowest source mirror
+// port.
+func (l MirrorLayout) Mirrors(source *Cluster) (ContentToSegConfig, error) {
+	if !source.HasMirrors() {
+		return nil, xerrors.New("mirrors can only be re-laid out when the source cluster has mirrors")
+	}
+
+	placements, err := l.placements(source, source.Mirrors.ExcludingStandby())
+	if err != nil {
+		return nil, err
+	}
+
+	mirrors := make(ContentToSegConfig)
+	for content, mirror := range source.Mirrors {
+		mirrors[content] = mirror
+	}
+
+	for content, placement := range placements {
+		mirror, ok := source.Mirrors[content]
+		if !ok || mirror.IsStandby() {
+			err = errorlist.Append(err, xerrors.Errorf("content %d does not have a mirror in the source cluster", content))
+			continue
+		}
+
+		mirror.Hostname = placement.Hostname
+		mirror.Address = placement.Hostname
+		mirror.Port = placement.Port
+		mirror.DataDir = placement.DataDir
+		mirrors[content] = mirror
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateMirrors(source, mirrors); err != nil {
+		return nil, err
+	}
+
+	return mirrors, nil
+}
+
+// AddMirrors returns mirrors, adding new mirrors placed by layout when the
+// source cluster has none and a standby when the source cluster has none. New
+// segments are given the dbids following those of the source cluster with the
+// standby last, matching how gpaddmirrors and gpinitstandby number them.
+func AddMirrors(source *Cluster, mirrors ContentToSegConfig, layout MirrorLayout, standby *MirrorPlacement) (ContentToSegConfig, error) {
+	added := make(ContentToSegConfig)
+	for content, mirror := range mirrors {
+		added[content] = mirror
+	}
+
+	nextDbID := 1
+	for _, seg := range source.SelectSegments(func(*SegConfig) bool { return true }) {
+		if seg.DbID >= nextDbID {
+			nextDbID = seg.DbID + 1
+		}
+	}
+
+	if !layout.IsEmpty() {
+		if source.HasMirrors() {
+			return nil, xerrors.New("mirrors can only be added when the source cluster has no mirrors")
+		}
+
+		if layout.Policy != "" && layout.DataDir == "" {
+			return nil, xerrors.Errorf("expected %s:port:datadir since the source cluster has no mirrors", layout.Policy)
+		}
+
+		placements, err := layout.placements(source, source.Primaries.ExcludingCoordinator())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, content := range sortedContents(source.Primaries.ExcludingCoordinator()) {
+			placement, ok := placements[content]
+			if !ok {
+				err = errorlist.Append(err, xerrors.Errorf("content %d does not have a mirror placement", content))
+				continue
+			}
+
+			added[content] = SegConfig{
+				DbID:      nextDbID,
+				ContentID: content,
+				Port:      placement.Port,
+				Hostname:  placement.Hostname,
+				Address:   placement.Hostname,
+				DataDir:   placement.DataDir,
+				Role:      MirrorRole,
+			}
+			nextDbID++
+		}
+
+		for content := range placements {
+			if _, ok := source.Primaries[content]; !ok || content < 0 {
+				err = errorlist.Append(err, xerrors.Errorf("content %d does not have a primary in the source cluster", content))
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if standby != nil {
+		if source.HasStandby() {
+			return nil, xerrors.New("a standby can only be added when the source cluster has no standby")
+		}
+
+		added[-1] = SegConfig{
+			DbID:      nextDbID,
+			ContentID: -1,
+			Port:      standby.Port,
+			Hostname:  standby.Hostname,
+			Address:   standby.Hostname,
+			DataDir:   standby.DataDir,
+			Role:      MirrorRole,
+		}
+	}
+
+	if err := validateMirrors(source, added); err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// placements returns the placements of the mirrors of the given primaries or
+// existing mirrors. By default policy placed mirrors keep the data directory
+// of the existing mirror and start at its lowest port.
+func (l MirrorLayout) placements(source *Cluster, segs ContentToSegConfig) (map[int]MirrorPlacement, error) {
+	if l.Policy == "" {
+		return l.Placements, nil
+	}
+
+	basePort := l.Port
+	if basePort == 0 {
+		for _, seg := range segs {
+			if basePort == 0 || seg.Port < basePort {
+				basePort = seg.Port
+			}
+		}
+	}
+
+	mirrorHosts, err := policyHosts(source, l.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	placements := make(map[int]MirrorPlacement)
+	nextPort := make(map[string]int)
+	for _, content := range sortedContents(segs) {
+		host, ok := mirrorHosts[content]
+		if !ok {
+			continue
+		}
+
+		dataDir := segs[content].DataDir
+		if l.DataDir != "" {
+			dataDir = filepath.Join(l.DataDir, filepath.Base(dataDir))
+		}
+
+		placements[content] = MirrorPlacement{
+			Hostname: host,
+			Port:     basePort + nextPort[host],
+			DataDir:  dataDir,
+		}
+		nextPort[host]++
+	}
+
+	return placements, nil
+}
+
+// policyHosts places the mirrors the same way as gpaddmirrors. Hosts are
+// ordered by name and primaries on a host by content. With group the mirrors
+// of the primaries on a host are placed on the next host, while with spread
+// the mirror of the nth primary on a host is placed n+1 hosts away.
+func policyHosts(source *Cluster, policy MirrorLayoutPolicy) (map[int]string, error) {
+	primariesByHost := make(map[string][]int)
+	for content, primary := range source.Primaries {
+		if content < 0 {
+			continue
+		}
+
+		primariesByHost[primary.Hostname] = append(primariesByHost[primary.Hostname], content)
+	}
+
+	var hosts []string
+	for host, contents := range primariesByHost {
+		sort.Ints(contents)
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	if len(hosts) < 2 {
+		return nil, xerrors.Errorf("%s mirroring requires at least 2 segment hosts but found %d", policy, len(hosts))
+	}
+
+	mirrorHost := make(map[int]string)
+	for i, host := range hosts {
+		contents := primariesByHost[host]
+		if policy == SpreadMirrors && len(contents) >= len(hosts) {
+			return nil, xerrors.Errorf("spread mirroring requires more segment hosts than the %d primaries on host %s but found %d", len(contents), host, len(hosts))
+		}
+
+		for n, content := range contents {
+			offset := 1
+			if policy == SpreadMirrors {
+				offset += n
+			}
+
+			mirrorHost[content] = hosts[(i+offset)%len(hosts)]
+		}
+	}
+
+	return mirrorHost, nil
+}
+
+// validateMirrors ensures the mirrors are placed on segment hosts apart from
+// their primaries, and that no mirror or standby shares a port or data
+// directory with another segment of either cluster on the same host.
+func validateMirrors(source *Cluster, mirrors ContentToSegConfig) error {
+	segmentHosts := make(map[string]bool)
+	for _, seg := range source.SelectSegments(func(seg *SegConfig) bool { return seg.ContentID >= 0 }) {
+		segmentHosts[seg.Hostname] = true
+	}
+
+	type hostPort struct {
+		host string
+		port int
+	}
+
+	type hostDir struct {
+		host string
+		dir  string
+	}
+
+	ports := make(map[hostPort]int)
+	dirs := make(map[hostDir]int)
+	for _, primary := range source.Primaries {
+		ports[hostPort{primary.Hostname, primary.Port}] = primary.DbID
+		dirs[hostDir{primary.Hostname, primary.DataDir}] = primary.DbID
+	}
+
+	// Relocated mirrors cannot take over the data directory of another source
+	// mirror since finalize archives the source data directories.
+	for _, mirror := range source.Mirrors {
+		dirs[hostDir{mirror.Hostname, mirror.DataDir}] = mirror.DbID
+	}
+
+	// Check the mirrors that were left in place first so that conflicts are
+	// reported against the relocated mirrors.
+	var contents []int
+	for content := range mirrors {
+		contents = append(contents, content)
+	}
+	sort.Slice(contents, func(i, j int) bool {
+		iMoved := mirrors[contents[i]] != source.Mirrors[contents[i]]
+		jMoved := mirrors[contents[j]] != source.Mirrors[contents[j]]
+		if iMoved != jMoved {
+			return !iMoved
+		}
+
+		return contents[i] < contents[j]
+	})
+
+	var err error
+	for _, content := range contents {
+		mirror := mirrors[content]
+
+		if !filepath.IsAbs(mirror.DataDir) {
+			err = errorlist.Append(err, xerrors.Errorf("%s has a relative data directory %q", segmentName(mirror), mirror.DataDir))
+		}
+
+		if mirror.IsStandby() {
+			continue
+		}
+
+		if !segmentHosts[mirror.Hostname] {
+			err = errorlist.Append(err, xerrors.Errorf("%s is placed on host %s which is not a segment host of the source cluster", segmentName(mirror), mirror.Hostname))
+		}
+
+		if mirror.Hostname == source.Primaries[content].Hostname {
+			err = errorlist.Append(err, xerrors.Errorf("%s is placed on the same host %s as its primary", segmentName(mirror), mirror.Hostname))
+		}
+	}
+
+	for _, content := range contents {
+		mirror := mirrors[content]
+
+		hp := hostPort{mirror.Hostname, mirror.Port}
+		if dbid, ok := ports[hp]; ok && dbid != mirror.DbID {
+			err = errorlist.Append(err, xerrors.Errorf("%s uses port %d on host %s which is already used by dbid %d", segmentName(mirror), mirror.Port, mirror.Hostname, dbid))
+		}
+		ports[hp] = mirror.DbID
+
+		hd := hostDir{mirror.Hostname, mirror.DataDir}
+		if dbid, ok := dirs[hd]; ok && dbid != mirror.DbID {
+			err = errorlist.Append(err, xerrors.Errorf("%s uses data directory %s on host %s which is already used by dbid %d", segmentName(mirror), mirror.DataDir, mirror.Hostname, dbid))
+		}
+		dirs[hd] = mirror.DbID
+	}
+
+	return err
+}
@@ -0,0 +1,380 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+)
+
+func TestParseMirrorLayout(t *testing.T) {
+	t.Run("parses policies", func(t *testing.T) {
+		for _, policy := range []greenplum.MirrorLayoutPolicy{greenplum.GroupMirrors, greenplum.SpreadMirrors} {
+			layout, err := greenplum.ParseMirrorLayout(" " + string(policy) + " ")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := greenplum.MirrorLayout{Policy: policy}
+			if !reflect.DeepEqual(layout, expected) {
+				t.Errorf("got %+v want %+v", layout, expected)
+			}
+		}
+	})
+
+	t.Run("parses policies with a port and data directory", func(t *testing.T) {
+		layout, err := greenplum.ParseMirrorLayout("spread:7000:/data/mirror/")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := greenplum.MirrorLayout{Policy: greenplum.SpreadMirrors, Port: 7000, DataDir: "/data/mirror"}
+		if !reflect.DeepEqual(layout, expected) {
+			t.Errorf("got %+v want %+v", layout, expected)
+		}
+
+		if layout.String() != "spread:7000:/data/mirror" {
+			t.Errorf("got %q want %q", layout.String(), "spread:7000:/data/mirror")
+		}
+	})
+
+	t.Run("parses placements", func(t *testing.T) {
+		layout, err := greenplum.ParseMirrorLayout("0=sdw2:7000:/data/mirror/gpseg0/, 1=[fd00::3]:7001:/data/mirror/gpseg1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := greenplum.MirrorLayout{Placements: map[int]greenplum.MirrorPlacement{
+			0: {Hostname: "sdw2", Port: 7000, DataDir: "/data/mirror/gpseg0"},
+			1: {Hostname: "fd00::3", Port: 7001, DataDir: "/data/mirror/gpseg1"},
+		}}
+		if !reflect.DeepEqual(layout, expected) {
+			t.Errorf("got %+v want %+v", layout, expected)
+		}
+
+		expectedString := "0=sdw2:7000:/data/mirror/gpseg0,1=[fd00::3]:7001:/data/mirror/gpseg1"
+		if layout.String() != expectedString {
+			t.Errorf("got %q want %q", layout.String(), expectedString)
+		}
+	})
+
+	t.Run("returns an empty layout for empty input", func(t *testing.T) {
+		layout, err := greenplum.ParseMirrorLayout("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !layout.IsEmpty() {
+			t.Errorf("got %+v want an empty layout", layout)
+		}
+	})
+
+	errCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "unknown policy", input: "ring", expected: "expected mirror layout"},
+		{name: "policy without datadir", input: "spread:7000", expected: "expected policy:port:datadir"},
+		{name: "policy with invalid port", input: "group:port:/data/mirror", expected: "expected a port between 1 and 65535"},
+		{name: "invalid content", input: "a=sdw2:7000:/data/mirror/gpseg0", expected: "expected a segment content id"},
+		{name: "coordinator content", input: "-1=sdw2:7000:/data/standby", expected: "expected a segment content id"},
+		{name: "duplicate content", input: "0=sdw2:7000:/data/m0,0=sdw3:7000:/data/m0", expected: "content 0 is placed more than once"},
+		{name: "relative datadir", input: "0=sdw2:7000:data/mirror/gpseg0", expected: "with an absolute datadir"},
+		{name: "missing port", input: "0=sdw2:/data/mirror/gpseg0", expected: "content 0: expected host:port:datadir"},
+		{name: "invalid port", input: "0=sdw2:70000:/data/mirror/gpseg0", expected: "expected a port between 1 and 65535"},
+	}
+
+	for _, c := range errCases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := greenplum.ParseMirrorLayout(c.input)
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("got error %v want %q", err, c.expected)
+			}
+		})
+	}
+}
+
+func TestMirrorLayoutMirrors(t *testing.T) {
+	// A lopsided cluster where sdw3 was added without rebalancing the mirrors.
+	source := MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/gpseg-1", Port: 5432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "scdw", DataDir: "/data/standby", Port: 5432, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/gpseg0", Port: 6000, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 1, Hostname: "sdw1", DataDir: "/data/primary/gpseg1", Port: 6001, Role: greenplum.PrimaryRole},
+		{DbID: 5, ContentID: 2, Hostname: "sdw2", DataDir: "/data/primary/gpseg2", Port: 6000, Role: greenplum.PrimaryRole},
+		{DbID: 6, ContentID: 3, Hostname: "sdw2", DataDir: "/data/primary/gpseg3", Port: 6001, Role: greenplum.PrimaryRole},
+		{DbID: 7, ContentID: 4, Hostname: "sdw3", DataDir: "/data/primary/gpseg4", Port: 6000, Role: greenplum.PrimaryRole},
+		{DbID: 8, ContentID: 5, Hostname: "sdw3", DataDir: "/data/primary/gpseg5", Port: 6001, Role: greenplum.PrimaryRole},
+		{DbID: 9, ContentID: 0, Hostname: "sdw2", DataDir: "/data/mirror/gpseg0", Port: 7000, Role: greenplum.MirrorRole},
+		{DbID: 10, ContentID: 1, Hostname: "sdw2", DataDir: "/data/mirror/gpseg1", Port: 7001, Role: greenplum.MirrorRole},
+		{DbID: 11, ContentID: 2, Hostname: "sdw1", DataDir: "/data/mirror/gpseg2", Port: 7000, Role: greenplum.MirrorRole},
+		{DbID: 12, ContentID: 3, Hostname: "sdw1", DataDir: "/data/mirror/gpseg3", Port: 7001, Role: greenplum.MirrorRole},
+		{DbID: 13, ContentID: 4, Hostname: "sdw1", DataDir: "/data/mirror/gpseg4", Port: 7002, Role: greenplum.MirrorRole},
+		{DbID: 14, ContentID: 5, Hostname: "sdw2", DataDir: "/data/mirror/gpseg5", Port: 7002, Role: greenplum.MirrorRole},
+	})
+
+	mirror := func(dbid int, content int, host string, port int) greenplum.SegConfig {
+		return greenplum.SegConfig{
+			DbID:      dbid,
+			ContentID: content,
+			Hostname:  host,
+			Address:   host,
+			DataDir:   source.Mirrors[content].DataDir,
+			Port:      port,
+			Role:      greenplum.MirrorRole,
+		}
+	}
+
+	t.Run("places mirrors with the group policy", func(t *testing.T) {
+		mirrors, err := greenplum.MirrorLayout{Policy: greenplum.GroupMirrors}.Mirrors(source)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := greenplum.ContentToSegConfig{
+			-1: source.Mirrors[-1],
+			0:  mirror(9, 0, "sdw2", 7000),
+			1:  mirror(10, 1, "sdw2", 7001),
+			2:  mirror(11, 2, "sdw3", 7000),
+			3:  mirror(12, 3, "sdw3", 7001),
+			4:  mirror(13, 4, "sdw1", 7000),
+			5:  mirror(14, 5, "sdw1", 7001),
+		}
+		if !reflect.DeepEqual(mirrors, expected) {
+			t.Errorf("got %+v want %+v", mirrors, expected)
+		}
+	})
+
+	t.Run("places mirrors with the spread policy", func(t *testing.T) {
+		mirrors, err := greenplum.MirrorLayout{Policy: greenplum.SpreadMirrors}.Mirrors(source)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := greenplum.ContentToSegConfig{
+			-1: source.Mirrors[-1],
+			0:  mirror(9, 0, "sdw2", 7000),
+			1:  mirror(10, 1, "sdw3", 7000),
+			2:  mirror(11, 2, "sdw3", 7001),
+			3:  mirror(12, 3, "sdw1", 7000),
+			4:  mirror(13, 4, "sdw1", 7001),
+			5:  mirror(14, 5, "sdw2", 7001),
+		}
+		if !reflect.DeepEqual(mirrors, expected) {
+			t.Errorf("got %+v want %+v", mirrors, expected)
+		}
+	})
+
+	t.Run("places mirrors explicitly leaving the rest in place", func(t *testing.T) {
+		layout := greenplum.MirrorLayout{Placements: map[int]greenplum.MirrorPlacement{
+			4: {Hostname: "sdw2", Port: 7004, DataDir: "/data2/mirror/gpseg4"},
+		}}
+
+		mirrors, err := layout.Mirrors(source)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := greenplum.SegConfig{DbID: 13, ContentID: 4, Hostname: "sdw2", Address: "sdw2", DataDir: "/data2/mirror/gpseg4", Port: 7004, Role: greenplum.MirrorRole}
+		if !reflect.DeepEqual(mirrors[4], expected) {
+			t.Errorf("got %+v want %+v", mirrors[4], expected)
+		}
+
+		if !reflect.DeepEqual(mirrors[5], source.Mirrors[5]) {
+			t.Errorf("got %+v want %+v", mirrors[5], source.Mirrors[5])
+		}
+
+		if source.Mirrors[4].Hostname != "sdw1" {
+			t.Errorf("expected the source mirrors to be unchanged")
+		}
+	})
+
+	errCases := []struct {
+		name     string
+		layout   greenplum.MirrorLayout
+		expected string
+	}{
+		{
+			name:     "unknown content",
+			layout:   greenplum.MirrorLayout{Placements: map[int]greenplum.MirrorPlacement{9: {Hostname: "sdw2", Port: 7009, DataDir: "/data/mirror/gpseg9"}}},
+			expected: "content 9 does not have a mirror in the source cluster",
+		},
+		{
+			name:     "unknown host",
+			layout:   greenplum.MirrorLayout{Placements: map[int]greenplum.MirrorPlacement{4: {Hostname: "sdw9", Port: 7004, DataDir: "/data/mirror/gpseg4"}}},
+			expected: "content 4 mirror is placed on host sdw9 which is not a segment host of the source cluster",
+		},
+		{
+			name:     "same host as primary",
+			layout:   greenplum.MirrorLayout{Placements: map[int]greenplum.MirrorPlacement{4: {Hostname: "sdw3", Port: 7004, DataDir: "/data/mirror/gpseg4"}}},
+			expected: "content 4 mirror is placed on the same host sdw3 as its primary",
+		},
+		{
+			name:     "port in use",
+			layout:   greenplum.MirrorLayout{Placements: map[int]greenplum.MirrorPlacement{4: {Hostname: "sdw2", Port: 7002, DataDir: "/data/mirror/gpseg4"}}},
+			expected: "content 4 mirror uses port 7002 on host sdw2 which is already used by dbid 14",
+		},
+		{
+			name:     "data directory in use",
+			layout:   greenplum.MirrorLayout{Placements: map[int]greenplum.MirrorPlacement{4: {Hostname: "sdw2", Port: 7004, DataDir: "/data/mirror/gpseg1"}}},
+			expected: "content 4 mirror uses data directory /data/mirror/gpseg1 on host sdw2 which is already used by dbid 10",
+		},
+	}
+
+	for _, c := range errCases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := c.layout.Mirrors(source)
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("got error %v want %q", err, c.expected)
+			}
+		})
+	}
+
+	t.Run("errors when spreading with too few hosts", func(t *testing.T) {
+		cluster := MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/gpseg-1", Port: 5432, Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/gpseg0", Port: 6000, Role: greenplum.PrimaryRole},
+			{DbID: 3, ContentID: 1, Hostname: "sdw1", DataDir: "/data/primary/gpseg1", Port: 6001, Role: greenplum.PrimaryRole},
+			{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/mirror/gpseg0", Port: 7000, Role: greenplum.MirrorRole},
+			{DbID: 5, ContentID: 1, Hostname: "sdw2", DataDir: "/data/mirror/gpseg1", Port: 7001, Role: greenplum.MirrorRole},
+			{DbID: 6, ContentID: 2, Hostname: "sdw2", DataDir: "/data/primary/gpseg2", Port: 6000, Role: greenplum.PrimaryRole},
+			{DbID: 7, ContentID: 2, Hostname: "sdw1", DataDir: "/data/mirror/gpseg2", Port: 7000, Role: greenplum.MirrorRole},
+		})
+
+		_, err := greenplum.MirrorLayout{Policy: greenplum.SpreadMirrors}.Mirrors(cluster)
+		expected := "spread mirroring requires more segment hosts than the 2 primaries on host sdw1 but found 2"
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+
+	t.Run("errors when the source cluster has no mirrors", func(t *testing.T) {
+		cluster := MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/gpseg-1", Port: 5432, Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/gpseg0", Port: 6000, Role: greenplum.PrimaryRole},
+		})
+
+		_, err := greenplum.MirrorLayout{Policy: greenplum.GroupMirrors}.Mirrors(cluster)
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
+
+func TestAddMirrors(t *testing.T) {
+	source := MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/gpseg-1", Port: 5432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/gpseg0", Port: 6000, Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 1, Hostname: "sdw1", DataDir: "/data/primary/gpseg1", Port: 6001, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 2, Hostname: "sdw2", DataDir: "/data/primary/gpseg2", Port: 6000, Role: greenplum.PrimaryRole},
+		{DbID: 5, ContentID: 3, Hostname: "sdw2", DataDir: "/data/primary/gpseg3", Port: 6001, Role: greenplum.PrimaryRole},
+	})
+
+	mirror := func(dbid int, content int, host string, port int, dataDir string) greenplum.SegConfig {
+		return greenplum.SegConfig{DbID: dbid, ContentID: content, Hostname: host, Address: host, DataDir: dataDir, Port: port, Role: greenplum.MirrorRole}
+	}
+
+	t.Run("adds mirrors by policy and a standby", func(t *testing.T) {
+		layout := greenplum.MirrorLayout{Policy: greenplum.GroupMirrors, Port: 7000, DataDir: "/data/mirror"}
+		standby := &greenplum.MirrorPlacement{Hostname: "scdw", Port: 5432, DataDir: "/data/standby"}
+
+		mirrors, err := greenplum.AddMirrors(source, source.Mirrors, layout, standby)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := greenplum.ContentToSegConfig{
+			-1: mirror(10, -1, "scdw", 5432, "/data/standby"),
+			0:  mirror(6, 0, "sdw2", 7000, "/data/mirror/gpseg0"),
+			1:  mirror(7, 1, "sdw2", 7001, "/data/mirror/gpseg1"),
+			2:  mirror(8, 2, "sdw1", 7000, "/data/mirror/gpseg2"),
+			3:  mirror(9, 3, "sdw1", 7001, "/data/mirror/gpseg3"),
+		}
+		if !reflect.DeepEqual(mirrors, expected) {
+			t.Errorf("got %+v want %+v", mirrors, expected)
+		}
+
+		if len(source.Mirrors) != 0 {
+			t.Errorf("expected the source mirrors to be unchanged")
+		}
+	})
+
+	t.Run("adds only a standby", func(t *testing.T) {
+		standby := &greenplum.MirrorPlacement{Hostname: "scdw", Port: 5432, DataDir: "/data/standby"}
+
+		mirrors, err := greenplum.AddMirrors(source, source.Mirrors, greenplum.MirrorLayout{}, standby)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := greenplum.ContentToSegConfig{-1: mirror(6, -1, "scdw", 5432, "/data/standby")}
+		if !reflect.DeepEqual(mirrors, expected) {
+			t.Errorf("got %+v want %+v", mirrors, expected)
+		}
+	})
+
+	errCases := []struct {
+		name     string
+		layout   greenplum.MirrorLayout
+		standby  *greenplum.MirrorPlacement
+		expected string
+	}{
+		{
+			name:     "policy without a port and data directory",
+			layout:   greenplum.MirrorLayout{Policy: greenplum.SpreadMirrors},
+			expected: "expected spread:port:datadir since the source cluster has no mirrors",
+		},
+		{
+			name: "placements missing a content",
+			layout: greenplum.MirrorLayout{Placements: map[int]greenplum.MirrorPlacement{
+				0: {Hostname: "sdw2", Port: 7000, DataDir: "/data/mirror/gpseg0"},
+				1: {Hostname: "sdw2", Port: 7001, DataDir: "/data/mirror/gpseg1"},
+				2: {Hostname: "sdw1", Port: 7000, DataDir: "/data/mirror/gpseg2"},
+				4: {Hostname: "sdw1", Port: 7001, DataDir: "/data/mirror/gpseg4"},
+			}},
+			expected: "content 3 does not have a mirror placement",
+		},
+		{
+			name:     "standby conflicting with the coordinator",
+			standby:  &greenplum.MirrorPlacement{Hostname: "cdw", Port: 5432, DataDir: "/data/standby"},
+			expected: "standby uses port 5432 on host cdw which is already used by dbid 1",
+		},
+	}
+
+	for _, c := range errCases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := greenplum.AddMirrors(source, source.Mirrors, c.layout, c.standby)
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("got error %v want %q", err, c.expected)
+			}
+		})
+	}
+
+	t.Run("errors when the source cluster already has mirrors and a standby", func(t *testing.T) {
+		mirrored := MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/gpseg-1", Port: 5432, Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: -1, Hostname: "scdw", DataDir: "/data/standby", Port: 5432, Role: greenplum.MirrorRole},
+			{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/gpseg0", Port: 6000, Role: greenplum.PrimaryRole},
+			{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/mirror/gpseg0", Port: 7000, Role: greenplum.MirrorRole},
+		})
+
+		layout := greenplum.MirrorLayout{Policy: greenplum.GroupMirrors, Port: 7000, DataDir: "/data/mirror"}
+		_, err := greenplum.AddMirrors(mirrored, mirrored.Mirrors, layout, nil)
+		expected := "mirrors can only be added when the source cluster has no mirrors"
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+
+		standby := &greenplum.MirrorPlacement{Hostname: "sdw2", Port: 5432, DataDir: "/data/standby"}
+		_, err = greenplum.AddMirrors(mirrored, mirrored.Mirrors, greenplum.MirrorLayout{}, standby)
+		expected = "a standby can only be added when the source cluster has no standby"
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// TablespaceMapping relocates the target cluster's user defined tablespaces
+// located within OldLocation on Host to NewLocation. An empty Host applies to
+// all hosts.
+type TablespaceMapping struct {
+	Host        string
+	OldLocation string
+	NewLocation string
+}
+
+type TablespaceMappings []TablespaceMapping
+
+// ParseTablespaceMappings parses a comma separated list of old=new location
+// pairs optionally prefixed by a host such as
+// "sdw1:/data/fs1=/ssd/fs1,/data/fs2=/ssd/fs2".
+func ParseTablespaceMappings(input string) (TablespaceMappings, error) {
+	var mappings TablespaceMappings
+	if strings.TrimSpace(input) == "" {
+		return mappings, nil
+	}
+
+	for _, pair := range strings.Split(input, ",") {
+		pair = strings.TrimSpace(pair)
+
+		host := ""
+		if !strings.HasPrefix(pair, "/") {
+			var ok bool
+			host, pair, ok = strings.Cut(pair, ":")
+			if !ok {
+				return nil, xerrors.Errorf("expected [host:]old_location=new_location but got %q", pair)
+			}
+		}
+
+		oldLocation, newLocation, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, xerrors.Errorf("expected [host:]old_location=new_location but got %q", pair)
+		}
+
+		oldLocation, newLocation = strings.TrimSpace(oldLocation), strings.TrimSpace(newLocation)
+		if !filepath.IsAbs(oldLocation) || !filepath.IsAbs(newLocation) {
+			return nil, xerrors.Errorf("expected absolute tablespace locations but got %q", pair)
+		}
+
+		if oldLocation == newLocation {
+			return nil, xerrors.Errorf("tablespace location %q is mapped to itself", oldLocation)
+		}
+
+		mappings = append(mappings, TablespaceMapping{
+			Host:        strings.TrimSpace(host),
+			OldLocation: filepath.Clean(oldLocation),
+			NewLocation: filepath.Clean(newLocation),
+		})
+	}
+
+	return mappings, nil
+}
+
+// Relocate returns where a tablespace location on host is relocated to. A
+// mapping for the host takes precedence over one for all hosts, and the
+// longest matching old location wins.
+func (m TablespaceMappings) Relocate(host string, location string) (string, bool) {
+	var match *TablespaceMapping
+	for i := range m {
+		mapping := &m[i]
+		if mapping.Host != "" && mapping.Host != host {
+			continue
+		}
+
+		if location != mapping.OldLocation && !strings.HasPrefix(location, mapping.OldLocation+string(filepath.Separator)) {
+			continue
+		}
+
+		if match == nil ||
+			(match.Host == "" && mapping.Host != "") ||
+			(match.Host == mapping.Host && len(mapping.OldLocation) > len(match.OldLocation)) {
+			match = mapping
+		}
+	}
+
+	if match == nil {
+		return location, false
+	}
+
+	return match.NewLocation + strings.TrimPrefix(location, match.OldLocation), true
+}
+
+// Relocations returns where the target tablespace directories created by
+// pg_upgrade for the segment are moved to. The catalog version is that of the
+// intermediate cluster.
+func (m TablespaceMappings) Relocations(seg SegConfig, tablespaces SegmentTablespaces, majorVersion uint64, catalogVersion string) []*idl.TablespaceRelocation {
+	var relocations []*idl.TablespaceRelocation
+	for oid, tablespace := range tablespaces {
+		if !tablespace.GetUserDefined() {
+			continue
+		}
+
+		newLocation, ok := m.Relocate(seg.Hostname, tablespace.GetLocation())
+		if !ok {
+			continue
+		}
+
+		relocations = append(relocations, &idl.TablespaceRelocation{
+			Oid:         oid,
+			Source:      upgrade.TablespacePath(tablespace.GetLocation(), int32(seg.DbID), majorVersion, catalogVersion),
+			Destination: upgrade.TablespacePath(newLocation, int32(seg.DbID), majorVersion, catalogVersion),
+		})
+	}
+
+	sort.Slice(relocations, func(i, j int) bool {
+		return relocations[i].GetOid() < relocations[j].GetOid()
+	})
+
+	return relocations
+}
+
+// Validate ensures every mapping relocates at least one user defined tablespace
+// of the coordinator or primaries. Only tablespaces of 5X source clusters are
+// known to gpupgrade, and only copy mode leaves the source tablespaces intact.
+func (m TablespaceMappings) Validate(source *Cluster, mode idl.Mode) error {
+	if len(m) == 0 {
+		return nil
+	}
+
+	if mode != idl.Mode_copy {
+		return xerrors.Errorf("tablespaces can only be relocated in copy mode")
+	}
+
+	if source.Version.Major != 5 {
+		return xerrors.Errorf("tablespaces can only be relocated when upgrading from Greenplum 5")
+	}
+
+	var err error
+	for _, mapping := range m {
+		used := false
+		for _, seg := range source.Primaries {
+			if mapping.Host != "" && !seg.IsOnHost(mapping.Host) {
+				continue
+			}
+
+			for _, location := range source.Tablespaces[int32(seg.DbID)].UserDefinedTablespacesLocations() {
+				if _, ok := (TablespaceMappings{mapping}).Relocate(seg.Hostname, location); ok {
+					used = true
+				}
+			}
+		}
+
+		if !used {
+			name := mapping.OldLocation
+			if mapping.Host != "" {
+				name = mapping.Host + ":" + name
+			}
+
+			err = errorlist.Append(err, xerrors.Errorf("tablespace location %q does not contain any user defined tablespaces", name))
+		}
+	}
+
+	return err
+}
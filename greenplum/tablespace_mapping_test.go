@@ -0,0 +1,183 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func TestParseTablespaceMappings(t *testing.T) {
+	t.Run("parses mappings with and without hosts", func(t *testing.T) {
+		mappings, err := greenplum.ParseTablespaceMappings(" /data/fs1=/ssd/fs1/ , sdw1:/data/fs2=/ssd/fs2")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := greenplum.TablespaceMappings{
+			{OldLocation: "/data/fs1", NewLocation: "/ssd/fs1"},
+			{Host: "sdw1", OldLocation: "/data/fs2", NewLocation: "/ssd/fs2"},
+		}
+		if !reflect.DeepEqual(mappings, expected) {
+			t.Errorf("got %+v want %+v", mappings, expected)
+		}
+	})
+
+	t.Run("returns no mappings for empty input", func(t *testing.T) {
+		mappings, err := greenplum.ParseTablespaceMappings("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(mappings) != 0 {
+			t.Errorf("got %+v want none", mappings)
+		}
+	})
+
+	errCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "missing new location", input: "/data/fs1", expected: "expected [host:]old_location=new_location"},
+		{name: "missing host separator", input: "data/fs1=/ssd/fs1", expected: "expected [host:]old_location=new_location"},
+		{name: "relative location", input: "/data/fs1=ssd/fs1", expected: "expected absolute tablespace locations"},
+		{name: "location mapped to itself", input: "/data/fs1=/data/fs1", expected: "is mapped to itself"},
+	}
+
+	for _, c := range errCases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := greenplum.ParseTablespaceMappings(c.input)
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("got error %v want %q", err, c.expected)
+			}
+		})
+	}
+}
+
+func TestTablespaceMappingsRelocate(t *testing.T) {
+	mappings := greenplum.TablespaceMappings{
+		{OldLocation: "/data", NewLocation: "/ssd"},
+		{OldLocation: "/data/fs1", NewLocation: "/nvme/fs1"},
+		{Host: "sdw1", OldLocation: "/data", NewLocation: "/sdw1"},
+	}
+
+	cases := []struct {
+		host     string
+		location string
+		expected string
+		ok       bool
+	}{
+		{host: "sdw2", location: "/data/fs2/16386", expected: "/ssd/fs2/16386", ok: true},
+		{host: "sdw2", location: "/data/fs1/16386", expected: "/nvme/fs1/16386", ok: true},
+		{host: "sdw1", location: "/data/fs1/16386", expected: "/sdw1/fs1/16386", ok: true},
+		{host: "sdw2", location: "/database/16386", expected: "/database/16386", ok: false},
+		{host: "sdw2", location: "/other/16386", expected: "/other/16386", ok: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.host+":"+c.location, func(t *testing.T) {
+			location, ok := mappings.Relocate(c.host, c.location)
+			if location != c.expected || ok != c.ok {
+				t.Errorf("got %q, %t want %q, %t", location, ok, c.expected, c.ok)
+			}
+		})
+	}
+}
+
+func TestTablespaceMappingsRelocations(t *testing.T) {
+	t.Run("returns relocations of user defined tablespaces sorted by oid", func(t *testing.T) {
+		mappings := greenplum.TablespaceMappings{{OldLocation: "/data", NewLocation: "/ssd"}}
+		seg := greenplum.SegConfig{DbID: 2, ContentID: 0, Hostname: "sdw1"}
+		tablespaces := greenplum.SegmentTablespaces{
+			16387: {Location: "/data/fs/16387", UserDefined: true},
+			16386: {Location: "/data/fs/16386", UserDefined: true},
+			16388: {Location: "/other/fs/16388", UserDefined: true},
+			1663:  {Location: "/data/primary/seg0", UserDefined: false},
+		}
+
+		relocations := mappings.Relocations(seg, tablespaces, 6, "301908232")
+
+		expected := []*idl.TablespaceRelocation{
+			{Oid: 16386, Source: "/data/fs/16386/2/GPDB_6_301908232", Destination: "/ssd/fs/16386/2/GPDB_6_301908232"},
+			{Oid: 16387, Source: "/data/fs/16387/2/GPDB_6_301908232", Destination: "/ssd/fs/16387/2/GPDB_6_301908232"},
+		}
+		if len(relocations) != len(expected) {
+			t.Fatalf("got %d relocations want %d", len(relocations), len(expected))
+		}
+
+		for i := range expected {
+			if relocations[i].GetOid() != expected[i].GetOid() ||
+				relocations[i].GetSource() != expected[i].GetSource() ||
+				relocations[i].GetDestination() != expected[i].GetDestination() {
+				t.Errorf("got relocation %v want %v", relocations[i], expected[i])
+			}
+		}
+	})
+}
+
+func TestTablespaceMappingsValidate(t *testing.T) {
+	source := MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/seg0", Role: greenplum.PrimaryRole},
+	})
+	source.Version = semver.MustParse("5.28.0")
+	source.Tablespaces = greenplum.Tablespaces{
+		1: {16386: {Location: "/data/fs/16386", UserDefined: true}},
+		2: {16386: {Location: "/data/fs/16386", UserDefined: true}},
+	}
+
+	t.Run("succeeds when every mapping relocates a tablespace", func(t *testing.T) {
+		mappings := greenplum.TablespaceMappings{{OldLocation: "/data/fs", NewLocation: "/ssd/fs"}}
+		if err := mappings.Validate(source, idl.Mode_copy); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("succeeds without mappings in any mode", func(t *testing.T) {
+		if err := greenplum.TablespaceMappings(nil).Validate(source, idl.Mode_link); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("errors in link mode", func(t *testing.T) {
+		mappings := greenplum.TablespaceMappings{{OldLocation: "/data/fs", NewLocation: "/ssd/fs"}}
+		err := mappings.Validate(source, idl.Mode_link)
+		if err == nil || !strings.Contains(err.Error(), "copy mode") {
+			t.Errorf("got error %v want copy mode error", err)
+		}
+	})
+
+	t.Run("errors when the source is not Greenplum 5", func(t *testing.T) {
+		source6 := MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		})
+		source6.Version = semver.MustParse("6.20.0")
+
+		mappings := greenplum.TablespaceMappings{{OldLocation: "/data/fs", NewLocation: "/ssd/fs"}}
+		err := mappings.Validate(source6, idl.Mode_copy)
+		if err == nil || !strings.Contains(err.Error(), "Greenplum 5") {
+			t.Errorf("got error %v want Greenplum 5 error", err)
+		}
+	})
+
+	t.Run("errors when a mapping does not relocate any tablespace", func(t *testing.T) {
+		mappings := greenplum.TablespaceMappings{
+			{OldLocation: "/data/fs", NewLocation: "/ssd/fs"},
+			{Host: "sdw2", OldLocation: "/data/fs", NewLocation: "/ssd/fs"},
+		}
+
+		err := mappings.Validate(source, idl.Mode_copy)
+		expected := `tablespace location "sdw2:/data/fs" does not contain any user defined tablespaces`
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+}
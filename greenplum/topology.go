@@ -0,0 +1,335 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum
+
+import (
+	"bufio"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// ComparedSettings are the postgresql.conf settings of the source coordinator
+// that are expected to carry over to the target cluster.
+var ComparedSettings = []string{
+	"port",
+	"listen_addresses",
+	"max_connections",
+	"max_prepared_transactions",
+	"gp_vmem_protect_limit",
+	"gp_resource_manager",
+}
+
+// DiffTarget returns how the running target cluster differs from the source
+// cluster it was upgraded from. This covers the segment configuration, the
+// tablespace locations, and the ComparedSettings found in sourceConfFile.
+// Settings are not compared when sourceConfFile no longer exists. Segments
+// are compared against the target cluster which only differs from the source
+// when the mirrors were re-laid out.
+func DiffTarget(db *sql.DB, target *Cluster, source *Cluster, intermediate *Cluster, upgradeID string, sourceConfFile string, tablespaceMappings TablespaceMappings) ([]string, error) {
+	segments, err := GetSegmentConfiguration(db, target.Version)
+	if err != nil {
+		return nil, xerrors.Errorf("querying gp_segment_configuration: %w", err)
+	}
+
+	live, err := NewCluster(segments)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := DiffTopology(target, intermediate, &live, upgradeID)
+
+	if len(source.Tablespaces) > 0 {
+		locations, err := TablespaceLocations(db)
+		if err != nil {
+			return nil, err
+		}
+
+		drift = append(drift, DiffTablespaces(source, locations, tablespaceMappings)...)
+	}
+
+	expected, err := ReadConfFile(sourceConfFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if len(expected) > 0 {
+		settings, err := Settings(db, ComparedSettings)
+		if err != nil {
+			return nil, err
+		}
+
+		drift = append(drift, DiffSettings(expected, settings)...)
+	}
+
+	return drift, nil
+}
+
+// DiffTopology compares the segments of the live cluster against those of the
+// source cluster. Ports and data directories left over from the intermediate
+// cluster are called out since they indicate finalize did not fully complete.
+func DiffTopology(source *Cluster, intermediate *Cluster, live *Cluster, upgradeID string) []string {
+	var drift []string
+
+	compare := func(expected ContentToSegConfig, actual ContentToSegConfig, temporary ContentToSegConfig) {
+		for _, content := range sortedContents(expected) {
+			seg := expected[content]
+			name := segmentName(seg)
+
+			liveSeg, ok := actual[content]
+			if !ok {
+				drift = append(drift, fmt.Sprintf("%s is missing", name))
+				continue
+			}
+
+			if liveSeg.DbID != seg.DbID {
+				drift = append(drift, fmt.Sprintf("%s dbid is %d but was %d", name, liveSeg.DbID, seg.DbID))
+			}
+
+			if liveSeg.Hostname != seg.Hostname {
+				drift = append(drift, fmt.Sprintf("%s hostname is %s but was %s", name, liveSeg.Hostname, seg.Hostname))
+			}
+
+			if liveSeg.Port != seg.Port {
+				message := fmt.Sprintf("%s port is %d but was %d", name, liveSeg.Port, seg.Port)
+				if tempSeg, ok := temporary[content]; ok && tempSeg.Port == liveSeg.Port {
+					message += " and is still the temporary port used during the upgrade"
+				}
+
+				drift = append(drift, message)
+			}
+
+			if liveSeg.DataDir != seg.DataDir {
+				message := fmt.Sprintf("%s data directory is %s but was %s", name, liveSeg.DataDir, seg.DataDir)
+				switch {
+				case upgradeID != "" && strings.Contains(filepath.Base(liveSeg.DataDir), upgradeID):
+					message += fmt.Sprintf(" and still carries the upgrade ID %s", upgradeID)
+				case strings.HasSuffix(liveSeg.DataDir, upgrade.OldSuffix):
+					message += " and is an archived source data directory"
+				}
+
+				drift = append(drift, message)
+			}
+		}
+
+		for _, content := range sortedContents(actual) {
+			if _, ok := expected[content]; !ok {
+				seg := actual[content]
+				drift = append(drift, fmt.Sprintf("unexpected %s on host %s", segmentName(seg), seg.Hostname))
+			}
+		}
+	}
+
+	var tempPrimaries, tempMirrors ContentToSegConfig
+	if intermediate != nil {
+		tempPrimaries, tempMirrors = intermediate.Primaries, intermediate.Mirrors
+	}
+
+	compare(source.Primaries, live.Primaries, tempPrimaries)
+	compare(source.Mirrors, live.Mirrors, tempMirrors)
+
+	return drift
+}
+
+// DiffTablespaces compares the user defined tablespace locations of the source
+// primaries against the live locations keyed by content and tablespace oid.
+// Tablespaces relocated by tablespaceMappings are expected at their new
+// location.
+func DiffTablespaces(source *Cluster, locations map[int]map[int32]string, tablespaceMappings TablespaceMappings) []string {
+	var drift []string
+
+	for _, content := range sortedContents(source.Primaries) {
+		seg := source.Primaries[content]
+		tablespaces := source.Tablespaces[int32(seg.DbID)]
+
+		var oids []int32
+		for oid, info := range tablespaces {
+			if info.GetUserDefined() {
+				oids = append(oids, oid)
+			}
+		}
+		sort.Slice(oids, func(i, j int) bool { return oids[i] < oids[j] })
+
+		for _, oid := range oids {
+			expected, _ := tablespaceMappings.Relocate(seg.Hostname, tablespaces[oid].GetLocation())
+
+			actual, ok := locations[content][oid]
+			if !ok {
+				drift = append(drift, fmt.Sprintf("tablespace %d is missing on %s", oid, segmentName(seg)))
+				continue
+			}
+
+			// Depending on the version the location may include the dbid
+			// directory that Greenplum creates within the tablespace.
+			if actual != expected && actual != filepath.Join(expected, fmt.Sprintf("%d", seg.DbID)) {
+				drift = append(drift, fmt.Sprintf("tablespace %d on %s is at %s but was at %s", oid, segmentName(seg), actual, expected))
+			}
+		}
+	}
+
+	return drift
+}
+
+// DiffSettings compares the expected setting values against the actual ones.
+// Only settings present in both are compared.
+func DiffSettings(expected map[string]string, actual map[string]string) []string {
+	var names []string
+	for name := range expected {
+		if _, ok := actual[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var drift []string
+	for _, name := range names {
+		if expected[name] != actual[name] {
+			drift = append(drift, fmt.Sprintf("setting %s is %q but was %q", name, actual[name], expected[name]))
+		}
+	}
+
+	return drift
+}
+
+// TablespaceLocations returns the location of each user defined tablespace
+// keyed by content and tablespace oid.
+func TablespaceLocations(db *sql.DB) (map[int]map[int32]string, error) {
+	rows, err := db.Query(`
+	SELECT l.gp_segment_id, t.oid, l.tblspc_loc
+	FROM pg_tablespace t, gp_tablespace_location(t.oid) l
+	WHERE t.spcname NOT IN ('pg_default', 'pg_global');`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying tablespace locations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := make(map[int]map[int32]string)
+	for rows.Next() {
+		var content int
+		var oid int32
+		var location string
+		if err := rows.Scan(&content, &oid, &location); err != nil {
+			return nil, xerrors.Errorf("scanning tablespace locations: %w", err)
+		}
+
+		if locations[content] == nil {
+			locations[content] = make(map[int32]string)
+		}
+		locations[content][oid] = location
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, xerrors.Errorf("querying tablespace locations: %w", err)
+	}
+
+	return locations, nil
+}
+
+// Settings returns the current value of each named setting.
+func Settings(db *sql.DB, names []string) (map[string]string, error) {
+	settings := make(map[string]string)
+	for _, name := range names {
+		var value string
+		err := db.QueryRow("SELECT current_setting($1);", name).Scan(&value)
+		if err != nil {
+			return nil, xerrors.Errorf("querying setting %q: %w", name, err)
+		}
+
+		settings[name] = value
+	}
+
+	return settings, nil
+}
+
+// ReadConfFile returns the settings of a postgresql.conf file. Later entries
+// override earlier ones, and include directives are not followed.
+func ReadConfFile(path string) (map[string]string, error) {
+	file, err := utils.System.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseConf(file)
+}
+
+// ParseConf parses the settings of a postgresql.conf file.
+func ParseConf(r io.Reader) (map[string]string, error) {
+	settings := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			name, value, ok = strings.Cut(line, " ")
+			if !ok {
+				continue
+			}
+		}
+
+		settings[strings.ToLower(strings.TrimSpace(name))] = confValue(strings.TrimSpace(value))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// confValue strips any trailing comment and the quotes around a value.
+func confValue(value string) string {
+	if strings.HasPrefix(value, "'") {
+		end := strings.Index(value[1:], "'")
+		if end >= 0 {
+			return value[1 : end+1]
+		}
+
+		return strings.TrimPrefix(value, "'")
+	}
+
+	if i := strings.Index(value, "#"); i >= 0 {
+		value = value[:i]
+	}
+
+	return strings.TrimSpace(value)
+}
+
+func segmentName(seg SegConfig) string {
+	switch {
+	case seg.IsCoordinator():
+		return "coordinator"
+	case seg.IsStandby():
+		return "standby"
+	case seg.IsMirror():
+		return fmt.Sprintf("content %d mirror", seg.ContentID)
+	default:
+		return fmt.Sprintf("content %d primary", seg.ContentID)
+	}
+}
+
+func sortedContents(segs ContentToSegConfig) []int {
+	var contents []int
+	for content := range segs {
+		contents = append(contents, content)
+	}
+	sort.Ints(contents)
+
+	return contents
+}
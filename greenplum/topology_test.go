@@ -0,0 +1,271 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package greenplum_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func topologySource(t *testing.T) *greenplum.Cluster {
+	return MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/seg-1", Port: 5432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "scdw", DataDir: "/data/standby", Port: 5432, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/seg0", Port: 25432, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/mirror/seg0", Port: 25433, Role: greenplum.MirrorRole},
+	})
+}
+
+func topologyIntermediate(t *testing.T) *greenplum.Cluster {
+	return MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/seg.AAAAAAAAAAA.-1", Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "scdw", DataDir: "/data/standby.AAAAAAAAAAA", Port: 50433, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/seg.AAAAAAAAAAA.0", Port: 50434, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/mirror/seg.AAAAAAAAAAA.0", Port: 50435, Role: greenplum.MirrorRole},
+	})
+}
+
+func TestDiffTopology(t *testing.T) {
+	source := topologySource(t)
+	intermediate := topologyIntermediate(t)
+
+	t.Run("finds nothing when the live cluster matches the source", func(t *testing.T) {
+		drift := greenplum.DiffTopology(source, intermediate, topologySource(t), "AAAAAAAAAAA")
+		if len(drift) != 0 {
+			t.Errorf("unexpected drift %v", drift)
+		}
+	})
+
+	t.Run("reports leftovers from the upgrade", func(t *testing.T) {
+		live := MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/seg-1", Port: 5432, Role: greenplum.PrimaryRole},
+			{DbID: 2, ContentID: -1, Hostname: "scdw", DataDir: "/data/standby.old", Port: 5432, Role: greenplum.MirrorRole},
+			{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/seg.AAAAAAAAAAA.0", Port: 50434, Role: greenplum.PrimaryRole},
+			{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/mirror/seg0", Port: 25433, Role: greenplum.MirrorRole},
+		})
+
+		expected := []string{
+			"content 0 primary port is 50434 but was 25432 and is still the temporary port used during the upgrade",
+			"content 0 primary data directory is /data/primary/seg.AAAAAAAAAAA.0 but was /data/primary/seg0 and still carries the upgrade ID AAAAAAAAAAA",
+			"standby data directory is /data/standby.old but was /data/standby and is an archived source data directory",
+		}
+
+		drift := greenplum.DiffTopology(source, intermediate, live, "AAAAAAAAAAA")
+		if !reflect.DeepEqual(drift, expected) {
+			t.Errorf("got drift %q want %q", drift, expected)
+		}
+	})
+
+	t.Run("reports moved, missing, and unexpected segments", func(t *testing.T) {
+		live := MustCreateCluster(t, greenplum.SegConfigs{
+			{DbID: 1, ContentID: -1, Hostname: "cdw", DataDir: "/data/qddir/seg-1", Port: 5432, Role: greenplum.PrimaryRole},
+			{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/primary/seg0", Port: 25432, Role: greenplum.PrimaryRole},
+			{DbID: 4, ContentID: 0, Hostname: "sdw3", DataDir: "/data/mirror/seg0", Port: 25433, Role: greenplum.MirrorRole},
+			{DbID: 5, ContentID: 1, Hostname: "sdw2", DataDir: "/data/primary/seg1", Port: 25434, Role: greenplum.PrimaryRole},
+		})
+
+		expected := []string{
+			"unexpected content 1 primary on host sdw2",
+			"standby is missing",
+			"content 0 mirror hostname is sdw3 but was sdw2",
+		}
+
+		drift := greenplum.DiffTopology(source, intermediate, live, "AAAAAAAAAAA")
+		if !reflect.DeepEqual(drift, expected) {
+			t.Errorf("got drift %q want %q", drift, expected)
+		}
+	})
+}
+
+func TestDiffTablespaces(t *testing.T) {
+	source := topologySource(t)
+	source.Tablespaces = greenplum.Tablespaces{
+		1: {
+			1663:  {Location: "/data/qddir/seg-1", UserDefined: false},
+			16386: {Location: "/tmp/tblspc/1/16386", UserDefined: true},
+		},
+		3: {
+			16386: {Location: "/tmp/tblspc/3/16386", UserDefined: true},
+			16387: {Location: "/tmp/other/3/16387", UserDefined: true},
+		},
+	}
+
+	t.Run("accepts locations with or without the dbid directory", func(t *testing.T) {
+		locations := map[int]map[int32]string{
+			-1: {16386: "/tmp/tblspc/1/16386"},
+			0:  {16386: "/tmp/tblspc/3/16386/3", 16387: "/tmp/other/3/16387"},
+		}
+
+		drift := greenplum.DiffTablespaces(source, locations, nil)
+		if len(drift) != 0 {
+			t.Errorf("unexpected drift %v", drift)
+		}
+	})
+
+	t.Run("reports moved and missing tablespaces", func(t *testing.T) {
+		locations := map[int]map[int32]string{
+			0: {16386: "/tmp/moved/3/16386"},
+		}
+
+		expected := []string{
+			"tablespace 16386 is missing on coordinator",
+			"tablespace 16386 on content 0 primary is at /tmp/moved/3/16386 but was at /tmp/tblspc/3/16386",
+			"tablespace 16387 is missing on content 0 primary",
+		}
+
+		drift := greenplum.DiffTablespaces(source, locations, nil)
+		if !reflect.DeepEqual(drift, expected) {
+			t.Errorf("got drift %q want %q", drift, expected)
+		}
+	})
+
+	t.Run("expects relocated tablespaces at their new location", func(t *testing.T) {
+		mappings := greenplum.TablespaceMappings{{OldLocation: "/tmp/tblspc", NewLocation: "/ssd/tblspc"}}
+		locations := map[int]map[int32]string{
+			-1: {16386: "/ssd/tblspc/1/16386"},
+			0:  {16386: "/tmp/tblspc/3/16386", 16387: "/tmp/other/3/16387"},
+		}
+
+		expected := []string{
+			"tablespace 16386 on content 0 primary is at /tmp/tblspc/3/16386 but was at /ssd/tblspc/3/16386",
+		}
+
+		drift := greenplum.DiffTablespaces(source, locations, mappings)
+		if !reflect.DeepEqual(drift, expected) {
+			t.Errorf("got drift %q want %q", drift, expected)
+		}
+	})
+}
+
+func TestDiffSettings(t *testing.T) {
+	expected := map[string]string{"max_connections": "250", "port": "5432", "shared_buffers": "125MB"}
+	actual := map[string]string{"max_connections": "750", "port": "5432"}
+
+	drift := greenplum.DiffSettings(expected, actual)
+	if !reflect.DeepEqual(drift, []string{`setting max_connections is "750" but was "250"`}) {
+		t.Errorf("got drift %q", drift)
+	}
+}
+
+func TestParseConf(t *testing.T) {
+	conf := `
+# a comment
+port=5432 # the port
+Max_Connections = 250
+listen_addresses = '*'		# what IP address(es) to listen on;
+gp_resource_manager 'queue'
+max_connections = 750
+`
+
+	settings, err := greenplum.ParseConf(strings.NewReader(conf))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"port":                "5432",
+		"max_connections":     "750",
+		"listen_addresses":    "*",
+		"gp_resource_manager": "queue",
+	}
+	if !reflect.DeepEqual(settings, expected) {
+		t.Errorf("got %v want %v", settings, expected)
+	}
+}
+
+func TestDiffTarget(t *testing.T) {
+	source := topologySource(t)
+	target := topologySource(t)
+	target.Version = semver.MustParse("6.20.0")
+	target.Destination = idl.ClusterDestination_target
+
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	confFile := filepath.Join(dir, "postgresql.conf")
+	testutils.MustWriteToFile(t, confFile, "port = 5432\nmax_connections = 250\n")
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("couldn't create sqlmock: %v", err)
+	}
+	defer testutils.FinishMock(mock, t)
+
+	expectSegmentConfiguration := func() {
+		rows := sqlmock.NewRows([]string{"dbid", "contentid", "port", "hostname", "address", "datadir", "role"})
+		for _, seg := range source.ExcludingCoordinatorOrStandby() {
+			rows.AddRow(seg.DbID, seg.ContentID, seg.Port, seg.Hostname, seg.Address, seg.DataDir, seg.Role)
+		}
+		for _, seg := range []greenplum.SegConfig{source.Coordinator(), source.Standby()} {
+			rows.AddRow(seg.DbID, seg.ContentID, seg.Port, seg.Hostname, seg.Address, seg.DataDir, seg.Role)
+		}
+		mock.ExpectQuery("FROM gp_segment_configuration").WillReturnRows(rows)
+	}
+
+	t.Run("compares the topology and settings of the live cluster", func(t *testing.T) {
+		expectSegmentConfiguration()
+		for _, setting := range greenplum.ComparedSettings {
+			value := "on"
+			switch setting {
+			case "port":
+				value = "5432"
+			case "max_connections":
+				value = "750"
+			}
+
+			mock.ExpectQuery(`SELECT current_setting\(\$1\);`).WithArgs(setting).
+				WillReturnRows(sqlmock.NewRows([]string{"current_setting"}).AddRow(value))
+		}
+
+		drift, err := greenplum.DiffTarget(db, target, source, topologyIntermediate(t), "AAAAAAAAAAA", confFile, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []string{`setting max_connections is "750" but was "250"`}
+		if !reflect.DeepEqual(drift, expected) {
+			t.Errorf("got drift %q want %q", drift, expected)
+		}
+	})
+
+	t.Run("skips the settings when the source configuration file is gone", func(t *testing.T) {
+		expectSegmentConfiguration()
+
+		drift, err := greenplum.DiffTarget(db, target, source, topologyIntermediate(t), "AAAAAAAAAAA", filepath.Join(dir, "does-not-exist"), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(drift) != 0 {
+			t.Errorf("unexpected drift %v", drift)
+		}
+	})
+
+	t.Run("queries the tablespace locations when the source has tablespaces", func(t *testing.T) {
+		source.Tablespaces = greenplum.Tablespaces{3: {16386: {Location: "/tmp/tblspc/3/16386", UserDefined: true}}}
+		defer func() { source.Tablespaces = nil }()
+
+		expectSegmentConfiguration()
+		mock.ExpectQuery("FROM pg_tablespace t, gp_tablespace_location").
+			WillReturnRows(sqlmock.NewRows([]string{"gp_segment_id", "oid", "tblspc_loc"}).AddRow(0, 16386, "/tmp/tblspc/3/16386"))
+
+		drift, err := greenplum.DiffTarget(db, target, source, topologyIntermediate(t), "AAAAAAAAAAA", filepath.Join(dir, "does-not-exist"), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(drift) != 0 {
+			t.Errorf("unexpected drift %v", drift)
+		}
+	})
+}
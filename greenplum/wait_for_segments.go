@@ -4,6 +4,7 @@
 package greenplum
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"time"
@@ -11,11 +12,11 @@ import (
 	"golang.org/x/xerrors"
 )
 
-func WaitForSegments(db *sql.DB, timeout time.Duration, cluster *Cluster) error {
+func WaitForSegments(ctx context.Context, db *sql.DB, timeout time.Duration, cluster *Cluster) error {
 	startTime := time.Now()
 	for {
 		if cluster.Version.Major > 5 {
-			rows, err := db.Query("SELECT gp_request_fts_probe_scan();")
+			rows, err := db.QueryContext(ctx, "SELECT gp_request_fts_probe_scan();")
 			if err != nil {
 				return xerrors.Errorf("requesting gp_request_fts_probe_scan: %w", err)
 			}
@@ -25,7 +26,7 @@ func WaitForSegments(db *sql.DB, timeout time.Duration, cluster *Cluster) error
 			}
 		}
 
-		ready, err := areSegmentsReady(db, cluster)
+		ready, err := areSegmentsReady(ctx, db, cluster)
 		if err != nil {
 			return err
 		}
@@ -38,11 +39,15 @@ func WaitForSegments(db *sql.DB, timeout time.Duration, cluster *Cluster) error
 			return xerrors.Errorf("%s timeout exceeded waiting for all segments to be up, in their preferred roles, and synchronized.", timeout)
 		}
 
-		time.Sleep(time.Second)
+		select {
+		case <-ctx.Done():
+			return xerrors.Errorf("waiting for all segments to be up, in their preferred roles, and synchronized: %w", ctx.Err())
+		case <-time.After(time.Second):
+		}
 	}
 }
 
-func areSegmentsReady(db *sql.DB, cluster *Cluster) (bool, error) {
+func areSegmentsReady(ctx context.Context, db *sql.DB, cluster *Cluster) (bool, error) {
 	var segments int
 
 	// check gp_segment_configuration for the segments
@@ -51,8 +56,8 @@ func areSegmentsReady(db *sql.DB, cluster *Cluster) (bool, error) {
 		whereClause = ""
 	}
 
-	row := db.QueryRow(`SELECT COUNT(*) FROM gp_segment_configuration 
-WHERE content > -1 AND status = 'u' AND (role = preferred_role) ` + whereClause)
+	row := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM gp_segment_configuration 
+WHERE content > -1 AND status = 'u' AND (role = preferred_role) `+whereClause)
 
 	if err := row.Scan(&segments); err != nil {
 		if err == sql.ErrNoRows {
@@ -77,7 +82,7 @@ WHERE content > -1 AND status = 'u' AND (role = preferred_role) ` + whereClause)
 		whereClause = "sent_lsn = flush_lsn;"
 	}
 
-	row = db.QueryRow("SELECT COUNT(*) FROM pg_stat_replication WHERE state = 'streaming' AND " + whereClause)
+	row = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pg_stat_replication WHERE state = 'streaming' AND "+whereClause)
 	if err := row.Scan(&segments); err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("no rows found when querying pg_stat_replication")
@@ -4,6 +4,7 @@
 package greenplum_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -39,7 +40,7 @@ func TestWaitForSegments(t *testing.T) {
 		expectGpSegmentConfigurationToReturn(mock, 4)
 		expectPgStatReplicationToReturn(mock, 1, target.Version)
 
-		err = greenplum.WaitForSegments(db, timeout, target)
+		err = greenplum.WaitForSegments(context.Background(), db, timeout, target)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -51,7 +52,7 @@ func TestWaitForSegments(t *testing.T) {
 		expectGpSegmentConfigurationToReturn(mock, 4)
 		expectPgStatReplicationToReturn(mock, 1, target.Version)
 
-		err = greenplum.WaitForSegments(db, timeout, target)
+		err = greenplum.WaitForSegments(context.Background(), db, timeout, target)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -70,7 +71,7 @@ func TestWaitForSegments(t *testing.T) {
 		expectFtsProbe(mock)
 		expectGpSegmentConfigurationToReturn(mock, 4)
 
-		err = greenplum.WaitForSegments(db, timeout, target)
+		err = greenplum.WaitForSegments(context.Background(), db, timeout, target)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -89,7 +90,7 @@ func TestWaitForSegments(t *testing.T) {
 		expectGpSegmentConfigurationWithoutMirrorsToReturn(mock, 2)
 		expectPgStatReplicationToReturn(mock, 1, target.Version)
 
-		err = greenplum.WaitForSegments(db, timeout, target)
+		err = greenplum.WaitForSegments(context.Background(), db, timeout, target)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -106,7 +107,7 @@ func TestWaitForSegments(t *testing.T) {
 		expectFtsProbe(mock)
 		expectGpSegmentConfigurationWithoutMirrorsToReturn(mock, 2)
 
-		err = greenplum.WaitForSegments(db, timeout, target)
+		err = greenplum.WaitForSegments(context.Background(), db, timeout, target)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -124,7 +125,7 @@ func TestWaitForSegments(t *testing.T) {
 		expectGpSegmentConfigurationToReturn(mock, 4)
 		expectPgStatReplicationToReturn(mock, 1, target.Version)
 
-		err = greenplum.WaitForSegments(db, timeout, target)
+		err = greenplum.WaitForSegments(context.Background(), db, timeout, target)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -142,7 +143,7 @@ func TestWaitForSegments(t *testing.T) {
 		expectGpSegmentConfigurationToReturn(mock, 4)
 		expectPgStatReplicationToReturn(mock, 1, target.Version)
 
-		err = greenplum.WaitForSegments(db, timeout, target)
+		err = greenplum.WaitForSegments(context.Background(), db, timeout, target)
 		if err != nil {
 			t.Errorf("unexpected error: %#v", err)
 		}
@@ -154,7 +155,7 @@ func TestWaitForSegments(t *testing.T) {
 		expectFtsProbe(mock)
 		expectGpSegmentConfigurationToReturn(mock, 0)
 
-		err = greenplum.WaitForSegments(db, -1*time.Second, target)
+		err = greenplum.WaitForSegments(context.Background(), db, -1*time.Second, target)
 		expected := "-1s timeout exceeded waiting for all segments to be up, in their preferred roles, and synchronized."
 		if err.Error() != expected {
 			t.Errorf("got: %#v want %s", err, expected)
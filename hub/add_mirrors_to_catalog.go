@@ -41,6 +41,11 @@ func AddMirrorsToGpSegmentConfiguration(db *sql.DB, intermediate *greenplum.Clus
 		err = commitOrRollback(tx, err)
 	}()
 
+	// Remove mirrors added by an earlier attempt so that this can be retried.
+	if _, err := tx.Exec("DELETE FROM gp_segment_configuration WHERE role = 'm' AND content <> -1;"); err != nil {
+		return xerrors.Errorf("delete existing mirrors from gp_segment_configuration: %w", err)
+	}
+
 	for _, seg := range intermediate.Mirrors.ExcludingStandby() {
 		if err := addSegment(tx, seg); err != nil {
 			return err
@@ -50,6 +55,41 @@ func AddMirrorsToGpSegmentConfiguration(db *sql.DB, intermediate *greenplum.Clus
 	return nil
 }
 
+func addStandbyToCatalog(intermediate *greenplum.Cluster) error {
+	options := []greenplum.Option{
+		greenplum.UtilityMode(),
+		greenplum.AllowSystemTableMods(),
+	}
+
+	db, err := sql.Open("pgx", intermediate.Connection(options...))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return AddStandbyToGpSegmentConfiguration(db, intermediate)
+}
+
+func AddStandbyToGpSegmentConfiguration(db *sql.DB, intermediate *greenplum.Cluster) (err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return xerrors.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		err = commitOrRollback(tx, err)
+	}()
+
+	if _, err := tx.Exec("DELETE FROM gp_segment_configuration WHERE role = 'm' AND content = -1;"); err != nil {
+		return xerrors.Errorf("delete existing standby from gp_segment_configuration: %w", err)
+	}
+
+	return addSegment(tx, intermediate.Standby())
+}
+
 func addSegment(tx *sql.Tx, seg greenplum.SegConfig) error {
 	result, err := tx.Exec("INSERT INTO gp_segment_configuration "+
 		"(dbid, content, role, preferred_role, mode, status, port, hostname, address, datadir) "+
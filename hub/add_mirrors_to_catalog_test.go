@@ -38,6 +38,7 @@ func TestAddMirrorsToCatalog(t *testing.T) {
 		mock.MatchExpectationsInOrder(false) // since we iterate over maps for which golang does not guarantee order
 
 		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM gp_segment_configuration").WillReturnResult(sqlmock.NewResult(0, 0))
 
 		for _, mirror := range target.Mirrors {
 			if mirror.IsStandby() {
@@ -82,10 +83,23 @@ func TestAddMirrorsToCatalog(t *testing.T) {
 				t.Errorf("got %#v want %#v", actual, expected)
 			}
 		},
+	}, {
+		name: "rolls back transaction when deleting existing mirrors fails",
+		expectations: func(mock sqlmock.Sqlmock) {
+			mock.ExpectBegin()
+			mock.ExpectExec("DELETE FROM gp_segment_configuration").WillReturnError(expected)
+			mock.ExpectRollback()
+		},
+		verifications: func(t *testing.T, actual error) {
+			if !errors.Is(actual, expected) {
+				t.Errorf("got %#v want %#v", actual, expected)
+			}
+		},
 	}, {
 		name: "rolls back transaction when inserting into the catalog fails",
 		expectations: func(mock sqlmock.Sqlmock) {
 			mock.ExpectBegin()
+			mock.ExpectExec("DELETE FROM gp_segment_configuration").WillReturnResult(sqlmock.NewResult(0, 0))
 			mock.ExpectExec("INSERT INTO gp_segment_configuration").WillReturnResult(sqlmock.NewResult(0, 1))
 			mock.ExpectExec("INSERT INTO gp_segment_configuration").WillReturnError(expected)
 			mock.ExpectRollback()
@@ -100,6 +114,7 @@ func TestAddMirrorsToCatalog(t *testing.T) {
 		expectations: func(mock sqlmock.Sqlmock) {
 			mock.MatchExpectationsInOrder(false) // since we iterate over maps for which golang does not guarantee order
 			mock.ExpectBegin()
+			mock.ExpectExec("DELETE FROM gp_segment_configuration").WillReturnResult(sqlmock.NewResult(0, 0))
 			for _, mirror := range target.Mirrors.ExcludingStandby() {
 				expectAddSegment(mock, mirror).WillReturnResult(sqlmock.NewResult(0, 1))
 			}
@@ -114,6 +129,7 @@ func TestAddMirrorsToCatalog(t *testing.T) {
 		name: "errors when rolling back fails",
 		expectations: func(mock sqlmock.Sqlmock) {
 			mock.ExpectBegin()
+			mock.ExpectExec("DELETE FROM gp_segment_configuration").WillReturnResult(sqlmock.NewResult(0, 0))
 			mock.ExpectExec("INSERT INTO gp_segment_configuration").WillReturnError(expected)
 			mock.ExpectRollback().WillReturnError(ErrRollback)
 		},
@@ -133,6 +149,7 @@ func TestAddMirrorsToCatalog(t *testing.T) {
 		name: "rolls back if inserting into gp_segment_configuration returns multiple rows",
 		expectations: func(mock sqlmock.Sqlmock) {
 			mock.ExpectBegin()
+			mock.ExpectExec("DELETE FROM gp_segment_configuration").WillReturnResult(sqlmock.NewResult(0, 0))
 			mock.ExpectExec("INSERT INTO gp_segment_configuration").WillReturnResult(sqlmock.NewResult(0, 2))
 			mock.ExpectRollback()
 		},
@@ -166,3 +183,51 @@ func expectAddSegment(mock sqlmock.Sqlmock, seg greenplum.SegConfig) *sqlmock.Ex
 		"VALUES\\((.+), (.+), (.+), (.+), 'n', 'u', (.+), (.+), (.+), (.+)\\);").
 		WithArgs(seg.DbID, seg.ContentID, seg.Role, seg.Role, seg.Port, seg.Hostname, seg.Hostname, seg.DataDir)
 }
+
+func TestAddStandbyToGpSegmentConfiguration(t *testing.T) {
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby.HqtFHX54y0o", Port: 50433, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg.HqtFHX54y0o.1", Port: 50435, Role: greenplum.MirrorRole},
+	})
+
+	t.Run("adds only the standby", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM gp_segment_configuration").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectAddSegment(mock, intermediate.Standby()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err = hub.AddStandbyToGpSegmentConfiguration(db, intermediate)
+		if err != nil {
+			t.Errorf("returned error %+v", err)
+		}
+	})
+
+	t.Run("rolls back when adding the standby fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+		defer db.Close()
+
+		expected := errors.New("sentinel error")
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM gp_segment_configuration").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectAddSegment(mock, intermediate.Standby()).WillReturnError(expected)
+		mock.ExpectRollback()
+
+		err = hub.AddStandbyToGpSegmentConfiguration(db, intermediate)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
@@ -5,20 +5,24 @@ package hub
 
 import (
 	"context"
-	"net"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-func AddReplicationEntriesOnPrimaries(agentConns []*idl.Connection, intermediate *greenplum.Cluster, useHbaHostnames bool) error {
+func AddReplicationEntriesOnPrimaries(agentConns []*idl.Connection, intermediate *greenplum.Cluster, useHbaHostnames bool, addressFamily utils.AddressFamily) error {
 	user, err := utils.System.Current()
 	if err != nil {
 		return err
 	}
 
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		intermediatePrimaries := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsCoordinator() && seg.IsPrimary()
 		})
@@ -29,7 +33,7 @@ func AddReplicationEntriesOnPrimaries(agentConns []*idl.Connection, intermediate
 
 			mirrorHostAddrs := []string{intermediateMirror.Hostname}
 			if useHbaHostnames {
-				err, mirrorIps := getIpAddresses(intermediateMirror.Hostname)
+				err, mirrorIps := getIpAddresses(intermediateMirror.Hostname, addressFamily)
 				if err != nil {
 					return err
 				}
@@ -47,16 +51,16 @@ func AddReplicationEntriesOnPrimaries(agentConns []*idl.Connection, intermediate
 		}
 
 		req := &idl.AddReplicationEntriesRequest{Entries: entries}
-		_, err := conn.AgentClient.AddReplicationEntries(context.Background(), req)
+		_, err := conn.AgentClient.AddReplicationEntries(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(context.Background(), agentConns, request)
 }
 
-// getIpAddresses returns a list of ip addresses with CIDR notation for use in
-// pg_hba.conf.
-func getIpAddresses(host string) (error, []string) {
+// getIpAddresses returns a list of ip addresses of the address family with
+// CIDR notation for use in pg_hba.conf.
+func getIpAddresses(host string, addressFamily utils.AddressFamily) (error, []string) {
 	ips, err := utils.System.LookupIP(host)
 	if err != nil {
 		return err, nil
@@ -64,13 +68,49 @@ func getIpAddresses(host string) (error, []string) {
 
 	var cidrs []string
 	for _, ip := range ips {
-		cidr := net.IPNet{IP: ip, Mask: net.CIDRMask(32, 32)}
-		if ip.To16() != nil {
-			cidr = net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+		if !addressFamily.Includes(ip) {
+			continue
 		}
 
-		cidrs = append(cidrs, cidr.String())
+		cidrs = append(cidrs, utils.HostCIDR(ip))
+	}
+
+	if len(cidrs) == 0 {
+		return xerrors.Errorf("found no %s addresses for host %q in %q", addressFamily, host, ips), nil
 	}
 
 	return nil, cidrs
 }
+
+// AddReplicationEntriesOnCoordinator allows the standby to replicate from the
+// coordinator, which is local to the hub.
+func AddReplicationEntriesOnCoordinator(intermediate *greenplum.Cluster, useHbaHostnames bool, addressFamily utils.AddressFamily) (err error) {
+	user, err := utils.System.Current()
+	if err != nil {
+		return err
+	}
+
+	standbyHostAddrs := []string{intermediate.StandbyHostname()}
+	if useHbaHostnames {
+		err, standbyIps := getIpAddresses(intermediate.StandbyHostname(), addressFamily)
+		if err != nil {
+			return err
+		}
+
+		standbyHostAddrs = standbyIps
+	}
+
+	path := filepath.Join(intermediate.CoordinatorDataDir(), "pg_hba.conf")
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := file.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	_, err = file.WriteString(greenplum.ReplicationHBAEntries(user.Username, standbyHostAddrs))
+	return err
+}
@@ -71,7 +71,7 @@ func TestAddReplicationEntriesOnPrimaries(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, false)
+		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, false, utils.AnyAddressFamily)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -123,12 +123,143 @@ func TestAddReplicationEntriesOnPrimaries(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, true)
+		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, true, utils.AnyAddressFamily)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
 	})
 
+	dualStack := func(host string) ([]net.IP, error) {
+		if host == "sdw2" {
+			return []net.IP{net.ParseIP("10.0.0.2"), net.ParseIP("fd00::2")}, nil
+		}
+
+		return []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("fd00::1")}, nil
+	}
+
+	addressFamilyCases := []struct {
+		name          string
+		addressFamily utils.AddressFamily
+		lookupIP      func(host string) ([]net.IP, error)
+		sdw1Addrs     []string
+		sdw2Addrs     []string
+	}{
+		{
+			name:          "uses both IPv4 and IPv6 addresses of dual-stack hosts",
+			addressFamily: utils.AnyAddressFamily,
+			lookupIP:      dualStack,
+			sdw1Addrs:     []string{"10.0.0.2/32", "fd00::2/128"},
+			sdw2Addrs:     []string{"10.0.0.1/32", "fd00::1/128"},
+		},
+		{
+			name:          "uses only IPv4 addresses of dual-stack hosts",
+			addressFamily: utils.IPv4AddressFamily,
+			lookupIP:      dualStack,
+			sdw1Addrs:     []string{"10.0.0.2/32"},
+			sdw2Addrs:     []string{"10.0.0.1/32"},
+		},
+		{
+			name:          "uses only IPv6 addresses of dual-stack hosts",
+			addressFamily: utils.IPv6AddressFamily,
+			lookupIP:      dualStack,
+			sdw1Addrs:     []string{"fd00::2/128"},
+			sdw2Addrs:     []string{"fd00::1/128"},
+		},
+		{
+			name:          "uses a /32 mask for IPv4-mapped addresses of IPv4-only hosts",
+			addressFamily: utils.IPv4AddressFamily,
+			lookupIP: func(host string) ([]net.IP, error) {
+				if host == "sdw2" {
+					return []net.IP{net.ParseIP("::ffff:10.0.0.2")}, nil
+				}
+
+				return []net.IP{net.ParseIP("::ffff:10.0.0.1")}, nil
+			},
+			sdw1Addrs: []string{"10.0.0.2/32"},
+			sdw2Addrs: []string{"10.0.0.1/32"},
+		},
+		{
+			name:          "uses IPv6-only hosts",
+			addressFamily: utils.IPv6AddressFamily,
+			lookupIP: func(host string) ([]net.IP, error) {
+				if host == "sdw2" {
+					return []net.IP{net.ParseIP("fd00::2")}, nil
+				}
+
+				return []net.IP{net.ParseIP("fd00::1")}, nil
+			},
+			sdw1Addrs: []string{"fd00::2/128"},
+			sdw2Addrs: []string{"fd00::1/128"},
+		},
+	}
+
+	for _, c := range addressFamilyCases {
+		t.Run(c.name, func(t *testing.T) {
+			utils.System.LookupIP = c.lookupIP
+			defer func() {
+				utils.System.LookupIP = net.LookupIP
+			}()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sdw1 := mock_idl.NewMockAgentClient(ctrl)
+			sdw1.EXPECT().AddReplicationEntries(
+				gomock.Any(),
+				&idl.AddReplicationEntriesRequest{
+					Entries: []*idl.AddReplicationEntriesRequest_Entry{
+						{
+							DataDir:   "/data/dbfast1/seg.HqtFHX54y0o.1",
+							User:      "gpadmin",
+							HostAddrs: c.sdw1Addrs,
+						}},
+				},
+			).Return(&idl.AddReplicationEntriesReply{}, nil)
+
+			sdw2 := mock_idl.NewMockAgentClient(ctrl)
+			sdw2.EXPECT().AddReplicationEntries(
+				gomock.Any(),
+				&idl.AddReplicationEntriesRequest{
+					Entries: []*idl.AddReplicationEntriesRequest_Entry{
+						{
+							DataDir:   "/data/dbfast2/seg.HqtFHX54y0o.2",
+							User:      "gpadmin",
+							HostAddrs: c.sdw2Addrs,
+						}},
+				},
+			).Return(&idl.AddReplicationEntriesReply{}, nil)
+
+			agentConns := []*idl.Connection{
+				{AgentClient: sdw1, Hostname: "sdw1"},
+				{AgentClient: sdw2, Hostname: "sdw2"},
+			}
+
+			err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, true, c.addressFamily)
+			if err != nil {
+				t.Errorf("unexpected err %#v", err)
+			}
+		})
+	}
+
+	t.Run("errors when a host has no addresses of the address family", func(t *testing.T) {
+		utils.System.LookupIP = func(host string) ([]net.IP, error) {
+			return []net.IP{net.ParseIP("10.0.0.1")}, nil
+		}
+		defer func() {
+			utils.System.LookupIP = net.LookupIP
+		}()
+
+		agentConns := []*idl.Connection{
+			{AgentClient: nil, Hostname: "sdw1"},
+		}
+
+		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, true, utils.IPv6AddressFamily)
+		expected := `found no ipv6 addresses for host "sdw2" in ["10.0.0.1"]`
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+
 	t.Run("returns errors when failing on segments", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -151,7 +282,7 @@ func TestAddReplicationEntriesOnPrimaries(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, false)
+		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, false, utils.AnyAddressFamily)
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("error %#v does not contain type %T", err, errs)
@@ -177,7 +308,7 @@ func TestAddReplicationEntriesOnPrimaries(t *testing.T) {
 			utils.System.Current = user.Current
 		}()
 
-		err := hub.AddReplicationEntriesOnPrimaries(nil, intermediate, true)
+		err := hub.AddReplicationEntriesOnPrimaries(nil, intermediate, true, utils.AnyAddressFamily)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
@@ -200,7 +331,7 @@ func TestAddReplicationEntriesOnPrimaries(t *testing.T) {
 			{AgentClient: nil, Hostname: "sdw2"},
 		}
 
-		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, true)
+		err := hub.AddReplicationEntriesOnPrimaries(agentConns, intermediate, true, utils.AnyAddressFamily)
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("error %#v does not contain type %T", err, errs)
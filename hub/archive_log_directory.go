@@ -28,17 +28,17 @@ func ArchiveLogDirectories(logDir string, logArchiveDir string, agentConns []*id
 }
 
 func ArchiveSegmentLogDirectories(agentConns []*idl.Connection, excludeHostname, logArchiveDir string) error {
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		if conn.Hostname == excludeHostname {
 			return nil
 		}
 
 		req := &idl.ArchiveLogDirectoryRequest{LogArchiveDir: logArchiveDir}
-		_, err := conn.AgentClient.ArchiveLogDirectory(context.Background(), req)
+		_, err := conn.AgentClient.ArchiveLogDirectory(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(context.Background(), agentConns, request)
 }
 
 // GetLogArchiveDir returns the name of the file to be used to store logs
@@ -0,0 +1,65 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"path"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/greenplum-db/gpupgrade/audit"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// destructiveAgentRequests delete, rename, or overwrite data on the segment
+// hosts and are recorded in the audit log.
+var destructiveAgentRequests = map[string]bool{
+	"DeleteDataDirectories":       true,
+	"DeleteTablespaceDirectories": true,
+	"DeleteBackupDirectory":       true,
+	"DeleteStateDirectory":        true,
+	"RenameDirectories":           true,
+	"RenameTablespaces":           true,
+	"RsyncDataDirectories":        true,
+	"RsyncTablespaceDirectories":  true,
+}
+
+// AuditUnaryClientInterceptor records destructive requests made to the agent
+// on host along with their outcome. Requests are not made if they cannot be
+// recorded.
+func AuditUnaryClientInterceptor(host string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		name := path.Base(method)
+		if !destructiveAgentRequests[name] {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		entry := audit.Entry{Event: audit.AgentRequest, AgentHost: host, Method: name, Status: "running"}
+		if msg, ok := req.(proto.Message); ok {
+			entry.Request = protojson.MarshalOptions{}.Format(msg)
+		}
+
+		if err := audit.Record(entry); err != nil {
+			return xerrors.Errorf("%s on host %s: %w", name, host, err)
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		entry.Status = "complete"
+		if err != nil {
+			entry.Status = "failed"
+			entry.Error = err.Error()
+		}
+
+		if aErr := audit.Record(entry); aErr != nil {
+			err = errorlist.Append(err, xerrors.Errorf("%s on host %s: %w", name, host, aErr))
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/greenplum-db/gpupgrade/audit"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestAuditUnaryClientInterceptor(t *testing.T) {
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	path := filepath.Join(stateDir, audit.FileName)
+	interceptor := hub.AuditUnaryClientInterceptor("sdw1")
+
+	invoke := func(method string, err error) (bool, error) {
+		invoked := false
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			invoked = true
+			return err
+		}
+
+		request := &idl.DeleteDataDirectoriesRequest{Datadirs: []string{"/data/primary/gpseg1"}}
+		return invoked, interceptor(context.Background(), method, request, nil, nil, invoker)
+	}
+
+	t.Run("records destructive requests and their outcome", func(t *testing.T) {
+		defer testutils.MustRemoveAll(t, path)
+
+		expected := errors.New("permission denied")
+		_, err := invoke("/idl.Agent/DeleteDataDirectories", expected)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+
+		lines := strings.Split(strings.TrimSpace(testutils.MustReadFile(t, path)), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("got %d audit entries want 2", len(lines))
+		}
+
+		var request, outcome audit.Entry
+		if err := json.Unmarshal([]byte(lines[0]), &request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := json.Unmarshal([]byte(lines[1]), &outcome); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if request.Event != audit.AgentRequest || request.AgentHost != "sdw1" || request.Method != "DeleteDataDirectories" ||
+			request.Status != "running" || !strings.Contains(request.Request, "/data/primary/gpseg1") {
+			t.Errorf("got entry %+v", request)
+		}
+
+		if outcome.Status != "failed" || outcome.Error != "permission denied" {
+			t.Errorf("got entry %+v", outcome)
+		}
+	})
+
+	t.Run("does not record other requests", func(t *testing.T) {
+		invoked, err := invoke("/idl.Agent/CheckDiskSpace", nil)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if !invoked {
+			t.Error("expected the request to be made")
+		}
+
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected no audit log, got %v", err)
+		}
+	})
+
+	t.Run("does not make destructive requests that cannot be recorded", func(t *testing.T) {
+		defer testutils.MustRemoveAll(t, path)
+
+		// Make the audit log a directory such that it cannot be opened.
+		if err := os.Mkdir(path, 0700); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		invoked, err := invoke("/idl.Agent/RenameDirectories", nil)
+		if err == nil || !strings.Contains(err.Error(), "RenameDirectories on host sdw1") {
+			t.Errorf("got error %v want an audit error", err)
+		}
+
+		if invoked {
+			t.Error("expected the request not to be made")
+		}
+	})
+}
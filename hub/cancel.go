@@ -0,0 +1,65 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"log"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+// stepContext returns the context of a new step. It is independent of the
+// CLI's stream such that the step keeps running when the CLI disconnects, and
+// is only canceled by the Cancel RPC. Call done once the step finishes.
+func (s *Server) stepContext() (ctx context.Context, done func()) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	s.stepMutex.Lock()
+	defer s.stepMutex.Unlock()
+
+	s.stepID++
+	id := s.stepID
+	s.cancelStep = cancel
+
+	return ctx, func() {
+		s.stepMutex.Lock()
+		defer s.stepMutex.Unlock()
+
+		// Leave the cancel of a step started since this one in place.
+		if s.stepID == id {
+			s.cancelStep = nil
+		}
+		cancel(nil)
+	}
+}
+
+// Cancel cancels the running step such as when the user interrupts the CLI.
+// The running substep is stopped and no further substeps are started.
+func (s *Server) Cancel(ctx context.Context, req *idl.CancelRequest) (*idl.CancelReply, error) {
+	s.stepMutex.Lock()
+	defer s.stepMutex.Unlock()
+
+	if s.cancelStep == nil {
+		return &idl.CancelReply{Canceled: false}, nil
+	}
+
+	log.Print("canceling the running step")
+	s.cancelStep(nil)
+	return &idl.CancelReply{Canceled: true}, nil
+}
+
+// abortStep cancels the running step with the cause such as when the disk
+// watchdog finds a coordinator filesystem about to run out of space.
+func (s *Server) abortStep(cause error) {
+	s.stepMutex.Lock()
+	defer s.stepMutex.Unlock()
+
+	if s.cancelStep == nil {
+		return
+	}
+
+	log.Printf("aborting the running step: %v", cause)
+	s.cancelStep(cause)
+}
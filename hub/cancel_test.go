@@ -0,0 +1,78 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+func TestCancel(t *testing.T) {
+	t.Run("cancels the running step", func(t *testing.T) {
+		server := hub.New(&config.Config{})
+
+		ctx, done := server.StepContext()
+		defer done()
+
+		reply, err := server.Cancel(context.Background(), &idl.CancelRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if !reply.GetCanceled() {
+			t.Errorf("expected the step to be canceled")
+		}
+
+		if ctx.Err() != context.Canceled {
+			t.Errorf("got step context error %v want %v", ctx.Err(), context.Canceled)
+		}
+	})
+
+	t.Run("does nothing when no step is running", func(t *testing.T) {
+		server := hub.New(&config.Config{})
+
+		ctx, done := server.StepContext()
+		done()
+
+		reply, err := server.Cancel(context.Background(), &idl.CancelRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if reply.GetCanceled() {
+			t.Errorf("expected no step to be canceled")
+		}
+
+		if ctx.Err() != context.Canceled {
+			t.Errorf("expected the finished step's context to be canceled")
+		}
+	})
+
+	t.Run("cancels the latest step after an earlier one finishes", func(t *testing.T) {
+		server := hub.New(&config.Config{})
+
+		_, doneEarlier := server.StepContext()
+		ctx, done := server.StepContext()
+		defer done()
+
+		doneEarlier()
+
+		reply, err := server.Cancel(context.Background(), &idl.CancelRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if !reply.GetCanceled() {
+			t.Errorf("expected the step to be canceled")
+		}
+
+		if ctx.Err() != context.Canceled {
+			t.Errorf("got step context error %v want %v", ctx.Err(), context.Canceled)
+		}
+	})
+}
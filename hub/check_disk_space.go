@@ -8,6 +8,7 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/greenplum-db/gpupgrade/config/backupdir"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
@@ -16,8 +17,21 @@ import (
 )
 
 var checkDiskUsage = disk.CheckUsage
+var measureDirs = disk.MeasureDirs
+
+// CheckDiskSpace estimates the space needed to upgrade in the given mode from
+// the on-disk size of the data directories and tablespaces on each host, and
+// errors with the shortfall of each filesystem lacking space. Hosts with a
+// backup directory also need space for the copy of the upgraded coordinator.
+func CheckDiskSpace(streams step.OutStreams, agentConns []*idl.Connection, mode idl.Mode, source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces, agentHostsToBackupDir backupdir.AgentHostsToBackupDir) error {
+	coordinatorDirs := []string{source.CoordinatorDataDir()}
+	coordinatorDirs = append(coordinatorDirs, sourceTablespaces.GetCoordinatorTablespaces().UserDefinedTablespacesLocations()...)
+
+	coordinatorSizes, err := measureDirs(coordinatorDirs...)
+	if err != nil {
+		return err
+	}
 
-func CheckDiskSpace(streams step.OutStreams, agentConns []*idl.Connection, diskFreeRatio float64, source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces) error {
 	var wg sync.WaitGroup
 	errs := make(chan error, len(agentConns)+1)
 	usagesChan := make(chan disk.FileSystemDiskUsage, len(agentConns)+1)
@@ -27,22 +41,18 @@ func CheckDiskSpace(streams step.OutStreams, agentConns []*idl.Connection, diskF
 	go func() {
 		defer wg.Done()
 
-		coordinatorDirs := []string{source.CoordinatorDataDir()}
-		coordinatorDirs = append(coordinatorDirs, sourceTablespaces.GetCoordinatorTablespaces().UserDefinedTablespacesLocations()...)
-
-		usage, err := checkDiskUsage(streams, disk.Local, diskFreeRatio, coordinatorDirs...)
+		usage, err := checkDiskUsage(streams, disk.Local, coordinatorSizes.Requirements(mode)...)
 		errs <- err
 		usagesChan <- usage
 	}()
 
-	checkDiskSpaceOnStandbyAndSegments(agentConns, errs, usagesChan, diskFreeRatio, source, sourceTablespaces)
+	checkDiskSpaceOnStandbyAndSegments(agentConns, errs, usagesChan, mode, coordinatorSizes.Total(), source, sourceTablespaces, agentHostsToBackupDir)
 
 	wg.Wait()
 	close(errs)
 	close(usagesChan)
 
 	// consolidate errors
-	var err error
 	for e := range errs {
 		err = errorlist.Append(err, e)
 	}
@@ -66,7 +76,7 @@ func CheckDiskSpace(streams step.OutStreams, agentConns []*idl.Connection, diskF
 	return nil
 }
 
-func checkDiskSpaceOnStandbyAndSegments(agentConns []*idl.Connection, errs chan<- error, usages chan<- disk.FileSystemDiskUsage, diskFreeRatio float64, source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces) {
+func checkDiskSpaceOnStandbyAndSegments(agentConns []*idl.Connection, errs chan<- error, usages chan<- disk.FileSystemDiskUsage, mode idl.Mode, coordinatorCopyBytes uint64, source *greenplum.Cluster, sourceTablespaces greenplum.Tablespaces, agentHostsToBackupDir backupdir.AgentHostsToBackupDir) {
 	var wg sync.WaitGroup
 
 	for _, conn := range agentConns {
@@ -77,7 +87,7 @@ func checkDiskSpaceOnStandbyAndSegments(agentConns []*idl.Connection, errs chan<
 		})
 		sort.Sort(segmentsExcludingCoordinator)
 		if len(segmentsExcludingCoordinator) == 0 {
-			return
+			continue
 		}
 
 		var dirs []string
@@ -91,8 +101,13 @@ func checkDiskSpaceOnStandbyAndSegments(agentConns []*idl.Connection, errs chan<
 			defer wg.Done()
 
 			req := &idl.CheckSegmentDiskSpaceRequest{
-				DiskFreeRatio: diskFreeRatio,
-				Dirs:          dirs,
+				Mode: mode,
+				Dirs: dirs,
+			}
+
+			if backupDir, ok := agentHostsToBackupDir[conn.Hostname]; ok {
+				req.BackupDir = backupDir
+				req.CoordinatorCopyBytes = coordinatorCopyBytes
 			}
 
 			reply, err := conn.AgentClient.CheckDiskSpace(context.Background(), req)
@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/greenplum-db/gpupgrade/config/backupdir"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
@@ -21,22 +22,33 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils/disk"
 )
 
-func CoordinatorHostCheckDiskUsagePasses(streams step.OutStreams, d disk.Disk, requiredRatio float64, paths ...string) (disk.FileSystemDiskUsage, error) {
+func CoordinatorHostCheckDiskUsagePasses(streams step.OutStreams, d disk.Disk, requirements ...disk.Requirement) (disk.FileSystemDiskUsage, error) {
 	return nil, nil
 }
 
 func CoordinatorHostErrorsWith(expected error) disk.CheckUsageType {
-	return func(streams step.OutStreams, d disk.Disk, requiredRatio float64, paths ...string) (disk.FileSystemDiskUsage, error) {
+	return func(streams step.OutStreams, d disk.Disk, requirements ...disk.Requirement) (disk.FileSystemDiskUsage, error) {
 		return nil, expected
 	}
 }
 
 func CoordinatorHostReturnsUsage(expected disk.FileSystemDiskUsage) disk.CheckUsageType {
-	return func(streams step.OutStreams, d disk.Disk, requiredRatio float64, paths ...string) (disk.FileSystemDiskUsage, error) {
+	return func(streams step.OutStreams, d disk.Disk, requirements ...disk.Requirement) (disk.FileSystemDiskUsage, error) {
 		return expected, nil
 	}
 }
 
+func MeasureDirsReturns(size disk.Size) disk.MeasureDirsType {
+	return func(dirs ...string) (disk.Sizes, error) {
+		sizes := make(disk.Sizes)
+		for _, dir := range dirs {
+			sizes[dir] = size
+		}
+
+		return sizes, nil
+	}
+}
+
 func TestCheckDiskSpace_OnCoordinator(t *testing.T) {
 	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
 		{ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
@@ -44,22 +56,63 @@ func TestCheckDiskSpace_OnCoordinator(t *testing.T) {
 
 	tablespaces := greenplum.Tablespaces{}
 
+	hub.SetMeasureDirs(MeasureDirsReturns(disk.Size{Relfiles: 4096, Catalog: 1024, WAL: 2048}))
+	defer hub.ResetMeasureDirs()
+
 	t.Run("does not return disk usage or any errors when checking disk usage on coordinator succeeds", func(t *testing.T) {
 		hub.SetCheckDiskUsage(CoordinatorHostCheckDiskUsagePasses)
 		defer hub.ResetCheckDiskUsage()
 
-		err := hub.CheckDiskSpace(step.DevNullStream, []*idl.Connection{}, 0, source, tablespaces)
+		err := hub.CheckDiskSpace(step.DevNullStream, []*idl.Connection{}, idl.Mode_copy, source, tablespaces, nil)
 		if err != nil {
 			t.Errorf("unexpected error %#v", err)
 		}
 	})
 
+	for _, c := range []struct {
+		mode     idl.Mode
+		expected uint64
+	}{
+		{mode: idl.Mode_copy, expected: 4096 + 1024 + 2048},
+		{mode: idl.Mode_link, expected: 1024 + 2048},
+	} {
+		t.Run("requires space based on the size of the coordinator in "+c.mode.String()+" mode", func(t *testing.T) {
+			hub.SetCheckDiskUsage(func(streams step.OutStreams, d disk.Disk, requirements ...disk.Requirement) (disk.FileSystemDiskUsage, error) {
+				expected := []disk.Requirement{{Path: "/data/qddir/seg-1", Bytes: c.expected}}
+				if !reflect.DeepEqual(requirements, expected) {
+					t.Errorf("got requirements %+v want %+v", requirements, expected)
+				}
+
+				return nil, nil
+			})
+			defer hub.ResetCheckDiskUsage()
+
+			err := hub.CheckDiskSpace(step.DevNullStream, []*idl.Connection{}, c.mode, source, tablespaces, nil)
+			if err != nil {
+				t.Errorf("unexpected error %#v", err)
+			}
+		})
+	}
+
+	t.Run("errors when measuring the coordinator fails", func(t *testing.T) {
+		expected := errors.New("permission denied")
+		hub.SetMeasureDirs(func(dirs ...string) (disk.Sizes, error) {
+			return nil, expected
+		})
+		defer hub.SetMeasureDirs(MeasureDirsReturns(disk.Size{Relfiles: 4096, Catalog: 1024, WAL: 2048}))
+
+		err := hub.CheckDiskSpace(step.DevNullStream, []*idl.Connection{}, idl.Mode_copy, source, tablespaces, nil)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v, want %#v", err, expected)
+		}
+	})
+
 	t.Run("errors when checking disk usage on coordinator fails", func(t *testing.T) {
 		expected := errors.New("permission denied")
 		hub.SetCheckDiskUsage(CoordinatorHostErrorsWith(expected))
 		defer hub.ResetCheckDiskUsage()
 
-		err := hub.CheckDiskSpace(step.DevNullStream, []*idl.Connection{}, 0, source, tablespaces)
+		err := hub.CheckDiskSpace(step.DevNullStream, []*idl.Connection{}, idl.Mode_copy, source, tablespaces, nil)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
@@ -75,7 +128,7 @@ func TestCheckDiskSpace_OnCoordinator(t *testing.T) {
 		hub.SetCheckDiskUsage(CoordinatorHostReturnsUsage(disk.FileSystemDiskUsage{usage}))
 		defer hub.ResetCheckDiskUsage()
 
-		err := hub.CheckDiskSpace(step.DevNullStream, []*idl.Connection{}, 0, source, tablespaces)
+		err := hub.CheckDiskSpace(step.DevNullStream, []*idl.Connection{}, idl.Mode_copy, source, tablespaces, nil)
 		expected := disk.NewSpaceUsageErrorFromUsage(usage)
 		if !reflect.DeepEqual(err, expected) {
 			t.Errorf("returned %v want %v", err, expected)
@@ -98,18 +151,25 @@ func TestCheckDiskSpace_OnSegments(t *testing.T) {
 	hub.SetCheckDiskUsage(CoordinatorHostCheckDiskUsagePasses)
 	defer hub.ResetCheckDiskUsage()
 
+	hub.SetMeasureDirs(MeasureDirsReturns(disk.Size{Relfiles: 4096, Catalog: 1024, WAL: 2048}))
+	defer hub.ResetMeasureDirs()
+
 	t.Run("returns no error or usage when checking disk usage on segment hosts succeeds", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		diskFreeRatio := 0.3
+		mode := idl.Mode_link
+		agentHostsToBackupDir := backupdir.AgentHostsToBackupDir{
+			"sdw1": "/data/dbfast/.gpupgrade",
+			"sdw2": "/data/dbfast/.gpupgrade",
+		}
 
 		smdw := mock_idl.NewMockAgentClient(ctrl)
 		smdw.EXPECT().CheckDiskSpace(
 			gomock.Any(),
 			equivalentCheckDiskRequest(&idl.CheckSegmentDiskSpaceRequest{
-				DiskFreeRatio: diskFreeRatio,
-				Dirs:          []string{"/data/standby", "/tmp/user_ts/m/standby/16384"},
+				Mode: mode,
+				Dirs: []string{"/data/standby", "/tmp/user_ts/m/standby/16384"},
 			}),
 		).Return(&idl.CheckDiskSpaceReply{}, nil)
 
@@ -117,8 +177,10 @@ func TestCheckDiskSpace_OnSegments(t *testing.T) {
 		sdw1.EXPECT().CheckDiskSpace(
 			gomock.Any(),
 			equivalentCheckDiskRequest(&idl.CheckSegmentDiskSpaceRequest{
-				DiskFreeRatio: diskFreeRatio,
-				Dirs:          []string{"/data/dbfast/seg1", "/tmp/user_ts/p1/16384", "/data/dbfast_mirror2/seg2", "/tmp/user_ts/m2/16384"},
+				Mode:                 mode,
+				Dirs:                 []string{"/data/dbfast/seg1", "/tmp/user_ts/p1/16384", "/data/dbfast_mirror2/seg2", "/tmp/user_ts/m2/16384"},
+				BackupDir:            "/data/dbfast/.gpupgrade",
+				CoordinatorCopyBytes: 2 * (4096 + 1024 + 2048), // coordinator data directory and tablespace
 			}),
 		).Return(&idl.CheckDiskSpaceReply{}, nil)
 
@@ -126,8 +188,10 @@ func TestCheckDiskSpace_OnSegments(t *testing.T) {
 		sdw2.EXPECT().CheckDiskSpace(
 			gomock.Any(),
 			equivalentCheckDiskRequest(&idl.CheckSegmentDiskSpaceRequest{
-				DiskFreeRatio: diskFreeRatio,
-				Dirs:          []string{"/data/dbfast_mirror1/seg1", "/tmp/user_ts/m1/16384", "/data/dbfast/seg2", "/tmp/user_ts/p2/16384"},
+				Mode:                 mode,
+				Dirs:                 []string{"/data/dbfast_mirror1/seg1", "/tmp/user_ts/m1/16384", "/data/dbfast/seg2", "/tmp/user_ts/p2/16384"},
+				BackupDir:            "/data/dbfast/.gpupgrade",
+				CoordinatorCopyBytes: 2 * (4096 + 1024 + 2048), // coordinator data directory and tablespace
 			}),
 		).Return(&idl.CheckDiskSpaceReply{}, nil)
 
@@ -137,7 +201,7 @@ func TestCheckDiskSpace_OnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.CheckDiskSpace(step.DevNullStream, agentConns, diskFreeRatio, source, tablespaces)
+		err := hub.CheckDiskSpace(step.DevNullStream, agentConns, mode, source, tablespaces, agentHostsToBackupDir)
 		if err != nil {
 			t.Errorf("unexpected error %#v", err)
 		}
@@ -158,7 +222,7 @@ func TestCheckDiskSpace_OnSegments(t *testing.T) {
 			{AgentClient: failedClient, Hostname: "sdw1"},
 		}
 
-		err := hub.CheckDiskSpace(step.DevNullStream, agentConns, 0, source, tablespaces)
+		err := hub.CheckDiskSpace(step.DevNullStream, agentConns, idl.Mode_copy, source, tablespaces, nil)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
@@ -185,7 +249,7 @@ func TestCheckDiskSpace_OnSegments(t *testing.T) {
 			{AgentClient: failedClient, Hostname: "smdw"},
 		}
 
-		err := hub.CheckDiskSpace(step.DevNullStream, agentConns, 0, source, tablespaces)
+		err := hub.CheckDiskSpace(step.DevNullStream, agentConns, idl.Mode_copy, source, tablespaces, nil)
 		expected := disk.NewSpaceUsageErrorFromUsage(usage)
 		if !reflect.DeepEqual(err, expected) {
 			t.Errorf("returned %v want %v", err, expected)
@@ -246,7 +310,7 @@ func TestCheckDiskSpace_OnSegments(t *testing.T) {
 			{DbID: 6, ContentID: 1, Hostname: "mirror", DataDir: "/data/dbfast_mirror2/seg2", Role: greenplum.MirrorRole},
 		})
 
-		err := hub.CheckDiskSpace(step.DevNullStream, agentConns, 0, sourceCluster, tablespaces)
+		err := hub.CheckDiskSpace(step.DevNullStream, agentConns, idl.Mode_copy, sourceCluster, tablespaces, nil)
 		expected := [][]string{
 			{"Hostname", "Filesystem", "Shortfall", "Available", "Required"},
 			{"mirror", "/data", disk.FormatBytes(2024), disk.FormatBytes(2024), disk.FormatBytes(4048)},
@@ -282,7 +346,7 @@ func TestCheckDiskSpace_OnSegments(t *testing.T) {
 			{ContentID: -1, Hostname: "mdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
 		})
 
-		err := hub.CheckDiskSpace(step.DevNullStream, agentConns, 0, coordinatorOnlyCluster, tablespaces)
+		err := hub.CheckDiskSpace(step.DevNullStream, agentConns, idl.Mode_copy, coordinatorOnlyCluster, tablespaces, nil)
 		if err != nil {
 			t.Errorf("unexpected error %#v", err)
 		}
@@ -4,103 +4,112 @@
 package hub
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"os/exec"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/xerrors"
 
-	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/environment"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-func CheckEnvironment(agentHostsIncludingCoordinator []string, sourceGphome string, intermediateGphome string) error {
-	errs := make(chan error, len(agentHostsIncludingCoordinator))
-	var wg sync.WaitGroup
+var collectEnvironment = environment.Collect
 
-	for _, host := range agentHostsIncludingCoordinator {
-		wg.Add(1)
-		go func(host string, sourceGphome string, targetGphome string) {
-			defer wg.Done()
+// XXX: for internal testing only
+func SetCollectEnvironment(collect func(gphomes ...string) *idl.EnvironmentReport) {
+	collectEnvironment = collect
+}
+
+// XXX: for internal testing only
+func ResetCollectEnvironment() {
+	collectEnvironment = environment.Collect
+}
 
-			errs <- CheckEnvironmentOnSegment(host, sourceGphome, targetGphome)
-		}(host, sourceGphome, intermediateGphome)
+// CheckEnvironment ensures that multiple versions of Greenplum environments
+// are not mixed and that each host has what the upgrade needs. This checks up
+// front for the following error as described here:
+// https://web.archive.org/web/20220506055918/https://groups.google.com/a/greenplum.org/g/gpdb-dev/c/JN-YwjCCReY/m/0L9wBOvlAQAJ
+// Warnings are written to streams without failing the check.
+func CheckEnvironment(streams step.OutStreams, agentConns []*idl.Connection, coordinatorHost string, sourceGPHome string, intermediateGPHome string) error {
+	reports, err := EnvironmentReports(step.Context(streams), agentConns, coordinatorHost, sourceGPHome, intermediateGPHome)
+	if err != nil {
+		return err
 	}
 
-	wg.Wait()
-	close(errs)
+	var coordinator *idl.EnvironmentReport
+	for _, report := range reports {
+		if report.Host == coordinatorHost {
+			coordinator = report.Report
+		}
+	}
+
+	rules := environment.Rules(sourceGPHome, intermediateGPHome, coordinator)
+	findings := environment.Evaluate(reports, rules)
+
+	for _, finding := range findings {
+		if !finding.Warning {
+			err = errorlist.Append(err, finding)
+			continue
+		}
 
-	var err error
-	for e := range errs {
-		err = errorlist.Append(err, e)
+		log.Printf("Warning: %s", finding.Error())
+		if _, pErr := fmt.Fprintf(streams.Stdout(), "Warning: %s\n", finding.Error()); pErr != nil {
+			return pErr
+		}
 	}
 
 	if err != nil {
-		nextAction := `On all segments remove sourcing greenplum_path.sh and setting any Greenplum variables
-in .bashrc or .bash_profile. In a fresh shell re-run gpupgrade.`
+		nextAction := strings.Join(environment.NextActions(findings, rules), "\n\n")
 		return utils.NewNextActionErr(err, nextAction)
 	}
 
 	return nil
 }
 
-var pathCommand = exec.Command
-var ldLibraryPathCommand = exec.Command
+// EnvironmentReports requests the environment report of each agent host. The
+// coordinator's report is collected by the hub unless an agent runs there.
+// The clock offset is measured against when the hub received each report.
+func EnvironmentReports(ctx context.Context, agentConns []*idl.Connection, coordinatorHost string, sourceGPHome string, intermediateGPHome string) ([]environment.HostReport, error) {
+	gphomes := []string{sourceGPHome, intermediateGPHome}
 
-// XXX: for internal testing only
-func SetPathCommand(command exectest.Command) {
-	pathCommand = command
-}
+	var mutex sync.Mutex
+	var reports []environment.HostReport
+	coordinatorHasAgent := false
 
-func SetLdLibraryPathCommand(command exectest.Command) {
-	ldLibraryPathCommand = command
-}
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		reply, err := conn.AgentClient.GetEnvironment(ctx, &idl.GetEnvironmentRequest{Gphomes: gphomes})
+		if err != nil {
+			return xerrors.Errorf("get environment on host %s: %w", conn.Hostname, err)
+		}
 
-// XXX: for internal testing only
-func ResetPathCommand() {
-	pathCommand = exec.Command
-}
+		offset := time.Duration(reply.GetReport().GetUnixNano() - time.Now().UnixNano())
 
-func ResetLdLibraryPathCommand() {
-	ldLibraryPathCommand = exec.Command
-}
+		mutex.Lock()
+		defer mutex.Unlock()
 
-// CheckEnvironmentOnSegment ensures that multiple versions of Greenplum
-// environments are not mixed. Use ssh instead of gRPC since our utilities like
-// gpinitsystem, gpstart, gpstop, etc. use ssh internally. This checks up front
-// for the following error as described here:
-// https://web.archive.org/web/20220506055918/https://groups.google.com/a/greenplum.org/g/gpdb-dev/c/JN-YwjCCReY/m/0L9wBOvlAQAJ
-func CheckEnvironmentOnSegment(host string, sourceGphome string, targetGphome string) error {
-	// check $PATH
-	cmd := pathCommand("ssh", "-q", host, "echo", "$PATH")
-	log.Printf("Executing: %q", cmd.String())
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return xerrors.Errorf("%q failed with %q: %w", cmd.String(), string(output), err)
-	}
+		reports = append(reports, environment.HostReport{Host: conn.Hostname, Report: reply.GetReport(), ClockOffset: offset})
+		if conn.Hostname == coordinatorHost {
+			coordinatorHasAgent = true
+		}
 
-	log.Printf("Output: %q", output)
-	path := string(output)
-	if strings.Contains(path, sourceGphome) || strings.Contains(path, targetGphome) {
-		return fmt.Errorf("on host %s PATH contains GPHOME", host)
+		return nil
 	}
 
-	// check $LD_LIBRARY_PATH
-	cmd = ldLibraryPathCommand("ssh", "-q", host, "echo", "$LD_LIBRARY_PATH")
-	log.Printf("Executing: %q", cmd.String())
-	output, err = cmd.CombinedOutput()
+	err := ExecuteRPC(ctx, agentConns, request)
 	if err != nil {
-		return xerrors.Errorf("%q failed with %q: %w", cmd.String(), string(output), err)
+		return nil, err
 	}
 
-	log.Printf("Output: %q", output)
-	ldLibraryPath := string(output)
-	if strings.Contains(ldLibraryPath, sourceGphome) || strings.Contains(ldLibraryPath, targetGphome) {
-		return fmt.Errorf("on host %s LD_LIBRARY_PATH contains GPHOME", host)
+	if !coordinatorHasAgent {
+		reports = append(reports, environment.HostReport{Host: coordinatorHost, Report: collectEnvironment(gphomes...)})
 	}
 
-	return nil
+	return reports, nil
 }
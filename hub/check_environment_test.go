@@ -4,195 +4,231 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
 
-	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/hub"
-	"github.com/greenplum-db/gpupgrade/testutils"
-	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/environment"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+const (
+	sourceGPHome       = "/usr/local/greenplum-db-source"
+	intermediateGPHome = "/usr/local/greenplum-db-target"
 )
 
-func TestCheckEnvironmentOnSegments(t *testing.T) {
+// cleanReport returns a report which passes every rule.
+func cleanReport() *idl.EnvironmentReport {
+	return &idl.EnvironmentReport{
+		Environment:      map[string]string{"PATH": "/usr/local/bin:/usr/bin", "LANG": "en_US.UTF-8"},
+		LoginEnvironment: map[string]string{"PATH": "/usr/local/bin:/usr/bin:/home/gpadmin/bin"},
+		Ulimits: []*idl.EnvironmentReport_Ulimit{
+			{Name: environment.OpenFiles, Soft: 524288, Hard: 524288},
+			{Name: environment.UserProcesses, Soft: 131072, Hard: 131072},
+		},
+		Locale:       "en_US.UTF-8",
+		UnixNano:     time.Now().UnixNano(),
+		RsyncVersion: "rsync  version 3.1.2  protocol version 31",
+		SshVersion:   "OpenSSH_7.4p1, OpenSSL 1.0.2k-fips  26 Jan 2017",
+		Gphomes: []*idl.EnvironmentReport_Gphome{
+			{Path: sourceGPHome, Reachable: true},
+			{Path: intermediateGPHome, Reachable: true},
+		},
+	}
+}
+
+func expectEnvironment(client *mock_idl.MockAgentClient, report *idl.EnvironmentReport) {
+	client.EXPECT().GetEnvironment(
+		gomock.Any(),
+		&idl.GetEnvironmentRequest{Gphomes: []string{sourceGPHome, intermediateGPHome}},
+	).Return(&idl.GetEnvironmentReply{Report: report}, nil)
+}
+
+func TestCheckEnvironment(t *testing.T) {
 	testlog.SetupTestLogger()
 
-	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
-		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
-		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby", Port: 16432, Role: greenplum.MirrorRole},
-		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25433, Role: greenplum.PrimaryRole},
-		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Port: 25434, Role: greenplum.MirrorRole},
-		{DbID: 5, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Port: 25435, Role: greenplum.PrimaryRole},
-		{DbID: 6, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg2", Port: 25436, Role: greenplum.MirrorRole},
-	})
-	source.GPHome = "/usr/local/greenplum-db-source"
-
-	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
-		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Port: 50432, Role: greenplum.PrimaryRole},
-		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby.HqtFHX54y0o", Port: 50433, Role: greenplum.MirrorRole},
-		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
-		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg.HqtFHX54y0o.1", Port: 50435, Role: greenplum.MirrorRole},
-		{DbID: 5, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg.HqtFHX54y0o.2", Port: 50436, Role: greenplum.PrimaryRole},
-		{DbID: 6, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg.HqtFHX54y0o.2", Port: 50437, Role: greenplum.MirrorRole},
+	hub.SetCollectEnvironment(func(gphomes ...string) *idl.EnvironmentReport {
+		return cleanReport()
 	})
-	intermediate.GPHome = "/usr/local/greenplum-db-target"
+	defer hub.ResetCollectEnvironment()
 
-	t.Run("checks environment on segments", func(t *testing.T) {
-		hub.SetPathCommand(exectest.NewCommand(hub.PathMain))
-		defer hub.ResetPathCommand()
+	t.Run("succeeds when each host passes the rules", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
-		hub.SetLdLibraryPathCommand(exectest.NewCommand(hub.LdLibraryPathMain))
-		defer hub.ResetLdLibraryPathCommand()
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		expectEnvironment(sdw1, cleanReport())
 
-		err := hub.CheckEnvironment(append(hub.AgentHosts(source), source.CoordinatorHostname()), source.GPHome, intermediate.GPHome)
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		expectEnvironment(sdw2, cleanReport())
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		streams := &step.BufferedStreams{}
+		err := hub.CheckEnvironment(streams, agentConns, "cdw", sourceGPHome, intermediateGPHome)
 		if err != nil {
-			t.Errorf("unexpected err %#v", err)
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		if streams.StdoutBuf.Len() != 0 {
+			t.Errorf("unexpected warnings %q", streams.StdoutBuf.String())
 		}
 	})
 
-	t.Run("returns error when failing to check PATH on segments", func(t *testing.T) {
-		hub.SetPathCommand(exectest.NewCommand(hub.Failure))
-		defer hub.ResetPathCommand()
+	t.Run("returns the findings of each host along with the next actions of the failed rules", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1Report := cleanReport()
+		sdw1Report.Environment["PATH"] = sourceGPHome + "/bin:/usr/bin"
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		expectEnvironment(sdw1, sdw1Report)
+
+		sdw2Report := cleanReport()
+		sdw2Report.RsyncVersion = ""
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		expectEnvironment(sdw2, sdw2Report)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.CheckEnvironment(&step.BufferedStreams{}, agentConns, "cdw", sourceGPHome, intermediateGPHome)
+		var nextActionErr utils.NextActionErr
+		if !errors.As(err, &nextActionErr) {
+			t.Fatalf("got type %T, want type %T", err, nextActionErr)
+		}
+
+		var errs errorlist.Errors
+		if !errors.As(nextActionErr.Err, &errs) {
+			t.Fatalf("got type %T, want type %T", nextActionErr.Err, errs)
+		}
 
-		hub.SetLdLibraryPathCommand(exectest.NewCommand(hub.LdLibraryPathMain))
-		defer hub.ResetLdLibraryPathCommand()
+		expected := []string{
+			`on host sdw1 PATH entry "/usr/local/greenplum-db-source/bin" is within GPHOME "/usr/local/greenplum-db-source"`,
+			"on host sdw2 rsync is not installed",
+		}
+		if len(errs) != len(expected) {
+			t.Fatalf("got %d errors want %d: %v", len(errs), len(expected), errs)
+		}
 
-		err := hub.CheckEnvironment(append(hub.AgentHosts(source), source.CoordinatorHostname()), source.GPHome, intermediate.GPHome)
-		var expected utils.NextActionErr
-		if !errors.As(err, &expected) {
-			t.Fatalf("got type %T, want type %T", err, expected)
+		for i := range errs {
+			if errs[i].Error() != expected[i] {
+				t.Errorf("got error %q want %q", errs[i], expected[i])
+			}
 		}
 
-		if !reflect.DeepEqual(err, expected) {
-			t.Fatalf("got err %#v, want %#v", err, expected)
+		for _, action := range []string{"remove sourcing greenplum_path.sh", "Install rsync on all hosts."} {
+			if !strings.Contains(nextActionErr.NextAction, action) {
+				t.Errorf("expected next action %q to contain %q", nextActionErr.NextAction, action)
+			}
 		}
 	})
 
-	t.Run("returns error when failing to check LD_LIBRARY_PATH on segments", func(t *testing.T) {
-		hub.SetPathCommand(exectest.NewCommand(hub.PathMain))
-		defer hub.ResetPathCommand()
+	t.Run("writes warnings without failing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		report := cleanReport()
+		report.LoginEnvironment["PGPORT"] = "5432"
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		expectEnvironment(sdw1, report)
 
-		hub.SetLdLibraryPathCommand(exectest.NewCommand(hub.Failure))
-		defer hub.ResetLdLibraryPathCommand()
+		streams := &step.BufferedStreams{}
+		err := hub.CheckEnvironment(streams, []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}, "cdw", sourceGPHome, intermediateGPHome)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
 
-		err := hub.CheckEnvironment(append(hub.AgentHosts(source), source.CoordinatorHostname()), source.GPHome, intermediate.GPHome)
-		var expected utils.NextActionErr
-		if !errors.As(err, &expected) {
-			t.Fatalf("got type %T, want type %T", err, expected)
+		expected := `Warning: on host sdw1 login shell PGPORT is set to "5432"` + "\n"
+		if streams.StdoutBuf.String() != expected {
+			t.Errorf("got %q want %q", streams.StdoutBuf.String(), expected)
 		}
+	})
+
+	t.Run("errors when an agent fails to report", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("connection refused")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetEnvironment(gomock.Any(), gomock.Any()).Return(nil, expected)
 
-		if !reflect.DeepEqual(err, expected) {
-			t.Fatalf("got err %#v, want %#v", err, expected)
+		err := hub.CheckEnvironment(&step.BufferedStreams{}, []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}, "cdw", sourceGPHome, intermediateGPHome)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
 		}
 	})
 }
 
-func TestCheckEnvironmentOnSegment(t *testing.T) {
-	testlog.SetupTestLogger()
+func TestEnvironmentReports(t *testing.T) {
+	t.Run("uses the agent report when an agent runs on the coordinator host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hub.SetCollectEnvironment(func(gphomes ...string) *idl.EnvironmentReport {
+			t.Error("unexpected call to collect the coordinator environment")
+			return nil
+		})
+		defer hub.ResetCollectEnvironment()
 
-	sourceGphome := "/usr/local/greenplum-db-source"
-	intermediateGphome := "/usr/local/greenplum-db-target"
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		expectEnvironment(cdw, cleanReport())
 
-	t.Run("success cases", func(t *testing.T) {
-		cases := []struct {
-			name          string
-			path          string
-			ldLibraryPath string
-		}{
-			{
-				name: "succeeds when PATH does not contain source cluster GPHOME",
-				path: "/usr/local/pxf-gp5/bin:/usr/local/greenplum-cc-4.11.1/bin:/usr/local/bin:/usr/bin:/usr/local/sbin:/usr/sbin:/usr/local/greenplum-cloud:/home/gpadmin/.local/bin:/home/gpadmin/bin",
-			},
-			{
-				name:          "succeeds when LD_LIBRARY_PATH does not contain source cluster GPHOME",
-				ldLibraryPath: "",
-			},
+		reports, err := hub.EnvironmentReports(context.Background(), []*idl.Connection{{AgentClient: cdw, Hostname: "cdw"}}, "cdw", sourceGPHome, intermediateGPHome)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
 		}
 
-		host := "mdw"
-		utils.System.Hostname = func() (string, error) {
-			return host, nil
+		if len(reports) != 1 || reports[0].Host != "cdw" {
+			t.Errorf("got reports %v", reports)
 		}
+	})
 
-		hub.SetPathCommand(exectest.NewCommand(hub.PathMain))
-		defer hub.ResetPathCommand()
+	t.Run("measures the clock offset of each agent host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
-		hub.SetLdLibraryPathCommand(exectest.NewCommand(hub.LdLibraryPathMain))
-		defer hub.ResetLdLibraryPathCommand()
+		hub.SetCollectEnvironment(func(gphomes ...string) *idl.EnvironmentReport {
+			return cleanReport()
+		})
+		defer hub.ResetCollectEnvironment()
 
-		for _, c := range cases {
-			t.Run(c.name, func(t *testing.T) {
-				resetPath := testutils.SetEnv(t, "PATH", c.path)
-				defer resetPath()
+		report := cleanReport()
+		report.UnixNano = time.Now().Add(time.Hour).UnixNano()
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		expectEnvironment(sdw1, report)
 
-				resetLDLibraryPath := testutils.SetEnv(t, "LD_LIBRARY_PATH", c.ldLibraryPath)
-				defer resetLDLibraryPath()
+		reports, err := hub.EnvironmentReports(context.Background(), []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}, "cdw", sourceGPHome, intermediateGPHome)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
 
-				err := hub.CheckEnvironmentOnSegment(host, sourceGphome, intermediateGphome)
-				if err != nil {
-					t.Errorf("unexpected error %#v", err)
-				}
-			})
+		if len(reports) != 2 {
+			t.Fatalf("got %d reports want 2", len(reports))
+		}
+
+		offset := reports[0].ClockOffset
+		if offset < 59*time.Minute || offset > time.Hour {
+			t.Errorf("got clock offset %s want about an hour", offset)
 		}
-	})
 
-	t.Run("error cases", func(t *testing.T) {
-		errorCases := []struct {
-			name          string
-			path          string
-			ldLibraryPath string
-			expected      string
-		}{
-			{
-				name:     "errors when PATH contains source cluster GPHOME",
-				path:     fmt.Sprintf("%[1]s/ext/R-3.3.3/bin:%[1]s/bin:%[1]s/ext/python/bin:/usr/local/pxf-gp5/bin:/usr/local/greenplum-cc-4.11.1/bin:/usr/local/bin:/usr/bin:/usr/local/sbin:/usr/sbin:/usr/local/greenplum-cloud:/home/gpadmin/.local/bin:/home/gpadmin/bin", sourceGphome),
-				expected: "PATH contains GPHOME",
-			},
-			{
-				name:     "errors when PATH contains target cluster GPHOME",
-				path:     fmt.Sprintf("%[1]s/ext/R-3.3.3/bin:%[1]s/bin:%[1]s/ext/python/bin:/usr/local/pxf-gp5/bin:/usr/local/greenplum-cc-4.11.1/bin:/usr/local/bin:/usr/bin:/usr/local/sbin:/usr/sbin:/usr/local/greenplum-cloud:/home/gpadmin/.local/bin:/home/gpadmin/bin", intermediateGphome),
-				expected: "PATH contains GPHOME",
-			},
-			{
-				name:          "errors when LD_LIBRARY_PATH contains source cluster GPHOME",
-				ldLibraryPath: fmt.Sprintf("%[1]s/ext/R-3.3.3/lib:%[1]s/ext/R-3.3.3/extlib:%[1]s/lib:%[1]s/ext/python/lib", sourceGphome),
-				expected:      "LD_LIBRARY_PATH contains GPHOME",
-			},
-			{
-				name:          "errors when LD_LIBRARY_PATH contains target cluster GPHOME",
-				ldLibraryPath: fmt.Sprintf("%[1]s/ext/R-3.3.3/lib:%[1]s/ext/R-3.3.3/extlib:%[1]s/lib:%[1]s/ext/python/lib", intermediateGphome),
-				expected:      "LD_LIBRARY_PATH contains GPHOME",
-			},
-		}
-
-		host := "mdw"
-		utils.System.Hostname = func() (string, error) {
-			return host, nil
-		}
-
-		hub.SetPathCommand(exectest.NewCommand(hub.PathMain))
-		defer hub.ResetPathCommand()
-
-		hub.SetLdLibraryPathCommand(exectest.NewCommand(hub.LdLibraryPathMain))
-		defer hub.ResetLdLibraryPathCommand()
-
-		for _, c := range errorCases {
-			t.Run(c.name, func(t *testing.T) {
-				resetPath := testutils.SetEnv(t, "PATH", c.path)
-				defer resetPath()
-
-				resetLDLibraryPath := testutils.SetEnv(t, "LD_LIBRARY_PATH", c.ldLibraryPath)
-				defer resetLDLibraryPath()
-
-				err := hub.CheckEnvironmentOnSegment(host, sourceGphome, intermediateGphome)
-				if !strings.Contains(err.Error(), c.expected) {
-					t.Errorf("got %+v, want %+v", err, c.expected)
-				}
-			})
+		if reports[1].Host != "cdw" || reports[1].ClockOffset != 0 {
+			t.Errorf("got coordinator report %v", reports[1])
 		}
 	})
 }
@@ -5,6 +5,7 @@ package hub
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -24,8 +25,6 @@ func init() {
 	exectest.RegisterMains(
 		Success,
 		Failure,
-		PathMain,
-		LdLibraryPathMain,
 		StreamingMain,
 		EnvironmentMain,
 	)
@@ -38,14 +37,6 @@ func Failure() {
 	os.Exit(1)
 }
 
-func PathMain() {
-	fmt.Println(os.Getenv("PATH"))
-}
-
-func LdLibraryPathMain() {
-	fmt.Println(os.Getenv("LD_LIBRARY_PATH"))
-}
-
 const StreamingMainStdout = "expected\nstdout\n"
 const StreamingMainStderr = "process\nstderr\n"
 
@@ -84,6 +75,22 @@ func ResetCheckDiskUsage() {
 	checkDiskUsage = disk.CheckUsage
 }
 
+func SetMeasureDirs(measureFunc disk.MeasureDirsType) {
+	measureDirs = measureFunc
+}
+
+func ResetMeasureDirs() {
+	measureDirs = disk.MeasureDirs
+}
+
+func SetFilesystemStatus(statusFunc disk.FilesystemStatusType) {
+	filesystemStatus = statusFunc
+}
+
+func ResetFilesystemStatus() {
+	filesystemStatus = disk.FilesystemStatus
+}
+
 // MustCreateCluster creates a utils.Cluster and calls t.Fatalf() if there is
 // any error.
 func MustCreateCluster(t *testing.T, segments greenplum.SegConfigs) *greenplum.Cluster {
@@ -96,3 +103,7 @@ func MustCreateCluster(t *testing.T, segments greenplum.SegConfigs) *greenplum.C
 
 	return &cluster
 }
+
+func (s *Server) StepContext() (context.Context, func()) {
+	return s.stepContext()
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 
 	"github.com/blang/semver/v4"
@@ -49,6 +50,7 @@ func Copy(streams step.OutStreams, sourceDirs []string, agentHostsToBackupDir ba
 				rsync.WithDestination(backupDir),
 				rsync.WithOptions("--archive", "--compress", "--delete", "--stats"),
 				rsync.WithStream(stream),
+				rsync.WithContext(step.Context(streams)),
 			}
 
 			err := rsync.Rsync(options...)
@@ -95,7 +97,11 @@ func CopyCoordinatorDataDir(streams step.OutStreams, coordinatorDataDir string,
 	return Copy(streams, source, destinationHostToBackupDir)
 }
 
-func CopyCoordinatorTablespaces(streams step.OutStreams, sourceVersion semver.Version, tablespaces greenplum.Tablespaces, agentHostsToBackupDir backupdir.AgentHostsToBackupDir) error {
+// CopyCoordinatorTablespaces copies the coordinator's user defined tablespaces
+// to each host for the primaries to restore. Relocated tablespaces no longer
+// have their target directory within the original location, so their new
+// location is copied to where the primaries expect it.
+func CopyCoordinatorTablespaces(streams step.OutStreams, sourceVersion semver.Version, tablespaces greenplum.Tablespaces, tablespaceMappings greenplum.TablespaceMappings, coordinatorHost string, agentHostsToBackupDir backupdir.AgentHostsToBackupDir) error {
 	if tablespaces == nil && sourceVersion.Major != 5 {
 		return nil
 	}
@@ -106,7 +112,20 @@ func CopyCoordinatorTablespaces(streams step.OutStreams, sourceVersion semver.Ve
 		sourcePaths = append(sourcePaths, utils.GetStateDirOldTablespacesFile())
 	}
 
-	sourcePaths = append(sourcePaths, tablespaces.GetCoordinatorTablespaces().UserDefinedTablespacesLocations()...)
+	relocated := make(map[int32]string)
+	for oid, tsInfo := range tablespaces.GetCoordinatorTablespaces() {
+		if !tsInfo.GetUserDefined() {
+			continue
+		}
+
+		newLocation, ok := tablespaceMappings.Relocate(coordinatorHost, tsInfo.GetLocation())
+		if !ok {
+			sourcePaths = append(sourcePaths, tsInfo.GetLocation())
+			continue
+		}
+
+		relocated[oid] = newLocation
+	}
 
 	destinationHostToBackupDir := make(backupdir.AgentHostsToBackupDir)
 	for host, backupDir := range agentHostsToBackupDir {
@@ -115,5 +134,20 @@ func CopyCoordinatorTablespaces(streams step.OutStreams, sourceVersion semver.Ve
 		destinationHostToBackupDir[host] = utils.GetTablespaceBackupDir(backupDir) + string(os.PathSeparator)
 	}
 
-	return Copy(streams, sourcePaths, destinationHostToBackupDir)
+	err := Copy(streams, sourcePaths, destinationHostToBackupDir)
+	if err != nil {
+		return err
+	}
+
+	for oid, newLocation := range relocated {
+		destinations := make(backupdir.AgentHostsToBackupDir)
+		for host, backupDir := range agentHostsToBackupDir {
+			destinations[host] = filepath.Join(utils.GetTablespaceBackupDir(backupDir), strconv.Itoa(int(oid))) + string(os.PathSeparator)
+		}
+
+		source := filepath.Join(newLocation, strconv.Itoa(greenplum.CoordinatorDbid))
+		err = errorlist.Append(err, Copy(streams, []string{source}, destinations))
+	}
+
+	return err
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
@@ -291,7 +292,7 @@ func TestCopyCoordinatorTablespaces(t *testing.T) {
 		rsync.SetRsyncCommand(cmd)
 		defer rsync.ResetRsyncCommand()
 
-		err := hub.CopyCoordinatorTablespaces(step.DevNullStream, semver.MustParse("5.0.0"), Tablespaces, backupDirs.AgentHostsToBackupDir)
+		err := hub.CopyCoordinatorTablespaces(step.DevNullStream, semver.MustParse("5.0.0"), Tablespaces, nil, "cdw", backupDirs.AgentHostsToBackupDir)
 		if err != nil {
 			t.Errorf("copying coordinator tablespace directories and mapping file: %+v", err)
 		}
@@ -323,7 +324,7 @@ func TestCopyCoordinatorTablespaces(t *testing.T) {
 		rsync.SetRsyncCommand(cmd)
 		defer rsync.ResetRsyncCommand()
 
-		err := hub.CopyCoordinatorTablespaces(step.DevNullStream, semver.MustParse("5.0.0"), nil, backupDirs.AgentHostsToBackupDir)
+		err := hub.CopyCoordinatorTablespaces(step.DevNullStream, semver.MustParse("5.0.0"), nil, nil, "cdw", backupDirs.AgentHostsToBackupDir)
 		if err != nil {
 			t.Errorf("got %+v, want nil", err)
 		}
@@ -341,6 +342,48 @@ func TestCopyCoordinatorTablespaces(t *testing.T) {
 		verifyArgs(t, actualArgsChan, expectedArgs)
 	})
 
+	t.Run("copies relocated coordinator tablespaces from their new location", func(t *testing.T) {
+		actualArgsChan := make(chan []string, 2*len(backupDirs.AgentHostsToBackupDir))
+
+		cmd := exectest.NewCommandWithVerifier(hub.Success, func(name string, args ...string) {
+			actualArgsChan <- args
+		})
+		rsync.SetRsyncCommand(cmd)
+		defer rsync.ResetRsyncCommand()
+
+		mappings := greenplum.TablespaceMappings{{Host: "cdw", OldLocation: "/tmp", NewLocation: "/ssd"}}
+		err := hub.CopyCoordinatorTablespaces(step.DevNullStream, semver.MustParse("5.0.0"), Tablespaces, mappings, "cdw", backupDirs.AgentHostsToBackupDir)
+		if err != nil {
+			t.Errorf("copying coordinator tablespace directories and mapping file: %+v", err)
+		}
+
+		close(actualArgsChan)
+
+		var expectedArgs []string
+		for host, backupDir := range backupDirs.AgentHostsToBackupDir {
+			expectedArgs = append(expectedArgs, strings.Join([]string{
+				"--archive", "--compress", "--delete", "--stats",
+				utils.GetStateDirOldTablespacesFile(),
+				fmt.Sprintf("%s:%s", host, utils.GetTablespaceBackupDir(backupDir)+string(os.PathSeparator))}, " "))
+
+			expectedArgs = append(expectedArgs, strings.Join([]string{
+				"--archive", "--compress", "--delete", "--stats",
+				"/ssd/tblspc2/1",
+				fmt.Sprintf("%s:%s", host, filepath.Join(utils.GetTablespaceBackupDir(backupDir), "1664")+string(os.PathSeparator))}, " "))
+		}
+
+		var actualArgs []string
+		for args := range actualArgsChan {
+			actualArgs = append(actualArgs, strings.Join(args, " "))
+		}
+
+		sort.Strings(actualArgs)
+		sort.Strings(expectedArgs)
+		if !reflect.DeepEqual(actualArgs, expectedArgs) {
+			t.Errorf("got %q want %q", actualArgs, expectedArgs)
+		}
+	})
+
 	t.Run("when source version is 6X and higher it does not copy the --old-tablespace-file", func(t *testing.T) {
 		actualArgsChan := make(chan []string, len(backupDirs.AgentHostsToBackupDir))
 
@@ -355,7 +398,7 @@ func TestCopyCoordinatorTablespaces(t *testing.T) {
 		rsync.SetRsyncCommand(cmd)
 		defer rsync.ResetRsyncCommand()
 
-		err := hub.CopyCoordinatorTablespaces(step.DevNullStream, semver.MustParse("6.0.0"), Tablespaces, backupDirs.AgentHostsToBackupDir)
+		err := hub.CopyCoordinatorTablespaces(step.DevNullStream, semver.MustParse("6.0.0"), Tablespaces, nil, "cdw", backupDirs.AgentHostsToBackupDir)
 		if err != nil {
 			t.Errorf("copying coordinator tablespace directories and mapping file: %+v", err)
 		}
@@ -387,7 +430,7 @@ func TestCopyCoordinatorTablespaces(t *testing.T) {
 		rsync.SetRsyncCommand(cmd)
 		defer rsync.ResetRsyncCommand()
 
-		err := hub.CopyCoordinatorTablespaces(step.DevNullStream, semver.MustParse("6.0.0"), nil, backupDirs.AgentHostsToBackupDir)
+		err := hub.CopyCoordinatorTablespaces(step.DevNullStream, semver.MustParse("6.0.0"), nil, nil, "cdw", backupDirs.AgentHostsToBackupDir)
 		if err != nil {
 			t.Errorf("copying coordinator tablespace directories and mapping file: %+v", err)
 		}
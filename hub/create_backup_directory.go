@@ -27,17 +27,17 @@ func CreateBackupDirectories(streams step.OutStreams, agentConns []*idl.Connecti
 		return err
 	}
 
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		if _, ok := backupDirs.AgentHostsToBackupDir[conn.Hostname]; !ok {
 			return nil
 		}
 
 		req := &idl.CreateBackupDirectoryRequest{BackupDir: backupDirs.AgentHostsToBackupDir[conn.Hostname]}
-		_, err = conn.AgentClient.CreateBackupDirectory(context.Background(), req)
+		_, err = conn.AgentClient.CreateBackupDirectory(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(step.Context(streams), agentConns, request)
 }
 
 func CreateBackupDirectory(backupDir string) error {
@@ -17,7 +17,7 @@ func CreateRecoveryConfOnSegments(agentConns []*idl.Connection, intermediate *gr
 		return err
 	}
 
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		intermediateMirrors := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
 		})
@@ -37,9 +37,9 @@ func CreateRecoveryConfOnSegments(agentConns []*idl.Connection, intermediate *gr
 		}
 
 		req := &idl.CreateRecoveryConfRequest{Connections: connReqs}
-		_, err := conn.AgentClient.CreateRecoveryConf(context.Background(), req)
+		_, err := conn.AgentClient.CreateRecoveryConf(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(context.Background(), agentConns, request)
 }
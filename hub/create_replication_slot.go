@@ -38,3 +38,25 @@ func CreateReplicationSlots(db *sql.DB) error {
 
 	return nil
 }
+
+// CreateCoordinatorReplicationSlot creates the replication slot the standby
+// streams from on the coordinator, replacing any existing one.
+func CreateCoordinatorReplicationSlot(db *sql.DB) error {
+	var slots int
+	row := db.QueryRow(`SELECT COUNT(slot_name) FROM pg_replication_slots WHERE slot_name = 'internal_wal_replication_slot';`)
+	if err := row.Scan(&slots); err != nil && err != sql.ErrNoRows {
+		return xerrors.Errorf("querying pg_replication_slots: %w", err)
+	}
+
+	if slots > 0 {
+		if _, err := db.Exec(`SELECT pg_drop_replication_slot('internal_wal_replication_slot');`); err != nil {
+			return xerrors.Errorf("pg_drop_replication_slot: %w", err)
+		}
+	}
+
+	if _, err := db.Exec(`SELECT pg_create_physical_replication_slot('internal_wal_replication_slot');`); err != nil {
+		return xerrors.Errorf("pg_create_physical_replication_slot: %w", err)
+	}
+
+	return nil
+}
@@ -97,3 +97,55 @@ func TestCreateReplicationSlots(t *testing.T) {
 		}
 	})
 }
+
+func TestCreateCoordinatorReplicationSlot(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("couldn't create sqlmock: %v", err)
+	}
+	defer testutils.FinishMock(mock, t)
+
+	t.Run("creates the replication slot when there is none", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(slot_name\) FROM pg_replication_slots WHERE slot_name = 'internal_wal_replication_slot';`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		mock.ExpectExec(`SELECT pg_create_physical_replication_slot\('internal_wal_replication_slot'\);`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = hub.CreateCoordinatorReplicationSlot(db)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("deletes the existing replication slot for idempotence", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(slot_name\) FROM pg_replication_slots WHERE slot_name = 'internal_wal_replication_slot';`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		mock.ExpectExec(`SELECT pg_drop_replication_slot\('internal_wal_replication_slot'\);`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectExec(`SELECT pg_create_physical_replication_slot\('internal_wal_replication_slot'\);`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = hub.CreateCoordinatorReplicationSlot(db)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("errors when pg_create_physical_replication_slot fails", func(t *testing.T) {
+		expected := errors.New("connection failed")
+
+		mock.ExpectQuery(`SELECT COUNT\(slot_name\) FROM pg_replication_slots WHERE slot_name = 'internal_wal_replication_slot';`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		mock.ExpectExec(`SELECT pg_create_physical_replication_slot\('internal_wal_replication_slot'\);`).
+			WillReturnError(expected)
+
+		err = hub.CreateCoordinatorReplicationSlot(db)
+		if !errors.Is(err, expected) {
+			t.Errorf("got %#v want %#v", err, expected)
+		}
+	})
+}
@@ -18,15 +18,15 @@ func DeleteBackupDirectories(streams step.OutStreams, agentConns []*idl.Connecti
 		return err
 	}
 
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		if _, ok := backupDirs.AgentHostsToBackupDir[conn.Hostname]; !ok {
 			return nil
 		}
 
 		req := &idl.DeleteBackupDirectoryRequest{BackupDir: backupDirs.AgentHostsToBackupDir[conn.Hostname]}
-		_, err := conn.AgentClient.DeleteBackupDirectory(context.Background(), req)
+		_, err := conn.AgentClient.DeleteBackupDirectory(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(step.Context(streams), agentConns, request)
 }
@@ -23,14 +23,14 @@ func DeleteCoordinatorAndPrimaryDataDirectories(streams step.OutStreams, agentCo
 	intermediateSegs := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
 		return seg.IsPrimary()
 	})
-	err := deleteDataDirectories(agentConns, intermediateSegs)
+	err := deleteDataDirectories(step.Context(streams), agentConns, intermediateSegs)
 	err = errorlist.Append(err, <-coordinatorErr)
 
 	return err
 }
 
-func deleteDataDirectories(agentConns []*idl.Connection, segConfigs greenplum.SegConfigs) error {
-	request := func(conn *idl.Connection) error {
+func deleteDataDirectories(ctx context.Context, agentConns []*idl.Connection, segConfigs greenplum.SegConfigs) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 
 		segs := segConfigs.Select(func(seg *greenplum.SegConfig) bool {
 			return seg.Hostname == conn.Hostname
@@ -47,24 +47,24 @@ func deleteDataDirectories(agentConns []*idl.Connection, segConfigs greenplum.Se
 			req.Datadirs = append(req.Datadirs, datadir)
 		}
 
-		_, err := conn.AgentClient.DeleteDataDirectories(context.Background(), req)
+		_, err := conn.AgentClient.DeleteDataDirectories(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
 
-func DeleteTargetTablespaces(streams step.OutStreams, agentConns []*idl.Connection, target *greenplum.Cluster, intermediateCatalogVersion string, sourceTablespaces greenplum.Tablespaces) error {
+func DeleteTargetTablespaces(streams step.OutStreams, agentConns []*idl.Connection, target *greenplum.Cluster, intermediateCatalogVersion string, sourceTablespaces greenplum.Tablespaces, tablespaceMappings greenplum.TablespaceMappings) error {
 	var wg sync.WaitGroup
 	errs := make(chan error, 2)
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		errs <- DeleteTargetTablespacesOnCoordinator(streams, target, sourceTablespaces.GetCoordinatorTablespaces(), intermediateCatalogVersion)
+		errs <- DeleteTargetTablespacesOnCoordinator(streams, target, sourceTablespaces.GetCoordinatorTablespaces(), intermediateCatalogVersion, tablespaceMappings)
 	}()
 
-	errs <- DeleteTargetTablespacesOnPrimaries(agentConns, target, sourceTablespaces, intermediateCatalogVersion)
+	errs <- DeleteTargetTablespacesOnPrimaries(step.Context(streams), agentConns, target, sourceTablespaces, intermediateCatalogVersion, tablespaceMappings)
 
 	wg.Wait()
 	close(errs)
@@ -77,7 +77,7 @@ func DeleteTargetTablespaces(streams step.OutStreams, agentConns []*idl.Connecti
 	return err
 }
 
-func DeleteTargetTablespacesOnCoordinator(streams step.OutStreams, target *greenplum.Cluster, coordinatorTablespaces greenplum.SegmentTablespaces, catalogVersion string) error {
+func DeleteTargetTablespacesOnCoordinator(streams step.OutStreams, target *greenplum.Cluster, coordinatorTablespaces greenplum.SegmentTablespaces, catalogVersion string, tablespaceMappings greenplum.TablespaceMappings) error {
 	var dirs []string
 	for _, tsInfo := range coordinatorTablespaces {
 		if !tsInfo.GetUserDefined() {
@@ -88,11 +88,15 @@ func DeleteTargetTablespacesOnCoordinator(streams step.OutStreams, target *green
 		dirs = append(dirs, path)
 	}
 
+	for _, relocation := range tablespaceMappings.Relocations(target.Coordinator(), coordinatorTablespaces, target.Version.Major, catalogVersion) {
+		dirs = append(dirs, relocation.GetDestination())
+	}
+
 	return upgrade.DeleteTablespaceDirectories(streams, dirs)
 }
 
-func DeleteTargetTablespacesOnPrimaries(agentConns []*idl.Connection, target *greenplum.Cluster, tablespaces greenplum.Tablespaces, catalogVersion string) error {
-	request := func(conn *idl.Connection) error {
+func DeleteTargetTablespacesOnPrimaries(ctx context.Context, agentConns []*idl.Connection, target *greenplum.Cluster, tablespaces greenplum.Tablespaces, catalogVersion string, tablespaceMappings greenplum.TablespaceMappings) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		if target == nil {
 			return nil
 		}
@@ -116,12 +120,18 @@ func DeleteTargetTablespacesOnPrimaries(agentConns []*idl.Connection, target *gr
 				path := upgrade.TablespacePath(tsInfo.GetLocation(), int32(seg.DbID), target.Version.Major, catalogVersion)
 				dirs = append(dirs, path)
 			}
+
+			// Relocated tablespaces may be in either location depending on
+			// how far the upgrade got.
+			for _, relocation := range tablespaceMappings.Relocations(seg, segTablespaces, target.Version.Major, catalogVersion) {
+				dirs = append(dirs, relocation.GetDestination())
+			}
 		}
 
 		req := &idl.DeleteTablespaceRequest{Dirs: dirs}
-		_, err := conn.AgentClient.DeleteTablespaceDirectories(context.Background(), req)
+		_, err := conn.AgentClient.DeleteTablespaceDirectories(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
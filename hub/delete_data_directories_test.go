@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -143,7 +144,7 @@ func TestDeleteTablespaceDirectories(t *testing.T) {
 			},
 		}
 
-		err := hub.DeleteTargetTablespacesOnCoordinator(step.DevNullStream, target, coordinatorTablespaces, "301908232")
+		err := hub.DeleteTargetTablespacesOnCoordinator(step.DevNullStream, target, coordinatorTablespaces, "301908232", nil)
 		if err != nil {
 			t.Errorf("DeleteTargetTablespacesOnCoordinator returned error %+v", err)
 		}
@@ -163,6 +164,37 @@ func TestDeleteTablespaceDirectories(t *testing.T) {
 
 	})
 
+	t.Run("deletes both the original and relocated tablespace directories on the coordinator", func(t *testing.T) {
+		tsDir, _, tsLocation := testutils.MustMakeTablespaceDir(t, 16386)
+		defer testutils.MustRemoveAll(t, tsLocation)
+
+		relocatedDir, _, relocatedLocation := testutils.MustMakeTablespaceDir(t, 16386)
+		defer testutils.MustRemoveAll(t, relocatedLocation)
+
+		coordinatorTablespaces := greenplum.SegmentTablespaces{
+			16386: {
+				Location:    tsLocation,
+				UserDefined: true,
+			},
+		}
+
+		mappings := greenplum.TablespaceMappings{{
+			Host:        "coordinator",
+			OldLocation: filepath.Dir(tsLocation),
+			NewLocation: filepath.Dir(relocatedLocation),
+		}}
+
+		err := hub.DeleteTargetTablespacesOnCoordinator(step.DevNullStream, target, coordinatorTablespaces, "301908232", mappings)
+		if err != nil {
+			t.Errorf("DeleteTargetTablespacesOnCoordinator returned error %+v", err)
+		}
+
+		for _, dir := range []string{tsDir, relocatedDir} {
+			testutils.PathMustNotExist(t, dir)
+			testutils.PathMustNotExist(t, filepath.Dir(dir))
+		}
+	})
+
 	t.Run("deletes tablespace directories only on the primaries", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -245,7 +277,7 @@ func TestDeleteTablespaceDirectories(t *testing.T) {
 			{AgentClient: standby, Hostname: "standby"},
 		}
 
-		err := hub.DeleteTargetTablespacesOnPrimaries(agentConns, target, tablespaces, "301908232")
+		err := hub.DeleteTargetTablespacesOnPrimaries(context.Background(), agentConns, target, tablespaces, "301908232", nil)
 		if err != nil {
 			t.Errorf("DeleteTargetTablespacesOnPrimaries returned error %+v", err)
 		}
@@ -273,7 +305,7 @@ func TestDeleteTablespaceDirectories(t *testing.T) {
 			{AgentClient: failedClient, Hostname: "sdw2"},
 		}
 
-		err := hub.DeleteTargetTablespacesOnPrimaries(agentConns, target, nil, "")
+		err := hub.DeleteTargetTablespacesOnPrimaries(context.Background(), agentConns, target, nil, "", nil)
 
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -292,7 +324,7 @@ func TestDeleteTablespaceDirectories(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.DeleteTargetTablespacesOnPrimaries(agentConns, nil, nil, "")
+		err := hub.DeleteTargetTablespacesOnPrimaries(context.Background(), agentConns, nil, nil, "", nil)
 		if err != nil {
 			t.Errorf("unexpected error %#v", err)
 		}
@@ -10,14 +10,14 @@ import (
 )
 
 func DeleteStateDirectories(agentConns []*idl.Connection, excludeHostname string) error {
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		if conn.Hostname == excludeHostname {
 			return nil
 		}
 
-		_, err := conn.AgentClient.DeleteStateDirectory(context.Background(), &idl.DeleteStateDirectoryRequest{})
+		_, err := conn.AgentClient.DeleteStateDirectory(ctx, &idl.DeleteStateDirectoryRequest{})
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(context.Background(), agentConns, request)
 }
@@ -0,0 +1,239 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/config/backupdir"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+var diskWatchdogInterval = 10 * time.Second
+
+var filesystemStatus = disk.FilesystemStatus
+
+// DiskWatchdog monitors the filesystems on the coordinator and each agent host
+// while a step runs. It is best-effort: failing to watch a host is logged
+// rather than failing the step.
+type DiskWatchdog struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mutex  sync.Mutex
+	aborts map[string]*idl.WatchDiskSpaceReply
+}
+
+// StartDiskWatchdog watches the coordinator filesystems from the hub and those
+// of the segments from their agents. Since the hub runs the coordinator's
+// pg_upgrade and rsync itself, abort is called should a coordinator filesystem
+// drop below the abort threshold.
+func StartDiskWatchdog(streams step.OutStreams, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, backupDirs backupdir.BackupDirs, thresholds disk.Thresholds, abort func(cause error)) *DiskWatchdog {
+	ctx, cancel := context.WithCancel(context.Background())
+	watchdog := &DiskWatchdog{
+		cancel: cancel,
+		aborts: make(map[string]*idl.WatchDiskSpaceReply),
+	}
+
+	watchdog.wg.Add(1)
+	go func() {
+		defer watchdog.wg.Done()
+
+		err := watchdog.watchCoordinator(ctx, streams, coordinatorDirs(source, intermediate, backupDirs.CoordinatorBackupDir), thresholds, abort)
+		if err != nil {
+			log.Printf("disk space watchdog on the coordinator: %v", err)
+		}
+	}()
+
+	for _, conn := range agentConns {
+		dirs := watchedDirs(conn.Hostname, source, intermediate, backupDirs.AgentHostsToBackupDir)
+		if len(dirs) == 0 {
+			continue
+		}
+
+		req := &idl.WatchDiskSpaceRequest{
+			Dirs:           dirs,
+			WarnFreeRatio:  thresholds.Warn.Ratio,
+			WarnFreeKB:     thresholds.Warn.KB,
+			AbortFreeRatio: thresholds.Abort.Ratio,
+			AbortFreeKB:    thresholds.Abort.KB,
+		}
+
+		watchdog.wg.Add(1)
+		go func(conn *idl.Connection) {
+			defer watchdog.wg.Done()
+
+			err := watchdog.watch(ctx, streams, conn, req)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("disk space watchdog on host %s: %v", conn.Hostname, err)
+			}
+		}(conn)
+	}
+
+	return watchdog
+}
+
+func (w *DiskWatchdog) watch(ctx context.Context, streams step.OutStreams, conn *idl.Connection, req *idl.WatchDiskSpaceRequest) error {
+	stream, err := conn.AgentClient.WatchDiskSpace(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		w.record(streams, reply)
+	}
+}
+
+// watchCoordinator periodically checks the coordinator filesystems the same as
+// the agents do for theirs.
+func (w *DiskWatchdog) watchCoordinator(ctx context.Context, streams step.OutStreams, dirs []string, thresholds disk.Thresholds, abort func(cause error)) error {
+	levels := make(map[string]idl.WatchDiskSpaceReply_Level)
+	ticker := time.NewTicker(diskWatchdogInterval)
+	defer ticker.Stop()
+
+	for {
+		statuses, err := filesystemStatus(disk.Local, dirs...)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			status.Level = disk.Level(status, thresholds.Warn, thresholds.Abort)
+
+			if levels[status.GetFs()] != status.GetLevel() {
+				levels[status.GetFs()] = status.GetLevel()
+				w.record(streams, status)
+			}
+
+			if status.GetLevel() == idl.WatchDiskSpaceReply_abort {
+				abort(xerrors.Errorf("%w: %s", disk.ErrLowDiskSpace, disk.DescribeStatus(status)))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *DiskWatchdog) record(streams step.OutStreams, reply *idl.WatchDiskSpaceReply) {
+	description := disk.DescribeStatus(reply)
+	log.Printf("disk space %s: %s", reply.GetLevel(), description)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	switch reply.GetLevel() {
+	case idl.WatchDiskSpaceReply_warn:
+		fmt.Fprintf(streams.Stderr(), "Warning: %s.\n", description)
+	case idl.WatchDiskSpaceReply_abort:
+		fmt.Fprintf(streams.Stderr(), "Error: %s. Aborting to avoid running out of disk space.\n", description)
+		// Keep the abort even if space is later freed since the work it
+		// terminated still failed.
+		w.aborts[reply.GetHost()+":"+reply.GetFs()] = reply
+	}
+}
+
+// Stop stops watching and waits for the coordinator watch and the agents
+// streams to finish.
+func (w *DiskWatchdog) Stop() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+// Err adds a next action to errors caused by the watchdog aborting pg_upgrade
+// or rsync. Other errors are returned unchanged.
+func (w *DiskWatchdog) Err(step idl.Step, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.aborts) == 0 && !strings.Contains(err.Error(), disk.ErrLowDiskSpace.Error()) {
+		return err
+	}
+
+	var filesystems []string
+	for _, reply := range w.aborts {
+		filesystems = append(filesystems, "  "+disk.DescribeStatus(reply))
+	}
+	sort.Strings(filesystems)
+
+	nextAction := fmt.Sprintf(`The upgrade was stopped before running out of disk space.
+Free up space and inodes on the following filesystems and re-run "gpupgrade %s":
+%s
+
+Or run "gpupgrade revert" to restore the source cluster.`, step, strings.Join(filesystems, "\n"))
+
+	return utils.NewNextActionErr(fmt.Errorf("%w: %w", disk.ErrLowDiskSpace, err), nextAction)
+}
+
+// coordinatorDirs returns the source and intermediate coordinator data
+// directories, the source coordinator tablespaces, and the coordinator backup
+// directory.
+func coordinatorDirs(source *greenplum.Cluster, intermediate *greenplum.Cluster, coordinatorBackupDir string) []string {
+	dirs := []string{source.CoordinatorDataDir(), intermediate.CoordinatorDataDir()}
+	dirs = append(dirs, source.Tablespaces.GetCoordinatorTablespaces().UserDefinedTablespacesLocations()...)
+
+	if coordinatorBackupDir != "" {
+		dirs = append(dirs, coordinatorBackupDir)
+	}
+
+	sort.Strings(dirs)
+	return dirs
+}
+
+// watchedDirs returns the source and intermediate data directories and
+// tablespaces on the host along with its backup directory.
+func watchedDirs(host string, source *greenplum.Cluster, intermediate *greenplum.Cluster, agentHostsToBackupDir backupdir.AgentHostsToBackupDir) []string {
+	onHost := func(seg *greenplum.SegConfig) bool {
+		return seg.IsOnHost(host) && !seg.IsCoordinator()
+	}
+
+	var dirs []string
+	for _, seg := range source.SelectSegments(onHost) {
+		dirs = append(dirs, seg.DataDir)
+		dirs = append(dirs, source.Tablespaces[int32(seg.DbID)].UserDefinedTablespacesLocations()...)
+	}
+
+	for _, seg := range intermediate.SelectSegments(onHost) {
+		dirs = append(dirs, seg.DataDir)
+	}
+
+	if backupDir, ok := agentHostsToBackupDir[host]; ok {
+		dirs = append(dirs, backupDir)
+	}
+
+	sort.Strings(dirs)
+	return dirs
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/config/backupdir"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+func TestDiskWatchdog(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{ContentID: -1, DbID: 1, Hostname: "cdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 3, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+	})
+	source.Tablespaces = greenplum.Tablespaces{
+		2: {16384: {Location: "/tmp/user_ts/p1/16384", UserDefined: true}},
+	}
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{ContentID: -1, DbID: 1, Hostname: "cdw", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Role: greenplum.PrimaryRole},
+	})
+
+	backupDirs := backupdir.BackupDirs{
+		CoordinatorBackupDir:  "/data/qddir/.gpupgrade",
+		AgentHostsToBackupDir: backupdir.AgentHostsToBackupDir{"sdw1": "/data/dbfast1/.gpupgrade"},
+	}
+
+	// The coordinator filesystems have plenty of space unless a test says
+	// otherwise.
+	coordinatorOK := func(d disk.Disk, paths ...string) ([]*idl.WatchDiskSpaceReply, error) {
+		return []*idl.WatchDiskSpaceReply{{Fs: "/data", Host: "cdw", Available: 90_000, Total: 100_000}}, nil
+	}
+	hub.SetFilesystemStatus(coordinatorOK)
+	defer hub.ResetFilesystemStatus()
+
+	noAbort := func(t *testing.T) func(error) {
+		return func(cause error) {
+			t.Errorf("unexpected abort: %v", cause)
+		}
+	}
+
+	warn := &idl.WatchDiskSpaceReply{Level: idl.WatchDiskSpaceReply_warn, Fs: "/data", Host: "sdw1", Available: 5_000, Total: 100_000, AvailableInodes: 900, TotalInodes: 1000}
+	abort := &idl.WatchDiskSpaceReply{Level: idl.WatchDiskSpaceReply_abort, Fs: "/data", Host: "sdw1", Available: 1_000, Total: 100_000, AvailableInodes: 900, TotalInodes: 1000}
+
+	mockWatch := func(t *testing.T, ctrl *gomock.Controller, expected *idl.WatchDiskSpaceRequest, replies ...*idl.WatchDiskSpaceReply) *mock_idl.MockAgentClient {
+		stream := mock_idl.NewMockAgent_WatchDiskSpaceClient(ctrl)
+		for _, reply := range replies {
+			stream.EXPECT().Recv().Return(reply, nil)
+		}
+		stream.EXPECT().Recv().Return(nil, io.EOF)
+
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().WatchDiskSpace(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, req *idl.WatchDiskSpaceRequest, _ ...interface{}) (idl.Agent_WatchDiskSpaceClient, error) {
+			if !reflect.DeepEqual(req, expected) {
+				t.Errorf("got request %v want %v", req, expected)
+			}
+			return stream, nil
+		})
+
+		return client
+	}
+
+	t.Run("watches the data directories, tablespaces, and backup directory on each host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mockWatch(t, ctrl, &idl.WatchDiskSpaceRequest{
+			Dirs:           []string{"/data/dbfast1/.gpupgrade", "/data/dbfast1/seg.HqtFHX54y0o.1", "/data/dbfast1/seg1", "/tmp/user_ts/p1/16384"},
+			WarnFreeKB:     50 * 1000 * 1000,
+			AbortFreeRatio: 0.02,
+		})
+
+		sdw2 := mockWatch(t, ctrl, &idl.WatchDiskSpaceRequest{
+			Dirs:           []string{"/data/dbfast_mirror1/seg1"},
+			WarnFreeKB:     50 * 1000 * 1000,
+			AbortFreeRatio: 0.02,
+		})
+
+		thresholds := disk.Thresholds{Warn: disk.Threshold{KB: 50 * 1000 * 1000}, Abort: disk.Threshold{Ratio: 0.02}}
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		watchdog := hub.StartDiskWatchdog(step.DevNullStream, agentConns, source, intermediate, backupDirs, thresholds, noAbort(t))
+		watchdog.Stop()
+
+		expected := errors.New("permission denied")
+		err := watchdog.Err(idl.Step_execute, expected)
+		if err != expected {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("warns and adds a next action when aborted for low disk space", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mockWatch(t, ctrl, &idl.WatchDiskSpaceRequest{
+			Dirs:           []string{"/data/dbfast1/.gpupgrade", "/data/dbfast1/seg.HqtFHX54y0o.1", "/data/dbfast1/seg1", "/tmp/user_ts/p1/16384"},
+			WarnFreeRatio:  disk.DefaultThresholds.Warn.Ratio,
+			AbortFreeRatio: disk.DefaultThresholds.Abort.Ratio,
+		}, warn, abort)
+
+		streams := new(step.BufferedStreams)
+		watchdog := hub.StartDiskWatchdog(streams, []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}, source, intermediate, backupDirs, disk.DefaultThresholds, noAbort(t))
+		watchdog.Stop()
+
+		stderr := streams.StderrBuf.String()
+		for _, expected := range []string{"Warning: " + disk.DescribeStatus(warn), "Error: " + disk.DescribeStatus(abort)} {
+			if !strings.Contains(stderr, expected) {
+				t.Errorf("expected stderr %q to contain %q", stderr, expected)
+			}
+		}
+
+		pgUpgradeErr := errors.New("pg_upgrade terminated")
+		err := watchdog.Err(idl.Step_execute, pgUpgradeErr)
+		var nextActionErr utils.NextActionErr
+		if !errors.As(err, &nextActionErr) {
+			t.Fatalf("got type %T want %T", err, nextActionErr)
+		}
+
+		if !errors.Is(nextActionErr.Err, pgUpgradeErr) || !errors.Is(nextActionErr.Err, disk.ErrLowDiskSpace) {
+			t.Errorf("got error %#v want %#v and %#v", nextActionErr.Err, pgUpgradeErr, disk.ErrLowDiskSpace)
+		}
+
+		for _, expected := range []string{`re-run "gpupgrade execute"`, disk.DescribeStatus(abort)} {
+			if !strings.Contains(nextActionErr.NextAction, expected) {
+				t.Errorf("expected next action %q to contain %q", nextActionErr.NextAction, expected)
+			}
+		}
+	})
+
+	t.Run("watches the coordinator filesystems and aborts when low on space", func(t *testing.T) {
+		source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{ContentID: -1, DbID: 1, Hostname: "cdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+			{ContentID: 0, DbID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		})
+		source.Tablespaces = greenplum.Tablespaces{
+			1: {16384: {Location: "/tmp/user_ts/m/16384", UserDefined: true}},
+		}
+
+		coordinatorAbort := &idl.WatchDiskSpaceReply{Fs: "/data", Host: "cdw", Available: 1_000, Total: 100_000}
+		hub.SetFilesystemStatus(func(d disk.Disk, paths ...string) ([]*idl.WatchDiskSpaceReply, error) {
+			expected := []string{"/data/qddir/.gpupgrade", "/data/qddir/seg-1", "/data/qddir/seg.HqtFHX54y0o.-1", "/tmp/user_ts/m/16384"}
+			if !reflect.DeepEqual(paths, expected) {
+				t.Errorf("got paths %q want %q", paths, expected)
+			}
+
+			return []*idl.WatchDiskSpaceReply{coordinatorAbort}, nil
+		})
+		defer hub.SetFilesystemStatus(coordinatorOK)
+
+		var causes []error
+		streams := new(step.BufferedStreams)
+		watchdog := hub.StartDiskWatchdog(streams, nil, source, intermediate, backupDirs, disk.DefaultThresholds, func(cause error) {
+			causes = append(causes, cause)
+		})
+		watchdog.Stop()
+
+		if len(causes) != 1 || !errors.Is(causes[0], disk.ErrLowDiskSpace) {
+			t.Errorf("got abort causes %v want %v", causes, disk.ErrLowDiskSpace)
+		}
+
+		coordinatorAbort.Level = idl.WatchDiskSpaceReply_abort
+		expected := "Error: " + disk.DescribeStatus(coordinatorAbort)
+		if !strings.Contains(streams.StderrBuf.String(), expected) {
+			t.Errorf("expected stderr %q to contain %q", streams.StderrBuf.String(), expected)
+		}
+
+		err := watchdog.Err(idl.Step_execute, context.Canceled)
+		var nextActionErr utils.NextActionErr
+		if !errors.As(err, &nextActionErr) {
+			t.Fatalf("got type %T want %T", err, nextActionErr)
+		}
+
+		if !strings.Contains(nextActionErr.NextAction, "on host cdw") {
+			t.Errorf("expected next action %q to contain the coordinator filesystem", nextActionErr.NextAction)
+		}
+	})
+
+	t.Run("adds a next action when the agent error is due to low disk space", func(t *testing.T) {
+		watchdog := hub.StartDiskWatchdog(step.DevNullStream, nil, source, intermediate, backupDirs, disk.DefaultThresholds, noAbort(t))
+		watchdog.Stop()
+
+		err := watchdog.Err(idl.Step_finalize, errors.New("rpc error: low disk space: filesystem /data on host sdw1"))
+		var nextActionErr utils.NextActionErr
+		if !errors.As(err, &nextActionErr) {
+			t.Fatalf("got type %T want %T", err, nextActionErr)
+		}
+	})
+
+	t.Run("ignores errors from hosts it fails to watch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().WatchDiskSpace(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+		watchdog := hub.StartDiskWatchdog(step.DevNullStream, []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}, source, intermediate, backupDirs, disk.DefaultThresholds, noAbort(t))
+		watchdog.Stop()
+
+		if err := watchdog.Err(idl.Step_execute, nil); err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+}
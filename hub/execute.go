@@ -4,7 +4,6 @@
 package hub
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/greenplum-db/gpupgrade/config/backupdir"
@@ -14,18 +13,23 @@ import (
 )
 
 func (s *Server) Execute(req *idl.ExecuteRequest, stream idl.CliToHub_ExecuteServer) (err error) {
-	st, err := step.Begin(idl.Step_execute, stream)
+	notifications := s.notifier.Step(idl.Step_execute, s.events.Sender(idl.Step_execute, stream))
+	ctx, done := s.stepContext()
+	defer done()
+
+	st, err := step.Begin(ctx, idl.Step_execute, notifications, s.SubstepTimeouts)
 	if err != nil {
 		return err
 	}
 
-	st.AlwaysRun(idl.Substep_ensure_gpupgrade_agents_are_running, func(_ step.OutStreams) error {
-		_, err := RestartAgents(context.Background(), nil, AgentHosts(s.Source), s.AgentPort, utils.GetStateDir())
+	var agentConns []*idl.Connection
+	st.AlwaysRun(idl.Substep_ensure_gpupgrade_agents_are_running, func(streams step.OutStreams) error {
+		_, err := s.restartAgents(step.Context(streams))
 		if err != nil {
 			return err
 		}
 
-		_, err = s.AgentConns()
+		agentConns, err = s.AgentConns()
 		if err != nil {
 			return err
 		}
@@ -33,6 +37,11 @@ func (s *Server) Execute(req *idl.ExecuteRequest, stream idl.CliToHub_ExecuteSer
 		return nil
 	})
 
+	// Monitor disk space while the primaries are upgraded since copy mode can
+	// fill a filesystem hours into the run.
+	watchdog := StartDiskWatchdog(st.Streams(), agentConns, s.Source, s.Intermediate, s.BackupDirs, s.DiskThresholds, s.abortStep)
+	defer watchdog.Stop()
+
 	st.AlwaysRun(idl.Substep_check_active_connections_on_source_cluster, func(streams step.OutStreams) error {
 		return s.Source.CheckActiveConnections(streams)
 	})
@@ -46,7 +55,7 @@ func (s *Server) Execute(req *idl.ExecuteRequest, stream idl.CliToHub_ExecuteSer
 			return err
 		}
 
-		return s.Source.WaitForClusterToBeReady()
+		return s.Source.WaitForClusterToBeReady(step.Context(streams))
 	})
 
 	st.AlwaysRun(idl.Substep_shutdown_source_cluster, func(streams step.OutStreams) error {
@@ -55,7 +64,8 @@ func (s *Server) Execute(req *idl.ExecuteRequest, stream idl.CliToHub_ExecuteSer
 
 	pgUpgradeTimestamp := utils.System.Now().Format(TimeStringFormat)
 	st.Run(idl.Substep_upgrade_master, func(streams step.OutStreams) error {
-		return UpgradeCoordinator(streams, s.BackupDirs.CoordinatorBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_upgrade, s.Mode, pgUpgradeTimestamp)
+		err := UpgradeCoordinator(streams, s.BackupDirs.CoordinatorBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_upgrade, s.Mode, pgUpgradeTimestamp, s.TablespaceMappings)
+		return watchdog.Err(idl.Step_execute, err)
 	})
 
 	st.Run(idl.Substep_copy_master, func(streams step.OutStreams) error {
@@ -105,7 +115,7 @@ the master.`
 			return utils.NewNextActionErr(err, nextAction)
 		}
 
-		err = CopyCoordinatorTablespaces(streams, s.Source.Version, s.Source.Tablespaces, s.BackupDirs.AgentHostsToBackupDir)
+		err = CopyCoordinatorTablespaces(streams, s.Source.Version, s.Source.Tablespaces, s.TablespaceMappings, s.Source.CoordinatorHostname(), s.BackupDirs.AgentHostsToBackupDir)
 		if err != nil {
 			return utils.NewNextActionErr(err, nextAction)
 		}
@@ -114,7 +124,8 @@ the master.`
 	})
 
 	st.Run(idl.Substep_upgrade_primaries, func(streams step.OutStreams) error {
-		return UpgradePrimaries(s.agentConns, s.BackupDirs.AgentHostsToBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_upgrade, s.Mode, pgUpgradeTimestamp)
+		err := UpgradePrimaries(step.Context(streams), s.agentConns, s.BackupDirs.AgentHostsToBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_upgrade, s.Mode, pgUpgradeTimestamp, s.TablespaceMappings)
+		return watchdog.Err(idl.Step_execute, err)
 	})
 
 	st.AlwaysRun(idl.Substep_start_target_cluster, func(streams step.OutStreams) error {
@@ -136,5 +147,5 @@ the master.`
 		return err
 	}
 
-	return st.Err()
+	return notifications.Finish(st.Err())
 }
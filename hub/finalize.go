@@ -4,9 +4,9 @@
 package hub
 
 import (
-	"context"
 	"time"
 
+	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
@@ -14,18 +14,23 @@ import (
 )
 
 func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_FinalizeServer) (err error) {
-	st, err := step.Begin(idl.Step_finalize, stream)
+	notifications := s.notifier.Step(idl.Step_finalize, s.events.Sender(idl.Step_finalize, stream))
+	ctx, done := s.stepContext()
+	defer done()
+
+	st, err := step.Begin(ctx, idl.Step_finalize, notifications, s.SubstepTimeouts)
 	if err != nil {
 		return err
 	}
 
-	st.AlwaysRun(idl.Substep_ensure_gpupgrade_agents_are_running, func(_ step.OutStreams) error {
-		_, err := RestartAgents(context.Background(), nil, AgentHosts(s.Source), s.AgentPort, utils.GetStateDir())
+	var agentConns []*idl.Connection
+	st.AlwaysRun(idl.Substep_ensure_gpupgrade_agents_are_running, func(streams step.OutStreams) error {
+		_, err := s.restartAgents(step.Context(streams))
 		if err != nil {
 			return err
 		}
 
-		_, err = s.AgentConns()
+		agentConns, err = s.AgentConns()
 		if err != nil {
 			return err
 		}
@@ -33,24 +38,44 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 		return nil
 	})
 
+	// Monitor disk space while the mirrors are upgraded.
+	watchdog := StartDiskWatchdog(st.Streams(), agentConns, s.Source, s.Intermediate, s.BackupDirs, s.DiskThresholds, s.abortStep)
+	defer watchdog.Stop()
+
 	st.AlwaysRun(idl.Substep_check_active_connections_on_target_cluster, func(streams step.OutStreams) error {
 		return s.Intermediate.CheckActiveConnections(streams)
 	})
 
-	st.RunConditionally(idl.Substep_upgrade_mirrors, s.Source.HasMirrors() && s.Mode == idl.Mode_link, func(streams step.OutStreams) error {
-		return UpgradeMirrorsUsingRsync(s.agentConns, s.Source, s.Intermediate, s.UseHbaHostnames)
+	mirrorUpgradeMethod := ResolveMirrorUpgradeMethod(s.Mode, s.MirrorUpgradeMethod)
+
+	st.RunConditionally(idl.Substep_upgrade_mirrors, s.Intermediate.HasMirrors(), func(streams step.OutStreams) error {
+		switch mirrorUpgradeMethod {
+		case idl.MirrorUpgradeMethod_rsync:
+			err := UpgradeMirrorsUsingRsync(step.Context(streams), streams, s.agentConns, s.Source, s.Intermediate, s.UseHbaHostnames, s.AddressFamily)
+			return watchdog.Err(idl.Step_finalize, err)
+		case idl.MirrorUpgradeMethod_pg_basebackup:
+			err := UpgradeMirrorsUsingPgBasebackup(step.Context(streams), streams, s.agentConns, s.Intermediate, s.UseHbaHostnames, s.AddressFamily)
+			return watchdog.Err(idl.Step_finalize, err)
+		default:
+			err := UpgradeMirrorsUsingGpAddMirrors(streams, s.Intermediate, s.UseHbaHostnames)
+			return watchdog.Err(idl.Step_finalize, err)
+		}
 	})
 
-	st.RunConditionally(idl.Substep_upgrade_mirrors, s.Source.HasMirrors() && s.Mode != idl.Mode_link, func(streams step.OutStreams) error {
-		return UpgradeMirrorsUsingGpAddMirrors(streams, s.Intermediate, s.UseHbaHostnames)
-	})
+	// gpinitstandby uses pg_basebackup so only rsync has its own standby
+	// upgrade. A standby added during the upgrade has no source standby to
+	// rsync from and is always created with gpinitstandby.
+	st.RunConditionally(idl.Substep_upgrade_standby, s.Intermediate.HasStandby(), func(streams step.OutStreams) error {
+		if mirrorUpgradeMethod == idl.MirrorUpgradeMethod_rsync && s.Source.HasStandby() {
+			err := UpgradeStandbyUsingRsync(step.Context(streams), streams, s.agentConns, s.Source, s.Intermediate, s.UseHbaHostnames, s.AddressFamily)
+			return watchdog.Err(idl.Step_finalize, err)
+		}
 
-	st.RunConditionally(idl.Substep_upgrade_standby, s.Source.HasStandby(), func(streams step.OutStreams) error {
 		return UpgradeStandby(streams, s.Intermediate, s.UseHbaHostnames)
 	})
 
 	st.Run(idl.Substep_wait_for_cluster_to_be_ready_after_adding_mirrors_and_standby, func(streams step.OutStreams) error {
-		return s.Intermediate.WaitForClusterToBeReady()
+		return s.Intermediate.WaitForClusterToBeReady(step.Context(streams))
 	})
 
 	st.AlwaysRun(idl.Substep_shutdown_target_cluster, func(streams step.OutStreams) error {
@@ -69,8 +94,18 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 		return s.Intermediate.StopCoordinatorOnly(streams)
 	})
 
+	st.Run(idl.Substep_migrate_pg_hba, func(streams step.OutStreams) error {
+		unmigratable, err := MigratePgHba(streams, s.agentConns, s.Source, s.Intermediate)
+		if err != nil {
+			return err
+		}
+
+		s.UnmigratablePgHbaRules = unmigratablePgHbaRules(unmigratable)
+		return s.Config.Write()
+	})
+
 	st.Run(idl.Substep_update_data_directories, func(_ step.OutStreams) error {
-		return RenameDataDirectories(s.agentConns, s.Source, s.Intermediate)
+		return RenameDataDirectories(s.agentConns, s.Source, s.Intermediate, s.Target)
 	})
 
 	st.Run(idl.Substep_update_target_conf_files, func(streams step.OutStreams) error {
@@ -86,7 +121,14 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 	})
 
 	st.AlwaysRun(idl.Substep_wait_for_cluster_to_be_ready_after_updating_catalog, func(streams step.OutStreams) error {
-		return s.Target.WaitForClusterToBeReady()
+		return s.Target.WaitForClusterToBeReady(step.Context(streams))
+	})
+
+	var topologyDrift []string
+	st.AlwaysRun(idl.Substep_verify_target_cluster, func(streams step.OutStreams) error {
+		var err error
+		topologyDrift, err = VerifyTargetCluster(streams, s.Source, s.Intermediate, s.Target, s.UpgradeID, s.TablespaceMappings)
+		return err
 	})
 
 	var logArchiveDir string
@@ -119,6 +161,8 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 			LogArchiveDirectory:                    logArchiveDir,
 			ArchivedSourceCoordinatorDataDirectory: s.Config.Intermediate.CoordinatorDataDir() + upgrade.OldSuffix,
 			UpgradeID:                              s.Config.UpgradeID,
+			TopologyDrift:                          topologyDrift,
+			UnmigratablePgHbaRules:                 s.UnmigratablePgHbaRules,
 		},
 	}}}}
 
@@ -126,5 +170,14 @@ func (s *Server) Finalize(req *idl.FinalizeRequest, stream idl.CliToHub_Finalize
 		return err
 	}
 
-	return st.Err()
+	return notifications.Finish(st.Err())
+}
+
+func unmigratablePgHbaRules(unmigratable []greenplum.UnmigratableHBARule) []string {
+	var rules []string
+	for _, rule := range unmigratable {
+		rules = append(rules, rule.String())
+	}
+
+	return rules
 }
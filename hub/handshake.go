@@ -0,0 +1,102 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// Handshake ensures every agent runs the same gpupgrade build and protocol as
+// the hub. Unlike upgrade.EnsureGpupgradeVersionsMatch which checks the
+// installed binaries, this checks the running agents.
+func Handshake(agentConns []*idl.Connection, hub *idl.HandshakeReply) error {
+	var mutex sync.Mutex
+	agents := make(map[string]*idl.HandshakeReply)
+
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		reply, err := conn.AgentClient.Handshake(ctx, &idl.HandshakeRequest{})
+		if err != nil {
+			return xerrors.Errorf("handshake with agent on host %s: %w", conn.Hostname, err)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		agents[conn.Hostname] = reply
+
+		return nil
+	}
+
+	err := ExecuteRPC(context.Background(), agentConns, request)
+	if err != nil {
+		return err
+	}
+
+	for _, agent := range agents {
+		if !versionsMatch(hub, agent) {
+			nextAction := `Install the same gpupgrade version on all hosts. Then restart the hub and agents with "gpupgrade kill-services && gpupgrade restart-services".`
+			return utils.NewNextActionErr(&AgentVersionMismatchError{Hub: hub, Agents: agents}, nextAction)
+		}
+	}
+
+	return nil
+}
+
+func versionsMatch(hub *idl.HandshakeReply, agent *idl.HandshakeReply) bool {
+	return agent.GetVersion() == hub.GetVersion() &&
+		agent.GetCommit() == hub.GetCommit() &&
+		agent.GetIdlRevision() == hub.GetIdlRevision()
+}
+
+type AgentVersionMismatchError struct {
+	Hub    *idl.HandshakeReply
+	Agents map[string]*idl.HandshakeReply
+}
+
+func (a *AgentVersionMismatchError) Error() string {
+	var b strings.Builder
+	b.WriteString("gpupgrade version mismatch between the hub and running agents.\n\n")
+
+	// Pretty-print our output with tab-alignment.
+	var t tabwriter.Writer
+	t.Init(&b, 0, 0, 2, ' ', 0)
+
+	for _, row := range a.Table() {
+		fmt.Fprintln(&t, strings.Join(row, "\t"))
+	}
+
+	t.Flush()
+	return b.String()
+}
+
+// Table lists the hub followed by each agent host with the mismatched agents
+// marked.
+func (a *AgentVersionMismatchError) Table() [][]string {
+	row := func(host string, reply *idl.HandshakeReply, mark string) []string {
+		return []string{host, reply.GetVersion(), reply.GetCommit(), reply.GetIdlRevision(), reply.GetStateDir(), mark}
+	}
+
+	var rows [][]string
+	for host, agent := range a.Agents {
+		mark := ""
+		if !versionsMatch(a.Hub, agent) {
+			mark = "mismatch"
+		}
+
+		rows = append(rows, row(host, agent, mark))
+	}
+	sort.Sort(utils.TableRows(rows))
+
+	header := []string{"Host", "Version", "Commit", "IDL Revision", "State Directory", ""}
+	return append([][]string{header, row("hub", a.Hub, "")}, rows...)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func TestHandshake(t *testing.T) {
+	hubReply := &idl.HandshakeReply{Version: "1.2.3", Commit: "5889c19", IdlRevision: "abc123", StateDir: "/home/gpadmin/.gpupgrade"}
+
+	mockHandshake := func(ctrl *gomock.Controller, reply *idl.HandshakeReply, err error) *mock_idl.MockAgentClient {
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().Handshake(gomock.Any(), &idl.HandshakeRequest{}).Return(reply, err)
+		return client
+	}
+
+	t.Run("succeeds when all agents match the hub", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		agentConns := []*idl.Connection{
+			{AgentClient: mockHandshake(ctrl, hubReply, nil), Hostname: "sdw1"},
+			{AgentClient: mockHandshake(ctrl, hubReply, nil), Hostname: "sdw2"},
+		}
+
+		err := hub.Handshake(agentConns, hubReply)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("errors with a table of versions when an agent does not match", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mismatched := &idl.HandshakeReply{Version: "1.2.0", Commit: "e4c3a5f", IdlRevision: "def456", StateDir: "/home/gpadmin/.gpupgrade"}
+		agentConns := []*idl.Connection{
+			{AgentClient: mockHandshake(ctrl, hubReply, nil), Hostname: "sdw1"},
+			{AgentClient: mockHandshake(ctrl, mismatched, nil), Hostname: "sdw2"},
+		}
+
+		err := hub.Handshake(agentConns, hubReply)
+		var nextActionErr utils.NextActionErr
+		if !errors.As(err, &nextActionErr) {
+			t.Fatalf("got type %T want %T", err, nextActionErr)
+		}
+
+		if !strings.Contains(nextActionErr.NextAction, "gpupgrade kill-services && gpupgrade restart-services") {
+			t.Errorf("unexpected next action %q", nextActionErr.NextAction)
+		}
+
+		var mismatchErr *hub.AgentVersionMismatchError
+		if !errors.As(nextActionErr.Err, &mismatchErr) {
+			t.Fatalf("got type %T want %T", nextActionErr.Err, mismatchErr)
+		}
+
+		expected := [][]string{
+			{"Host", "Version", "Commit", "IDL Revision", "State Directory", ""},
+			{"hub", "1.2.3", "5889c19", "abc123", "/home/gpadmin/.gpupgrade", ""},
+			{"sdw1", "1.2.3", "5889c19", "abc123", "/home/gpadmin/.gpupgrade", ""},
+			{"sdw2", "1.2.0", "e4c3a5f", "def456", "/home/gpadmin/.gpupgrade", "mismatch"},
+		}
+		if !reflect.DeepEqual(mismatchErr.Table(), expected) {
+			t.Errorf("got %q want %q", mismatchErr.Table(), expected)
+		}
+	})
+
+	t.Run("detects a mismatched IDL revision with the same version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mismatched := &idl.HandshakeReply{Version: "1.2.3", Commit: "5889c19", IdlRevision: "def456", StateDir: "/home/gpadmin/.gpupgrade"}
+		agentConns := []*idl.Connection{
+			{AgentClient: mockHandshake(ctrl, mismatched, nil), Hostname: "sdw1"},
+		}
+
+		err := hub.Handshake(agentConns, hubReply)
+		var nextActionErr utils.NextActionErr
+		if !errors.As(err, &nextActionErr) {
+			t.Fatalf("got type %T want %T", err, nextActionErr)
+		}
+
+		var mismatchErr *hub.AgentVersionMismatchError
+		if !errors.As(nextActionErr.Err, &mismatchErr) {
+			t.Errorf("got type %T want %T", nextActionErr.Err, mismatchErr)
+		}
+	})
+
+	t.Run("errors when the handshake fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("unimplemented")
+		agentConns := []*idl.Connection{
+			{AgentClient: mockHandshake(ctrl, nil, expected), Hostname: "sdw1"},
+		}
+
+		err := hub.Handshake(agentConns, hubReply)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
@@ -68,7 +69,7 @@ func TestRestartAgent(t *testing.T) {
 			return listener.Dial()
 		}
 
-		restartedHosts, err := hub.RestartAgents(ctx, dialer, hostnames, port, stateDir)
+		restartedHosts, err := hub.RestartAgents(ctx, dialer, utils.AnyAddressFamily, hostnames, port, stateDir)
 		if err != nil {
 			t.Errorf("returned %#v", err)
 		}
@@ -88,7 +89,7 @@ func TestRestartAgent(t *testing.T) {
 			return listener.Dial()
 		}
 
-		restartedHosts, err := hub.RestartAgents(ctx, dialer, hostnames, port, stateDir)
+		restartedHosts, err := hub.RestartAgents(ctx, dialer, utils.AnyAddressFamily, hostnames, port, stateDir)
 		if err != nil {
 			t.Errorf("returned %#v", err)
 		}
@@ -111,7 +112,7 @@ func TestRestartAgent(t *testing.T) {
 			return nil, immediateFailure{}
 		}
 
-		restartedHosts, err := hub.RestartAgents(ctx, dialer, hostnames, port, stateDir)
+		restartedHosts, err := hub.RestartAgents(ctx, dialer, utils.AnyAddressFamily, hostnames, port, stateDir)
 		if err == nil {
 			t.Errorf("expected restart agents to fail")
 		}
@@ -137,6 +138,33 @@ func TestRestartAgent(t *testing.T) {
 		}
 	})
 
+	t.Run("does not start agents once the context is canceled", func(t *testing.T) {
+		hub.SetExecCommand(exectest.NewCommand(gpupgrade_agent))
+
+		dialer := func(ctx context.Context, address string) (net.Conn, error) {
+			return nil, immediateFailure{}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		restartedHosts, err := hub.RestartAgents(ctx, dialer, utils.AnyAddressFamily, hostnames, port, stateDir)
+		var errs errorlist.Errors
+		if !errors.As(err, &errs) {
+			t.Fatalf("got error %#v, want type %T", err, errs)
+		}
+
+		for _, err := range errs {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("got error %#v want %#v", err, context.Canceled)
+			}
+		}
+
+		if len(restartedHosts) != 0 {
+			t.Errorf("restarted hosts %v", restartedHosts)
+		}
+	})
+
 	t.Run("starts agents with correct args including specified port and state directory", func(t *testing.T) {
 		host := "host1"
 
@@ -162,11 +190,38 @@ func TestRestartAgent(t *testing.T) {
 			return listener.Dial()
 		}
 
-		_, err := hub.RestartAgents(ctx, dialer, hostnames, port, stateDir)
+		_, err := hub.RestartAgents(ctx, dialer, utils.AnyAddressFamily, hostnames, port, stateDir)
 		if err != nil {
 			t.Errorf("unexpected errr %#v", err)
 		}
 	})
+
+	t.Run("restricts ssh to the address family", func(t *testing.T) {
+		host := "host1"
+
+		execCmd := exectest.NewCommandWithVerifier(gpupgrade_agent, func(name string, args ...string) {
+			cmd := fmt.Sprintf("bash -c \"%s/gpupgrade agent --daemonize --port %d --state-directory %s\"", testutils.MustGetExecutablePath(t), port, stateDir)
+			expected := []string{"-6", host, cmd}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got %q want %q", args, expected)
+			}
+		})
+		hub.SetExecCommand(execCmd)
+		defer hub.ResetExecCommand()
+
+		dialer := func(ctx context.Context, address string) (net.Conn, error) {
+			if strings.HasPrefix(address, host) { // fail connection attempts to host
+				return nil, immediateFailure{}
+			}
+
+			return listener.Dial()
+		}
+
+		_, err := hub.RestartAgents(ctx, dialer, utils.IPv6AddressFamily, hostnames, port, stateDir)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
 }
 
 // immediateFailure is an error that is explicitly marked non-temporary for
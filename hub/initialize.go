@@ -4,9 +4,9 @@
 package hub
 
 import (
-	"context"
 	"log"
 
+	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/upgrade"
@@ -14,18 +14,22 @@ import (
 )
 
 func (s *Server) Initialize(req *idl.InitializeRequest, stream idl.CliToHub_InitializeServer) (err error) {
-	st, err := step.Begin(idl.Step_initialize, stream)
+	notifications := s.notifier.Step(idl.Step_initialize, s.events.Sender(idl.Step_initialize, stream))
+	ctx, done := s.stepContext()
+	defer done()
+
+	st, err := step.Begin(ctx, idl.Step_initialize, notifications, s.SubstepTimeouts)
 	if err != nil {
 		return err
 	}
 
 	// Since the agents might not be up if gpupgrade is not properly installed, check it early on using ssh.
 	st.Run(idl.Substep_verify_gpupgrade_is_installed_across_all_hosts, func(streams step.OutStreams) error {
-		return upgrade.EnsureGpupgradeVersionsMatch(AgentHosts(s.Source))
+		return upgrade.EnsureGpupgradeVersionsMatch(AgentHosts(s.Source, s.Target))
 	})
 
-	st.AlwaysRun(idl.Substep_start_agents, func(_ step.OutStreams) error {
-		_, err := RestartAgents(context.Background(), nil, AgentHosts(s.Source), s.AgentPort, utils.GetStateDir())
+	st.AlwaysRun(idl.Substep_start_agents, func(streams step.OutStreams) error {
+		_, err := s.restartAgents(step.Context(streams))
 		if err != nil {
 			return err
 		}
@@ -39,7 +43,7 @@ func (s *Server) Initialize(req *idl.InitializeRequest, stream idl.CliToHub_Init
 	})
 
 	st.AlwaysRun(idl.Substep_check_environment, func(streams step.OutStreams) error {
-		return CheckEnvironment(append(AgentHosts(s.Source), s.Source.CoordinatorHostname()), s.Source.GPHome, s.Intermediate.GPHome)
+		return CheckEnvironment(streams, s.agentConns, s.Source.CoordinatorHostname(), s.Source.GPHome, s.Intermediate.GPHome)
 	})
 
 	st.Run(idl.Substep_create_backupdirs, func(streams step.OutStreams) error {
@@ -65,20 +69,24 @@ the master.
 			return utils.NewNextActionErr(err, nextAction)
 		}
 
-		// The following CheckDiskSpace encapsulates the backup directory since
-		// it usually is on the same filesystem as the data directories.
+		// The following CheckDiskSpace accounts for the copy of the upgraded
+		// coordinator stored in the backup directory.
 		return nil
 	})
 
-	st.RunConditionally(idl.Substep_check_disk_space, req.GetDiskFreeRatio() > 0, func(streams step.OutStreams) error {
-		return CheckDiskSpace(streams, s.agentConns, req.GetDiskFreeRatio(), s.Source, s.Source.Tablespaces)
+	st.RunConditionally(idl.Substep_check_disk_space, !req.GetSkipDiskSpaceCheck(), func(streams step.OutStreams) error {
+		return CheckDiskSpace(streams, s.agentConns, s.Mode, s.Source, s.Source.Tablespaces, s.BackupDirs.AgentHostsToBackupDir)
 	})
 
-	return st.Err()
+	return notifications.Fail(st.Err())
 }
 
 func (s *Server) InitializeCreateCluster(req *idl.InitializeCreateClusterRequest, stream idl.CliToHub_InitializeCreateClusterServer) (err error) {
-	st, err := step.Begin(idl.Step_initialize, stream)
+	notifications := s.notifier.ContinueStep(idl.Step_initialize, s.events.Sender(idl.Step_initialize, stream))
+	ctx, done := s.stepContext()
+	defer done()
+
+	st, err := step.Begin(ctx, idl.Step_initialize, notifications, s.SubstepTimeouts)
 	if err != nil {
 		return err
 	}
@@ -114,6 +122,16 @@ func (s *Server) InitializeCreateCluster(req *idl.InitializeCreateClusterRequest
 		return AppendDynamicLibraryPath(s.Intermediate, req.GetDynamicLibraryPath())
 	})
 
+	st.Run(idl.Substep_migrate_source_settings, func(stream step.OutStreams) error {
+		unmigratable, err := MigrateSourceSettings(stream, s.agentConns, s.Source, s.Intermediate)
+		if err != nil {
+			return err
+		}
+
+		s.UnmigratableSettings = unmigratableSettings(unmigratable)
+		return s.Config.Write()
+	})
+
 	st.AlwaysRun(idl.Substep_shutdown_target_cluster, func(stream step.OutStreams) error {
 		return s.Intermediate.Stop(stream)
 	})
@@ -126,7 +144,7 @@ func (s *Server) InitializeCreateCluster(req *idl.InitializeCreateClusterRequest
 	})
 
 	st.AlwaysRun(idl.Substep_initialize_wait_for_cluster_to_be_ready, func(streams step.OutStreams) error {
-		return s.Source.WaitForClusterToBeReady()
+		return s.Source.WaitForClusterToBeReady(step.Context(streams))
 	})
 
 	st.AlwaysRun(idl.Substep_check_upgrade, func(stream step.OutStreams) error {
@@ -137,16 +155,17 @@ func (s *Server) InitializeCreateCluster(req *idl.InitializeCreateClusterRequest
 
 		pgUpgradeTimestamp := utils.System.Now().Format(TimeStringFormat)
 
-		if err := UpgradeCoordinator(stream, s.BackupDirs.CoordinatorBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_check, s.Mode, pgUpgradeTimestamp); err != nil {
+		if err := UpgradeCoordinator(stream, s.BackupDirs.CoordinatorBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_check, s.Mode, pgUpgradeTimestamp, s.TablespaceMappings); err != nil {
 			return err
 		}
 
-		return UpgradePrimaries(s.agentConns, s.BackupDirs.AgentHostsToBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_check, s.Mode, pgUpgradeTimestamp)
+		return UpgradePrimaries(step.Context(stream), s.agentConns, s.BackupDirs.AgentHostsToBackupDir, req.GetPgUpgradeVerbose(), req.GetSkipPgUpgradeChecks(), s.PgUpgradeJobs, s.Source, s.Intermediate, idl.PgOptions_check, s.Mode, pgUpgradeTimestamp, s.TablespaceMappings)
 	})
 
 	message := &idl.Message{Contents: &idl.Message_Response{Response: &idl.Response{Contents: &idl.Response_InitializeResponse{
 		InitializeResponse: &idl.InitializeResponse{
 			HasAllMirrorsAndStandby: s.Config.Source.HasAllMirrorsAndStandby(),
+			UnmigratableSettings:    s.UnmigratableSettings,
 		},
 	}}}}
 
@@ -154,5 +173,14 @@ func (s *Server) InitializeCreateCluster(req *idl.InitializeCreateClusterRequest
 		return err
 	}
 
-	return st.Err()
+	return notifications.Finish(st.Err())
+}
+
+func unmigratableSettings(gucs []greenplum.UnmigratableGUC) []string {
+	var settings []string
+	for _, guc := range gucs {
+		settings = append(settings, guc.String())
+	}
+
+	return settings
 }
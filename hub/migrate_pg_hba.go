@@ -0,0 +1,215 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// PgHbaMigration is the pg_hba.conf of an intermediate segment before and
+// after merging in the rules of the corresponding source segment.
+type PgHbaMigration struct {
+	Segment      greenplum.SegConfig
+	Current      string
+	Merged       string
+	Unmigratable []greenplum.UnmigratableHBARule
+}
+
+func (m PgHbaMigration) Changed() bool {
+	return m.Current != m.Merged
+}
+
+// MigratePgHba writes the client access rules of the source cluster into the
+// pg_hba.conf of each intermediate segment. Rules that cannot be carried over
+// are reported and returned rather than failing finalize.
+func MigratePgHba(streams step.OutStreams, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) ([]greenplum.UnmigratableHBARule, error) {
+	ctx := step.Context(streams)
+
+	migrations, err := PlanPgHbaMigration(ctx, agentConns, source, intermediate, func(seg *greenplum.SegConfig) bool {
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	confs := make(map[string][]*idl.PgHbaConf)
+	for _, migration := range migrations {
+		if !migration.Changed() {
+			continue
+		}
+
+		if migration.Segment.IsCoordinator() {
+			path := filepath.Join(migration.Segment.DataDir, "pg_hba.conf")
+			if err := utils.AtomicallyWrite(path, []byte(migration.Merged)); err != nil {
+				return nil, xerrors.Errorf("write %q: %w", path, err)
+			}
+
+			continue
+		}
+
+		host := migration.Segment.Hostname
+		confs[host] = append(confs[host], &idl.PgHbaConf{DataDir: migration.Segment.DataDir, Contents: migration.Merged})
+	}
+
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		if len(confs[conn.Hostname]) == 0 {
+			return nil
+		}
+
+		_, err := conn.AgentClient.WritePgHbaConfs(ctx, &idl.WritePgHbaConfsRequest{Confs: confs[conn.Hostname]})
+		if err != nil {
+			return xerrors.Errorf("write pg_hba.conf on host %s: %w", conn.Hostname, err)
+		}
+
+		return nil
+	}
+
+	if err := ExecuteRPC(ctx, agentConns, request); err != nil {
+		return nil, err
+	}
+
+	// Segments usually share the same rules so only report each rule once.
+	reported := make(map[greenplum.UnmigratableHBARule]bool)
+	var unmigratable []greenplum.UnmigratableHBARule
+	for _, migration := range migrations {
+		for _, rule := range migration.Unmigratable {
+			if reported[rule] {
+				continue
+			}
+			reported[rule] = true
+
+			unmigratable = append(unmigratable, rule)
+			log.Printf("Warning: not migrating pg_hba.conf rule %s", rule)
+			if _, err := fmt.Fprintf(streams.Stdout(), "Warning: not migrating pg_hba.conf rule %s\n", rule); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return unmigratable, nil
+}
+
+// PlanPgHbaMigration merges the pg_hba.conf of each selected intermediate
+// segment with the pg_hba.conf of the source segment having the same content
+// and role. The coordinator files are read locally and the rest by the agents.
+func PlanPgHbaMigration(ctx context.Context, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, selector func(seg *greenplum.SegConfig) bool) ([]PgHbaMigration, error) {
+	type pair struct {
+		source       greenplum.SegConfig
+		intermediate greenplum.SegConfig
+	}
+
+	var pairs []pair
+	dataDirs := make(map[string][]string)
+	for _, seg := range intermediate.SelectSegments(selector) {
+		sourceSegs := source.Mirrors
+		if seg.Role == greenplum.PrimaryRole {
+			sourceSegs = source.Primaries
+		}
+
+		sourceSeg, ok := sourceSegs[seg.ContentID]
+		if !ok {
+			continue
+		}
+
+		pairs = append(pairs, pair{source: sourceSeg, intermediate: seg})
+		if seg.IsCoordinator() {
+			continue
+		}
+
+		dataDirs[sourceSeg.Hostname] = append(dataDirs[sourceSeg.Hostname], sourceSeg.DataDir)
+		dataDirs[seg.Hostname] = append(dataDirs[seg.Hostname], seg.DataDir)
+	}
+
+	contents, err := getPgHbaConfs(ctx, agentConns, dataDirs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range pairs {
+		if !p.intermediate.IsCoordinator() {
+			continue
+		}
+
+		for _, seg := range []greenplum.SegConfig{p.source, p.intermediate} {
+			path := filepath.Join(seg.DataDir, "pg_hba.conf")
+			conf, err := utils.System.ReadFile(path)
+			if err != nil {
+				return nil, xerrors.Errorf("read %q: %w", path, err)
+			}
+
+			contents[hostDataDir{seg.Hostname, seg.DataDir}] = string(conf)
+		}
+	}
+
+	var migrations []PgHbaMigration
+	for _, p := range pairs {
+		current := contents[hostDataDir{p.intermediate.Hostname, p.intermediate.DataDir}]
+		sourceConf := contents[hostDataDir{p.source.Hostname, p.source.DataDir}]
+
+		merged, unmigratable := greenplum.MergeHBA(current, sourceConf, source.Version.Major, intermediate.Version.Major)
+		migrations = append(migrations, PgHbaMigration{
+			Segment:      p.intermediate,
+			Current:      current,
+			Merged:       merged,
+			Unmigratable: unmigratable,
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Segment.DbID < migrations[j].Segment.DbID
+	})
+
+	return migrations, nil
+}
+
+type hostDataDir struct {
+	host    string
+	dataDir string
+}
+
+func getPgHbaConfs(ctx context.Context, agentConns []*idl.Connection, dataDirs map[string][]string) (map[hostDataDir]string, error) {
+	var mutex sync.Mutex
+	contents := make(map[hostDataDir]string)
+
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		dirs := dataDirs[conn.Hostname]
+		if len(dirs) == 0 {
+			return nil
+		}
+		sort.Strings(dirs)
+
+		reply, err := conn.AgentClient.GetPgHbaConfs(ctx, &idl.GetPgHbaConfsRequest{DataDirs: dirs})
+		if err != nil {
+			return xerrors.Errorf("get pg_hba.conf on host %s: %w", conn.Hostname, err)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		for _, conf := range reply.GetConfs() {
+			contents[hostDataDir{conn.Hostname, conf.GetDataDir()}] = conf.GetContents()
+		}
+
+		return nil
+	}
+
+	err := ExecuteRPC(ctx, agentConns, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return contents, nil
+}
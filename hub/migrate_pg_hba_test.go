@@ -0,0 +1,176 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+const (
+	sourceHba       = "local all gpadmin ident sameuser\nhost all all 10.1.0.0/16 ident admins\nhost all all 10.2.0.0/16 crypt\n"
+	intermediateHba = "local all gpadmin ident\nhost all gpadmin 10.0.0.2/32 trust\n"
+	mergedHba       = intermediateHba + "\n" + greenplum.HBAMigrationMarker + "\n" +
+		"host all all 10.1.0.0/16 ident map=admins\n" +
+		"# not migrated: host all all 10.2.0.0/16 crypt (the crypt authentication method was removed in PostgreSQL 8.4)\n"
+)
+
+// mustCreatePgHbaClusters returns source and intermediate clusters with the
+// coordinator pg_hba.conf files written to temporary directories.
+func mustCreatePgHbaClusters(t *testing.T) (*greenplum.Cluster, *greenplum.Cluster) {
+	t.Helper()
+
+	sourceCoordinatorDir := t.TempDir()
+	testutils.MustWriteToFile(t, filepath.Join(sourceCoordinatorDir, "pg_hba.conf"), sourceHba)
+
+	intermediateCoordinatorDir := t.TempDir()
+	testutils.MustWriteToFile(t, filepath.Join(intermediateCoordinatorDir, "pg_hba.conf"), intermediateHba)
+
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{ContentID: -1, DbID: 1, Port: 15432, Hostname: "cdw", DataDir: sourceCoordinatorDir, Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Port: 25432, Hostname: "sdw1", DataDir: "/data/dbfast1/seg0", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 3, Port: 25433, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg0", Role: greenplum.MirrorRole},
+	})
+	source.Version = semver.MustParse("5.28.0")
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{ContentID: -1, DbID: 1, Port: 50432, Hostname: "cdw", DataDir: intermediateCoordinatorDir, Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Port: 50434, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.AAAAAAAAAAA.0", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 3, Port: 50435, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg.AAAAAAAAAAA.0", Role: greenplum.MirrorRole},
+	})
+	intermediate.Version = semver.MustParse("6.20.0")
+
+	return source, intermediate
+}
+
+// expectGetPgHbaConfs expects the data directories of a source segment and the
+// intermediate segment in sorted order.
+func expectGetPgHbaConfs(client *mock_idl.MockAgentClient, sourceDir string, intermediateDir string) {
+	dataDirs := []string{sourceDir, intermediateDir}
+	sort.Strings(dataDirs)
+
+	client.EXPECT().GetPgHbaConfs(
+		gomock.Any(),
+		&idl.GetPgHbaConfsRequest{DataDirs: dataDirs},
+	).Return(&idl.GetPgHbaConfsReply{Confs: []*idl.PgHbaConf{
+		{DataDir: sourceDir, Contents: sourceHba},
+		{DataDir: intermediateDir, Contents: intermediateHba},
+	}}, nil)
+}
+
+func TestMigratePgHba(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("writes the merged pg_hba.conf of every segment", func(t *testing.T) {
+		source, intermediate := mustCreatePgHbaClusters(t)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		expectGetPgHbaConfs(sdw1, "/data/dbfast1/seg0", "/data/dbfast1/seg.AAAAAAAAAAA.0")
+		sdw1.EXPECT().WritePgHbaConfs(gomock.Any(), &idl.WritePgHbaConfsRequest{Confs: []*idl.PgHbaConf{
+			{DataDir: "/data/dbfast1/seg.AAAAAAAAAAA.0", Contents: mergedHba},
+		}}).Return(&idl.WritePgHbaConfsReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		expectGetPgHbaConfs(sdw2, "/data/dbfast_mirror1/seg0", "/data/dbfast_mirror1/seg.AAAAAAAAAAA.0")
+		sdw2.EXPECT().WritePgHbaConfs(gomock.Any(), &idl.WritePgHbaConfsRequest{Confs: []*idl.PgHbaConf{
+			{DataDir: "/data/dbfast_mirror1/seg.AAAAAAAAAAA.0", Contents: mergedHba},
+		}}).Return(&idl.WritePgHbaConfsReply{}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		streams := &step.BufferedStreams{}
+		unmigratable, err := hub.MigratePgHba(streams, agentConns, source, intermediate)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		actual := testutils.MustReadFile(t, filepath.Join(intermediate.CoordinatorDataDir(), "pg_hba.conf"))
+		if actual != mergedHba {
+			t.Errorf("got coordinator pg_hba.conf\n%s\nwant\n%s", actual, mergedHba)
+		}
+
+		expected := []greenplum.UnmigratableHBARule{{
+			Rule:   "host all all 10.2.0.0/16 crypt",
+			Reason: "the crypt authentication method was removed in PostgreSQL 8.4",
+		}}
+		if !reflect.DeepEqual(unmigratable, expected) {
+			t.Errorf("got %v want %v", unmigratable, expected)
+		}
+
+		if strings.Count(streams.StdoutBuf.String(), "Warning: not migrating pg_hba.conf rule") != 1 {
+			t.Errorf("expected a single warning got %q", streams.StdoutBuf.String())
+		}
+	})
+
+	t.Run("errors when an agent fails to write", func(t *testing.T) {
+		source, intermediate := mustCreatePgHbaClusters(t)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		expectGetPgHbaConfs(sdw1, "/data/dbfast1/seg0", "/data/dbfast1/seg.AAAAAAAAAAA.0")
+		sdw1.EXPECT().WritePgHbaConfs(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		expectGetPgHbaConfs(sdw2, "/data/dbfast_mirror1/seg0", "/data/dbfast_mirror1/seg.AAAAAAAAAAA.0")
+		sdw2.EXPECT().WritePgHbaConfs(gomock.Any(), gomock.Any()).Return(&idl.WritePgHbaConfsReply{}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		_, err := hub.MigratePgHba(step.DevNullStream, agentConns, source, intermediate)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+
+	t.Run("errors when the source coordinator pg_hba.conf cannot be read", func(t *testing.T) {
+		source, intermediate := mustCreatePgHbaClusters(t)
+		testutils.MustRemoveAll(t, filepath.Join(source.CoordinatorDataDir(), "pg_hba.conf"))
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		expectGetPgHbaConfs(sdw1, "/data/dbfast1/seg0", "/data/dbfast1/seg.AAAAAAAAAAA.0")
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		expectGetPgHbaConfs(sdw2, "/data/dbfast_mirror1/seg0", "/data/dbfast_mirror1/seg.AAAAAAAAAAA.0")
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		_, err := hub.MigratePgHba(step.DevNullStream, agentConns, source, intermediate)
+		if err == nil || !strings.Contains(err.Error(), "pg_hba.conf") {
+			t.Errorf("got error %v", err)
+		}
+	})
+}
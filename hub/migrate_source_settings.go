@@ -0,0 +1,182 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// MigrateSourceSettings carries the settings of the source coordinator and
+// primary postgresql.conf files over to the running intermediate cluster.
+// Settings that cannot be carried over are reported and returned rather than
+// failing initialize.
+func MigrateSourceSettings(streams step.OutStreams, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) ([]greenplum.UnmigratableGUC, error) {
+	coordinator, err := greenplum.ReadPostgresqlConf(source.CoordinatorDataDir())
+	if err != nil {
+		return nil, xerrors.Errorf("read source master postgresql.conf: %w", err)
+	}
+
+	segments, err := GetPrimaryPostgresqlConfs(step.Context(streams), agentConns, source)
+	if err != nil {
+		return nil, err
+	}
+
+	gucs, unmigratable, err := greenplum.PlanGUCMigration(coordinator, segments, source.Version.Major, intermediate.Version.Major)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := currentSettings(intermediate)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, guc := range gucs {
+		currentValue, ok := current[guc.Name]
+		if !ok {
+			unmigratable = append(unmigratable, greenplum.UnmigratableGUC{Name: guc.SourceName, Value: guc.Value,
+				Reason: fmt.Sprintf("%s is not supported by Greenplum %d", guc.Name, intermediate.Version.Major)})
+			continue
+		}
+
+		if guc.Name == "shared_preload_libraries" {
+			missing := greenplum.MissingLibraries(intermediate.GPHome, guc.Value+","+guc.CoordinatorValue)
+			if len(missing) > 0 {
+				unmigratable = append(unmigratable, greenplum.UnmigratableGUC{Name: guc.SourceName, Value: guc.Value,
+					Reason: fmt.Sprintf("%s not installed in the target GPHOME %q", strings.Join(missing, ", "), intermediate.GPHome)})
+				continue
+			}
+		}
+
+		if guc.CoordinatorValue == "" && strings.EqualFold(currentValue, guc.Value) {
+			continue
+		}
+
+		stream := &step.BufferedStreams{}
+		err := intermediate.RunGreenplumCmdWithEnvironment(stream, "gpconfig", gpconfigArgs(guc, intermediate.Version.Major),
+			utils.FilterEnv([]string{"USER"})) // gpconfig requires the USER environment variable
+		if err != nil {
+			unmigratable = append(unmigratable, greenplum.UnmigratableGUC{Name: guc.SourceName, Value: guc.Value,
+				Reason: fmt.Sprintf("gpconfig failed: %s", strings.TrimSpace(stream.StderrBuf.String()+stream.StdoutBuf.String()))})
+			continue
+		}
+
+		log.Printf("migrated setting %s = '%s' to %s", guc.SourceName, guc.Value, guc.Name)
+	}
+
+	for _, guc := range unmigratable {
+		log.Printf("Warning: not migrating setting %s", guc)
+		if _, err := fmt.Fprintf(streams.Stdout(), "Warning: not migrating setting %s\n", guc); err != nil {
+			return nil, err
+		}
+	}
+
+	return unmigratable, nil
+}
+
+func gpconfigArgs(guc greenplum.GUC, targetMajor uint64) []string {
+	args := []string{"-c", guc.Name, "-v", quoteGUCValue(guc.Value)}
+
+	if guc.CoordinatorOnly {
+		coordinatorOnly := "--masteronly"
+		if targetMajor >= 7 {
+			coordinatorOnly = "--coordinatoronly"
+		}
+
+		args = append(args, coordinatorOnly)
+	}
+
+	if guc.CoordinatorValue != "" {
+		args = append(args, "-m", quoteGUCValue(guc.CoordinatorValue))
+	}
+
+	return args
+}
+
+var simpleGUCValue = regexp.MustCompile(`^[A-Za-z0-9._+-]*$`)
+
+// quoteGUCValue restores the quotes removed when parsing postgresql.conf for
+// values that are not a plain number or word.
+func quoteGUCValue(value string) string {
+	if value != "" && simpleGUCValue.MatchString(value) {
+		return value
+	}
+
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func currentSettings(cluster *greenplum.Cluster) (settings map[string]string, err error) {
+	db, err := sql.Open("pgx", cluster.Connection())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return greenplum.CurrentSettings(db)
+}
+
+// GetPrimaryPostgresqlConfs returns the postgresql.conf settings of each
+// source primary keyed by content.
+func GetPrimaryPostgresqlConfs(ctx context.Context, agentConns []*idl.Connection, source *greenplum.Cluster) (map[int]map[string]string, error) {
+	var mutex sync.Mutex
+	segments := make(map[int]map[string]string)
+
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		primaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return seg.IsOnHost(conn.Hostname) && seg.IsPrimary() && !seg.IsCoordinator()
+		})
+
+		if len(primaries) == 0 {
+			return nil
+		}
+
+		contents := make(map[string]int)
+		var dataDirs []string
+		for _, seg := range primaries {
+			contents[seg.DataDir] = seg.ContentID
+			dataDirs = append(dataDirs, seg.DataDir)
+		}
+		sort.Strings(dataDirs)
+
+		reply, err := conn.AgentClient.GetPostgresqlConfs(ctx, &idl.GetPostgresqlConfsRequest{DataDirs: dataDirs})
+		if err != nil {
+			return xerrors.Errorf("get postgresql.conf on host %s: %w", conn.Hostname, err)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		for _, conf := range reply.GetConfs() {
+			segments[contents[conf.GetDataDir()]] = conf.GetSettings()
+		}
+
+		return nil
+	}
+
+	err := ExecuteRPC(ctx, agentConns, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return segments, nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestGetPrimaryPostgresqlConfs(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{ContentID: -1, DbID: 1, Port: 15432, Hostname: "cdw", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 2, Port: 25432, Hostname: "sdw1", DataDir: "/data/dbfast1/seg0", Role: greenplum.PrimaryRole},
+		{ContentID: 1, DbID: 3, Port: 25433, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+		{ContentID: 0, DbID: 4, Port: 25434, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg0", Role: greenplum.MirrorRole},
+		{ContentID: 1, DbID: 5, Port: 25435, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg1", Role: greenplum.MirrorRole},
+	})
+
+	t.Run("returns the settings of each primary by content", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetPostgresqlConfs(
+			gomock.Any(),
+			&idl.GetPostgresqlConfsRequest{DataDirs: []string{"/data/dbfast1/seg0", "/data/dbfast1/seg1"}},
+		).Return(&idl.GetPostgresqlConfsReply{Confs: []*idl.GetPostgresqlConfsReply_PostgresqlConf{
+			{DataDir: "/data/dbfast1/seg0", Settings: map[string]string{"work_mem": "32MB"}},
+			{DataDir: "/data/dbfast1/seg1", Settings: map[string]string{"work_mem": "64MB"}},
+		}}, nil)
+
+		// sdw2 only has mirrors
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		segments, err := hub.GetPrimaryPostgresqlConfs(context.Background(), agentConns, source)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := map[int]map[string]string{
+			0: {"work_mem": "32MB"},
+			1: {"work_mem": "64MB"},
+		}
+		if !reflect.DeepEqual(segments, expected) {
+			t.Errorf("got %v want %v", segments, expected)
+		}
+	})
+
+	t.Run("errors when an agent fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetPostgresqlConfs(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*idl.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		_, err := hub.GetPrimaryPostgresqlConfs(context.Background(), agentConns, source)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
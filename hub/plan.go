@@ -0,0 +1,72 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+// Plan previews the changes finalize makes to the target cluster without
+// making them.
+func (s *Server) Plan(ctx context.Context, req *idl.PlanRequest) (*idl.PlanReply, error) {
+	if s.Intermediate == nil || s.Intermediate.CoordinatorDataDir() == "" {
+		return nil, status.Error(codes.FailedPrecondition, `the target cluster has not been created. Run "gpupgrade initialize" first.`)
+	}
+
+	agentConns, err := s.AgentConns()
+	if err != nil {
+		return nil, err
+	}
+
+	previews, err := PgHbaPreviews(ctx, agentConns, s.Source, s.Intermediate)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &idl.PlanReply{PgHbaPreviews: previews}
+	if s.Target.HasMirrors() || s.Target.HasStandby() {
+		reply.MirrorUpgradeMethod = ResolveMirrorUpgradeMethod(s.Mode, s.MirrorUpgradeMethod)
+	}
+
+	return reply, nil
+}
+
+// PgHbaPreviews returns the pg_hba.conf changes of the intermediate coordinator
+// and primaries. The mirrors and standby are left out as finalize creates them
+// before migrating their rules.
+func PgHbaPreviews(ctx context.Context, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) ([]*idl.PlanReply_PgHbaPreview, error) {
+	migrations, err := PlanPgHbaMigration(ctx, agentConns, source, intermediate, func(seg *greenplum.SegConfig) bool {
+		return seg.IsCoordinator() || seg.IsPrimary()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var previews []*idl.PlanReply_PgHbaPreview
+	for _, migration := range migrations {
+		if !migration.Changed() {
+			continue
+		}
+
+		var unmigratable []string
+		for _, rule := range migration.Unmigratable {
+			unmigratable = append(unmigratable, rule.String())
+		}
+
+		previews = append(previews, &idl.PlanReply_PgHbaPreview{
+			Hostname:     migration.Segment.Hostname,
+			DataDir:      migration.Segment.DataDir,
+			Diff:         greenplum.DiffLines(migration.Current, migration.Merged),
+			Unmigratable: unmigratable,
+		})
+	}
+
+	return previews, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestPgHbaPreviews(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("previews the changes to the coordinator and primaries", func(t *testing.T) {
+		source, intermediate := mustCreatePgHbaClusters(t)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		expectGetPgHbaConfs(sdw1, "/data/dbfast1/seg0", "/data/dbfast1/seg.AAAAAAAAAAA.0")
+
+		// the mirrors on sdw2 are not previewed
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		previews, err := hub.PgHbaPreviews(context.Background(), agentConns, source, intermediate)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(previews) != 2 {
+			t.Fatalf("got %d previews want 2", len(previews))
+		}
+
+		coordinator, primary := previews[0], previews[1]
+		if coordinator.GetHostname() != "cdw" || coordinator.GetDataDir() != intermediate.CoordinatorDataDir() {
+			t.Errorf("got coordinator preview for %s:%s", coordinator.GetHostname(), coordinator.GetDataDir())
+		}
+
+		if primary.GetHostname() != "sdw1" || primary.GetDataDir() != "/data/dbfast1/seg.AAAAAAAAAAA.0" {
+			t.Errorf("got primary preview for %s:%s", primary.GetHostname(), primary.GetDataDir())
+		}
+
+		expected := "+ host all all 10.1.0.0/16 ident map=admins"
+		found := false
+		for _, line := range primary.GetDiff() {
+			found = found || line == expected
+		}
+		if !found {
+			t.Errorf("expected diff %q to contain %q", primary.GetDiff(), expected)
+		}
+
+		if len(primary.GetUnmigratable()) != 1 {
+			t.Errorf("got unmigratable %q want a single rule", primary.GetUnmigratable())
+		}
+	})
+}
@@ -5,6 +5,7 @@ package hub
 
 import (
 	"context"
+	"path/filepath"
 
 	"golang.org/x/xerrors"
 
@@ -17,14 +18,14 @@ var RenameDirectories = upgrade.RenameDirectories
 
 type RenameMap = map[string][]*idl.RenameDirectories
 
-func RenameDataDirectories(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+func RenameDataDirectories(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, target *greenplum.Cluster) error {
 	src := source.CoordinatorDataDir()
 	dst := intermediate.CoordinatorDataDir()
 	if err := RenameDirectories(src, dst); err != nil {
 		return xerrors.Errorf("renaming master data directories: %w", err)
 	}
 
-	renameMap := getRenameMap(source, intermediate)
+	renameMap := getRenameMap(source, intermediate, target)
 	if err := RenameSegmentDataDirs(agentConns, renameMap); err != nil {
 		return xerrors.Errorf("renaming segment data directories: %w", err)
 	}
@@ -37,7 +38,13 @@ func RenameDataDirectories(agentConns []*idl.Connection, source *greenplum.Clust
 // the mirrors have been deleted to save disk space, so exclude them from the map.
 // Since the upgraded mirrors will be added later to the correct directory there
 // is no need to rename target to source, so only archive the source directory.
-func getRenameMap(source *greenplum.Cluster, intermediate *greenplum.Cluster) RenameMap {
+//
+// Mirrors re-laid out onto a different host or data directory cannot be
+// swapped in place. Instead the source mirror is archived on its host and the
+// intermediate mirror is moved to the target data directory on its new host.
+// Mirrors and a standby added during the upgrade have no source directory, so
+// their intermediate directory is only moved to the target data directory.
+func getRenameMap(source *greenplum.Cluster, intermediate *greenplum.Cluster, target *greenplum.Cluster) RenameMap {
 	m := make(RenameMap)
 
 	for _, seg := range source.Primaries {
@@ -51,10 +58,37 @@ func getRenameMap(source *greenplum.Cluster, intermediate *greenplum.Cluster) Re
 		})
 	}
 
-	for _, seg := range source.Mirrors {
+	for _, targetMirror := range target.Mirrors {
+		intermediateMirror := intermediate.Mirrors[targetMirror.ContentID]
+
+		seg, ok := source.Mirrors[targetMirror.ContentID]
+		if !ok {
+			m[targetMirror.Hostname] = append(m[targetMirror.Hostname], &idl.RenameDirectories{
+				Source:   intermediateMirror.DataDir,
+				Target:   targetMirror.DataDir,
+				MoveOnly: true,
+			})
+			continue
+		}
+
+		if seg.Hostname == targetMirror.Hostname && seg.DataDir == targetMirror.DataDir {
+			m[seg.Hostname] = append(m[seg.Hostname], &idl.RenameDirectories{
+				Source: seg.DataDir,
+				Target: intermediateMirror.DataDir,
+			})
+			continue
+		}
+
 		m[seg.Hostname] = append(m[seg.Hostname], &idl.RenameDirectories{
-			Source: seg.DataDir,
-			Target: intermediate.Mirrors[seg.ContentID].DataDir,
+			Source:   seg.DataDir,
+			Target:   filepath.Join(filepath.Dir(seg.DataDir), filepath.Base(intermediateMirror.DataDir)) + upgrade.OldSuffix,
+			MoveOnly: true,
+		})
+
+		m[targetMirror.Hostname] = append(m[targetMirror.Hostname], &idl.RenameDirectories{
+			Source:   intermediateMirror.DataDir,
+			Target:   targetMirror.DataDir,
+			MoveOnly: true,
 		})
 	}
 
@@ -64,15 +98,15 @@ func getRenameMap(source *greenplum.Cluster, intermediate *greenplum.Cluster) Re
 // e.g. for source /data/dbfast1/demoDataDir0 becomes /data/dbfast1/demoDataDir0_old
 // e.g. for target /data/dbfast1/demoDataDir0_123ABC becomes /data/dbfast1/demoDataDir0
 func RenameSegmentDataDirs(agentConns []*idl.Connection, renames RenameMap) error {
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		if len(renames[conn.Hostname]) == 0 {
 			return nil
 		}
 
 		req := &idl.RenameDirectoriesRequest{Dirs: renames[conn.Hostname]}
-		_, err := conn.AgentClient.RenameDirectories(context.Background(), req)
+		_, err := conn.AgentClient.RenameDirectories(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(context.Background(), agentConns, request)
 }
@@ -188,7 +188,7 @@ func TestUpdateDataDirectories(t *testing.T) {
 			}
 		}()
 
-		err := hub.RenameDataDirectories(nil, conf.Source, conf.Intermediate)
+		err := hub.RenameDataDirectories(nil, conf.Source, conf.Intermediate, conf.Source)
 		if err != nil {
 			t.Errorf("UpdateDataDirectories() returned error: %+v", err)
 		}
@@ -207,7 +207,7 @@ func TestUpdateDataDirectories(t *testing.T) {
 			}
 		}()
 
-		err := hub.RenameDataDirectories(nil, conf.Source, conf.Intermediate)
+		err := hub.RenameDataDirectories(nil, conf.Source, conf.Intermediate, conf.Source)
 		if !errors.Is(err, expected) {
 			t.Errorf("got %#v want %#v", err, expected)
 		}
@@ -264,7 +264,7 @@ func TestUpdateDataDirectories(t *testing.T) {
 			{AgentClient: standby, Hostname: "standby"},
 		}
 
-		err := hub.RenameDataDirectories(agentConns, conf.Source, conf.Intermediate)
+		err := hub.RenameDataDirectories(agentConns, conf.Source, conf.Intermediate, conf.Source)
 		if err != nil {
 			t.Errorf("RenameDataDirectories() returned error: %+v", err)
 		}
@@ -320,7 +320,161 @@ func TestUpdateDataDirectories(t *testing.T) {
 			{AgentClient: standby, Hostname: "standby"},
 		}
 
-		err := hub.RenameDataDirectories(agentConns, conf.Source, conf.Intermediate)
+		err := hub.RenameDataDirectories(agentConns, conf.Source, conf.Intermediate, conf.Source)
+		if err != nil {
+			t.Errorf("RenameDataDirectories() returned error: %+v", err)
+		}
+	})
+
+	t.Run("archives and moves mirrors that were re-laid out onto another host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		target := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{ContentID: -1, Hostname: "sdw1", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+			{ContentID: -1, Hostname: "standby", DataDir: "/data/standby", Role: greenplum.MirrorRole},
+
+			{ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+			{ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+			{ContentID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg3", Role: greenplum.PrimaryRole},
+			{ContentID: 3, Hostname: "sdw2", DataDir: "/data/dbfast2/seg4", Role: greenplum.PrimaryRole},
+
+			{ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror2/seg1", Role: greenplum.MirrorRole},
+			{ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast_mirror2/seg2", Role: greenplum.MirrorRole},
+			{ContentID: 2, Hostname: "sdw1", DataDir: "/data/dbfast_mirror1/seg3", Role: greenplum.MirrorRole},
+			{ContentID: 3, Hostname: "sdw2", DataDir: "/data/dbfast_mirror2/seg4", Role: greenplum.MirrorRole},
+		})
+
+		intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{ContentID: -1, Hostname: "sdw1", DataDir: "/data/qddir/seg-1_123ABC-1", Role: greenplum.PrimaryRole},
+			{ContentID: -1, Hostname: "standby", DataDir: "/data/standby_123ABC", Role: greenplum.MirrorRole},
+
+			{ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1_123ABC", Role: greenplum.PrimaryRole},
+			{ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2_123ABC", Role: greenplum.PrimaryRole},
+			{ContentID: 2, Hostname: "sdw1", DataDir: "/data/dbfast1/seg3_123ABC", Role: greenplum.PrimaryRole},
+			{ContentID: 3, Hostname: "sdw2", DataDir: "/data/dbfast2/seg4_123ABC", Role: greenplum.PrimaryRole},
+
+			{ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror2/seg1_123ABC", Role: greenplum.MirrorRole},
+			{ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast_mirror2/seg2_123ABC", Role: greenplum.MirrorRole},
+			{ContentID: 2, Hostname: "sdw1", DataDir: "/data/dbfast_mirror1/seg3_123ABC", Role: greenplum.MirrorRole},
+			{ContentID: 3, Hostname: "sdw2", DataDir: "/data/dbfast_mirror2/seg4_123ABC", Role: greenplum.MirrorRole},
+		})
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		expectRenames(sdw1, []*idl.RenameDirectories{{
+			Source: "/data/dbfast1/seg1",
+			Target: "/data/dbfast1/seg1_123ABC",
+		}, {
+			Source: "/data/dbfast1/seg3",
+			Target: "/data/dbfast1/seg3_123ABC",
+		}, {
+			Source:   "/data/dbfast_mirror1/seg1",
+			Target:   "/data/dbfast_mirror1/seg1_123ABC.old",
+			MoveOnly: true,
+		}, {
+			Source: "/data/dbfast_mirror1/seg3",
+			Target: "/data/dbfast_mirror1/seg3_123ABC",
+		}})
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		expectRenames(sdw2, []*idl.RenameDirectories{{
+			Source: "/data/dbfast2/seg2",
+			Target: "/data/dbfast2/seg2_123ABC",
+		}, {
+			Source: "/data/dbfast2/seg4",
+			Target: "/data/dbfast2/seg4_123ABC",
+		}, {
+			Source:   "/data/dbfast_mirror2/seg1_123ABC",
+			Target:   "/data/dbfast_mirror2/seg1",
+			MoveOnly: true,
+		}, {
+			Source: "/data/dbfast_mirror2/seg2",
+			Target: "/data/dbfast_mirror2/seg2_123ABC",
+		}, {
+			Source: "/data/dbfast_mirror2/seg4",
+			Target: "/data/dbfast_mirror2/seg4_123ABC",
+		}})
+
+		standby := mock_idl.NewMockAgentClient(ctrl)
+		expectRenames(standby, []*idl.RenameDirectories{{
+			Source: "/data/standby",
+			Target: "/data/standby_123ABC",
+		}})
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: standby, Hostname: "standby"},
+		}
+
+		err := hub.RenameDataDirectories(agentConns, conf.Source, intermediate, target)
+		if err != nil {
+			t.Errorf("RenameDataDirectories() returned error: %+v", err)
+		}
+	})
+
+	t.Run("moves mirrors and a standby added to a source cluster without them", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{ContentID: -1, Hostname: "sdw1", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+			{ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+			{ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+		})
+
+		target := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{ContentID: -1, Hostname: "sdw1", DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+			{ContentID: -1, Hostname: "standby", DataDir: "/data/standby", Role: greenplum.MirrorRole},
+			{ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Role: greenplum.PrimaryRole},
+			{ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2", Role: greenplum.PrimaryRole},
+			{ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror2/seg1", Role: greenplum.MirrorRole},
+			{ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror1/seg2", Role: greenplum.MirrorRole},
+		})
+
+		intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{ContentID: -1, Hostname: "sdw1", DataDir: "/data/qddir/seg-1_123ABC-1", Role: greenplum.PrimaryRole},
+			{ContentID: -1, Hostname: "standby", DataDir: "/data/standby_123ABC", Role: greenplum.MirrorRole},
+			{ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1_123ABC", Role: greenplum.PrimaryRole},
+			{ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg2_123ABC", Role: greenplum.PrimaryRole},
+			{ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror2/seg1_123ABC", Role: greenplum.MirrorRole},
+			{ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror1/seg2_123ABC", Role: greenplum.MirrorRole},
+		})
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		expectRenames(sdw1, []*idl.RenameDirectories{{
+			Source: "/data/dbfast1/seg1",
+			Target: "/data/dbfast1/seg1_123ABC",
+		}, {
+			Source:   "/data/dbfast_mirror1/seg2_123ABC",
+			Target:   "/data/dbfast_mirror1/seg2",
+			MoveOnly: true,
+		}})
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		expectRenames(sdw2, []*idl.RenameDirectories{{
+			Source: "/data/dbfast2/seg2",
+			Target: "/data/dbfast2/seg2_123ABC",
+		}, {
+			Source:   "/data/dbfast_mirror2/seg1_123ABC",
+			Target:   "/data/dbfast_mirror2/seg1",
+			MoveOnly: true,
+		}})
+
+		standby := mock_idl.NewMockAgentClient(ctrl)
+		expectRenames(standby, []*idl.RenameDirectories{{
+			Source:   "/data/standby_123ABC",
+			Target:   "/data/standby",
+			MoveOnly: true,
+		}})
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: standby, Hostname: "standby"},
+		}
+
+		err := hub.RenameDataDirectories(agentConns, source, intermediate, target)
 		if err != nil {
 			t.Errorf("RenameDataDirectories() returned error: %+v", err)
 		}
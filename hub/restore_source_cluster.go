@@ -26,7 +26,7 @@ func RsyncCoordinatorAndPrimaries(stream step.OutStreams, agentConns []*idl.Conn
 		errs <- RsyncCoordinator(stream, source.Standby(), source.Coordinator())
 	}()
 
-	errs <- RsyncPrimaries(agentConns, source)
+	errs <- RsyncPrimaries(step.Context(stream), agentConns, source)
 
 	wg.Wait()
 	close(errs)
@@ -49,7 +49,7 @@ func RsyncCoordinatorAndPrimariesTablespaces(stream step.OutStreams, agentConns
 		errs <- RsyncCoordinatorTablespaces(stream, source.StandbyHostname(), source.Tablespaces[int32(source.Coordinator().DbID)], source.Tablespaces[int32(source.Standby().DbID)])
 	}()
 
-	errs <- RsyncPrimariesTablespaces(agentConns, source, source.Tablespaces)
+	errs <- RsyncPrimariesTablespaces(step.Context(stream), agentConns, source, source.Tablespaces)
 
 	wg.Wait()
 	close(errs)
@@ -107,8 +107,8 @@ func RsyncCoordinatorTablespaces(stream step.OutStreams, standbyHostname string,
 	return nil
 }
 
-func RsyncPrimaries(agentConns []*idl.Connection, source *greenplum.Cluster) error {
-	request := func(conn *idl.Connection) error {
+func RsyncPrimaries(ctx context.Context, agentConns []*idl.Connection, source *greenplum.Cluster) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		mirrors := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
 		})
@@ -130,15 +130,15 @@ func RsyncPrimaries(agentConns []*idl.Connection, source *greenplum.Cluster) err
 		}
 
 		req := &idl.RsyncRequest{Options: opts}
-		_, err := conn.AgentClient.RsyncDataDirectories(context.Background(), req)
+		_, err := conn.AgentClient.RsyncDataDirectories(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
 
-func RsyncPrimariesTablespaces(agentConns []*idl.Connection, source *greenplum.Cluster, tablespaces greenplum.Tablespaces) error {
-	request := func(conn *idl.Connection) error {
+func RsyncPrimariesTablespaces(ctx context.Context, agentConns []*idl.Connection, source *greenplum.Cluster, tablespaces greenplum.Tablespaces) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		mirrors := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
 		})
@@ -170,11 +170,11 @@ func RsyncPrimariesTablespaces(agentConns []*idl.Connection, source *greenplum.C
 		}
 
 		req := &idl.RsyncRequest{Options: opts}
-		_, err := conn.AgentClient.RsyncTablespaceDirectories(context.Background(), req)
+		_, err := conn.AgentClient.RsyncTablespaceDirectories(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
 
 func RestoreCoordinatorAndPrimariesPgControl(streams step.OutStreams, agentConns []*idl.Connection, source *greenplum.Cluster) error {
@@ -187,7 +187,7 @@ func RestoreCoordinatorAndPrimariesPgControl(streams step.OutStreams, agentConns
 		errs <- upgrade.RestorePgControl(source.CoordinatorDataDir(), streams)
 	}()
 
-	errs <- restorePrimariesPgControl(agentConns, source)
+	errs <- restorePrimariesPgControl(step.Context(streams), agentConns, source)
 
 	wg.Wait()
 	close(errs)
@@ -200,8 +200,8 @@ func RestoreCoordinatorAndPrimariesPgControl(streams step.OutStreams, agentConns
 	return err
 }
 
-func restorePrimariesPgControl(agentConns []*idl.Connection, source *greenplum.Cluster) error {
-	request := func(conn *idl.Connection) error {
+func restorePrimariesPgControl(ctx context.Context, agentConns []*idl.Connection, source *greenplum.Cluster) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		primaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsPrimary()
 		})
@@ -219,9 +219,9 @@ func restorePrimariesPgControl(agentConns []*idl.Connection, source *greenplum.C
 			Datadirs: dataDirs,
 		}
 
-		_, err := conn.AgentClient.RestorePrimariesPgControl(context.Background(), req)
+		_, err := conn.AgentClient.RestorePrimariesPgControl(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
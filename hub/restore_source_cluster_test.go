@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -153,7 +154,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 			{AgentClient: standby, Hostname: "standby"},
 		}
 
-		err := hub.RsyncPrimaries(agentConns, cluster)
+		err := hub.RsyncPrimaries(context.Background(), agentConns, cluster)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -199,7 +200,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 			{AgentClient: standby, Hostname: "standby"},
 		}
 
-		err := hub.RsyncPrimariesTablespaces(agentConns, cluster, tablespaces)
+		err := hub.RsyncPrimariesTablespaces(context.Background(), agentConns, cluster, tablespaces)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -255,7 +256,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 			{AgentClient: failedClient, Hostname: "msdw2"},
 		}
 
-		err := hub.RsyncPrimaries(agentConns, cluster)
+		err := hub.RsyncPrimaries(context.Background(), agentConns, cluster)
 
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -284,7 +285,7 @@ func TestRsyncCoordinatorAndPrimaries(t *testing.T) {
 			{AgentClient: failedClient, Hostname: "msdw2"},
 		}
 
-		err := hub.RsyncPrimariesTablespaces(agentConns, cluster, tablespaces)
+		err := hub.RsyncPrimariesTablespaces(context.Background(), agentConns, cluster, tablespaces)
 
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
@@ -4,7 +4,6 @@
 package hub
 
 import (
-	"context"
 	"os/exec"
 	"time"
 
@@ -17,7 +16,11 @@ import (
 )
 
 func (s *Server) Revert(_ *idl.RevertRequest, stream idl.CliToHub_RevertServer) (err error) {
-	st, err := step.Begin(idl.Step_revert, stream)
+	notifications := s.notifier.Step(idl.Step_revert, s.events.Sender(idl.Step_revert, stream))
+	ctx, done := s.stepContext()
+	defer done()
+
+	st, err := step.Begin(ctx, idl.Step_revert, notifications, s.SubstepTimeouts)
 	if err != nil {
 		return err
 	}
@@ -44,8 +47,8 @@ Cannot revert and restore the source cluster. Please contact support.`)
 		return err
 	}
 
-	st.RunConditionally(idl.Substep_ensure_gpupgrade_agents_are_running, configCreated && agentsStarted, func(_ step.OutStreams) error {
-		_, err := RestartAgents(context.Background(), nil, AgentHosts(s.Source), s.AgentPort, utils.GetStateDir())
+	st.RunConditionally(idl.Substep_ensure_gpupgrade_agents_are_running, configCreated && agentsStarted, func(streams step.OutStreams) error {
+		_, err := s.restartAgents(step.Context(streams))
 		if err != nil {
 			return err
 		}
@@ -71,7 +74,7 @@ Cannot revert and restore the source cluster. Please contact support.`)
 	})
 
 	st.RunConditionally(idl.Substep_delete_tablespaces, configCreated, func(streams step.OutStreams) error {
-		return DeleteTargetTablespaces(streams, s.agentConns, s.Config.Intermediate, s.Intermediate.CatalogVersion, s.Source.Tablespaces)
+		return DeleteTargetTablespaces(streams, s.agentConns, s.Config.Intermediate, s.Intermediate.CatalogVersion, s.Source.Tablespaces, s.TablespaceMappings)
 	})
 
 	// See "Reverting to old cluster" from https://www.postgresql.org/docs/9.4/pgupgrade.html
@@ -156,5 +159,5 @@ Cannot revert and restore the source cluster. Please contact support.`)
 		return xerrors.Errorf("sending response message: %w", err)
 	}
 
-	return st.Err()
+	return notifications.Finish(st.Err())
 }
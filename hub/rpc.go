@@ -4,13 +4,16 @@
 package hub
 
 import (
+	"context"
 	"sync"
 
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-func ExecuteRPC(agentConns []*idl.Connection, executeRequest func(conn *idl.Connection) error) error {
+// ExecuteRPC runs the request against each agent in parallel. The context is
+// passed to each request such that canceling the step cancels the requests.
+func ExecuteRPC(ctx context.Context, agentConns []*idl.Connection, executeRequest func(ctx context.Context, conn *idl.Connection) error) error {
 	var wg sync.WaitGroup
 	errs := make(chan error, len(agentConns))
 
@@ -21,7 +24,7 @@ func ExecuteRPC(agentConns []*idl.Connection, executeRequest func(conn *idl.Conn
 		go func() {
 			defer wg.Done()
 
-			err := executeRequest(conn)
+			err := executeRequest(ctx, conn)
 			errs <- err
 		}()
 	}
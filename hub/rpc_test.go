@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"sort"
@@ -21,12 +22,12 @@ func TestExecuteRPC(t *testing.T) {
 		}
 
 		hosts := make(chan string, len(agentConns))
-		request := func(conn *idl.Connection) error {
+		request := func(ctx context.Context, conn *idl.Connection) error {
 			hosts <- conn.Hostname
 			return nil
 		}
 
-		err := hub.ExecuteRPC(agentConns, request)
+		err := hub.ExecuteRPC(context.Background(), agentConns, request)
 		if err != nil {
 			t.Errorf("ExecuteRPC returned error %+v", err)
 		}
@@ -52,7 +53,7 @@ func TestExecuteRPC(t *testing.T) {
 		}
 
 		expected := errors.New("permission denied")
-		request := func(conn *idl.Connection) error {
+		request := func(ctx context.Context, conn *idl.Connection) error {
 			if conn.Hostname == "mdw" {
 				return expected
 			}
@@ -60,10 +61,24 @@ func TestExecuteRPC(t *testing.T) {
 			return nil
 		}
 
-		err := hub.ExecuteRPC(agentConns, request)
+		err := hub.ExecuteRPC(context.Background(), agentConns, request)
 
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
 	})
+
+	t.Run("passes the context to each request", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		request := func(ctx context.Context, conn *idl.Connection) error {
+			return ctx.Err()
+		}
+
+		err := hub.ExecuteRPC(ctx, []*idl.Connection{{Hostname: "sdw"}}, request)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got error %#v, want %#v", err, context.Canceled)
+		}
+	})
 }
@@ -4,16 +4,19 @@
 package hub
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/kballard/go-shellquote"
 	"github.com/pkg/errors"
 	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
@@ -22,8 +25,10 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/fault"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/notify"
 	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/daemon"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
@@ -32,16 +37,38 @@ import (
 
 var DialTimeout = 3 * time.Second
 
+// NotifierCloseTimeout bounds how long stopping the hub waits to deliver the
+// last step's notifications. Undelivered notifications are recorded to the
+// delivery log.
+const NotifierCloseTimeout = 30 * time.Second
+
 // Returned from Server.Start() if Server.Stop() has already been called.
 var ErrHubStopped = errors.New("hub is stopped")
 
 type Server struct {
 	*config.Config
 
+	// Version and Commit identify the running hub binary. Agents must match
+	// them to be used.
+	Version string
+	Commit  string
+
 	agentConns []*idl.Connection
-	mutex      sync.Mutex
-	gRPCserver *grpc.Server
-	listener   net.Listener
+	// agentsVerified is set once the agents of agentConns have handshaken,
+	// and reset when agents are restarted.
+	agentsVerified bool
+	events         *Events
+	notifier       *notify.Notifier
+	mutex          sync.Mutex
+	gRPCserver     *grpc.Server
+	listener       net.Listener
+
+	// cancelStep cancels the running step. It is guarded by stepMutex rather
+	// than mutex so that Cancel is never blocked by dialing the agents.
+	stepMutex  sync.Mutex
+	cancelStep context.CancelCauseFunc
+	// stepID identifies the step of cancelStep.
+	stepID uint64
 
 	// This is used both as a channel to communicate from Start() to
 	// Stop() to indicate to Stop() that it can finally terminate
@@ -55,11 +82,25 @@ type Server struct {
 
 func New(conf *config.Config) *Server {
 	return &Server{
-		Config:  conf,
-		stopped: make(chan struct{}, 1),
+		Config:   conf,
+		events:   NewEvents(),
+		notifier: notify.New(conf.Notifications, conf.UpgradeID, notificationLogPath()),
+		stopped:  make(chan struct{}, 1),
 	}
 }
 
+// notificationLogPath records each notification delivery attempt alongside the
+// hub log.
+func notificationLogPath() string {
+	logDir, err := utils.GetLogDir()
+	if err != nil {
+		log.Printf("get log directory for the notification log: %v", err)
+		return ""
+	}
+
+	return filepath.Join(logDir, "notifications.log")
+}
+
 func (s *Server) Start(port int, daemonize bool) error {
 	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
 	if err != nil {
@@ -112,8 +153,8 @@ func (s *Server) StopServices(ctx context.Context, in *idl.StopServicesRequest)
 
 // TODO: Add unit tests which is currently tricky due to h.AgentConns() mutating global state
 func (s *Server) StopAgents() error {
-	request := func(conn *idl.Connection) error {
-		_, err := conn.AgentClient.StopAgent(context.Background(), &idl.StopAgentRequest{})
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		_, err := conn.AgentClient.StopAgent(ctx, &idl.StopAgentRequest{})
 		if err == nil { // no error means the agent did not terminate as expected
 			return xerrors.Errorf("failed to stop agent on host: %s", conn.Hostname)
 		}
@@ -131,11 +172,15 @@ func (s *Server) StopAgents() error {
 
 	// FIXME: s.AgentConns() fails fast if a single agent isn't available
 	//    we need to connect to all available agents so we can stop just those
-	_, err := s.AgentConns()
+	// Skip the handshake so mismatched agents can still be stopped.
+	s.mutex.Lock()
+	agentConns, err := s.dialAgentConns()
+	s.mutex.Unlock()
 	if err != nil {
 		return err
 	}
-	return ExecuteRPC(s.agentConns, request)
+
+	return ExecuteRPC(context.Background(), agentConns, request)
 }
 
 func (s *Server) Stop(closeAgentConns bool) {
@@ -155,10 +200,13 @@ func (s *Server) Stop(closeAgentConns bool) {
 	// Mark this server stopped so that a concurrent Start() doesn't try to
 	// start things up again.
 	s.stopped = nil
+
+	// Deliver the notifications of the last step before exiting.
+	s.notifier.Close(NotifierCloseTimeout)
 }
 
 func (s *Server) RestartAgents(ctx context.Context, in *idl.RestartAgentsRequest) (*idl.RestartAgentsReply, error) {
-	restartedHosts, err := RestartAgents(ctx, nil, AgentHosts(s.Source), s.AgentPort, utils.GetStateDir())
+	restartedHosts, err := s.restartAgents(ctx)
 	if err != nil {
 		return &idl.RestartAgentsReply{}, err
 	}
@@ -171,8 +219,23 @@ func (s *Server) RestartAgents(ctx context.Context, in *idl.RestartAgentsRequest
 	return &idl.RestartAgentsReply{AgentHosts: restartedHosts}, err
 }
 
+// restartAgents starts the agents that are not running. Since the started
+// agents may be from a different installation than the ones previously
+// connected to, the next AgentConns handshakes with them again.
+func (s *Server) restartAgents(ctx context.Context) ([]string, error) {
+	restartedHosts, err := RestartAgents(ctx, s.AddressFamily.Dialer(), s.AddressFamily, AgentHosts(s.Source, s.Target), s.AgentPort, utils.GetStateDir())
+	if len(restartedHosts) > 0 {
+		s.mutex.Lock()
+		s.agentsVerified = false
+		s.mutex.Unlock()
+	}
+
+	return restartedHosts, err
+}
+
 func RestartAgents(ctx context.Context,
 	dialer func(context.Context, string) (net.Conn, error),
+	addressFamily utils.AddressFamily,
 	hostnames []string,
 	port int,
 	stateDir string) ([]string, error) {
@@ -186,7 +249,7 @@ func RestartAgents(ctx context.Context,
 		go func(host string) {
 			defer wg.Done()
 
-			address := host + ":" + strconv.Itoa(port)
+			address := net.JoinHostPort(host, strconv.Itoa(port))
 			timeoutCtx, cancelFunc := context.WithTimeout(ctx, 3*time.Second)
 			opts := []grpc.DialOption{
 				grpc.WithBlock(),
@@ -214,15 +277,25 @@ func RestartAgents(ctx context.Context,
 				errs <- err
 				return
 			}
-			cmd := ExecCommand("ssh", host,
-				fmt.Sprintf("bash -c \"%s agent --daemonize --port %d --state-directory %s\"", path, port, stateDir))
-			stdout, err := cmd.Output()
+			// Agents started over ssh do not inherit the environment of the
+			// hub so pass along whether faults are injected.
+			env := ""
+			if value := os.Getenv(fault.EnvVar); value != "" {
+				env = fmt.Sprintf("%s=%s ", fault.EnvVar, shellquote.Join(value))
+			}
+
+			args := append(addressFamily.SSHOptions(), host,
+				fmt.Sprintf("bash -c \"%s%s agent --daemonize --port %d --state-directory %s\"", env, path, port, stateDir))
+			cmd := ExecCommand("ssh", args...)
+			var stdout bytes.Buffer
+			cmd.Stdout = &stdout
+			err = utils.RunContext(ctx, cmd)
 			if err != nil {
 				errs <- err
 				return
 			}
 
-			log.Print(string(stdout))
+			log.Print(stdout.String())
 			restartedHosts <- host
 		}(host)
 	}
@@ -263,6 +336,38 @@ func (s *Server) AgentConns() ([]*idl.Connection, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	agentConns, err := s.dialAgentConns()
+	if err != nil {
+		return nil, err
+	}
+
+	// Handshake only once per set of agents rather than on every call.
+	// StopAgents dials without handshaking so mismatched agents can still be
+	// stopped.
+	if !s.agentsVerified {
+		err = Handshake(agentConns, s.handshakeReply())
+		if err != nil {
+			return nil, err
+		}
+
+		s.agentsVerified = true
+	}
+
+	return agentConns, nil
+}
+
+func (s *Server) handshakeReply() *idl.HandshakeReply {
+	return &idl.HandshakeReply{
+		Version:     s.Version,
+		Commit:      s.Commit,
+		IdlRevision: idl.Revision(),
+		StateDir:    utils.GetStateDir(),
+	}
+}
+
+// dialAgentConns returns the saved agent connections or dials new ones.
+// Callers must hold the Server's mutex.
+func (s *Server) dialAgentConns() ([]*idl.Connection, error) {
 	if s.agentConns != nil {
 		err := EnsureConnsAreReady(s.agentConns, 15*time.Second)
 		if err != nil {
@@ -272,12 +377,14 @@ func (s *Server) AgentConns() ([]*idl.Connection, error) {
 		return s.agentConns, nil
 	}
 
-	hostnames := AgentHosts(s.Source)
+	hostnames := AgentHosts(s.Source, s.Target)
 	for _, host := range hostnames {
 		ctx, cancelFunc := context.WithTimeout(context.Background(), DialTimeout)
 		conn, err := gRPCDialer(ctx,
-			host+":"+strconv.Itoa(s.AgentPort),
-			grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+			net.JoinHostPort(host, strconv.Itoa(s.AgentPort)),
+			grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock(),
+			grpc.WithContextDialer(s.AddressFamily.Dialer()),
+			grpc.WithChainUnaryInterceptor(s.events.UnaryClientInterceptor(host), AuditUnaryClientInterceptor(host), fault.UnaryClientInterceptor(host)))
 		if err != nil {
 			cancelFunc()
 			return nil, xerrors.Errorf("agent connections: %w", err)
@@ -362,15 +469,24 @@ func (s *Server) closeAgentConns() {
 	}
 }
 
-func AgentHosts(c *greenplum.Cluster) []string {
+// AgentHosts returns the unique non-coordinator hosts of the given clusters.
+// The target cluster is included since it may have mirrors or a standby on
+// hosts the source cluster does not use. Nil clusters are skipped.
+func AgentHosts(clusters ...*greenplum.Cluster) []string {
 	uniqueHosts := make(map[string]bool)
 
 	excludingCoordinator := func(seg *greenplum.SegConfig) bool {
 		return !seg.IsCoordinator()
 	}
 
-	for _, seg := range c.SelectSegments(excludingCoordinator) {
-		uniqueHosts[seg.Hostname] = true
+	for _, c := range clusters {
+		if c == nil {
+			continue
+		}
+
+		for _, seg := range c.SelectSegments(excludingCoordinator) {
+			uniqueHosts[seg.Hostname] = true
+		}
 	}
 
 	hosts := make([]string, 0)
@@ -23,6 +23,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/hub"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/testutils/mock_agent"
 	"github.com/greenplum-db/gpupgrade/testutils/testlog"
 	"github.com/greenplum-db/gpupgrade/utils"
@@ -254,6 +255,58 @@ func TestAgentConns(t *testing.T) {
 		}
 	})
 
+	t.Run("handshakes with the agents only when dialing", func(t *testing.T) {
+		hubServer := hub.New(conf)
+
+		_, err := hubServer.AgentConns()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		calls := agentServer.NumberOfCalls()
+
+		_, err = hubServer.AgentConns()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if agentServer.NumberOfCalls() != calls {
+			t.Errorf("got %d agent calls want %d", agentServer.NumberOfCalls(), calls)
+		}
+	})
+
+	t.Run("handshakes again with restarted agents", func(t *testing.T) {
+		hub.SetExecCommand(exectest.NewCommand(gpupgrade_agent))
+		defer hub.ResetExecCommand()
+
+		hubServer := hub.New(conf)
+
+		_, err := hubServer.AgentConns()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		// The agents are restarted from another installation.
+		agentServer.SetVersion("1.0.0")
+		defer agentServer.SetVersion("")
+
+		_, err = hubServer.AgentConns()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		_, err = hubServer.RestartAgents(context.Background(), &idl.RestartAgentsRequest{})
+		var nextActionErr utils.NextActionErr
+		if !errors.As(err, &nextActionErr) {
+			t.Fatalf("got type %T want %T", err, nextActionErr)
+		}
+
+		var mismatchErr *hub.AgentVersionMismatchError
+		if !errors.As(nextActionErr.Err, &mismatchErr) {
+			t.Errorf("got type %T want %T", nextActionErr.Err, mismatchErr)
+		}
+	})
+
 	t.Run("returns an error if any connections have non-ready states when first dialing", func(t *testing.T) {
 		expected := errors.New("ahh!")
 		hub.SetgRPCDialer(func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
@@ -416,39 +469,55 @@ func doesStateEventuallyReach(conn *grpc.ClientConn, state connectivity.State) (
 func TestAgentHosts(t *testing.T) {
 	cases := []struct {
 		name     string
-		cluster  *greenplum.Cluster
+		clusters []*greenplum.Cluster
 		expected []string // must be in alphabetical order
 	}{{
 		"coordinator excluded",
-		hub.MustCreateCluster(t, greenplum.SegConfigs{
+		[]*greenplum.Cluster{hub.MustCreateCluster(t, greenplum.SegConfigs{
 			{ContentID: -1, Hostname: "mdw", Role: greenplum.PrimaryRole},
 			{ContentID: 0, Hostname: "sdw1", Role: greenplum.PrimaryRole},
 			{ContentID: 1, Hostname: "sdw1", Role: greenplum.PrimaryRole},
-		}),
+		})},
 		[]string{"sdw1"},
 	}, {
 		"coordinator included if another segment is with it",
-		hub.MustCreateCluster(t, greenplum.SegConfigs{
+		[]*greenplum.Cluster{hub.MustCreateCluster(t, greenplum.SegConfigs{
 			{ContentID: -1, Hostname: "mdw", Role: greenplum.PrimaryRole},
 			{ContentID: 0, Hostname: "mdw", Role: greenplum.PrimaryRole},
-		}),
+		})},
 		[]string{"mdw"},
 	}, {
 		"mirror and standby hosts are handled",
-		hub.MustCreateCluster(t, greenplum.SegConfigs{
+		[]*greenplum.Cluster{hub.MustCreateCluster(t, greenplum.SegConfigs{
 			{ContentID: -1, Hostname: "mdw", Role: greenplum.PrimaryRole},
 			{ContentID: -1, Hostname: "smdw", Role: greenplum.MirrorRole},
 			{ContentID: 0, Hostname: "sdw1", Role: greenplum.PrimaryRole},
 			{ContentID: 0, Hostname: "sdw1", Role: greenplum.MirrorRole},
 			{ContentID: 1, Hostname: "sdw1", Role: greenplum.PrimaryRole},
 			{ContentID: 1, Hostname: "sdw2", Role: greenplum.MirrorRole},
-		}),
+		})},
+		[]string{"sdw1", "sdw2", "smdw"},
+	}, {
+		"hosts across clusters are combined and nil clusters are skipped",
+		[]*greenplum.Cluster{
+			hub.MustCreateCluster(t, greenplum.SegConfigs{
+				{ContentID: -1, Hostname: "mdw", Role: greenplum.PrimaryRole},
+				{ContentID: 0, Hostname: "sdw1", Role: greenplum.PrimaryRole},
+			}),
+			nil,
+			hub.MustCreateCluster(t, greenplum.SegConfigs{
+				{ContentID: -1, Hostname: "mdw", Role: greenplum.PrimaryRole},
+				{ContentID: -1, Hostname: "smdw", Role: greenplum.MirrorRole},
+				{ContentID: 0, Hostname: "sdw1", Role: greenplum.PrimaryRole},
+				{ContentID: 0, Hostname: "sdw2", Role: greenplum.MirrorRole},
+			}),
+		},
 		[]string{"sdw1", "sdw2", "smdw"},
 	}}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			actual := hub.AgentHosts(c.cluster)
+			actual := hub.AgentHosts(c.clusters...)
 			sort.Strings(actual) // order not guaranteed
 
 			if !reflect.DeepEqual(actual, c.expected) {
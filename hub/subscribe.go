@@ -0,0 +1,238 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+// MaxReplayedOutput bounds the bytes of recent step output replayed to new
+// subscribers.
+const MaxReplayedOutput = 64 * 1024
+
+// subscriberBuffer is the number of events a subscriber may fall behind before
+// it is dropped so that a slow subscriber never blocks the step.
+const subscriberBuffer = 1024
+
+// Events records the progress of the current step and publishes it to
+// subscribers such as "gpupgrade watch" which may attach and detach at any
+// time.
+type Events struct {
+	mutex       sync.Mutex
+	step        idl.Step
+	statuses    []*idl.SubstepStatus
+	output      []*idl.Chunk
+	outputBytes int
+	agents      map[string]*idl.AgentActivity
+	subscribers map[chan *idl.SubscribeReply]struct{}
+}
+
+func NewEvents() *Events {
+	return &Events{
+		agents:      make(map[string]*idl.AgentActivity),
+		subscribers: make(map[chan *idl.SubscribeReply]struct{}),
+	}
+}
+
+// Sender starts recording a new step and returns a sender that publishes the
+// step's messages in addition to sending them to the CLI.
+func (e *Events) Sender(step idl.Step, sender idl.MessageSender) idl.MessageSender {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.step = step
+	e.statuses = nil
+	e.output = nil
+	e.outputBytes = 0
+	e.publish(&idl.SubscribeReply{Contents: &idl.SubscribeReply_Step{Step: step}})
+
+	return &eventSender{events: e, sender: sender}
+}
+
+type eventSender struct {
+	events *Events
+
+	mutex  sync.Mutex
+	sender idl.MessageSender
+}
+
+// Send continues publishing the step's messages after the CLI disconnects so
+// subscribers can follow the step to completion.
+func (e *eventSender) Send(msg *idl.Message) error {
+	e.events.record(msg)
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.sender == nil {
+		return nil
+	}
+
+	if err := e.sender.Send(msg); err != nil {
+		log.Printf("halting client sender: %v", err)
+		e.sender = nil
+	}
+
+	return nil
+}
+
+func (e *Events) record(msg *idl.Message) {
+	// Copy the message since the output buffers belong to the writer.
+	msg = proto.Clone(msg).(*idl.Message)
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	switch x := msg.GetContents().(type) {
+	case *idl.Message_Status:
+		e.recordStatus(x.Status)
+	case *idl.Message_Chunk:
+		e.recordChunk(x.Chunk)
+	}
+
+	e.publish(&idl.SubscribeReply{Contents: &idl.SubscribeReply_Message{Message: msg}})
+}
+
+func (e *Events) recordStatus(status *idl.SubstepStatus) {
+	for i, existing := range e.statuses {
+		if existing.GetStep() == status.GetStep() {
+			e.statuses[i] = status
+			return
+		}
+	}
+
+	e.statuses = append(e.statuses, status)
+}
+
+func (e *Events) recordChunk(chunk *idl.Chunk) {
+	e.output = append(e.output, chunk)
+	e.outputBytes += len(chunk.GetBuffer())
+
+	for len(e.output) > 1 && e.outputBytes > MaxReplayedOutput {
+		e.outputBytes -= len(e.output[0].GetBuffer())
+		e.output = e.output[1:]
+	}
+}
+
+// RecordAgentActivity publishes the start and end of a request to an agent.
+func (e *Events) RecordAgentActivity(host string, method string, running bool, err error) {
+	activity := &idl.AgentActivity{Host: host, Method: method, Running: running}
+	if err != nil {
+		activity.Error = err.Error()
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.agents[host] = activity
+	e.publish(&idl.SubscribeReply{Contents: &idl.SubscribeReply_AgentActivity{AgentActivity: activity}})
+}
+
+// UnaryClientInterceptor records the requests made to the agent on host.
+func (e *Events) UnaryClientInterceptor(host string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		e.RecordAgentActivity(host, method, true, nil)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		e.RecordAgentActivity(host, method, false, err)
+		return err
+	}
+}
+
+// publish must be called with the mutex held.
+func (e *Events) publish(reply *idl.SubscribeReply) {
+	for subscriber := range e.subscribers {
+		select {
+		case subscriber <- reply:
+		default:
+			delete(e.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
+
+// Subscribe returns the events to replay for the current step along with a
+// channel of new events. The channel is closed if the subscriber falls too far
+// behind. The returned function unsubscribes.
+func (e *Events) Subscribe() ([]*idl.SubscribeReply, <-chan *idl.SubscribeReply, func()) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	var replay []*idl.SubscribeReply
+	if e.step != idl.Step_unknown_step {
+		replay = append(replay, &idl.SubscribeReply{Contents: &idl.SubscribeReply_Step{Step: e.step}})
+	}
+
+	for _, status := range e.statuses {
+		replay = append(replay, &idl.SubscribeReply{Contents: &idl.SubscribeReply_Message{
+			Message: &idl.Message{Contents: &idl.Message_Status{Status: status}},
+		}})
+	}
+
+	for _, chunk := range e.output {
+		replay = append(replay, &idl.SubscribeReply{Contents: &idl.SubscribeReply_Message{
+			Message: &idl.Message{Contents: &idl.Message_Chunk{Chunk: chunk}},
+		}})
+	}
+
+	hosts := make([]string, 0, len(e.agents))
+	for host := range e.agents {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		replay = append(replay, &idl.SubscribeReply{Contents: &idl.SubscribeReply_AgentActivity{AgentActivity: e.agents[host]}})
+	}
+
+	subscriber := make(chan *idl.SubscribeReply, subscriberBuffer)
+	e.subscribers[subscriber] = struct{}{}
+
+	unsubscribe := func() {
+		e.mutex.Lock()
+		defer e.mutex.Unlock()
+
+		if _, ok := e.subscribers[subscriber]; ok {
+			delete(e.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+
+	return replay, subscriber, unsubscribe
+}
+
+func (s *Server) Subscribe(req *idl.SubscribeRequest, stream idl.CliToHub_SubscribeServer) error {
+	replay, events, unsubscribe := s.events.Subscribe()
+	defer unsubscribe()
+
+	for _, reply := range replay {
+		if err := stream.Send(reply); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case reply, ok := <-events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "subscriber fell too far behind the upgrade events")
+			}
+
+			if err := stream.Send(reply); err != nil {
+				return err
+			}
+		}
+	}
+}
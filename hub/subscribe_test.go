@@ -0,0 +1,275 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func statusMessage(substep idl.Substep, status idl.Status) *idl.Message {
+	return &idl.Message{Contents: &idl.Message_Status{Status: &idl.SubstepStatus{Step: substep, Status: status}}}
+}
+
+func chunkMessage(output []byte) *idl.Message {
+	return &idl.Message{Contents: &idl.Message_Chunk{Chunk: &idl.Chunk{Buffer: output, Type: idl.Chunk_stdout}}}
+}
+
+func messageReply(msg *idl.Message) *idl.SubscribeReply {
+	return &idl.SubscribeReply{Contents: &idl.SubscribeReply_Message{Message: msg}}
+}
+
+func expectReplies(t *testing.T, replies []*idl.SubscribeReply, expected []*idl.SubscribeReply) {
+	t.Helper()
+
+	if len(replies) != len(expected) {
+		t.Fatalf("got %d replies want %d: %v", len(replies), len(expected), replies)
+	}
+
+	for i := range replies {
+		if !proto.Equal(replies[i], expected[i]) {
+			t.Errorf("got reply %v want %v", replies[i], expected[i])
+		}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	t.Run("replays the current step, its substep statuses, output, and agent activity", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stream := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		stream.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		events := hub.NewEvents()
+
+		initialize := events.Sender(idl.Step_initialize, stream)
+		_ = initialize.Send(statusMessage(idl.Substep_check_upgrade, idl.Status_failed))
+
+		sender := events.Sender(idl.Step_execute, stream)
+		_ = sender.Send(statusMessage(idl.Substep_upgrade_master, idl.Status_running))
+		_ = sender.Send(chunkMessage([]byte("upgrading master\n")))
+		_ = sender.Send(statusMessage(idl.Substep_upgrade_master, idl.Status_complete))
+		_ = sender.Send(statusMessage(idl.Substep_upgrade_primaries, idl.Status_running))
+
+		events.RecordAgentActivity("sdw2", "/idl.Agent/UpgradePrimaries", true, nil)
+		events.RecordAgentActivity("sdw1", "/idl.Agent/UpgradePrimaries", false, errors.New("pg_upgrade failed"))
+
+		replay, _, unsubscribe := events.Subscribe()
+		defer unsubscribe()
+
+		expectReplies(t, replay, []*idl.SubscribeReply{
+			{Contents: &idl.SubscribeReply_Step{Step: idl.Step_execute}},
+			messageReply(statusMessage(idl.Substep_upgrade_master, idl.Status_complete)),
+			messageReply(statusMessage(idl.Substep_upgrade_primaries, idl.Status_running)),
+			messageReply(chunkMessage([]byte("upgrading master\n"))),
+			{Contents: &idl.SubscribeReply_AgentActivity{AgentActivity: &idl.AgentActivity{Host: "sdw1", Method: "/idl.Agent/UpgradePrimaries", Error: "pg_upgrade failed"}}},
+			{Contents: &idl.SubscribeReply_AgentActivity{AgentActivity: &idl.AgentActivity{Host: "sdw2", Method: "/idl.Agent/UpgradePrimaries", Running: true}}},
+		})
+	})
+
+	t.Run("publishes new events to each subscriber", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stream := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		stream.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		events := hub.NewEvents()
+
+		replay, first, unsubscribeFirst := events.Subscribe()
+		defer unsubscribeFirst()
+
+		if len(replay) != 0 {
+			t.Errorf("got replay %v want none", replay)
+		}
+
+		_, second, unsubscribeSecond := events.Subscribe()
+		defer unsubscribeSecond()
+
+		sender := events.Sender(idl.Step_finalize, stream)
+		_ = sender.Send(statusMessage(idl.Substep_upgrade_mirrors, idl.Status_running))
+
+		expected := []*idl.SubscribeReply{
+			{Contents: &idl.SubscribeReply_Step{Step: idl.Step_finalize}},
+			messageReply(statusMessage(idl.Substep_upgrade_mirrors, idl.Status_running)),
+		}
+
+		for _, subscriber := range []<-chan *idl.SubscribeReply{first, second} {
+			expectReplies(t, []*idl.SubscribeReply{<-subscriber, <-subscriber}, expected)
+		}
+	})
+
+	t.Run("copies the output since the writer may reuse its buffer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stream := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		stream.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		events := hub.NewEvents()
+		sender := events.Sender(idl.Step_execute, stream)
+
+		buffer := []byte("original")
+		_ = sender.Send(chunkMessage(buffer))
+		copy(buffer, "modified")
+
+		replay, _, unsubscribe := events.Subscribe()
+		defer unsubscribe()
+
+		expectReplies(t, replay[1:], []*idl.SubscribeReply{messageReply(chunkMessage([]byte("original")))})
+	})
+
+	t.Run("bounds the replayed output", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stream := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		stream.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		events := hub.NewEvents()
+		sender := events.Sender(idl.Step_execute, stream)
+
+		line := []byte(strings.Repeat("x", 1023) + "\n")
+		for i := 0; i < 2*hub.MaxReplayedOutput/len(line); i++ {
+			_ = sender.Send(chunkMessage(line))
+		}
+
+		replay, _, unsubscribe := events.Subscribe()
+		defer unsubscribe()
+
+		var replayed int
+		for _, reply := range replay {
+			replayed += len(reply.GetMessage().GetChunk().GetBuffer())
+		}
+
+		if replayed != hub.MaxReplayedOutput {
+			t.Errorf("got %d bytes of replayed output want %d", replayed, hub.MaxReplayedOutput)
+		}
+	})
+
+	t.Run("drops subscribers that fall behind", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stream := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		stream.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		events := hub.NewEvents()
+		_, subscriber, unsubscribe := events.Subscribe()
+		defer unsubscribe()
+
+		sender := events.Sender(idl.Step_execute, stream)
+		for i := 0; i < 2000; i++ {
+			_ = sender.Send(chunkMessage([]byte("output\n")))
+		}
+
+		for range subscriber {
+			// drain until the channel is closed
+		}
+	})
+
+	t.Run("continues publishing after the CLI disconnects", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stream := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		stream.EXPECT().Send(gomock.Any()).Return(errors.New("transport is closing")).Times(1)
+
+		events := hub.NewEvents()
+		sender := events.Sender(idl.Step_execute, stream)
+
+		for _, substep := range []idl.Substep{idl.Substep_upgrade_master, idl.Substep_copy_master} {
+			err := sender.Send(statusMessage(substep, idl.Status_complete))
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+
+		replay, _, unsubscribe := events.Subscribe()
+		defer unsubscribe()
+
+		expectReplies(t, replay, []*idl.SubscribeReply{
+			{Contents: &idl.SubscribeReply_Step{Step: idl.Step_execute}},
+			messageReply(statusMessage(idl.Substep_upgrade_master, idl.Status_complete)),
+			messageReply(statusMessage(idl.Substep_copy_master, idl.Status_complete)),
+		})
+	})
+	t.Run("keeps publishing substeps until the step completes after the originating stream closes", func(t *testing.T) {
+		stateDir := testutils.GetTempDir(t, "")
+		defer testutils.MustRemoveAll(t, stateDir)
+
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+		defer resetEnv()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		streamCtx, closeStream := context.WithCancel(context.Background())
+		var closed atomic.Bool
+
+		stream := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		stream.EXPECT().Context().Return(streamCtx).AnyTimes()
+		stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(*idl.Message) error {
+			if closed.Load() {
+				return errors.New("transport is closing")
+			}
+			return nil
+		}).AnyTimes()
+
+		events := hub.NewEvents()
+		sender := events.Sender(idl.Step_execute, stream)
+
+		_, subscriber, unsubscribe := events.Subscribe()
+		defer unsubscribe()
+
+		st, err := step.Begin(context.Background(), idl.Step_execute, sender, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		st.Run(idl.Substep_upgrade_master, func(streams step.OutStreams) error {
+			return nil
+		})
+
+		closed.Store(true)
+		closeStream()
+
+		for _, substep := range []idl.Substep{idl.Substep_copy_master, idl.Substep_upgrade_primaries} {
+			st.Run(substep, func(streams step.OutStreams) error {
+				return step.Context(streams).Err()
+			})
+		}
+
+		if st.Err() != nil {
+			t.Fatalf("unexpected error: %v", st.Err())
+		}
+
+		var completed []idl.Substep
+		for len(subscriber) > 0 {
+			status := (<-subscriber).GetMessage().GetStatus()
+			if status.GetStatus() == idl.Status_complete {
+				completed = append(completed, status.GetStep())
+			}
+		}
+
+		expected := []idl.Substep{idl.Substep_upgrade_master, idl.Substep_copy_master, idl.Substep_upgrade_primaries}
+		if !reflect.DeepEqual(completed, expected) {
+			t.Errorf("got completed substeps %v want %v", completed, expected)
+		}
+	})
+}
@@ -19,7 +19,7 @@ import (
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-func UpdateConfFiles(agentConns []*idl.Connection, _ step.OutStreams, version semver.Version, intermediate *greenplum.Cluster, target *greenplum.Cluster) error {
+func UpdateConfFiles(agentConns []*idl.Connection, streams step.OutStreams, version semver.Version, intermediate *greenplum.Cluster, target *greenplum.Cluster) error {
 	if version.Major < 7 {
 		// update gpperfmon.conf on coordinator
 		err := UpdateConfigurationFile([]*idl.UpdateFileConfOptions{{
@@ -42,22 +42,22 @@ func UpdateConfFiles(agentConns []*idl.Connection, _ step.OutStreams, version se
 		return err
 	}
 
-	if err := UpdatePostgresqlConfOnSegments(agentConns, intermediate, target); err != nil {
+	if err := UpdatePostgresqlConfOnSegments(step.Context(streams), agentConns, intermediate, target); err != nil {
 		return err
 	}
 
-	if err := UpdateRecoveryConfOnSegments(agentConns, version, intermediate, target); err != nil {
+	if err := UpdateRecoveryConfOnSegments(step.Context(streams), agentConns, version, intermediate, target); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func UpdatePostgresqlConfOnSegments(agentConns []*idl.Connection, intermediate *greenplum.Cluster, target *greenplum.Cluster) error {
+func UpdatePostgresqlConfOnSegments(ctx context.Context, agentConns []*idl.Connection, intermediate *greenplum.Cluster, target *greenplum.Cluster) error {
 	pattern := `(^port[ \t]*=[ \t]*)%d([^0-9]|$)`
 	replacement := `\1%d\2`
 
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		var opts []*idl.UpdateFileConfOptions
 
 		// add standby
@@ -102,14 +102,14 @@ func UpdatePostgresqlConfOnSegments(agentConns []*idl.Connection, intermediate *
 		}
 
 		req := &idl.UpdateConfigurationRequest{Options: opts}
-		_, err := conn.AgentClient.UpdateConfiguration(context.Background(), req)
+		_, err := conn.AgentClient.UpdateConfiguration(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
 
-func UpdateRecoveryConfOnSegments(agentConns []*idl.Connection, version semver.Version, intermediateCluster *greenplum.Cluster, target *greenplum.Cluster) error {
+func UpdateRecoveryConfOnSegments(ctx context.Context, agentConns []*idl.Connection, version semver.Version, intermediateCluster *greenplum.Cluster, target *greenplum.Cluster) error {
 	file := "postgresql.auto.conf"
 	if version.Major == 6 {
 		file = "recovery.conf"
@@ -118,7 +118,7 @@ func UpdateRecoveryConfOnSegments(agentConns []*idl.Connection, version semver.V
 	pattern := `(primary_conninfo .* port[ \t]*=[ \t]*)%d([^0-9]|$)`
 	replacement := `\1%d\2`
 
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		var opts []*idl.UpdateFileConfOptions
 
 		// add standby
@@ -148,18 +148,18 @@ func UpdateRecoveryConfOnSegments(agentConns []*idl.Connection, version semver.V
 		}
 
 		req := &idl.UpdateConfigurationRequest{Options: opts}
-		_, err := conn.AgentClient.UpdateConfiguration(context.Background(), req)
+		_, err := conn.AgentClient.UpdateConfiguration(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
 
-func UpdateInternalAutoConfOnMirrors(agentConns []*idl.Connection, intermediate *greenplum.Cluster) error {
+func UpdateInternalAutoConfOnMirrors(ctx context.Context, agentConns []*idl.Connection, intermediate *greenplum.Cluster) error {
 	pattern := `(^gp_dbid=)%d([^0-9]|$)`
 	replacement := `\1%d\2`
 
-	request := func(conn *idl.Connection) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		intermediateMirrors := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
 		})
@@ -181,11 +181,11 @@ func UpdateInternalAutoConfOnMirrors(agentConns []*idl.Connection, intermediate
 		}
 
 		req := &idl.UpdateConfigurationRequest{Options: opts}
-		_, err := conn.AgentClient.UpdateConfiguration(context.Background(), req)
+		_, err := conn.AgentClient.UpdateConfiguration(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
 
 func UpdateConfigurationFile(opts []*idl.UpdateFileConfOptions) error {
@@ -23,7 +23,7 @@ import (
 // format of yyyyMMddTHHmmss
 const TimeStringFormat = "20060102T150405"
 
-func UpgradeCoordinator(streams step.OutStreams, backupDir string, pgUpgradeVerbose bool, skipPgUpgradeChecks bool, pgUpgradeJobs uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, action idl.PgOptions_Action, mode idl.Mode, pgUpgradeTimestamp string) error {
+func UpgradeCoordinator(streams step.OutStreams, backupDir string, pgUpgradeVerbose bool, skipPgUpgradeChecks bool, pgUpgradeJobs uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, action idl.PgOptions_Action, mode idl.Mode, pgUpgradeTimestamp string, tablespaceMappings greenplum.TablespaceMappings) error {
 	oldOptions := ""
 	// When upgrading from 5 the coordinator must be provided with its standby's dbid to allow WAL to sync.
 	if source.Version.Major == 5 && source.HasStandby() {
@@ -58,7 +58,7 @@ func UpgradeCoordinator(streams step.OutStreams, backupDir string, pgUpgradeVerb
 		return err
 	}
 
-	err = upgrade.Run(streams.Stdout(), streams.Stderr(), opts)
+	err = upgrade.Run(step.Context(streams), streams.Stdout(), streams.Stderr(), opts)
 	if err != nil {
 		if opts.Action != idl.PgOptions_check {
 			return xerrors.Errorf("%s master: %v", action, err)
@@ -90,6 +90,14 @@ PGPORT=%d`, pgUpgradeDir,
 		return utils.NewNextActionErr(xerrors.Errorf("%s master: %v", action, err), nextAction)
 	}
 
+	if action == idl.PgOptions_upgrade {
+		relocations := tablespaceMappings.Relocations(intermediate.Coordinator(), source.Tablespaces.GetCoordinatorTablespaces(), intermediate.Version.Major, intermediate.CatalogVersion)
+		err = upgrade.RelocateTablespaces(step.Context(streams), intermediate.CoordinatorDataDir(), relocations)
+		if err != nil {
+			return xerrors.Errorf("relocate master tablespaces: %w", err)
+		}
+	}
+
 	return nil
 }
 
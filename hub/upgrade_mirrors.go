@@ -0,0 +1,200 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// ResolveMirrorUpgradeMethod returns the method finalize uses to create the
+// mirrors and standby. auto, or unset for configurations saved by earlier
+// versions, uses rsync in link mode since the source mirrors already hold most
+// of the upgraded files, and gpaddmirrors in copy mode.
+func ResolveMirrorUpgradeMethod(mode idl.Mode, method idl.MirrorUpgradeMethod) idl.MirrorUpgradeMethod {
+	if method != idl.MirrorUpgradeMethod_auto && method != idl.MirrorUpgradeMethod_unknown_mirror_upgrade_method {
+		return method
+	}
+
+	if mode == idl.Mode_link {
+		return idl.MirrorUpgradeMethod_rsync
+	}
+
+	return idl.MirrorUpgradeMethod_gpaddmirrors
+}
+
+// MirrorsInCatalog returns the number of mirrors excluding the standby in
+// gp_segment_configuration.
+func MirrorsInCatalog(db *sql.DB) (int, error) {
+	var count int
+	row := db.QueryRow(`SELECT COUNT(*) FROM gp_segment_configuration WHERE role = 'm' AND content <> -1;`)
+	if err := row.Scan(&count); err != nil {
+		return 0, xerrors.Errorf("querying gp_segment_configuration for mirrors: %w", err)
+	}
+
+	return count, nil
+}
+
+// mirrorsAdded returns whether a previous run already added all mirrors to the
+// catalog such that they are not created again with method. Only some mirrors
+// being added needs manual intervention.
+func mirrorsAdded(streams step.OutStreams, db *sql.DB, intermediate *greenplum.Cluster, method string) (bool, error) {
+	added, err := MirrorsInCatalog(db)
+	if err != nil {
+		return false, err
+	}
+
+	expected := len(intermediate.Mirrors.ExcludingStandby())
+	switch {
+	case added == expected:
+		return true, reportProgress(streams, "Skipping %s since all %d mirrors were already added.", method, expected)
+	case added > 0:
+		return false, utils.NewNextActionErr(
+			xerrors.Errorf("found %d of %d mirrors already added to the target cluster", added, expected),
+			"Remove the added mirrors from the target cluster or add the remaining mirrors with gpaddmirrors and re-run gpupgrade finalize.")
+	}
+
+	return false, nil
+}
+
+// reportProgress writes a progress message to the substep output and log.
+func reportProgress(streams step.OutStreams, format string, args ...interface{}) error {
+	message := fmt.Sprintf(format, args...)
+	log.Print(message)
+
+	_, err := fmt.Fprintln(streams.Stdout(), message)
+	return err
+}
+
+// UpgradeMirrorsUsingPgBasebackup creates each mirror with pg_basebackup from
+// its running primary on the mirror host. It is skipped when a previous run
+// already added the mirrors to the catalog.
+func UpgradeMirrorsUsingPgBasebackup(ctx context.Context, streams step.OutStreams, agentConns []*idl.Connection, intermediate *greenplum.Cluster, useHbaHostnames bool, addressFamily utils.AddressFamily) (err error) {
+	// A retry after a failure may find the cluster stopped for adding the
+	// mirrors to the catalog.
+	if err := intermediate.Start(streams); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("pgx", intermediate.Connection())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	added, err := mirrorsAdded(streams, db, intermediate, "pg_basebackup")
+	if err != nil || added {
+		return err
+	}
+
+	if err := reportProgress(streams, "Creating replication slots on the primaries..."); err != nil {
+		return err
+	}
+
+	if err := CreateReplicationSlots(db); err != nil {
+		return err
+	}
+
+	if err := reportProgress(streams, "Allowing the mirror hosts to replicate from the primaries..."); err != nil {
+		return err
+	}
+
+	if err := AddReplicationEntriesOnPrimaries(agentConns, intermediate, useHbaHostnames, addressFamily); err != nil {
+		return err
+	}
+
+	if err := intermediate.RunGreenplumCmd(streams, "gpstop", "-u"); err != nil {
+		return err
+	}
+
+	if err := reportProgress(streams, "Running pg_basebackup for %d mirrors...", len(intermediate.Mirrors.ExcludingStandby())); err != nil {
+		return err
+	}
+
+	if err := BaseBackupMirrorsOnSegments(ctx, streams, agentConns, intermediate); err != nil {
+		return err
+	}
+
+	if err := reportProgress(streams, "Adding the mirrors to the catalog..."); err != nil {
+		return err
+	}
+
+	if err := intermediate.Stop(step.DevNullStream); err != nil {
+		return err
+	}
+
+	if err := intermediate.StartCoordinatorOnly(step.DevNullStream); err != nil {
+		return err
+	}
+
+	if err := addMirrorsToCatalog(intermediate); err != nil {
+		return err
+	}
+
+	if err := intermediate.StopCoordinatorOnly(step.DevNullStream); err != nil {
+		return err
+	}
+
+	if err := intermediate.Start(step.DevNullStream); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BaseBackupMirrorsOnSegments runs pg_basebackup on each mirror host and
+// reports each host as it completes.
+func BaseBackupMirrorsOnSegments(ctx context.Context, streams step.OutStreams, agentConns []*idl.Connection, intermediate *greenplum.Cluster) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		mirrors := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
+			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
+		})
+
+		if len(mirrors) == 0 {
+			return nil
+		}
+
+		sort.Sort(greenplum.SegConfigs(mirrors))
+
+		var backups []*idl.BaseBackupMirrorsRequest_BaseBackup
+		for _, mirror := range mirrors {
+			primary := intermediate.Primaries[mirror.ContentID]
+			backups = append(backups, &idl.BaseBackupMirrorsRequest_BaseBackup{
+				MirrorDataDir: mirror.DataDir,
+				MirrorDbId:    int32(mirror.DbID),
+				PrimaryHost:   primary.Hostname,
+				PrimaryPort:   int32(primary.Port),
+			})
+		}
+
+		req := &idl.BaseBackupMirrorsRequest{
+			TargetBinDir: filepath.Join(intermediate.GPHome, "bin"),
+			Backups:      backups,
+		}
+
+		if _, err := conn.AgentClient.BaseBackupMirrors(ctx, req); err != nil {
+			return xerrors.Errorf("pg_basebackup on host %s: %w", conn.Hostname, err)
+		}
+
+		return reportProgress(streams, "Completed pg_basebackup of %d mirrors on host %s", len(backups), conn.Hostname)
+	}
+
+	return ExecuteRPC(ctx, agentConns, request)
+}
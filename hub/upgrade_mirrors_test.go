@@ -0,0 +1,158 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestResolveMirrorUpgradeMethod(t *testing.T) {
+	cases := []struct {
+		name     string
+		mode     idl.Mode
+		method   idl.MirrorUpgradeMethod
+		expected idl.MirrorUpgradeMethod
+	}{
+		{name: "auto uses rsync in link mode", mode: idl.Mode_link, method: idl.MirrorUpgradeMethod_auto, expected: idl.MirrorUpgradeMethod_rsync},
+		{name: "auto uses gpaddmirrors in copy mode", mode: idl.Mode_copy, method: idl.MirrorUpgradeMethod_auto, expected: idl.MirrorUpgradeMethod_gpaddmirrors},
+		{name: "unset behaves like auto", mode: idl.Mode_link, method: idl.MirrorUpgradeMethod_unknown_mirror_upgrade_method, expected: idl.MirrorUpgradeMethod_rsync},
+		{name: "uses rsync in copy mode", mode: idl.Mode_copy, method: idl.MirrorUpgradeMethod_rsync, expected: idl.MirrorUpgradeMethod_rsync},
+		{name: "uses gpaddmirrors in link mode", mode: idl.Mode_link, method: idl.MirrorUpgradeMethod_gpaddmirrors, expected: idl.MirrorUpgradeMethod_gpaddmirrors},
+		{name: "uses pg_basebackup", mode: idl.Mode_link, method: idl.MirrorUpgradeMethod_pg_basebackup, expected: idl.MirrorUpgradeMethod_pg_basebackup},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			method := hub.ResolveMirrorUpgradeMethod(c.mode, c.method)
+			if method != c.expected {
+				t.Errorf("got %s want %s", method, c.expected)
+			}
+		})
+	}
+}
+
+func TestMirrorsInCatalog(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("couldn't create sqlmock: %v", err)
+	}
+	defer testutils.FinishMock(mock, t)
+
+	query := `SELECT COUNT\(\*\) FROM gp_segment_configuration WHERE role = 'm' AND content <> -1;`
+
+	t.Run("returns the number of mirrors", func(t *testing.T) {
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		count, err := hub.MirrorsInCatalog(db)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if count != 2 {
+			t.Errorf("got %d want 2", count)
+		}
+	})
+
+	t.Run("errors when querying fails", func(t *testing.T) {
+		expected := errors.New("connection failed")
+		mock.ExpectQuery(query).WillReturnError(expected)
+
+		_, err := hub.MirrorsInCatalog(db)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestBaseBackupMirrorsOnSegments(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby.HqtFHX54y0o", Port: 50433, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
+		{DbID: 4, ContentID: 0, Hostname: "sdw2", DataDir: "/data/dbfast_mirror1/seg.HqtFHX54y0o.1", Port: 50435, Role: greenplum.MirrorRole},
+		{DbID: 5, ContentID: 1, Hostname: "sdw2", DataDir: "/data/dbfast2/seg.HqtFHX54y0o.2", Port: 50436, Role: greenplum.PrimaryRole},
+		{DbID: 6, ContentID: 1, Hostname: "sdw1", DataDir: "/data/dbfast_mirror2/seg.HqtFHX54y0o.2", Port: 50437, Role: greenplum.MirrorRole},
+	})
+	intermediate.GPHome = "/usr/local/greenplum-db-target"
+
+	t.Run("runs pg_basebackup for the mirrors on each host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().BaseBackupMirrors(
+			gomock.Any(),
+			&idl.BaseBackupMirrorsRequest{
+				TargetBinDir: "/usr/local/greenplum-db-target/bin",
+				Backups: []*idl.BaseBackupMirrorsRequest_BaseBackup{{
+					MirrorDataDir: "/data/dbfast_mirror2/seg.HqtFHX54y0o.2",
+					MirrorDbId:    6,
+					PrimaryHost:   "sdw2",
+					PrimaryPort:   50436,
+				}},
+			},
+		).Return(&idl.BaseBackupMirrorsReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().BaseBackupMirrors(
+			gomock.Any(),
+			&idl.BaseBackupMirrorsRequest{
+				TargetBinDir: "/usr/local/greenplum-db-target/bin",
+				Backups: []*idl.BaseBackupMirrorsRequest_BaseBackup{{
+					MirrorDataDir: "/data/dbfast_mirror1/seg.HqtFHX54y0o.1",
+					MirrorDbId:    4,
+					PrimaryHost:   "sdw1",
+					PrimaryPort:   50434,
+				}},
+			},
+		).Return(&idl.BaseBackupMirrorsReply{}, nil)
+
+		// the standby is added separately
+		standby := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: standby, Hostname: "standby"},
+		}
+
+		err := hub.BaseBackupMirrorsOnSegments(context.Background(), step.DevNullStream, agentConns, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("returns errors when failing on segments", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().BaseBackupMirrors(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.BaseBackupMirrorsOnSegments(context.Background(), step.DevNullStream, agentConns, intermediate)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
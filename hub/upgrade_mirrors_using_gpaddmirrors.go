@@ -5,15 +5,39 @@ package hub
 
 import (
 	"bytes"
+	"database/sql"
 	"fmt"
 	"os"
 
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
+// UpgradeMirrorsUsingGpAddMirrors adds the mirrors with gpaddmirrors unless an
+// earlier attempt already added them. Since gpaddmirrors cannot add some of
+// the mirrors, partially added mirrors must be resolved manually.
 func UpgradeMirrorsUsingGpAddMirrors(streams step.OutStreams, intermediate *greenplum.Cluster, useHbaHostnames bool) (err error) {
+	db, err := sql.Open("pgx", intermediate.Connection())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	added, err := mirrorsAdded(streams, db, intermediate, "gpaddmirrors")
+	if err != nil || added {
+		return err
+	}
+
+	if err := reportProgress(streams, "Adding %d mirrors using gpaddmirrors...", len(intermediate.Mirrors.ExcludingStandby())); err != nil {
+		return err
+	}
+
 	config, err := writeAddMirrorsConfig(intermediate)
 	if err != nil {
 		return err
@@ -13,10 +13,11 @@ import (
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
 	"github.com/greenplum-db/gpupgrade/utils/errorlist"
 )
 
-func UpgradeMirrorsUsingRsync(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, useHbaHostnames bool) error {
+func UpgradeMirrorsUsingRsync(ctx context.Context, streams step.OutStreams, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, useHbaHostnames bool, addressFamily utils.AddressFamily) (err error) {
 	db, err := sql.Open("pgx", intermediate.Connection())
 	if err != nil {
 		return err
@@ -27,6 +28,10 @@ func UpgradeMirrorsUsingRsync(agentConns []*idl.Connection, source *greenplum.Cl
 		}
 	}()
 
+	if err := reportProgress(streams, "Creating replication slots on the primaries..."); err != nil {
+		return err
+	}
+
 	if err := CreateReplicationSlots(db); err != nil {
 		return err
 	}
@@ -35,15 +40,27 @@ func UpgradeMirrorsUsingRsync(agentConns []*idl.Connection, source *greenplum.Cl
 		return err
 	}
 
-	if err := RsyncMirrorDataDirsOnSegments(agentConns, source, intermediate); err != nil {
+	if err := reportProgress(streams, "Rsyncing %d mirror data directories...", len(intermediate.Mirrors.ExcludingStandby())); err != nil {
+		return err
+	}
+
+	if err := RsyncMirrorDataDirsOnSegments(ctx, agentConns, source, intermediate); err != nil {
 		return err
 	}
 
-	if err := RsyncMirrorTablespacesOnSegments(agentConns, source, intermediate); err != nil {
+	if err := reportProgress(streams, "Rsyncing mirror tablespaces..."); err != nil {
 		return err
 	}
 
-	if err := RenameMirrorTablespacesOnSegments(agentConns, source, intermediate); err != nil {
+	if err := RsyncMirrorTablespacesOnSegments(ctx, agentConns, source, intermediate); err != nil {
+		return err
+	}
+
+	if err := RenameMirrorTablespacesOnSegments(ctx, agentConns, source, intermediate); err != nil {
+		return err
+	}
+
+	if err := reportProgress(streams, "Configuring the mirrors to replicate from the primaries..."); err != nil {
 		return err
 	}
 
@@ -51,11 +68,15 @@ func UpgradeMirrorsUsingRsync(agentConns []*idl.Connection, source *greenplum.Cl
 		return err
 	}
 
-	if err := AddReplicationEntriesOnPrimaries(agentConns, intermediate, useHbaHostnames); err != nil {
+	if err := AddReplicationEntriesOnPrimaries(agentConns, intermediate, useHbaHostnames, addressFamily); err != nil {
+		return err
+	}
+
+	if err := UpdateInternalAutoConfOnMirrors(ctx, agentConns, intermediate); err != nil {
 		return err
 	}
 
-	if err := UpdateInternalAutoConfOnMirrors(agentConns, intermediate); err != nil {
+	if err := reportProgress(streams, "Adding the mirrors to the catalog..."); err != nil {
 		return err
 	}
 
@@ -78,8 +99,8 @@ func UpgradeMirrorsUsingRsync(agentConns []*idl.Connection, source *greenplum.Cl
 	return nil
 }
 
-func RsyncMirrorDataDirsOnSegments(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
-	request := func(conn *idl.Connection) error {
+func RsyncMirrorDataDirsOnSegments(ctx context.Context, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		sourcePrimaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsCoordinator() && seg.IsPrimary()
 		})
@@ -102,15 +123,15 @@ func RsyncMirrorDataDirsOnSegments(agentConns []*idl.Connection, source *greenpl
 		}
 
 		req := &idl.RsyncRequest{Options: opts}
-		_, err := conn.AgentClient.RsyncDataDirectories(context.Background(), req)
+		_, err := conn.AgentClient.RsyncDataDirectories(ctx, req)
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
 
-func RsyncMirrorTablespacesOnSegments(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
-	request := func(conn *idl.Connection) error {
+func RsyncMirrorTablespacesOnSegments(ctx context.Context, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		sourcePrimaries := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsCoordinator() && seg.IsPrimary()
 		})
@@ -139,15 +160,15 @@ func RsyncMirrorTablespacesOnSegments(agentConns []*idl.Connection, source *gree
 			}
 		}
 
-		_, err := conn.AgentClient.RsyncTablespaceDirectories(context.Background(), &idl.RsyncRequest{Options: opts})
+		_, err := conn.AgentClient.RsyncTablespaceDirectories(ctx, &idl.RsyncRequest{Options: opts})
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
 
-func RenameMirrorTablespacesOnSegments(agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
-	request := func(conn *idl.Connection) error {
+func RenameMirrorTablespacesOnSegments(ctx context.Context, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		intermediateMirrors := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && !seg.IsStandby() && seg.IsMirror()
 		})
@@ -177,9 +198,9 @@ func RenameMirrorTablespacesOnSegments(agentConns []*idl.Connection, source *gre
 			}
 		}
 
-		_, err := conn.AgentClient.RenameTablespaces(context.Background(), &idl.RenameTablespacesRequest{RenamePairs: pairs})
+		_, err := conn.AgentClient.RenameTablespaces(ctx, &idl.RenameTablespacesRequest{RenamePairs: pairs})
 		return err
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -73,7 +74,7 @@ func TestRsyncMirrorDataDirsOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RsyncMirrorDataDirsOnSegments(agentConns, intermediate, source)
+		err := hub.RsyncMirrorDataDirsOnSegments(context.Background(), agentConns, intermediate, source)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -101,7 +102,7 @@ func TestRsyncMirrorDataDirsOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RsyncMirrorDataDirsOnSegments(agentConns, intermediate, source)
+		err := hub.RsyncMirrorDataDirsOnSegments(context.Background(), agentConns, intermediate, source)
 		var errs errorlist.Errors
 		if !errors.As(err, &errs) {
 			t.Fatalf("error %#v does not contain type %T", err, errs)
@@ -176,7 +177,7 @@ func TestRsyncAndRenameMirrorTablespacesOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RsyncMirrorTablespacesOnSegments(agentConns, source, intermediate)
+		err := hub.RsyncMirrorTablespacesOnSegments(context.Background(), agentConns, source, intermediate)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -212,7 +213,7 @@ func TestRsyncAndRenameMirrorTablespacesOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RsyncMirrorTablespacesOnSegments(agentConns, source, intermediate)
+		err := hub.RsyncMirrorTablespacesOnSegments(context.Background(), agentConns, source, intermediate)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
@@ -259,7 +260,7 @@ func TestRenameMirrorTablespacesOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RenameMirrorTablespacesOnSegments(agentConns, source, intermediate)
+		err := hub.RenameMirrorTablespacesOnSegments(context.Background(), agentConns, source, intermediate)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -286,7 +287,7 @@ func TestRenameMirrorTablespacesOnSegments(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.RenameMirrorTablespacesOnSegments(agentConns, source, intermediate)
+		err := hub.RenameMirrorTablespacesOnSegments(context.Background(), agentConns, source, intermediate)
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v, want %#v", err, expected)
 		}
@@ -15,8 +15,8 @@ import (
 	"github.com/greenplum-db/gpupgrade/idl"
 )
 
-func UpgradePrimaries(agentConns []*idl.Connection, agentHostToBackupDir backupdir.AgentHostsToBackupDir, pgUpgradeVerbose bool, skipPgUpgradeChecks bool, pgUpgradeJobs uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, action idl.PgOptions_Action, mode idl.Mode, pgUpgradeTimestamp string) error {
-	request := func(conn *idl.Connection) error {
+func UpgradePrimaries(ctx context.Context, agentConns []*idl.Connection, agentHostToBackupDir backupdir.AgentHostsToBackupDir, pgUpgradeVerbose bool, skipPgUpgradeChecks bool, pgUpgradeJobs uint, source *greenplum.Cluster, intermediate *greenplum.Cluster, action idl.PgOptions_Action, mode idl.Mode, pgUpgradeTimestamp string, tablespaceMappings greenplum.TablespaceMappings) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
 		intermediatePrimaries := intermediate.SelectSegments(func(seg *greenplum.SegConfig) bool {
 			return seg.IsOnHost(conn.Hostname) && seg.IsPrimary() && !seg.IsCoordinator()
 		})
@@ -47,11 +47,15 @@ func UpgradePrimaries(agentConns []*idl.Connection, agentHostToBackupDir backupd
 				Tablespaces:         source.Tablespaces[int32(intermediatePrimary.DbID)],
 				PgUpgradeTimestamp:  pgUpgradeTimestamp,
 			}
+
+			if action == idl.PgOptions_upgrade {
+				opt.TablespaceRelocations = tablespaceMappings.Relocations(intermediatePrimary, source.Tablespaces[int32(intermediatePrimary.DbID)], intermediate.Version.Major, intermediate.CatalogVersion)
+			}
 			opts = append(opts, opt)
 		}
 
 		req := &idl.UpgradePrimariesRequest{Action: action, Opts: opts}
-		_, err := conn.AgentClient.UpgradePrimaries(context.Background(), req)
+		_, err := conn.AgentClient.UpgradePrimaries(ctx, req)
 		if err != nil {
 			return xerrors.Errorf("%s primary segment on host %s: %w", action, conn.Hostname, err)
 		}
@@ -59,5 +63,5 @@ func UpgradePrimaries(agentConns []*idl.Connection, agentHostToBackupDir backupd
 		return nil
 	}
 
-	return ExecuteRPC(agentConns, request)
+	return ExecuteRPC(ctx, agentConns, request)
 }
@@ -4,6 +4,7 @@
 package hub_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -183,7 +184,7 @@ func TestUpgradePrimaries(t *testing.T) {
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
 
-		err := hub.UpgradePrimaries(agentConns, backupDirs.AgentHostsToBackupDir, true, true, 1, source, intermediate, idl.PgOptions_check, idl.Mode_copy, pgUpgradeTimestamp)
+		err := hub.UpgradePrimaries(context.Background(), agentConns, backupDirs.AgentHostsToBackupDir, true, true, 1, source, intermediate, idl.PgOptions_check, idl.Mode_copy, pgUpgradeTimestamp, nil)
 		if err != nil {
 			t.Errorf("unexpected err %#v", err)
 		}
@@ -229,7 +230,7 @@ func TestUpgradePrimaries(t *testing.T) {
 				{AgentClient: sdw2, Hostname: "sdw2"},
 			}
 
-			err := hub.UpgradePrimaries(agentConns, backupDirs.AgentHostsToBackupDir, false, false, 1, source, intermediate, c.Action, idl.Mode_link, pgUpgradeTimestamp)
+			err := hub.UpgradePrimaries(context.Background(), agentConns, backupDirs.AgentHostsToBackupDir, false, false, 1, source, intermediate, c.Action, idl.Mode_link, pgUpgradeTimestamp, nil)
 			var errs errorlist.Errors
 			if !errors.As(err, &errs) {
 				t.Fatalf("error %#v does not contain type %T", err, errs)
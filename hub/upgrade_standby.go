@@ -4,21 +4,29 @@
 package hub
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 	"strconv"
 
+	"golang.org/x/xerrors"
+
 	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
 )
 
-// UpgradeStandby removes any possible existing standby from the cluster
-// before adding a new one for idempotency. In the happy-path, we expect this to
-// fail as there should not be an existing  standby for the cluster.
+// UpgradeStandby removes any existing standby from the cluster before adding
+// a new one with gpinitstandby for idempotency.
 func UpgradeStandby(streams step.OutStreams, intermediate *greenplum.Cluster, useHbaHostnames bool) error {
-	err := intermediate.RunGreenplumCmd(streams, "gpinitstandby", "-r", "-a")
-	if err != nil {
-		// FIXME: Don't ignore actual errors. Perhaps check if there is a standby to remove before attemtping.
-		log.Printf("Failed to remove existing standby. Expected during normal operation. %v", err)
+	if err := RemoveStandby(streams, intermediate); err != nil {
+		return err
 	}
 
 	args := []string{
@@ -34,3 +42,260 @@ func UpgradeStandby(streams step.OutStreams, intermediate *greenplum.Cluster, us
 
 	return intermediate.RunGreenplumCmd(streams, "gpinitstandby", args...)
 }
+
+// UpgradeStandbyUsingRsync creates the standby from the upgraded coordinator
+// using rsync rather than gpinitstandby. In link mode only the files changed
+// by the upgrade are transferred to the source standby host.
+func UpgradeStandbyUsingRsync(ctx context.Context, streams step.OutStreams, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster, useHbaHostnames bool, addressFamily utils.AddressFamily) (err error) {
+	// A retry after a failure may find the cluster stopped for the rsync.
+	if err := intermediate.Start(streams); err != nil {
+		return err
+	}
+
+	if err := RemoveStandby(streams, intermediate); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("pgx", intermediate.Connection())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	if err := CreateCoordinatorReplicationSlot(db); err != nil {
+		return err
+	}
+
+	if err := intermediate.Stop(step.DevNullStream); err != nil {
+		return err
+	}
+
+	if err := RsyncStandbyDataDir(ctx, streams, source, intermediate); err != nil {
+		return err
+	}
+
+	if err := RsyncStandbyTablespaces(ctx, streams, source, intermediate); err != nil {
+		return err
+	}
+
+	if err := RenameStandbyTablespaces(ctx, agentConns, source, intermediate); err != nil {
+		return err
+	}
+
+	if err := CreateRecoveryConfOnStandby(ctx, agentConns, intermediate); err != nil {
+		return err
+	}
+
+	if err := AddReplicationEntriesOnCoordinator(intermediate, useHbaHostnames, addressFamily); err != nil {
+		return err
+	}
+
+	if err := UpdateInternalAutoConfOnStandby(ctx, agentConns, intermediate); err != nil {
+		return err
+	}
+
+	if err := intermediate.StartCoordinatorOnly(step.DevNullStream); err != nil {
+		return err
+	}
+
+	if err := addStandbyToCatalog(intermediate); err != nil {
+		// Stop the coordinator so a retry can start the whole cluster.
+		if sErr := intermediate.StopCoordinatorOnly(step.DevNullStream); sErr != nil {
+			err = errorlist.Append(err, sErr)
+		}
+		return err
+	}
+
+	if err := intermediate.StopCoordinatorOnly(step.DevNullStream); err != nil {
+		return err
+	}
+
+	if err := intermediate.Start(step.DevNullStream); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveStandby removes the standby when one is registered such as when
+// retrying after a failure. The cluster must be running.
+func RemoveStandby(streams step.OutStreams, intermediate *greenplum.Cluster) (err error) {
+	db, err := sql.Open("pgx", intermediate.Connection())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	exists, err := StandbyExists(db)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return nil
+	}
+
+	log.Print("removing existing standby")
+	if err := intermediate.RunGreenplumCmd(streams, "gpinitstandby", "-r", "-a"); err != nil {
+		return xerrors.Errorf("remove existing standby: %w", err)
+	}
+
+	return nil
+}
+
+func StandbyExists(db *sql.DB) (bool, error) {
+	var count int
+	row := db.QueryRow(`SELECT COUNT(*) FROM gp_segment_configuration WHERE content = -1 AND role = 'm';`)
+	if err := row.Scan(&count); err != nil {
+		return false, xerrors.Errorf("querying gp_segment_configuration for the standby: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// RsyncStandbyDataDir copies the intermediate coordinator data directory to
+// the standby host. When the standby directories are named like the
+// coordinator directories both the source and intermediate coordinator are
+// copied together so that hard links between them are preserved and the
+// existing source standby is reused.
+func RsyncStandbyDataDir(ctx context.Context, streams step.OutStreams, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	sourceCoordinator := source.Coordinator()
+	sourceStandby := source.Standby()
+	intermediateCoordinator := intermediate.Coordinator()
+	intermediateStandby := intermediate.Standby()
+
+	opts := []rsync.Option{
+		rsync.WithDestinationHost(intermediateStandby.Hostname),
+		rsync.WithOptions("--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive"),
+		rsync.WithContext(ctx),
+		rsync.WithStream(streams),
+	}
+
+	sameNames := filepath.Base(sourceCoordinator.DataDir) == filepath.Base(sourceStandby.DataDir) &&
+		filepath.Base(intermediateCoordinator.DataDir) == filepath.Base(intermediateStandby.DataDir) &&
+		filepath.Dir(sourceStandby.DataDir) == filepath.Dir(intermediateStandby.DataDir)
+
+	if sameNames {
+		opts = append(opts,
+			rsync.WithSources(sourceCoordinator.DataDir, intermediateCoordinator.DataDir),
+			rsync.WithDestination(filepath.Dir(intermediateStandby.DataDir)))
+	} else {
+		opts = append(opts,
+			rsync.WithSources(intermediateCoordinator.DataDir+string(os.PathSeparator)),
+			rsync.WithDestination(intermediateStandby.DataDir))
+	}
+
+	return rsync.Rsync(opts...)
+}
+
+func RsyncStandbyTablespaces(ctx context.Context, streams step.OutStreams, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	coordinatorTablespaces := source.Tablespaces[int32(source.Coordinator().DbID)]
+	standbyTablespaces := source.Tablespaces[int32(intermediate.Standby().DbID)]
+
+	for oid, coordinatorTsInfo := range coordinatorTablespaces {
+		if !coordinatorTsInfo.GetUserDefined() {
+			continue
+		}
+
+		opts := []rsync.Option{
+			rsync.WithSources(coordinatorTsInfo.GetLocation() + string(os.PathSeparator)),
+			rsync.WithDestinationHost(intermediate.StandbyHostname()),
+			rsync.WithDestination(standbyTablespaces[oid].GetLocation()),
+			rsync.WithOptions("--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive"),
+			rsync.WithContext(ctx),
+			rsync.WithStream(streams),
+		}
+
+		if err := rsync.Rsync(opts...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RenameStandbyTablespaces renames the copied coordinator tablespace
+// directories on the standby host from the coordinator dbid to the standby
+// dbid.
+func RenameStandbyTablespaces(ctx context.Context, agentConns []*idl.Connection, source *greenplum.Cluster, intermediate *greenplum.Cluster) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		if conn.Hostname != intermediate.StandbyHostname() {
+			return nil
+		}
+
+		standby := intermediate.Standby()
+		var pairs []*idl.RenameTablespacesRequest_RenamePair
+		for oid, coordinatorTsInfo := range source.Tablespaces[int32(source.Coordinator().DbID)] {
+			if !coordinatorTsInfo.GetUserDefined() {
+				continue
+			}
+
+			standbyTsLocation := source.Tablespaces[int32(standby.DbID)][oid].GetLocation()
+			pairs = append(pairs, &idl.RenameTablespacesRequest_RenamePair{
+				Source:      filepath.Join(standbyTsLocation, strconv.Itoa(intermediate.Coordinator().DbID)),
+				Destination: filepath.Join(standbyTsLocation, strconv.Itoa(standby.DbID)),
+			})
+		}
+
+		if len(pairs) == 0 {
+			return nil
+		}
+
+		_, err := conn.AgentClient.RenameTablespaces(ctx, &idl.RenameTablespacesRequest{RenamePairs: pairs})
+		return err
+	}
+
+	return ExecuteRPC(ctx, agentConns, request)
+}
+
+func CreateRecoveryConfOnStandby(ctx context.Context, agentConns []*idl.Connection, intermediate *greenplum.Cluster) error {
+	user, err := utils.System.Current()
+	if err != nil {
+		return err
+	}
+
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		if conn.Hostname != intermediate.StandbyHostname() {
+			return nil
+		}
+
+		req := &idl.CreateRecoveryConfRequest{Connections: []*idl.CreateRecoveryConfRequest_Connection{{
+			MirrorDataDir: intermediate.StandbyDataDir(),
+			User:          user.Username,
+			PrimaryHost:   intermediate.CoordinatorHostname(),
+			PrimaryPort:   int32(intermediate.CoordinatorPort()),
+		}}}
+
+		_, err := conn.AgentClient.CreateRecoveryConf(ctx, req)
+		return err
+	}
+
+	return ExecuteRPC(ctx, agentConns, request)
+}
+
+func UpdateInternalAutoConfOnStandby(ctx context.Context, agentConns []*idl.Connection, intermediate *greenplum.Cluster) error {
+	request := func(ctx context.Context, conn *idl.Connection) error {
+		if conn.Hostname != intermediate.StandbyHostname() {
+			return nil
+		}
+
+		req := &idl.UpdateConfigurationRequest{Options: []*idl.UpdateFileConfOptions{{
+			Path:        filepath.Join(intermediate.StandbyDataDir(), "internal.auto.conf"),
+			Pattern:     fmt.Sprintf(`(^gp_dbid=)%d([^0-9]|$)`, intermediate.Coordinator().DbID),
+			Replacement: fmt.Sprintf(`\1%d\2`, intermediate.Standby().DbID),
+		}}}
+
+		_, err := conn.AgentClient.UpdateConfiguration(ctx, req)
+		return err
+	}
+
+	return ExecuteRPC(ctx, agentConns, request)
+}
@@ -0,0 +1,310 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"os/user"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+func mustCreateStandbyClusters(t *testing.T, sourceStandbyDir string, intermediateStandbyDir string) (*greenplum.Cluster, *greenplum.Cluster) {
+	t.Helper()
+
+	source := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg-1", Port: 15432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: sourceStandbyDir, Port: 16432, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg1", Port: 25433, Role: greenplum.PrimaryRole},
+	})
+	source.Tablespaces = testutils.CreateTablespaces()
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: "/data/qddir/seg.HqtFHX54y0o.-1", Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: intermediateStandbyDir, Port: 50433, Role: greenplum.MirrorRole},
+		{DbID: 3, ContentID: 0, Hostname: "sdw1", DataDir: "/data/dbfast1/seg.HqtFHX54y0o.1", Port: 50434, Role: greenplum.PrimaryRole},
+	})
+
+	return source, intermediate
+}
+
+func TestStandbyExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("couldn't create sqlmock: %v", err)
+	}
+	defer testutils.FinishMock(mock, t)
+
+	query := `SELECT COUNT\(\*\) FROM gp_segment_configuration WHERE content = -1 AND role = 'm';`
+
+	cases := []struct {
+		name     string
+		count    int
+		expected bool
+	}{
+		{name: "returns true when the standby is registered", count: 1, expected: true},
+		{name: "returns false when there is no standby", count: 0, expected: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(c.count))
+
+			exists, err := hub.StandbyExists(db)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			if exists != c.expected {
+				t.Errorf("got %t want %t", exists, c.expected)
+			}
+		})
+	}
+
+	t.Run("errors when querying fails", func(t *testing.T) {
+		expected := errors.New("connection failed")
+		mock.ExpectQuery(query).WillReturnError(expected)
+
+		_, err := hub.StandbyExists(db)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestRsyncStandbyDataDir(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	options := []string{"--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive"}
+
+	t.Run("copies the source and intermediate coordinator together when the standby is named like the coordinator", func(t *testing.T) {
+		source, intermediate := mustCreateStandbyClusters(t, "/data/standby/seg-1", "/data/standby/seg.HqtFHX54y0o.-1")
+
+		defer rsync.ResetRsyncCommand()
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+			expected := append(append([]string{}, options...), "/data/qddir/seg-1", "/data/qddir/seg.HqtFHX54y0o.-1", "standby:/data/standby")
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+
+		err := hub.RsyncStandbyDataDir(context.Background(), step.DevNullStream, source, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("copies only the intermediate coordinator when the standby is named differently", func(t *testing.T) {
+		source, intermediate := mustCreateStandbyClusters(t, "/data/standby", "/data/standby.HqtFHX54y0o")
+
+		defer rsync.ResetRsyncCommand()
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+			expected := append(append([]string{}, options...), "/data/qddir/seg.HqtFHX54y0o.-1/", "standby:/data/standby.HqtFHX54y0o")
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+
+		err := hub.RsyncStandbyDataDir(context.Background(), step.DevNullStream, source, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("errors when rsync fails", func(t *testing.T) {
+		source, intermediate := mustCreateStandbyClusters(t, "/data/standby", "/data/standby.HqtFHX54y0o")
+
+		defer rsync.ResetRsyncCommand()
+		rsync.SetRsyncCommand(exectest.NewCommand(hub.Failure))
+
+		err := hub.RsyncStandbyDataDir(context.Background(), step.DevNullStream, source, intermediate)
+		var rsyncErr rsync.RsyncError
+		if !errors.As(err, &rsyncErr) {
+			t.Errorf("got error %#v want type %T", err, rsyncErr)
+		}
+	})
+}
+
+func TestRsyncStandbyTablespaces(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	source, intermediate := mustCreateStandbyClusters(t, "/data/standby", "/data/standby.HqtFHX54y0o")
+
+	t.Run("copies the user defined coordinator tablespaces to the standby host", func(t *testing.T) {
+		calls := 0
+		defer rsync.ResetRsyncCommand()
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(hub.Success, func(utility string, args ...string) {
+			calls++
+
+			expected := []string{"--archive", "--delete", "--hard-links", "--size-only", "--no-inc-recursive",
+				"/tmp/user_ts/m/qddir/16384/", "standby:/tmp/user_ts/m/standby/16384"}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("got args %q want %q", args, expected)
+			}
+		}))
+
+		err := hub.RsyncStandbyTablespaces(context.Background(), step.DevNullStream, source, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("got %d rsync calls want 1", calls)
+		}
+	})
+}
+
+func TestUpgradeStandbyAgentRequests(t *testing.T) {
+	source, intermediate := mustCreateStandbyClusters(t, "/data/standby", "/data/standby.HqtFHX54y0o")
+
+	utils.System.Current = func() (*user.User, error) {
+		return &user.User{Username: "gpadmin"}, nil
+	}
+	defer func() {
+		utils.System.Current = user.Current
+	}()
+
+	t.Run("renames the standby tablespaces on the standby host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		standby := mock_idl.NewMockAgentClient(ctrl)
+		standby.EXPECT().RenameTablespaces(
+			gomock.Any(),
+			&idl.RenameTablespacesRequest{RenamePairs: []*idl.RenameTablespacesRequest_RenamePair{{
+				Source:      "/tmp/user_ts/m/standby/16384/1",
+				Destination: "/tmp/user_ts/m/standby/16384/2",
+			}}},
+		).Return(&idl.RenameTablespacesReply{}, nil)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: standby, Hostname: "standby"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.RenameStandbyTablespaces(context.Background(), agentConns, source, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("creates the recovery configuration on the standby host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		standby := mock_idl.NewMockAgentClient(ctrl)
+		standby.EXPECT().CreateRecoveryConf(
+			gomock.Any(),
+			&idl.CreateRecoveryConfRequest{Connections: []*idl.CreateRecoveryConfRequest_Connection{{
+				MirrorDataDir: "/data/standby.HqtFHX54y0o",
+				User:          "gpadmin",
+				PrimaryHost:   "coordinator",
+				PrimaryPort:   50432,
+			}}},
+		).Return(&idl.CreateRecoveryConfReply{}, nil)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: standby, Hostname: "standby"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hub.CreateRecoveryConfOnStandby(context.Background(), agentConns, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("updates the dbid of the standby", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		standby := mock_idl.NewMockAgentClient(ctrl)
+		standby.EXPECT().UpdateConfiguration(
+			gomock.Any(),
+			&idl.UpdateConfigurationRequest{Options: []*idl.UpdateFileConfOptions{{
+				Path:        "/data/standby.HqtFHX54y0o/internal.auto.conf",
+				Pattern:     `(^gp_dbid=)1([^0-9]|$)`,
+				Replacement: `\1` + "2" + `\2`,
+			}}},
+		).Return(&idl.UpdateConfigurationReply{}, nil)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: standby, Hostname: "standby"},
+		}
+
+		err := hub.UpdateInternalAutoConfOnStandby(context.Background(), agentConns, intermediate)
+		if err != nil {
+			t.Errorf("unexpected err %#v", err)
+		}
+	})
+
+	t.Run("returns agent errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("permission denied")
+		standby := mock_idl.NewMockAgentClient(ctrl)
+		standby.EXPECT().CreateRecoveryConf(gomock.Any(), gomock.Any()).Return(nil, expected)
+
+		agentConns := []*idl.Connection{
+			{AgentClient: standby, Hostname: "standby"},
+		}
+
+		err := hub.CreateRecoveryConfOnStandby(context.Background(), agentConns, intermediate)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
+
+func TestAddReplicationEntriesOnCoordinator(t *testing.T) {
+	utils.System.Current = func() (*user.User, error) {
+		return &user.User{Username: "gpadmin"}, nil
+	}
+	defer func() {
+		utils.System.Current = user.Current
+	}()
+
+	dir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, dir)
+
+	intermediate := hub.MustCreateCluster(t, greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Hostname: "coordinator", DataDir: dir, Port: 50432, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: -1, Hostname: "standby", DataDir: "/data/standby.HqtFHX54y0o", Port: 50433, Role: greenplum.MirrorRole},
+	})
+
+	path := filepath.Join(dir, "pg_hba.conf")
+	testutils.MustWriteToFile(t, path, "local all gpadmin ident\n")
+
+	err := hub.AddReplicationEntriesOnCoordinator(intermediate, false, utils.AnyAddressFamily)
+	if err != nil {
+		t.Fatalf("unexpected err %#v", err)
+	}
+
+	contents := testutils.MustReadFile(t, path)
+	expected := "local all gpadmin ident\n" + greenplum.ReplicationHBAEntries("gpadmin", []string{"standby"})
+	if contents != expected {
+		t.Errorf("got %q want %q", contents, expected)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+// VerifyTargetCluster compares the running target cluster against the source
+// cluster it was upgraded from. Any drift is written to streams and returned
+// rather than failing finalize since the upgrade itself has already completed.
+// For the same reason failing to verify is reported as drift.
+func VerifyTargetCluster(streams step.OutStreams, source *greenplum.Cluster, intermediate *greenplum.Cluster, target *greenplum.Cluster, upgradeID string, tablespaceMappings greenplum.TablespaceMappings) ([]string, error) {
+	drift, err := diffTarget(source, intermediate, target, upgradeID, tablespaceMappings)
+	if err != nil {
+		log.Printf("verifying target cluster: %v", err)
+		drift = append(drift, fmt.Sprintf("could not be verified: %v", err))
+	}
+
+	for _, difference := range drift {
+		log.Printf("Warning: target cluster %s", difference)
+		if _, err := fmt.Fprintf(streams.Stdout(), "Warning: target cluster %s\n", difference); err != nil {
+			return nil, err
+		}
+	}
+
+	return drift, nil
+}
+
+func diffTarget(source *greenplum.Cluster, intermediate *greenplum.Cluster, target *greenplum.Cluster, upgradeID string, tablespaceMappings greenplum.TablespaceMappings) (drift []string, err error) {
+	db, err := sql.Open("pgx", target.Connection())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	return greenplum.DiffTarget(db, target, source, intermediate, upgradeID, ArchivedSourceConfFile(intermediate), tablespaceMappings)
+}
+
+// ArchivedSourceConfFile is the postgresql.conf of the source coordinator
+// after finalize has renamed its data directory.
+func ArchivedSourceConfFile(intermediate *greenplum.Cluster) string {
+	return filepath.Join(intermediate.CoordinatorDataDir()+upgrade.OldSuffix, "postgresql.conf")
+}
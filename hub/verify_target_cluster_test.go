@@ -0,0 +1,40 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package hub_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestVerifyTargetCluster(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("reports the target as drifted rather than failing when it cannot be verified", func(t *testing.T) {
+		cluster := hub.MustCreateCluster(t, greenplum.SegConfigs{
+			{ContentID: -1, DbID: 1, Hostname: "localhost", Port: testutils.MustGetPort(t), DataDir: "/data/qddir/seg-1", Role: greenplum.PrimaryRole},
+		})
+
+		streams := new(step.BufferedStreams)
+		drift, err := hub.VerifyTargetCluster(streams, cluster, cluster, cluster, "ABC123", nil)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if len(drift) != 1 || !strings.HasPrefix(drift[0], "could not be verified: ") {
+			t.Errorf("got drift %q want it to not be verified", drift)
+		}
+
+		expected := "Warning: target cluster could not be verified: "
+		if !strings.Contains(streams.StdoutBuf.String(), expected) {
+			t.Errorf("expected stdout %q to contain %q", streams.StdoutBuf.String(), expected)
+		}
+	})
+}
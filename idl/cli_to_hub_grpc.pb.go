@@ -30,6 +30,9 @@ const (
 	CliToHub_GetConfig_FullMethodName               = "/idl.CliToHub/GetConfig"
 	CliToHub_RestartAgents_FullMethodName           = "/idl.CliToHub/RestartAgents"
 	CliToHub_StopServices_FullMethodName            = "/idl.CliToHub/StopServices"
+	CliToHub_Subscribe_FullMethodName               = "/idl.CliToHub/Subscribe"
+	CliToHub_Plan_FullMethodName                    = "/idl.CliToHub/Plan"
+	CliToHub_Cancel_FullMethodName                  = "/idl.CliToHub/Cancel"
 )
 
 // CliToHubClient is the client API for CliToHub service.
@@ -44,6 +47,9 @@ type CliToHubClient interface {
 	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigReply, error)
 	RestartAgents(ctx context.Context, in *RestartAgentsRequest, opts ...grpc.CallOption) (*RestartAgentsReply, error)
 	StopServices(ctx context.Context, in *StopServicesRequest, opts ...grpc.CallOption) (*StopServicesReply, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (CliToHub_SubscribeClient, error)
+	Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanReply, error)
+	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelReply, error)
 }
 
 type cliToHubClient struct {
@@ -241,6 +247,56 @@ func (c *cliToHubClient) StopServices(ctx context.Context, in *StopServicesReque
 	return out, nil
 }
 
+func (c *cliToHubClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (CliToHub_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CliToHub_ServiceDesc.Streams[5], CliToHub_Subscribe_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cliToHubSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CliToHub_SubscribeClient interface {
+	Recv() (*SubscribeReply, error)
+	grpc.ClientStream
+}
+
+type cliToHubSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *cliToHubSubscribeClient) Recv() (*SubscribeReply, error) {
+	m := new(SubscribeReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *cliToHubClient) Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanReply, error) {
+	out := new(PlanReply)
+	err := c.cc.Invoke(ctx, CliToHub_Plan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cliToHubClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelReply, error) {
+	out := new(CancelReply)
+	err := c.cc.Invoke(ctx, CliToHub_Cancel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CliToHubServer is the server API for CliToHub service.
 // All implementations should embed UnimplementedCliToHubServer
 // for forward compatibility
@@ -253,6 +309,9 @@ type CliToHubServer interface {
 	GetConfig(context.Context, *GetConfigRequest) (*GetConfigReply, error)
 	RestartAgents(context.Context, *RestartAgentsRequest) (*RestartAgentsReply, error)
 	StopServices(context.Context, *StopServicesRequest) (*StopServicesReply, error)
+	Subscribe(*SubscribeRequest, CliToHub_SubscribeServer) error
+	Plan(context.Context, *PlanRequest) (*PlanReply, error)
+	Cancel(context.Context, *CancelRequest) (*CancelReply, error)
 }
 
 // UnimplementedCliToHubServer should be embedded to have forward compatible implementations.
@@ -283,6 +342,15 @@ func (UnimplementedCliToHubServer) RestartAgents(context.Context, *RestartAgents
 func (UnimplementedCliToHubServer) StopServices(context.Context, *StopServicesRequest) (*StopServicesReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopServices not implemented")
 }
+func (UnimplementedCliToHubServer) Subscribe(*SubscribeRequest, CliToHub_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedCliToHubServer) Plan(context.Context, *PlanRequest) (*PlanReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Plan not implemented")
+}
+func (UnimplementedCliToHubServer) Cancel(context.Context, *CancelRequest) (*CancelReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
 
 // UnsafeCliToHubServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to CliToHubServer will
@@ -454,6 +522,63 @@ func _CliToHub_StopServices_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CliToHub_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CliToHubServer).Subscribe(m, &cliToHubSubscribeServer{stream})
+}
+
+type CliToHub_SubscribeServer interface {
+	Send(*SubscribeReply) error
+	grpc.ServerStream
+}
+
+type cliToHubSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *cliToHubSubscribeServer) Send(m *SubscribeReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CliToHub_Plan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliToHubServer).Plan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CliToHub_Plan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliToHubServer).Plan(ctx, req.(*PlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CliToHub_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliToHubServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CliToHub_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliToHubServer).Cancel(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CliToHub_ServiceDesc is the grpc.ServiceDesc for CliToHub service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -473,6 +598,14 @@ var CliToHub_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StopServices",
 			Handler:    _CliToHub_StopServices_Handler,
 		},
+		{
+			MethodName: "Plan",
+			Handler:    _CliToHub_Plan_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _CliToHub_Cancel_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -500,6 +633,11 @@ var CliToHub_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _CliToHub_Revert_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _CliToHub_Subscribe_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "cli_to_hub.proto",
 }
@@ -4,6 +4,8 @@
 package idl
 
 import (
+	"strings"
+
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	grpcStatus "google.golang.org/grpc/status"
@@ -21,13 +23,15 @@ type Connection struct {
 // do not  want to return an error since it is already stopped. However, there
 // is no clean way to differentiate based on the gRPC error code if a server is
 // already stopped versus generally unavailable. Thus, ignore the gRPC error
-// returned when trying to access an already stopped gRPC server.
+// returned when trying to access an already stopped gRPC server. The
+// connection is reset rather than closed when the server's process exits
+// before reading everything sent to it.
 // See https://github.com/grpc/grpc/blob/v1.56.2/doc/statuscodes.md
 func ServerAlreadyStopped(err error) bool {
 	errStatus := grpcStatus.Convert(err)
-	if errStatus.Code() == codes.Unavailable && errStatus.Message() == "error reading from server: EOF" {
-		return true
+	if errStatus.Code() != codes.Unavailable || !strings.HasPrefix(errStatus.Message(), "error reading from server: ") {
+		return false
 	}
 
-	return false
+	return errStatus.Message() == "error reading from server: EOF" || strings.HasSuffix(errStatus.Message(), "connection reset by peer")
 }
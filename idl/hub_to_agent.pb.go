@@ -69,7 +69,7 @@ func (x PgOptions_PgUpgradeMode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PgOptions_PgUpgradeMode.Descriptor instead.
 func (PgOptions_PgUpgradeMode) EnumDescriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{0, 0}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{2, 0}
 }
 
 type PgOptions_Action int32
@@ -118,7 +118,168 @@ func (x PgOptions_Action) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PgOptions_Action.Descriptor instead.
 func (PgOptions_Action) EnumDescriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{0, 1}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{2, 1}
+}
+
+type WatchDiskSpaceReply_Level int32
+
+const (
+	WatchDiskSpaceReply_unknown_level WatchDiskSpaceReply_Level = 0 // http://androiddevblog.com/protocol-buffers-pitfall-adding-enum-values/
+	WatchDiskSpaceReply_ok            WatchDiskSpaceReply_Level = 1
+	WatchDiskSpaceReply_warn          WatchDiskSpaceReply_Level = 2
+	WatchDiskSpaceReply_abort         WatchDiskSpaceReply_Level = 3
+)
+
+// Enum value maps for WatchDiskSpaceReply_Level.
+var (
+	WatchDiskSpaceReply_Level_name = map[int32]string{
+		0: "unknown_level",
+		1: "ok",
+		2: "warn",
+		3: "abort",
+	}
+	WatchDiskSpaceReply_Level_value = map[string]int32{
+		"unknown_level": 0,
+		"ok":            1,
+		"warn":          2,
+		"abort":         3,
+	}
+)
+
+func (x WatchDiskSpaceReply_Level) Enum() *WatchDiskSpaceReply_Level {
+	p := new(WatchDiskSpaceReply_Level)
+	*p = x
+	return p
+}
+
+func (x WatchDiskSpaceReply_Level) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchDiskSpaceReply_Level) Descriptor() protoreflect.EnumDescriptor {
+	return file_hub_to_agent_proto_enumTypes[2].Descriptor()
+}
+
+func (WatchDiskSpaceReply_Level) Type() protoreflect.EnumType {
+	return &file_hub_to_agent_proto_enumTypes[2]
+}
+
+func (x WatchDiskSpaceReply_Level) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchDiskSpaceReply_Level.Descriptor instead.
+func (WatchDiskSpaceReply_Level) EnumDescriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{27, 0}
+}
+
+type HandshakeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *HandshakeRequest) Reset() {
+	*x = HandshakeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HandshakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandshakeRequest) ProtoMessage() {}
+
+func (x *HandshakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandshakeRequest.ProtoReflect.Descriptor instead.
+func (*HandshakeRequest) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{0}
+}
+
+type HandshakeReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version     string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Commit      string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	IdlRevision string `protobuf:"bytes,3,opt,name=idlRevision,proto3" json:"idlRevision,omitempty"`
+	StateDir    string `protobuf:"bytes,4,opt,name=stateDir,proto3" json:"stateDir,omitempty"`
+}
+
+func (x *HandshakeReply) Reset() {
+	*x = HandshakeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HandshakeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandshakeReply) ProtoMessage() {}
+
+func (x *HandshakeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandshakeReply.ProtoReflect.Descriptor instead.
+func (*HandshakeReply) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HandshakeReply) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HandshakeReply) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *HandshakeReply) GetIdlRevision() string {
+	if x != nil {
+		return x.IdlRevision
+	}
+	return ""
+}
+
+func (x *HandshakeReply) GetStateDir() string {
+	if x != nil {
+		return x.StateDir
+	}
+	return ""
 }
 
 type PgOptions struct {
@@ -126,33 +287,34 @@ type PgOptions struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BackupDir           string                    `protobuf:"bytes,1,opt,name=backupDir,proto3" json:"backupDir,omitempty"`
-	PgUpgradeVerbose    bool                      `protobuf:"varint,2,opt,name=pgUpgradeVerbose,proto3" json:"pgUpgradeVerbose,omitempty"`
-	SkipPgUpgradeChecks bool                      `protobuf:"varint,3,opt,name=skipPgUpgradeChecks,proto3" json:"skipPgUpgradeChecks,omitempty"`
-	PgUpgradeJobs       string                    `protobuf:"bytes,4,opt,name=pgUpgradeJobs,proto3" json:"pgUpgradeJobs,omitempty"`
-	Action              PgOptions_Action          `protobuf:"varint,5,opt,name=action,proto3,enum=idl.PgOptions_Action" json:"action,omitempty"`
-	Role                string                    `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
-	ContentID           int32                     `protobuf:"varint,7,opt,name=contentID,proto3" json:"contentID,omitempty"`
-	PgUpgradeMode       PgOptions_PgUpgradeMode   `protobuf:"varint,8,opt,name=pgUpgradeMode,proto3,enum=idl.PgOptions_PgUpgradeMode" json:"pgUpgradeMode,omitempty"`
-	OldOptions          string                    `protobuf:"bytes,9,opt,name=oldOptions,proto3" json:"oldOptions,omitempty"`
-	Mode                Mode                      `protobuf:"varint,10,opt,name=mode,proto3,enum=idl.Mode" json:"mode,omitempty"`
-	TargetVersion       string                    `protobuf:"bytes,11,opt,name=targetVersion,proto3" json:"targetVersion,omitempty"`
-	OldBinDir           string                    `protobuf:"bytes,12,opt,name=oldBinDir,proto3" json:"oldBinDir,omitempty"`
-	OldDataDir          string                    `protobuf:"bytes,13,opt,name=oldDataDir,proto3" json:"oldDataDir,omitempty"`
-	OldPort             string                    `protobuf:"bytes,14,opt,name=oldPort,proto3" json:"oldPort,omitempty"`
-	OldDBID             string                    `protobuf:"bytes,15,opt,name=oldDBID,proto3" json:"oldDBID,omitempty"`
-	NewBinDir           string                    `protobuf:"bytes,16,opt,name=newBinDir,proto3" json:"newBinDir,omitempty"`
-	NewDataDir          string                    `protobuf:"bytes,17,opt,name=newDataDir,proto3" json:"newDataDir,omitempty"`
-	NewPort             string                    `protobuf:"bytes,18,opt,name=newPort,proto3" json:"newPort,omitempty"`
-	NewDBID             string                    `protobuf:"bytes,19,opt,name=newDBID,proto3" json:"newDBID,omitempty"`
-	Tablespaces         map[int32]*TablespaceInfo `protobuf:"bytes,20,rep,name=Tablespaces,proto3" json:"Tablespaces,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	PgUpgradeTimestamp  string                    `protobuf:"bytes,21,opt,name=pgUpgradeTimeStamp,proto3" json:"pgUpgradeTimeStamp,omitempty"`
+	BackupDir             string                    `protobuf:"bytes,1,opt,name=backupDir,proto3" json:"backupDir,omitempty"`
+	PgUpgradeVerbose      bool                      `protobuf:"varint,2,opt,name=pgUpgradeVerbose,proto3" json:"pgUpgradeVerbose,omitempty"`
+	SkipPgUpgradeChecks   bool                      `protobuf:"varint,3,opt,name=skipPgUpgradeChecks,proto3" json:"skipPgUpgradeChecks,omitempty"`
+	PgUpgradeJobs         string                    `protobuf:"bytes,4,opt,name=pgUpgradeJobs,proto3" json:"pgUpgradeJobs,omitempty"`
+	Action                PgOptions_Action          `protobuf:"varint,5,opt,name=action,proto3,enum=idl.PgOptions_Action" json:"action,omitempty"`
+	Role                  string                    `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	ContentID             int32                     `protobuf:"varint,7,opt,name=contentID,proto3" json:"contentID,omitempty"`
+	PgUpgradeMode         PgOptions_PgUpgradeMode   `protobuf:"varint,8,opt,name=pgUpgradeMode,proto3,enum=idl.PgOptions_PgUpgradeMode" json:"pgUpgradeMode,omitempty"`
+	OldOptions            string                    `protobuf:"bytes,9,opt,name=oldOptions,proto3" json:"oldOptions,omitempty"`
+	Mode                  Mode                      `protobuf:"varint,10,opt,name=mode,proto3,enum=idl.Mode" json:"mode,omitempty"`
+	TargetVersion         string                    `protobuf:"bytes,11,opt,name=targetVersion,proto3" json:"targetVersion,omitempty"`
+	OldBinDir             string                    `protobuf:"bytes,12,opt,name=oldBinDir,proto3" json:"oldBinDir,omitempty"`
+	OldDataDir            string                    `protobuf:"bytes,13,opt,name=oldDataDir,proto3" json:"oldDataDir,omitempty"`
+	OldPort               string                    `protobuf:"bytes,14,opt,name=oldPort,proto3" json:"oldPort,omitempty"`
+	OldDBID               string                    `protobuf:"bytes,15,opt,name=oldDBID,proto3" json:"oldDBID,omitempty"`
+	NewBinDir             string                    `protobuf:"bytes,16,opt,name=newBinDir,proto3" json:"newBinDir,omitempty"`
+	NewDataDir            string                    `protobuf:"bytes,17,opt,name=newDataDir,proto3" json:"newDataDir,omitempty"`
+	NewPort               string                    `protobuf:"bytes,18,opt,name=newPort,proto3" json:"newPort,omitempty"`
+	NewDBID               string                    `protobuf:"bytes,19,opt,name=newDBID,proto3" json:"newDBID,omitempty"`
+	Tablespaces           map[int32]*TablespaceInfo `protobuf:"bytes,20,rep,name=Tablespaces,proto3" json:"Tablespaces,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	PgUpgradeTimestamp    string                    `protobuf:"bytes,21,opt,name=pgUpgradeTimeStamp,proto3" json:"pgUpgradeTimeStamp,omitempty"`
+	TablespaceRelocations []*TablespaceRelocation   `protobuf:"bytes,22,rep,name=tablespaceRelocations,proto3" json:"tablespaceRelocations,omitempty"`
 }
 
 func (x *PgOptions) Reset() {
 	*x = PgOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[0]
+		mi := &file_hub_to_agent_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -165,7 +327,7 @@ func (x *PgOptions) String() string {
 func (*PgOptions) ProtoMessage() {}
 
 func (x *PgOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[0]
+	mi := &file_hub_to_agent_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -178,7 +340,7 @@ func (x *PgOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PgOptions.ProtoReflect.Descriptor instead.
 func (*PgOptions) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{0}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *PgOptions) GetBackupDir() string {
@@ -328,6 +490,13 @@ func (x *PgOptions) GetPgUpgradeTimeStamp() string {
 	return ""
 }
 
+func (x *PgOptions) GetTablespaceRelocations() []*TablespaceRelocation {
+	if x != nil {
+		return x.TablespaceRelocations
+	}
+	return nil
+}
+
 type TablespaceInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -340,7 +509,7 @@ type TablespaceInfo struct {
 func (x *TablespaceInfo) Reset() {
 	*x = TablespaceInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[1]
+		mi := &file_hub_to_agent_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -353,7 +522,7 @@ func (x *TablespaceInfo) String() string {
 func (*TablespaceInfo) ProtoMessage() {}
 
 func (x *TablespaceInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[1]
+	mi := &file_hub_to_agent_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -366,7 +535,7 @@ func (x *TablespaceInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TablespaceInfo.ProtoReflect.Descriptor instead.
 func (*TablespaceInfo) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{1}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *TablespaceInfo) GetLocation() string {
@@ -383,6 +552,71 @@ func (x *TablespaceInfo) GetUserDefined() bool {
 	return false
 }
 
+// TablespaceRelocation moves the target tablespace directory created by
+// pg_upgrade to a new location after the upgrade.
+type TablespaceRelocation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Oid         int32  `protobuf:"varint,1,opt,name=oid,proto3" json:"oid,omitempty"`
+	Source      string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Destination string `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+}
+
+func (x *TablespaceRelocation) Reset() {
+	*x = TablespaceRelocation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TablespaceRelocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TablespaceRelocation) ProtoMessage() {}
+
+func (x *TablespaceRelocation) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TablespaceRelocation.ProtoReflect.Descriptor instead.
+func (*TablespaceRelocation) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TablespaceRelocation) GetOid() int32 {
+	if x != nil {
+		return x.Oid
+	}
+	return 0
+}
+
+func (x *TablespaceRelocation) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *TablespaceRelocation) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
 type UpgradePrimariesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -395,7 +629,7 @@ type UpgradePrimariesRequest struct {
 func (x *UpgradePrimariesRequest) Reset() {
 	*x = UpgradePrimariesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[2]
+		mi := &file_hub_to_agent_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -408,7 +642,7 @@ func (x *UpgradePrimariesRequest) String() string {
 func (*UpgradePrimariesRequest) ProtoMessage() {}
 
 func (x *UpgradePrimariesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[2]
+	mi := &file_hub_to_agent_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -421,7 +655,7 @@ func (x *UpgradePrimariesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpgradePrimariesRequest.ProtoReflect.Descriptor instead.
 func (*UpgradePrimariesRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{2}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UpgradePrimariesRequest) GetAction() PgOptions_Action {
@@ -447,7 +681,7 @@ type UpgradePrimariesReply struct {
 func (x *UpgradePrimariesReply) Reset() {
 	*x = UpgradePrimariesReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[3]
+		mi := &file_hub_to_agent_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -460,7 +694,7 @@ func (x *UpgradePrimariesReply) String() string {
 func (*UpgradePrimariesReply) ProtoMessage() {}
 
 func (x *UpgradePrimariesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[3]
+	mi := &file_hub_to_agent_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -473,7 +707,7 @@ func (x *UpgradePrimariesReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpgradePrimariesReply.ProtoReflect.Descriptor instead.
 func (*UpgradePrimariesReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{3}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{6}
 }
 
 type CreateBackupDirectoryRequest struct {
@@ -487,7 +721,7 @@ type CreateBackupDirectoryRequest struct {
 func (x *CreateBackupDirectoryRequest) Reset() {
 	*x = CreateBackupDirectoryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[4]
+		mi := &file_hub_to_agent_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -500,7 +734,7 @@ func (x *CreateBackupDirectoryRequest) String() string {
 func (*CreateBackupDirectoryRequest) ProtoMessage() {}
 
 func (x *CreateBackupDirectoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[4]
+	mi := &file_hub_to_agent_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -513,7 +747,7 @@ func (x *CreateBackupDirectoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBackupDirectoryRequest.ProtoReflect.Descriptor instead.
 func (*CreateBackupDirectoryRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{4}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CreateBackupDirectoryRequest) GetBackupDir() string {
@@ -532,7 +766,7 @@ type CreateBackupDirectoryReply struct {
 func (x *CreateBackupDirectoryReply) Reset() {
 	*x = CreateBackupDirectoryReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[5]
+		mi := &file_hub_to_agent_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -545,7 +779,7 @@ func (x *CreateBackupDirectoryReply) String() string {
 func (*CreateBackupDirectoryReply) ProtoMessage() {}
 
 func (x *CreateBackupDirectoryReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[5]
+	mi := &file_hub_to_agent_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -558,7 +792,7 @@ func (x *CreateBackupDirectoryReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBackupDirectoryReply.ProtoReflect.Descriptor instead.
 func (*CreateBackupDirectoryReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{5}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{8}
 }
 
 type DeleteDataDirectoriesRequest struct {
@@ -572,7 +806,7 @@ type DeleteDataDirectoriesRequest struct {
 func (x *DeleteDataDirectoriesRequest) Reset() {
 	*x = DeleteDataDirectoriesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[6]
+		mi := &file_hub_to_agent_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -585,7 +819,7 @@ func (x *DeleteDataDirectoriesRequest) String() string {
 func (*DeleteDataDirectoriesRequest) ProtoMessage() {}
 
 func (x *DeleteDataDirectoriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[6]
+	mi := &file_hub_to_agent_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -598,7 +832,7 @@ func (x *DeleteDataDirectoriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteDataDirectoriesRequest.ProtoReflect.Descriptor instead.
 func (*DeleteDataDirectoriesRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{6}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeleteDataDirectoriesRequest) GetDatadirs() []string {
@@ -617,7 +851,7 @@ type DeleteDataDirectoriesReply struct {
 func (x *DeleteDataDirectoriesReply) Reset() {
 	*x = DeleteDataDirectoriesReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[7]
+		mi := &file_hub_to_agent_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -630,7 +864,7 @@ func (x *DeleteDataDirectoriesReply) String() string {
 func (*DeleteDataDirectoriesReply) ProtoMessage() {}
 
 func (x *DeleteDataDirectoriesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[7]
+	mi := &file_hub_to_agent_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -643,7 +877,7 @@ func (x *DeleteDataDirectoriesReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteDataDirectoriesReply.ProtoReflect.Descriptor instead.
 func (*DeleteDataDirectoriesReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{7}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{10}
 }
 
 type DeleteStateDirectoryRequest struct {
@@ -655,7 +889,7 @@ type DeleteStateDirectoryRequest struct {
 func (x *DeleteStateDirectoryRequest) Reset() {
 	*x = DeleteStateDirectoryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[8]
+		mi := &file_hub_to_agent_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -668,7 +902,7 @@ func (x *DeleteStateDirectoryRequest) String() string {
 func (*DeleteStateDirectoryRequest) ProtoMessage() {}
 
 func (x *DeleteStateDirectoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[8]
+	mi := &file_hub_to_agent_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -681,7 +915,7 @@ func (x *DeleteStateDirectoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteStateDirectoryRequest.ProtoReflect.Descriptor instead.
 func (*DeleteStateDirectoryRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{8}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{11}
 }
 
 type DeleteStateDirectoryReply struct {
@@ -693,7 +927,7 @@ type DeleteStateDirectoryReply struct {
 func (x *DeleteStateDirectoryReply) Reset() {
 	*x = DeleteStateDirectoryReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[9]
+		mi := &file_hub_to_agent_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -706,7 +940,7 @@ func (x *DeleteStateDirectoryReply) String() string {
 func (*DeleteStateDirectoryReply) ProtoMessage() {}
 
 func (x *DeleteStateDirectoryReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[9]
+	mi := &file_hub_to_agent_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -719,7 +953,7 @@ func (x *DeleteStateDirectoryReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteStateDirectoryReply.ProtoReflect.Descriptor instead.
 func (*DeleteStateDirectoryReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{9}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{12}
 }
 
 type DeleteBackupDirectoryRequest struct {
@@ -733,7 +967,7 @@ type DeleteBackupDirectoryRequest struct {
 func (x *DeleteBackupDirectoryRequest) Reset() {
 	*x = DeleteBackupDirectoryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[10]
+		mi := &file_hub_to_agent_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -746,7 +980,7 @@ func (x *DeleteBackupDirectoryRequest) String() string {
 func (*DeleteBackupDirectoryRequest) ProtoMessage() {}
 
 func (x *DeleteBackupDirectoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[10]
+	mi := &file_hub_to_agent_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -759,7 +993,7 @@ func (x *DeleteBackupDirectoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBackupDirectoryRequest.ProtoReflect.Descriptor instead.
 func (*DeleteBackupDirectoryRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{10}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DeleteBackupDirectoryRequest) GetBackupDir() string {
@@ -778,7 +1012,7 @@ type DeleteBackupDirectoryReply struct {
 func (x *DeleteBackupDirectoryReply) Reset() {
 	*x = DeleteBackupDirectoryReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[11]
+		mi := &file_hub_to_agent_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -791,7 +1025,7 @@ func (x *DeleteBackupDirectoryReply) String() string {
 func (*DeleteBackupDirectoryReply) ProtoMessage() {}
 
 func (x *DeleteBackupDirectoryReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[11]
+	mi := &file_hub_to_agent_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -804,7 +1038,7 @@ func (x *DeleteBackupDirectoryReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBackupDirectoryReply.ProtoReflect.Descriptor instead.
 func (*DeleteBackupDirectoryReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{11}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{14}
 }
 
 type DeleteTablespaceRequest struct {
@@ -818,7 +1052,7 @@ type DeleteTablespaceRequest struct {
 func (x *DeleteTablespaceRequest) Reset() {
 	*x = DeleteTablespaceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[12]
+		mi := &file_hub_to_agent_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -831,7 +1065,7 @@ func (x *DeleteTablespaceRequest) String() string {
 func (*DeleteTablespaceRequest) ProtoMessage() {}
 
 func (x *DeleteTablespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[12]
+	mi := &file_hub_to_agent_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -844,7 +1078,7 @@ func (x *DeleteTablespaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTablespaceRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTablespaceRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{12}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DeleteTablespaceRequest) GetDirs() []string {
@@ -863,7 +1097,7 @@ type DeleteTablespaceReply struct {
 func (x *DeleteTablespaceReply) Reset() {
 	*x = DeleteTablespaceReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[13]
+		mi := &file_hub_to_agent_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -876,7 +1110,7 @@ func (x *DeleteTablespaceReply) String() string {
 func (*DeleteTablespaceReply) ProtoMessage() {}
 
 func (x *DeleteTablespaceReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[13]
+	mi := &file_hub_to_agent_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -889,7 +1123,7 @@ func (x *DeleteTablespaceReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTablespaceReply.ProtoReflect.Descriptor instead.
 func (*DeleteTablespaceReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{13}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{16}
 }
 
 type ArchiveLogDirectoryRequest struct {
@@ -903,7 +1137,7 @@ type ArchiveLogDirectoryRequest struct {
 func (x *ArchiveLogDirectoryRequest) Reset() {
 	*x = ArchiveLogDirectoryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[14]
+		mi := &file_hub_to_agent_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -916,7 +1150,7 @@ func (x *ArchiveLogDirectoryRequest) String() string {
 func (*ArchiveLogDirectoryRequest) ProtoMessage() {}
 
 func (x *ArchiveLogDirectoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[14]
+	mi := &file_hub_to_agent_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -929,7 +1163,7 @@ func (x *ArchiveLogDirectoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ArchiveLogDirectoryRequest.ProtoReflect.Descriptor instead.
 func (*ArchiveLogDirectoryRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{14}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ArchiveLogDirectoryRequest) GetLogArchiveDir() string {
@@ -948,7 +1182,7 @@ type ArchiveLogDirectoryReply struct {
 func (x *ArchiveLogDirectoryReply) Reset() {
 	*x = ArchiveLogDirectoryReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[15]
+		mi := &file_hub_to_agent_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -961,7 +1195,7 @@ func (x *ArchiveLogDirectoryReply) String() string {
 func (*ArchiveLogDirectoryReply) ProtoMessage() {}
 
 func (x *ArchiveLogDirectoryReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[15]
+	mi := &file_hub_to_agent_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -974,7 +1208,7 @@ func (x *ArchiveLogDirectoryReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ArchiveLogDirectoryReply.ProtoReflect.Descriptor instead.
 func (*ArchiveLogDirectoryReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{15}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{18}
 }
 
 type RenameDirectories struct {
@@ -984,12 +1218,16 @@ type RenameDirectories struct {
 
 	Source string `protobuf:"bytes,1,opt,name=Source,proto3" json:"Source,omitempty"`
 	Target string `protobuf:"bytes,2,opt,name=Target,proto3" json:"Target,omitempty"`
+	// MoveOnly renames Source to Target rather than archiving Source and
+	// renaming Target to Source. It is used for mirrors that were re-laid out
+	// onto different hosts.
+	MoveOnly bool `protobuf:"varint,3,opt,name=MoveOnly,proto3" json:"MoveOnly,omitempty"`
 }
 
 func (x *RenameDirectories) Reset() {
 	*x = RenameDirectories{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[16]
+		mi := &file_hub_to_agent_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1002,7 +1240,7 @@ func (x *RenameDirectories) String() string {
 func (*RenameDirectories) ProtoMessage() {}
 
 func (x *RenameDirectories) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[16]
+	mi := &file_hub_to_agent_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,7 +1253,7 @@ func (x *RenameDirectories) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenameDirectories.ProtoReflect.Descriptor instead.
 func (*RenameDirectories) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{16}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *RenameDirectories) GetSource() string {
@@ -1032,6 +1270,13 @@ func (x *RenameDirectories) GetTarget() string {
 	return ""
 }
 
+func (x *RenameDirectories) GetMoveOnly() bool {
+	if x != nil {
+		return x.MoveOnly
+	}
+	return false
+}
+
 type RenameDirectoriesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1043,7 +1288,7 @@ type RenameDirectoriesRequest struct {
 func (x *RenameDirectoriesRequest) Reset() {
 	*x = RenameDirectoriesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[17]
+		mi := &file_hub_to_agent_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1056,7 +1301,7 @@ func (x *RenameDirectoriesRequest) String() string {
 func (*RenameDirectoriesRequest) ProtoMessage() {}
 
 func (x *RenameDirectoriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[17]
+	mi := &file_hub_to_agent_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1069,7 +1314,7 @@ func (x *RenameDirectoriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenameDirectoriesRequest.ProtoReflect.Descriptor instead.
 func (*RenameDirectoriesRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{17}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RenameDirectoriesRequest) GetDirs() []*RenameDirectories {
@@ -1088,7 +1333,7 @@ type RenameDirectoriesReply struct {
 func (x *RenameDirectoriesReply) Reset() {
 	*x = RenameDirectoriesReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[18]
+		mi := &file_hub_to_agent_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1101,7 +1346,7 @@ func (x *RenameDirectoriesReply) String() string {
 func (*RenameDirectoriesReply) ProtoMessage() {}
 
 func (x *RenameDirectoriesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[18]
+	mi := &file_hub_to_agent_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1114,7 +1359,7 @@ func (x *RenameDirectoriesReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenameDirectoriesReply.ProtoReflect.Descriptor instead.
 func (*RenameDirectoriesReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{18}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{21}
 }
 
 type StopAgentRequest struct {
@@ -1126,7 +1371,7 @@ type StopAgentRequest struct {
 func (x *StopAgentRequest) Reset() {
 	*x = StopAgentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[19]
+		mi := &file_hub_to_agent_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1139,7 +1384,7 @@ func (x *StopAgentRequest) String() string {
 func (*StopAgentRequest) ProtoMessage() {}
 
 func (x *StopAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[19]
+	mi := &file_hub_to_agent_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1152,7 +1397,7 @@ func (x *StopAgentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopAgentRequest.ProtoReflect.Descriptor instead.
 func (*StopAgentRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{19}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{22}
 }
 
 type StopAgentReply struct {
@@ -1164,7 +1409,7 @@ type StopAgentReply struct {
 func (x *StopAgentReply) Reset() {
 	*x = StopAgentReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[20]
+		mi := &file_hub_to_agent_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1177,7 +1422,7 @@ func (x *StopAgentReply) String() string {
 func (*StopAgentReply) ProtoMessage() {}
 
 func (x *StopAgentReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[20]
+	mi := &file_hub_to_agent_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1190,7 +1435,7 @@ func (x *StopAgentReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopAgentReply.ProtoReflect.Descriptor instead.
 func (*StopAgentReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{20}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{23}
 }
 
 type CheckSegmentDiskSpaceRequest struct {
@@ -1198,14 +1443,16 @@ type CheckSegmentDiskSpaceRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DiskFreeRatio float64  `protobuf:"fixed64,1,opt,name=diskFreeRatio,proto3" json:"diskFreeRatio,omitempty"`
-	Dirs          []string `protobuf:"bytes,2,rep,name=dirs,proto3" json:"dirs,omitempty"`
+	Dirs                 []string `protobuf:"bytes,2,rep,name=dirs,proto3" json:"dirs,omitempty"`
+	BackupDir            string   `protobuf:"bytes,3,opt,name=backupDir,proto3" json:"backupDir,omitempty"`
+	CoordinatorCopyBytes uint64   `protobuf:"varint,4,opt,name=coordinatorCopyBytes,proto3" json:"coordinatorCopyBytes,omitempty"`
+	Mode                 Mode     `protobuf:"varint,5,opt,name=mode,proto3,enum=idl.Mode" json:"mode,omitempty"`
 }
 
 func (x *CheckSegmentDiskSpaceRequest) Reset() {
 	*x = CheckSegmentDiskSpaceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[21]
+		mi := &file_hub_to_agent_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1218,7 +1465,7 @@ func (x *CheckSegmentDiskSpaceRequest) String() string {
 func (*CheckSegmentDiskSpaceRequest) ProtoMessage() {}
 
 func (x *CheckSegmentDiskSpaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[21]
+	mi := &file_hub_to_agent_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1231,21 +1478,35 @@ func (x *CheckSegmentDiskSpaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckSegmentDiskSpaceRequest.ProtoReflect.Descriptor instead.
 func (*CheckSegmentDiskSpaceRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{21}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CheckSegmentDiskSpaceRequest) GetDirs() []string {
+	if x != nil {
+		return x.Dirs
+	}
+	return nil
+}
+
+func (x *CheckSegmentDiskSpaceRequest) GetBackupDir() string {
+	if x != nil {
+		return x.BackupDir
+	}
+	return ""
 }
 
-func (x *CheckSegmentDiskSpaceRequest) GetDiskFreeRatio() float64 {
+func (x *CheckSegmentDiskSpaceRequest) GetCoordinatorCopyBytes() uint64 {
 	if x != nil {
-		return x.DiskFreeRatio
+		return x.CoordinatorCopyBytes
 	}
 	return 0
 }
 
-func (x *CheckSegmentDiskSpaceRequest) GetDirs() []string {
+func (x *CheckSegmentDiskSpaceRequest) GetMode() Mode {
 	if x != nil {
-		return x.Dirs
+		return x.Mode
 	}
-	return nil
+	return Mode_unknown_mode
 }
 
 type CheckDiskSpaceReply struct {
@@ -1259,7 +1520,7 @@ type CheckDiskSpaceReply struct {
 func (x *CheckDiskSpaceReply) Reset() {
 	*x = CheckDiskSpaceReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[22]
+		mi := &file_hub_to_agent_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1272,7 +1533,7 @@ func (x *CheckDiskSpaceReply) String() string {
 func (*CheckDiskSpaceReply) ProtoMessage() {}
 
 func (x *CheckDiskSpaceReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[22]
+	mi := &file_hub_to_agent_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1285,7 +1546,7 @@ func (x *CheckDiskSpaceReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckDiskSpaceReply.ProtoReflect.Descriptor instead.
 func (*CheckDiskSpaceReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{22}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *CheckDiskSpaceReply) GetUsages() []*CheckDiskSpaceReply_DiskUsage {
@@ -1295,6 +1556,180 @@ func (x *CheckDiskSpaceReply) GetUsages() []*CheckDiskSpaceReply_DiskUsage {
 	return nil
 }
 
+type WatchDiskSpaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dirs           []string `protobuf:"bytes,1,rep,name=dirs,proto3" json:"dirs,omitempty"`
+	WarnFreeRatio  float64  `protobuf:"fixed64,2,opt,name=warnFreeRatio,proto3" json:"warnFreeRatio,omitempty"`
+	AbortFreeRatio float64  `protobuf:"fixed64,3,opt,name=abortFreeRatio,proto3" json:"abortFreeRatio,omitempty"`
+	WarnFreeKB     uint64   `protobuf:"varint,4,opt,name=warnFreeKB,proto3" json:"warnFreeKB,omitempty"`
+	AbortFreeKB    uint64   `protobuf:"varint,5,opt,name=abortFreeKB,proto3" json:"abortFreeKB,omitempty"`
+}
+
+func (x *WatchDiskSpaceRequest) Reset() {
+	*x = WatchDiskSpaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchDiskSpaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDiskSpaceRequest) ProtoMessage() {}
+
+func (x *WatchDiskSpaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDiskSpaceRequest.ProtoReflect.Descriptor instead.
+func (*WatchDiskSpaceRequest) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WatchDiskSpaceRequest) GetDirs() []string {
+	if x != nil {
+		return x.Dirs
+	}
+	return nil
+}
+
+func (x *WatchDiskSpaceRequest) GetWarnFreeRatio() float64 {
+	if x != nil {
+		return x.WarnFreeRatio
+	}
+	return 0
+}
+
+func (x *WatchDiskSpaceRequest) GetAbortFreeRatio() float64 {
+	if x != nil {
+		return x.AbortFreeRatio
+	}
+	return 0
+}
+
+func (x *WatchDiskSpaceRequest) GetWarnFreeKB() uint64 {
+	if x != nil {
+		return x.WarnFreeKB
+	}
+	return 0
+}
+
+func (x *WatchDiskSpaceRequest) GetAbortFreeKB() uint64 {
+	if x != nil {
+		return x.AbortFreeKB
+	}
+	return 0
+}
+
+type WatchDiskSpaceReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level           WatchDiskSpaceReply_Level `protobuf:"varint,1,opt,name=level,proto3,enum=idl.WatchDiskSpaceReply_Level" json:"level,omitempty"`
+	Fs              string                    `protobuf:"bytes,2,opt,name=fs,proto3" json:"fs,omitempty"`
+	Host            string                    `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Available       uint64                    `protobuf:"varint,4,opt,name=available,proto3" json:"available,omitempty"`
+	Total           uint64                    `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	AvailableInodes uint64                    `protobuf:"varint,6,opt,name=availableInodes,proto3" json:"availableInodes,omitempty"`
+	TotalInodes     uint64                    `protobuf:"varint,7,opt,name=totalInodes,proto3" json:"totalInodes,omitempty"`
+}
+
+func (x *WatchDiskSpaceReply) Reset() {
+	*x = WatchDiskSpaceReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchDiskSpaceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDiskSpaceReply) ProtoMessage() {}
+
+func (x *WatchDiskSpaceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDiskSpaceReply.ProtoReflect.Descriptor instead.
+func (*WatchDiskSpaceReply) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WatchDiskSpaceReply) GetLevel() WatchDiskSpaceReply_Level {
+	if x != nil {
+		return x.Level
+	}
+	return WatchDiskSpaceReply_unknown_level
+}
+
+func (x *WatchDiskSpaceReply) GetFs() string {
+	if x != nil {
+		return x.Fs
+	}
+	return ""
+}
+
+func (x *WatchDiskSpaceReply) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *WatchDiskSpaceReply) GetAvailable() uint64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *WatchDiskSpaceReply) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *WatchDiskSpaceReply) GetAvailableInodes() uint64 {
+	if x != nil {
+		return x.AvailableInodes
+	}
+	return 0
+}
+
+func (x *WatchDiskSpaceReply) GetTotalInodes() uint64 {
+	if x != nil {
+		return x.TotalInodes
+	}
+	return 0
+}
+
 type RsyncRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1306,7 +1741,7 @@ type RsyncRequest struct {
 func (x *RsyncRequest) Reset() {
 	*x = RsyncRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[23]
+		mi := &file_hub_to_agent_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1319,7 +1754,7 @@ func (x *RsyncRequest) String() string {
 func (*RsyncRequest) ProtoMessage() {}
 
 func (x *RsyncRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[23]
+	mi := &file_hub_to_agent_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1332,7 +1767,7 @@ func (x *RsyncRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RsyncRequest.ProtoReflect.Descriptor instead.
 func (*RsyncRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{23}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *RsyncRequest) GetOptions() []*RsyncRequest_RsyncOptions {
@@ -1351,7 +1786,7 @@ type RsyncReply struct {
 func (x *RsyncReply) Reset() {
 	*x = RsyncReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[24]
+		mi := &file_hub_to_agent_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1364,7 +1799,7 @@ func (x *RsyncReply) String() string {
 func (*RsyncReply) ProtoMessage() {}
 
 func (x *RsyncReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[24]
+	mi := &file_hub_to_agent_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1377,7 +1812,7 @@ func (x *RsyncReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RsyncReply.ProtoReflect.Descriptor instead.
 func (*RsyncReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{24}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{29}
 }
 
 type RestorePgControlRequest struct {
@@ -1391,7 +1826,7 @@ type RestorePgControlRequest struct {
 func (x *RestorePgControlRequest) Reset() {
 	*x = RestorePgControlRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[25]
+		mi := &file_hub_to_agent_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1404,7 +1839,7 @@ func (x *RestorePgControlRequest) String() string {
 func (*RestorePgControlRequest) ProtoMessage() {}
 
 func (x *RestorePgControlRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[25]
+	mi := &file_hub_to_agent_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1417,7 +1852,7 @@ func (x *RestorePgControlRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RestorePgControlRequest.ProtoReflect.Descriptor instead.
 func (*RestorePgControlRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{25}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *RestorePgControlRequest) GetDatadirs() []string {
@@ -1436,7 +1871,7 @@ type RestorePgControlReply struct {
 func (x *RestorePgControlReply) Reset() {
 	*x = RestorePgControlReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[26]
+		mi := &file_hub_to_agent_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1449,7 +1884,7 @@ func (x *RestorePgControlReply) String() string {
 func (*RestorePgControlReply) ProtoMessage() {}
 
 func (x *RestorePgControlReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[26]
+	mi := &file_hub_to_agent_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1462,7 +1897,7 @@ func (x *RestorePgControlReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RestorePgControlReply.ProtoReflect.Descriptor instead.
 func (*RestorePgControlReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{26}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{31}
 }
 
 type UpdateFileConfOptions struct {
@@ -1478,7 +1913,7 @@ type UpdateFileConfOptions struct {
 func (x *UpdateFileConfOptions) Reset() {
 	*x = UpdateFileConfOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[27]
+		mi := &file_hub_to_agent_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1491,7 +1926,7 @@ func (x *UpdateFileConfOptions) String() string {
 func (*UpdateFileConfOptions) ProtoMessage() {}
 
 func (x *UpdateFileConfOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[27]
+	mi := &file_hub_to_agent_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1504,7 +1939,7 @@ func (x *UpdateFileConfOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateFileConfOptions.ProtoReflect.Descriptor instead.
 func (*UpdateFileConfOptions) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{27}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *UpdateFileConfOptions) GetPath() string {
@@ -1539,7 +1974,7 @@ type UpdateConfigurationRequest struct {
 func (x *UpdateConfigurationRequest) Reset() {
 	*x = UpdateConfigurationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[28]
+		mi := &file_hub_to_agent_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1552,7 +1987,7 @@ func (x *UpdateConfigurationRequest) String() string {
 func (*UpdateConfigurationRequest) ProtoMessage() {}
 
 func (x *UpdateConfigurationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[28]
+	mi := &file_hub_to_agent_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1565,7 +2000,7 @@ func (x *UpdateConfigurationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateConfigurationRequest.ProtoReflect.Descriptor instead.
 func (*UpdateConfigurationRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{28}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *UpdateConfigurationRequest) GetOptions() []*UpdateFileConfOptions {
@@ -1584,7 +2019,7 @@ type UpdateConfigurationReply struct {
 func (x *UpdateConfigurationReply) Reset() {
 	*x = UpdateConfigurationReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[29]
+		mi := &file_hub_to_agent_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1597,7 +2032,7 @@ func (x *UpdateConfigurationReply) String() string {
 func (*UpdateConfigurationReply) ProtoMessage() {}
 
 func (x *UpdateConfigurationReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[29]
+	mi := &file_hub_to_agent_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1610,7 +2045,7 @@ func (x *UpdateConfigurationReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateConfigurationReply.ProtoReflect.Descriptor instead.
 func (*UpdateConfigurationReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{29}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{34}
 }
 
 type RenameTablespacesRequest struct {
@@ -1624,7 +2059,7 @@ type RenameTablespacesRequest struct {
 func (x *RenameTablespacesRequest) Reset() {
 	*x = RenameTablespacesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[30]
+		mi := &file_hub_to_agent_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1637,7 +2072,7 @@ func (x *RenameTablespacesRequest) String() string {
 func (*RenameTablespacesRequest) ProtoMessage() {}
 
 func (x *RenameTablespacesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[30]
+	mi := &file_hub_to_agent_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1650,7 +2085,7 @@ func (x *RenameTablespacesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenameTablespacesRequest.ProtoReflect.Descriptor instead.
 func (*RenameTablespacesRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{30}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *RenameTablespacesRequest) GetRenamePairs() []*RenameTablespacesRequest_RenamePair {
@@ -1669,7 +2104,7 @@ type RenameTablespacesReply struct {
 func (x *RenameTablespacesReply) Reset() {
 	*x = RenameTablespacesReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[31]
+		mi := &file_hub_to_agent_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1682,7 +2117,7 @@ func (x *RenameTablespacesReply) String() string {
 func (*RenameTablespacesReply) ProtoMessage() {}
 
 func (x *RenameTablespacesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[31]
+	mi := &file_hub_to_agent_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1695,7 +2130,7 @@ func (x *RenameTablespacesReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenameTablespacesReply.ProtoReflect.Descriptor instead.
 func (*RenameTablespacesReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{31}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{36}
 }
 
 type CreateRecoveryConfRequest struct {
@@ -1709,7 +2144,7 @@ type CreateRecoveryConfRequest struct {
 func (x *CreateRecoveryConfRequest) Reset() {
 	*x = CreateRecoveryConfRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[32]
+		mi := &file_hub_to_agent_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1722,7 +2157,7 @@ func (x *CreateRecoveryConfRequest) String() string {
 func (*CreateRecoveryConfRequest) ProtoMessage() {}
 
 func (x *CreateRecoveryConfRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[32]
+	mi := &file_hub_to_agent_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1735,7 +2170,7 @@ func (x *CreateRecoveryConfRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRecoveryConfRequest.ProtoReflect.Descriptor instead.
 func (*CreateRecoveryConfRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{32}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *CreateRecoveryConfRequest) GetConnections() []*CreateRecoveryConfRequest_Connection {
@@ -1754,7 +2189,7 @@ type CreateRecoveryConfReply struct {
 func (x *CreateRecoveryConfReply) Reset() {
 	*x = CreateRecoveryConfReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[33]
+		mi := &file_hub_to_agent_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1767,7 +2202,7 @@ func (x *CreateRecoveryConfReply) String() string {
 func (*CreateRecoveryConfReply) ProtoMessage() {}
 
 func (x *CreateRecoveryConfReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[33]
+	mi := &file_hub_to_agent_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1780,34 +2215,668 @@ func (x *CreateRecoveryConfReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRecoveryConfReply.ProtoReflect.Descriptor instead.
 func (*CreateRecoveryConfReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{33}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{38}
 }
 
-type AddReplicationEntriesRequest struct {
+type BaseBackupMirrorsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Entries []*AddReplicationEntriesRequest_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	TargetBinDir string                                 `protobuf:"bytes,1,opt,name=targetBinDir,proto3" json:"targetBinDir,omitempty"`
+	Backups      []*BaseBackupMirrorsRequest_BaseBackup `protobuf:"bytes,2,rep,name=backups,proto3" json:"backups,omitempty"`
 }
 
-func (x *AddReplicationEntriesRequest) Reset() {
-	*x = AddReplicationEntriesRequest{}
+func (x *BaseBackupMirrorsRequest) Reset() {
+	*x = BaseBackupMirrorsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[34]
+		mi := &file_hub_to_agent_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AddReplicationEntriesRequest) String() string {
+func (x *BaseBackupMirrorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BaseBackupMirrorsRequest) ProtoMessage() {}
+
+func (x *BaseBackupMirrorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BaseBackupMirrorsRequest.ProtoReflect.Descriptor instead.
+func (*BaseBackupMirrorsRequest) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BaseBackupMirrorsRequest) GetTargetBinDir() string {
+	if x != nil {
+		return x.TargetBinDir
+	}
+	return ""
+}
+
+func (x *BaseBackupMirrorsRequest) GetBackups() []*BaseBackupMirrorsRequest_BaseBackup {
+	if x != nil {
+		return x.Backups
+	}
+	return nil
+}
+
+type BaseBackupMirrorsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BaseBackupMirrorsReply) Reset() {
+	*x = BaseBackupMirrorsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BaseBackupMirrorsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BaseBackupMirrorsReply) ProtoMessage() {}
+
+func (x *BaseBackupMirrorsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BaseBackupMirrorsReply.ProtoReflect.Descriptor instead.
+func (*BaseBackupMirrorsReply) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{40}
+}
+
+type AddReplicationEntriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*AddReplicationEntriesRequest_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *AddReplicationEntriesRequest) Reset() {
+	*x = AddReplicationEntriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddReplicationEntriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
 func (*AddReplicationEntriesRequest) ProtoMessage() {}
 
-func (x *AddReplicationEntriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[34]
+func (x *AddReplicationEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReplicationEntriesRequest.ProtoReflect.Descriptor instead.
+func (*AddReplicationEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AddReplicationEntriesRequest) GetEntries() []*AddReplicationEntriesRequest_Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type AddReplicationEntriesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AddReplicationEntriesReply) Reset() {
+	*x = AddReplicationEntriesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddReplicationEntriesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReplicationEntriesReply) ProtoMessage() {}
+
+func (x *AddReplicationEntriesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReplicationEntriesReply.ProtoReflect.Descriptor instead.
+func (*AddReplicationEntriesReply) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{42}
+}
+
+type GetEnvironmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gphomes []string `protobuf:"bytes,1,rep,name=gphomes,proto3" json:"gphomes,omitempty"`
+}
+
+func (x *GetEnvironmentRequest) Reset() {
+	*x = GetEnvironmentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEnvironmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEnvironmentRequest) ProtoMessage() {}
+
+func (x *GetEnvironmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEnvironmentRequest.ProtoReflect.Descriptor instead.
+func (*GetEnvironmentRequest) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetEnvironmentRequest) GetGphomes() []string {
+	if x != nil {
+		return x.Gphomes
+	}
+	return nil
+}
+
+type GetEnvironmentReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Report *EnvironmentReport `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+}
+
+func (x *GetEnvironmentReply) Reset() {
+	*x = GetEnvironmentReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEnvironmentReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEnvironmentReply) ProtoMessage() {}
+
+func (x *GetEnvironmentReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEnvironmentReply.ProtoReflect.Descriptor instead.
+func (*GetEnvironmentReply) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetEnvironmentReply) GetReport() *EnvironmentReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type EnvironmentReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hostname         string                      `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Environment      map[string]string           `protobuf:"bytes,2,rep,name=environment,proto3" json:"environment,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	LoginEnvironment map[string]string           `protobuf:"bytes,3,rep,name=loginEnvironment,proto3" json:"loginEnvironment,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Ulimits          []*EnvironmentReport_Ulimit `protobuf:"bytes,4,rep,name=ulimits,proto3" json:"ulimits,omitempty"`
+	Locale           string                      `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	UnixNano         int64                       `protobuf:"varint,6,opt,name=unixNano,proto3" json:"unixNano,omitempty"`
+	RsyncVersion     string                      `protobuf:"bytes,7,opt,name=rsyncVersion,proto3" json:"rsyncVersion,omitempty"`
+	SshVersion       string                      `protobuf:"bytes,8,opt,name=sshVersion,proto3" json:"sshVersion,omitempty"`
+	Gphomes          []*EnvironmentReport_Gphome `protobuf:"bytes,9,rep,name=gphomes,proto3" json:"gphomes,omitempty"`
+	Errors           []string                    `protobuf:"bytes,10,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *EnvironmentReport) Reset() {
+	*x = EnvironmentReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnvironmentReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvironmentReport) ProtoMessage() {}
+
+func (x *EnvironmentReport) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvironmentReport.ProtoReflect.Descriptor instead.
+func (*EnvironmentReport) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *EnvironmentReport) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *EnvironmentReport) GetEnvironment() map[string]string {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
+}
+
+func (x *EnvironmentReport) GetLoginEnvironment() map[string]string {
+	if x != nil {
+		return x.LoginEnvironment
+	}
+	return nil
+}
+
+func (x *EnvironmentReport) GetUlimits() []*EnvironmentReport_Ulimit {
+	if x != nil {
+		return x.Ulimits
+	}
+	return nil
+}
+
+func (x *EnvironmentReport) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *EnvironmentReport) GetUnixNano() int64 {
+	if x != nil {
+		return x.UnixNano
+	}
+	return 0
+}
+
+func (x *EnvironmentReport) GetRsyncVersion() string {
+	if x != nil {
+		return x.RsyncVersion
+	}
+	return ""
+}
+
+func (x *EnvironmentReport) GetSshVersion() string {
+	if x != nil {
+		return x.SshVersion
+	}
+	return ""
+}
+
+func (x *EnvironmentReport) GetGphomes() []*EnvironmentReport_Gphome {
+	if x != nil {
+		return x.Gphomes
+	}
+	return nil
+}
+
+func (x *EnvironmentReport) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type GetPostgresqlConfsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DataDirs []string `protobuf:"bytes,1,rep,name=dataDirs,proto3" json:"dataDirs,omitempty"`
+}
+
+func (x *GetPostgresqlConfsRequest) Reset() {
+	*x = GetPostgresqlConfsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPostgresqlConfsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostgresqlConfsRequest) ProtoMessage() {}
+
+func (x *GetPostgresqlConfsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostgresqlConfsRequest.ProtoReflect.Descriptor instead.
+func (*GetPostgresqlConfsRequest) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetPostgresqlConfsRequest) GetDataDirs() []string {
+	if x != nil {
+		return x.DataDirs
+	}
+	return nil
+}
+
+type GetPostgresqlConfsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Confs []*GetPostgresqlConfsReply_PostgresqlConf `protobuf:"bytes,1,rep,name=confs,proto3" json:"confs,omitempty"`
+}
+
+func (x *GetPostgresqlConfsReply) Reset() {
+	*x = GetPostgresqlConfsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPostgresqlConfsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostgresqlConfsReply) ProtoMessage() {}
+
+func (x *GetPostgresqlConfsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostgresqlConfsReply.ProtoReflect.Descriptor instead.
+func (*GetPostgresqlConfsReply) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetPostgresqlConfsReply) GetConfs() []*GetPostgresqlConfsReply_PostgresqlConf {
+	if x != nil {
+		return x.Confs
+	}
+	return nil
+}
+
+type PgHbaConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DataDir  string `protobuf:"bytes,1,opt,name=dataDir,proto3" json:"dataDir,omitempty"`
+	Contents string `protobuf:"bytes,2,opt,name=contents,proto3" json:"contents,omitempty"`
+}
+
+func (x *PgHbaConf) Reset() {
+	*x = PgHbaConf{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PgHbaConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PgHbaConf) ProtoMessage() {}
+
+func (x *PgHbaConf) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PgHbaConf.ProtoReflect.Descriptor instead.
+func (*PgHbaConf) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *PgHbaConf) GetDataDir() string {
+	if x != nil {
+		return x.DataDir
+	}
+	return ""
+}
+
+func (x *PgHbaConf) GetContents() string {
+	if x != nil {
+		return x.Contents
+	}
+	return ""
+}
+
+type GetPgHbaConfsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DataDirs []string `protobuf:"bytes,1,rep,name=dataDirs,proto3" json:"dataDirs,omitempty"`
+}
+
+func (x *GetPgHbaConfsRequest) Reset() {
+	*x = GetPgHbaConfsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPgHbaConfsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPgHbaConfsRequest) ProtoMessage() {}
+
+func (x *GetPgHbaConfsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPgHbaConfsRequest.ProtoReflect.Descriptor instead.
+func (*GetPgHbaConfsRequest) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetPgHbaConfsRequest) GetDataDirs() []string {
+	if x != nil {
+		return x.DataDirs
+	}
+	return nil
+}
+
+type GetPgHbaConfsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Confs []*PgHbaConf `protobuf:"bytes,1,rep,name=confs,proto3" json:"confs,omitempty"`
+}
+
+func (x *GetPgHbaConfsReply) Reset() {
+	*x = GetPgHbaConfsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPgHbaConfsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPgHbaConfsReply) ProtoMessage() {}
+
+func (x *GetPgHbaConfsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPgHbaConfsReply.ProtoReflect.Descriptor instead.
+func (*GetPgHbaConfsReply) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetPgHbaConfsReply) GetConfs() []*PgHbaConf {
+	if x != nil {
+		return x.Confs
+	}
+	return nil
+}
+
+type WritePgHbaConfsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Confs []*PgHbaConf `protobuf:"bytes,1,rep,name=confs,proto3" json:"confs,omitempty"`
+}
+
+func (x *WritePgHbaConfsRequest) Reset() {
+	*x = WritePgHbaConfsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WritePgHbaConfsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WritePgHbaConfsRequest) ProtoMessage() {}
+
+func (x *WritePgHbaConfsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1818,41 +2887,41 @@ func (x *AddReplicationEntriesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddReplicationEntriesRequest.ProtoReflect.Descriptor instead.
-func (*AddReplicationEntriesRequest) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use WritePgHbaConfsRequest.ProtoReflect.Descriptor instead.
+func (*WritePgHbaConfsRequest) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *AddReplicationEntriesRequest) GetEntries() []*AddReplicationEntriesRequest_Entry {
+func (x *WritePgHbaConfsRequest) GetConfs() []*PgHbaConf {
 	if x != nil {
-		return x.Entries
+		return x.Confs
 	}
 	return nil
 }
 
-type AddReplicationEntriesReply struct {
+type WritePgHbaConfsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *AddReplicationEntriesReply) Reset() {
-	*x = AddReplicationEntriesReply{}
+func (x *WritePgHbaConfsReply) Reset() {
+	*x = WritePgHbaConfsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[35]
+		mi := &file_hub_to_agent_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AddReplicationEntriesReply) String() string {
+func (x *WritePgHbaConfsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddReplicationEntriesReply) ProtoMessage() {}
+func (*WritePgHbaConfsReply) ProtoMessage() {}
 
-func (x *AddReplicationEntriesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[35]
+func (x *WritePgHbaConfsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1863,9 +2932,9 @@ func (x *AddReplicationEntriesReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddReplicationEntriesReply.ProtoReflect.Descriptor instead.
-func (*AddReplicationEntriesReply) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use WritePgHbaConfsReply.ProtoReflect.Descriptor instead.
+func (*WritePgHbaConfsReply) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{52}
 }
 
 type CheckDiskSpaceReply_DiskUsage struct {
@@ -1882,7 +2951,7 @@ type CheckDiskSpaceReply_DiskUsage struct {
 func (x *CheckDiskSpaceReply_DiskUsage) Reset() {
 	*x = CheckDiskSpaceReply_DiskUsage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[37]
+		mi := &file_hub_to_agent_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1895,7 +2964,7 @@ func (x *CheckDiskSpaceReply_DiskUsage) String() string {
 func (*CheckDiskSpaceReply_DiskUsage) ProtoMessage() {}
 
 func (x *CheckDiskSpaceReply_DiskUsage) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[37]
+	mi := &file_hub_to_agent_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1908,7 +2977,7 @@ func (x *CheckDiskSpaceReply_DiskUsage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckDiskSpaceReply_DiskUsage.ProtoReflect.Descriptor instead.
 func (*CheckDiskSpaceReply_DiskUsage) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{22, 0}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{25, 0}
 }
 
 func (x *CheckDiskSpaceReply_DiskUsage) GetFs() string {
@@ -1954,7 +3023,7 @@ type RsyncRequest_RsyncOptions struct {
 func (x *RsyncRequest_RsyncOptions) Reset() {
 	*x = RsyncRequest_RsyncOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[38]
+		mi := &file_hub_to_agent_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1967,7 +3036,7 @@ func (x *RsyncRequest_RsyncOptions) String() string {
 func (*RsyncRequest_RsyncOptions) ProtoMessage() {}
 
 func (x *RsyncRequest_RsyncOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[38]
+	mi := &file_hub_to_agent_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1980,7 +3049,7 @@ func (x *RsyncRequest_RsyncOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RsyncRequest_RsyncOptions.ProtoReflect.Descriptor instead.
 func (*RsyncRequest_RsyncOptions) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{23, 0}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{28, 0}
 }
 
 func (x *RsyncRequest_RsyncOptions) GetSources() []string {
@@ -2030,7 +3099,7 @@ type RenameTablespacesRequest_RenamePair struct {
 func (x *RenameTablespacesRequest_RenamePair) Reset() {
 	*x = RenameTablespacesRequest_RenamePair{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[39]
+		mi := &file_hub_to_agent_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2043,7 +3112,7 @@ func (x *RenameTablespacesRequest_RenamePair) String() string {
 func (*RenameTablespacesRequest_RenamePair) ProtoMessage() {}
 
 func (x *RenameTablespacesRequest_RenamePair) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[39]
+	mi := &file_hub_to_agent_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2056,7 +3125,7 @@ func (x *RenameTablespacesRequest_RenamePair) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use RenameTablespacesRequest_RenamePair.ProtoReflect.Descriptor instead.
 func (*RenameTablespacesRequest_RenamePair) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{30, 0}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{35, 0}
 }
 
 func (x *RenameTablespacesRequest_RenamePair) GetSource() string {
@@ -2087,7 +3156,7 @@ type CreateRecoveryConfRequest_Connection struct {
 func (x *CreateRecoveryConfRequest_Connection) Reset() {
 	*x = CreateRecoveryConfRequest_Connection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[40]
+		mi := &file_hub_to_agent_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2100,7 +3169,7 @@ func (x *CreateRecoveryConfRequest_Connection) String() string {
 func (*CreateRecoveryConfRequest_Connection) ProtoMessage() {}
 
 func (x *CreateRecoveryConfRequest_Connection) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[40]
+	mi := &file_hub_to_agent_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2113,7 +3182,7 @@ func (x *CreateRecoveryConfRequest_Connection) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use CreateRecoveryConfRequest_Connection.ProtoReflect.Descriptor instead.
 func (*CreateRecoveryConfRequest_Connection) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{32, 0}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{37, 0}
 }
 
 func (x *CreateRecoveryConfRequest_Connection) GetMirrorDataDir() string {
@@ -2144,6 +3213,77 @@ func (x *CreateRecoveryConfRequest_Connection) GetPrimaryPort() int32 {
 	return 0
 }
 
+type BaseBackupMirrorsRequest_BaseBackup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MirrorDataDir string `protobuf:"bytes,1,opt,name=mirrorDataDir,proto3" json:"mirrorDataDir,omitempty"`
+	MirrorDbId    int32  `protobuf:"varint,2,opt,name=mirrorDbId,proto3" json:"mirrorDbId,omitempty"`
+	PrimaryHost   string `protobuf:"bytes,3,opt,name=primaryHost,proto3" json:"primaryHost,omitempty"`
+	PrimaryPort   int32  `protobuf:"varint,4,opt,name=primaryPort,proto3" json:"primaryPort,omitempty"`
+}
+
+func (x *BaseBackupMirrorsRequest_BaseBackup) Reset() {
+	*x = BaseBackupMirrorsRequest_BaseBackup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BaseBackupMirrorsRequest_BaseBackup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BaseBackupMirrorsRequest_BaseBackup) ProtoMessage() {}
+
+func (x *BaseBackupMirrorsRequest_BaseBackup) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BaseBackupMirrorsRequest_BaseBackup.ProtoReflect.Descriptor instead.
+func (*BaseBackupMirrorsRequest_BaseBackup) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{39, 0}
+}
+
+func (x *BaseBackupMirrorsRequest_BaseBackup) GetMirrorDataDir() string {
+	if x != nil {
+		return x.MirrorDataDir
+	}
+	return ""
+}
+
+func (x *BaseBackupMirrorsRequest_BaseBackup) GetMirrorDbId() int32 {
+	if x != nil {
+		return x.MirrorDbId
+	}
+	return 0
+}
+
+func (x *BaseBackupMirrorsRequest_BaseBackup) GetPrimaryHost() string {
+	if x != nil {
+		return x.PrimaryHost
+	}
+	return ""
+}
+
+func (x *BaseBackupMirrorsRequest_BaseBackup) GetPrimaryPort() int32 {
+	if x != nil {
+		return x.PrimaryPort
+	}
+	return 0
+}
+
 type AddReplicationEntriesRequest_Entry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2157,7 +3297,7 @@ type AddReplicationEntriesRequest_Entry struct {
 func (x *AddReplicationEntriesRequest_Entry) Reset() {
 	*x = AddReplicationEntriesRequest_Entry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_to_agent_proto_msgTypes[41]
+		mi := &file_hub_to_agent_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2170,7 +3310,7 @@ func (x *AddReplicationEntriesRequest_Entry) String() string {
 func (*AddReplicationEntriesRequest_Entry) ProtoMessage() {}
 
 func (x *AddReplicationEntriesRequest_Entry) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_to_agent_proto_msgTypes[41]
+	mi := &file_hub_to_agent_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2183,7 +3323,7 @@ func (x *AddReplicationEntriesRequest_Entry) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use AddReplicationEntriesRequest_Entry.ProtoReflect.Descriptor instead.
 func (*AddReplicationEntriesRequest_Entry) Descriptor() ([]byte, []int) {
-	return file_hub_to_agent_proto_rawDescGZIP(), []int{34, 0}
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{41, 0}
 }
 
 func (x *AddReplicationEntriesRequest_Entry) GetDataDir() string {
@@ -2207,321 +3347,708 @@ func (x *AddReplicationEntriesRequest_Entry) GetHostAddrs() []string {
 	return nil
 }
 
+type EnvironmentReport_Ulimit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Soft uint64 `protobuf:"varint,2,opt,name=soft,proto3" json:"soft,omitempty"`
+	Hard uint64 `protobuf:"varint,3,opt,name=hard,proto3" json:"hard,omitempty"`
+}
+
+func (x *EnvironmentReport_Ulimit) Reset() {
+	*x = EnvironmentReport_Ulimit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnvironmentReport_Ulimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvironmentReport_Ulimit) ProtoMessage() {}
+
+func (x *EnvironmentReport_Ulimit) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvironmentReport_Ulimit.ProtoReflect.Descriptor instead.
+func (*EnvironmentReport_Ulimit) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{45, 0}
+}
+
+func (x *EnvironmentReport_Ulimit) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EnvironmentReport_Ulimit) GetSoft() uint64 {
+	if x != nil {
+		return x.Soft
+	}
+	return 0
+}
+
+func (x *EnvironmentReport_Ulimit) GetHard() uint64 {
+	if x != nil {
+		return x.Hard
+	}
+	return 0
+}
+
+type EnvironmentReport_Gphome struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path      string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Reachable bool   `protobuf:"varint,2,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	Error     string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *EnvironmentReport_Gphome) Reset() {
+	*x = EnvironmentReport_Gphome{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnvironmentReport_Gphome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvironmentReport_Gphome) ProtoMessage() {}
+
+func (x *EnvironmentReport_Gphome) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvironmentReport_Gphome.ProtoReflect.Descriptor instead.
+func (*EnvironmentReport_Gphome) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{45, 1}
+}
+
+func (x *EnvironmentReport_Gphome) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *EnvironmentReport_Gphome) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *EnvironmentReport_Gphome) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetPostgresqlConfsReply_PostgresqlConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DataDir string `protobuf:"bytes,1,opt,name=dataDir,proto3" json:"dataDir,omitempty"`
+	// settings of postgresql.conf overridden by postgresql.auto.conf
+	Settings map[string]string `protobuf:"bytes,2,rep,name=settings,proto3" json:"settings,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *GetPostgresqlConfsReply_PostgresqlConf) Reset() {
+	*x = GetPostgresqlConfsReply_PostgresqlConf{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_to_agent_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPostgresqlConfsReply_PostgresqlConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostgresqlConfsReply_PostgresqlConf) ProtoMessage() {}
+
+func (x *GetPostgresqlConfsReply_PostgresqlConf) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_to_agent_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostgresqlConfsReply_PostgresqlConf.ProtoReflect.Descriptor instead.
+func (*GetPostgresqlConfsReply_PostgresqlConf) Descriptor() ([]byte, []int) {
+	return file_hub_to_agent_proto_rawDescGZIP(), []int{47, 0}
+}
+
+func (x *GetPostgresqlConfsReply_PostgresqlConf) GetDataDir() string {
+	if x != nil {
+		return x.DataDir
+	}
+	return ""
+}
+
+func (x *GetPostgresqlConfsReply_PostgresqlConf) GetSettings() map[string]string {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
 var File_hub_to_agent_proto protoreflect.FileDescriptor
 
 var file_hub_to_agent_proto_rawDesc = []byte{
 	0x0a, 0x12, 0x68, 0x75, 0x62, 0x5f, 0x74, 0x6f, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x69, 0x64, 0x6c, 0x1a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe2, 0x07, 0x0a, 0x09, 0x50, 0x67, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44,
-	0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70,
-	0x44, 0x69, 0x72, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
-	0x56, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x70,
-	0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x56, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x12,
-	0x30, 0x0a, 0x13, 0x73, 0x6b, 0x69, 0x70, 0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x73, 0x6b,
-	0x69, 0x70, 0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x4a, 0x6f,
-	0x62, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72,
-	0x61, 0x64, 0x65, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x2d, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x50, 0x67,
-	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f,
-	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x42, 0x0a, 0x0d, 0x70, 0x67, 0x55, 0x70,
-	0x67, 0x72, 0x61, 0x64, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x1c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x50, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
-	0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0d, 0x70,
-	0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
-	0x6f, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x04,
-	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x69, 0x64, 0x6c,
-	0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x6c, 0x64, 0x42, 0x69, 0x6e, 0x44, 0x69, 0x72, 0x18, 0x0c,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x6c, 0x64, 0x42, 0x69, 0x6e, 0x44, 0x69, 0x72, 0x12,
-	0x1e, 0x0a, 0x0a, 0x6f, 0x6c, 0x64, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x18, 0x0d, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x12,
-	0x18, 0x0a, 0x07, 0x6f, 0x6c, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6f, 0x6c, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x6c, 0x64,
-	0x44, 0x42, 0x49, 0x44, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x6c, 0x64, 0x44,
-	0x42, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x42, 0x69, 0x6e, 0x44, 0x69, 0x72,
-	0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x42, 0x69, 0x6e, 0x44, 0x69,
-	0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x18,
-	0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x77, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69,
-	0x72, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x12, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e,
-	0x65, 0x77, 0x44, 0x42, 0x49, 0x44, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65,
-	0x77, 0x44, 0x42, 0x49, 0x44, 0x12, 0x41, 0x0a, 0x0b, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x64, 0x6c,
-	0x2e, 0x50, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x54, 0x61, 0x62,
-	0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x70, 0x67, 0x55, 0x70,
-	0x67, 0x72, 0x61, 0x64, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x15,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x54,
-	0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x1a, 0x53, 0x0a, 0x10, 0x54, 0x61, 0x62, 0x6c,
-	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x69, 0x64, 0x6c, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x6e,
-	0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x47, 0x0a,
-	0x0d, 0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x19,
-	0x0a, 0x15, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72,
-	0x61, 0x64, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x64, 0x69, 0x73,
-	0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x73, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x10, 0x02, 0x22, 0x34, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x12, 0x0a, 0x0e, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x10,
-	0x01, 0x12, 0x09, 0x0a, 0x05, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x10, 0x02, 0x22, 0x4e, 0x0a, 0x0e,
-	0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a,
-	0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x55, 0x73,
-	0x65, 0x72, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0b, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64, 0x22, 0x6c, 0x0a, 0x17,
-	0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x50, 0x67,
-	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x50, 0x67, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x55, 0x70,
-	0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x3c, 0x0a, 0x1c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63,
-	0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69,
-	0x72, 0x22, 0x1c, 0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75,
-	0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x3a, 0x0a, 0x1c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x64, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x64, 0x69, 0x72, 0x73, 0x22, 0x1c, 0x0a, 0x1a, 0x44,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x12, 0x0a, 0x10, 0x48, 0x61, 0x6e, 0x64, 0x73,
+	0x68, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x0e,
+	0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x69, 0x64, 0x6c, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x64, 0x6c, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x22, 0xb3,
+	0x08, 0x0a, 0x09, 0x50, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x67,
+	0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x56, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x56,
+	0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x6b, 0x69, 0x70, 0x50, 0x67,
+	0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x13, 0x73, 0x6b, 0x69, 0x70, 0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x67, 0x55, 0x70,
+	0x67, 0x72, 0x61, 0x64, 0x65, 0x4a, 0x6f, 0x62, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x2d,
+	0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x50, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12,
+	0x42, 0x0a, 0x0d, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x4d, 0x6f, 0x64, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x50, 0x67, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0d, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x09, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x6c, 0x64, 0x42,
+	0x69, 0x6e, 0x44, 0x69, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x6c, 0x64,
+	0x42, 0x69, 0x6e, 0x44, 0x69, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x6c, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x44, 0x69, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x6c, 0x64, 0x50, 0x6f, 0x72,
+	0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x6c, 0x64, 0x50, 0x6f, 0x72, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x6f, 0x6c, 0x64, 0x44, 0x42, 0x49, 0x44, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6f, 0x6c, 0x64, 0x44, 0x42, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65,
+	0x77, 0x42, 0x69, 0x6e, 0x44, 0x69, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
+	0x65, 0x77, 0x42, 0x69, 0x6e, 0x44, 0x69, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x44,
+	0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65,
+	0x77, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x50,
+	0x6f, 0x72, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x50, 0x6f,
+	0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x44, 0x42, 0x49, 0x44, 0x18, 0x13, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x44, 0x42, 0x49, 0x44, 0x12, 0x41, 0x0a, 0x0b,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x50, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0b, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12,
+	0x2e, 0x0a, 0x12, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x54, 0x69, 0x6d, 0x65,
+	0x53, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x67, 0x55,
+	0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x4f, 0x0a, 0x15, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52,
+	0x65, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x15, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x1a, 0x53, 0x0a, 0x10, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x47, 0x0a, 0x0d, 0x50, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x5f, 0x70, 0x67, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x10,
+	0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x10,
+	0x01, 0x12, 0x0b, 0x0a, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x10, 0x02, 0x22, 0x34,
+	0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x0e, 0x75, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07,
+	0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x10, 0x02, 0x22, 0x4e, 0x0a, 0x0e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x66,
+	0x69, 0x6e, 0x65, 0x64, 0x22, 0x62, 0x0a, 0x14, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x52, 0x65, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x6f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6f, 0x69, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x6c, 0x0a, 0x17, 0x55, 0x70, 0x67, 0x72,
+	0x61, 0x64, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x50, 0x67, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x50, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x3c, 0x0a, 0x1c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x22, 0x1c, 0x0a,
+	0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x3a, 0x0a, 0x1c, 0x44,
 	0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
-	0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x1d, 0x0a, 0x1b, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x1b, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x3c, 0x0a, 0x1c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42,
-	0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44,
-	0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70,
-	0x44, 0x69, 0x72, 0x22, 0x1c, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x61, 0x63,
-	0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x2d, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x69, 0x72, 0x73,
-	0x22, 0x17, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x42, 0x0a, 0x1a, 0x41, 0x72, 0x63,
+	0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x61, 0x74, 0x61, 0x64, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x64,
+	0x61, 0x74, 0x61, 0x64, 0x69, 0x72, 0x73, 0x22, 0x1c, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x1d, 0x0a, 0x1b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x1b, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x3c, 0x0a, 0x1c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75,
+	0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x22,
+	0x1c, 0x0a, 0x1a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x2d, 0x0a,
+	0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x69, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x69, 0x72, 0x73, 0x22, 0x17, 0x0a, 0x15,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x42, 0x0a, 0x1a, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
+	0x4c, 0x6f, 0x67, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76,
+	0x65, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x6f, 0x67, 0x41,
+	0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x44, 0x69, 0x72, 0x22, 0x1a, 0x0a, 0x18, 0x41, 0x72, 0x63,
 	0x68, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x67, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x41, 0x72,
-	0x63, 0x68, 0x69, 0x76, 0x65, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
-	0x6c, 0x6f, 0x67, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x44, 0x69, 0x72, 0x22, 0x1a, 0x0a,
-	0x18, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x67, 0x44, 0x69, 0x72, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x43, 0x0a, 0x11, 0x52, 0x65, 0x6e,
-	0x61, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x16,
-	0x0a, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x46,
-	0x0a, 0x18, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x44, 0x69,
-	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52,
-	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73,
-	0x52, 0x04, 0x44, 0x69, 0x72, 0x73, 0x22, 0x18, 0x0a, 0x16, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65,
-	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x12, 0x0a, 0x10, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x22, 0x10, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e,
-	0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x58, 0x0a, 0x1c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53,
-	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x6b, 0x46, 0x72,
-	0x65, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x64,
-	0x69, 0x73, 0x6b, 0x46, 0x72, 0x65, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x69, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x69, 0x72, 0x73,
-	0x22, 0xbc, 0x01, 0x0a, 0x13, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70,
-	0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x06, 0x75, 0x73, 0x61, 0x67,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43,
-	0x68, 0x65, 0x63, 0x6b, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x75, 0x73,
-	0x61, 0x67, 0x65, 0x73, 0x1a, 0x69, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x66,
-	0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
-	0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
-	0x62, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x22,
-	0xff, 0x01, 0x0a, 0x0c, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x38, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1e, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0xb4, 0x01, 0x0a, 0x0c, 0x52,
-	0x73, 0x79, 0x6e, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
-	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x6f, 0x73, 0x74, 0x12,
-	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x65,
-	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65,
-	0x73, 0x22, 0x0c, 0x0a, 0x0a, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x35, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x67, 0x43, 0x6f, 0x6e, 0x74,
-	0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61,
-	0x74, 0x61, 0x64, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61,
-	0x74, 0x61, 0x64, 0x69, 0x72, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x50, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x67, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e,
-	0x66, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07,
-	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70,
-	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x70,
-	0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x52, 0x0a, 0x1a, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x1a, 0x0a, 0x18,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xae, 0x01, 0x0a, 0x18, 0x52, 0x65, 0x6e,
-	0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4a, 0x0a, 0x0b, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x50,
-	0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x69, 0x64, 0x6c,
-	0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65,
-	0x50, 0x61, 0x69, 0x72, 0x52, 0x0b, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x50, 0x61, 0x69, 0x72,
-	0x73, 0x1a, 0x46, 0x0a, 0x0a, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x50, 0x61, 0x69, 0x72, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69,
-	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
-	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x18, 0x0a, 0x16, 0x52, 0x65, 0x6e,
-	0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0xf5, 0x01, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x4b, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x8a,
-	0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a,
-	0x0d, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61,
-	0x44, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61,
-	0x72, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72,
-	0x69, 0x6d, 0x61, 0x72, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69,
-	0x6d, 0x61, 0x72, 0x79, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
-	0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x19, 0x0a, 0x17, 0x43,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x5f, 0x0a, 0x11, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4d, 0x6f,
+	0x76, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x4d, 0x6f,
+	0x76, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x46, 0x0a, 0x18, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x44, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x04, 0x44, 0x69, 0x72, 0x73, 0x22, 0x18,
+	0x0a, 0x16, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x12, 0x0a, 0x10, 0x53, 0x74, 0x6f, 0x70,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x10, 0x0a, 0x0e,
+	0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xb8,
+	0x01, 0x0a, 0x1c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x44,
+	0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x69, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64,
+	0x69, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69,
+	0x72, 0x12, 0x32, 0x0a, 0x14, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x43, 0x6f, 0x70, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x14, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x70, 0x79,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x52, 0x0d, 0x64, 0x69, 0x73, 0x6b,
+	0x46, 0x72, 0x65, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x22, 0xbc, 0x01, 0x0a, 0x13, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x3a, 0x0a, 0x06, 0x75, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x69, 0x73,
+	0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x6b,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x75, 0x73, 0x61, 0x67, 0x65, 0x73, 0x1a, 0x69, 0x0a,
+	0x09, 0x44, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x66, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x22, 0xbb, 0x01, 0x0a, 0x15, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x64, 0x69, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x77, 0x61, 0x72, 0x6e, 0x46, 0x72,
+	0x65, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x77,
+	0x61, 0x72, 0x6e, 0x46, 0x72, 0x65, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x26, 0x0a, 0x0e,
+	0x61, 0x62, 0x6f, 0x72, 0x74, 0x46, 0x72, 0x65, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x46, 0x72, 0x65, 0x65, 0x52,
+	0x61, 0x74, 0x69, 0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x61, 0x72, 0x6e, 0x46, 0x72, 0x65, 0x65,
+	0x4b, 0x42, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x77, 0x61, 0x72, 0x6e, 0x46, 0x72,
+	0x65, 0x65, 0x4b, 0x42, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x46, 0x72, 0x65,
+	0x65, 0x4b, 0x42, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x61, 0x62, 0x6f, 0x72, 0x74,
+	0x46, 0x72, 0x65, 0x65, 0x4b, 0x42, 0x22, 0xa8, 0x02, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x34,
+	0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e,
+	0x69, 0x64, 0x6c, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61,
+	0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x66, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x76, 0x61, 0x69,
+	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x28, 0x0a, 0x0f,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x49, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x49,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x49, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x37, 0x0a, 0x05, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x12, 0x11, 0x0a, 0x0d, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x6f, 0x6b, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04,
+	0x77, 0x61, 0x72, 0x6e, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x10,
+	0x03, 0x22, 0xff, 0x01, 0x0a, 0x0c, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0xb4, 0x01, 0x0a,
+	0x0c, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x6f, 0x73,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a,
+	0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x22, 0x0c, 0x0a, 0x0a, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x35, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x67, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x61, 0x74, 0x61, 0x64, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x61, 0x74, 0x61, 0x64, 0x69, 0x72, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x50, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x67, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x52, 0x0a, 0x1a, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x1a,
+	0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xae, 0x01, 0x0a, 0x18, 0x52,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4a, 0x0a, 0x0b, 0x72, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x50, 0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x50, 0x61, 0x69, 0x72, 0x52, 0x0b, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x50, 0x61,
+	0x69, 0x72, 0x73, 0x1a, 0x46, 0x0a, 0x0a, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x50, 0x61, 0x69,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x18, 0x0a, 0x16, 0x52,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xf5, 0x01, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43,
 	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e,
-	0x66, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xb6, 0x01, 0x0a, 0x1c, 0x41, 0x64, 0x64, 0x52, 0x65,
-	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x41,
-	0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74,
-	0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x53, 0x0a, 0x05, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x12, 0x12, 0x0a,
-	0x04, 0x55, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x55, 0x73, 0x65,
-	0x72, 0x12, 0x1c, 0x0a, 0x09, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x64, 0x64, 0x72, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x64, 0x64, 0x72, 0x73, 0x22,
-	0x1c, 0x0a, 0x1a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x32, 0x9f, 0x0b,
-	0x0a, 0x05, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x5d, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
-	0x12, 0x21, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63,
-	0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44,
-	0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x21, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43,
-	0x68, 0x65, 0x63, 0x6b, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x53,
-	0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x69, 0x64,
-	0x6c, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x10, 0x55, 0x70, 0x67, 0x72, 0x61,
-	0x64, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x69, 0x64,
-	0x6c, 0x2e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69,
-	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
-	0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x11, 0x52, 0x65, 0x6e, 0x61, 0x6d,
-	0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x69,
-	0x64, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
-	0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x64,
-	0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x09, 0x53, 0x74,
-	0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74,
-	0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
-	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44,
-	0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x21,
-	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x44,
-	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61,
-	0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x61,
-	0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x21, 0x2e,
-	0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70,
-	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x61, 0x63,
-	0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x20, 0x2e, 0x69, 0x64,
-	0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
-	0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44,
+	0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x1a, 0x8a, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x24, 0x0a, 0x0d, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x61,
+	0x74, 0x61, 0x44, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69,
+	0x6d, 0x61, 0x72, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70,
+	0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x19, 0x0a,
+	0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43,
+	0x6f, 0x6e, 0x66, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x9b, 0x02, 0x0a, 0x18, 0x42, 0x61, 0x73,
+	0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42,
+	0x69, 0x6e, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x42, 0x69, 0x6e, 0x44, 0x69, 0x72, 0x12, 0x42, 0x0a, 0x07, 0x62, 0x61, 0x63,
+	0x6b, 0x75, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x42, 0x61, 0x73, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4d, 0x69, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x42, 0x61,
+	0x63, 0x6b, 0x75, 0x70, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x1a, 0x96, 0x01,
+	0x0a, 0x0a, 0x42, 0x61, 0x73, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x24, 0x0a, 0x0d,
+	0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x61, 0x74, 0x61, 0x44,
+	0x69, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x62, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x62,
+	0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x48, 0x6f, 0x73,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79,
+	0x48, 0x6f, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x50,
+	0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61,
+	0x72, 0x79, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x18, 0x0a, 0x16, 0x42, 0x61, 0x73, 0x65, 0x42, 0x61,
+	0x63, 0x6b, 0x75, 0x70, 0x4d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0xb6, 0x01, 0x0a, 0x1c, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x41, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x1a, 0x53, 0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x18, 0x0a,
+	0x07, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x48,
+	0x6f, 0x73, 0x74, 0x41, 0x64, 0x64, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x48, 0x6f, 0x73, 0x74, 0x41, 0x64, 0x64, 0x72, 0x73, 0x22, 0x1c, 0x0a, 0x1a, 0x41, 0x64, 0x64,
+	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x31, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x45, 0x6e,
+	0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x67, 0x70, 0x68, 0x6f, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x67, 0x70, 0x68, 0x6f, 0x6d, 0x65, 0x73, 0x22, 0x45, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x22, 0xf3, 0x05, 0x0a, 0x11, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x49, 0x0a, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x45,
+	0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x58,
+	0x0a, 0x10, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x45,
+	0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x45, 0x6e, 0x76,
+	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x37, 0x0a, 0x07, 0x75, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
+	0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x2e, 0x55, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x07, 0x75, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x69,
+	0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x6e, 0x69,
+	0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x73, 0x79, 0x6e, 0x63, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x73, 0x79,
+	0x6e, 0x63, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x73, 0x68,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73,
+	0x73, 0x68, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x07, 0x67, 0x70, 0x68,
+	0x6f, 0x6d, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x47, 0x70, 0x68, 0x6f, 0x6d, 0x65, 0x52, 0x07, 0x67, 0x70, 0x68, 0x6f, 0x6d,
+	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x1a, 0x44, 0x0a, 0x06, 0x55, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x66, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x6f, 0x66, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x61, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x68, 0x61, 0x72, 0x64,
+	0x1a, 0x50, 0x0a, 0x06, 0x47, 0x70, 0x68, 0x6f, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x1a, 0x3e, 0x0a, 0x10, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x43, 0x0a, 0x15, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x45, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x37, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x50, 0x6f,
+	0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x71, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x73,
+	0x22, 0x9d, 0x02, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73,
+	0x71, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x41, 0x0a, 0x05,
+	0x63, 0x6f, 0x6e, 0x66, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x69, 0x64,
+	0x6c, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x71, 0x6c, 0x43,
+	0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72,
+	0x65, 0x73, 0x71, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x05, 0x63, 0x6f, 0x6e, 0x66, 0x73, 0x1a,
+	0xbe, 0x01, 0x0a, 0x0e, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x71, 0x6c, 0x43, 0x6f,
+	0x6e, 0x66, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x12, 0x55, 0x0a, 0x08,
+	0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73,
+	0x71, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x50, 0x6f, 0x73,
+	0x74, 0x67, 0x72, 0x65, 0x73, 0x71, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x41, 0x0a, 0x09, 0x50, 0x67, 0x48, 0x62, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x18, 0x0a,
+	0x07, 0x64, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x64, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0x32, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x50, 0x67, 0x48, 0x62, 0x61, 0x43,
+	0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x64,
+	0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x73, 0x22, 0x3a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x50, 0x67,
+	0x48, 0x62, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x24, 0x0a,
+	0x05, 0x63, 0x6f, 0x6e, 0x66, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x50, 0x67, 0x48, 0x62, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x05, 0x63, 0x6f,
+	0x6e, 0x66, 0x73, 0x22, 0x3e, 0x0a, 0x16, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x67, 0x48, 0x62,
+	0x61, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a,
+	0x05, 0x63, 0x6f, 0x6e, 0x66, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x50, 0x67, 0x48, 0x62, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x05, 0x63, 0x6f,
+	0x6e, 0x66, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x67, 0x48, 0x62,
+	0x61, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x32, 0xad, 0x0f, 0x0a, 0x05,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x09, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61,
+	0x6b, 0x65, 0x12, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61,
+	0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
+	0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x5d, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x21, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44,
 	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x59, 0x0a, 0x1b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1c,
-	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x69,
-	0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x13, 0x41, 0x72,
+	0x4f, 0x0a, 0x0e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x21, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x4a, 0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61,
+	0x63, 0x65, 0x12, 0x1a, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x69,
+	0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x70,
+	0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x10,
+	0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73,
+	0x12, 0x1c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x72,
+	0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x72, 0x69, 0x6d,
+	0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x11,
+	0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x1d, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x39, 0x0a, 0x09, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x15, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x15, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x79, 0x12, 0x21, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42,
+	0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
+	0x12, 0x20, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x1b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x57, 0x0a, 0x13, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x67, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x41, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x67, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x41, 0x72,
 	0x63, 0x68, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x67, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x79, 0x12, 0x1f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x4c,
-	0x6f, 0x67, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
-	0x4c, 0x6f, 0x67, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x14, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x44, 0x61, 0x74, 0x61,
-	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x11, 0x2e, 0x69, 0x64,
-	0x6c, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f,
-	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x42, 0x0a, 0x1a, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12,
-	0x11, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x19, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x50, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x72,
-	0x6f, 0x6c, 0x12, 0x1c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x50, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1a, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x67,
-	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x57,
-	0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x11, 0x52, 0x65, 0x6e, 0x61, 0x6d,
-	0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x69,
-	0x64, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x64,
-	0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x12, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66,
-	0x12, 0x1e, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63,
-	0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63,
-	0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
-	0x12, 0x5d, 0x0a, 0x15, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
-	0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e,
-	0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x69,
-	0x64, 0x6c, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42,
-	0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x72,
-	0x65, 0x65, 0x6e, 0x70, 0x6c, 0x75, 0x6d, 0x2d, 0x64, 0x62, 0x2f, 0x67, 0x70, 0x75, 0x70, 0x67,
-	0x72, 0x61, 0x64, 0x65, 0x2f, 0x69, 0x64, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x14, 0x52, 0x73, 0x79, 0x6e,
+	0x63, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x12, 0x11, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x1a, 0x52, 0x73, 0x79, 0x6e, 0x63, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x11, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x73, 0x79, 0x6e, 0x63,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x73,
+	0x79, 0x6e, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x19, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x50, 0x67,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x1c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x50, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x69, 0x64,
+	0x6c, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x11,
+	0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x73, 0x12, 0x1d, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x54, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72,
+	0x79, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x1e, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x15, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x21,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65, 0x74,
+	0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x69,
+	0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x71, 0x6c, 0x43,
+	0x6f, 0x6e, 0x66, 0x73, 0x12, 0x1e, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f,
+	0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x71, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f,
+	0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x71, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x50, 0x67, 0x48, 0x62, 0x61,
+	0x43, 0x6f, 0x6e, 0x66, 0x73, 0x12, 0x19, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x50,
+	0x67, 0x48, 0x62, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x67, 0x48, 0x62, 0x61, 0x43,
+	0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0f, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x50, 0x67, 0x48, 0x62, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x12, 0x1b,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x67, 0x48, 0x62, 0x61, 0x43,
+	0x6f, 0x6e, 0x66, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x64,
+	0x6c, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x67, 0x48, 0x62, 0x61, 0x43, 0x6f, 0x6e, 0x66,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x11, 0x42, 0x61, 0x73, 0x65,
+	0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x1d, 0x2e,
+	0x69, 0x64, 0x6c, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4d, 0x69,
+	0x72, 0x72, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4d, 0x69, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x27, 0x5a, 0x25, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x72, 0x65, 0x65, 0x6e, 0x70,
+	0x6c, 0x75, 0x6d, 0x2d, 0x64, 0x62, 0x2f, 0x67, 0x70, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x2f, 0x69, 0x64, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2536,109 +4063,161 @@ func file_hub_to_agent_proto_rawDescGZIP() []byte {
 	return file_hub_to_agent_proto_rawDescData
 }
 
-var file_hub_to_agent_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_hub_to_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
+var file_hub_to_agent_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_hub_to_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 66)
 var file_hub_to_agent_proto_goTypes = []interface{}{
-	(PgOptions_PgUpgradeMode)(0),                 // 0: idl.PgOptions.PgUpgradeMode
-	(PgOptions_Action)(0),                        // 1: idl.PgOptions.Action
-	(*PgOptions)(nil),                            // 2: idl.PgOptions
-	(*TablespaceInfo)(nil),                       // 3: idl.TablespaceInfo
-	(*UpgradePrimariesRequest)(nil),              // 4: idl.UpgradePrimariesRequest
-	(*UpgradePrimariesReply)(nil),                // 5: idl.UpgradePrimariesReply
-	(*CreateBackupDirectoryRequest)(nil),         // 6: idl.CreateBackupDirectoryRequest
-	(*CreateBackupDirectoryReply)(nil),           // 7: idl.CreateBackupDirectoryReply
-	(*DeleteDataDirectoriesRequest)(nil),         // 8: idl.DeleteDataDirectoriesRequest
-	(*DeleteDataDirectoriesReply)(nil),           // 9: idl.DeleteDataDirectoriesReply
-	(*DeleteStateDirectoryRequest)(nil),          // 10: idl.DeleteStateDirectoryRequest
-	(*DeleteStateDirectoryReply)(nil),            // 11: idl.DeleteStateDirectoryReply
-	(*DeleteBackupDirectoryRequest)(nil),         // 12: idl.DeleteBackupDirectoryRequest
-	(*DeleteBackupDirectoryReply)(nil),           // 13: idl.DeleteBackupDirectoryReply
-	(*DeleteTablespaceRequest)(nil),              // 14: idl.DeleteTablespaceRequest
-	(*DeleteTablespaceReply)(nil),                // 15: idl.DeleteTablespaceReply
-	(*ArchiveLogDirectoryRequest)(nil),           // 16: idl.ArchiveLogDirectoryRequest
-	(*ArchiveLogDirectoryReply)(nil),             // 17: idl.ArchiveLogDirectoryReply
-	(*RenameDirectories)(nil),                    // 18: idl.RenameDirectories
-	(*RenameDirectoriesRequest)(nil),             // 19: idl.RenameDirectoriesRequest
-	(*RenameDirectoriesReply)(nil),               // 20: idl.RenameDirectoriesReply
-	(*StopAgentRequest)(nil),                     // 21: idl.StopAgentRequest
-	(*StopAgentReply)(nil),                       // 22: idl.StopAgentReply
-	(*CheckSegmentDiskSpaceRequest)(nil),         // 23: idl.CheckSegmentDiskSpaceRequest
-	(*CheckDiskSpaceReply)(nil),                  // 24: idl.CheckDiskSpaceReply
-	(*RsyncRequest)(nil),                         // 25: idl.RsyncRequest
-	(*RsyncReply)(nil),                           // 26: idl.RsyncReply
-	(*RestorePgControlRequest)(nil),              // 27: idl.RestorePgControlRequest
-	(*RestorePgControlReply)(nil),                // 28: idl.RestorePgControlReply
-	(*UpdateFileConfOptions)(nil),                // 29: idl.UpdateFileConfOptions
-	(*UpdateConfigurationRequest)(nil),           // 30: idl.UpdateConfigurationRequest
-	(*UpdateConfigurationReply)(nil),             // 31: idl.UpdateConfigurationReply
-	(*RenameTablespacesRequest)(nil),             // 32: idl.RenameTablespacesRequest
-	(*RenameTablespacesReply)(nil),               // 33: idl.RenameTablespacesReply
-	(*CreateRecoveryConfRequest)(nil),            // 34: idl.CreateRecoveryConfRequest
-	(*CreateRecoveryConfReply)(nil),              // 35: idl.CreateRecoveryConfReply
-	(*AddReplicationEntriesRequest)(nil),         // 36: idl.AddReplicationEntriesRequest
-	(*AddReplicationEntriesReply)(nil),           // 37: idl.AddReplicationEntriesReply
-	nil,                                          // 38: idl.PgOptions.TablespacesEntry
-	(*CheckDiskSpaceReply_DiskUsage)(nil),        // 39: idl.CheckDiskSpaceReply.DiskUsage
-	(*RsyncRequest_RsyncOptions)(nil),            // 40: idl.RsyncRequest.RsyncOptions
-	(*RenameTablespacesRequest_RenamePair)(nil),  // 41: idl.RenameTablespacesRequest.RenamePair
-	(*CreateRecoveryConfRequest_Connection)(nil), // 42: idl.CreateRecoveryConfRequest.Connection
-	(*AddReplicationEntriesRequest_Entry)(nil),   // 43: idl.AddReplicationEntriesRequest.Entry
-	(Mode)(0), // 44: idl.Mode
+	(PgOptions_PgUpgradeMode)(0),                   // 0: idl.PgOptions.PgUpgradeMode
+	(PgOptions_Action)(0),                          // 1: idl.PgOptions.Action
+	(WatchDiskSpaceReply_Level)(0),                 // 2: idl.WatchDiskSpaceReply.Level
+	(*HandshakeRequest)(nil),                       // 3: idl.HandshakeRequest
+	(*HandshakeReply)(nil),                         // 4: idl.HandshakeReply
+	(*PgOptions)(nil),                              // 5: idl.PgOptions
+	(*TablespaceInfo)(nil),                         // 6: idl.TablespaceInfo
+	(*TablespaceRelocation)(nil),                   // 7: idl.TablespaceRelocation
+	(*UpgradePrimariesRequest)(nil),                // 8: idl.UpgradePrimariesRequest
+	(*UpgradePrimariesReply)(nil),                  // 9: idl.UpgradePrimariesReply
+	(*CreateBackupDirectoryRequest)(nil),           // 10: idl.CreateBackupDirectoryRequest
+	(*CreateBackupDirectoryReply)(nil),             // 11: idl.CreateBackupDirectoryReply
+	(*DeleteDataDirectoriesRequest)(nil),           // 12: idl.DeleteDataDirectoriesRequest
+	(*DeleteDataDirectoriesReply)(nil),             // 13: idl.DeleteDataDirectoriesReply
+	(*DeleteStateDirectoryRequest)(nil),            // 14: idl.DeleteStateDirectoryRequest
+	(*DeleteStateDirectoryReply)(nil),              // 15: idl.DeleteStateDirectoryReply
+	(*DeleteBackupDirectoryRequest)(nil),           // 16: idl.DeleteBackupDirectoryRequest
+	(*DeleteBackupDirectoryReply)(nil),             // 17: idl.DeleteBackupDirectoryReply
+	(*DeleteTablespaceRequest)(nil),                // 18: idl.DeleteTablespaceRequest
+	(*DeleteTablespaceReply)(nil),                  // 19: idl.DeleteTablespaceReply
+	(*ArchiveLogDirectoryRequest)(nil),             // 20: idl.ArchiveLogDirectoryRequest
+	(*ArchiveLogDirectoryReply)(nil),               // 21: idl.ArchiveLogDirectoryReply
+	(*RenameDirectories)(nil),                      // 22: idl.RenameDirectories
+	(*RenameDirectoriesRequest)(nil),               // 23: idl.RenameDirectoriesRequest
+	(*RenameDirectoriesReply)(nil),                 // 24: idl.RenameDirectoriesReply
+	(*StopAgentRequest)(nil),                       // 25: idl.StopAgentRequest
+	(*StopAgentReply)(nil),                         // 26: idl.StopAgentReply
+	(*CheckSegmentDiskSpaceRequest)(nil),           // 27: idl.CheckSegmentDiskSpaceRequest
+	(*CheckDiskSpaceReply)(nil),                    // 28: idl.CheckDiskSpaceReply
+	(*WatchDiskSpaceRequest)(nil),                  // 29: idl.WatchDiskSpaceRequest
+	(*WatchDiskSpaceReply)(nil),                    // 30: idl.WatchDiskSpaceReply
+	(*RsyncRequest)(nil),                           // 31: idl.RsyncRequest
+	(*RsyncReply)(nil),                             // 32: idl.RsyncReply
+	(*RestorePgControlRequest)(nil),                // 33: idl.RestorePgControlRequest
+	(*RestorePgControlReply)(nil),                  // 34: idl.RestorePgControlReply
+	(*UpdateFileConfOptions)(nil),                  // 35: idl.UpdateFileConfOptions
+	(*UpdateConfigurationRequest)(nil),             // 36: idl.UpdateConfigurationRequest
+	(*UpdateConfigurationReply)(nil),               // 37: idl.UpdateConfigurationReply
+	(*RenameTablespacesRequest)(nil),               // 38: idl.RenameTablespacesRequest
+	(*RenameTablespacesReply)(nil),                 // 39: idl.RenameTablespacesReply
+	(*CreateRecoveryConfRequest)(nil),              // 40: idl.CreateRecoveryConfRequest
+	(*CreateRecoveryConfReply)(nil),                // 41: idl.CreateRecoveryConfReply
+	(*BaseBackupMirrorsRequest)(nil),               // 42: idl.BaseBackupMirrorsRequest
+	(*BaseBackupMirrorsReply)(nil),                 // 43: idl.BaseBackupMirrorsReply
+	(*AddReplicationEntriesRequest)(nil),           // 44: idl.AddReplicationEntriesRequest
+	(*AddReplicationEntriesReply)(nil),             // 45: idl.AddReplicationEntriesReply
+	(*GetEnvironmentRequest)(nil),                  // 46: idl.GetEnvironmentRequest
+	(*GetEnvironmentReply)(nil),                    // 47: idl.GetEnvironmentReply
+	(*EnvironmentReport)(nil),                      // 48: idl.EnvironmentReport
+	(*GetPostgresqlConfsRequest)(nil),              // 49: idl.GetPostgresqlConfsRequest
+	(*GetPostgresqlConfsReply)(nil),                // 50: idl.GetPostgresqlConfsReply
+	(*PgHbaConf)(nil),                              // 51: idl.PgHbaConf
+	(*GetPgHbaConfsRequest)(nil),                   // 52: idl.GetPgHbaConfsRequest
+	(*GetPgHbaConfsReply)(nil),                     // 53: idl.GetPgHbaConfsReply
+	(*WritePgHbaConfsRequest)(nil),                 // 54: idl.WritePgHbaConfsRequest
+	(*WritePgHbaConfsReply)(nil),                   // 55: idl.WritePgHbaConfsReply
+	nil,                                            // 56: idl.PgOptions.TablespacesEntry
+	(*CheckDiskSpaceReply_DiskUsage)(nil),          // 57: idl.CheckDiskSpaceReply.DiskUsage
+	(*RsyncRequest_RsyncOptions)(nil),              // 58: idl.RsyncRequest.RsyncOptions
+	(*RenameTablespacesRequest_RenamePair)(nil),    // 59: idl.RenameTablespacesRequest.RenamePair
+	(*CreateRecoveryConfRequest_Connection)(nil),   // 60: idl.CreateRecoveryConfRequest.Connection
+	(*BaseBackupMirrorsRequest_BaseBackup)(nil),    // 61: idl.BaseBackupMirrorsRequest.BaseBackup
+	(*AddReplicationEntriesRequest_Entry)(nil),     // 62: idl.AddReplicationEntriesRequest.Entry
+	(*EnvironmentReport_Ulimit)(nil),               // 63: idl.EnvironmentReport.Ulimit
+	(*EnvironmentReport_Gphome)(nil),               // 64: idl.EnvironmentReport.Gphome
+	nil,                                            // 65: idl.EnvironmentReport.EnvironmentEntry
+	nil,                                            // 66: idl.EnvironmentReport.LoginEnvironmentEntry
+	(*GetPostgresqlConfsReply_PostgresqlConf)(nil), // 67: idl.GetPostgresqlConfsReply.PostgresqlConf
+	nil,       // 68: idl.GetPostgresqlConfsReply.PostgresqlConf.SettingsEntry
+	(Mode)(0), // 69: idl.Mode
 }
 var file_hub_to_agent_proto_depIdxs = []int32{
 	1,  // 0: idl.PgOptions.action:type_name -> idl.PgOptions.Action
 	0,  // 1: idl.PgOptions.pgUpgradeMode:type_name -> idl.PgOptions.PgUpgradeMode
-	44, // 2: idl.PgOptions.mode:type_name -> idl.Mode
-	38, // 3: idl.PgOptions.Tablespaces:type_name -> idl.PgOptions.TablespacesEntry
-	1,  // 4: idl.UpgradePrimariesRequest.action:type_name -> idl.PgOptions.Action
-	2,  // 5: idl.UpgradePrimariesRequest.opts:type_name -> idl.PgOptions
-	18, // 6: idl.RenameDirectoriesRequest.Dirs:type_name -> idl.RenameDirectories
-	39, // 7: idl.CheckDiskSpaceReply.usages:type_name -> idl.CheckDiskSpaceReply.DiskUsage
-	40, // 8: idl.RsyncRequest.options:type_name -> idl.RsyncRequest.RsyncOptions
-	29, // 9: idl.UpdateConfigurationRequest.options:type_name -> idl.UpdateFileConfOptions
-	41, // 10: idl.RenameTablespacesRequest.renamePairs:type_name -> idl.RenameTablespacesRequest.RenamePair
-	42, // 11: idl.CreateRecoveryConfRequest.connections:type_name -> idl.CreateRecoveryConfRequest.Connection
-	43, // 12: idl.AddReplicationEntriesRequest.entries:type_name -> idl.AddReplicationEntriesRequest.Entry
-	3,  // 13: idl.PgOptions.TablespacesEntry.value:type_name -> idl.TablespaceInfo
-	6,  // 14: idl.Agent.CreateBackupDirectory:input_type -> idl.CreateBackupDirectoryRequest
-	23, // 15: idl.Agent.CheckDiskSpace:input_type -> idl.CheckSegmentDiskSpaceRequest
-	4,  // 16: idl.Agent.UpgradePrimaries:input_type -> idl.UpgradePrimariesRequest
-	19, // 17: idl.Agent.RenameDirectories:input_type -> idl.RenameDirectoriesRequest
-	21, // 18: idl.Agent.StopAgent:input_type -> idl.StopAgentRequest
-	8,  // 19: idl.Agent.DeleteDataDirectories:input_type -> idl.DeleteDataDirectoriesRequest
-	12, // 20: idl.Agent.DeleteBackupDirectory:input_type -> idl.DeleteBackupDirectoryRequest
-	10, // 21: idl.Agent.DeleteStateDirectory:input_type -> idl.DeleteStateDirectoryRequest
-	14, // 22: idl.Agent.DeleteTablespaceDirectories:input_type -> idl.DeleteTablespaceRequest
-	16, // 23: idl.Agent.ArchiveLogDirectory:input_type -> idl.ArchiveLogDirectoryRequest
-	25, // 24: idl.Agent.RsyncDataDirectories:input_type -> idl.RsyncRequest
-	25, // 25: idl.Agent.RsyncTablespaceDirectories:input_type -> idl.RsyncRequest
-	27, // 26: idl.Agent.RestorePrimariesPgControl:input_type -> idl.RestorePgControlRequest
-	30, // 27: idl.Agent.UpdateConfiguration:input_type -> idl.UpdateConfigurationRequest
-	32, // 28: idl.Agent.RenameTablespaces:input_type -> idl.RenameTablespacesRequest
-	34, // 29: idl.Agent.CreateRecoveryConf:input_type -> idl.CreateRecoveryConfRequest
-	36, // 30: idl.Agent.AddReplicationEntries:input_type -> idl.AddReplicationEntriesRequest
-	7,  // 31: idl.Agent.CreateBackupDirectory:output_type -> idl.CreateBackupDirectoryReply
-	24, // 32: idl.Agent.CheckDiskSpace:output_type -> idl.CheckDiskSpaceReply
-	5,  // 33: idl.Agent.UpgradePrimaries:output_type -> idl.UpgradePrimariesReply
-	20, // 34: idl.Agent.RenameDirectories:output_type -> idl.RenameDirectoriesReply
-	22, // 35: idl.Agent.StopAgent:output_type -> idl.StopAgentReply
-	9,  // 36: idl.Agent.DeleteDataDirectories:output_type -> idl.DeleteDataDirectoriesReply
-	13, // 37: idl.Agent.DeleteBackupDirectory:output_type -> idl.DeleteBackupDirectoryReply
-	11, // 38: idl.Agent.DeleteStateDirectory:output_type -> idl.DeleteStateDirectoryReply
-	15, // 39: idl.Agent.DeleteTablespaceDirectories:output_type -> idl.DeleteTablespaceReply
-	17, // 40: idl.Agent.ArchiveLogDirectory:output_type -> idl.ArchiveLogDirectoryReply
-	26, // 41: idl.Agent.RsyncDataDirectories:output_type -> idl.RsyncReply
-	26, // 42: idl.Agent.RsyncTablespaceDirectories:output_type -> idl.RsyncReply
-	28, // 43: idl.Agent.RestorePrimariesPgControl:output_type -> idl.RestorePgControlReply
-	31, // 44: idl.Agent.UpdateConfiguration:output_type -> idl.UpdateConfigurationReply
-	33, // 45: idl.Agent.RenameTablespaces:output_type -> idl.RenameTablespacesReply
-	35, // 46: idl.Agent.CreateRecoveryConf:output_type -> idl.CreateRecoveryConfReply
-	37, // 47: idl.Agent.AddReplicationEntries:output_type -> idl.AddReplicationEntriesReply
-	31, // [31:48] is the sub-list for method output_type
-	14, // [14:31] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	69, // 2: idl.PgOptions.mode:type_name -> idl.Mode
+	56, // 3: idl.PgOptions.Tablespaces:type_name -> idl.PgOptions.TablespacesEntry
+	7,  // 4: idl.PgOptions.tablespaceRelocations:type_name -> idl.TablespaceRelocation
+	1,  // 5: idl.UpgradePrimariesRequest.action:type_name -> idl.PgOptions.Action
+	5,  // 6: idl.UpgradePrimariesRequest.opts:type_name -> idl.PgOptions
+	22, // 7: idl.RenameDirectoriesRequest.Dirs:type_name -> idl.RenameDirectories
+	69, // 8: idl.CheckSegmentDiskSpaceRequest.mode:type_name -> idl.Mode
+	57, // 9: idl.CheckDiskSpaceReply.usages:type_name -> idl.CheckDiskSpaceReply.DiskUsage
+	2,  // 10: idl.WatchDiskSpaceReply.level:type_name -> idl.WatchDiskSpaceReply.Level
+	58, // 11: idl.RsyncRequest.options:type_name -> idl.RsyncRequest.RsyncOptions
+	35, // 12: idl.UpdateConfigurationRequest.options:type_name -> idl.UpdateFileConfOptions
+	59, // 13: idl.RenameTablespacesRequest.renamePairs:type_name -> idl.RenameTablespacesRequest.RenamePair
+	60, // 14: idl.CreateRecoveryConfRequest.connections:type_name -> idl.CreateRecoveryConfRequest.Connection
+	61, // 15: idl.BaseBackupMirrorsRequest.backups:type_name -> idl.BaseBackupMirrorsRequest.BaseBackup
+	62, // 16: idl.AddReplicationEntriesRequest.entries:type_name -> idl.AddReplicationEntriesRequest.Entry
+	48, // 17: idl.GetEnvironmentReply.report:type_name -> idl.EnvironmentReport
+	65, // 18: idl.EnvironmentReport.environment:type_name -> idl.EnvironmentReport.EnvironmentEntry
+	66, // 19: idl.EnvironmentReport.loginEnvironment:type_name -> idl.EnvironmentReport.LoginEnvironmentEntry
+	63, // 20: idl.EnvironmentReport.ulimits:type_name -> idl.EnvironmentReport.Ulimit
+	64, // 21: idl.EnvironmentReport.gphomes:type_name -> idl.EnvironmentReport.Gphome
+	67, // 22: idl.GetPostgresqlConfsReply.confs:type_name -> idl.GetPostgresqlConfsReply.PostgresqlConf
+	51, // 23: idl.GetPgHbaConfsReply.confs:type_name -> idl.PgHbaConf
+	51, // 24: idl.WritePgHbaConfsRequest.confs:type_name -> idl.PgHbaConf
+	6,  // 25: idl.PgOptions.TablespacesEntry.value:type_name -> idl.TablespaceInfo
+	68, // 26: idl.GetPostgresqlConfsReply.PostgresqlConf.settings:type_name -> idl.GetPostgresqlConfsReply.PostgresqlConf.SettingsEntry
+	3,  // 27: idl.Agent.Handshake:input_type -> idl.HandshakeRequest
+	10, // 28: idl.Agent.CreateBackupDirectory:input_type -> idl.CreateBackupDirectoryRequest
+	27, // 29: idl.Agent.CheckDiskSpace:input_type -> idl.CheckSegmentDiskSpaceRequest
+	29, // 30: idl.Agent.WatchDiskSpace:input_type -> idl.WatchDiskSpaceRequest
+	8,  // 31: idl.Agent.UpgradePrimaries:input_type -> idl.UpgradePrimariesRequest
+	23, // 32: idl.Agent.RenameDirectories:input_type -> idl.RenameDirectoriesRequest
+	25, // 33: idl.Agent.StopAgent:input_type -> idl.StopAgentRequest
+	12, // 34: idl.Agent.DeleteDataDirectories:input_type -> idl.DeleteDataDirectoriesRequest
+	16, // 35: idl.Agent.DeleteBackupDirectory:input_type -> idl.DeleteBackupDirectoryRequest
+	14, // 36: idl.Agent.DeleteStateDirectory:input_type -> idl.DeleteStateDirectoryRequest
+	18, // 37: idl.Agent.DeleteTablespaceDirectories:input_type -> idl.DeleteTablespaceRequest
+	20, // 38: idl.Agent.ArchiveLogDirectory:input_type -> idl.ArchiveLogDirectoryRequest
+	31, // 39: idl.Agent.RsyncDataDirectories:input_type -> idl.RsyncRequest
+	31, // 40: idl.Agent.RsyncTablespaceDirectories:input_type -> idl.RsyncRequest
+	33, // 41: idl.Agent.RestorePrimariesPgControl:input_type -> idl.RestorePgControlRequest
+	36, // 42: idl.Agent.UpdateConfiguration:input_type -> idl.UpdateConfigurationRequest
+	38, // 43: idl.Agent.RenameTablespaces:input_type -> idl.RenameTablespacesRequest
+	40, // 44: idl.Agent.CreateRecoveryConf:input_type -> idl.CreateRecoveryConfRequest
+	44, // 45: idl.Agent.AddReplicationEntries:input_type -> idl.AddReplicationEntriesRequest
+	46, // 46: idl.Agent.GetEnvironment:input_type -> idl.GetEnvironmentRequest
+	49, // 47: idl.Agent.GetPostgresqlConfs:input_type -> idl.GetPostgresqlConfsRequest
+	52, // 48: idl.Agent.GetPgHbaConfs:input_type -> idl.GetPgHbaConfsRequest
+	54, // 49: idl.Agent.WritePgHbaConfs:input_type -> idl.WritePgHbaConfsRequest
+	42, // 50: idl.Agent.BaseBackupMirrors:input_type -> idl.BaseBackupMirrorsRequest
+	4,  // 51: idl.Agent.Handshake:output_type -> idl.HandshakeReply
+	11, // 52: idl.Agent.CreateBackupDirectory:output_type -> idl.CreateBackupDirectoryReply
+	28, // 53: idl.Agent.CheckDiskSpace:output_type -> idl.CheckDiskSpaceReply
+	30, // 54: idl.Agent.WatchDiskSpace:output_type -> idl.WatchDiskSpaceReply
+	9,  // 55: idl.Agent.UpgradePrimaries:output_type -> idl.UpgradePrimariesReply
+	24, // 56: idl.Agent.RenameDirectories:output_type -> idl.RenameDirectoriesReply
+	26, // 57: idl.Agent.StopAgent:output_type -> idl.StopAgentReply
+	13, // 58: idl.Agent.DeleteDataDirectories:output_type -> idl.DeleteDataDirectoriesReply
+	17, // 59: idl.Agent.DeleteBackupDirectory:output_type -> idl.DeleteBackupDirectoryReply
+	15, // 60: idl.Agent.DeleteStateDirectory:output_type -> idl.DeleteStateDirectoryReply
+	19, // 61: idl.Agent.DeleteTablespaceDirectories:output_type -> idl.DeleteTablespaceReply
+	21, // 62: idl.Agent.ArchiveLogDirectory:output_type -> idl.ArchiveLogDirectoryReply
+	32, // 63: idl.Agent.RsyncDataDirectories:output_type -> idl.RsyncReply
+	32, // 64: idl.Agent.RsyncTablespaceDirectories:output_type -> idl.RsyncReply
+	34, // 65: idl.Agent.RestorePrimariesPgControl:output_type -> idl.RestorePgControlReply
+	37, // 66: idl.Agent.UpdateConfiguration:output_type -> idl.UpdateConfigurationReply
+	39, // 67: idl.Agent.RenameTablespaces:output_type -> idl.RenameTablespacesReply
+	41, // 68: idl.Agent.CreateRecoveryConf:output_type -> idl.CreateRecoveryConfReply
+	45, // 69: idl.Agent.AddReplicationEntries:output_type -> idl.AddReplicationEntriesReply
+	47, // 70: idl.Agent.GetEnvironment:output_type -> idl.GetEnvironmentReply
+	50, // 71: idl.Agent.GetPostgresqlConfs:output_type -> idl.GetPostgresqlConfsReply
+	53, // 72: idl.Agent.GetPgHbaConfs:output_type -> idl.GetPgHbaConfsReply
+	55, // 73: idl.Agent.WritePgHbaConfs:output_type -> idl.WritePgHbaConfsReply
+	43, // 74: idl.Agent.BaseBackupMirrors:output_type -> idl.BaseBackupMirrorsReply
+	51, // [51:75] is the sub-list for method output_type
+	27, // [27:51] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_hub_to_agent_proto_init() }
@@ -2649,7 +4228,7 @@ func file_hub_to_agent_proto_init() {
 	file_common_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_hub_to_agent_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PgOptions); i {
+			switch v := v.(*HandshakeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2661,6 +4240,30 @@ func file_hub_to_agent_proto_init() {
 			}
 		}
 		file_hub_to_agent_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HandshakeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PgOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TablespaceInfo); i {
 			case 0:
 				return &v.state
@@ -2672,7 +4275,19 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TablespaceRelocation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpgradePrimariesRequest); i {
 			case 0:
 				return &v.state
@@ -2684,7 +4299,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpgradePrimariesReply); i {
 			case 0:
 				return &v.state
@@ -2696,7 +4311,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateBackupDirectoryRequest); i {
 			case 0:
 				return &v.state
@@ -2708,7 +4323,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateBackupDirectoryReply); i {
 			case 0:
 				return &v.state
@@ -2720,7 +4335,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteDataDirectoriesRequest); i {
 			case 0:
 				return &v.state
@@ -2732,7 +4347,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteDataDirectoriesReply); i {
 			case 0:
 				return &v.state
@@ -2744,7 +4359,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteStateDirectoryRequest); i {
 			case 0:
 				return &v.state
@@ -2756,7 +4371,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteStateDirectoryReply); i {
 			case 0:
 				return &v.state
@@ -2768,7 +4383,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteBackupDirectoryRequest); i {
 			case 0:
 				return &v.state
@@ -2780,7 +4395,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteBackupDirectoryReply); i {
 			case 0:
 				return &v.state
@@ -2792,7 +4407,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteTablespaceRequest); i {
 			case 0:
 				return &v.state
@@ -2804,7 +4419,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteTablespaceReply); i {
 			case 0:
 				return &v.state
@@ -2816,7 +4431,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ArchiveLogDirectoryRequest); i {
 			case 0:
 				return &v.state
@@ -2828,7 +4443,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ArchiveLogDirectoryReply); i {
 			case 0:
 				return &v.state
@@ -2840,7 +4455,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RenameDirectories); i {
 			case 0:
 				return &v.state
@@ -2852,7 +4467,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RenameDirectoriesRequest); i {
 			case 0:
 				return &v.state
@@ -2864,7 +4479,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RenameDirectoriesReply); i {
 			case 0:
 				return &v.state
@@ -2876,7 +4491,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StopAgentRequest); i {
 			case 0:
 				return &v.state
@@ -2888,7 +4503,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StopAgentReply); i {
 			case 0:
 				return &v.state
@@ -2900,7 +4515,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CheckSegmentDiskSpaceRequest); i {
 			case 0:
 				return &v.state
@@ -2912,7 +4527,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CheckDiskSpaceReply); i {
 			case 0:
 				return &v.state
@@ -2924,7 +4539,31 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchDiskSpaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchDiskSpaceReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RsyncRequest); i {
 			case 0:
 				return &v.state
@@ -2936,7 +4575,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RsyncReply); i {
 			case 0:
 				return &v.state
@@ -2948,7 +4587,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RestorePgControlRequest); i {
 			case 0:
 				return &v.state
@@ -2960,7 +4599,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RestorePgControlReply); i {
 			case 0:
 				return &v.state
@@ -2972,7 +4611,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateFileConfOptions); i {
 			case 0:
 				return &v.state
@@ -2984,7 +4623,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateConfigurationRequest); i {
 			case 0:
 				return &v.state
@@ -2996,7 +4635,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateConfigurationReply); i {
 			case 0:
 				return &v.state
@@ -3008,7 +4647,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RenameTablespacesRequest); i {
 			case 0:
 				return &v.state
@@ -3020,7 +4659,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RenameTablespacesReply); i {
 			case 0:
 				return &v.state
@@ -3032,7 +4671,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateRecoveryConfRequest); i {
 			case 0:
 				return &v.state
@@ -3044,7 +4683,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateRecoveryConfReply); i {
 			case 0:
 				return &v.state
@@ -3056,7 +4695,31 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BaseBackupMirrorsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BaseBackupMirrorsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AddReplicationEntriesRequest); i {
 			case 0:
 				return &v.state
@@ -3068,7 +4731,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AddReplicationEntriesReply); i {
 			case 0:
 				return &v.state
@@ -3080,7 +4743,127 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEnvironmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEnvironmentReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnvironmentReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPostgresqlConfsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPostgresqlConfsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PgHbaConf); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPgHbaConfsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPgHbaConfsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WritePgHbaConfsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WritePgHbaConfsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CheckDiskSpaceReply_DiskUsage); i {
 			case 0:
 				return &v.state
@@ -3092,7 +4875,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RsyncRequest_RsyncOptions); i {
 			case 0:
 				return &v.state
@@ -3104,7 +4887,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RenameTablespacesRequest_RenamePair); i {
 			case 0:
 				return &v.state
@@ -3116,7 +4899,7 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateRecoveryConfRequest_Connection); i {
 			case 0:
 				return &v.state
@@ -3128,7 +4911,19 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
-		file_hub_to_agent_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+		file_hub_to_agent_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BaseBackupMirrorsRequest_BaseBackup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AddReplicationEntriesRequest_Entry); i {
 			case 0:
 				return &v.state
@@ -3140,14 +4935,50 @@ func file_hub_to_agent_proto_init() {
 				return nil
 			}
 		}
+		file_hub_to_agent_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnvironmentReport_Ulimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnvironmentReport_Gphome); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_to_agent_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPostgresqlConfsReply_PostgresqlConf); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_hub_to_agent_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   42,
+			NumEnums:      3,
+			NumMessages:   66,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,8 +22,10 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
+	Agent_Handshake_FullMethodName                   = "/idl.Agent/Handshake"
 	Agent_CreateBackupDirectory_FullMethodName       = "/idl.Agent/CreateBackupDirectory"
 	Agent_CheckDiskSpace_FullMethodName              = "/idl.Agent/CheckDiskSpace"
+	Agent_WatchDiskSpace_FullMethodName              = "/idl.Agent/WatchDiskSpace"
 	Agent_UpgradePrimaries_FullMethodName            = "/idl.Agent/UpgradePrimaries"
 	Agent_RenameDirectories_FullMethodName           = "/idl.Agent/RenameDirectories"
 	Agent_StopAgent_FullMethodName                   = "/idl.Agent/StopAgent"
@@ -39,14 +41,21 @@ const (
 	Agent_RenameTablespaces_FullMethodName           = "/idl.Agent/RenameTablespaces"
 	Agent_CreateRecoveryConf_FullMethodName          = "/idl.Agent/CreateRecoveryConf"
 	Agent_AddReplicationEntries_FullMethodName       = "/idl.Agent/AddReplicationEntries"
+	Agent_GetEnvironment_FullMethodName              = "/idl.Agent/GetEnvironment"
+	Agent_GetPostgresqlConfs_FullMethodName          = "/idl.Agent/GetPostgresqlConfs"
+	Agent_GetPgHbaConfs_FullMethodName               = "/idl.Agent/GetPgHbaConfs"
+	Agent_WritePgHbaConfs_FullMethodName             = "/idl.Agent/WritePgHbaConfs"
+	Agent_BaseBackupMirrors_FullMethodName           = "/idl.Agent/BaseBackupMirrors"
 )
 
 // AgentClient is the client API for Agent service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type AgentClient interface {
+	Handshake(ctx context.Context, in *HandshakeRequest, opts ...grpc.CallOption) (*HandshakeReply, error)
 	CreateBackupDirectory(ctx context.Context, in *CreateBackupDirectoryRequest, opts ...grpc.CallOption) (*CreateBackupDirectoryReply, error)
 	CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSpaceRequest, opts ...grpc.CallOption) (*CheckDiskSpaceReply, error)
+	WatchDiskSpace(ctx context.Context, in *WatchDiskSpaceRequest, opts ...grpc.CallOption) (Agent_WatchDiskSpaceClient, error)
 	UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error)
 	RenameDirectories(ctx context.Context, in *RenameDirectoriesRequest, opts ...grpc.CallOption) (*RenameDirectoriesReply, error)
 	StopAgent(ctx context.Context, in *StopAgentRequest, opts ...grpc.CallOption) (*StopAgentReply, error)
@@ -62,6 +71,11 @@ type AgentClient interface {
 	RenameTablespaces(ctx context.Context, in *RenameTablespacesRequest, opts ...grpc.CallOption) (*RenameTablespacesReply, error)
 	CreateRecoveryConf(ctx context.Context, in *CreateRecoveryConfRequest, opts ...grpc.CallOption) (*CreateRecoveryConfReply, error)
 	AddReplicationEntries(ctx context.Context, in *AddReplicationEntriesRequest, opts ...grpc.CallOption) (*AddReplicationEntriesReply, error)
+	GetEnvironment(ctx context.Context, in *GetEnvironmentRequest, opts ...grpc.CallOption) (*GetEnvironmentReply, error)
+	GetPostgresqlConfs(ctx context.Context, in *GetPostgresqlConfsRequest, opts ...grpc.CallOption) (*GetPostgresqlConfsReply, error)
+	GetPgHbaConfs(ctx context.Context, in *GetPgHbaConfsRequest, opts ...grpc.CallOption) (*GetPgHbaConfsReply, error)
+	WritePgHbaConfs(ctx context.Context, in *WritePgHbaConfsRequest, opts ...grpc.CallOption) (*WritePgHbaConfsReply, error)
+	BaseBackupMirrors(ctx context.Context, in *BaseBackupMirrorsRequest, opts ...grpc.CallOption) (*BaseBackupMirrorsReply, error)
 }
 
 type agentClient struct {
@@ -72,6 +86,15 @@ func NewAgentClient(cc grpc.ClientConnInterface) AgentClient {
 	return &agentClient{cc}
 }
 
+func (c *agentClient) Handshake(ctx context.Context, in *HandshakeRequest, opts ...grpc.CallOption) (*HandshakeReply, error) {
+	out := new(HandshakeReply)
+	err := c.cc.Invoke(ctx, Agent_Handshake_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *agentClient) CreateBackupDirectory(ctx context.Context, in *CreateBackupDirectoryRequest, opts ...grpc.CallOption) (*CreateBackupDirectoryReply, error) {
 	out := new(CreateBackupDirectoryReply)
 	err := c.cc.Invoke(ctx, Agent_CreateBackupDirectory_FullMethodName, in, out, opts...)
@@ -90,6 +113,38 @@ func (c *agentClient) CheckDiskSpace(ctx context.Context, in *CheckSegmentDiskSp
 	return out, nil
 }
 
+func (c *agentClient) WatchDiskSpace(ctx context.Context, in *WatchDiskSpaceRequest, opts ...grpc.CallOption) (Agent_WatchDiskSpaceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[0], Agent_WatchDiskSpace_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentWatchDiskSpaceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_WatchDiskSpaceClient interface {
+	Recv() (*WatchDiskSpaceReply, error)
+	grpc.ClientStream
+}
+
+type agentWatchDiskSpaceClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentWatchDiskSpaceClient) Recv() (*WatchDiskSpaceReply, error) {
+	m := new(WatchDiskSpaceReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *agentClient) UpgradePrimaries(ctx context.Context, in *UpgradePrimariesRequest, opts ...grpc.CallOption) (*UpgradePrimariesReply, error) {
 	out := new(UpgradePrimariesReply)
 	err := c.cc.Invoke(ctx, Agent_UpgradePrimaries_FullMethodName, in, out, opts...)
@@ -225,12 +280,59 @@ func (c *agentClient) AddReplicationEntries(ctx context.Context, in *AddReplicat
 	return out, nil
 }
 
+func (c *agentClient) GetEnvironment(ctx context.Context, in *GetEnvironmentRequest, opts ...grpc.CallOption) (*GetEnvironmentReply, error) {
+	out := new(GetEnvironmentReply)
+	err := c.cc.Invoke(ctx, Agent_GetEnvironment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetPostgresqlConfs(ctx context.Context, in *GetPostgresqlConfsRequest, opts ...grpc.CallOption) (*GetPostgresqlConfsReply, error) {
+	out := new(GetPostgresqlConfsReply)
+	err := c.cc.Invoke(ctx, Agent_GetPostgresqlConfs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetPgHbaConfs(ctx context.Context, in *GetPgHbaConfsRequest, opts ...grpc.CallOption) (*GetPgHbaConfsReply, error) {
+	out := new(GetPgHbaConfsReply)
+	err := c.cc.Invoke(ctx, Agent_GetPgHbaConfs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) WritePgHbaConfs(ctx context.Context, in *WritePgHbaConfsRequest, opts ...grpc.CallOption) (*WritePgHbaConfsReply, error) {
+	out := new(WritePgHbaConfsReply)
+	err := c.cc.Invoke(ctx, Agent_WritePgHbaConfs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) BaseBackupMirrors(ctx context.Context, in *BaseBackupMirrorsRequest, opts ...grpc.CallOption) (*BaseBackupMirrorsReply, error) {
+	out := new(BaseBackupMirrorsReply)
+	err := c.cc.Invoke(ctx, Agent_BaseBackupMirrors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServer is the server API for Agent service.
 // All implementations should embed UnimplementedAgentServer
 // for forward compatibility
 type AgentServer interface {
+	Handshake(context.Context, *HandshakeRequest) (*HandshakeReply, error)
 	CreateBackupDirectory(context.Context, *CreateBackupDirectoryRequest) (*CreateBackupDirectoryReply, error)
 	CheckDiskSpace(context.Context, *CheckSegmentDiskSpaceRequest) (*CheckDiskSpaceReply, error)
+	WatchDiskSpace(*WatchDiskSpaceRequest, Agent_WatchDiskSpaceServer) error
 	UpgradePrimaries(context.Context, *UpgradePrimariesRequest) (*UpgradePrimariesReply, error)
 	RenameDirectories(context.Context, *RenameDirectoriesRequest) (*RenameDirectoriesReply, error)
 	StopAgent(context.Context, *StopAgentRequest) (*StopAgentReply, error)
@@ -246,18 +348,29 @@ type AgentServer interface {
 	RenameTablespaces(context.Context, *RenameTablespacesRequest) (*RenameTablespacesReply, error)
 	CreateRecoveryConf(context.Context, *CreateRecoveryConfRequest) (*CreateRecoveryConfReply, error)
 	AddReplicationEntries(context.Context, *AddReplicationEntriesRequest) (*AddReplicationEntriesReply, error)
+	GetEnvironment(context.Context, *GetEnvironmentRequest) (*GetEnvironmentReply, error)
+	GetPostgresqlConfs(context.Context, *GetPostgresqlConfsRequest) (*GetPostgresqlConfsReply, error)
+	GetPgHbaConfs(context.Context, *GetPgHbaConfsRequest) (*GetPgHbaConfsReply, error)
+	WritePgHbaConfs(context.Context, *WritePgHbaConfsRequest) (*WritePgHbaConfsReply, error)
+	BaseBackupMirrors(context.Context, *BaseBackupMirrorsRequest) (*BaseBackupMirrorsReply, error)
 }
 
 // UnimplementedAgentServer should be embedded to have forward compatible implementations.
 type UnimplementedAgentServer struct {
 }
 
+func (UnimplementedAgentServer) Handshake(context.Context, *HandshakeRequest) (*HandshakeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Handshake not implemented")
+}
 func (UnimplementedAgentServer) CreateBackupDirectory(context.Context, *CreateBackupDirectoryRequest) (*CreateBackupDirectoryReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateBackupDirectory not implemented")
 }
 func (UnimplementedAgentServer) CheckDiskSpace(context.Context, *CheckSegmentDiskSpaceRequest) (*CheckDiskSpaceReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckDiskSpace not implemented")
 }
+func (UnimplementedAgentServer) WatchDiskSpace(*WatchDiskSpaceRequest, Agent_WatchDiskSpaceServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDiskSpace not implemented")
+}
 func (UnimplementedAgentServer) UpgradePrimaries(context.Context, *UpgradePrimariesRequest) (*UpgradePrimariesReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpgradePrimaries not implemented")
 }
@@ -303,6 +416,21 @@ func (UnimplementedAgentServer) CreateRecoveryConf(context.Context, *CreateRecov
 func (UnimplementedAgentServer) AddReplicationEntries(context.Context, *AddReplicationEntriesRequest) (*AddReplicationEntriesReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddReplicationEntries not implemented")
 }
+func (UnimplementedAgentServer) GetEnvironment(context.Context, *GetEnvironmentRequest) (*GetEnvironmentReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEnvironment not implemented")
+}
+func (UnimplementedAgentServer) GetPostgresqlConfs(context.Context, *GetPostgresqlConfsRequest) (*GetPostgresqlConfsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostgresqlConfs not implemented")
+}
+func (UnimplementedAgentServer) GetPgHbaConfs(context.Context, *GetPgHbaConfsRequest) (*GetPgHbaConfsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPgHbaConfs not implemented")
+}
+func (UnimplementedAgentServer) WritePgHbaConfs(context.Context, *WritePgHbaConfsRequest) (*WritePgHbaConfsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WritePgHbaConfs not implemented")
+}
+func (UnimplementedAgentServer) BaseBackupMirrors(context.Context, *BaseBackupMirrorsRequest) (*BaseBackupMirrorsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BaseBackupMirrors not implemented")
+}
 
 // UnsafeAgentServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to AgentServer will
@@ -315,6 +443,24 @@ func RegisterAgentServer(s grpc.ServiceRegistrar, srv AgentServer) {
 	s.RegisterService(&Agent_ServiceDesc, srv)
 }
 
+func _Agent_Handshake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandshakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).Handshake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_Handshake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Handshake(ctx, req.(*HandshakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Agent_CreateBackupDirectory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateBackupDirectoryRequest)
 	if err := dec(in); err != nil {
@@ -351,6 +497,27 @@ func _Agent_CheckDiskSpace_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_WatchDiskSpace_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDiskSpaceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).WatchDiskSpace(m, &agentWatchDiskSpaceServer{stream})
+}
+
+type Agent_WatchDiskSpaceServer interface {
+	Send(*WatchDiskSpaceReply) error
+	grpc.ServerStream
+}
+
+type agentWatchDiskSpaceServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentWatchDiskSpaceServer) Send(m *WatchDiskSpaceReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Agent_UpgradePrimaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpgradePrimariesRequest)
 	if err := dec(in); err != nil {
@@ -621,6 +788,96 @@ func _Agent_AddReplicationEntries_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_GetEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEnvironmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_GetEnvironment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetEnvironment(ctx, req.(*GetEnvironmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetPostgresqlConfs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostgresqlConfsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetPostgresqlConfs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_GetPostgresqlConfs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetPostgresqlConfs(ctx, req.(*GetPostgresqlConfsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetPgHbaConfs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPgHbaConfsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetPgHbaConfs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_GetPgHbaConfs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetPgHbaConfs(ctx, req.(*GetPgHbaConfsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_WritePgHbaConfs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WritePgHbaConfsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).WritePgHbaConfs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_WritePgHbaConfs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).WritePgHbaConfs(ctx, req.(*WritePgHbaConfsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_BaseBackupMirrors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BaseBackupMirrorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).BaseBackupMirrors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Agent_BaseBackupMirrors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).BaseBackupMirrors(ctx, req.(*BaseBackupMirrorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Agent_ServiceDesc is the grpc.ServiceDesc for Agent service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -628,6 +885,10 @@ var Agent_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "idl.Agent",
 	HandlerType: (*AgentServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Handshake",
+			Handler:    _Agent_Handshake_Handler,
+		},
 		{
 			MethodName: "CreateBackupDirectory",
 			Handler:    _Agent_CreateBackupDirectory_Handler,
@@ -696,7 +957,33 @@ var Agent_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddReplicationEntries",
 			Handler:    _Agent_AddReplicationEntries_Handler,
 		},
+		{
+			MethodName: "GetEnvironment",
+			Handler:    _Agent_GetEnvironment_Handler,
+		},
+		{
+			MethodName: "GetPostgresqlConfs",
+			Handler:    _Agent_GetPostgresqlConfs_Handler,
+		},
+		{
+			MethodName: "GetPgHbaConfs",
+			Handler:    _Agent_GetPgHbaConfs_Handler,
+		},
+		{
+			MethodName: "WritePgHbaConfs",
+			Handler:    _Agent_WritePgHbaConfs_Handler,
+		},
+		{
+			MethodName: "BaseBackupMirrors",
+			Handler:    _Agent_BaseBackupMirrors_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDiskSpace",
+			Handler:       _Agent_WatchDiskSpace_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "hub_to_agent.proto",
 }
@@ -37,6 +37,26 @@ func (m *MockCliToHubClient) EXPECT() *MockCliToHubClientMockRecorder {
 	return m.recorder
 }
 
+// Cancel mocks base method.
+func (m *MockCliToHubClient) Cancel(ctx context.Context, in *idl.CancelRequest, opts ...grpc.CallOption) (*idl.CancelReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Cancel", varargs...)
+	ret0, _ := ret[0].(*idl.CancelReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockCliToHubClientMockRecorder) Cancel(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockCliToHubClient)(nil).Cancel), varargs...)
+}
+
 // Execute mocks base method.
 func (m *MockCliToHubClient) Execute(ctx context.Context, in *idl.ExecuteRequest, opts ...grpc.CallOption) (idl.CliToHub_ExecuteClient, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +157,26 @@ func (mr *MockCliToHubClientMockRecorder) InitializeCreateCluster(ctx, in interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitializeCreateCluster", reflect.TypeOf((*MockCliToHubClient)(nil).InitializeCreateCluster), varargs...)
 }
 
+// Plan mocks base method.
+func (m *MockCliToHubClient) Plan(ctx context.Context, in *idl.PlanRequest, opts ...grpc.CallOption) (*idl.PlanReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Plan", varargs...)
+	ret0, _ := ret[0].(*idl.PlanReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockCliToHubClientMockRecorder) Plan(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockCliToHubClient)(nil).Plan), varargs...)
+}
+
 // RestartAgents mocks base method.
 func (m *MockCliToHubClient) RestartAgents(ctx context.Context, in *idl.RestartAgentsRequest, opts ...grpc.CallOption) (*idl.RestartAgentsReply, error) {
 	m.ctrl.T.Helper()
@@ -197,6 +237,26 @@ func (mr *MockCliToHubClientMockRecorder) StopServices(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopServices", reflect.TypeOf((*MockCliToHubClient)(nil).StopServices), varargs...)
 }
 
+// Subscribe mocks base method.
+func (m *MockCliToHubClient) Subscribe(ctx context.Context, in *idl.SubscribeRequest, opts ...grpc.CallOption) (idl.CliToHub_SubscribeClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Subscribe", varargs...)
+	ret0, _ := ret[0].(idl.CliToHub_SubscribeClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockCliToHubClientMockRecorder) Subscribe(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockCliToHubClient)(nil).Subscribe), varargs...)
+}
+
 // MockCliToHub_InitializeClient is a mock of CliToHub_InitializeClient interface.
 type MockCliToHub_InitializeClient struct {
 	ctrl     *gomock.Controller
@@ -812,6 +872,129 @@ func (mr *MockCliToHub_RevertClientMockRecorder) Trailer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockCliToHub_RevertClient)(nil).Trailer))
 }
 
+// MockCliToHub_SubscribeClient is a mock of CliToHub_SubscribeClient interface.
+type MockCliToHub_SubscribeClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCliToHub_SubscribeClientMockRecorder
+}
+
+// MockCliToHub_SubscribeClientMockRecorder is the mock recorder for MockCliToHub_SubscribeClient.
+type MockCliToHub_SubscribeClientMockRecorder struct {
+	mock *MockCliToHub_SubscribeClient
+}
+
+// NewMockCliToHub_SubscribeClient creates a new mock instance.
+func NewMockCliToHub_SubscribeClient(ctrl *gomock.Controller) *MockCliToHub_SubscribeClient {
+	mock := &MockCliToHub_SubscribeClient{ctrl: ctrl}
+	mock.recorder = &MockCliToHub_SubscribeClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCliToHub_SubscribeClient) EXPECT() *MockCliToHub_SubscribeClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockCliToHub_SubscribeClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockCliToHub_SubscribeClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockCliToHub_SubscribeClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockCliToHub_SubscribeClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockCliToHub_SubscribeClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCliToHub_SubscribeClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockCliToHub_SubscribeClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockCliToHub_SubscribeClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockCliToHub_SubscribeClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockCliToHub_SubscribeClient) Recv() (*idl.SubscribeReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.SubscribeReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockCliToHub_SubscribeClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockCliToHub_SubscribeClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockCliToHub_SubscribeClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockCliToHub_SubscribeClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCliToHub_SubscribeClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockCliToHub_SubscribeClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockCliToHub_SubscribeClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCliToHub_SubscribeClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockCliToHub_SubscribeClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockCliToHub_SubscribeClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockCliToHub_SubscribeClient)(nil).Trailer))
+}
+
 // MockCliToHubServer is a mock of CliToHubServer interface.
 type MockCliToHubServer struct {
 	ctrl     *gomock.Controller
@@ -835,6 +1018,21 @@ func (m *MockCliToHubServer) EXPECT() *MockCliToHubServerMockRecorder {
 	return m.recorder
 }
 
+// Cancel mocks base method.
+func (m *MockCliToHubServer) Cancel(arg0 context.Context, arg1 *idl.CancelRequest) (*idl.CancelReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CancelReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockCliToHubServerMockRecorder) Cancel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockCliToHubServer)(nil).Cancel), arg0, arg1)
+}
+
 // Execute mocks base method.
 func (m *MockCliToHubServer) Execute(arg0 *idl.ExecuteRequest, arg1 idl.CliToHub_ExecuteServer) error {
 	m.ctrl.T.Helper()
@@ -906,6 +1104,21 @@ func (mr *MockCliToHubServerMockRecorder) InitializeCreateCluster(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitializeCreateCluster", reflect.TypeOf((*MockCliToHubServer)(nil).InitializeCreateCluster), arg0, arg1)
 }
 
+// Plan mocks base method.
+func (m *MockCliToHubServer) Plan(arg0 context.Context, arg1 *idl.PlanRequest) (*idl.PlanReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Plan", arg0, arg1)
+	ret0, _ := ret[0].(*idl.PlanReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockCliToHubServerMockRecorder) Plan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockCliToHubServer)(nil).Plan), arg0, arg1)
+}
+
 // RestartAgents mocks base method.
 func (m *MockCliToHubServer) RestartAgents(arg0 context.Context, arg1 *idl.RestartAgentsRequest) (*idl.RestartAgentsReply, error) {
 	m.ctrl.T.Helper()
@@ -950,6 +1163,20 @@ func (mr *MockCliToHubServerMockRecorder) StopServices(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopServices", reflect.TypeOf((*MockCliToHubServer)(nil).StopServices), arg0, arg1)
 }
 
+// Subscribe mocks base method.
+func (m *MockCliToHubServer) Subscribe(arg0 *idl.SubscribeRequest, arg1 idl.CliToHub_SubscribeServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockCliToHubServerMockRecorder) Subscribe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockCliToHubServer)(nil).Subscribe), arg0, arg1)
+}
+
 // MockUnsafeCliToHubServer is a mock of UnsafeCliToHubServer interface.
 type MockUnsafeCliToHubServer struct {
 	ctrl     *gomock.Controller
@@ -1579,3 +1806,122 @@ func (mr *MockCliToHub_RevertServerMockRecorder) SetTrailer(arg0 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockCliToHub_RevertServer)(nil).SetTrailer), arg0)
 }
+
+// MockCliToHub_SubscribeServer is a mock of CliToHub_SubscribeServer interface.
+type MockCliToHub_SubscribeServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCliToHub_SubscribeServerMockRecorder
+}
+
+// MockCliToHub_SubscribeServerMockRecorder is the mock recorder for MockCliToHub_SubscribeServer.
+type MockCliToHub_SubscribeServerMockRecorder struct {
+	mock *MockCliToHub_SubscribeServer
+}
+
+// NewMockCliToHub_SubscribeServer creates a new mock instance.
+func NewMockCliToHub_SubscribeServer(ctrl *gomock.Controller) *MockCliToHub_SubscribeServer {
+	mock := &MockCliToHub_SubscribeServer{ctrl: ctrl}
+	mock.recorder = &MockCliToHub_SubscribeServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCliToHub_SubscribeServer) EXPECT() *MockCliToHub_SubscribeServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockCliToHub_SubscribeServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockCliToHub_SubscribeServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockCliToHub_SubscribeServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockCliToHub_SubscribeServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockCliToHub_SubscribeServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockCliToHub_SubscribeServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockCliToHub_SubscribeServer) Send(arg0 *idl.SubscribeReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockCliToHub_SubscribeServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockCliToHub_SubscribeServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockCliToHub_SubscribeServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockCliToHub_SubscribeServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockCliToHub_SubscribeServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockCliToHub_SubscribeServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockCliToHub_SubscribeServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockCliToHub_SubscribeServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockCliToHub_SubscribeServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockCliToHub_SubscribeServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockCliToHub_SubscribeServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockCliToHub_SubscribeServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockCliToHub_SubscribeServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockCliToHub_SubscribeServer)(nil).SetTrailer), arg0)
+}
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	idl "github.com/greenplum-db/gpupgrade/idl"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
 // MockAgentClient is a mock of AgentClient interface.
@@ -76,6 +77,26 @@ func (mr *MockAgentClientMockRecorder) ArchiveLogDirectory(ctx, in interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveLogDirectory", reflect.TypeOf((*MockAgentClient)(nil).ArchiveLogDirectory), varargs...)
 }
 
+// BaseBackupMirrors mocks base method.
+func (m *MockAgentClient) BaseBackupMirrors(ctx context.Context, in *idl.BaseBackupMirrorsRequest, opts ...grpc.CallOption) (*idl.BaseBackupMirrorsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BaseBackupMirrors", varargs...)
+	ret0, _ := ret[0].(*idl.BaseBackupMirrorsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BaseBackupMirrors indicates an expected call of BaseBackupMirrors.
+func (mr *MockAgentClientMockRecorder) BaseBackupMirrors(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseBackupMirrors", reflect.TypeOf((*MockAgentClient)(nil).BaseBackupMirrors), varargs...)
+}
+
 // CheckDiskSpace mocks base method.
 func (m *MockAgentClient) CheckDiskSpace(ctx context.Context, in *idl.CheckSegmentDiskSpaceRequest, opts ...grpc.CallOption) (*idl.CheckDiskSpaceReply, error) {
 	m.ctrl.T.Helper()
@@ -216,6 +237,86 @@ func (mr *MockAgentClientMockRecorder) DeleteTablespaceDirectories(ctx, in inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTablespaceDirectories", reflect.TypeOf((*MockAgentClient)(nil).DeleteTablespaceDirectories), varargs...)
 }
 
+// GetEnvironment mocks base method.
+func (m *MockAgentClient) GetEnvironment(ctx context.Context, in *idl.GetEnvironmentRequest, opts ...grpc.CallOption) (*idl.GetEnvironmentReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetEnvironment", varargs...)
+	ret0, _ := ret[0].(*idl.GetEnvironmentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnvironment indicates an expected call of GetEnvironment.
+func (mr *MockAgentClientMockRecorder) GetEnvironment(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironment", reflect.TypeOf((*MockAgentClient)(nil).GetEnvironment), varargs...)
+}
+
+// GetPgHbaConfs mocks base method.
+func (m *MockAgentClient) GetPgHbaConfs(ctx context.Context, in *idl.GetPgHbaConfsRequest, opts ...grpc.CallOption) (*idl.GetPgHbaConfsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPgHbaConfs", varargs...)
+	ret0, _ := ret[0].(*idl.GetPgHbaConfsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPgHbaConfs indicates an expected call of GetPgHbaConfs.
+func (mr *MockAgentClientMockRecorder) GetPgHbaConfs(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPgHbaConfs", reflect.TypeOf((*MockAgentClient)(nil).GetPgHbaConfs), varargs...)
+}
+
+// GetPostgresqlConfs mocks base method.
+func (m *MockAgentClient) GetPostgresqlConfs(ctx context.Context, in *idl.GetPostgresqlConfsRequest, opts ...grpc.CallOption) (*idl.GetPostgresqlConfsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPostgresqlConfs", varargs...)
+	ret0, _ := ret[0].(*idl.GetPostgresqlConfsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostgresqlConfs indicates an expected call of GetPostgresqlConfs.
+func (mr *MockAgentClientMockRecorder) GetPostgresqlConfs(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostgresqlConfs", reflect.TypeOf((*MockAgentClient)(nil).GetPostgresqlConfs), varargs...)
+}
+
+// Handshake mocks base method.
+func (m *MockAgentClient) Handshake(ctx context.Context, in *idl.HandshakeRequest, opts ...grpc.CallOption) (*idl.HandshakeReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Handshake", varargs...)
+	ret0, _ := ret[0].(*idl.HandshakeReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Handshake indicates an expected call of Handshake.
+func (mr *MockAgentClientMockRecorder) Handshake(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handshake", reflect.TypeOf((*MockAgentClient)(nil).Handshake), varargs...)
+}
+
 // RenameDirectories mocks base method.
 func (m *MockAgentClient) RenameDirectories(ctx context.Context, in *idl.RenameDirectoriesRequest, opts ...grpc.CallOption) (*idl.RenameDirectoriesReply, error) {
 	m.ctrl.T.Helper()
@@ -376,6 +477,169 @@ func (mr *MockAgentClientMockRecorder) UpgradePrimaries(ctx, in interface{}, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradePrimaries", reflect.TypeOf((*MockAgentClient)(nil).UpgradePrimaries), varargs...)
 }
 
+// WatchDiskSpace mocks base method.
+func (m *MockAgentClient) WatchDiskSpace(ctx context.Context, in *idl.WatchDiskSpaceRequest, opts ...grpc.CallOption) (idl.Agent_WatchDiskSpaceClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchDiskSpace", varargs...)
+	ret0, _ := ret[0].(idl.Agent_WatchDiskSpaceClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchDiskSpace indicates an expected call of WatchDiskSpace.
+func (mr *MockAgentClientMockRecorder) WatchDiskSpace(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchDiskSpace", reflect.TypeOf((*MockAgentClient)(nil).WatchDiskSpace), varargs...)
+}
+
+// WritePgHbaConfs mocks base method.
+func (m *MockAgentClient) WritePgHbaConfs(ctx context.Context, in *idl.WritePgHbaConfsRequest, opts ...grpc.CallOption) (*idl.WritePgHbaConfsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WritePgHbaConfs", varargs...)
+	ret0, _ := ret[0].(*idl.WritePgHbaConfsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WritePgHbaConfs indicates an expected call of WritePgHbaConfs.
+func (mr *MockAgentClientMockRecorder) WritePgHbaConfs(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WritePgHbaConfs", reflect.TypeOf((*MockAgentClient)(nil).WritePgHbaConfs), varargs...)
+}
+
+// MockAgent_WatchDiskSpaceClient is a mock of Agent_WatchDiskSpaceClient interface.
+type MockAgent_WatchDiskSpaceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_WatchDiskSpaceClientMockRecorder
+}
+
+// MockAgent_WatchDiskSpaceClientMockRecorder is the mock recorder for MockAgent_WatchDiskSpaceClient.
+type MockAgent_WatchDiskSpaceClientMockRecorder struct {
+	mock *MockAgent_WatchDiskSpaceClient
+}
+
+// NewMockAgent_WatchDiskSpaceClient creates a new mock instance.
+func NewMockAgent_WatchDiskSpaceClient(ctrl *gomock.Controller) *MockAgent_WatchDiskSpaceClient {
+	mock := &MockAgent_WatchDiskSpaceClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_WatchDiskSpaceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_WatchDiskSpaceClient) EXPECT() *MockAgent_WatchDiskSpaceClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockAgent_WatchDiskSpaceClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_WatchDiskSpaceClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_WatchDiskSpaceClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_WatchDiskSpaceClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_WatchDiskSpaceClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_WatchDiskSpaceClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_WatchDiskSpaceClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAgent_WatchDiskSpaceClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_WatchDiskSpaceClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_WatchDiskSpaceClient) Recv() (*idl.WatchDiskSpaceReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.WatchDiskSpaceReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_WatchDiskSpaceClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_WatchDiskSpaceClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_WatchDiskSpaceClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_WatchDiskSpaceClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_WatchDiskSpaceClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_WatchDiskSpaceClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_WatchDiskSpaceClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_WatchDiskSpaceClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_WatchDiskSpaceClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_WatchDiskSpaceClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_WatchDiskSpaceClient)(nil).Trailer))
+}
+
 // MockAgentServer is a mock of AgentServer interface.
 type MockAgentServer struct {
 	ctrl     *gomock.Controller
@@ -429,6 +693,21 @@ func (mr *MockAgentServerMockRecorder) ArchiveLogDirectory(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveLogDirectory", reflect.TypeOf((*MockAgentServer)(nil).ArchiveLogDirectory), arg0, arg1)
 }
 
+// BaseBackupMirrors mocks base method.
+func (m *MockAgentServer) BaseBackupMirrors(arg0 context.Context, arg1 *idl.BaseBackupMirrorsRequest) (*idl.BaseBackupMirrorsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseBackupMirrors", arg0, arg1)
+	ret0, _ := ret[0].(*idl.BaseBackupMirrorsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BaseBackupMirrors indicates an expected call of BaseBackupMirrors.
+func (mr *MockAgentServerMockRecorder) BaseBackupMirrors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseBackupMirrors", reflect.TypeOf((*MockAgentServer)(nil).BaseBackupMirrors), arg0, arg1)
+}
+
 // CheckDiskSpace mocks base method.
 func (m *MockAgentServer) CheckDiskSpace(arg0 context.Context, arg1 *idl.CheckSegmentDiskSpaceRequest) (*idl.CheckDiskSpaceReply, error) {
 	m.ctrl.T.Helper()
@@ -534,6 +813,66 @@ func (mr *MockAgentServerMockRecorder) DeleteTablespaceDirectories(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTablespaceDirectories", reflect.TypeOf((*MockAgentServer)(nil).DeleteTablespaceDirectories), arg0, arg1)
 }
 
+// GetEnvironment mocks base method.
+func (m *MockAgentServer) GetEnvironment(arg0 context.Context, arg1 *idl.GetEnvironmentRequest) (*idl.GetEnvironmentReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvironment", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetEnvironmentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnvironment indicates an expected call of GetEnvironment.
+func (mr *MockAgentServerMockRecorder) GetEnvironment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironment", reflect.TypeOf((*MockAgentServer)(nil).GetEnvironment), arg0, arg1)
+}
+
+// GetPgHbaConfs mocks base method.
+func (m *MockAgentServer) GetPgHbaConfs(arg0 context.Context, arg1 *idl.GetPgHbaConfsRequest) (*idl.GetPgHbaConfsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPgHbaConfs", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetPgHbaConfsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPgHbaConfs indicates an expected call of GetPgHbaConfs.
+func (mr *MockAgentServerMockRecorder) GetPgHbaConfs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPgHbaConfs", reflect.TypeOf((*MockAgentServer)(nil).GetPgHbaConfs), arg0, arg1)
+}
+
+// GetPostgresqlConfs mocks base method.
+func (m *MockAgentServer) GetPostgresqlConfs(arg0 context.Context, arg1 *idl.GetPostgresqlConfsRequest) (*idl.GetPostgresqlConfsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostgresqlConfs", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetPostgresqlConfsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostgresqlConfs indicates an expected call of GetPostgresqlConfs.
+func (mr *MockAgentServerMockRecorder) GetPostgresqlConfs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostgresqlConfs", reflect.TypeOf((*MockAgentServer)(nil).GetPostgresqlConfs), arg0, arg1)
+}
+
+// Handshake mocks base method.
+func (m *MockAgentServer) Handshake(arg0 context.Context, arg1 *idl.HandshakeRequest) (*idl.HandshakeReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handshake", arg0, arg1)
+	ret0, _ := ret[0].(*idl.HandshakeReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Handshake indicates an expected call of Handshake.
+func (mr *MockAgentServerMockRecorder) Handshake(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handshake", reflect.TypeOf((*MockAgentServer)(nil).Handshake), arg0, arg1)
+}
+
 // RenameDirectories mocks base method.
 func (m *MockAgentServer) RenameDirectories(arg0 context.Context, arg1 *idl.RenameDirectoriesRequest) (*idl.RenameDirectoriesReply, error) {
 	m.ctrl.T.Helper()
@@ -654,6 +993,35 @@ func (mr *MockAgentServerMockRecorder) UpgradePrimaries(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradePrimaries", reflect.TypeOf((*MockAgentServer)(nil).UpgradePrimaries), arg0, arg1)
 }
 
+// WatchDiskSpace mocks base method.
+func (m *MockAgentServer) WatchDiskSpace(arg0 *idl.WatchDiskSpaceRequest, arg1 idl.Agent_WatchDiskSpaceServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchDiskSpace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchDiskSpace indicates an expected call of WatchDiskSpace.
+func (mr *MockAgentServerMockRecorder) WatchDiskSpace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchDiskSpace", reflect.TypeOf((*MockAgentServer)(nil).WatchDiskSpace), arg0, arg1)
+}
+
+// WritePgHbaConfs mocks base method.
+func (m *MockAgentServer) WritePgHbaConfs(arg0 context.Context, arg1 *idl.WritePgHbaConfsRequest) (*idl.WritePgHbaConfsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WritePgHbaConfs", arg0, arg1)
+	ret0, _ := ret[0].(*idl.WritePgHbaConfsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WritePgHbaConfs indicates an expected call of WritePgHbaConfs.
+func (mr *MockAgentServerMockRecorder) WritePgHbaConfs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WritePgHbaConfs", reflect.TypeOf((*MockAgentServer)(nil).WritePgHbaConfs), arg0, arg1)
+}
+
 // MockUnsafeAgentServer is a mock of UnsafeAgentServer interface.
 type MockUnsafeAgentServer struct {
 	ctrl     *gomock.Controller
@@ -688,3 +1056,122 @@ func (mr *MockUnsafeAgentServerMockRecorder) mustEmbedUnimplementedAgentServer()
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAgentServer", reflect.TypeOf((*MockUnsafeAgentServer)(nil).mustEmbedUnimplementedAgentServer))
 }
+
+// MockAgent_WatchDiskSpaceServer is a mock of Agent_WatchDiskSpaceServer interface.
+type MockAgent_WatchDiskSpaceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_WatchDiskSpaceServerMockRecorder
+}
+
+// MockAgent_WatchDiskSpaceServerMockRecorder is the mock recorder for MockAgent_WatchDiskSpaceServer.
+type MockAgent_WatchDiskSpaceServerMockRecorder struct {
+	mock *MockAgent_WatchDiskSpaceServer
+}
+
+// NewMockAgent_WatchDiskSpaceServer creates a new mock instance.
+func NewMockAgent_WatchDiskSpaceServer(ctrl *gomock.Controller) *MockAgent_WatchDiskSpaceServer {
+	mock := &MockAgent_WatchDiskSpaceServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_WatchDiskSpaceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_WatchDiskSpaceServer) EXPECT() *MockAgent_WatchDiskSpaceServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_WatchDiskSpaceServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_WatchDiskSpaceServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_WatchDiskSpaceServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_WatchDiskSpaceServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_WatchDiskSpaceServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_WatchDiskSpaceServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_WatchDiskSpaceServer) Send(arg0 *idl.WatchDiskSpaceReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_WatchDiskSpaceServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_WatchDiskSpaceServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_WatchDiskSpaceServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_WatchDiskSpaceServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_WatchDiskSpaceServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_WatchDiskSpaceServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_WatchDiskSpaceServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_WatchDiskSpaceServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_WatchDiskSpaceServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_WatchDiskSpaceServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_WatchDiskSpaceServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_WatchDiskSpaceServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_WatchDiskSpaceServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_WatchDiskSpaceServer)(nil).SetTrailer), arg0)
+}
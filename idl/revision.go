@@ -0,0 +1,26 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package idl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+)
+
+// Revision identifies the hub to agent protocol compiled into this binary. It
+// is a hash of the proto definition so a hub and agent built from different
+// protos never match even when reporting the same gpupgrade version.
+func Revision() string {
+	desc := protodesc.ToFileDescriptorProto(File_hub_to_agent_proto)
+	contents, err := proto.MarshalOptions{Deterministic: true}.Marshal(desc)
+	if err != nil {
+		return "unknown"
+	}
+
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,453 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+type Event string
+
+const (
+	StepStarted      Event = "step_started"
+	StepCompleted    Event = "step_completed"
+	StepFailed       Event = "step_failed"
+	SubstepCompleted Event = "substep_completed"
+	SubstepFailed    Event = "substep_failed"
+)
+
+var AllEvents = []Event{StepStarted, StepCompleted, StepFailed, SubstepCompleted, SubstepFailed}
+
+// DefaultEvents notify when a step finishes and when anything fails.
+var DefaultEvents = []Event{StepCompleted, StepFailed, SubstepFailed}
+
+// ParseEvents parses a comma separated list of events such as
+// "step_failed,step_completed". An empty list returns DefaultEvents.
+func ParseEvents(input string) ([]Event, error) {
+	if strings.TrimSpace(input) == "" {
+		return DefaultEvents, nil
+	}
+
+	var events []Event
+	for _, name := range strings.Split(input, ",") {
+		event := Event(strings.TrimSpace(name))
+		if !contains(AllEvents, event) {
+			return nil, xerrors.Errorf("invalid notification event %q. Expected one of %s.", event, AllEvents)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func contains(events []Event, event Event) bool {
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Config selects which events are delivered to webhooks and the command.
+type Config struct {
+	Webhooks []string
+	Command  string
+	Events   []Event
+	Retries  int
+}
+
+func (c Config) Enabled() bool {
+	return len(c.Webhooks) > 0 || c.Command != ""
+}
+
+// Payload is POSTed as JSON to each webhook and written to the command's
+// stdin.
+type Payload struct {
+	UpgradeID   string    `json:"upgrade_id"`
+	Event       Event     `json:"event"`
+	Step        string    `json:"step"`
+	Substep     string    `json:"substep,omitempty"`
+	Status      string    `json:"status"`
+	Host        string    `json:"host"`
+	Error       string    `json:"error,omitempty"`
+	NextActions string    `json:"next_actions,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// queueSize bounds the undelivered notifications. Notifications are dropped
+// rather than blocking the upgrade when a target is unreachable.
+const queueSize = 100
+
+var retryInterval = time.Second
+
+// XXX: for internal testing only
+func SetRetryInterval(interval time.Duration) {
+	retryInterval = interval
+}
+
+// XXX: for internal testing only
+func ResetRetryInterval() {
+	retryInterval = time.Second
+}
+
+// Notifier delivers notifications in order in the background such that slow
+// or unreachable targets do not delay the upgrade.
+type Notifier struct {
+	config    Config
+	upgradeID string
+	host      string
+	logPath   string
+	client    *http.Client
+
+	mutex    sync.Mutex
+	closed   bool
+	inFlight *Payload
+	queue    chan Payload
+	done     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// New returns a notifier which records each delivery attempt to logPath.
+func New(config Config, upgradeID string, logPath string) *Notifier {
+	host, err := os.Hostname()
+	if err != nil {
+		log.Printf("get hostname for notifications: %v", err)
+	}
+
+	n := &Notifier{
+		config:    config,
+		upgradeID: upgradeID,
+		host:      host,
+		logPath:   logPath,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		queue:     make(chan Payload, queueSize),
+		done:      make(chan struct{}),
+		stop:      make(chan struct{}),
+	}
+
+	if !config.Enabled() {
+		close(n.done)
+		return n
+	}
+
+	go n.deliverAll()
+	return n
+}
+
+// Notify queues the payload for delivery if its event is selected.
+func (n *Notifier) Notify(payload Payload) {
+	if !n.config.Enabled() || !contains(n.config.Events, payload.Event) {
+		return
+	}
+
+	payload.UpgradeID = n.upgradeID
+	payload.Host = n.host
+	payload.Time = time.Now()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.closed {
+		n.record(payload, "queue", 0, xerrors.New("dropped since the notifier is closed"))
+		return
+	}
+
+	select {
+	case n.queue <- payload:
+	default:
+		n.record(payload, "queue", 0, xerrors.New("dropped since too many notifications are pending"))
+	}
+}
+
+// errUndelivered records notifications abandoned when Close times out.
+var errUndelivered = xerrors.New("undelivered since the notifier closed before delivering it")
+
+// Close waits up to timeout for the queued notifications to be delivered. Any
+// notifications still undelivered are recorded to the delivery log and
+// abandoned such that an unreachable target does not delay stopping the hub.
+func (n *Notifier) Close(timeout time.Duration) {
+	n.mutex.Lock()
+	if n.config.Enabled() && !n.closed {
+		close(n.queue)
+	}
+	n.closed = true
+	n.mutex.Unlock()
+
+	select {
+	case <-n.done:
+		return
+	case <-time.After(timeout):
+	}
+
+	n.stopOnce.Do(func() { close(n.stop) })
+
+	n.mutex.Lock()
+	inFlight := n.inFlight
+	n.mutex.Unlock()
+
+	if inFlight != nil {
+		n.record(*inFlight, "close", 0, errUndelivered)
+	}
+
+	for payload := range n.queue {
+		n.record(payload, "close", 0, errUndelivered)
+	}
+}
+
+func (n *Notifier) deliverAll() {
+	defer close(n.done)
+
+	for payload := range n.queue {
+		select {
+		case <-n.stop:
+			n.record(payload, "close", 0, errUndelivered)
+			continue
+		default:
+		}
+
+		n.setInFlight(&payload)
+		n.deliver(payload)
+		n.setInFlight(nil)
+	}
+}
+
+func (n *Notifier) setInFlight(payload *Payload) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.inFlight = payload
+}
+
+func (n *Notifier) deliver(payload Payload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		n.record(payload, "marshal", 0, err)
+		return
+	}
+
+	for _, url := range n.config.Webhooks {
+		n.retry(payload, url, func() error {
+			return n.post(url, body)
+		})
+	}
+
+	if n.config.Command != "" {
+		n.retry(payload, n.config.Command, func() error {
+			return run(n.config.Command, payload, body)
+		})
+	}
+}
+
+func (n *Notifier) retry(payload Payload, target string, deliver func() error) {
+	interval := retryInterval
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-n.stop:
+			return
+		default:
+		}
+
+		err := deliver()
+		n.record(payload, target, attempt, err)
+		if err == nil || attempt > n.config.Retries {
+			return
+		}
+
+		select {
+		case <-n.stop:
+			return
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+}
+
+func (n *Notifier) post(url string, body []byte) error {
+	response, err := n.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return xerrors.Errorf("unexpected response %q", response.Status)
+	}
+
+	return nil
+}
+
+// run passes the payload on stdin and its fields as environment variables.
+func run(command string, payload Payload, body []byte) error {
+	cmd := exec.Command("bash", "-c", command)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"GPUPGRADE_EVENT="+string(payload.Event),
+		"GPUPGRADE_STEP="+payload.Step,
+		"GPUPGRADE_SUBSTEP="+payload.Substep,
+		"GPUPGRADE_STATUS="+payload.Status,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return xerrors.Errorf("%q: %w: %s", command, err, output)
+	}
+
+	return nil
+}
+
+// record appends each delivery attempt to the delivery log.
+func (n *Notifier) record(payload Payload, target string, attempt int, err error) {
+	result := "delivered"
+	if err != nil {
+		result = "failed: " + err.Error()
+	}
+
+	if attempt > 0 {
+		result = fmt.Sprintf("attempt %d of %d %s", attempt, n.config.Retries+1, result)
+	}
+
+	line := fmt.Sprintf("%s %s %s %s %s\n", time.Now().Format(time.RFC3339), payload.Event, payload.Step, target, result)
+	log.Print("notification: " + line)
+
+	if n.logPath == "" {
+		return
+	}
+
+	file, oErr := utils.System.OpenFile(n.logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if oErr != nil {
+		log.Printf("open notification log: %v", oErr)
+		return
+	}
+	defer file.Close()
+
+	if _, wErr := file.WriteString(line); wErr != nil {
+		log.Printf("write notification log: %v", wErr)
+	}
+}
+
+// Step notifies the start of the step and returns a sender which notifies
+// substep status changes in addition to sending them.
+func (n *Notifier) Step(step idl.Step, sender idl.MessageSender) *StepNotifier {
+	n.Notify(Payload{Event: StepStarted, Step: step.String(), Status: idl.Status_running.String()})
+	return n.ContinueStep(step, sender)
+}
+
+// ContinueStep is used for the later requests of a step which the CLI splits
+// across several requests such as initialize.
+func (n *Notifier) ContinueStep(step idl.Step, sender idl.MessageSender) *StepNotifier {
+	return &StepNotifier{notifier: n, step: step, sender: sender}
+}
+
+type StepNotifier struct {
+	notifier *Notifier
+	step     idl.Step
+	sender   idl.MessageSender
+
+	mutex  sync.Mutex
+	failed idl.Substep
+}
+
+func (s *StepNotifier) Send(msg *idl.Message) error {
+	err := s.sender.Send(msg)
+
+	substep := msg.GetStatus()
+	if substep == nil {
+		return err
+	}
+
+	payload := Payload{Step: s.step.String(), Substep: substep.GetStep().String(), Status: substep.GetStatus().String()}
+	switch substep.GetStatus() {
+	case idl.Status_complete:
+		payload.Event = SubstepCompleted
+	case idl.Status_failed, idl.Status_quit:
+		payload.Event = SubstepFailed
+
+		s.mutex.Lock()
+		s.failed = substep.GetStep()
+		s.mutex.Unlock()
+	default:
+		return err
+	}
+
+	s.notifier.Notify(payload)
+	return err
+}
+
+// Finish notifies whether the step completed or failed and returns err.
+func (s *StepNotifier) Finish(err error) error {
+	if err == nil {
+		s.notifier.Notify(Payload{Event: StepCompleted, Step: s.step.String(), Status: idl.Status_complete.String()})
+		return nil
+	}
+
+	return s.Fail(err)
+}
+
+// Fail notifies if the step failed and returns err. It is used rather than
+// Finish when the step continues in a later request. The failure includes the
+// substep that failed along with the error and next actions.
+func (s *StepNotifier) Fail(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	s.mutex.Lock()
+	failed := s.failed
+	s.mutex.Unlock()
+
+	payload := Payload{Event: StepFailed, Step: s.step.String(), Status: idl.Status_failed.String()}
+	if failed != idl.Substep_unknown_substep {
+		payload.Substep = failed.String()
+	}
+
+	payload.Error, payload.NextActions = describe(err)
+	s.notifier.Notify(payload)
+	return err
+}
+
+// describe returns the error message and next actions of either a
+// utils.NextActionErr or the gRPC status returned by step.Step.Err.
+func describe(err error) (string, string) {
+	var nextActionErr utils.NextActionErr
+	if errors.As(err, &nextActionErr) {
+		return nextActionErr.Err.Error(), nextActionErr.NextAction
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err.Error(), ""
+	}
+
+	var nextActions []string
+	for _, detail := range st.Details() {
+		if msg, ok := detail.(*idl.NextActions); ok {
+			nextActions = append(nextActions, msg.GetNextActions())
+		}
+	}
+
+	return st.Message(), strings.Join(nextActions, "\n")
+}
@@ -0,0 +1,364 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package notify_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/notify"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// webhook is a local stand-in for a webhook which records the payloads it
+// receives and fails the first failures requests.
+type webhook struct {
+	mutex    sync.Mutex
+	payloads []notify.Payload
+	requests int
+	failures int
+}
+
+func (w *webhook) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.requests++
+	if w.requests <= w.failures {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	var payload notify.Payload
+	if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.payloads = append(w.payloads, payload)
+}
+
+func (w *webhook) Events() []notify.Event {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var events []notify.Event
+	for _, payload := range w.payloads {
+		events = append(events, payload.Event)
+	}
+
+	return events
+}
+
+func statusMessage(substep idl.Substep, status idl.Status) *idl.Message {
+	return &idl.Message{Contents: &idl.Message_Status{Status: &idl.SubstepStatus{Step: substep, Status: status}}}
+}
+
+func TestParseEvents(t *testing.T) {
+	t.Run("defaults to step completion and failures", func(t *testing.T) {
+		events, err := notify.ParseEvents("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(events, notify.DefaultEvents) {
+			t.Errorf("got %v want %v", events, notify.DefaultEvents)
+		}
+	})
+
+	t.Run("parses a list of events", func(t *testing.T) {
+		events, err := notify.ParseEvents("step_started, substep_completed")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []notify.Event{notify.StepStarted, notify.SubstepCompleted}
+		if !reflect.DeepEqual(events, expected) {
+			t.Errorf("got %v want %v", events, expected)
+		}
+	})
+
+	t.Run("errors on unknown events", func(t *testing.T) {
+		_, err := notify.ParseEvents("step_failed,upgrade_done")
+		if err == nil || !strings.Contains(err.Error(), `"upgrade_done"`) {
+			t.Errorf("got error %v want an invalid event error", err)
+		}
+	})
+}
+
+func TestNotifier(t *testing.T) {
+	notify.SetRetryInterval(time.Millisecond)
+	defer notify.ResetRetryInterval()
+
+	logDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, logDir)
+
+	logPath := filepath.Join(logDir, "notifications.log")
+
+	t.Run("posts the step and substep events to each webhook", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first, second := &webhook{}, &webhook{}
+		firstServer, secondServer := httptest.NewServer(first), httptest.NewServer(second)
+		defer firstServer.Close()
+		defer secondServer.Close()
+
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		sender.EXPECT().Send(gomock.Any()).Times(3)
+
+		notifier := notify.New(notify.Config{
+			Webhooks: []string{firstServer.URL, secondServer.URL},
+			Events:   notify.AllEvents,
+		}, "ABC123", logPath)
+
+		notifications := notifier.Step(idl.Step_execute, sender)
+		_ = notifications.Send(statusMessage(idl.Substep_upgrade_master, idl.Status_running))
+		_ = notifications.Send(statusMessage(idl.Substep_upgrade_master, idl.Status_complete))
+		_ = notifications.Send(statusMessage(idl.Substep_upgrade_primaries, idl.Status_failed))
+
+		stepErr := status.New(codes.Internal, `substep "upgrade_primaries": pg_upgrade failed`)
+		stepErr, err := stepErr.WithDetails(&idl.NextActions{NextActions: `Run "gpupgrade revert".`})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = notifications.Finish(stepErr.Err())
+		if err == nil {
+			t.Error("expected the step error to be returned")
+		}
+
+		notifier.Close(time.Minute)
+
+		expected := []notify.Event{notify.StepStarted, notify.SubstepCompleted, notify.SubstepFailed, notify.StepFailed}
+		for _, hook := range []*webhook{first, second} {
+			if !reflect.DeepEqual(hook.Events(), expected) {
+				t.Errorf("got events %v want %v", hook.Events(), expected)
+			}
+		}
+
+		failed := first.payloads[len(first.payloads)-1]
+		hostname, _ := os.Hostname()
+		if failed.UpgradeID != "ABC123" || failed.Step != "execute" || failed.Substep != "upgrade_primaries" ||
+			failed.Status != "failed" || failed.Host != hostname ||
+			failed.Error != `substep "upgrade_primaries": pg_upgrade failed` ||
+			failed.NextActions != `Run "gpupgrade revert".` {
+			t.Errorf("got payload %+v", failed)
+		}
+	})
+
+	t.Run("only notifies of the selected events", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hook := &webhook{}
+		server := httptest.NewServer(hook)
+		defer server.Close()
+
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		sender.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		notifier := notify.New(notify.Config{Webhooks: []string{server.URL}, Events: notify.DefaultEvents}, "", logPath)
+
+		notifications := notifier.Step(idl.Step_finalize, sender)
+		_ = notifications.Send(statusMessage(idl.Substep_upgrade_mirrors, idl.Status_complete))
+		_ = notifications.Finish(nil)
+		notifier.Close(time.Minute)
+
+		expected := []notify.Event{notify.StepCompleted}
+		if !reflect.DeepEqual(hook.Events(), expected) {
+			t.Errorf("got events %v want %v", hook.Events(), expected)
+		}
+	})
+
+	t.Run("does not notify of completion for a step continued in a later request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hook := &webhook{}
+		server := httptest.NewServer(hook)
+		defer server.Close()
+
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+
+		notifier := notify.New(notify.Config{Webhooks: []string{server.URL}, Events: notify.AllEvents}, "", logPath)
+		_ = notifier.Step(idl.Step_initialize, sender).Fail(nil)
+		_ = notifier.ContinueStep(idl.Step_initialize, sender).Finish(nil)
+		notifier.Close(time.Minute)
+
+		expected := []notify.Event{notify.StepStarted, notify.StepCompleted}
+		if !reflect.DeepEqual(hook.Events(), expected) {
+			t.Errorf("got events %v want %v", hook.Events(), expected)
+		}
+	})
+
+	t.Run("retries failed deliveries and records each attempt", func(t *testing.T) {
+		testutils.MustWriteToFile(t, logPath, "")
+
+		hook := &webhook{failures: 2}
+		server := httptest.NewServer(hook)
+		defer server.Close()
+
+		notifier := notify.New(notify.Config{Webhooks: []string{server.URL}, Events: notify.AllEvents, Retries: 3}, "", logPath)
+		notifier.Notify(notify.Payload{Event: notify.StepFailed, Step: "revert"})
+		notifier.Close(time.Minute)
+
+		if len(hook.payloads) != 1 {
+			t.Errorf("got %d payloads want 1", len(hook.payloads))
+		}
+
+		contents := testutils.MustReadFile(t, logPath)
+		for _, expected := range []string{
+			"step_failed revert " + server.URL + ` attempt 1 of 4 failed: unexpected response "503 Service Unavailable"`,
+			"step_failed revert " + server.URL + ` attempt 2 of 4 failed`,
+			"step_failed revert " + server.URL + " attempt 3 of 4 delivered",
+		} {
+			if !strings.Contains(contents, expected) {
+				t.Errorf("expected delivery log %q to contain %q", contents, expected)
+			}
+		}
+	})
+
+	t.Run("gives up after the retries are exhausted", func(t *testing.T) {
+		testutils.MustWriteToFile(t, logPath, "")
+
+		hook := &webhook{failures: 10}
+		server := httptest.NewServer(hook)
+		defer server.Close()
+
+		notifier := notify.New(notify.Config{Webhooks: []string{server.URL}, Events: notify.AllEvents, Retries: 1}, "", logPath)
+		notifier.Notify(notify.Payload{Event: notify.StepFailed, Step: "revert"})
+		notifier.Close(time.Minute)
+
+		if hook.requests != 2 {
+			t.Errorf("got %d requests want 2", hook.requests)
+		}
+
+		contents := testutils.MustReadFile(t, logPath)
+		if !strings.Contains(contents, "attempt 2 of 2 failed") {
+			t.Errorf("expected delivery log %q to record the last attempt", contents)
+		}
+	})
+
+	t.Run("records undelivered notifications when closing times out", func(t *testing.T) {
+		testutils.MustWriteToFile(t, logPath, "")
+
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+
+		notifier := notify.New(notify.Config{Webhooks: []string{server.URL}, Events: notify.AllEvents, Retries: 3}, "", logPath)
+		notifier.Notify(notify.Payload{Event: notify.StepStarted, Step: "execute"})
+		notifier.Notify(notify.Payload{Event: notify.StepFailed, Step: "execute"})
+
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			notifier.Close(10 * time.Millisecond)
+		}()
+
+		select {
+		case <-closed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected close to return once its timeout expired")
+		}
+
+		contents := testutils.MustReadFile(t, logPath)
+		for _, expected := range []string{
+			"step_started execute close failed: undelivered",
+			"step_failed execute close failed: undelivered",
+		} {
+			if !strings.Contains(contents, expected) {
+				t.Errorf("expected delivery log %q to contain %q", contents, expected)
+			}
+		}
+	})
+
+	t.Run("runs the command with the payload on stdin", func(t *testing.T) {
+		output := filepath.Join(logDir, "command_output")
+
+		notifier := notify.New(notify.Config{
+			Command: `echo "$GPUPGRADE_EVENT $GPUPGRADE_STEP $GPUPGRADE_SUBSTEP $GPUPGRADE_STATUS" > ` + output + ` && cat >> ` + output,
+			Events:  notify.AllEvents,
+		}, "ABC123", logPath)
+		notifier.Notify(notify.Payload{Event: notify.SubstepFailed, Step: "execute", Substep: "upgrade_master", Status: "failed"})
+		notifier.Close(time.Minute)
+
+		contents := testutils.MustReadFile(t, output)
+		lines := strings.SplitN(contents, "\n", 2)
+		if lines[0] != "substep_failed execute upgrade_master failed" {
+			t.Errorf("got environment %q", lines[0])
+		}
+
+		var payload notify.Payload
+		if err := json.Unmarshal([]byte(lines[1]), &payload); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if payload.UpgradeID != "ABC123" || payload.Substep != "upgrade_master" {
+			t.Errorf("got payload %+v", payload)
+		}
+	})
+
+	t.Run("does nothing when no targets are configured", func(t *testing.T) {
+		notifier := notify.New(notify.Config{Events: notify.AllEvents}, "", logPath)
+		notifier.Notify(notify.Payload{Event: notify.StepFailed})
+		notifier.Close(time.Minute)
+	})
+
+	t.Run("records failures to write the delivery log", func(t *testing.T) {
+		utils.System.OpenFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
+			return nil, os.ErrPermission
+		}
+		defer utils.ResetSystemFunctions()
+
+		hook := &webhook{}
+		server := httptest.NewServer(hook)
+		defer server.Close()
+
+		notifier := notify.New(notify.Config{Webhooks: []string{server.URL}, Events: notify.AllEvents}, "", logPath)
+		notifier.Notify(notify.Payload{Event: notify.StepCompleted})
+		notifier.Close(time.Minute)
+
+		if len(hook.payloads) != 1 {
+			t.Errorf("expected the notification to be delivered when the delivery log cannot be written")
+		}
+	})
+}
+
+func TestStepNotifier(t *testing.T) {
+	t.Run("returns errors from the wrapped sender", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := errors.New("transport is closing")
+		sender := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		sender.EXPECT().Send(gomock.Any()).Return(expected)
+
+		notifier := notify.New(notify.Config{}, "", "")
+		err := notifier.Step(idl.Step_execute, sender).Send(statusMessage(idl.Substep_upgrade_master, idl.Status_running))
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package statistics
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+const LedgerFileName = "analyze_ledger.json"
+
+type ledgerEntry struct {
+	Stage    string `json:"stage"`
+	Database string `json:"database"`
+	Schema   string `json:"schema"`
+	Table    string `json:"table"`
+}
+
+// Ledger records each table analyzed per stage, one JSON entry per line, such
+// that a partially written entry from an interrupted run only loses that table.
+type Ledger struct {
+	path     string
+	mu       sync.Mutex
+	file     *os.File
+	analyzed map[ledgerEntry]bool
+}
+
+func OpenLedger(path string) (*Ledger, error) {
+	ledger := &Ledger{path: path, analyzed: make(map[ledgerEntry]bool)}
+
+	contents, err := utils.System.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, xerrors.Errorf("read analyze ledger: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		var entry ledgerEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// ignore a truncated last entry
+			continue
+		}
+
+		ledger.analyzed[entry] = true
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Finalize archives the log directory holding the ledger before
+	// analyzing the target cluster.
+	if err := utils.System.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, xerrors.Errorf("create analyze ledger directory: %w", err)
+	}
+
+	ledger.file, err = utils.System.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, xerrors.Errorf("open analyze ledger: %w", err)
+	}
+
+	return ledger, nil
+}
+
+func (l *Ledger) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.analyzed)
+}
+
+func (l *Ledger) Analyzed(stage Stage, database string, table Table) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.analyzed[newLedgerEntry(stage, database, table)]
+}
+
+func (l *Ledger) Record(stage Stage, database string, table Table) error {
+	entry := newLedgerEntry(stage, database, table)
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return xerrors.Errorf("record %s in analyze ledger: %w", table, err)
+	}
+
+	l.analyzed[entry] = true
+	return nil
+}
+
+func (l *Ledger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// Remove deletes the ledger once every table has been analyzed such that the
+// next run starts over.
+func (l *Ledger) Remove() error {
+	if err := l.Close(); err != nil {
+		return err
+	}
+
+	err := utils.System.Remove(l.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func newLedgerEntry(stage Stage, database string, table Table) ledgerEntry {
+	return ledgerEntry{Stage: stage.key(), Database: database, Schema: table.Schema, Table: table.Name}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package statistics_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/statistics"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+func TestLedger(t *testing.T) {
+	t.Run("records tables per stage and database", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), statistics.LedgerFileName)
+
+		ledger, err := statistics.OpenLedger(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := ledger.Record(statistics.Stages[0], "sales", orders); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := ledger.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		ledger, err = statistics.OpenLedger(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer ledger.Close()
+
+		if !ledger.Analyzed(statistics.Stages[0], "sales", orders) {
+			t.Errorf("expected %s to be analyzed", orders)
+		}
+
+		if ledger.Analyzed(statistics.FullStage, "sales", orders) {
+			t.Errorf("expected %s to not be analyzed in %s", orders, statistics.FullStage.Name)
+		}
+
+		if ledger.Analyzed(statistics.Stages[0], "postgres", orders) {
+			t.Errorf("expected %s to not be analyzed in another database", orders)
+		}
+	})
+
+	t.Run("ignores a truncated entry", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), statistics.LedgerFileName)
+		testutils.MustWriteToFile(t, path, `{"stage":"default","database":"sales","schema":"public","table":"orders"}
+{"stage":"default","datab`)
+
+		ledger, err := statistics.OpenLedger(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer ledger.Close()
+
+		if ledger.Len() != 1 {
+			t.Errorf("got %d entries want 1", ledger.Len())
+		}
+	})
+
+	t.Run("creates the missing directory of the ledger", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gpupgrade", statistics.LedgerFileName)
+
+		ledger, err := statistics.OpenLedger(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer ledger.Close()
+
+		testutils.PathMustExist(t, path)
+	})
+
+	t.Run("remove deletes the ledger", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), statistics.LedgerFileName)
+
+		ledger, err := statistics.OpenLedger(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := ledger.Remove(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected ledger to be removed got %v", err)
+		}
+	})
+}
@@ -0,0 +1,412 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package statistics creates optimizer statistics on the upgraded target
+// cluster. Databases are analyzed in parallel, tables within a database are
+// analyzed in priority order, and each analyzed table is recorded in a ledger
+// such that an interrupted run resumes where it stopped.
+package statistics
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/errorlist"
+)
+
+type Priority string
+
+const (
+	// Size analyzes the largest tables first.
+	Size Priority = "size"
+	// Usage analyzes the most scanned tables first.
+	Usage Priority = "usage"
+	// List analyzes the tables in the table list first followed by the
+	// remaining tables by size.
+	List Priority = "list"
+)
+
+var Priorities = []Priority{Size, Usage, List}
+
+func ParsePriority(input string) (Priority, error) {
+	for _, priority := range Priorities {
+		if Priority(strings.TrimSpace(input)) == priority {
+			return priority, nil
+		}
+	}
+
+	return "", xerrors.Errorf("invalid priority %q. Please specify either %s.", input, Priorities)
+}
+
+// Stage is a pass over all tables with default_statistics_target set to
+// Target. An empty Target uses the configured default.
+type Stage struct {
+	Name   string
+	Target string
+}
+
+// FullStage creates the same statistics as running ANALYZE without staging.
+var FullStage = Stage{Name: "full statistics", Target: ""}
+
+// Stages mirrors "vacuumdb --analyze-in-stages" by quickly creating minimal
+// statistics on every table before refining them.
+var Stages = []Stage{
+	{Name: "minimal statistics", Target: "1"},
+	{Name: "medium statistics", Target: "10"},
+	FullStage,
+}
+
+func (s Stage) key() string {
+	if s.Target == "" {
+		return "default"
+	}
+
+	return s.Target
+}
+
+type Options struct {
+	// Jobs is the number of databases analyzed in parallel.
+	Jobs     int
+	Priority Priority
+	// TableList is the file of database.schema.table names analyzed first
+	// when Priority is List.
+	TableList string
+	InStages  bool
+	// LedgerFile records the analyzed tables. It is removed once every
+	// database has been analyzed.
+	LedgerFile string
+}
+
+// Connector opens a connection to the named database of the target cluster.
+type Connector func(database string) (*sql.DB, error)
+
+type Table struct {
+	Schema string
+	Name   string
+}
+
+func (t Table) String() string {
+	return quoteIdentifier(t.Schema) + "." + quoteIdentifier(t.Name)
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// Analyze creates optimizer statistics for every table in every database
+// accepting connections. Failures in one database do not stop the others.
+func Analyze(ctx context.Context, out io.Writer, connect Connector, opts Options) (err error) {
+	if opts.Jobs < 1 {
+		return xerrors.Errorf("expected jobs to be at least 1 got %d", opts.Jobs)
+	}
+
+	var listed map[string][]Table
+	if opts.Priority == List {
+		listed, err = ReadTableList(opts.TableList)
+		if err != nil {
+			return err
+		}
+	}
+
+	ledger, err := OpenLedger(opts.LedgerFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := ledger.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	if ledger.Len() > 0 {
+		fmt.Fprintf(out, "Resuming from %s with %d tables already analyzed.\n", opts.LedgerFile, ledger.Len())
+	}
+
+	databases, err := listDatabases(connect)
+	if err != nil {
+		return err
+	}
+
+	stages := []Stage{FullStage}
+	if opts.InStages {
+		stages = Stages
+	}
+
+	progress := &progressWriter{out: out}
+
+	// Like "vacuumdb --analyze-in-stages" each stage covers every database
+	// before the next stage starts so that all databases quickly have minimal
+	// statistics. A database that fails is skipped in the later stages.
+	failed := make(map[string]bool)
+	for i, stage := range stages {
+		var wg sync.WaitGroup
+		errs := make(chan databaseErr, len(databases))
+		sem := make(chan struct{}, opts.Jobs)
+
+		for _, database := range databases {
+			if failed[database] {
+				continue
+			}
+
+			wg.Add(1)
+			go func(database string) {
+				defer wg.Done()
+
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				err := analyzeDatabase(ctx, progress, connect, database, opts.Priority, listed[database], stageProgress{i + 1, len(stages), stage}, ledger)
+				if err != nil {
+					errs <- databaseErr{database: database, err: err}
+				}
+			}(database)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for e := range errs {
+			failed[e.database] = true
+			err = errorlist.Append(err, xerrors.Errorf("analyze database %q: %w", e.database, e.err))
+		}
+	}
+
+	if err != nil {
+		return utils.NewNextActionErr(err, fmt.Sprintf("Re-run the command to resume analyzing from %s.", opts.LedgerFile))
+	}
+
+	return ledger.Remove()
+}
+
+func listDatabases(connect Connector) (databases []string, err error) {
+	db, err := connect("template1")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	rows, err := db.Query(`SELECT datname FROM pg_database WHERE datallowconn ORDER BY datname`)
+	if err != nil {
+		return nil, xerrors.Errorf("querying databases: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var database string
+		if err := rows.Scan(&database); err != nil {
+			return nil, err
+		}
+
+		databases = append(databases, database)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return databases, nil
+}
+
+type databaseErr struct {
+	database string
+	err      error
+}
+
+// stageProgress is the stage being run and its position among all stages.
+type stageProgress struct {
+	num   int
+	count int
+	stage Stage
+}
+
+func analyzeDatabase(ctx context.Context, progress *progressWriter, connect Connector, database string, priority Priority, listed []Table, current stageProgress, ledger *Ledger) (err error) {
+	stage := current.stage
+
+	db, err := connect(database)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := db.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	tables, err := ListTables(db, priority, listed)
+	if err != nil {
+		return err
+	}
+
+	var remaining []Table
+	for _, table := range tables {
+		if !ledger.Analyzed(stage, database, table) {
+			remaining = append(remaining, table)
+		}
+	}
+
+	done := len(tables) - len(remaining)
+	progress.Printf("%s: stage %d of %d (%s) %d of %d tables already analyzed\n", database, current.num, current.count, stage.Name, done, len(tables))
+	if len(remaining) == 0 {
+		return nil
+	}
+
+	// default_statistics_target is set per session so every ANALYZE must
+	// run on the same connection.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := conn.Close(); cErr != nil {
+			err = errorlist.Append(err, cErr)
+		}
+	}()
+
+	setting := "RESET default_statistics_target"
+	if stage.Target != "" {
+		setting = "SET default_statistics_target = " + stage.Target
+	}
+
+	if _, err := conn.ExecContext(ctx, setting); err != nil {
+		return err
+	}
+
+	for _, table := range remaining {
+		if _, err := conn.ExecContext(ctx, "ANALYZE "+table.String()); err != nil {
+			return xerrors.Errorf("analyze %s: %w", table, err)
+		}
+
+		if err := ledger.Record(stage, database, table); err != nil {
+			return err
+		}
+
+		done++
+		progress.Printf("%s: stage %d of %d (%s) analyzed %d of %d tables: %s\n", database, current.num, current.count, stage.Name, done, len(tables), table)
+	}
+
+	return nil
+}
+
+// ListTables returns the tables of the database in the order they are
+// analyzed. Listed tables come first in the given order.
+func ListTables(db *sql.DB, priority Priority, listed []Table) ([]Table, error) {
+	order := "pg_total_relation_size(c.oid) DESC"
+	if priority == Usage {
+		order = "coalesce(s.seq_scan, 0) + coalesce(s.idx_scan, 0) DESC"
+	}
+
+	rows, err := db.Query(fmt.Sprintf(tablesQuery, order))
+	if err != nil {
+		return nil, xerrors.Errorf("querying tables: %w", err)
+	}
+	defer rows.Close()
+
+	var all []Table
+	for rows.Next() {
+		var table Table
+		if err := rows.Scan(&table.Schema, &table.Name); err != nil {
+			return nil, err
+		}
+
+		all = append(all, table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	exists := make(map[Table]bool, len(all))
+	for _, table := range all {
+		exists[table] = true
+	}
+
+	var tables []Table
+	seen := make(map[Table]bool)
+	for _, table := range listed {
+		if exists[table] && !seen[table] {
+			tables = append(tables, table)
+			seen[table] = true
+		}
+	}
+
+	for _, table := range all {
+		if !seen[table] {
+			tables = append(tables, table)
+		}
+	}
+
+	return tables, nil
+}
+
+// tablesQuery includes materialized views and, from Greenplum 7 on, the
+// partitioned roots since they have statistics of their own.
+const tablesQuery = `
+SELECT n.nspname, c.relname
+FROM pg_class c
+    JOIN pg_namespace n ON n.oid = c.relnamespace
+    LEFT JOIN pg_stat_all_tables s ON s.relid = c.oid
+WHERE c.relkind IN ('r', 'p', 'm')
+    AND n.nspname NOT LIKE 'pg_temp_%%'
+    AND n.nspname NOT LIKE 'pg_toast%%'
+ORDER BY %s, n.nspname, c.relname;`
+
+// ReadTableList reads database.schema.table names, one per line, keyed by
+// database. Blank lines and lines starting with # are ignored.
+func ReadTableList(path string) (map[string][]Table, error) {
+	if path == "" {
+		return nil, xerrors.New("a table list is required when prioritizing by list")
+	}
+
+	file, err := utils.System.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	tables := make(map[string][]Table)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		parts := strings.Split(text, ".")
+		if len(parts) != 3 {
+			return nil, xerrors.Errorf("%s:%d: expected database.schema.table got %q", path, line, text)
+		}
+
+		tables[parts[0]] = append(tables[parts[0]], Table{Schema: parts[1], Name: parts[2]})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// progressWriter serializes progress from databases analyzed in parallel.
+type progressWriter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (p *progressWriter) Printf(format string, args ...interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fmt.Fprintf(p.out, format, args...)
+}
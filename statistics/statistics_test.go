@@ -0,0 +1,359 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package statistics_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/greenplum-db/gpupgrade/statistics"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+type mockDatabases map[string]sqlmock.Sqlmock
+
+// newMockDatabases returns a mock for each named connection. Later connections
+// to the same database are named with their number such as "sales#2".
+func newMockDatabases(t *testing.T, names ...string) (mockDatabases, statistics.Connector) {
+	t.Helper()
+
+	mocks := make(mockDatabases)
+	dbs := make(map[string]*sql.DB)
+	for _, name := range names {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+
+		mocks[name] = mock
+		dbs[name] = db
+	}
+
+	var mutex sync.Mutex
+	connections := make(map[string]int)
+	connect := func(database string) (*sql.DB, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		connections[database]++
+		name := database
+		if connections[database] > 1 {
+			name = fmt.Sprintf("%s#%d", database, connections[database])
+		}
+
+		db, ok := dbs[name]
+		if !ok {
+			return nil, errors.New("unexpected database " + name)
+		}
+
+		return db, nil
+	}
+
+	return mocks, connect
+}
+
+func (m mockDatabases) finish(t *testing.T) {
+	t.Helper()
+
+	for _, mock := range m {
+		testutils.FinishMock(mock, t)
+	}
+}
+
+func expectDatabases(mock sqlmock.Sqlmock, names ...string) {
+	rows := sqlmock.NewRows([]string{"datname"})
+	for _, name := range names {
+		rows.AddRow(name)
+	}
+
+	mock.ExpectQuery("SELECT datname FROM pg_database").WillReturnRows(rows)
+	mock.ExpectClose()
+}
+
+func expectTables(mock sqlmock.Sqlmock, tables ...statistics.Table) {
+	rows := sqlmock.NewRows([]string{"nspname", "relname"})
+	for _, table := range tables {
+		rows.AddRow(table.Schema, table.Name)
+	}
+
+	mock.ExpectQuery("SELECT n.nspname, c.relname").WillReturnRows(rows)
+}
+
+func expectAnalyze(mock sqlmock.Sqlmock, tables ...statistics.Table) {
+	for _, table := range tables {
+		mock.ExpectExec(regexp.QuoteMeta("ANALYZE " + table.String())).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+}
+
+var (
+	orders    = statistics.Table{Schema: "public", Name: "orders"}
+	customers = statistics.Table{Schema: "public", Name: "customers"}
+	events    = statistics.Table{Schema: "audit", Name: "events"}
+)
+
+func TestAnalyze(t *testing.T) {
+	t.Run("analyzes every table in every database and removes the ledger", func(t *testing.T) {
+		ledgerFile := filepath.Join(t.TempDir(), statistics.LedgerFileName)
+
+		mocks, connect := newMockDatabases(t, "template1", "postgres", "sales")
+		defer mocks.finish(t)
+
+		expectDatabases(mocks["template1"], "postgres", "sales")
+
+		expectTables(mocks["postgres"])
+		mocks["postgres"].ExpectClose()
+
+		expectTables(mocks["sales"], orders, customers)
+		mocks["sales"].ExpectExec("RESET default_statistics_target").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectAnalyze(mocks["sales"], orders, customers)
+		mocks["sales"].ExpectClose()
+
+		out := new(bytes.Buffer)
+		err := statistics.Analyze(context.Background(), out, connect, statistics.Options{Jobs: 2, Priority: statistics.Size, LedgerFile: ledgerFile})
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		expected := `sales: stage 1 of 1 (full statistics) analyzed 2 of 2 tables: "public"."customers"`
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected output %q to contain %q", out, expected)
+		}
+
+		testutils.PathMustNotExist(t, ledgerFile)
+	})
+
+	t.Run("analyzes in stages", func(t *testing.T) {
+		ledgerFile := filepath.Join(t.TempDir(), statistics.LedgerFileName)
+
+		mocks, connect := newMockDatabases(t, "template1", "sales", "sales#2", "sales#3")
+		defer mocks.finish(t)
+
+		expectDatabases(mocks["template1"], "sales")
+
+		for name, setting := range map[string]string{
+			"sales":   "SET default_statistics_target = 1",
+			"sales#2": "SET default_statistics_target = 10",
+			"sales#3": "RESET default_statistics_target",
+		} {
+			expectTables(mocks[name], orders)
+			mocks[name].ExpectExec(setting).WillReturnResult(sqlmock.NewResult(0, 0))
+			expectAnalyze(mocks[name], orders)
+			mocks[name].ExpectClose()
+		}
+
+		err := statistics.Analyze(context.Background(), new(bytes.Buffer), connect, statistics.Options{Jobs: 1, InStages: true, LedgerFile: ledgerFile})
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("runs each stage across all databases before the next stage", func(t *testing.T) {
+		ledgerFile := filepath.Join(t.TempDir(), statistics.LedgerFileName)
+
+		names := []string{"template1"}
+		for _, database := range []string{"postgres", "sales"} {
+			names = append(names, database, database+"#2", database+"#3")
+		}
+
+		mocks, connect := newMockDatabases(t, names...)
+		defer mocks.finish(t)
+
+		expectDatabases(mocks["template1"], "postgres", "sales")
+		for _, name := range names[1:] {
+			expectTables(mocks[name])
+			mocks[name].ExpectClose()
+		}
+
+		out := new(bytes.Buffer)
+		err := statistics.Analyze(context.Background(), out, connect, statistics.Options{Jobs: 2, InStages: true, LedgerFile: ledgerFile})
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		var stages []string
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			_, stage, _ := strings.Cut(line, ": ")
+			stages = append(stages, strings.SplitN(stage, " (", 2)[0])
+		}
+
+		expected := []string{
+			"stage 1 of 3", "stage 1 of 3",
+			"stage 2 of 3", "stage 2 of 3",
+			"stage 3 of 3", "stage 3 of 3",
+		}
+		if !reflect.DeepEqual(stages, expected) {
+			t.Errorf("got stages %q want %q", stages, expected)
+		}
+	})
+
+	t.Run("resumes from the ledger after a failure", func(t *testing.T) {
+		ledgerFile := filepath.Join(t.TempDir(), statistics.LedgerFileName)
+
+		mocks, connect := newMockDatabases(t, "template1", "sales")
+		expectDatabases(mocks["template1"], "sales")
+
+		expected := errors.New("canceling statement due to user request")
+		expectTables(mocks["sales"], orders, customers)
+		mocks["sales"].ExpectExec("RESET default_statistics_target").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectAnalyze(mocks["sales"], orders)
+		mocks["sales"].ExpectExec(regexp.QuoteMeta("ANALYZE " + customers.String())).WillReturnError(expected)
+		mocks["sales"].ExpectClose()
+
+		err := statistics.Analyze(context.Background(), new(bytes.Buffer), connect, statistics.Options{Jobs: 1, LedgerFile: ledgerFile})
+		var nextActionsErr utils.NextActionErr
+		if !errors.As(err, &nextActionsErr) || !errors.Is(nextActionsErr.Err, expected) {
+			t.Fatalf("got error %#v want %#v", err, expected)
+		}
+		mocks.finish(t)
+
+		testutils.PathMustExist(t, ledgerFile)
+
+		mocks, connect = newMockDatabases(t, "template1", "sales")
+		defer mocks.finish(t)
+
+		expectDatabases(mocks["template1"], "sales")
+		expectTables(mocks["sales"], orders, customers)
+		mocks["sales"].ExpectExec("RESET default_statistics_target").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectAnalyze(mocks["sales"], customers)
+		mocks["sales"].ExpectClose()
+
+		out := new(bytes.Buffer)
+		err = statistics.Analyze(context.Background(), out, connect, statistics.Options{Jobs: 1, LedgerFile: ledgerFile})
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if !strings.Contains(out.String(), "with 1 tables already analyzed") {
+			t.Errorf("expected output %q to report resuming", out)
+		}
+
+		testutils.PathMustNotExist(t, ledgerFile)
+	})
+
+	t.Run("errors when jobs is less than one", func(t *testing.T) {
+		err := statistics.Analyze(context.Background(), new(bytes.Buffer), nil, statistics.Options{Jobs: 0})
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestListTables(t *testing.T) {
+	t.Run("orders listed tables first", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		expectTables(mock, orders, customers, events)
+
+		missing := statistics.Table{Schema: "public", Name: "missing"}
+		tables, err := statistics.ListTables(db, statistics.List, []statistics.Table{events, missing, events})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []statistics.Table{events, orders, customers}
+		if !reflect.DeepEqual(tables, expected) {
+			t.Errorf("got %v want %v", tables, expected)
+		}
+	})
+
+	t.Run("includes partitioned roots and materialized views", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE c.relkind IN ('r', 'p', 'm')")).
+			WillReturnRows(sqlmock.NewRows([]string{"nspname", "relname"}))
+
+		_, err = statistics.ListTables(db, statistics.Size, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("orders by usage", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("couldn't create sqlmock: %v", err)
+		}
+		defer testutils.FinishMock(mock, t)
+
+		mock.ExpectQuery(regexp.QuoteMeta("ORDER BY coalesce(s.seq_scan, 0) + coalesce(s.idx_scan, 0) DESC")).
+			WillReturnRows(sqlmock.NewRows([]string{"nspname", "relname"}))
+
+		_, err = statistics.ListTables(db, statistics.Usage, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestReadTableList(t *testing.T) {
+	t.Run("reads tables by database", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tables")
+		testutils.MustWriteToFile(t, path, "# most queried\nsales.public.orders\n\nsales.audit.events\npostgres.public.customers\n")
+
+		tables, err := statistics.ReadTableList(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := map[string][]statistics.Table{
+			"sales":    {orders, events},
+			"postgres": {customers},
+		}
+		if !reflect.DeepEqual(tables, expected) {
+			t.Errorf("got %v want %v", tables, expected)
+		}
+	})
+
+	t.Run("errors on invalid names", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tables")
+		testutils.MustWriteToFile(t, path, "public.orders\n")
+
+		_, err := statistics.ReadTableList(path)
+		if err == nil || !strings.Contains(err.Error(), "expected database.schema.table") {
+			t.Errorf("got error %v", err)
+		}
+	})
+
+	t.Run("errors when the file does not exist", func(t *testing.T) {
+		_, err := statistics.ReadTableList(filepath.Join(t.TempDir(), "does-not-exist"))
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("got error %v want %v", err, os.ErrNotExist)
+		}
+	})
+}
+
+func TestParsePriority(t *testing.T) {
+	for _, priority := range statistics.Priorities {
+		parsed, err := statistics.ParsePriority(string(priority))
+		if err != nil || parsed != priority {
+			t.Errorf("got %q, %v want %q", parsed, err, priority)
+		}
+	}
+
+	_, err := statistics.ParsePriority("random")
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -4,6 +4,7 @@
 package step
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -16,6 +17,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/greenplum-db/gpupgrade/fault"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/substeps"
 	"github.com/greenplum-db/gpupgrade/utils"
@@ -30,6 +32,7 @@ type Step struct {
 	sender       idl.MessageSender // sends substep status messages
 	substepStore SubstepStore      // persistent substep status storage
 	streams      OutStreams        // writes substep stdout/err
+	timeouts     Timeouts          // overrides of DefaultTimeouts
 	err          error
 }
 
@@ -42,20 +45,27 @@ func New(name idl.Step, sender idl.MessageSender, substepStore SubstepStore, str
 	}
 }
 
-func Begin(step idl.Step, sender idl.MessageSender) (*Step, error) {
+// Begin starts the step. The context is typically that of the hub's running
+// step which outlives the CLI's stream and is canceled by the Cancel RPC when
+// the user interrupts the CLI. Substeps retrieve it from their streams using
+// Context. Substeps are bounded by DefaultTimeouts unless overridden by
+// timeouts.
+func Begin(ctx context.Context, step idl.Step, sender idl.MessageSender, timeouts Timeouts) (*Step, error) {
 	substepStore, err := NewSubstepFileStore()
 	if err != nil {
 		return nil, err
 	}
 
-	streams := newLogMessageSender(sender)
+	streams := newLogMessageSender(ctx, sender)
 
 	_, err = fmt.Fprintf(streams.Stdout(), "\n%s in progress.\n", cases.Title(language.English).String(step.String()))
 	if err != nil {
 		return nil, err
 	}
 
-	return New(step, sender, substepStore, streams), nil
+	st := New(step, sender, substepStore, streams)
+	st.timeouts = timeouts
+	return st, nil
 }
 
 func HasStarted(step idl.Step) (bool, error) {
@@ -180,6 +190,11 @@ func (s *Step) run(substep idl.Substep, f func(OutStreams) error, alwaysRun bool
 		return
 	}
 
+	// Do not start any more substeps once the step has been canceled.
+	if err = Context(s.streams).Err(); err != nil {
+		return
+	}
+
 	status, err := s.substepStore.Read(s.name, substep)
 	if err != nil {
 		return
@@ -211,7 +226,17 @@ func (s *Step) run(substep idl.Substep, f func(OutStreams) error, alwaysRun bool
 		return
 	}
 
-	err = f(s.streams)
+	err = s.runWithTimeout(substep, func(streams OutStreams) error {
+		if err := fault.Check(Context(streams), fault.SubstepPoint(s.name, substep, fault.Before)); err != nil {
+			return err
+		}
+
+		if err := f(streams); err != nil {
+			return err
+		}
+
+		return fault.Check(Context(streams), fault.SubstepPoint(s.name, substep, fault.After))
+	})
 
 	switch {
 	case errors.Is(err, Skip):
@@ -220,7 +245,14 @@ func (s *Step) run(substep idl.Substep, f func(OutStreams) error, alwaysRun bool
 		return
 
 	case err != nil:
-		if werr := s.write(substep, idl.Status_failed); werr != nil {
+		// Mark canceled substeps as quit rather than failed to indicate the
+		// user stopped them. Either way they are re-run.
+		status := idl.Status_failed
+		if Context(s.streams).Err() != nil {
+			status = idl.Status_quit
+		}
+
+		if werr := s.write(substep, status); werr != nil {
 			err = errorlist.Append(err, werr)
 		}
 
@@ -4,6 +4,7 @@
 package step_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"google.golang.org/grpc/status"
 
+	"github.com/greenplum-db/gpupgrade/fault"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
 	"github.com/greenplum-db/gpupgrade/step"
@@ -344,6 +346,135 @@ func TestStepRun(t *testing.T) {
 			t.Error("got nil want err")
 		}
 	})
+
+	t.Run("marks a canceled substep as quit and skips subsequent substeps", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		substepStore := &TestSubstepStore{}
+		s := step.New(idl.Step_execute, server, substepStore, contextStreams{step.DevNullStream, ctx})
+
+		s.Run(idl.Substep_upgrade_primaries, func(streams step.OutStreams) error {
+			cancel()
+			<-step.Context(streams).Done()
+			return step.Context(streams).Err()
+		})
+
+		if substepStore.Status != idl.Status_quit {
+			t.Errorf("got status %q want %q", substepStore.Status, idl.Status_quit)
+		}
+
+		var called bool
+		s.Run(idl.Substep_start_target_cluster, func(streams step.OutStreams) error {
+			called = true
+			return nil
+		})
+
+		if called {
+			t.Error("expected substep to be skipped")
+		}
+
+		if !errors.Is(s.Err(), context.Canceled) {
+			t.Errorf("got error %#v, want %#v", s.Err(), context.Canceled)
+		}
+	})
+
+	t.Run("fails a substep before it runs when a fault is injected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		t.Setenv(fault.EnvVar, "1")
+		fault.Inject(fault.SubstepPoint(idl.Step_execute, idl.Substep_upgrade_primaries, fault.Before), fault.Fail)
+		defer fault.Reset()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		substepStore := &TestSubstepStore{}
+		s := step.New(idl.Step_execute, server, substepStore, step.DevNullStream)
+
+		var called bool
+		s.Run(idl.Substep_upgrade_primaries, func(streams step.OutStreams) error {
+			called = true
+			return nil
+		})
+
+		if called {
+			t.Error("expected substep to not be called")
+		}
+
+		if substepStore.Status != idl.Status_failed {
+			t.Errorf("got status %q want %q", substepStore.Status, idl.Status_failed)
+		}
+
+		if !errors.Is(s.Err(), fault.ErrInjected) {
+			t.Errorf("got error %#v, want %#v", s.Err(), fault.ErrInjected)
+		}
+	})
+
+	t.Run("fails a substep after it runs when a fault is injected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		t.Setenv(fault.EnvVar, "1")
+		fault.Inject(fault.SubstepPoint(idl.Step_execute, idl.Substep_upgrade_primaries, fault.After), fault.Fail)
+		defer fault.Reset()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		substepStore := &TestSubstepStore{}
+		s := step.New(idl.Step_execute, server, substepStore, step.DevNullStream)
+
+		var called bool
+		s.Run(idl.Substep_upgrade_primaries, func(streams step.OutStreams) error {
+			called = true
+			return nil
+		})
+
+		if !called {
+			t.Error("expected substep to be called")
+		}
+
+		if substepStore.Status != idl.Status_failed {
+			t.Errorf("got status %q want %q", substepStore.Status, idl.Status_failed)
+		}
+
+		if !errors.Is(s.Err(), fault.ErrInjected) {
+			t.Errorf("got error %#v, want %#v", s.Err(), fault.ErrInjected)
+		}
+	})
+
+	t.Run("ignores injected faults when fault injection is disabled", func(t *testing.T) {
+		if fault.Enabled() {
+			t.Skip("fault injection is enabled")
+		}
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fault.Inject(fault.SubstepPoint(idl.Step_execute, idl.Substep_upgrade_primaries, fault.Before), fault.Fail)
+		defer fault.Reset()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		substepStore := &TestSubstepStore{}
+		s := step.New(idl.Step_execute, server, substepStore, step.DevNullStream)
+		s.Run(idl.Substep_upgrade_primaries, func(streams step.OutStreams) error {
+			return nil
+		})
+
+		if substepStore.Status != idl.Status_complete {
+			t.Errorf("got status %q want %q", substepStore.Status, idl.Status_complete)
+		}
+	})
 }
 
 func TestHasStarted(t *testing.T) {
@@ -812,6 +943,15 @@ func TestStepErr(t *testing.T) {
 	})
 }
 
+type contextStreams struct {
+	step.OutStreams
+	ctx context.Context
+}
+
+func (c contextStreams) Context() context.Context {
+	return c.ctx
+}
+
 type TestSubstepStore struct {
 	Status   idl.Status
 	WriteErr error
@@ -6,6 +6,7 @@ package step
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"io"
 	"log"
 	"os"
@@ -124,12 +125,13 @@ type logMessageSender struct {
 	stdout io.Writer
 	stderr io.Writer
 
+	ctx    context.Context
 	sender idl.MessageSender
 	mutex  sync.Mutex
 }
 
-func newLogMessageSender(stream idl.MessageSender) *logMessageSender {
-	lms := &logMessageSender{sender: stream}
+func newLogMessageSender(ctx context.Context, stream idl.MessageSender) *logMessageSender {
+	lms := &logMessageSender{ctx: ctx, sender: stream}
 
 	lms.stdout = &logMessageSenderWriter{
 		logMessageSender: lms,
@@ -152,6 +154,21 @@ func (l *logMessageSender) Stderr() io.Writer {
 	return l.stderr
 }
 
+func (l *logMessageSender) Context() context.Context {
+	return l.ctx
+}
+
+// Context returns the context of the step the streams were created for so
+// long running commands can be terminated when the step is canceled. Streams
+// not belonging to a step return a background context.
+func Context(streams OutStreams) context.Context {
+	if s, ok := streams.(interface{ Context() context.Context }); ok {
+		return s.Context()
+	}
+
+	return context.Background()
+}
+
 // logMessageSenderWriter is an internal type used by logMessageSender to send stdout and
 // stderr to both a gRPC MessageSender and log file.
 type logMessageSenderWriter struct {
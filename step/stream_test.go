@@ -4,6 +4,7 @@
 package step
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -73,7 +74,7 @@ func TestMultiplexedStream(t *testing.T) {
 				Type:   idl.Chunk_stderr,
 			}}})
 
-		stream := newLogMessageSender(mockStream)
+		stream := newLogMessageSender(context.Background(), mockStream)
 		fmt.Fprint(stream.Stdout(), expectedStdout)
 		fmt.Fprint(stream.Stderr(), expectedStderr)
 	})
@@ -87,7 +88,7 @@ func TestMultiplexedStream(t *testing.T) {
 			Send(gomock.Any()).
 			AnyTimes()
 
-		stream := newLogMessageSender(mockStream)
+		stream := newLogMessageSender(context.Background(), mockStream)
 
 		// Write 10 bytes to each stream.
 		for i := 0; i < 10; i++ {
@@ -121,7 +122,7 @@ func TestMultiplexedStream(t *testing.T) {
 			Return(errors.New("error during send")).
 			Times(1) // we expect only one failed attempt to Send
 
-		stream := newLogMessageSender(mockStream)
+		stream := newLogMessageSender(context.Background(), mockStream)
 
 		// Write 10 bytes to each stream.
 		for i := 0; i < 10; i++ {
@@ -154,3 +155,21 @@ func TestMultiplexedStream(t *testing.T) {
 		}
 	})
 }
+
+func TestContext(t *testing.T) {
+	t.Run("returns the context of the streams", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		streams := newLogMessageSender(ctx, nil)
+		if Context(streams) != ctx {
+			t.Errorf("got context %v want %v", Context(streams), ctx)
+		}
+	})
+
+	t.Run("returns a background context for streams without one", func(t *testing.T) {
+		if Context(DevNullStream) != context.Background() {
+			t.Errorf("got context %v want %v", Context(DevNullStream), context.Background())
+		}
+	})
+}
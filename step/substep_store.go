@@ -10,6 +10,7 @@ import (
 
 	"golang.org/x/xerrors"
 
+	"github.com/greenplum-db/gpupgrade/audit"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/utils"
 )
@@ -123,5 +124,22 @@ func (f *SubstepFileStore) Write(step idl.Step, substep idl.Substep, status idl.
 		return err
 	}
 
-	return utils.AtomicallyWrite(f.path, data)
+	err = utils.AtomicallyWrite(f.path, data)
+	if err != nil {
+		return err
+	}
+
+	return recordTransition(step, substep, status)
+}
+
+// recordTransition audits the status change. The overall step status is
+// stored as the step_status substep.
+func recordTransition(step idl.Step, substep idl.Substep, status idl.Status) error {
+	entry := audit.Entry{Event: audit.SubstepTransition, Step: step.String(), Substep: substep.String(), Status: status.String()}
+	if substep == idl.Substep_step_status {
+		entry.Event = audit.StepTransition
+		entry.Substep = ""
+	}
+
+	return audit.Record(entry)
 }
@@ -8,8 +8,10 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/greenplum-db/gpupgrade/audit"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/testutils"
@@ -209,6 +211,43 @@ func TestFileStore(t *testing.T) {
 			t.Errorf("status[%q][%q] = %q, want %q", initialize, key, raw[initialize.String()][key], status.String())
 		}
 	})
+
+	t.Run("records transitions in the audit log", func(t *testing.T) {
+		resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", tmpDir)
+		defer resetEnv()
+
+		clear(t, path)
+		if err := fs.Write(initialize, idl.Substep_init_target_cluster, idl.Status_running); err != nil {
+			t.Fatalf("Write(): %+v", err)
+		}
+
+		if err := fs.Write(initialize, idl.Substep_step_status, idl.Status_complete); err != nil {
+			t.Fatalf("Write(): %+v", err)
+		}
+
+		contents := testutils.MustReadFile(t, filepath.Join(tmpDir, audit.FileName))
+		lines := strings.Split(strings.TrimSpace(contents), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("got %d audit entries want 2", len(lines))
+		}
+
+		var substep, step audit.Entry
+		if err := json.Unmarshal([]byte(lines[0]), &substep); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := json.Unmarshal([]byte(lines[1]), &step); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if substep.Event != audit.SubstepTransition || substep.Step != "initialize" || substep.Substep != "init_target_cluster" || substep.Status != "running" {
+			t.Errorf("got entry %+v", substep)
+		}
+
+		if step.Event != audit.StepTransition || step.Step != "initialize" || step.Substep != "" || step.Status != "complete" {
+			t.Errorf("got entry %+v", step)
+		}
+	})
 }
 
 // clear writes an empty JSON map to the given SubstepFileStore backing path.
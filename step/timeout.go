@@ -0,0 +1,191 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// Timeouts maps a substep to how long it may run before it is canceled. A
+// timeout of zero means the substep is not bounded.
+type Timeouts map[idl.Substep]time.Duration
+
+// DefaultTimeouts bounds substeps that start, stop, or wait on processes since
+// they take about the same time regardless of how much data the cluster has.
+// Substeps that copy or upgrade data scale with the size of the cluster and
+// are not bounded by default.
+var DefaultTimeouts = Timeouts{
+	idl.Substep_start_agents:                                                  5 * time.Minute,
+	idl.Substep_ensure_gpupgrade_agents_are_running:                           5 * time.Minute,
+	idl.Substep_check_environment:                                             10 * time.Minute,
+	idl.Substep_shutdown_source_cluster:                                       time.Hour,
+	idl.Substep_shutdown_target_cluster:                                       time.Hour,
+	idl.Substep_start_source_cluster:                                          time.Hour,
+	idl.Substep_start_target_cluster:                                          time.Hour,
+	idl.Substep_initialize_wait_for_cluster_to_be_ready:                       15 * time.Minute,
+	idl.Substep_wait_for_cluster_to_be_ready_before_upgrade_master:            15 * time.Minute,
+	idl.Substep_wait_for_cluster_to_be_ready_after_updating_catalog:           15 * time.Minute,
+	idl.Substep_wait_for_cluster_to_be_ready_after_adding_mirrors_and_standby: 15 * time.Minute,
+}
+
+// Timeout returns the timeout for the substep falling back to the default
+// when it is not overridden.
+func (t Timeouts) Timeout(substep idl.Substep) time.Duration {
+	if timeout, ok := t[substep]; ok {
+		return timeout
+	}
+
+	return DefaultTimeouts[substep]
+}
+
+// ParseTimeouts parses overrides of the form "substep=duration,..." such as
+// "start_target_cluster=2h,upgrade_primaries=0".
+func ParseTimeouts(val string) (Timeouts, error) {
+	timeouts := make(Timeouts)
+	if strings.TrimSpace(val) == "" {
+		return timeouts, nil
+	}
+
+	for _, pair := range strings.Split(val, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, xerrors.Errorf("expected substep=duration but got %q", pair)
+		}
+
+		substep, ok := idl.Substep_value[strings.TrimSpace(name)]
+		if !ok || substep == int32(idl.Substep_unknown_substep) {
+			return nil, xerrors.Errorf("unknown substep %q", name)
+		}
+
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, xerrors.Errorf("parse timeout for substep %q: %w", name, err)
+		}
+
+		if timeout < 0 {
+			return nil, xerrors.Errorf("timeout for substep %q must not be negative", name)
+		}
+
+		timeouts[idl.Substep(substep)] = timeout
+	}
+
+	return timeouts, nil
+}
+
+func (t Timeouts) String() string {
+	var pairs []string
+	for substep, timeout := range t {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", substep, timeout))
+	}
+
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// TimeoutError is the cause of a substep being canceled for exceeding its
+// timeout.
+type TimeoutError struct {
+	Substep idl.Substep
+	Timeout time.Duration
+}
+
+func (t *TimeoutError) Error() string {
+	return fmt.Sprintf("substep %q timed out after %s", t.Substep, t.Timeout)
+}
+
+func (t *TimeoutError) Is(err error) bool {
+	return err == context.DeadlineExceeded
+}
+
+// contextStreams overrides the context of streams such that substeps using
+// Context observe their timeout.
+type contextStreams struct {
+	OutStreams
+	ctx context.Context
+}
+
+func (c contextStreams) Context() context.Context {
+	return c.ctx
+}
+
+// runWithTimeout runs the substep canceling its context once it exceeds its
+// timeout. On timeout the goroutine stacks are logged to show where the
+// substep was stuck. A substep that does not return shortly after being
+// canceled is left running in the background and the step fails.
+func (s *Step) runWithTimeout(substep idl.Substep, f func(OutStreams) error) error {
+	timeout := s.timeouts.Timeout(substep)
+	if timeout <= 0 {
+		return f(s.streams)
+	}
+
+	timeoutErr := &TimeoutError{Substep: substep, Timeout: timeout}
+	ctx, cancel := context.WithTimeoutCause(Context(s.streams), timeout, timeoutErr)
+	defer cancel()
+
+	// Log the stacks as soon as the timeout expires rather than once the
+	// substep returns since by then the stuck goroutines may have unwound.
+	logged := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		defer close(logged)
+		if context.Cause(ctx) == error(timeoutErr) {
+			logStacks(timeoutErr)
+		}
+	})
+	defer stop()
+
+	// Run the substep in a goroutine so that a substep which ignores its
+	// context cannot hold up the step forever. Such a substep is abandoned
+	// once it fails to return within a grace period of being canceled.
+	errs := make(chan error, 1)
+	go func() {
+		errs <- f(contextStreams{OutStreams: s.streams, ctx: ctx})
+	}()
+
+	var err error
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		grace := gracePeriod(timeout)
+		select {
+		case err = <-errs:
+		case <-time.After(grace):
+			log.Printf("abandoning substep %q which did not return within %s of being canceled", substep, grace)
+			err = fmt.Errorf("%w: substep did not return within %s of being canceled", ctx.Err(), grace)
+		}
+	}
+
+	if err == nil || context.Cause(ctx) != error(timeoutErr) {
+		return err
+	}
+
+	<-logged
+
+	nextAction := fmt.Sprintf(`Substep %q did not finish within %s and was canceled. The state of the hub at the time was written to the log for diagnosis.
+Investigate why the substep is stuck. If it needs more time increase its timeout using the "substep_timeouts" parameter such as "substep_timeouts = %s=%s".`,
+		substep, timeout, substep, 2*timeout)
+	return utils.NewNextActionErr(fmt.Errorf("%w: %w", timeoutErr, err), nextAction)
+}
+
+// gracePeriod is how long a canceled substep has to return before it is
+// abandoned.
+func gracePeriod(timeout time.Duration) time.Duration {
+	return min(timeout/10, time.Minute)
+}
+
+func logStacks(timeoutErr *TimeoutError) {
+	buf := make([]byte, 1<<20)
+	n := runtime.Stack(buf, true)
+	log.Printf("%s. Goroutine stacks:\n%s", timeoutErr, buf[:n])
+}
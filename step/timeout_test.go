@@ -0,0 +1,224 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package step_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/idl/mock_idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+)
+
+func TestParseTimeouts(t *testing.T) {
+	t.Run("parses timeouts for each substep", func(t *testing.T) {
+		timeouts, err := step.ParseTimeouts("start_target_cluster=2h, upgrade_primaries = 0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := step.Timeouts{
+			idl.Substep_start_target_cluster: 2 * time.Hour,
+			idl.Substep_upgrade_primaries:    0,
+		}
+		if !reflect.DeepEqual(timeouts, expected) {
+			t.Errorf("got %v want %v", timeouts, expected)
+		}
+	})
+
+	t.Run("returns no timeouts when empty", func(t *testing.T) {
+		timeouts, err := step.ParseTimeouts("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(timeouts) != 0 {
+			t.Errorf("got %v want no timeouts", timeouts)
+		}
+	})
+
+	errCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "a missing duration", input: "start_target_cluster"},
+		{name: "an unknown substep", input: "start_everything=1h"},
+		{name: "an invalid duration", input: "start_target_cluster=soon"},
+		{name: "a negative duration", input: "start_target_cluster=-1h"},
+	}
+
+	for _, c := range errCases {
+		t.Run("errors on "+c.name, func(t *testing.T) {
+			_, err := step.ParseTimeouts(c.input)
+			if err == nil {
+				t.Errorf("expected an error for %q", c.input)
+			}
+		})
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	t.Run("overrides the default timeout", func(t *testing.T) {
+		timeouts := step.Timeouts{idl.Substep_start_target_cluster: 0}
+
+		if timeouts.Timeout(idl.Substep_start_target_cluster) != 0 {
+			t.Errorf("got %s want 0", timeouts.Timeout(idl.Substep_start_target_cluster))
+		}
+
+		expected := step.DefaultTimeouts[idl.Substep_shutdown_source_cluster]
+		if timeouts.Timeout(idl.Substep_shutdown_source_cluster) != expected {
+			t.Errorf("got %s want %s", timeouts.Timeout(idl.Substep_shutdown_source_cluster), expected)
+		}
+	})
+
+	t.Run("substeps scaling with the size of the cluster are not bounded by default", func(t *testing.T) {
+		var timeouts step.Timeouts
+		if timeouts.Timeout(idl.Substep_upgrade_primaries) != 0 {
+			t.Errorf("got %s want 0", timeouts.Timeout(idl.Substep_upgrade_primaries))
+		}
+	})
+}
+
+func TestStepTimeout(t *testing.T) {
+	logOutput := testlog.SetupTestLogger()
+
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	t.Run("cancels a substep exceeding its timeout and marks it failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		timeouts := step.Timeouts{idl.Substep_start_target_cluster: 10 * time.Millisecond}
+		s, err := step.Begin(context.Background(), idl.Step_execute, server, timeouts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		s.Run(idl.Substep_start_target_cluster, func(streams step.OutStreams) error {
+			<-step.Context(streams).Done()
+			return context.Cause(step.Context(streams))
+		})
+
+		completed, err := step.HasCompleted(idl.Step_execute, idl.Substep_start_target_cluster)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if completed {
+			t.Error("expected substep to not be completed")
+		}
+
+		err = s.Err()
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("got %#v want a gRPC status error", err)
+		}
+
+		expected := `substep "start_target_cluster" timed out after 10ms`
+		if !strings.Contains(st.Message(), expected) {
+			t.Errorf("expected error %q to contain %q", st.Message(), expected)
+		}
+
+		var nextActions string
+		for _, detail := range st.Details() {
+			if msg, ok := detail.(*idl.NextActions); ok {
+				nextActions += msg.GetNextActions()
+			}
+		}
+
+		if !strings.Contains(nextActions, "substep_timeouts") {
+			t.Errorf("expected next actions %q to contain %q", nextActions, "substep_timeouts")
+		}
+
+		if !strings.Contains(string(logOutput.Bytes()), "Goroutine stacks") {
+			t.Errorf("expected log %q to contain the goroutine stacks", string(logOutput.Bytes()))
+		}
+	})
+
+	t.Run("abandons a substep ignoring its context once it exceeds its timeout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		timeouts := step.Timeouts{idl.Substep_check_environment: 10 * time.Millisecond}
+		s, err := step.Begin(context.Background(), idl.Step_execute, server, timeouts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		release := make(chan struct{})
+		defer close(release)
+
+		returned := make(chan struct{})
+		go func() {
+			defer close(returned)
+			s.Run(idl.Substep_check_environment, func(streams step.OutStreams) error {
+				<-release
+				return nil
+			})
+		}()
+
+		select {
+		case <-returned:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the substep to be abandoned once it exceeded its timeout")
+		}
+
+		expected := `substep "check_environment" timed out after 10ms`
+		if s.Err() == nil || !strings.Contains(s.Err().Error(), expected) {
+			t.Errorf("got error %v want it to contain %q", s.Err(), expected)
+		}
+
+		completed, err := step.HasCompleted(idl.Step_execute, idl.Substep_check_environment)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if completed {
+			t.Error("expected substep to not be completed")
+		}
+	})
+
+	t.Run("does not bound substeps without a timeout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := mock_idl.NewMockCliToHub_ExecuteServer(ctrl)
+		server.EXPECT().Send(gomock.Any()).AnyTimes()
+
+		s, err := step.Begin(context.Background(), idl.Step_execute, server, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		s.Run(idl.Substep_upgrade_primaries, func(streams step.OutStreams) error {
+			if _, ok := step.Context(streams).Deadline(); ok {
+				t.Error("expected no deadline")
+			}
+			return nil
+		})
+
+		if s.Err() != nil {
+			t.Errorf("unexpected error: %v", s.Err())
+		}
+	})
+}
@@ -22,6 +22,83 @@ func (s Substeps) String() string {
 	return output
 }
 
+// Steps lists the substeps of each step in the order they are run.
+var Steps = map[idl.Step]Substeps{
+	idl.Step_initialize: {
+		idl.Substep_verify_gpdb_versions,
+		idl.Substep_saving_source_cluster_config,
+		idl.Substep_start_hub,
+		idl.Substep_generate_data_migration_scripts,
+		idl.Substep_execute_stats_data_migration_scripts,
+		idl.Substep_execute_initialize_data_migration_scripts,
+		idl.Substep_verify_gpupgrade_is_installed_across_all_hosts,
+		idl.Substep_start_agents,
+		idl.Substep_check_environment,
+		idl.Substep_create_backupdirs,
+		idl.Substep_check_disk_space,
+		idl.Substep_generate_target_config,
+		idl.Substep_init_target_cluster,
+		idl.Substep_setting_dynamic_library_path_on_target_cluster,
+		idl.Substep_migrate_source_settings,
+		idl.Substep_shutdown_target_cluster,
+		idl.Substep_backup_target_master,
+		idl.Substep_initialize_wait_for_cluster_to_be_ready,
+		idl.Substep_check_upgrade,
+	},
+
+	idl.Step_execute: {
+		idl.Substep_ensure_gpupgrade_agents_are_running,
+		idl.Substep_check_active_connections_on_source_cluster,
+		idl.Substep_wait_for_cluster_to_be_ready_before_upgrade_master,
+		idl.Substep_shutdown_source_cluster,
+		idl.Substep_upgrade_master,
+		idl.Substep_copy_master,
+		idl.Substep_upgrade_primaries,
+		idl.Substep_start_target_cluster,
+	},
+
+	idl.Step_finalize: {
+		idl.Substep_ensure_gpupgrade_agents_are_running,
+		idl.Substep_check_active_connections_on_target_cluster,
+		idl.Substep_upgrade_mirrors,
+		idl.Substep_upgrade_standby,
+		idl.Substep_wait_for_cluster_to_be_ready_after_adding_mirrors_and_standby,
+		idl.Substep_shutdown_target_cluster,
+		idl.Substep_update_target_catalog,
+		idl.Substep_migrate_pg_hba,
+		idl.Substep_update_data_directories,
+		idl.Substep_update_target_conf_files,
+		idl.Substep_start_target_cluster,
+		idl.Substep_wait_for_cluster_to_be_ready_after_updating_catalog,
+		idl.Substep_verify_target_cluster,
+		idl.Substep_archive_log_directories,
+		idl.Substep_delete_backupdir,
+		idl.Substep_delete_segment_statedirs,
+		idl.Substep_stop_hub_and_agents,
+		idl.Substep_execute_finalize_data_migration_scripts,
+		idl.Substep_analyze_target_cluster,
+		idl.Substep_delete_master_statedir,
+	},
+
+	idl.Step_revert: {
+		idl.Substep_ensure_gpupgrade_agents_are_running,
+		idl.Substep_check_active_connections_on_target_cluster,
+		idl.Substep_shutdown_target_cluster,
+		idl.Substep_delete_target_cluster_datadirs,
+		idl.Substep_delete_tablespaces,
+		idl.Substep_restore_pgcontrol,
+		idl.Substep_restore_source_cluster,
+		idl.Substep_start_source_cluster,
+		idl.Substep_recoverseg_source_cluster,
+		idl.Substep_archive_log_directories,
+		idl.Substep_delete_backupdir,
+		idl.Substep_delete_segment_statedirs,
+		idl.Substep_stop_hub_and_agents,
+		idl.Substep_execute_revert_data_migration_scripts,
+		idl.Substep_delete_master_statedir,
+	},
+}
+
 type substepText struct {
 	OutputText string
 	HelpText   string
@@ -37,6 +114,7 @@ var SubstepDescriptions = map[idl.Substep]substepText{
 	idl.Substep_generate_target_config:                                        substepText{"Generating target cluster configuration...", "Generate target cluster configuration"},
 	idl.Substep_init_target_cluster:                                           substepText{"Creating target cluster...", "Create target cluster"},
 	idl.Substep_setting_dynamic_library_path_on_target_cluster:                substepText{"Setting dynamic library path on target cluster...", "Set dynamic library path on target cluster"},
+	idl.Substep_migrate_source_settings:                                       substepText{"Migrating source cluster settings to target cluster...", "Migrate source cluster settings to target cluster"},
 	idl.Substep_shutdown_target_cluster:                                       substepText{"Stopping target cluster...", "Stop target cluster"},
 	idl.Substep_backup_target_master:                                          substepText{"Backing up target master...", "Back up target master"},
 	idl.Substep_check_upgrade:                                                 substepText{"Running pg_upgrade checks...", "Run pg_upgrade checks"},
@@ -46,6 +124,7 @@ var SubstepDescriptions = map[idl.Substep]substepText{
 	idl.Substep_upgrade_primaries:                                             substepText{"Upgrading primary segments...", "Upgrade primary segments"},
 	idl.Substep_start_target_cluster:                                          substepText{"Starting target cluster...", "Start target cluster"},
 	idl.Substep_update_target_catalog:                                         substepText{"Updating target master catalog...", "Update target master catalog"},
+	idl.Substep_migrate_pg_hba:                                                substepText{"Migrating source cluster pg_hba.conf rules to target cluster...", "Migrate source cluster pg_hba.conf rules to target cluster"},
 	idl.Substep_update_data_directories:                                       substepText{"Updating data directories...", "Update data directories"},
 	idl.Substep_update_target_conf_files:                                      substepText{"Updating target master configuration files...", "Update target master configuration files"},
 	idl.Substep_upgrade_standby:                                               substepText{"Upgrading standby master...", "Upgrade standby master"},
@@ -76,4 +155,5 @@ var SubstepDescriptions = map[idl.Substep]substepText{
 	idl.Substep_verify_gpupgrade_is_installed_across_all_hosts:                substepText{"Verifying gpupgrade is installed across all hosts...", "Verify gpupgrade is installed across all hosts"},
 	idl.Substep_initialize_wait_for_cluster_to_be_ready:                       substepText{"Waiting for cluster to be ready...", "Wait for cluster to be ready"},
 	idl.Substep_wait_for_cluster_to_be_ready_before_upgrade_master:            substepText{"Waiting for cluster to be ready...", "Wait for cluster to be ready"},
+	idl.Substep_verify_target_cluster:                                         substepText{"Verifying target cluster against source cluster...", "Verify target cluster against source cluster"},
 }
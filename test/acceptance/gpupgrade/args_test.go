@@ -48,7 +48,7 @@ func TestArgs(t *testing.T) {
 			t.Errorf("expected error got nil")
 		}
 
-		expected := "Error: The file flag cannot be used with any other flag except verbose and non-interactive.\n"
+		expected := "Error: The file flag cannot be used with any other flag except verbose, non-interactive, and answers-file.\n"
 		if string(output) != expected {
 			t.Errorf("got %q want %q", string(output), expected)
 		}
@@ -61,7 +61,7 @@ func TestArgs(t *testing.T) {
 			"--target-gphome", acceptance.GPHOME_TARGET,
 			"--source-master-port", acceptance.PGPORT,
 			"--stop-before-cluster-creation",
-			"--disk-free-ratio", "0",
+			"--skip-disk-space-check",
 			"--pg-upgrade-verbose",
 		)
 		output, err := cmd.CombinedOutput()
@@ -80,7 +80,7 @@ func TestArgs(t *testing.T) {
 		contents := fmt.Sprintf(`source-gphome = %s
 target-gphome = %s
 source-master-port = %s
-disk-free-ratio = 0
+skip-disk-space-check = true
 stop-before-cluster-creation = true
 `, acceptance.GPHOME_SOURCE, acceptance.GPHOME_TARGET, acceptance.PGPORT)
 		testutils.MustWriteToFile(t, configFile, contents)
@@ -115,7 +115,7 @@ stop-before-cluster-creation = true
 			"--target-gphome", acceptance.GPHOME_TARGET+string(os.PathSeparator)+string(os.PathSeparator),
 			"--source-master-port", acceptance.PGPORT,
 			"--stop-before-cluster-creation",
-			"--disk-free-ratio", "0",
+			"--skip-disk-space-check",
 		)
 		output, err := cmd.CombinedOutput()
 		if err != nil {
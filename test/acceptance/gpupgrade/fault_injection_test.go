@@ -0,0 +1,200 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package gpupgrade_test
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/config"
+	"github.com/greenplum-db/gpupgrade/fault"
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/substeps"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/acceptance"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// TestInjectedFaults fails initialize, execute, and finalize before and after
+// each of their substeps. Afterwards initialize and finalize are re-run and
+// execute is reverted to verify the failure is recoverable.
+func TestInjectedFaults(t *testing.T) {
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	resetFaultEnv := testutils.SetEnv(t, fault.EnvVar, "1")
+	defer resetFaultEnv()
+
+	source := acceptance.GetSourceCluster(t)
+	hosts := source.Hosts()
+
+	t.Run("re-running initialize after it fails succeeds", func(t *testing.T) {
+		acceptance.Initialize(t, idl.Mode_copy)
+		points := substepPoints(t, idl.Step_initialize)
+		acceptance.Revert(t)
+
+		for _, point := range points {
+			t.Run(string(point), func(t *testing.T) {
+				defer acceptance.RevertIgnoreFailures(t) // cleanup in case we fail part way through
+
+				mustFailAt(t, hosts, point, "initialize",
+					"--non-interactive", "--verbose",
+					"--mode", idl.Mode_copy.String(),
+					"--source-gphome", acceptance.GPHOME_SOURCE,
+					"--target-gphome", acceptance.GPHOME_TARGET,
+					"--source-master-port", acceptance.PGPORT,
+					"--temp-port-range", acceptance.TARGET_PGPORT+"-6040",
+					"--skip-disk-space-check")
+
+				acceptance.Initialize(t, idl.Mode_copy)
+				revertAndVerify(t, source)
+			})
+		}
+	})
+
+	t.Run("reverting after execute fails succeeds", func(t *testing.T) {
+		acceptance.Initialize(t, idl.Mode_copy)
+		acceptance.Execute(t)
+		points := substepPoints(t, idl.Step_execute)
+		acceptance.Revert(t)
+
+		points = append(points,
+			fault.RPCPoint("UpgradePrimaries", fault.AnyHost),
+			fault.CommandPoint("pg_upgrade"),
+		)
+
+		for _, point := range points {
+			t.Run(string(point), func(t *testing.T) {
+				acceptance.Initialize(t, idl.Mode_copy)
+				defer acceptance.RevertIgnoreFailures(t) // cleanup in case we fail part way through
+
+				mustFailAt(t, hosts, point, "execute", "--non-interactive", "--verbose")
+
+				revertAndVerify(t, source)
+			})
+		}
+	})
+
+	t.Run("re-running finalize after it fails succeeds", func(t *testing.T) {
+		// The last substep deletes the state directory along with the status
+		// file. Fail right before it to read the status file and then finish
+		// finalize as for the rest of the points.
+		last := substeps.Steps[idl.Step_finalize][len(substeps.Steps[idl.Step_finalize])-1]
+		lastPoint := fault.SubstepPoint(idl.Step_finalize, last, fault.Before)
+
+		var points []fault.Point
+		testFinalizeFault(t, source, lastPoint, func() {
+			points = substepPoints(t, idl.Step_finalize)
+		})
+
+		for _, point := range points {
+			if point == lastPoint {
+				continue
+			}
+
+			t.Run(string(point), func(t *testing.T) {
+				testFinalizeFault(t, source, point, func() {})
+			})
+		}
+	})
+}
+
+// substepPoints returns the points before and after each substep of the step
+// in order. Only substeps completed according to the status file are included
+// since substeps whose run condition is not met never reach their faults.
+func substepPoints(t *testing.T, currentStep idl.Step) []fault.Point {
+	t.Helper()
+
+	store := step.NewSubstepStoreUsingFile(filepath.Join(utils.GetStateDir(), step.SubstepsFileName))
+	statuses, err := store.ReadStep(currentStep)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var points []fault.Point
+	for _, substep := range substeps.Steps[currentStep] {
+		if statuses[substep.String()].Status != idl.Status_complete {
+			continue
+		}
+
+		for _, when := range []fault.When{fault.Before, fault.After} {
+			points = append(points, fault.SubstepPoint(currentStep, substep, when))
+		}
+	}
+
+	if len(points) == 0 {
+		t.Fatalf("expected completed %s substeps in the status file", currentStep)
+	}
+
+	return points
+}
+
+// mustFailAt runs the gpupgrade command with a fault injected at point and
+// verifies it fails there. The fault is removed afterwards.
+func mustFailAt(t *testing.T, hosts []string, point fault.Point, command string, args ...string) {
+	t.Helper()
+
+	acceptance.InjectFaults(t, hosts, fault.Faults{point: fault.Fail})
+	defer acceptance.RemoveFaults(t, hosts)
+
+	cmd := exec.Command("gpupgrade", append([]string{command}, args...)...)
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected %s to fail at %s: %s", command, point, output)
+	}
+
+	if !strings.Contains(string(output), string(point)) {
+		t.Errorf("expected output %q to contain %q", output, point)
+	}
+}
+
+func revertAndVerify(t *testing.T, source greenplum.Cluster) {
+	t.Helper()
+
+	conf, err := config.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revertOutput := acceptance.Revert(t)
+
+	logArchiveDir := acceptance.MustGetLogArchiveDir(t, conf.UpgradeID)
+	verifyRevert(t, source, conf.Intermediate, revertOutput, logArchiveDir)
+}
+
+// testFinalizeFault fails finalize at point, calls afterFailure, and verifies
+// re-running finalize upgrades the cluster. The source cluster is restored
+// afterwards.
+func testFinalizeFault(t *testing.T, source greenplum.Cluster, point fault.Point, afterFailure func()) {
+	t.Helper()
+
+	backupDir := testutils.GetTempDir(t, "backup")
+	defer testutils.MustRemoveAll(t, backupDir)
+
+	acceptance.BackupDemoCluster(t, backupDir, source)
+	defer acceptance.RestoreDemoCluster(t, backupDir, source, acceptance.GetTempTargetCluster(t))
+
+	acceptance.Initialize(t, idl.Mode_copy)
+	defer acceptance.RevertIgnoreFailures(t) // cleanup in case we fail part way through
+
+	acceptance.Execute(t)
+
+	conf, err := config.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mustFailAt(t, source.Hosts(), point, "finalize", "--non-interactive", "--verbose")
+	afterFailure()
+
+	finalizeOutput := acceptance.Finalize(t)
+	verifyFinalize(t, source, conf, finalizeOutput, false)
+}
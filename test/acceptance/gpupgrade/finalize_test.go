@@ -5,6 +5,7 @@ package gpupgrade_test
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os/exec"
 	"path/filepath"
@@ -76,7 +77,7 @@ func testFinalize(t *testing.T, mode idl.Mode, useHbaHostnames bool) {
 		"--target-gphome", acceptance.GPHOME_TARGET,
 		"--source-master-port", acceptance.PGPORT,
 		"--temp-port-range", acceptance.TARGET_PGPORT+"-6040",
-		"--disk-free-ratio", "0",
+		"--skip-disk-space-check",
 		hbaHostnames)
 	output, err := cmd.CombinedOutput()
 	defer acceptance.RevertIgnoreFailures(t) // cleanup in case we fail part way through
@@ -194,7 +195,7 @@ func verifyFinalize(t *testing.T, source greenplum.Cluster, conf *config.Config,
 	// verify configuration matches before and after upgrading
 	compareFinalizedCluster(t, *conf.Target, acceptance.GetTargetCluster(t))
 
-	err := conf.Target.WaitForClusterToBeReady()
+	err := conf.Target.WaitForClusterToBeReady(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -6,18 +6,17 @@ package gpupgrade_test
 import (
 	"bufio"
 	"bytes"
-	"fmt"
 	"math"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
 
-	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/testutils"
 	"github.com/greenplum-db/gpupgrade/testutils/acceptance"
-	"github.com/greenplum-db/gpupgrade/utils/disk"
 )
 
 func TestCheckDiskSpace(t *testing.T) {
@@ -27,55 +26,72 @@ func TestCheckDiskSpace(t *testing.T) {
 	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
 	defer resetEnv()
 
-	t.Run("initialize fails when passed invalid --disk-free-ratio values", func(t *testing.T) {
-		opts := []string{
-			"1.5",
-			"-0.5",
-			"abcd",
+	t.Run("initialize skips disk space check when --skip-disk-space-check is set", func(t *testing.T) {
+		output := acceptance.Initialize(t, idl.Mode_copy)
+
+		if strings.Contains(output, idl.Substep_check_disk_space.String()) {
+			t.Fatalf("expected output %q to not contain %q", output, idl.Substep_check_disk_space)
 		}
+	})
 
-		for _, opt := range opts {
+	for _, mode := range []idl.Mode{idl.Mode_copy, idl.Mode_link} {
+		t.Run("initialize checks disk space based on the size of the cluster in "+mode.String()+" mode", func(t *testing.T) {
 			cmd := exec.Command("gpupgrade", "initialize",
 				"--non-interactive", "--verbose",
+				"--mode", mode.String(),
 				"--source-gphome", acceptance.GPHOME_SOURCE,
 				"--target-gphome", acceptance.GPHOME_TARGET,
 				"--source-master-port", acceptance.PGPORT,
 				"--temp-port-range", acceptance.TARGET_PGPORT+"-6040",
-				"--stop-before-cluster-creation",
-				"--disk-free-ratio", opt)
+				"--stop-before-cluster-creation")
 			output, err := cmd.CombinedOutput()
-			if err == nil {
-				t.Errorf("expected nil got error %v", err)
+			if err != nil {
+				t.Fatalf("unexpected err: %v stderr: %q", err, output)
 			}
+			defer acceptance.Revert(t)
 
-			initializeOutput := strings.ReplaceAll(string(output), `"`, ``)
-			expected := fmt.Sprintf("Error: invalid argument %s for --disk-free-ratio flag", opt)
-			if !strings.HasPrefix(initializeOutput, expected) {
-				t.Fatalf("got %q want %q", initializeOutput, expected)
+			expected := "Checking disk space"
+			if !strings.Contains(string(output), expected) {
+				t.Fatalf("expected %q to contain %q", string(output), expected)
 			}
+		})
+	}
+
+	t.Run("initialize fails with the shortfall when the cluster is larger than the available disk space", func(t *testing.T) {
+		source := acceptance.GetSourceCluster(t)
+		available := availableDiskSpaceInKb(t, source.CoordinatorHostname(), source.CoordinatorDataDir())
+
+		// The estimate sums apparent file sizes, so a sparse file larger than
+		// the available space forces a shortfall without consuming any disk.
+		const extraKb = 1000 * 1000 * 1000 // 1 TB
+		path := filepath.Join(source.CoordinatorDataDir(), "gpupgrade_check_disk_space")
+		file, err := os.Create(path)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
 		}
-	})
+		defer testutils.MustRemoveAll(t, path)
 
-	t.Run("initialize skips disk space check when --disk-free-ratio is 0", func(t *testing.T) {
-		output := acceptance.Initialize(t, idl.Mode_copy)
+		err = file.Truncate(int64((available + extraKb) * 1024))
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
 
-		if strings.Contains(output, idl.Substep_check_disk_space.String()) {
-			t.Fatalf("expected output %q to not contain %q", output, idl.Substep_check_disk_space)
+		err = file.Close()
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
 		}
-	})
 
-	t.Run("initialize fails with disk space error", func(t *testing.T) {
 		cmd := exec.Command("gpupgrade", "initialize",
 			"--non-interactive", "--verbose",
+			"--mode", idl.Mode_copy.String(),
 			"--source-gphome", acceptance.GPHOME_SOURCE,
 			"--target-gphome", acceptance.GPHOME_TARGET,
 			"--source-master-port", acceptance.PGPORT,
 			"--temp-port-range", acceptance.TARGET_PGPORT+"-6040",
-			"--stop-before-cluster-creation",
-			"--disk-free-ratio", "1.0")
+			"--stop-before-cluster-creation")
 		output, err := cmd.CombinedOutput()
 		if err == nil {
-			t.Errorf("expected nil got error %v", err)
+			t.Errorf("expected error got nil")
 		}
 		defer acceptance.Revert(t)
 
@@ -84,60 +100,37 @@ func TestCheckDiskSpace(t *testing.T) {
 			t.Fatalf("expected %q to contain %q", string(output), expected)
 		}
 
-		actualUsages := actualDiskUsage(t, output)
-		expectedUsages := expectedDiskUsage(t)
+		usages := reportedDiskUsage(t, output)
+		usage, ok := usages[source.CoordinatorHostname()]
+		if !ok {
+			t.Fatalf("expected %q to report a shortfall for host %q", string(output), source.CoordinatorHostname())
+		}
 
-		for actualFS, actualUsage := range actualUsages {
-			expectedUsage, ok := expectedUsages[actualFS]
-			if !ok {
-				t.Fatalf("actual filesystem not found. got %v want %v", actualFS, expectedUsage)
-			}
+		if !withinTolerance(usage.available, available) {
+			t.Errorf("got available %d KB want %d KB", usage.available, available)
+		}
 
-			if !withinTolerance(actualUsage.GetAvailable(), expectedUsage.GetAvailable()) {
-				t.Errorf("got %d want %d", actualUsage.GetAvailable(), expectedUsage.GetAvailable())
-			}
+		if !withinTolerance(usage.shortfall, usage.required-usage.available) {
+			t.Errorf("got shortfall %d KB want required %d KB less available %d KB", usage.shortfall, usage.required, usage.available)
+		}
 
-			if !withinTolerance(actualUsage.GetRequired(), expectedUsage.GetRequired()) {
-				t.Errorf("got %d want %d", actualUsage.GetRequired(), expectedUsage.GetRequired())
-			}
+		// The shortfall is the sparse file in excess of the available space
+		// plus the rest of the cluster which is small in comparison.
+		if !withinTolerance(usage.shortfall, extraKb) {
+			t.Errorf("got shortfall %d KB want about %d KB", usage.shortfall, uint64(extraKb))
 		}
 	})
 }
 
+// withinTolerance allows for the four significant digits of the reported sizes
+// and for the disk usage changing between measurements.
 func withinTolerance(actual uint64, expected uint64) bool {
-	tolerance := 0.001
+	tolerance := 0.01
 
 	percentDiff := math.Abs(float64(actual)-float64(expected)) / float64(expected)
 	return percentDiff <= tolerance
 }
 
-func expectedDiskUsage(t *testing.T) map[disk.FilesystemHost]*idl.CheckDiskSpaceReply_DiskUsage {
-	source := acceptance.GetSourceCluster(t)
-	segments := source.SelectSegments(func(seg *greenplum.SegConfig) bool {
-		return !seg.IsMirror()
-	})
-
-	usage := make(map[disk.FilesystemHost]*idl.CheckDiskSpaceReply_DiskUsage)
-	visitedHosts := make(map[string]bool)
-	for _, seg := range segments {
-		if _, ok := visitedHosts[seg.Hostname]; ok {
-			continue
-		}
-
-		visitedHosts[seg.Hostname] = true
-
-		fs := disk.FilesystemHost{Host: seg.Hostname, Filesystem: ""}
-		usage[fs] = &idl.CheckDiskSpaceReply_DiskUsage{
-			Fs:        "",
-			Host:      seg.Hostname,
-			Available: availableDiskSpaceInKb(t, seg.Hostname, seg.DataDir),
-			Required:  totalDiskSpaceInKb(t, seg.Hostname, seg.DataDir), // required is total disk space since --disk-free-ratio is 1
-		}
-	}
-
-	return usage
-}
-
 func availableDiskSpaceInKb(t *testing.T, host string, path string) uint64 {
 	// Use the external stat utility rather than os.stat to test using a different
 	// implementation than the code itself.
@@ -155,27 +148,16 @@ func availableDiskSpaceInKb(t *testing.T, host string, path string) uint64 {
 	return uint64(availableSpace)
 }
 
-func totalDiskSpaceInKb(t *testing.T, host string, path string) uint64 {
-	// Use the external stat utility rather than os.stat to test using a different
-	// implementation than the code itself.
-	cmd := exec.Command("ssh", host, acceptance.GetStatUtility(), "-f -c '%S %a %f %b'", path)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		t.Fatalf("unexpected err: %v stderr: %q", err, output)
-	}
-
-	parts := strings.Fields(strings.TrimSpace(string(output)))
-	blockSize := testutils.MustConvertStringToInt(t, parts[0])
-	blockAvailable := testutils.MustConvertStringToInt(t, parts[1])
-	blockFree := testutils.MustConvertStringToInt(t, parts[2])
-	blockTotal := testutils.MustConvertStringToInt(t, parts[3])
-
-	totalSpace := (blockTotal - blockFree + blockAvailable) * blockSize / 1024
-	return uint64(totalSpace)
+type diskUsage struct {
+	shortfall uint64
+	available uint64
+	required  uint64
 }
 
-func actualDiskUsage(t *testing.T, output []byte) map[disk.FilesystemHost]*idl.CheckDiskSpaceReply_DiskUsage {
-	usage := make(map[disk.FilesystemHost]*idl.CheckDiskSpaceReply_DiskUsage)
+// reportedDiskUsage parses the table of the disk space error returning the
+// filesystem with the largest shortfall for each host.
+func reportedDiskUsage(t *testing.T, output []byte) map[string]diskUsage {
+	usages := make(map[string]diskUsage)
 
 	parseUsage := false
 	scanner := bufio.NewScanner(bytes.NewReader(output))
@@ -186,30 +168,33 @@ func actualDiskUsage(t *testing.T, output []byte) map[disk.FilesystemHost]*idl.C
 			continue
 		}
 
-		if parseUsage && line != "" {
-			parts := strings.Fields(line)
+		if parseUsage && line == "" {
+			break
+		}
 
-			fs := disk.FilesystemHost{Host: parts[0], Filesystem: ""}
-			usage[fs] = &idl.CheckDiskSpaceReply_DiskUsage{
-				Fs:        parts[0],
-				Host:      parts[1],
-				Available: convertToKBytes(t, parts[4], parts[5]),
-				Required:  convertToKBytes(t, parts[6], parts[7]),
+		if parseUsage {
+			parts := strings.Fields(line)
+			if len(parts) != 8 {
+				t.Fatalf("unexpected disk usage row %q", line)
 			}
 
-		}
+			usage := diskUsage{
+				shortfall: convertToKBytes(t, parts[2], parts[3]),
+				available: convertToKBytes(t, parts[4], parts[5]),
+				required:  convertToKBytes(t, parts[6], parts[7]),
+			}
 
-		if parseUsage && line == "" {
-			break
+			if usage.shortfall > usages[parts[0]].shortfall {
+				usages[parts[0]] = usage
+			}
 		}
-
 	}
 
 	if err := scanner.Err(); err != nil {
 		t.Fatalf("scanning output: %v", err)
 	}
 
-	return usage
+	return usages
 }
 
 func convertToKBytes(t *testing.T, input string, units string) uint64 {
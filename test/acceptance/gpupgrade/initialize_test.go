@@ -44,7 +44,7 @@ func TestInitialize(t *testing.T) {
 			"--target-gphome", acceptance.GPHOME_TARGET,
 			"--source-master-port", acceptance.PGPORT,
 			"--temp-port-range", expectedPortRange,
-			"--disk-free-ratio", "0")
+			"--skip-disk-space-check")
 		output, err := cmd.CombinedOutput()
 		defer acceptance.Revert(t)
 		if err != nil {
@@ -83,7 +83,7 @@ func TestInitialize(t *testing.T) {
 			"--target-gphome", acceptance.GPHOME_TARGET,
 			"--source-master-port", acceptance.PGPORT,
 			"--temp-port-range", acceptance.PGPORT+"-"+strconv.Itoa(testutils.MustConvertStringToInt(t, acceptance.PGPORT)+20),
-			"--disk-free-ratio", "0")
+			"--skip-disk-space-check")
 		output, err := cmd.CombinedOutput()
 		if err == nil {
 			t.Errorf("expected nil got error %v", err)
@@ -115,7 +115,7 @@ func TestInitialize(t *testing.T) {
 			"--source-master-port", acceptance.PGPORT,
 			"--temp-port-range", acceptance.TARGET_PGPORT+"-6040",
 			"--stop-before-cluster-creation",
-			"--disk-free-ratio", "0")
+			"--skip-disk-space-check")
 		output, err := cmd.CombinedOutput()
 		defer acceptance.Revert(t)
 		if err == nil {
@@ -146,7 +146,7 @@ func TestInitialize(t *testing.T) {
 			"--target-gphome", acceptance.GPHOME_TARGET,
 			"--source-master-port", acceptance.PGPORT,
 			"--temp-port-range", acceptance.TARGET_PGPORT+"-6040",
-			"--disk-free-ratio", "0")
+			"--skip-disk-space-check")
 		output, err := cmd.CombinedOutput()
 		if err == nil {
 			t.Errorf("expected nil got error %v", err)
@@ -4,6 +4,7 @@
 package gpupgrade_test
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -59,7 +60,7 @@ func TestRevert(t *testing.T) {
 			"--target-gphome", acceptance.GPHOME_TARGET,
 			"--source-master-port", acceptance.PGPORT,
 			"--temp-port-range", acceptance.TARGET_PGPORT+"-6040",
-			"--disk-free-ratio", "0",
+			"--skip-disk-space-check",
 			"--seed-dir", filepath.Join(acceptance.MustGetRepoRoot(t), "data-migration-scripts"))
 		cmd.Stdin = strings.NewReader("y\nq\n") // cause initialize to exit early
 		output, err := cmd.CombinedOutput()
@@ -281,7 +282,7 @@ func verifyRevert(t *testing.T, source greenplum.Cluster, intermediate *greenplu
 		t.Errorf("want: %v", acceptance.GetSourceCluster(t))
 	}
 
-	err := source.WaitForClusterToBeReady()
+	err := source.WaitForClusterToBeReady(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
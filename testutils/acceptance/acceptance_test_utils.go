@@ -16,6 +16,7 @@ import (
 
 	"github.com/blang/semver/v4"
 
+	"github.com/greenplum-db/gpupgrade/fault"
 	"github.com/greenplum-db/gpupgrade/greenplum"
 	"github.com/greenplum-db/gpupgrade/greenplum/connection"
 	"github.com/greenplum-db/gpupgrade/hub"
@@ -119,7 +120,7 @@ func Initialize_stopBeforeClusterCreation(t *testing.T) string {
 		"--source-master-port", PGPORT,
 		"--temp-port-range", TARGET_PGPORT+"-6040",
 		"--stop-before-cluster-creation",
-		"--disk-free-ratio", "0")
+		"--skip-disk-space-check")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		t.Fatalf("unexpected err: %#v stderr %s", err, output)
@@ -138,7 +139,7 @@ func Initialize(t *testing.T, mode idl.Mode) string {
 		"--target-gphome", GPHOME_TARGET,
 		"--source-master-port", PGPORT,
 		"--temp-port-range", TARGET_PGPORT+"-6040",
-		"--disk-free-ratio", "0")
+		"--skip-disk-space-check")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		t.Fatalf("unexpected err: %#v stderr %s", err, output)
@@ -435,6 +436,32 @@ func MustGetLogArchiveDir(t *testing.T, upgradeID string) string {
 	return hub.GetLogArchiveDir(logDir, upgradeID, time.Now())
 }
 
+// InjectFaults writes the faults to the state directory of each host. The hub
+// and agents only inject them when started with GPUPGRADE_FAULT_INJECTION set
+// or when built with the faultinjection tag. The file is written over ssh
+// rather than rsync since rsync itself may have a fault injected.
+func InjectFaults(t *testing.T, hosts []string, faults fault.Faults) {
+	t.Helper()
+
+	path := filepath.Join(utils.GetStateDir(), fault.FileName)
+	for _, host := range hosts {
+		cmd := exec.Command("ssh", host, fmt.Sprintf("cat > %s", path))
+		cmd.Stdin = strings.NewReader(faults.String() + "\n")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("injecting faults on host %s: %v stderr: %q", host, err, output)
+		}
+	}
+}
+
+func RemoveFaults(t *testing.T, hosts []string) {
+	t.Helper()
+
+	for _, host := range hosts {
+		testutils.MustRemoveAllRemotely(t, host, filepath.Join(utils.GetStateDir(), fault.FileName))
+	}
+}
+
 func CreateMarkerFilesOnMirrors(t *testing.T, mirrors greenplum.ContentToSegConfig) {
 	t.Helper()
 
@@ -0,0 +1,276 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package fakegphome
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/blang/semver/v4"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+)
+
+// CatalogFileName is written to every data directory. It holds what the fake
+// postgres answers queries with. Only the coordinator has segments.
+const CatalogFileName = "gpupgrade_fake_catalog.json"
+
+type Catalog struct {
+	Version        string            `json:"version"`
+	CatalogVersion string            `json:"catalog_version"`
+	Settings       map[string]string `json:"settings"`
+	Segments       []Segment         `json:"segments,omitempty"`
+
+	// Responses answer queries matching their pattern before any table is
+	// consulted. Tests add them to simulate user data such as tablespaces.
+	Responses []Response `json:"responses,omitempty"`
+}
+
+// Segment is a row of gp_segment_configuration.
+type Segment struct {
+	DbID          int    `json:"dbid"`
+	ContentID     int    `json:"content"`
+	Role          string `json:"role"`
+	PreferredRole string `json:"preferred_role"`
+	Mode          string `json:"mode"`
+	Status        string `json:"status"`
+	Port          int    `json:"port"`
+	Hostname      string `json:"hostname"`
+	Address       string `json:"address"`
+	DataDir       string `json:"datadir"`
+}
+
+type Response struct {
+	Pattern string     `json:"pattern"`
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
+type versionDefaults struct {
+	pgVersion      string
+	serverVersion  string
+	catalogVersion string
+	controlVersion string
+	// recoveryConf holds the primary_conninfo of mirrors and the standby.
+	recoveryConf string
+}
+
+var defaultsByMajor = map[uint64]versionDefaults{
+	6: {pgVersion: "9.4", serverVersion: "9.4.26", catalogVersion: "301908232", controlVersion: "9420600", recoveryConf: "recovery.conf"},
+	7: {pgVersion: "12", serverVersion: "12.12", catalogVersion: "302307241", controlVersion: "12010700", recoveryConf: "postgresql.auto.conf"},
+}
+
+func defaultsFor(version string) (versionDefaults, error) {
+	v, err := semver.Parse(version)
+	if err != nil {
+		return versionDefaults{}, xerrors.Errorf("parse version %q: %w", version, err)
+	}
+
+	defaults, ok := defaultsByMajor[v.Major]
+	if !ok {
+		return versionDefaults{}, xerrors.Errorf("fake Greenplum %s is not supported. Only 6X and 7X are.", version)
+	}
+
+	return defaults, nil
+}
+
+func NewCatalog(version string) (Catalog, error) {
+	defaults, err := defaultsFor(version)
+	if err != nil {
+		return Catalog{}, err
+	}
+
+	return Catalog{
+		Version:        version,
+		CatalogVersion: defaults.catalogVersion,
+		Settings: map[string]string{
+			"server_encoding":     "UTF8",
+			"checkpoint_segments": "8",
+			"max_connections":     "250",
+			"server_version":      defaults.serverVersion,
+		},
+	}, nil
+}
+
+func ReadCatalog(dataDir string) (Catalog, error) {
+	contents, err := os.ReadFile(filepath.Join(dataDir, CatalogFileName))
+	if err != nil {
+		return Catalog{}, xerrors.Errorf("read fake catalog: %w", err)
+	}
+
+	var catalog Catalog
+	if err := json.Unmarshal(contents, &catalog); err != nil {
+		return Catalog{}, xerrors.Errorf("parse fake catalog: %w", err)
+	}
+
+	return catalog, nil
+}
+
+// WriteCatalog atomically replaces the catalog of dataDir so that a running
+// fake postgres never reads a partially written one.
+func WriteCatalog(dataDir string, catalog Catalog) error {
+	sort.Slice(catalog.Segments, func(i, j int) bool {
+		if catalog.Segments[i].ContentID != catalog.Segments[j].ContentID {
+			return catalog.Segments[i].ContentID < catalog.Segments[j].ContentID
+		}
+		return catalog.Segments[i].Role > catalog.Segments[j].Role // primaries before mirrors
+	})
+
+	contents, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dataDir, CatalogFileName)
+	if err := os.WriteFile(path+".tmp", contents, 0600); err != nil {
+		return xerrors.Errorf("write fake catalog: %w", err)
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+func (c *Catalog) Coordinator() (Segment, error) {
+	for _, seg := range c.Segments {
+		if seg.ContentID == -1 && seg.Role == greenplum.PrimaryRole {
+			return seg, nil
+		}
+	}
+
+	return Segment{}, errors.New("fake catalog has no coordinator")
+}
+
+func (c *Catalog) Standby() (Segment, bool) {
+	for _, seg := range c.Segments {
+		if seg.ContentID == -1 && seg.Role == greenplum.MirrorRole {
+			return seg, true
+		}
+	}
+
+	return Segment{}, false
+}
+
+func (c *Catalog) Primary(content int) (Segment, bool) {
+	for _, seg := range c.Segments {
+		if seg.ContentID == content && seg.Role == greenplum.PrimaryRole {
+			return seg, true
+		}
+	}
+
+	return Segment{}, false
+}
+
+func (c *Catalog) nextDbID() int {
+	max := 0
+	for _, seg := range c.Segments {
+		if seg.DbID > max {
+			max = seg.DbID
+		}
+	}
+
+	return max + 1
+}
+
+func (c *Catalog) addSegment(seg Segment) {
+	c.Segments = append(c.Segments, seg)
+}
+
+// synchronize marks the segments synchronized when the cluster has mirrors as
+// is the case once they are added and streaming.
+func (c *Catalog) synchronize() {
+	mirrored := false
+	for _, seg := range c.Segments {
+		if seg.ContentID != -1 && seg.Role == greenplum.MirrorRole {
+			mirrored = true
+		}
+	}
+
+	if !mirrored {
+		return
+	}
+
+	for i := range c.Segments {
+		if c.Segments[i].ContentID != -1 {
+			c.Segments[i].Mode = "s"
+		}
+	}
+}
+
+func (c *Catalog) removeSegments(remove func(Segment) bool) []Segment {
+	var kept, removed []Segment
+	for _, seg := range c.Segments {
+		if remove(seg) {
+			removed = append(removed, seg)
+			continue
+		}
+		kept = append(kept, seg)
+	}
+
+	c.Segments = kept
+	return removed
+}
+
+func SegmentFromSegConfig(seg greenplum.SegConfig) Segment {
+	address := seg.Address
+	if address == "" {
+		address = seg.Hostname
+	}
+
+	return Segment{
+		DbID:          seg.DbID,
+		ContentID:     seg.ContentID,
+		Role:          seg.Role,
+		PreferredRole: seg.Role,
+		Mode:          "n",
+		Status:        "u",
+		Port:          seg.Port,
+		Hostname:      seg.Hostname,
+		Address:       address,
+		DataDir:       seg.DataDir,
+	}
+}
+
+func (s Segment) row() map[string]string {
+	return map[string]string{
+		"dbid":           strconv.Itoa(s.DbID),
+		"content":        strconv.Itoa(s.ContentID),
+		"role":           s.Role,
+		"preferred_role": s.PreferredRole,
+		"mode":           s.Mode,
+		"status":         s.Status,
+		"port":           strconv.Itoa(s.Port),
+		"hostname":       s.Hostname,
+		"address":        s.Address,
+		"datadir":        s.DataDir,
+	}
+}
+
+func segmentFromRow(row map[string]string) (Segment, error) {
+	var seg Segment
+	var err error
+	for _, field := range []struct {
+		name string
+		dst  *int
+	}{{"dbid", &seg.DbID}, {"content", &seg.ContentID}, {"port", &seg.Port}} {
+		*field.dst, err = strconv.Atoi(row[field.name])
+		if err != nil {
+			return Segment{}, fmt.Errorf("invalid %s %q", field.name, row[field.name])
+		}
+	}
+
+	seg.Role = row["role"]
+	seg.PreferredRole = row["preferred_role"]
+	seg.Mode = row["mode"]
+	seg.Status = row["status"]
+	seg.Hostname = row["hostname"]
+	seg.Address = row["address"]
+	seg.DataDir = row["datadir"]
+
+	return seg, nil
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// fakegp is the fake Greenplum utility named by the link it is run as.
+package main
+
+import (
+	"os"
+
+	"github.com/greenplum-db/gpupgrade/testutils/fakegphome"
+)
+
+func main() {
+	os.Exit(fakegphome.Main(os.Args))
+}
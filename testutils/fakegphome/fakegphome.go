@@ -0,0 +1,150 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fakegphome generates a fake Greenplum installation such that the
+// initialize, execute, finalize, and revert flows can be run hermetically on
+// a single machine without Greenplum.
+//
+// The GPHOME has a single fakegp binary linked as postgres, pg_upgrade,
+// pg_controldata, gpinitsystem, gpstart, gpstop, gpaddmirrors, gpinitstandby,
+// gprecoverseg, and gpconfig. Each data directory has PG_VERSION,
+// postgresql.conf, pg_hba.conf, and a JSON catalog. The fake postgres listens
+// on the port of its segment and answers the queries of gpupgrade from the
+// coordinator catalog, which holds gp_segment_configuration, settings, and
+// canned responses to simulate user data. The hub and agents reach hosts
+// with ssh and rsync, so put the directory from Shims first in PATH to run
+// the full CLI without sshd or rsync.
+package fakegphome
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+const fakegp = "fakegp"
+
+// Generate builds a fake GPHOME of the given version in a temporary directory
+// that is removed once the test completes.
+func Generate(t *testing.T, version semver.Version) string {
+	t.Helper()
+
+	if _, err := defaultsFor(version.String()); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	gphome := testutils.GetTempDir(t, "fake_gphome")
+	t.Cleanup(func() { testutils.MustRemoveAll(t, gphome) })
+
+	bin := filepath.Join(gphome, "bin")
+	testutils.MustCreateDir(t, bin)
+	buildFakegp(t, bin)
+
+	for _, utility := range Utilities() {
+		if err := os.Symlink(fakegp, filepath.Join(bin, utility)); err != nil {
+			t.Fatalf("linking %s: %+v", utility, err)
+		}
+	}
+
+	testutils.MustWriteToFile(t, filepath.Join(gphome, versionFile), version.String()+"\n")
+	testutils.MustWriteToFile(t, filepath.Join(gphome, "greenplum_path.sh"),
+		"GPHOME="+strconv.Quote(gphome)+"\nPATH=$GPHOME/bin:$PATH\nexport GPHOME PATH\n")
+
+	return gphome
+}
+
+func buildFakegp(t *testing.T, dir string) {
+	t.Helper()
+
+	cmd := exec.Command("go", "build", "-o", filepath.Join(dir, fakegp), "github.com/greenplum-db/gpupgrade/testutils/fakegphome/fakegp")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("building %s: %+v: %s", fakegp, err, output)
+	}
+}
+
+// CreateCluster initializes and starts a cluster of the given segments using
+// the fake GPHOME. The cluster is stopped once the test completes.
+func CreateCluster(t *testing.T, gphome string, destination idl.ClusterDestination, segments greenplum.SegConfigs) *greenplum.Cluster {
+	t.Helper()
+
+	version, err := readVersion(gphome)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	catalog, err := NewCatalog(version)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	for _, seg := range segments {
+		catalog.addSegment(SegmentFromSegConfig(seg))
+	}
+	catalog.synchronize()
+
+	for _, seg := range catalog.Segments {
+		if err := initDataDir(seg, catalog); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	cluster, err := greenplum.NewCluster(segments)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	cluster.Destination = destination
+	cluster.GPHome = gphome
+	cluster.Version = semver.MustParse(version)
+	cluster.CatalogVersion = catalog.CatalogVersion
+
+	// Stop the segments in the catalog at the end of the test in addition to
+	// the original ones, as the test may have added some or stopped only the
+	// coordinator.
+	t.Cleanup(func() {
+		segs := catalog.Segments
+		if current, err := ReadCatalog(cluster.CoordinatorDataDir()); err == nil {
+			segs = append(segs, current.Segments...)
+		}
+
+		for _, seg := range segs {
+			if err := stopSegment(seg); err != nil {
+				t.Errorf("stopping fake segment: %+v", err)
+			}
+		}
+	})
+
+	err = cluster.Start(step.DevNullStream)
+	if err != nil {
+		t.Fatalf("starting fake cluster: %+v", err)
+	}
+
+	return &cluster
+}
+
+// AddResponse answers queries matching pattern with the given rows. It
+// simulates user data such as tablespaces and extensions that the fake
+// catalog does not otherwise have.
+func AddResponse(t *testing.T, coordinatorDataDir string, response Response) {
+	t.Helper()
+
+	catalog, err := ReadCatalog(coordinatorDataDir)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	catalog.Responses = append(catalog.Responses, response)
+	if err := WriteCatalog(coordinatorDataDir, catalog); err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
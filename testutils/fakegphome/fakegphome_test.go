@@ -0,0 +1,568 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package fakegphome_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+	"github.com/greenplum-db/gpupgrade/hub"
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/fakegphome"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+var (
+	sourceVersion = semver.MustParse("6.26.0")
+	targetVersion = semver.MustParse("7.1.0")
+)
+
+func TestVersion(t *testing.T) {
+	for _, version := range []semver.Version{sourceVersion, targetVersion} {
+		t.Run(version.String(), func(t *testing.T) {
+			gphome := fakegphome.Generate(t, version)
+
+			actual, err := greenplum.Version(gphome)
+			if err != nil {
+				t.Fatalf("unexpected error %#v", err)
+			}
+
+			if !actual.Equals(version) {
+				t.Errorf("got version %s want %s", actual, version)
+			}
+		})
+	}
+}
+
+func TestCluster(t *testing.T) {
+	gphome := fakegphome.Generate(t, sourceVersion)
+
+	t.Run("starts and stops the cluster", func(t *testing.T) {
+		source := fakegphome.CreateCluster(t, gphome, idl.ClusterDestination_source, segments(t, "source", true))
+
+		running, err := source.IsCoordinatorRunning(step.DevNullStream)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if !running {
+			t.Fatal("expected coordinator to be running")
+		}
+
+		catalogVersion, err := hub.GetCatalogVersion(source)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if catalogVersion != "301908232" {
+			t.Errorf("got catalog version %q want %q", catalogVersion, "301908232")
+		}
+
+		if err := source.Stop(step.DevNullStream); err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		running, err = source.IsCoordinatorRunning(step.DevNullStream)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if running {
+			t.Error("expected coordinator to be stopped")
+		}
+	})
+
+	t.Run("answers queries from the catalog", func(t *testing.T) {
+		segs := segments(t, "source", true)
+		source := fakegphome.CreateCluster(t, gphome, idl.ClusterDestination_source, segs)
+
+		db := mustOpen(t, source)
+
+		actual, err := greenplum.GetSegmentConfiguration(db, source.Version)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		sort.Sort(actual)
+		sort.Sort(segs)
+		if !reflect.DeepEqual(actual, segs) {
+			t.Errorf("got %+v want %+v", actual, segs)
+		}
+
+		err = greenplum.WaitForSegments(context.Background(), db, 5*time.Second, source)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		config, err := hub.GetCheckpointSegmentsAndEncoding(nil, source.Version, db)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := []string{"ENCODING=UTF8", "CHECK_POINT_SEGMENTS=8"}
+		if !reflect.DeepEqual(config, expected) {
+			t.Errorf("got %q want %q", config, expected)
+		}
+	})
+
+	t.Run("answers queries with responses added by tests", func(t *testing.T) {
+		source := fakegphome.CreateCluster(t, gphome, idl.ClusterDestination_source, segments(t, "source", false))
+
+		fakegphome.AddResponse(t, source.CoordinatorDataDir(), fakegphome.Response{
+			Pattern: `(?i)FROM pg_tablespace`,
+			Columns: []string{"spcname"},
+			Rows:    [][]string{{"batman"}},
+		})
+
+		var name string
+		err := mustOpen(t, source).QueryRow("SELECT spcname FROM pg_tablespace WHERE spcname <> $1", "pg_default").Scan(&name)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if name != "batman" {
+			t.Errorf("got %q want %q", name, "batman")
+		}
+	})
+}
+
+func TestUpgrade(t *testing.T) {
+	stateDir := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, stateDir)
+
+	resetEnv := testutils.SetEnv(t, "GPUPGRADE_HOME", stateDir)
+	defer resetEnv()
+
+	utils.System.Current = func() (*user.User, error) {
+		return &user.User{HomeDir: stateDir}, nil
+	}
+	defer utils.ResetSystemFunctions()
+
+	sourceGPHome := fakegphome.Generate(t, sourceVersion)
+	targetGPHome := fakegphome.Generate(t, targetVersion)
+
+	source := fakegphome.CreateCluster(t, sourceGPHome, idl.ClusterDestination_source, segments(t, "source", false))
+
+	intermediate := mustCreateCluster(t, segments(t, "intermediate", true))
+	intermediate.Destination = idl.ClusterDestination_intermediate
+	intermediate.GPHome = targetGPHome
+	intermediate.Version = targetVersion
+	defer intermediate.Stop(step.DevNullStream) //nolint:errcheck
+
+	t.Run("initializes the intermediate cluster with gpinitsystem", func(t *testing.T) {
+		config, err := hub.CreateInitialInitsystemConfig(intermediate.CoordinatorDataDir(), false)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		config, err = hub.GetCheckpointSegmentsAndEncoding(config, source.Version, mustOpen(t, source))
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		config, err = hub.WriteSegmentArray(config, intermediate)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		err = hub.WriteInitsystemFile(config, utils.GetInitsystemConfig())
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		err = hub.InitTargetCluster(step.DevNullStream, intermediate)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		running, err := intermediate.IsCoordinatorRunning(step.DevNullStream)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if !running {
+			t.Error("expected intermediate coordinator to be running")
+		}
+
+		catalogVersion, err := hub.GetCatalogVersion(intermediate)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if catalogVersion != "302307241" {
+			t.Errorf("got catalog version %q want %q", catalogVersion, "302307241")
+		}
+
+		if err := intermediate.Stop(step.DevNullStream); err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("fails to upgrade a running source cluster", func(t *testing.T) {
+		err := upgrade.Run(context.Background(), new(strings.Builder), new(strings.Builder), pgOptions(source, intermediate, idl.PgOptions_upgrade))
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("upgrades the coordinator with pg_upgrade", func(t *testing.T) {
+		if err := source.Stop(step.DevNullStream); err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		fakegphome.AddResponse(t, source.CoordinatorDataDir(), fakegphome.Response{
+			Pattern: `(?i)FROM pg_tablespace`,
+			Columns: []string{"spcname"},
+			Rows:    [][]string{{"batman"}},
+		})
+
+		for _, action := range []idl.PgOptions_Action{idl.PgOptions_check, idl.PgOptions_upgrade} {
+			stdout := new(strings.Builder)
+			err := upgrade.Run(context.Background(), stdout, new(strings.Builder), pgOptions(source, intermediate, action))
+			if err != nil {
+				t.Fatalf("unexpected error %#v", err)
+			}
+
+			expected := map[idl.PgOptions_Action]string{
+				idl.PgOptions_check:   "*Clusters are compatible*",
+				idl.PgOptions_upgrade: "Upgrade Complete",
+			}[action]
+			if !strings.Contains(stdout.String(), expected) {
+				t.Errorf("expected stdout %q to contain %q", stdout.String(), expected)
+			}
+		}
+
+		catalog, err := fakegphome.ReadCatalog(intermediate.CoordinatorDataDir())
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		if len(catalog.Responses) != 1 {
+			t.Errorf("expected the responses of the source to be upgraded but got %+v", catalog.Responses)
+		}
+	})
+
+	t.Run("adds mirrors and a standby and updates the catalog", func(t *testing.T) {
+		if err := intermediate.Start(step.DevNullStream); err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		err := hub.UpgradeMirrorsUsingGpAddMirrors(step.DevNullStream, intermediate, false)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		// Retrying with pg_basebackup must not overwrite the added mirrors.
+		streams := &step.BufferedStreams{}
+		err = hub.UpgradeMirrorsUsingPgBasebackup(context.Background(), streams, nil, intermediate, false, utils.AnyAddressFamily)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		expected := "Skipping pg_basebackup since all 2 mirrors were already added."
+		if !strings.Contains(streams.StdoutBuf.String(), expected) {
+			t.Errorf("expected stdout %q to contain %q", streams.StdoutBuf.String(), expected)
+		}
+
+		err = hub.UpgradeStandby(step.DevNullStream, intermediate, false)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		db := mustOpen(t, intermediate)
+		err = greenplum.WaitForSegments(context.Background(), db, 5*time.Second, intermediate)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+
+		// Point the catalog at new ports keeping the data directories such
+		// that the cluster can still be stopped.
+		var segs greenplum.SegConfigs
+		for _, seg := range intermediate.Primaries {
+			segs = append(segs, seg)
+		}
+		for _, seg := range intermediate.Mirrors {
+			segs = append(segs, seg)
+		}
+		for i := range segs {
+			segs[i].Port = testutils.MustGetPort(t)
+		}
+
+		err = hub.UpdateCatalog(intermediate, mustCreateCluster(t, segs))
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		actual, err := greenplum.GetSegmentConfiguration(db, intermediate.Version)
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		sort.Sort(actual)
+		sort.Sort(segs)
+		if !reflect.DeepEqual(actual, segs) {
+			t.Errorf("got %+v want %+v", actual, segs)
+		}
+	})
+}
+
+// TestGpupgrade runs the gpupgrade CLI along with the real hub and agents
+// against fake clusters through each step.
+func TestGpupgrade(t *testing.T) {
+	bin := testutils.GetTempDir(t, "bin")
+	defer testutils.MustRemoveAll(t, bin)
+
+	cmd := exec.Command("go", "build", "-o", filepath.Join(bin, "gpupgrade"), "github.com/greenplum-db/gpupgrade/cmd/gpupgrade")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("building gpupgrade: %+v: %s", err, output)
+	}
+
+	sourceGPHome := fakegphome.Generate(t, sourceVersion)
+	targetGPHome := fakegphome.Generate(t, targetVersion)
+	path := bin + string(os.PathListSeparator) + fakegphome.Shims(t) + string(os.PathListSeparator) + os.Getenv("PATH")
+
+	t.Run("reverts after execute", func(t *testing.T) {
+		source := fakegphome.CreateCluster(t, sourceGPHome, idl.ClusterDestination_source, segments(t, "source", true))
+		gpupgrade := newGpupgrade(t, bin, path, source, targetGPHome)
+
+		gpupgrade.run(t, "initialize")
+		gpupgrade.run(t, "execute")
+		output := gpupgrade.run(t, "revert")
+
+		if !strings.Contains(output, "Revert completed successfully") {
+			t.Errorf("expected output %q to contain %q", output, "Revert completed successfully")
+		}
+
+		verifyCoordinator(t, source, sourceVersion)
+		testutils.PathMustNotExist(t, gpupgrade.stateDir)
+	})
+
+	t.Run("finalizes after execute", func(t *testing.T) {
+		source := fakegphome.CreateCluster(t, sourceGPHome, idl.ClusterDestination_source, segments(t, "source", true))
+		gpupgrade := newGpupgrade(t, bin, path, source, targetGPHome)
+
+		gpupgrade.run(t, "initialize")
+		gpupgrade.run(t, "execute")
+		output := gpupgrade.run(t, "finalize")
+
+		if !strings.Contains(output, "Finalize completed successfully") {
+			t.Errorf("expected output %q to contain %q", output, "Finalize completed successfully")
+		}
+
+		verifyCoordinator(t, source, targetVersion)
+		testutils.PathMustNotExist(t, gpupgrade.stateDir)
+	})
+}
+
+// gpupgrade runs the gpupgrade CLI with its own state directory and ports.
+type gpupgrade struct {
+	path     string
+	env      []string
+	stateDir string
+	flags    []string
+}
+
+func newGpupgrade(t *testing.T, bin string, path string, source *greenplum.Cluster, targetGPHome string) *gpupgrade {
+	t.Helper()
+
+	stateDir := testutils.GetTempDir(t, "state")
+	t.Cleanup(func() { testutils.MustRemoveAll(t, stateDir) })
+
+	hubPort := testutils.MustGetPort(t)
+	agentPort := testutils.MustGetPort(t)
+
+	g := &gpupgrade{
+		path:     filepath.Join(bin, "gpupgrade"),
+		env:      append(os.Environ(), "PATH="+path, "GPUPGRADE_HOME="+stateDir),
+		stateDir: stateDir,
+		flags: []string{
+			"--source-gphome", source.GPHome,
+			"--target-gphome", targetGPHome,
+			"--source-master-port", strconv.Itoa(source.CoordinatorPort()),
+			"--temp-port-range", tempPortRange(t, source, hubPort, agentPort),
+			"--hub-port", strconv.Itoa(hubPort),
+			"--agent-port", strconv.Itoa(agentPort),
+			"--mode", idl.Mode_copy.String(),
+			"--skip-disk-space-check",
+		},
+	}
+
+	// Stop the hub and agents should a step fail.
+	t.Cleanup(func() {
+		cmd := g.command("kill-services")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Logf("killing services: %+v: %s", err, output)
+		}
+	})
+
+	return g
+}
+
+// tempPortRange returns a free range of ports for the intermediate cluster
+// that does not overlap the source cluster or the other reserved ports.
+func tempPortRange(t *testing.T, source *greenplum.Cluster, reserved ...int) string {
+	t.Helper()
+
+	for _, seg := range source.Primaries {
+		reserved = append(reserved, seg.Port)
+	}
+
+	for _, seg := range source.Mirrors {
+		reserved = append(reserved, seg.Port)
+	}
+
+	const size = 10
+	for {
+		start := testutils.MustGetPort(t)
+
+		overlaps := false
+		for _, port := range reserved {
+			overlaps = overlaps || (port >= start && port <= start+size)
+		}
+
+		if !overlaps {
+			return fmt.Sprintf("%d-%d", start, start+size)
+		}
+	}
+}
+
+func (g *gpupgrade) command(args ...string) *exec.Cmd {
+	cmd := exec.Command(g.path, args...)
+	cmd.Env = g.env
+	return cmd
+}
+
+func (g *gpupgrade) run(t *testing.T, command string) string {
+	t.Helper()
+
+	args := []string{command, "--non-interactive", "--verbose"}
+	if command == idl.Step_initialize.String() {
+		args = append(args, g.flags...)
+	}
+
+	output, err := g.command(args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("gpupgrade %s: %+v: %s", command, err, output)
+	}
+
+	return string(output)
+}
+
+// verifyCoordinator checks the cluster at the coordinator data directory and
+// port of source is running the expected version.
+func verifyCoordinator(t *testing.T, source *greenplum.Cluster, version semver.Version) {
+	t.Helper()
+
+	catalog, err := fakegphome.ReadCatalog(source.CoordinatorDataDir())
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	if catalog.Version != version.String() {
+		t.Errorf("got version %s want %s", catalog.Version, version)
+	}
+
+	running, err := source.IsCoordinatorRunning(step.DevNullStream)
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	if !running {
+		t.Error("expected coordinator to be running")
+	}
+}
+
+// segments returns a coordinator and two primaries, plus their mirrors and a
+// standby when mirrored, in a temporary directory on free ports.
+func segments(t *testing.T, name string, mirrored bool) greenplum.SegConfigs {
+	t.Helper()
+
+	dir := testutils.GetTempDir(t, name)
+	t.Cleanup(func() { testutils.MustRemoveAll(t, dir) })
+
+	segs := greenplum.SegConfigs{
+		{DbID: 1, ContentID: -1, Role: greenplum.PrimaryRole},
+		{DbID: 2, ContentID: 0, Role: greenplum.PrimaryRole},
+		{DbID: 3, ContentID: 1, Role: greenplum.PrimaryRole},
+	}
+
+	if mirrored {
+		segs = append(segs,
+			greenplum.SegConfig{DbID: 4, ContentID: 0, Role: greenplum.MirrorRole},
+			greenplum.SegConfig{DbID: 5, ContentID: 1, Role: greenplum.MirrorRole},
+			greenplum.SegConfig{DbID: 6, ContentID: -1, Role: greenplum.MirrorRole},
+		)
+	}
+
+	for i := range segs {
+		segs[i].Hostname = "localhost"
+		segs[i].Address = "localhost"
+		segs[i].Port = testutils.MustGetPort(t)
+		segs[i].DataDir = filepath.Join(dir, segs[i].Role, "demoDataDir"+strconv.Itoa(segs[i].ContentID))
+	}
+
+	return segs
+}
+
+func mustOpen(t *testing.T, cluster *greenplum.Cluster) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("pgx", cluster.Connection())
+	if err != nil {
+		t.Fatalf("unexpected error %#v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func pgOptions(source *greenplum.Cluster, intermediate *greenplum.Cluster, action idl.PgOptions_Action) *idl.PgOptions {
+	return &idl.PgOptions{
+		Action:             action,
+		Role:               greenplum.PrimaryRole,
+		ContentID:          -1,
+		PgUpgradeMode:      idl.PgOptions_dispatcher,
+		Mode:               idl.Mode_copy,
+		TargetVersion:      intermediate.Version.String(),
+		OldBinDir:          filepath.Join(source.GPHome, "bin"),
+		OldDataDir:         source.CoordinatorDataDir(),
+		OldPort:            strconv.Itoa(source.CoordinatorPort()),
+		OldDBID:            "1",
+		NewBinDir:          filepath.Join(intermediate.GPHome, "bin"),
+		NewDataDir:         intermediate.CoordinatorDataDir(),
+		NewPort:            strconv.Itoa(intermediate.CoordinatorPort()),
+		NewDBID:            "1",
+		PgUpgradeJobs:      "1",
+		PgUpgradeTimestamp: "20231019T000000",
+	}
+}
+
+func mustCreateCluster(t *testing.T, segments greenplum.SegConfigs) *greenplum.Cluster {
+	t.Helper()
+
+	cluster, err := greenplum.NewCluster(segments)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	return &cluster
+}
@@ -0,0 +1,384 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package fakegphome
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/chunkreader/v2"
+	"github.com/jackc/pgproto3/v2"
+	"golang.org/x/xerrors"
+)
+
+// serve answers connections on listener until it is closed. Each connection
+// speaks the frontend/backend protocol with trust authentication.
+func serve(listener net.Listener, db *database) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer conn.Close()
+
+			if err := newSession(conn, db).run(); err != nil && !errors.Is(err, io.EOF) {
+				log.Printf("fake postgres session: %v", err)
+			}
+		}()
+	}
+}
+
+type prepared struct {
+	stmt statement
+}
+
+type portal struct {
+	stmt    statement
+	params  []string
+	formats []int16
+}
+
+type session struct {
+	conn       net.Conn
+	backend    *pgproto3.Backend
+	db         *database
+	txStatus   byte
+	statements map[string]prepared
+	portals    map[string]portal
+
+	// failed discards extended query messages until the next Sync after an
+	// error as postgres does.
+	failed bool
+}
+
+func newSession(conn net.Conn, db *database) *session {
+	return &session{
+		conn:       conn,
+		backend:    pgproto3.NewBackend(chunkreader.New(conn), conn),
+		db:         db,
+		txStatus:   'I',
+		statements: make(map[string]prepared),
+		portals:    make(map[string]portal),
+	}
+}
+
+func (s *session) run() error {
+	if err := s.startup(); err != nil {
+		return err
+	}
+
+	for {
+		msg, err := s.backend.Receive()
+		if err != nil {
+			return err
+		}
+
+		if s.failed {
+			if _, ok := msg.(*pgproto3.Sync); !ok {
+				continue
+			}
+		}
+
+		switch msg := msg.(type) {
+		case *pgproto3.Query:
+			err = s.simpleQuery(msg.String)
+		case *pgproto3.Parse:
+			err = s.parse(msg)
+		case *pgproto3.Bind:
+			err = s.bind(msg)
+		case *pgproto3.Describe:
+			err = s.describe(msg)
+		case *pgproto3.Execute:
+			err = s.execute(msg)
+		case *pgproto3.Close:
+			if msg.ObjectType == 'S' {
+				delete(s.statements, msg.Name)
+			} else {
+				delete(s.portals, msg.Name)
+			}
+			err = s.backend.Send(&pgproto3.CloseComplete{})
+		case *pgproto3.Sync:
+			s.failed = false
+			err = s.backend.Send(&pgproto3.ReadyForQuery{TxStatus: s.txStatus})
+		case *pgproto3.Flush:
+		case *pgproto3.Terminate:
+			return nil
+		default:
+			err = s.sendError(xerrors.Errorf("fake postgres does not support %T", msg))
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *session) startup() error {
+	for {
+		msg, err := s.backend.ReceiveStartupMessage()
+		if err != nil {
+			return err
+		}
+
+		switch msg.(type) {
+		case *pgproto3.SSLRequest, *pgproto3.GSSEncRequest:
+			if _, err := s.conn.Write([]byte("N")); err != nil {
+				return err
+			}
+			continue
+		case *pgproto3.CancelRequest:
+			return nil
+		}
+
+		break
+	}
+
+	catalog, err := ReadCatalog(s.db.dataDir)
+	if err != nil {
+		return err
+	}
+
+	messages := []pgproto3.BackendMessage{
+		&pgproto3.AuthenticationOk{},
+		&pgproto3.ParameterStatus{Name: "server_version", Value: catalog.Settings["server_version"]},
+		&pgproto3.ParameterStatus{Name: "server_encoding", Value: catalog.Settings["server_encoding"]},
+		&pgproto3.ParameterStatus{Name: "client_encoding", Value: "UTF8"},
+		&pgproto3.ParameterStatus{Name: "standard_conforming_strings", Value: "on"},
+		&pgproto3.ParameterStatus{Name: "integer_datetimes", Value: "on"},
+		&pgproto3.ParameterStatus{Name: "DateStyle", Value: "ISO, MDY"},
+		&pgproto3.BackendKeyData{ProcessID: uint32(s.db.port), SecretKey: 0},
+		&pgproto3.ReadyForQuery{TxStatus: s.txStatus},
+	}
+
+	for _, msg := range messages {
+		if err := s.backend.Send(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *session) simpleQuery(query string) error {
+	for _, q := range splitTopLevel(query, ";") {
+		if q == "" {
+			continue
+		}
+
+		if err := s.runQuery(q); err != nil {
+			if err := s.sendError(err); err != nil {
+				return err
+			}
+			s.failed = false // only extended queries skip messages after an error
+			break
+		}
+	}
+
+	if normalize(query) == "" {
+		if err := s.backend.Send(&pgproto3.EmptyQueryResponse{}); err != nil {
+			return err
+		}
+	}
+
+	return s.backend.Send(&pgproto3.ReadyForQuery{TxStatus: s.txStatus})
+}
+
+// runQuery runs a query of a simple query message. Errors from the query are
+// returned to be sent to the client, while errors writing to the connection
+// are not distinguished since the session ends either way.
+func (s *session) runQuery(query string) error {
+	catalog, err := ReadCatalog(s.db.dataDir)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := parse(query, catalog)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.execute(s.db, nil)
+	if err != nil {
+		return err
+	}
+
+	if stmt.columns != nil {
+		if err := s.backend.Send(rowDescription(stmt.columns, nil)); err != nil {
+			return err
+		}
+	}
+
+	return s.sendResult(res)
+}
+
+func (s *session) parse(msg *pgproto3.Parse) error {
+	catalog, err := ReadCatalog(s.db.dataDir)
+	if err != nil {
+		return s.sendError(err)
+	}
+
+	stmt, err := parse(msg.Query, catalog)
+	if err != nil {
+		return s.sendError(err)
+	}
+
+	s.statements[msg.Name] = prepared{stmt: stmt}
+	return s.backend.Send(&pgproto3.ParseComplete{})
+}
+
+func (s *session) bind(msg *pgproto3.Bind) error {
+	p, ok := s.statements[msg.PreparedStatement]
+	if !ok {
+		return s.sendError(xerrors.Errorf("prepared statement %q does not exist", msg.PreparedStatement))
+	}
+
+	// Copy the parameters since the message is reused by the next Receive.
+	var params []string
+	for i, param := range msg.Parameters {
+		format := int16(0)
+		switch len(msg.ParameterFormatCodes) {
+		case 0:
+		case 1:
+			format = msg.ParameterFormatCodes[0]
+		default:
+			format = msg.ParameterFormatCodes[i]
+		}
+
+		params = append(params, decodeParam(param, format, p.stmt.params, i))
+	}
+
+	s.portals[msg.DestinationPortal] = portal{
+		stmt:    p.stmt,
+		params:  params,
+		formats: append([]int16(nil), msg.ResultFormatCodes...),
+	}
+
+	return s.backend.Send(&pgproto3.BindComplete{})
+}
+
+func decodeParam(param []byte, format int16, oids []uint32, i int) string {
+	if param == nil || format == 0 {
+		return string(param)
+	}
+
+	if i < len(oids) && oids[i] == int4OID && len(param) == 4 {
+		return strconv.Itoa(int(int32(binary.BigEndian.Uint32(param))))
+	}
+
+	return string(param)
+}
+
+func (s *session) describe(msg *pgproto3.Describe) error {
+	if msg.ObjectType == 'S' {
+		p, ok := s.statements[msg.Name]
+		if !ok {
+			return s.sendError(xerrors.Errorf("prepared statement %q does not exist", msg.Name))
+		}
+
+		if err := s.backend.Send(&pgproto3.ParameterDescription{ParameterOIDs: p.stmt.params}); err != nil {
+			return err
+		}
+
+		if p.stmt.columns == nil {
+			return s.backend.Send(&pgproto3.NoData{})
+		}
+
+		return s.backend.Send(rowDescription(p.stmt.columns, nil))
+	}
+
+	p, ok := s.portals[msg.Name]
+	if !ok {
+		return s.sendError(xerrors.Errorf("portal %q does not exist", msg.Name))
+	}
+
+	if p.stmt.columns == nil {
+		return s.backend.Send(&pgproto3.NoData{})
+	}
+
+	return s.backend.Send(rowDescription(p.stmt.columns, p.formats))
+}
+
+func (s *session) execute(msg *pgproto3.Execute) error {
+	p, ok := s.portals[msg.Portal]
+	if !ok {
+		return s.sendError(xerrors.Errorf("portal %q does not exist", msg.Portal))
+	}
+
+	res, err := p.stmt.execute(s.db, p.params)
+	if err != nil {
+		return s.sendError(err)
+	}
+
+	return s.sendResult(res)
+}
+
+// sendResult sends the rows and command tag of a result. Every value is text
+// which has the same representation in the text and binary formats.
+func (s *session) sendResult(res result) error {
+	for _, row := range res.rows {
+		var values [][]byte
+		for _, value := range row {
+			values = append(values, []byte(value))
+		}
+
+		if err := s.backend.Send(&pgproto3.DataRow{Values: values}); err != nil {
+			return err
+		}
+	}
+
+	switch strings.SplitN(res.tag, " ", 2)[0] {
+	case "BEGIN":
+		s.txStatus = 'T'
+	case "COMMIT", "ROLLBACK":
+		s.txStatus = 'I'
+	}
+
+	return s.backend.Send(&pgproto3.CommandComplete{CommandTag: []byte(res.tag)})
+}
+
+func (s *session) sendError(err error) error {
+	code := "XX000"
+	var sqlErr *sqlError
+	if errors.As(err, &sqlErr) {
+		code = sqlErr.code
+	}
+
+	s.failed = true
+	if s.txStatus == 'T' {
+		s.txStatus = 'E'
+	}
+
+	return s.backend.Send(&pgproto3.ErrorResponse{Severity: "ERROR", Code: code, Message: err.Error()})
+}
+
+func rowDescription(columns []string, formats []int16) *pgproto3.RowDescription {
+	var fields []pgproto3.FieldDescription
+	for i, column := range columns {
+		format := int16(0)
+		switch len(formats) {
+		case 0:
+		case 1:
+			format = formats[0]
+		default:
+			format = formats[i]
+		}
+
+		fields = append(fields, pgproto3.FieldDescription{
+			Name:         []byte(column),
+			DataTypeOID:  textOID,
+			DataTypeSize: -1,
+			TypeModifier: -1,
+			Format:       format,
+		})
+	}
+
+	return &pgproto3.RowDescription{Fields: fields}
+}
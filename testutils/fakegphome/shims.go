@@ -0,0 +1,301 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package fakegphome
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/testutils"
+)
+
+// shims replace the utilities that gpupgrade uses to reach other hosts. Every
+// segment of a fake cluster is on this machine so they act locally.
+var shims = map[string]utility{
+	"ssh":   ssh,
+	"rsync": rsync,
+}
+
+// Shims builds a directory with ssh and rsync replacements that run locally.
+// Put it first in PATH such that the hub and agents run without sshd or
+// rsync. It is kept outside of the fake GPHOME since gpupgrade refuses to
+// run with a GPHOME in PATH.
+func Shims(t *testing.T) string {
+	t.Helper()
+
+	dir := testutils.GetTempDir(t, "fake_shims")
+	t.Cleanup(func() { testutils.MustRemoveAll(t, dir) })
+
+	buildFakegp(t, dir)
+
+	for name := range shims {
+		if err := os.Symlink(fakegp, filepath.Join(dir, name)); err != nil {
+			t.Fatalf("linking %s: %+v", name, err)
+		}
+	}
+
+	return dir
+}
+
+// sshOptionsWithValue are the ssh options that take an argument.
+const sshOptionsWithValue = "BbcDEeFIiJLlmOopQRSWw"
+
+// ssh runs the command locally in a shell regardless of the host. Options are
+// ignored.
+func ssh(_ string, args []string, stdout io.Writer) error {
+	host := ""
+	var command []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "-V" {
+			_, err := fmt.Fprintln(stdout, "OpenSSH_fake, gpupgrade fake ssh")
+			return err
+		}
+
+		if host == "" && strings.HasPrefix(arg, "-") {
+			if len(arg) == 2 && strings.ContainsRune(sshOptionsWithValue, rune(arg[1])) {
+				i++
+			}
+			continue
+		}
+
+		if host == "" {
+			host = arg
+			continue
+		}
+
+		command = args[i:]
+		break
+	}
+
+	if host == "" || len(command) == 0 {
+		return errors.New("usage: ssh [options] host command")
+	}
+
+	cmd := exec.Command("sh", "-c", strings.Join(command, " "))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+type rsyncOptions struct {
+	delete   bool
+	excludes []string
+}
+
+// rsync copies the sources to the destination like rsync --archive. A source
+// ending in a slash copies its contents rather than the directory itself.
+// Hosts are stripped from the paths. Of the other options only --delete and
+// --exclude are honored.
+func rsync(_ string, args []string, stdout io.Writer) error {
+	var opts rsyncOptions
+	var paths []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--version":
+			_, err := fmt.Fprintln(stdout, "rsync  version 3.2.7  protocol version 31 (gpupgrade fake rsync)")
+			return err
+		case arg == "--delete":
+			opts.delete = true
+		case arg == "--exclude":
+			i++
+			if i < len(args) {
+				opts.excludes = append(opts.excludes, args[i])
+			}
+		case strings.HasPrefix(arg, "--exclude="):
+			opts.excludes = append(opts.excludes, strings.TrimPrefix(arg, "--exclude="))
+		case strings.HasPrefix(arg, "-"):
+			continue
+		default:
+			paths = append(paths, stripHost(arg))
+		}
+	}
+
+	if len(paths) < 2 {
+		return errors.New("usage: rsync [options] source... destination")
+	}
+
+	sources, dest := paths[:len(paths)-1], paths[len(paths)-1]
+	for _, source := range sources {
+		if err := opts.sync(source, dest, len(sources) == 1); err != nil {
+			return xerrors.Errorf("rsync %q to %q: %w", source, dest, err)
+		}
+	}
+
+	return nil
+}
+
+// stripHost removes the host of a host:path or [address]:path argument.
+func stripHost(arg string) string {
+	if strings.HasPrefix(arg, "[") {
+		if i := strings.Index(arg, "]:"); i > 0 {
+			return arg[i+2:]
+		}
+	}
+
+	if i := strings.Index(arg, ":"); i > 0 && !strings.Contains(arg[:i], "/") {
+		return arg[i+1:]
+	}
+
+	return arg
+}
+
+func (o rsyncOptions) sync(source string, dest string, single bool) error {
+	info, err := os.Lstat(source)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() && strings.HasSuffix(source, "/") {
+		return o.syncDir(source, dest, "")
+	}
+
+	// Like rsync a single file is copied to the destination path unless it
+	// is an existing directory.
+	if !info.IsDir() && single {
+		if destInfo, err := os.Stat(dest); err != nil || !destInfo.IsDir() {
+			return copyEntry(source, dest, info)
+		}
+	}
+
+	if err := os.MkdirAll(dest, 0700); err != nil {
+		return err
+	}
+
+	name := filepath.Base(source)
+	if info.IsDir() {
+		return o.syncDir(source, filepath.Join(dest, name), name)
+	}
+
+	return copyEntry(source, filepath.Join(dest, name), info)
+}
+
+// syncDir copies the entries of source to dest. rel is the path of source
+// relative to the root of the transfer that exclude patterns are matched
+// against.
+func (o rsyncOptions) syncDir(source string, dest string, rel string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dest, info.Mode().Perm()|0700); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return err
+	}
+
+	copied := make(map[string]bool)
+	for _, entry := range entries {
+		entryRel := path.Join(rel, entry.Name())
+		if o.excluded(entryRel) {
+			continue
+		}
+
+		copied[entry.Name()] = true
+		src := filepath.Join(source, entry.Name())
+		dst := filepath.Join(dest, entry.Name())
+
+		if entry.IsDir() {
+			if err := o.syncDir(src, dst, entryRel); err != nil {
+				return err
+			}
+			continue
+		}
+
+		entryInfo, err := os.Lstat(src)
+		if err != nil {
+			return err
+		}
+
+		if err := copyEntry(src, dst, entryInfo); err != nil {
+			return err
+		}
+	}
+
+	if o.delete {
+		existing, err := os.ReadDir(dest)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range existing {
+			if copied[entry.Name()] || o.excluded(path.Join(rel, entry.Name())) {
+				continue
+			}
+
+			if err := os.RemoveAll(filepath.Join(dest, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return os.Chmod(dest, info.Mode().Perm())
+}
+
+// excluded matches rel like rsync. Patterns without a slash match the last
+// component, anchored patterns match from the root of the transfer, and the
+// rest match the trailing components.
+func (o rsyncOptions) excluded(rel string) bool {
+	for _, pattern := range o.excludes {
+		pattern = strings.TrimSuffix(pattern, "/")
+		anchored := strings.HasPrefix(pattern, "/")
+		pattern = strings.TrimPrefix(pattern, "/")
+
+		components := strings.Split(rel, "/")
+		count := strings.Count(pattern, "/") + 1
+		if count > len(components) || (anchored && count != len(components)) {
+			continue
+		}
+
+		if matched, _ := path.Match(pattern, strings.Join(components[len(components)-count:], "/")); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// copyEntry copies a file or symlink preserving its mode and modification
+// time.
+func copyEntry(source string, dest string, info os.FileInfo) error {
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(source)
+		if err != nil {
+			return err
+		}
+
+		return os.Symlink(target, dest)
+	}
+
+	contents, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(dest, contents, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	return os.Chtimes(dest, time.Now(), info.ModTime())
+}
@@ -0,0 +1,652 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package fakegphome
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// The fake postgres understands just enough SQL to answer the queries of
+// gpupgrade. Queries select columns, counts, settings, and function calls
+// from a handful of tables derived from the catalog, filtered by conditions
+// joined with AND. gp_segment_configuration can also be updated, deleted
+// from, and inserted into. Tables it does not know are empty.
+
+const (
+	textOID = 25
+	int4OID = 23
+)
+
+var intColumns = map[string]bool{"dbid": true, "content": true, "port": true}
+
+// database answers queries from the catalog of a data directory.
+type database struct {
+	dataDir string
+	port    int
+}
+
+type result struct {
+	rows [][]string
+	tag  string
+}
+
+// statement is a parsed query. Its columns and parameters are known before it
+// is executed so that prepared statements can be described.
+type statement struct {
+	columns []string
+	params  []uint32
+	execute func(db *database, params []string) (result, error)
+}
+
+var (
+	selectPattern = regexp.MustCompile(`(?is)^SELECT\s+(.+?)(?:\s+FROM\s+(.+?))?(?:\s+WHERE\s+(.+?))?(?:\s+ORDER\s+BY\s+.+?)?$`)
+	updatePattern = regexp.MustCompile(`(?is)^UPDATE\s+(\w+)\s+SET\s+(.+?)(?:\s+WHERE\s+(.+))?$`)
+	deletePattern = regexp.MustCompile(`(?is)^DELETE\s+FROM\s+(\w+)(?:\s+WHERE\s+(.+))?$`)
+	insertPattern = regexp.MustCompile(`(?is)^INSERT\s+INTO\s+(\w+)\s*\((.+?)\)\s*VALUES\s*\((.+)\)$`)
+	paramPattern  = regexp.MustCompile(`\$(\d+)`)
+	comparedParam = regexp.MustCompile(`(?i)([\w.]+)\s*(?:=|<>|!=|>=|<=|>|<)\s*\$(\d+)`)
+	aliasPattern  = regexp.MustCompile(`(?is)^(.+?)\s+(?:AS\s+)?(\w+)$`)
+	callPattern   = regexp.MustCompile(`(?is)^(\w+)\s*\((.*)\)$`)
+	operator      = regexp.MustCompile(`^(.+?)\s*(<>|!=|>=|<=|=|>|<)\s*(.+)$`)
+	identifier    = regexp.MustCompile(`^[A-Za-z_][\w.]*$`)
+)
+
+func normalize(query string) string {
+	query = strings.TrimSpace(query)
+	query = strings.TrimRight(query, "; \t\n")
+	return strings.Join(strings.Fields(query), " ")
+}
+
+func parse(query string, catalog Catalog) (statement, error) {
+	query = normalize(query)
+
+	for _, response := range catalog.Responses {
+		pattern, err := regexp.Compile(response.Pattern)
+		if err != nil {
+			return statement{}, xerrors.Errorf("invalid response pattern %q: %w", response.Pattern, err)
+		}
+
+		if pattern.MatchString(query) {
+			response := response
+			return statement{
+				columns: response.Columns,
+				params:  paramOIDs(query, nil),
+				execute: func(*database, []string) (result, error) {
+					return result{rows: response.Rows, tag: fmt.Sprintf("SELECT %d", len(response.Rows))}, nil
+				},
+			}, nil
+		}
+	}
+
+	if matches := selectPattern.FindStringSubmatch(query); matches != nil {
+		return parseSelect(query, matches[1], matches[2], matches[3])
+	}
+
+	if matches := updatePattern.FindStringSubmatch(query); matches != nil {
+		return parseUpdate(query, matches[1], matches[2], matches[3])
+	}
+
+	if matches := deletePattern.FindStringSubmatch(query); matches != nil {
+		return parseDelete(query, matches[1], matches[2])
+	}
+
+	if matches := insertPattern.FindStringSubmatch(query); matches != nil {
+		return parseInsert(query, matches[1], matches[2], matches[3])
+	}
+
+	// Commands such as BEGIN, COMMIT, SET, and CHECKPOINT succeed without
+	// doing anything.
+	tag := strings.ToUpper(strings.SplitN(query, " ", 2)[0])
+	switch tag {
+	case "START":
+		tag = "BEGIN"
+	case "END":
+		tag = "COMMIT"
+	case "ABORT":
+		tag = "ROLLBACK"
+	}
+
+	return statement{
+		params: paramOIDs(query, nil),
+		execute: func(*database, []string) (result, error) {
+			return result{tag: tag}, nil
+		},
+	}, nil
+}
+
+// paramOIDs types parameters compared with or inserted into integer columns as
+// int4 and the rest as text.
+func paramOIDs(query string, inserted map[int]string) []uint32 {
+	count := 0
+	for _, match := range paramPattern.FindAllStringSubmatch(query, -1) {
+		n, _ := strconv.Atoi(match[1])
+		if n > count {
+			count = n
+		}
+	}
+
+	oids := make([]uint32, count)
+	for i := range oids {
+		oids[i] = textOID
+	}
+
+	for _, match := range comparedParam.FindAllStringSubmatch(query, -1) {
+		n, _ := strconv.Atoi(match[2])
+		if intColumns[column(match[1])] {
+			oids[n-1] = int4OID
+		}
+	}
+
+	for n, col := range inserted {
+		if intColumns[col] {
+			oids[n-1] = int4OID
+		}
+	}
+
+	return oids
+}
+
+// column strips any table qualifier such as "s.dbid".
+func column(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return strings.ToLower(name)
+}
+
+type table struct {
+	name     string
+	writable bool
+	rows     []map[string]string
+}
+
+func (db *database) table(catalog Catalog, from string) (table, error) {
+	from = strings.TrimSpace(from)
+
+	// gp_dist_random('t') reads t from every segment which for the fake is
+	// the same as reading it once.
+	if matches := callPattern.FindStringSubmatch(from); matches != nil && strings.EqualFold(matches[1], "gp_dist_random") {
+		from = strings.Trim(strings.TrimSpace(matches[2]), "'")
+	}
+
+	name := strings.ToLower(strings.Fields(from)[0])
+	switch name {
+	case "gp_segment_configuration":
+		var rows []map[string]string
+		for _, seg := range catalog.Segments {
+			rows = append(rows, seg.row())
+		}
+		return table{name: name, writable: true, rows: rows}, nil
+
+	case "pg_stat_replication":
+		var rows []map[string]string
+		if _, ok := catalog.Standby(); ok {
+			rows = append(rows, map[string]string{
+				"state": "streaming", "sent_location": "0/0", "flush_location": "0/0", "sent_lsn": "0/0", "flush_lsn": "0/0",
+			})
+		}
+		return table{name: name, rows: rows}, nil
+
+	case "pg_settings":
+		var rows []map[string]string
+		for setting, value := range db.settings(catalog) {
+			rows = append(rows, map[string]string{"name": setting, "setting": value})
+		}
+		return table{name: name, rows: rows}, nil
+
+	case "gp_id":
+		return table{name: name, rows: []map[string]string{{"gpname": "Greenplum", "numsegments": "0", "dbid": "1", "content": "-1"}}}, nil
+	}
+
+	return table{name: name}, nil
+}
+
+// defaultSettings are reported for the settings gpupgrade queries unless the
+// catalog overrides them.
+var defaultSettings = map[string]string{
+	"listen_addresses":          "*",
+	"max_prepared_transactions": "250",
+	"gp_vmem_protect_limit":     "8192",
+	"gp_resource_manager":       "queue",
+}
+
+func (db *database) settings(catalog Catalog) map[string]string {
+	settings := make(map[string]string)
+	for name, value := range defaultSettings {
+		settings[name] = value
+	}
+	for name, value := range catalog.Settings {
+		settings[name] = value
+	}
+	settings["port"] = strconv.Itoa(db.port)
+
+	return settings
+}
+
+type expression struct {
+	name  string
+	expr  string
+	count bool
+}
+
+func parseSelect(query, list, from, where string) (statement, error) {
+	var exprs []expression
+	for _, item := range splitTopLevel(list, ",") {
+		expr := expression{expr: item}
+
+		if matches := aliasPattern.FindStringSubmatch(item); matches != nil && !strings.HasSuffix(item, ")") {
+			expr.expr, expr.name = matches[1], matches[2]
+		}
+
+		if matches := callPattern.FindStringSubmatch(expr.expr); matches != nil {
+			expr.count = strings.EqualFold(matches[1], "count")
+			if expr.name == "" {
+				expr.name = strings.ToLower(matches[1])
+			}
+		}
+
+		if expr.name == "" {
+			expr.name = column(expr.expr)
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	var columns []string
+	for _, expr := range exprs {
+		columns = append(columns, expr.name)
+	}
+
+	execute := func(db *database, params []string) (result, error) {
+		catalog, err := ReadCatalog(db.dataDir)
+		if err != nil {
+			return result{}, err
+		}
+
+		// Without a table the expressions are evaluated once.
+		rows := []map[string]string{{}}
+		if from != "" {
+			t, err := db.table(catalog, from)
+			if err != nil {
+				return result{}, err
+			}
+
+			rows, err = filter(db, catalog, t.rows, where, params)
+			if err != nil {
+				return result{}, err
+			}
+		}
+
+		var values [][]string
+		if len(exprs) > 0 && exprs[0].count {
+			values = append(values, []string{strconv.Itoa(len(rows))})
+		} else {
+			for _, row := range rows {
+				var value []string
+				for _, expr := range exprs {
+					if expr.expr == "*" {
+						return result{}, xerrors.New("fake postgres does not support SELECT *")
+					}
+
+					v, err := evaluate(db, catalog, row, expr.expr, params)
+					if err != nil {
+						return result{}, err
+					}
+					value = append(value, v)
+				}
+				values = append(values, value)
+			}
+		}
+
+		return result{rows: values, tag: fmt.Sprintf("SELECT %d", len(values))}, nil
+	}
+
+	return statement{columns: columns, params: paramOIDs(query, nil), execute: execute}, nil
+}
+
+func parseUpdate(query, name, set, where string) (statement, error) {
+	type assignment struct{ column, value string }
+
+	var assignments []assignment
+	for _, item := range splitTopLevel(set, ",") {
+		matches := operator.FindStringSubmatch(item)
+		if matches == nil || matches[2] != "=" {
+			return statement{}, xerrors.Errorf("fake postgres cannot parse assignment %q", item)
+		}
+		assignments = append(assignments, assignment{column(matches[1]), matches[3]})
+	}
+
+	execute := func(db *database, params []string) (result, error) {
+		return db.modify(name, func(catalog Catalog, t table) ([]map[string]string, int, error) {
+			matched, err := filter(db, catalog, t.rows, where, params)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			for _, row := range matched {
+				for _, a := range assignments {
+					value, err := evaluate(db, catalog, row, a.value, params)
+					if err != nil {
+						return nil, 0, err
+					}
+					row[a.column] = value
+				}
+			}
+
+			return t.rows, len(matched), nil
+		}, "UPDATE %d")
+	}
+
+	return statement{params: paramOIDs(query, nil), execute: execute}, nil
+}
+
+func parseDelete(query, name, where string) (statement, error) {
+	execute := func(db *database, params []string) (result, error) {
+		return db.modify(name, func(catalog Catalog, t table) ([]map[string]string, int, error) {
+			matched, err := filter(db, catalog, t.rows, where, params)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			deleted := make(map[string]bool)
+			for _, row := range matched {
+				deleted[row["dbid"]] = true
+			}
+
+			var kept []map[string]string
+			for _, row := range t.rows {
+				if !deleted[row["dbid"]] {
+					kept = append(kept, row)
+				}
+			}
+
+			return kept, len(matched), nil
+		}, "DELETE %d")
+	}
+
+	return statement{params: paramOIDs(query, nil), execute: execute}, nil
+}
+
+func parseInsert(query, name, list, values string) (statement, error) {
+	columns := splitTopLevel(list, ",")
+	exprs := splitTopLevel(values, ",")
+	if len(columns) != len(exprs) {
+		return statement{}, xerrors.Errorf("INSERT has %d columns but %d values", len(columns), len(exprs))
+	}
+
+	inserted := make(map[int]string)
+	for i, expr := range exprs {
+		if matches := paramPattern.FindStringSubmatch(expr); matches != nil && matches[0] == expr {
+			n, _ := strconv.Atoi(matches[1])
+			inserted[n] = column(columns[i])
+		}
+	}
+
+	execute := func(db *database, params []string) (result, error) {
+		return db.modify(name, func(catalog Catalog, t table) ([]map[string]string, int, error) {
+			row := make(map[string]string)
+			for i, expr := range exprs {
+				value, err := evaluate(db, catalog, nil, expr, params)
+				if err != nil {
+					return nil, 0, err
+				}
+				row[column(columns[i])] = value
+			}
+
+			return append(t.rows, row), 1, nil
+		}, "INSERT 0 %d")
+	}
+
+	return statement{params: paramOIDs(query, inserted), execute: execute}, nil
+}
+
+// modify replaces the rows of a writable table with those returned by change.
+// Changes to other tables affect no rows.
+func (db *database) modify(name string, change func(Catalog, table) ([]map[string]string, int, error), tag string) (result, error) {
+	catalog, err := ReadCatalog(db.dataDir)
+	if err != nil {
+		return result{}, err
+	}
+
+	t, err := db.table(catalog, name)
+	if err != nil {
+		return result{}, err
+	}
+
+	if !t.writable {
+		return result{tag: fmt.Sprintf(tag, 0)}, nil
+	}
+
+	rows, affected, err := change(catalog, t)
+	if err != nil {
+		return result{}, err
+	}
+
+	catalog.Segments = nil
+	for _, row := range rows {
+		seg, err := segmentFromRow(row)
+		if err != nil {
+			return result{}, xerrors.Errorf("gp_segment_configuration: %w", err)
+		}
+		catalog.Segments = append(catalog.Segments, seg)
+	}
+
+	if err := WriteCatalog(db.dataDir, catalog); err != nil {
+		return result{}, err
+	}
+
+	return result{tag: fmt.Sprintf(tag, affected)}, nil
+}
+
+func filter(db *database, catalog Catalog, rows []map[string]string, where string, params []string) ([]map[string]string, error) {
+	if where == "" {
+		return rows, nil
+	}
+
+	var conditions []string
+	for _, condition := range splitTopLevel(where, " AND ") {
+		conditions = append(conditions, strings.TrimSpace(condition))
+	}
+
+	var matched []map[string]string
+	for _, row := range rows {
+		ok := true
+		for _, condition := range conditions {
+			match, err := compare(db, catalog, row, condition, params)
+			if err != nil {
+				return nil, err
+			}
+
+			if !match {
+				ok = false
+				break
+			}
+		}
+
+		if ok {
+			matched = append(matched, row)
+		}
+	}
+
+	return matched, nil
+}
+
+func compare(db *database, catalog Catalog, row map[string]string, condition string, params []string) (bool, error) {
+	condition = strings.TrimSpace(condition)
+	for strings.HasPrefix(condition, "(") && strings.HasSuffix(condition, ")") {
+		condition = strings.TrimSpace(condition[1 : len(condition)-1])
+	}
+
+	if len(splitTopLevel(condition, " OR ")) > 1 {
+		return false, xerrors.Errorf("fake postgres does not support OR in %q", condition)
+	}
+
+	upper := strings.ToUpper(condition)
+	switch {
+	case strings.HasSuffix(upper, " IS NOT NULL"):
+		value, err := evaluate(db, catalog, row, condition[:len(condition)-len(" IS NOT NULL")], params)
+		return value != "", err
+	case strings.HasSuffix(upper, " IS NULL"):
+		value, err := evaluate(db, catalog, row, condition[:len(condition)-len(" IS NULL")], params)
+		return value == "", err
+	}
+
+	matches := operator.FindStringSubmatch(condition)
+	if matches == nil {
+		return false, xerrors.Errorf("fake postgres cannot parse condition %q", condition)
+	}
+
+	left, err := evaluate(db, catalog, row, matches[1], params)
+	if err != nil {
+		return false, err
+	}
+
+	right, err := evaluate(db, catalog, row, matches[3], params)
+	if err != nil {
+		return false, err
+	}
+
+	cmp := strings.Compare(left, right)
+	l, lErr := strconv.Atoi(left)
+	r, rErr := strconv.Atoi(right)
+	if lErr == nil && rErr == nil {
+		cmp = l - r
+	}
+
+	switch matches[2] {
+	case "=":
+		return cmp == 0, nil
+	case "<>", "!=":
+		return cmp != 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	case "<":
+		return cmp < 0, nil
+	default:
+		return cmp <= 0, nil
+	}
+}
+
+// evaluate returns the value of a literal, parameter, column of row, or
+// function call. Functions other than current_setting and version return an
+// empty value.
+func evaluate(db *database, catalog Catalog, row map[string]string, expr string, params []string) (string, error) {
+	expr = strings.TrimSpace(expr)
+
+	switch {
+	case strings.HasPrefix(expr, "'") && strings.HasSuffix(expr, "'") && len(expr) >= 2:
+		return strings.ReplaceAll(expr[1:len(expr)-1], "''", "'"), nil
+
+	case strings.HasPrefix(expr, "$"):
+		n, err := strconv.Atoi(expr[1:])
+		if err != nil || n < 1 || n > len(params) {
+			return "", xerrors.Errorf("there is no parameter %s", expr)
+		}
+		return params[n-1], nil
+
+	case strings.HasPrefix(expr, "("):
+		return evaluate(db, catalog, row, strings.TrimSuffix(expr[1:], ")"), params)
+	}
+
+	if _, err := strconv.Atoi(expr); err == nil {
+		return expr, nil
+	}
+
+	if matches := callPattern.FindStringSubmatch(expr); matches != nil {
+		switch strings.ToLower(matches[1]) {
+		case "current_setting":
+			name, err := evaluate(db, catalog, row, matches[2], params)
+			if err != nil {
+				return "", err
+			}
+
+			value, ok := db.settings(catalog)[name]
+			if !ok {
+				return "", &sqlError{code: "42704", message: fmt.Sprintf("unrecognized configuration parameter %q", name)}
+			}
+			return value, nil
+
+		case "version":
+			defaults, err := defaultsFor(catalog.Version)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("PostgreSQL %s (Greenplum Database %s build fake)", defaults.serverVersion, catalog.Version), nil
+
+		case "gp_request_fts_probe_scan":
+			return "t", nil
+		}
+
+		return "", nil
+	}
+
+	if identifier.MatchString(expr) {
+		switch strings.ToLower(expr) {
+		case "true":
+			return "t", nil
+		case "false":
+			return "f", nil
+		case "null":
+			return "", nil
+		}
+
+		value, ok := row[column(expr)]
+		if !ok {
+			return "", &sqlError{code: "42703", message: fmt.Sprintf("column %q does not exist", expr)}
+		}
+		return value, nil
+	}
+
+	return "", xerrors.Errorf("fake postgres cannot evaluate %q", expr)
+}
+
+// splitTopLevel splits s on sep ignoring separators within parentheses or
+// quotes. Separators are matched case insensitively.
+func splitTopLevel(s string, sep string) []string {
+	var parts []string
+	depth := 0
+	quoted := false
+	start := 0
+	upper := strings.ToUpper(s)
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'':
+			quoted = !quoted
+		case '(':
+			if !quoted {
+				depth++
+			}
+		case ')':
+			if !quoted {
+				depth--
+			}
+		}
+
+		if depth == 0 && !quoted && strings.HasPrefix(upper[i:], sep) {
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + len(sep)
+			i += len(sep) - 1
+		}
+	}
+
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+type sqlError struct {
+	code    string
+	message string
+}
+
+func (e *sqlError) Error() string {
+	return e.message
+}
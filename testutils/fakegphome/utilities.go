@@ -0,0 +1,892 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package fakegphome
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/greenplum"
+)
+
+const (
+	pidFile     = "postmaster.pid"
+	logFile     = "fake_postgres.log"
+	versionFile = "fake_version"
+
+	startTimeout = 30 * time.Second
+)
+
+type utility func(gphome string, args []string, stdout io.Writer) error
+
+var utilities = map[string]utility{
+	"postgres":       postgres,
+	"pg_upgrade":     pgUpgrade,
+	"pg_controldata": pgControldata,
+	"gpinitsystem":   gpinitsystem,
+	"gpstart":        gpstart,
+	"gpstop":         gpstop,
+	"gpaddmirrors":   gpaddmirrors,
+	"gpinitstandby":  gpinitstandby,
+	"gprecoverseg":   gprecoverseg,
+	"gpconfig":       gpconfig,
+}
+
+// Utilities returns the names of the utilities that the fake Greenplum
+// provides.
+func Utilities() []string {
+	var names []string
+	for name := range utilities {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Main runs the utility named by the base name of args[0] and returns its exit
+// code. The fake GPHOME is the parent of the directory of the executable such
+// that utilities work with an empty environment as pg_upgrade is run.
+func Main(args []string) int {
+	name := filepath.Base(args[0])
+	run, ok := utilities[name]
+	if !ok {
+		run, ok = shims[name]
+	}
+	if !ok {
+		fmt.Fprintf(os.Stderr, "fake Greenplum has no utility %q\n", name)
+		return 2
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return 1
+	}
+
+	if err := run(filepath.Dir(filepath.Dir(executable)), args[1:], os.Stdout); err != nil {
+		// Exit as the command run by ssh did.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+			return exitErr.ExitCode()
+		}
+
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return 1
+	}
+
+	return 0
+}
+
+func readVersion(gphome string) (string, error) {
+	contents, err := os.ReadFile(filepath.Join(gphome, versionFile))
+	if err != nil {
+		return "", xerrors.Errorf("read fake Greenplum version: %w", err)
+	}
+
+	return strings.TrimSpace(string(contents)), nil
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// postgres prints the version or serves the data directory given by -D on the
+// port given by -p until it is terminated. Other options are ignored.
+func postgres(gphome string, args []string, stdout io.Writer) error {
+	var dataDir, port string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--gp-version":
+			version, err := readVersion(gphome)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintf(stdout, "postgres (Greenplum Database) %s build fake\n", version)
+			return err
+		case "-D":
+			i++
+			if i < len(args) {
+				dataDir = args[i]
+			}
+		case "-p":
+			i++
+			if i < len(args) {
+				port = args[i]
+			}
+		}
+	}
+
+	if dataDir == "" {
+		return errors.New("no data directory was specified with -D")
+	}
+
+	if port == "" {
+		var err error
+		port, err = configuredPort(dataDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	portNum, err := strconv.Atoi(port)
+	if err != nil {
+		return xerrors.Errorf("invalid port %q: %w", port, err)
+	}
+
+	if _, err := ReadCatalog(dataDir); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", port))
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	db := &database{dataDir: dataDir, port: portNum}
+	go serve(listener, db)
+
+	// Serve IPv6 when available as connections can be made to either family.
+	if listener6, err := net.Listen("tcp", net.JoinHostPort("::1", port)); err == nil {
+		defer listener6.Close()
+		go serve(listener6, db)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	// Write the pid file atomically since gpstart may read it concurrently.
+	contents := fmt.Sprintf("%d\n%s\n%d\n%s\n/tmp\nlocalhost\n0\nready\n", os.Getpid(), dataDir, time.Now().Unix(), port)
+	if err := os.WriteFile(filepath.Join(dataDir, pidFile+".tmp"), []byte(contents), 0600); err != nil {
+		return err
+	}
+
+	if err := os.Rename(filepath.Join(dataDir, pidFile+".tmp"), filepath.Join(dataDir, pidFile)); err != nil {
+		return err
+	}
+	defer os.Remove(filepath.Join(dataDir, pidFile))
+
+	<-signals
+	return nil
+}
+
+var portSetting = regexp.MustCompile(`(?m)^\s*port\s*=\s*(\d+)`)
+
+func configuredPort(dataDir string) (string, error) {
+	contents, err := os.ReadFile(filepath.Join(dataDir, "postgresql.conf"))
+	if err != nil {
+		return "", err
+	}
+
+	matches := portSetting.FindSubmatch(contents)
+	if matches == nil {
+		return "", xerrors.Errorf("no port is configured in %q", dataDir)
+	}
+
+	return string(matches[1]), nil
+}
+
+// initDataDir creates the files of a data directory. Only the coordinator
+// catalog has segments.
+func initDataDir(seg Segment, catalog Catalog) error {
+	defaults, err := defaultsFor(catalog.Version)
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(seg.DataDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if len(entries) > 0 {
+		return xerrors.Errorf("data directory %q already exists and is not empty", seg.DataDir)
+	}
+
+	if err := os.MkdirAll(seg.DataDir, 0700); err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		"PG_VERSION":      defaults.pgVersion + "\n",
+		"postgresql.conf": fmt.Sprintf("port=%d\n", seg.Port),
+		"pg_hba.conf":     "local all all trust\nhost all all 127.0.0.1/32 trust\nhost all all ::1/128 trust\n",
+	}
+
+	// Mirrors and the standby replicate from their primary which finalize
+	// points at the upgraded ports.
+	if seg.Role == greenplum.MirrorRole {
+		primary, ok := catalog.Primary(seg.ContentID)
+		if !ok {
+			return xerrors.Errorf("content %d has no primary", seg.ContentID)
+		}
+
+		files[defaults.recoveryConf] = fmt.Sprintf("primary_conninfo = 'user=gpadmin host=%s port=%d sslmode=disable application_name=gp_walreceiver'\n", primary.Hostname, primary.Port)
+	}
+
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(seg.DataDir, name), []byte(contents), 0600); err != nil {
+			return err
+		}
+	}
+
+	if seg.ContentID != -1 || seg.Role != greenplum.PrimaryRole {
+		catalog.Segments = nil
+	}
+
+	return WriteCatalog(seg.DataDir, catalog)
+}
+
+// isRunning returns whether the postmaster.pid of dataDir names a live
+// process. A stale pid file is removed.
+func isRunning(dataDir string) (int, bool, error) {
+	contents, err := os.ReadFile(filepath.Join(dataDir, pidFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	pid, err := strconv.Atoi(strings.SplitN(string(contents), "\n", 2)[0])
+	if err != nil {
+		return 0, false, xerrors.Errorf("invalid %s in %q: %w", pidFile, dataDir, err)
+	}
+
+	if err := syscall.Kill(pid, 0); err != nil {
+		return 0, false, os.Remove(filepath.Join(dataDir, pidFile))
+	}
+
+	return pid, true, nil
+}
+
+func startSegment(gphome string, seg Segment) error {
+	_, running, err := isRunning(seg.DataDir)
+	if err != nil || running {
+		return err
+	}
+
+	log, err := os.OpenFile(filepath.Join(seg.DataDir, logFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+
+	cmd := exec.Command(filepath.Join(gphome, "bin", "postgres"), "-D", seg.DataDir, "-p", strconv.Itoa(seg.Port))
+	cmd.Stdout = log
+	cmd.Stderr = log
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	deadline := time.Now().Add(startTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case err := <-exited:
+			return xerrors.Errorf("postgres for %q exited with %v. See %q.", seg.DataDir, err, filepath.Join(seg.DataDir, logFile))
+		default:
+		}
+
+		_, running, err := isRunning(seg.DataDir)
+		if err != nil {
+			return err
+		}
+
+		if running {
+			conn, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(seg.Port)))
+			if err == nil {
+				return conn.Close()
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return xerrors.Errorf("timed out waiting for postgres on port %d for %q to start", seg.Port, seg.DataDir)
+}
+
+func stopSegment(seg Segment) error {
+	pid, running, err := isRunning(seg.DataDir)
+	if err != nil || !running {
+		return err
+	}
+
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		return err
+	}
+
+	// Wait for the pid file rather than the process which may linger as a
+	// zombie until its parent reaps it.
+	deadline := time.Now().Add(startTimeout)
+	for time.Now().Before(deadline) {
+		_, err := os.Stat(filepath.Join(seg.DataDir, pidFile))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return xerrors.Errorf("timed out waiting for postgres for %q to stop", seg.DataDir)
+}
+
+// coordinatorCatalog returns the catalog of the coordinator data directory
+// given by -d or MASTER_DATA_DIRECTORY.
+func coordinatorCatalog(dataDir string) (string, Catalog, error) {
+	if dataDir == "" {
+		dataDir = os.Getenv("MASTER_DATA_DIRECTORY")
+	}
+
+	if dataDir == "" {
+		dataDir = os.Getenv("COORDINATOR_DATA_DIRECTORY")
+	}
+
+	if dataDir == "" {
+		return "", Catalog{}, errors.New("no coordinator data directory was specified with -d or MASTER_DATA_DIRECTORY")
+	}
+
+	catalog, err := ReadCatalog(dataDir)
+	if err != nil {
+		return "", Catalog{}, err
+	}
+
+	return dataDir, catalog, nil
+}
+
+// localCoordinator returns the catalog and coordinator of the coordinator
+// data directory. Like gpstart and gpstop the coordinator is found from its
+// data directory and configured port rather than the catalog which finalize
+// updates to the locations of the source cluster.
+func localCoordinator(dataDir string) (Catalog, Segment, error) {
+	dataDir, catalog, err := coordinatorCatalog(dataDir)
+	if err != nil {
+		return Catalog{}, Segment{}, err
+	}
+
+	coordinator, err := catalog.Coordinator()
+	if err != nil {
+		return Catalog{}, Segment{}, err
+	}
+
+	port, err := configuredPort(dataDir)
+	if err != nil {
+		return Catalog{}, Segment{}, err
+	}
+
+	coordinator.DataDir = dataDir
+	coordinator.Port, err = strconv.Atoi(port)
+	if err != nil {
+		return Catalog{}, Segment{}, xerrors.Errorf("invalid port %q: %w", port, err)
+	}
+
+	return catalog, coordinator, nil
+}
+
+func gpstart(gphome string, args []string, stdout io.Writer) error {
+	flags := newFlagSet("gpstart")
+	flags.Bool("a", false, "")
+	coordinatorOnly := flags.Bool("m", false, "")
+	dataDir := flags.String("d", "", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	catalog, coordinator, err := localCoordinator(*dataDir)
+	if err != nil {
+		return err
+	}
+
+	if err := startSegment(gphome, coordinator); err != nil {
+		return err
+	}
+
+	if !*coordinatorOnly {
+		for _, seg := range catalog.Segments {
+			if seg.DbID == coordinator.DbID {
+				continue
+			}
+
+			if err := startSegment(gphome, seg); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = fmt.Fprintln(stdout, "Database successfully started")
+	return err
+}
+
+func gpstop(gphome string, args []string, stdout io.Writer) error {
+	flags := newFlagSet("gpstop")
+	flags.Bool("a", false, "")
+	flags.String("M", "", "")
+	reload := flags.Bool("u", false, "")
+	coordinatorOnly := flags.Bool("m", false, "")
+	dataDir := flags.String("d", "", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *reload {
+		return nil
+	}
+
+	catalog, coordinator, err := localCoordinator(*dataDir)
+	if err != nil {
+		return err
+	}
+
+	if !*coordinatorOnly {
+		for _, seg := range catalog.Segments {
+			if seg.DbID == coordinator.DbID {
+				continue
+			}
+
+			if err := stopSegment(seg); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := stopSegment(coordinator); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(stdout, "Database successfully shutdown")
+	return err
+}
+
+// gpinitsystem creates and starts a cluster from the QD_PRIMARY_ARRAY,
+// PRIMARY_ARRAY, and MIRROR_ARRAY entries of the configuration file.
+func gpinitsystem(gphome string, args []string, stdout io.Writer) error {
+	flags := newFlagSet("gpinitsystem")
+	flags.Bool("a", false, "")
+	flags.Bool("ignore-warnings", false, "")
+	configFile := flags.String("I", "", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	file, err := os.Open(*configFile)
+	if err != nil {
+		return xerrors.Errorf("open gpinitsystem config: %w", err)
+	}
+	defer file.Close()
+
+	version, err := readVersion(gphome)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := NewCatalog(version)
+	if err != nil {
+		return err
+	}
+
+	role := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "QD_PRIMARY_ARRAY="):
+			seg, err := parseArrayEntry(strings.TrimPrefix(line, "QD_PRIMARY_ARRAY="), greenplum.PrimaryRole)
+			if err != nil {
+				return err
+			}
+			catalog.addSegment(seg)
+		case strings.HasPrefix(line, "declare -a PRIMARY_ARRAY=("):
+			role = greenplum.PrimaryRole
+		case strings.HasPrefix(line, "declare -a MIRROR_ARRAY=("):
+			role = greenplum.MirrorRole
+		case line == ")":
+			role = ""
+		case role != "" && line != "":
+			seg, err := parseArrayEntry(line, role)
+			if err != nil {
+				return err
+			}
+			catalog.addSegment(seg)
+		case strings.HasPrefix(line, "ENCODING="):
+			catalog.Settings["server_encoding"] = strings.TrimPrefix(line, "ENCODING=")
+		case strings.HasPrefix(line, "CHECK_POINT_SEGMENTS="):
+			catalog.Settings["checkpoint_segments"] = strings.TrimPrefix(line, "CHECK_POINT_SEGMENTS=")
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return xerrors.Errorf("read gpinitsystem config: %w", err)
+	}
+
+	coordinator, err := catalog.Coordinator()
+	if err != nil {
+		return xerrors.Errorf("gpinitsystem config %q has no QD_PRIMARY_ARRAY", *configFile)
+	}
+
+	catalog.synchronize()
+
+	for _, seg := range catalog.Segments {
+		if err := initDataDir(seg, catalog); err != nil {
+			return err
+		}
+	}
+
+	if err := gpstart(gphome, []string{"-a", "-d", coordinator.DataDir}, stdout); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(stdout, "Greenplum Database instance successfully created")
+	return err
+}
+
+// parseArrayEntry parses host~address~port~datadir~dbid~content.
+func parseArrayEntry(entry string, role string) (Segment, error) {
+	fields := strings.Split(entry, "~")
+	if len(fields) != 6 {
+		return Segment{}, xerrors.Errorf("invalid gpinitsystem array entry %q", entry)
+	}
+
+	var ints [3]int
+	for i, field := range []string{fields[2], fields[4], fields[5]} {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return Segment{}, xerrors.Errorf("invalid gpinitsystem array entry %q: %w", entry, err)
+		}
+		ints[i] = value
+	}
+
+	return SegmentFromSegConfig(greenplum.SegConfig{
+		Hostname:  fields[0],
+		Address:   fields[1],
+		Port:      ints[0],
+		DataDir:   fields[3],
+		DbID:      ints[1],
+		ContentID: ints[2],
+		Role:      role,
+	}), nil
+}
+
+func pgControldata(gphome string, args []string, stdout io.Writer) error {
+	if len(args) != 1 {
+		return errors.New("usage: pg_controldata DATADIR")
+	}
+
+	catalog, err := ReadCatalog(args[0])
+	if err != nil {
+		return err
+	}
+
+	defaults, err := defaultsFor(catalog.Version)
+	if err != nil {
+		return err
+	}
+
+	state := "shut down"
+	if _, running, err := isRunning(args[0]); err != nil {
+		return err
+	} else if running {
+		state = "in production"
+	}
+
+	_, err = fmt.Fprintf(stdout, "pg_control version number:            %s\nCatalog version number:               %s\nDatabase cluster state:               %s\n",
+		defaults.controlVersion, catalog.CatalogVersion, state)
+	return err
+}
+
+// pgUpgrade checks that both clusters are stopped and of the expected
+// versions. Upgrading copies the responses simulating user data from the old
+// catalog to the new one.
+func pgUpgrade(gphome string, args []string, stdout io.Writer) error {
+	flags := newFlagSet("pg_upgrade")
+	for _, name := range []string{"old-bindir", "new-bindir", "old-port", "new-port", "mode", "jobs", "output-dir",
+		"old-options", "old-tablespaces-file", "old-gp-dbid", "new-gp-dbid"} {
+		flags.String(name, "", "")
+	}
+	for _, name := range []string{"retain", "progress", "verbose", "skip-checks", "continue-check-on-fatal", "link"} {
+		flags.Bool(name, false, "")
+	}
+	oldDataDir := flags.String("old-datadir", "", "")
+	newDataDir := flags.String("new-datadir", "", "")
+	check := flags.Bool("check", false, "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	version, err := readVersion(gphome)
+	if err != nil {
+		return err
+	}
+
+	oldCatalog, err := ReadCatalog(*oldDataDir)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range []string{*oldDataDir, *newDataDir} {
+		if _, err := os.Stat(filepath.Join(dir, "PG_VERSION")); err != nil {
+			return xerrors.Errorf("%q is not a valid data directory: %w", dir, err)
+		}
+
+		// Like pg_upgrade --check the source cluster may be running.
+		if *check && dir == *oldDataDir {
+			continue
+		}
+
+		if _, running, err := isRunning(dir); err != nil {
+			return err
+		} else if running {
+			return xerrors.Errorf("there seems to be a postmaster servicing the cluster in %q", dir)
+		}
+	}
+
+	if err := os.WriteFile("pg_upgrade_internal.log", []byte(fmt.Sprintf("fake pg_upgrade from %s to %s\n", oldCatalog.Version, version)), 0600); err != nil {
+		return err
+	}
+
+	if *check {
+		_, err := fmt.Fprintln(stdout, "*Clusters are compatible*")
+		return err
+	}
+
+	newCatalog, err := ReadCatalog(*newDataDir)
+	if err != nil {
+		return err
+	}
+
+	newCatalog.Responses = oldCatalog.Responses
+	if err := WriteCatalog(*newDataDir, newCatalog); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(stdout, "Upgrade Complete")
+	return err
+}
+
+// gpaddmirrors adds the mirrors of the content|host|port|datadir lines of the
+// configuration file in synchronized mode.
+func gpaddmirrors(gphome string, args []string, stdout io.Writer) error {
+	flags := newFlagSet("gpaddmirrors")
+	flags.Bool("a", false, "")
+	flags.Bool("hba-hostnames", false, "")
+	configFile := flags.String("i", "", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	contents, err := os.ReadFile(*configFile)
+	if err != nil {
+		return xerrors.Errorf("read gpaddmirrors config: %w", err)
+	}
+
+	dataDir, catalog, err := coordinatorCatalog("")
+	if err != nil {
+		return err
+	}
+
+	var added []Segment
+	for _, line := range strings.Split(strings.TrimSpace(string(contents)), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "|")
+		if len(fields) != 4 {
+			return xerrors.Errorf("invalid gpaddmirrors config line %q", line)
+		}
+
+		content, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return xerrors.Errorf("invalid gpaddmirrors config line %q: %w", line, err)
+		}
+
+		port, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return xerrors.Errorf("invalid gpaddmirrors config line %q: %w", line, err)
+		}
+
+		if _, ok := catalog.Primary(content); !ok {
+			return xerrors.Errorf("content %d has no primary", content)
+		}
+
+		added = append(added, SegmentFromSegConfig(greenplum.SegConfig{
+			ContentID: content,
+			Port:      port,
+			Hostname:  fields[1],
+			DataDir:   fields[3],
+			Role:      greenplum.MirrorRole,
+		}))
+	}
+
+	// Number the mirrors in content order as gpaddmirrors does.
+	sort.Slice(added, func(i, j int) bool {
+		return added[i].ContentID < added[j].ContentID
+	})
+
+	for i := range added {
+		added[i].DbID = catalog.nextDbID()
+		if err := initDataDir(added[i], catalog); err != nil {
+			return err
+		}
+
+		catalog.addSegment(added[i])
+	}
+
+	catalog.synchronize()
+	if err := WriteCatalog(dataDir, catalog); err != nil {
+		return err
+	}
+
+	for _, mirror := range added {
+		if err := startSegment(gphome, mirror); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(stdout, "Added %d mirrors\n", len(added))
+	return err
+}
+
+// gpinitstandby adds the standby given by -s, -S, and -P or removes the
+// existing standby with -r.
+func gpinitstandby(gphome string, args []string, stdout io.Writer) error {
+	flags := newFlagSet("gpinitstandby")
+	flags.Bool("a", false, "")
+	flags.Bool("hba-hostnames", false, "")
+	remove := flags.Bool("r", false, "")
+	host := flags.String("s", "", "")
+	standbyDataDir := flags.String("S", "", "")
+	port := flags.Int("P", 0, "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	dataDir, catalog, err := coordinatorCatalog("")
+	if err != nil {
+		return err
+	}
+
+	if *remove {
+		removed := catalog.removeSegments(func(seg Segment) bool {
+			return seg.ContentID == -1 && seg.Role == greenplum.MirrorRole
+		})
+
+		for _, standby := range removed {
+			if err := stopSegment(standby); err != nil {
+				return err
+			}
+		}
+
+		return WriteCatalog(dataDir, catalog)
+	}
+
+	if _, ok := catalog.Standby(); ok {
+		return errors.New("the cluster already has a standby")
+	}
+
+	standby := SegmentFromSegConfig(greenplum.SegConfig{
+		DbID:      catalog.nextDbID(),
+		ContentID: -1,
+		Port:      *port,
+		Hostname:  *host,
+		DataDir:   *standbyDataDir,
+		Role:      greenplum.MirrorRole,
+	})
+
+	if err := initDataDir(standby, catalog); err != nil {
+		return err
+	}
+
+	catalog.addSegment(standby)
+	if err := WriteCatalog(dataDir, catalog); err != nil {
+		return err
+	}
+
+	if err := startSegment(gphome, standby); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(stdout, "Successfully created standby master")
+	return err
+}
+
+// gprecoverseg marks every segment up and synchronized in its preferred role
+// and starts those that are not running.
+func gprecoverseg(gphome string, args []string, stdout io.Writer) error {
+	flags := newFlagSet("gprecoverseg")
+	flags.Bool("a", false, "")
+	flags.Bool("hba-hostnames", false, "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	dataDir, catalog, err := coordinatorCatalog("")
+	if err != nil {
+		return err
+	}
+
+	for i := range catalog.Segments {
+		seg := &catalog.Segments[i]
+		if seg.ContentID == -1 {
+			continue
+		}
+
+		seg.Role = seg.PreferredRole
+		seg.Status = "u"
+		seg.Mode = "s"
+
+		if err := startSegment(gphome, *seg); err != nil {
+			return err
+		}
+	}
+
+	return WriteCatalog(dataDir, catalog)
+}
+
+// gpconfig sets the coordinator value of the setting given by -c to -v or -m.
+func gpconfig(gphome string, args []string, stdout io.Writer) error {
+	flags := newFlagSet("gpconfig")
+	flags.Bool("masteronly", false, "")
+	flags.Bool("coordinatoronly", false, "")
+	name := flags.String("c", "", "")
+	value := flags.String("v", "", "")
+	coordinatorValue := flags.String("m", "", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *name == "" {
+		return errors.New("no setting was specified with -c")
+	}
+
+	dataDir, catalog, err := coordinatorCatalog("")
+	if err != nil {
+		return err
+	}
+
+	catalog.Settings[*name] = strings.Trim(*value, "'")
+	if *coordinatorValue != "" {
+		catalog.Settings[*name] = strings.Trim(*coordinatorValue, "'")
+	}
+
+	return WriteCatalog(dataDir, catalog)
+}
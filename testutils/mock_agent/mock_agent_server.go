@@ -18,6 +18,7 @@ type MockAgentServer struct {
 	addr       net.Addr
 	grpcServer *grpc.Server
 	numCalls   int
+	version    string
 	mu         sync.Mutex
 
 	UpgradeConvertPrimarySegmentsRequest *idl.UpgradePrimariesRequest
@@ -169,3 +170,42 @@ func (m *MockAgentServer) CreateRecoveryConf(context context.Context, in *idl.Cr
 func (m *MockAgentServer) AddReplicationEntries(context context.Context, in *idl.AddReplicationEntriesRequest) (*idl.AddReplicationEntriesReply, error) {
 	return &idl.AddReplicationEntriesReply{}, nil
 }
+
+func (m *MockAgentServer) GetEnvironment(context context.Context, in *idl.GetEnvironmentRequest) (*idl.GetEnvironmentReply, error) {
+	return &idl.GetEnvironmentReply{}, nil
+}
+
+func (m *MockAgentServer) GetPostgresqlConfs(context context.Context, in *idl.GetPostgresqlConfsRequest) (*idl.GetPostgresqlConfsReply, error) {
+	return &idl.GetPostgresqlConfsReply{}, nil
+}
+
+func (m *MockAgentServer) GetPgHbaConfs(context context.Context, in *idl.GetPgHbaConfsRequest) (*idl.GetPgHbaConfsReply, error) {
+	return &idl.GetPgHbaConfsReply{}, nil
+}
+
+func (m *MockAgentServer) WritePgHbaConfs(context context.Context, in *idl.WritePgHbaConfsRequest) (*idl.WritePgHbaConfsReply, error) {
+	return &idl.WritePgHbaConfsReply{}, nil
+}
+
+func (m *MockAgentServer) BaseBackupMirrors(context context.Context, in *idl.BaseBackupMirrorsRequest) (*idl.BaseBackupMirrorsReply, error) {
+	return &idl.BaseBackupMirrorsReply{}, nil
+}
+
+func (m *MockAgentServer) WatchDiskSpace(in *idl.WatchDiskSpaceRequest, stream idl.Agent_WatchDiskSpaceServer) error {
+	return nil
+}
+
+func (m *MockAgentServer) Handshake(ctx context.Context, in *idl.HandshakeRequest) (*idl.HandshakeReply, error) {
+	m.increaseCalls()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &idl.HandshakeReply{Version: m.version, IdlRevision: idl.Revision()}, nil
+}
+
+// SetVersion sets the version the agent reports when handshaking.
+func (m *MockAgentServer) SetVersion(version string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.version = version
+}
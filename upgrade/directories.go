@@ -93,6 +93,21 @@ func RenameDirectories(source, target string) error {
 	return nil
 }
 
+// MoveDirectory renames the source data directory to target unless a previous
+// run already did so.
+func MoveDirectory(source, target string) error {
+	alreadyRenamed, err := AlreadyRenamed(source, target)
+	if err != nil {
+		return err
+	}
+
+	if alreadyRenamed {
+		return nil
+	}
+
+	return renameDataDirectory(source, target)
+}
+
 func renameDataDirectory(src, dst string) error {
 	if err := VerifyDataDirectory(src); err != nil {
 		return err
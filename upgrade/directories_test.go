@@ -275,6 +275,66 @@ func setup(t *testing.T) (teardown func(), directories []string, requiredPaths [
 	return teardown, directories, requiredPaths
 }
 
+func TestMoveDirectory(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("renames source to target", func(t *testing.T) {
+		source, target, cleanup := testutils.MustCreateDataDirs(t)
+		defer cleanup(t)
+		testutils.MustRemoveAll(t, target)
+
+		err := upgrade.MoveDirectory(source, target)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+
+		testutils.PathMustNotExist(t, source)
+		testutils.PathMustExist(t, target)
+	})
+
+	t.Run("returns early if already moved", func(t *testing.T) {
+		source, target, cleanup := testutils.MustCreateDataDirs(t)
+		defer cleanup(t)
+		testutils.MustRemoveAll(t, source)
+
+		called := false
+		utils.System.Rename = func(old, new string) error {
+			called = true
+			return nil
+		}
+		defer func() {
+			utils.System.Rename = os.Rename
+		}()
+
+		err := upgrade.MoveDirectory(source, target)
+		if err != nil {
+			t.Errorf("unexpected error: %#v", err)
+		}
+
+		if called {
+			t.Errorf("expected rename to not be called")
+		}
+	})
+
+	t.Run("errors when moving a directory that is not like postgres", func(t *testing.T) {
+		source := testutils.GetTempDir(t, "source")
+		defer testutils.MustRemoveAll(t, source)
+
+		err := upgrade.MoveDirectory(source, source+".moved")
+
+		var errs errorlist.Errors
+		if !errors.As(err, &errs) {
+			t.Fatalf("returned %#v want error type %T", err, errs)
+		}
+
+		for _, err := range errs {
+			if !errors.Is(err, upgrade.ErrInvalidDataDirectory) {
+				t.Errorf("returned error %#v want %#v", err, upgrade.ErrInvalidDataDirectory)
+			}
+		}
+	})
+}
+
 func TestDeleteDirectories(t *testing.T) {
 	testlog.SetupTestLogger()
 
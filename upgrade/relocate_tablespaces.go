@@ -0,0 +1,73 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/step"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+// RelocateTablespaces copies each target tablespace directory created by
+// pg_upgrade to its new location, points the tablespace symlink of dataDir at
+// the new location, and then deletes the original target tablespace directory.
+// Since the tablespace location is derived from the symlink there is no
+// catalog to update. The source cluster's tablespaces are left untouched.
+func RelocateTablespaces(ctx context.Context, dataDir string, relocations []*idl.TablespaceRelocation) error {
+	for _, relocation := range relocations {
+		// Create the destination itself rather than only its parent such
+		// that a partially relocated tablespace is still recognized as a
+		// valid tablespace directory when reverting.
+		if err := utils.System.MkdirAll(relocation.GetDestination(), 0700); err != nil {
+			return xerrors.Errorf("create relocated tablespace directory: %w", err)
+		}
+
+		options := []rsync.Option{
+			rsync.WithSources(filepath.Clean(relocation.GetSource()) + string(os.PathSeparator)),
+			rsync.WithDestination(relocation.GetDestination()),
+			rsync.WithOptions("--archive", "--delete"),
+			rsync.WithContext(ctx),
+		}
+
+		if err := rsync.Rsync(options...); err != nil {
+			return xerrors.Errorf("rsync tablespace %d to %q: %w", relocation.GetOid(), relocation.GetDestination(), err)
+		}
+
+		symlink := filepath.Join(dataDir, "pg_tblspc", strconv.Itoa(int(relocation.GetOid())))
+		if err := RecreateSymlink(filepath.Dir(relocation.GetDestination()), symlink); err != nil {
+			return xerrors.Errorf("recreate symbolic link: %w", err)
+		}
+
+		if err := DeleteTablespaceDirectories(step.DevNullStream, []string{relocation.GetSource()}); err != nil {
+			return xerrors.Errorf("delete relocated tablespace %d: %w", relocation.GetOid(), err)
+		}
+	}
+
+	return nil
+}
+
+func RecreateSymlink(sourceDir, symLinkName string) error {
+	_, err := utils.System.Lstat(symLinkName)
+	if err == nil {
+		if err := utils.System.Remove(symLinkName); err != nil {
+			return xerrors.Errorf("unlink %q: %w", symLinkName, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return xerrors.Errorf("stat symbolic link %q: %w", symLinkName, err)
+	}
+
+	if err := utils.System.Symlink(sourceDir, symLinkName); err != nil {
+		return xerrors.Errorf("create symbolic link %q to directory %q: %w", symLinkName, sourceDir, err)
+	}
+
+	return nil
+}
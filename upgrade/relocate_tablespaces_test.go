@@ -0,0 +1,103 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/upgrade"
+	"github.com/greenplum-db/gpupgrade/utils/rsync"
+)
+
+func TestRelocateTablespaces(t *testing.T) {
+	setup := func(t *testing.T) (string, string, *idl.TablespaceRelocation) {
+		t.Helper()
+
+		tsDir, dbIdDir, tsLocation := testutils.MustMakeTablespaceDir(t, 16386)
+		t.Cleanup(func() { testutils.MustRemoveAll(t, tsLocation) })
+
+		newLocation := testutils.GetTempDir(t, "")
+		t.Cleanup(func() { testutils.MustRemoveAll(t, newLocation) })
+
+		dataDir := testutils.GetTempDir(t, "")
+		t.Cleanup(func() { testutils.MustRemoveAll(t, dataDir) })
+
+		testutils.MustCreateDir(t, filepath.Join(dataDir, "pg_tblspc"))
+		symlink := filepath.Join(dataDir, "pg_tblspc", "16386")
+		if err := os.Symlink(dbIdDir, symlink); err != nil {
+			t.Fatalf("creating symlink: %v", err)
+		}
+
+		relocation := &idl.TablespaceRelocation{
+			Oid:         16386,
+			Source:      tsDir,
+			Destination: upgrade.TablespacePath(filepath.Join(newLocation, "16386"), 1, 6, "301908232"),
+		}
+
+		return dataDir, symlink, relocation
+	}
+
+	t.Run("copies the tablespace to its new location and repoints the symlink", func(t *testing.T) {
+		dataDir, symlink, relocation := setup(t)
+
+		var rsyncArgs []string
+		rsync.SetRsyncCommand(exectest.NewCommandWithVerifier(upgrade.Success, func(name string, args ...string) {
+			rsyncArgs = args
+		}))
+		defer rsync.ResetRsyncCommand()
+
+		err := upgrade.RelocateTablespaces(context.Background(), dataDir, []*idl.TablespaceRelocation{relocation})
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		expected := []string{"--archive", "--delete", relocation.GetSource() + string(os.PathSeparator), relocation.GetDestination()}
+		if !reflect.DeepEqual(rsyncArgs, expected) {
+			t.Errorf("got rsync args %q want %q", rsyncArgs, expected)
+		}
+
+		testutils.PathMustExist(t, relocation.GetDestination())
+		testutils.PathMustNotExist(t, relocation.GetSource())
+		testutils.PathMustNotExist(t, filepath.Dir(relocation.GetSource()))
+
+		target, err := os.Readlink(symlink)
+		if err != nil {
+			t.Fatalf("reading symlink: %v", err)
+		}
+
+		if target != filepath.Dir(relocation.GetDestination()) {
+			t.Errorf("got symlink target %q want %q", target, filepath.Dir(relocation.GetDestination()))
+		}
+	})
+
+	t.Run("leaves the original tablespace in place when copying fails", func(t *testing.T) {
+		dataDir, symlink, relocation := setup(t)
+
+		rsync.SetRsyncCommand(exectest.NewCommand(upgrade.Failure))
+		defer rsync.ResetRsyncCommand()
+
+		err := upgrade.RelocateTablespaces(context.Background(), dataDir, []*idl.TablespaceRelocation{relocation})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		testutils.PathMustExist(t, relocation.GetSource())
+
+		target, err := os.Readlink(symlink)
+		if err != nil {
+			t.Fatalf("reading symlink: %v", err)
+		}
+
+		if target != filepath.Dir(relocation.GetSource()) {
+			t.Errorf("got symlink target %q want %q", target, filepath.Dir(relocation.GetSource()))
+		}
+	})
+}
@@ -4,6 +4,7 @@
 package upgrade
 
 import (
+	"context"
 	"io"
 	"log"
 	"os/exec"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/blang/semver/v4"
 
+	"github.com/greenplum-db/gpupgrade/fault"
 	"github.com/greenplum-db/gpupgrade/idl"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
 	"github.com/greenplum-db/gpupgrade/utils"
@@ -22,7 +24,9 @@ const DefaultDynamicLibraryPath = "$libdir"
 
 var pgupgradeCmd = exec.Command
 
-func Run(stdout, stderr io.Writer, opts *idl.PgOptions) error {
+// Run executes pg_upgrade. When the context is done pg_upgrade is terminated
+// and the returned error wraps the cause.
+func Run(ctx context.Context, stdout, stderr io.Writer, opts *idl.PgOptions) error {
 	upgradeDir, err := utils.GetPgUpgradeDir(
 		opts.GetRole(),
 		opts.GetContentID(),
@@ -98,9 +102,14 @@ func Run(stdout, stderr io.Writer, opts *idl.PgOptions) error {
 	// like PATH and PGPORT which are explicitly forbidden to be set.
 	cmd.Env = []string{}
 
+	cmd, err = fault.Command("pg_upgrade", cmd)
+	if err != nil {
+		return err
+	}
+
 	log.Printf("Executing: %q", cmd.String())
 
-	return cmd.Run()
+	return utils.RunContext(ctx, cmd)
 }
 
 func SetPgUpgradeCommand(cmdFunc exectest.Command) {
@@ -6,6 +6,7 @@ package upgrade_test
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -89,7 +90,7 @@ func TestRun(t *testing.T) {
 			PgUpgradeTimestamp: "RandomTimestamp",
 		}
 
-		err := upgrade.Run(nil, nil, opts)
+		err := upgrade.Run(context.Background(), nil, nil, opts)
 		if err != nil {
 			t.Fatalf("unexpected error %+v", err)
 		}
@@ -117,7 +118,7 @@ func TestRun(t *testing.T) {
 			PgUpgradeTimestamp: "RandomTimestamp",
 		}
 
-		err = upgrade.Run(nil, nil, opts)
+		err = upgrade.Run(context.Background(), nil, nil, opts)
 		if err != nil {
 			t.Fatalf("unexpected error %+v", err)
 		}
@@ -133,7 +134,7 @@ func TestRun(t *testing.T) {
 		upgrade.SetPgUpgradeCommand(exectest.NewCommand(upgrade.Success))
 		defer upgrade.ResetPgUpgradeCommand()
 
-		err := upgrade.Run(nil, nil, &idl.PgOptions{})
+		err := upgrade.Run(context.Background(), nil, nil, &idl.PgOptions{})
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v want %#v", err, expected)
 		}
@@ -149,7 +150,7 @@ func TestRun(t *testing.T) {
 		upgrade.SetPgUpgradeCommand(exectest.NewCommand(upgrade.Success))
 		defer upgrade.ResetPgUpgradeCommand()
 
-		err := upgrade.Run(nil, nil, &idl.PgOptions{TargetVersion: "7.2.0"})
+		err := upgrade.Run(context.Background(), nil, nil, &idl.PgOptions{TargetVersion: "7.2.0"})
 		if !errors.Is(err, expected) {
 			t.Errorf("got error %#v want %#v", err, expected)
 		}
@@ -168,7 +169,7 @@ func TestRun(t *testing.T) {
 			TargetVersion:      "6.20.0",
 			PgUpgradeTimestamp: "RandomTimestamp",
 		}
-		err := upgrade.Run(stdout, stderr, opts)
+		err := upgrade.Run(context.Background(), stdout, stderr, opts)
 		if err != nil {
 			t.Fatalf("unexpected error %+v", err)
 		}
@@ -197,7 +198,7 @@ func TestRun(t *testing.T) {
 			TargetVersion:      "6.20.0",
 			PgUpgradeTimestamp: "RandomTimestamp",
 		}
-		err := upgrade.Run(stdout, nil, opts)
+		err := upgrade.Run(context.Background(), stdout, nil, opts)
 		if err != nil {
 			t.Fatalf("unexpected error %+v", err)
 		}
@@ -233,7 +234,7 @@ func TestRun(t *testing.T) {
 			TargetVersion:      "6.20.0",
 			PgUpgradeTimestamp: "RandomTimestamp",
 		}
-		err := upgrade.Run(stdout, nil, opts)
+		err := upgrade.Run(context.Background(), stdout, nil, opts)
 		if err != nil {
 			t.Fatalf("unexpected error %+v", err)
 		}
@@ -265,7 +266,7 @@ func TestRun(t *testing.T) {
 			PgUpgradeTimestamp: "RandomTimestamp",
 		}
 
-		err := upgrade.Run(nil, nil, opts)
+		err := upgrade.Run(context.Background(), nil, nil, opts)
 		var exitErr *exec.ExitError
 		if !errors.As(err, &exitErr) {
 			t.Fatalf("got error %#v, want type *exec.ExitError", err)
@@ -799,7 +800,7 @@ func TestRun(t *testing.T) {
 			}))
 			defer upgrade.ResetPgUpgradeCommand()
 
-			err := upgrade.Run(nil, nil, c.opts)
+			err := upgrade.Run(context.Background(), nil, nil, c.opts)
 			if err != nil {
 				t.Fatalf("unexpected error %+v", err)
 			}
@@ -0,0 +1,105 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// AddressFamily selects the IP versions used to reach the hosts of the
+// cluster. The zero value is the same as AnyAddressFamily.
+type AddressFamily string
+
+const (
+	// AnyAddressFamily uses both IPv4 and IPv6 such as on dual-stack hosts.
+	AnyAddressFamily  AddressFamily = "any"
+	IPv4AddressFamily AddressFamily = "ipv4"
+	IPv6AddressFamily AddressFamily = "ipv6"
+)
+
+var AddressFamilies = []AddressFamily{AnyAddressFamily, IPv4AddressFamily, IPv6AddressFamily}
+
+func ParseAddressFamily(input string) (AddressFamily, error) {
+	if input == "" {
+		return AnyAddressFamily, nil
+	}
+
+	for _, family := range AddressFamilies {
+		if strings.EqualFold(input, string(family)) {
+			return family, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid address family %q. Expected one of %q", input, AddressFamilies)
+}
+
+// Includes returns whether ip belongs to the address family.
+func (f AddressFamily) Includes(ip net.IP) bool {
+	switch f {
+	case IPv4AddressFamily:
+		return ip.To4() != nil
+	case IPv6AddressFamily:
+		return ip.To4() == nil && ip.To16() != nil
+	default:
+		return ip.To16() != nil
+	}
+}
+
+// Network returns the network for net.Dial restricted to the address family.
+func (f AddressFamily) Network() string {
+	switch f {
+	case IPv4AddressFamily:
+		return "tcp4"
+	case IPv6AddressFamily:
+		return "tcp6"
+	default:
+		return "tcp"
+	}
+}
+
+// SSHOptions returns the ssh options restricting it to the address family.
+func (f AddressFamily) SSHOptions() []string {
+	switch f {
+	case IPv4AddressFamily:
+		return []string{"-4"}
+	case IPv6AddressFamily:
+		return []string{"-6"}
+	default:
+		return nil
+	}
+}
+
+// Dialer dials host:port addresses using only the address family. It is
+// suitable for grpc.WithContextDialer.
+func (f AddressFamily) Dialer() func(ctx context.Context, address string) (net.Conn, error) {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, f.Network(), address)
+	}
+}
+
+// HostCIDR returns ip in CIDR notation matching only itself, such as for
+// pg_hba.conf. IPv4 addresses including IPv4-mapped IPv6 addresses get a /32
+// mask and the rest a /128 mask.
+func HostCIDR(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return (&net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}).String()
+	}
+
+	return (&net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}).String()
+}
+
+// JoinHostPath formats a remote path for rsync and ssh as host:path enclosing
+// IPv6 literals in brackets so their colons are not mistaken for the
+// separator.
+func JoinHostPath(host string, path string) string {
+	if strings.Contains(host, ":") && !strings.HasPrefix(host, "[") {
+		host = "[" + host + "]"
+	}
+
+	return host + ":" + path
+}
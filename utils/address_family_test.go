@@ -0,0 +1,124 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func TestParseAddressFamily(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected utils.AddressFamily
+	}{
+		{input: "", expected: utils.AnyAddressFamily},
+		{input: "any", expected: utils.AnyAddressFamily},
+		{input: "ipv4", expected: utils.IPv4AddressFamily},
+		{input: "IPv6", expected: utils.IPv6AddressFamily},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			family, err := utils.ParseAddressFamily(c.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if family != c.expected {
+				t.Errorf("got %q want %q", family, c.expected)
+			}
+		})
+	}
+
+	t.Run("errors on invalid address families", func(t *testing.T) {
+		_, err := utils.ParseAddressFamily("ipx")
+		expected := `invalid address family "ipx". Expected one of ["any" "ipv4" "ipv6"]`
+		if err == nil || err.Error() != expected {
+			t.Errorf("got error %v want %q", err, expected)
+		}
+	})
+}
+
+func TestAddressFamily(t *testing.T) {
+	ipv4 := net.ParseIP("10.0.0.1")
+	ipv6 := net.ParseIP("fd00::1")
+
+	cases := []struct {
+		family     utils.AddressFamily
+		network    string
+		sshOptions []string
+		includesV4 bool
+		includesV6 bool
+	}{
+		{family: "", network: "tcp", includesV4: true, includesV6: true},
+		{family: utils.AnyAddressFamily, network: "tcp", includesV4: true, includesV6: true},
+		{family: utils.IPv4AddressFamily, network: "tcp4", sshOptions: []string{"-4"}, includesV4: true, includesV6: false},
+		{family: utils.IPv6AddressFamily, network: "tcp6", sshOptions: []string{"-6"}, includesV4: false, includesV6: true},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.family), func(t *testing.T) {
+			if network := c.family.Network(); network != c.network {
+				t.Errorf("got network %q want %q", network, c.network)
+			}
+
+			if options := c.family.SSHOptions(); !reflect.DeepEqual(options, c.sshOptions) {
+				t.Errorf("got ssh options %q want %q", options, c.sshOptions)
+			}
+
+			if includes := c.family.Includes(ipv4); includes != c.includesV4 {
+				t.Errorf("got includes IPv4 %t want %t", includes, c.includesV4)
+			}
+
+			if includes := c.family.Includes(ipv6); includes != c.includesV6 {
+				t.Errorf("got includes IPv6 %t want %t", includes, c.includesV6)
+			}
+		})
+	}
+}
+
+func TestHostCIDR(t *testing.T) {
+	cases := []struct {
+		ip       string
+		expected string
+	}{
+		{ip: "10.0.0.1", expected: "10.0.0.1/32"},
+		{ip: "::ffff:10.0.0.1", expected: "10.0.0.1/32"},
+		{ip: "fe80::903a:1c1a:e802:11e4", expected: "fe80::903a:1c1a:e802:11e4/128"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.ip, func(t *testing.T) {
+			cidr := utils.HostCIDR(net.ParseIP(c.ip))
+			if cidr != c.expected {
+				t.Errorf("got %q want %q", cidr, c.expected)
+			}
+		})
+	}
+}
+
+func TestJoinHostPath(t *testing.T) {
+	cases := []struct {
+		host     string
+		expected string
+	}{
+		{host: "sdw1", expected: "sdw1:/data/primary"},
+		{host: "10.0.0.1", expected: "10.0.0.1:/data/primary"},
+		{host: "fd00::1", expected: "[fd00::1]:/data/primary"},
+		{host: "[fd00::1]", expected: "[fd00::1]:/data/primary"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.host, func(t *testing.T) {
+			path := utils.JoinHostPath(c.host, "/data/primary")
+			if path != c.expected {
+				t.Errorf("got %q want %q", path, c.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// terminateGracePeriod is how long a terminated command has to exit before
+// its process group is killed.
+var terminateGracePeriod = 30 * time.Second
+
+// XXX: for internal testing only
+func SetTerminateGracePeriod(period time.Duration) {
+	terminateGracePeriod = period
+}
+
+// RunContext runs the command and terminates it when the context is done.
+// Unlike exec.CommandContext the command's whole process group is sent SIGTERM
+// rather than SIGKILL giving it a chance to exit gracefully, and ensuring
+// utilities run through a shell such as gpstart are terminated along with it.
+// A process group still running after terminateGracePeriod is sent SIGKILL.
+// When the context is done the returned error wraps both its cause and the
+// error from the command.
+func RunContext(ctx context.Context, cmd *exec.Cmd) error {
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+
+		timer := time.NewTimer(terminateGracePeriod)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			log.Printf("killing %q which did not exit within %s of being terminated", cmd.String(), terminateGracePeriod)
+			_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		case <-done:
+		}
+	}()
+
+	err := cmd.Wait()
+	close(done)
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("%w: %w", context.Cause(ctx), err)
+	}
+
+	return err
+}
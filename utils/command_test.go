@@ -0,0 +1,137 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+func TestRunContext(t *testing.T) {
+	t.Run("runs the command", func(t *testing.T) {
+		cmd := exec.Command("true")
+
+		err := utils.RunContext(context.Background(), cmd)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("returns the command error", func(t *testing.T) {
+		cmd := exec.Command("false")
+
+		err := utils.RunContext(context.Background(), cmd)
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Errorf("got %T want %T", err, exitErr)
+		}
+	})
+
+	t.Run("does not start the command when the context is already done", func(t *testing.T) {
+		expected := errors.New("out of space")
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(expected)
+
+		cmd := exec.Command("true")
+
+		err := utils.RunContext(ctx, cmd)
+		if !errors.Is(err, expected) {
+			t.Errorf("got %#v want %#v", err, expected)
+		}
+
+		if cmd.Process != nil {
+			t.Errorf("expected command to not be started")
+		}
+	})
+
+	t.Run("terminates the command when the context is done", func(t *testing.T) {
+		expected := errors.New("out of space")
+		ctx, cancel := context.WithCancelCause(context.Background())
+
+		cmd := exec.Command("sleep", "60")
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			cancel(expected)
+		}()
+
+		err := utils.RunContext(ctx, cmd)
+		if !errors.Is(err, expected) {
+			t.Errorf("got %#v want %#v", err, expected)
+		}
+
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("got %T want %T", err, exitErr)
+		}
+
+		status, ok := exitErr.Sys().(syscall.WaitStatus)
+		if !ok || status.Signal() != syscall.SIGTERM {
+			t.Errorf("got status %v want it to be terminated by %v", exitErr.Sys(), syscall.SIGTERM)
+		}
+	})
+
+	t.Run("kills the command when it does not exit once terminated", func(t *testing.T) {
+		utils.SetTerminateGracePeriod(100 * time.Millisecond)
+		defer utils.SetTerminateGracePeriod(30 * time.Second)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// Only signal once the shell has ignored SIGTERM.
+		cmd := exec.Command("sh", "-c", `trap "" TERM; echo ready; sleep 60`)
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			t.Fatalf("unexpected error %#v", err)
+		}
+
+		go func() {
+			_, _ = stdout.Read(make([]byte, len("ready\n")))
+			cancel()
+		}()
+
+		err = utils.RunContext(ctx, cmd)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got %#v want %#v", err, context.Canceled)
+		}
+
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("got %T want %T", err, exitErr)
+		}
+
+		status, ok := exitErr.Sys().(syscall.WaitStatus)
+		if !ok || status.Signal() != syscall.SIGKILL {
+			t.Errorf("got status %v want it to be killed by %v", exitErr.Sys(), syscall.SIGKILL)
+		}
+	})
+
+	t.Run("terminates the process group of the command", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// The shell forks sleep which holds stdout open. Only terminating
+		// the shell would leave Wait blocked until sleep exits.
+		cmd := exec.Command("sh", "-c", "sleep 60; true")
+		cmd.Stdout = new(bytes.Buffer)
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			cancel()
+		}()
+
+		start := time.Now()
+		err := utils.RunContext(ctx, cmd)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got %#v want %#v", err, context.Canceled)
+		}
+
+		if elapsed := time.Since(start); elapsed > 30*time.Second {
+			t.Errorf("took %s to terminate the process group", elapsed)
+		}
+	})
+}
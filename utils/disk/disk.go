@@ -47,28 +47,80 @@ func (f FileSystemDiskUsage) Swap(i, j int) {
 	f[i], f[j] = f[j], f[i]
 }
 
-type CheckUsageType func(streams step.OutStreams, d Disk, diskFreeRatio float64, paths ...string) (FileSystemDiskUsage, error)
-
-// CheckUsage uses the given Disk to look up filesystem usage for each path, and
-// compares the available space to the required disk ratio. Any filesystems that
-// don't have enough space will be given an entry in the returned SpaceFailures
-// map. Note that this is one entry per filesystem, not one entry per path.
+type CheckUsageType func(streams step.OutStreams, d Disk, requirements ...Requirement) (FileSystemDiskUsage, error)
+
+// CheckUsage uses the given Disk to look up filesystem usage for each
+// requirement, and compares the available space to the sum of the bytes
+// required on that filesystem. Any filesystems that don't have enough space
+// will be given an entry in the returned usage. Note that this is one entry per
+// filesystem, not one entry per requirement. Required and available space are
+// reported in KB to match the filesystem usage.
 //
 // This function ignores space that has been reserved for the superuser (i.e.
 // the difference between "free" and "avail" in statfs(2)). It does not consider
-// that space to be free for use, nor does it count that space against the total
-// disk size. For example, a disk with 25% avail space and 75% free space -- as
-// defined by statfs(2) -- would be considered 50% available by CheckUsage.
-func CheckUsage(streams step.OutStreams, d Disk, diskFreeRatio float64, paths ...string) (FileSystemDiskUsage, error) {
+// that space to be free for use.
+func CheckUsage(streams step.OutStreams, d Disk, requirements ...Requirement) (FileSystemDiskUsage, error) {
 	hostname, err := utils.System.Hostname()
 	if err != nil {
 		return nil, xerrors.Errorf("determining hostname: %w", err)
 	}
 
-	failures := make(map[string]*idl.CheckDiskSpaceReply_DiskUsage)
+	fsByID, err := filesystemsByID(d, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sum the requirements of paths sharing a filesystem since they all
+	// consume the same available space.
+	var filesystems []string
+	totals := make(map[string]*idl.CheckDiskSpaceReply_DiskUsage)
+	for _, requirement := range requirements {
+		stat, err := d.Stat(requirement.Path)
+		if err != nil {
+			return nil, xerrors.Errorf("stat'ing %s: %w", requirement.Path, err)
+		}
+
+		fs, ok := fsByID[uint64(stat.Dev)]
+		if !ok {
+			// Rather than blow up if we can't associate a path with a
+			// filesystem, just use the path itself.
+			fs = requirement.Path
+		}
+
+		total, ok := totals[fs]
+		if !ok {
+			usage, err := d.Usage(requirement.Path)
+			if err != nil {
+				return nil, xerrors.Errorf("getting fs usage for %s: %w", requirement.Path, err)
+			}
+
+			total = &idl.CheckDiskSpaceReply_DiskUsage{Fs: fs, Host: hostname, Available: usage.Avail}
+			totals[fs] = total
+			filesystems = append(filesystems, fs)
+		}
+
+		required := bytesToKB(requirement.Bytes)
+		total.Required += required
+
+		log.Printf("%s: %d KB required on %s", requirement.Path, required, fs)
+	}
+
+	var usage FileSystemDiskUsage
+	for _, fs := range filesystems {
+		total := totals[fs]
+		log.Printf("%s: %d KB avail of %d KB required", fs, total.GetAvailable(), total.GetRequired())
+
+		if total.GetAvailable() < total.GetRequired() {
+			usage = append(usage, total)
+		}
+	}
+
+	return usage, nil
+}
 
-	// Find the device ID for every filesystem. We'll use these to map data
-	// directories to filesystems later.
+// filesystemsByID finds the device ID for every filesystem which is used to
+// map paths to filesystems.
+func filesystemsByID(d Disk, hostname string) (map[uint64]string, error) {
 	fs, err := d.Filesystems()
 	if err != nil {
 		return nil, xerrors.Errorf("enumerating filesystems: %w", err)
@@ -89,51 +141,11 @@ func CheckUsage(streams step.OutStreams, d Disk, diskFreeRatio float64, paths ..
 		fsByID[uint64(stat.Dev)] = f.DirName
 	}
 
-	for _, path := range paths {
-		usage, err := d.Usage(path)
-		if err != nil {
-			return nil, xerrors.Errorf("getting fs usage for %s: %w", path, err)
-		}
-
-		// Exclude superuser-reserved space.
-		total := usage.Used + usage.Avail
-		required := uint64(diskFreeRatio * float64(total))
-
-		log.Printf("%s: %d avail of %d required (%d used, %d total)",
-			path, usage.Avail, required, usage.Used, usage.Total)
-
-		if usage.Avail < required {
-			// Get the filesystem that this path belongs to.
-			stat, err := d.Stat(path)
-			if err != nil {
-				return nil, xerrors.Errorf("stat'ing %s: %w", path, err)
-			}
-
-			fs, ok := fsByID[uint64(stat.Dev)]
-			if !ok {
-				// Rather than blow up if we can't associate a path with a
-				// filesystem, just use the path itself.
-				fs = path
-			}
-
-			failures[fs] = &idl.CheckDiskSpaceReply_DiskUsage{
-				Fs:        fs,
-				Host:      hostname,
-				Required:  required,
-				Available: usage.Avail,
-			}
-		}
-	}
-
-	// NOTE: Transform the failures map used to prevent duplicate filesystems
-	// into a list since protobuf can't handle maps with complex keys such as a
-	// struct of filesystem and host.
-	var usage FileSystemDiskUsage
-	for _, failure := range failures {
-		usage = append(usage, failure)
-	}
+	return fsByID, nil
+}
 
-	return usage, nil
+func bytesToKB(bytes uint64) uint64 {
+	return (bytes + 1023) / 1024
 }
 
 // Local is a standard implementation of the Disk interface that uses gosigar
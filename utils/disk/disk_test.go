@@ -82,22 +82,24 @@ func TestCheckUsage(t *testing.T) {
 		},
 	}
 
+	// kb returns the requirement in bytes for the given fraction of the disk.
+	kb := func(path string, ratio float64) disk.Requirement {
+		return disk.Requirement{Path: path, Bytes: scale(size, ratio) * 1024}
+	}
+
 	cases := []struct {
-		name     string
-		ratio    float64
-		paths    []string
-		expected disk.FileSystemDiskUsage
+		name         string
+		requirements []disk.Requirement
+		expected     disk.FileSystemDiskUsage
 	}{
 		{
-			name:     "returns no failures with adequate space",
-			ratio:    0.1,
-			paths:    []string{"/test/path"},
-			expected: nil,
+			name:         "returns no failures with adequate space",
+			requirements: []disk.Requirement{kb("/test/path", 0.1)},
+			expected:     nil,
 		},
 		{
-			name:  "returns failures with inadequate space",
-			ratio: 0.8,
-			paths: []string{"/test/path"},
+			name:         "returns failures with inadequate space",
+			requirements: []disk.Requirement{kb("/test/path", 0.8)},
 			expected: disk.FileSystemDiskUsage{
 				&idl.CheckDiskSpaceReply_DiskUsage{
 					Fs:        "/",
@@ -108,14 +110,25 @@ func TestCheckUsage(t *testing.T) {
 			},
 		},
 		{
-			name:  "returns only one failure per filesystem",
-			ratio: 0.8,
-			paths: []string{"/test/path", "/test/other/path", "/tmp/path"},
+			name:         "sums requirements on the same filesystem",
+			requirements: []disk.Requirement{kb("/test/path", 0.5), kb("/test/other/path", 0.5), kb("/tmp/path", 0.1)},
 			expected: disk.FileSystemDiskUsage{
 				&idl.CheckDiskSpaceReply_DiskUsage{
 					Fs:        "/",
 					Host:      host,
-					Required:  scale(size, 0.8),
+					Required:  2 * scale(size, 0.5),
+					Available: scale(size, 0.75),
+				},
+			},
+		},
+		{
+			name:         "returns only one failure per filesystem",
+			requirements: []disk.Requirement{kb("/test/path", 0.8), kb("/test/other/path", 0.1), kb("/tmp/path", 0.8)},
+			expected: disk.FileSystemDiskUsage{
+				&idl.CheckDiskSpaceReply_DiskUsage{
+					Fs:        "/",
+					Host:      host,
+					Required:  scale(size, 0.8) + scale(size, 0.1),
 					Available: scale(size, 0.75),
 				},
 				&idl.CheckDiskSpaceReply_DiskUsage{
@@ -127,9 +140,8 @@ func TestCheckUsage(t *testing.T) {
 			},
 		},
 		{
-			name:  "uses path directly if mount point is not found",
-			ratio: 0.8,
-			paths: []string{"/unmounted/path"},
+			name:         "uses path directly if mount point is not found",
+			requirements: []disk.Requirement{kb("/unmounted/path", 0.8)},
 			expected: disk.FileSystemDiskUsage{
 				&idl.CheckDiskSpaceReply_DiskUsage{
 					Fs:        "/unmounted/path",
@@ -139,11 +151,23 @@ func TestCheckUsage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:         "rounds required bytes up to the nearest KB",
+			requirements: []disk.Requirement{{Path: "/tmp/path", Bytes: scale(size, 0.25)*1024 + 1}},
+			expected: disk.FileSystemDiskUsage{
+				&idl.CheckDiskSpaceReply_DiskUsage{
+					Fs:        "/tmp",
+					Host:      host,
+					Required:  scale(size, 0.25) + 1,
+					Available: scale(size, 0.25),
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			actual, err := disk.CheckUsage(step.DevNullStream, d, c.ratio, c.paths...)
+			actual, err := disk.CheckUsage(step.DevNullStream, d, c.requirements...)
 			if err != nil {
 				t.Fatalf("returned error %#v", err)
 			}
@@ -155,7 +179,7 @@ func TestCheckUsage(t *testing.T) {
 		})
 	}
 
-	t.Run("excludes superuser-reserved space from total disk size", func(t *testing.T) {
+	t.Run("excludes superuser-reserved space from available space", func(t *testing.T) {
 		// Use the previous test disk setup; just tweak the usage.
 		d.usage = func(path string) (sigar.FileSystemUsage, error) {
 			u := sigar.FileSystemUsage{Total: size}
@@ -168,11 +192,9 @@ func TestCheckUsage(t *testing.T) {
 			return u, nil
 		}
 
-		// If you look at just the available and total disk space, you might
-		// assume that we have only 25% of the disk to use. In fact we have
-		// 50% of our available space, because we've only used half of the
-		// unreserved disk.
-		actual, err := disk.CheckUsage(step.DevNullStream, d, 0.4, "/path")
+		// Even though 75% of the disk is free only the 25% not reserved for
+		// the superuser is available.
+		actual, err := disk.CheckUsage(step.DevNullStream, d, kb("/path", 0.2))
 		if err != nil {
 			t.Errorf("returned error %#v", err)
 		}
@@ -180,8 +202,7 @@ func TestCheckUsage(t *testing.T) {
 			t.Errorf("returned %v; want no failures", actual)
 		}
 
-		// Make sure the required disk count is correctly calculated as well.
-		actual, err = disk.CheckUsage(step.DevNullStream, d, 0.6, "/path")
+		actual, err = disk.CheckUsage(step.DevNullStream, d, kb("/path", 0.5))
 		if err != nil {
 			t.Errorf("returned error %#v", err)
 		}
@@ -190,8 +211,8 @@ func TestCheckUsage(t *testing.T) {
 			&idl.CheckDiskSpaceReply_DiskUsage{
 				Fs:        "/",
 				Host:      host,
-				Required:  scale(size/2, 0.6),
-				Available: scale(size/2, 0.5),
+				Required:  scale(size, 0.5),
+				Available: scale(size, 0.25),
 			},
 		}
 		if !reflect.DeepEqual(actual, expected) {
@@ -199,42 +220,6 @@ func TestCheckUsage(t *testing.T) {
 		}
 	})
 
-	// regression test to catch float representation errors
-	t.Run("does floating point math correctly", func(t *testing.T) {
-		d := testDisk{
-			err: errors.New("should never happen"),
-
-			filesystems: func() (sigar.FileSystemList, error) {
-				return sigar.FileSystemList{List: []sigar.FileSystem{
-					{DirName: "/"},
-				}}, nil
-			},
-
-			// Utilization is 40% exactly.
-			usage: func(path string) (sigar.FileSystemUsage, error) {
-				u := sigar.FileSystemUsage{
-					Total: 487260160,
-					Avail: 292356096,
-				}
-				u.Free = u.Avail
-				u.Used = u.Total - u.Avail
-				return u, nil
-			},
-
-			stat: func(path string) (*unix.Stat_t, error) {
-				return &unix.Stat_t{Dev: 1}, nil
-			},
-		}
-
-		actual, err := disk.CheckUsage(step.DevNullStream, d, 0.6, "/path")
-		if err != nil {
-			t.Errorf("returned error %#v", err)
-		}
-		if len(actual) > 0 {
-			t.Errorf("returned %v; want no failures", actual)
-		}
-	})
-
 	t.Run("bubbles up any errors", func(t *testing.T) {
 		// This is a whitebox test. Start with the "everything fails" case, then
 		// slowly implement more and more of the functionality until we've hit
@@ -243,7 +228,7 @@ func TestCheckUsage(t *testing.T) {
 		checkError := func() {
 			t.Helper() // don't include this function in the stack trace
 
-			_, err := disk.CheckUsage(step.DevNullStream, d, 0.5, "/test/path")
+			_, err := disk.CheckUsage(step.DevNullStream, d, disk.Requirement{Path: "/test/path", Bytes: 1024})
 			if !errors.Is(err, d.err) {
 				t.Errorf("returned %#v want %#v", err, d.err)
 			}
@@ -267,6 +252,11 @@ func TestCheckUsage(t *testing.T) {
 		}
 		checkError()
 
+		d.stat = func(path string) (*unix.Stat_t, error) {
+			return &unix.Stat_t{Dev: 1}, nil
+		}
+		checkError()
+
 		d.usage = func(path string) (sigar.FileSystemUsage, error) {
 			return sigar.FileSystemUsage{
 				Total: 1024,
@@ -275,15 +265,10 @@ func TestCheckUsage(t *testing.T) {
 				Avail: 0,
 			}, nil
 		}
-		checkError()
-
-		d.stat = func(path string) (*unix.Stat_t, error) {
-			return &unix.Stat_t{Dev: 1}, nil
-		}
 
 		// At this point everything should work; otherwise we're missing
 		// coverage for a path.
-		_, err := disk.CheckUsage(step.DevNullStream, d, 0.5, "/test/path")
+		_, err := disk.CheckUsage(step.DevNullStream, d, disk.Requirement{Path: "/test/path", Bytes: 1024})
 		if err != nil {
 			t.Errorf("returned %#v after all error cases were removed", err)
 		}
@@ -327,7 +312,7 @@ func TestCheckUsage(t *testing.T) {
 			return stat, nil
 		}
 
-		actual, err := disk.CheckUsage(step.DevNullStream, d, 0.8, "/data/qddir/seg-1")
+		actual, err := disk.CheckUsage(step.DevNullStream, d, kb("/data/qddir/seg-1", 0.8))
 		if err != nil {
 			t.Errorf("unexpected error %#v", err)
 		}
@@ -0,0 +1,146 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package disk
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// FirstNormalObjectId is the first oid assigned to user objects. Relation
+// files numbered below it belong to the system catalog.
+const FirstNormalObjectId = 16384
+
+// Size is the on-disk size in bytes of a data directory or tablespace location
+// broken down by what the upgrade does with it.
+type Size struct {
+	// Relfiles are user relation files which are duplicated in copy mode and
+	// hard linked in link mode.
+	Relfiles uint64
+	// Catalog is everything other than user relation files and WAL. The
+	// target cluster always needs its own copy.
+	Catalog uint64
+	// WAL is the write-ahead log which is used as an estimate of the WAL the
+	// target cluster generates.
+	WAL uint64
+}
+
+func (s Size) Total() uint64 {
+	return s.Relfiles + s.Catalog + s.WAL
+}
+
+// Required returns the bytes the upgrade needs in addition to what is already
+// used. Copy mode duplicates the user relation files, while link mode only
+// needs a new catalog along with headroom for WAL.
+func (s Size) Required(mode idl.Mode) uint64 {
+	if mode == idl.Mode_link {
+		return s.Catalog + s.WAL
+	}
+
+	return s.Total()
+}
+
+// Sizes maps a directory to its size.
+type Sizes map[string]Size
+
+func (s Sizes) Total() uint64 {
+	var total uint64
+	for _, size := range s {
+		total += size.Total()
+	}
+
+	return total
+}
+
+// Requirements returns the space required for each directory in the given
+// mode.
+func (s Sizes) Requirements(mode idl.Mode) []Requirement {
+	var requirements []Requirement
+	for dir, size := range s {
+		requirements = append(requirements, Requirement{Path: dir, Bytes: size.Required(mode)})
+	}
+
+	return requirements
+}
+
+// Requirement is the space in bytes needed on the filesystem containing Path.
+type Requirement struct {
+	Path  string
+	Bytes uint64
+}
+
+type MeasureDirsType func(dirs ...string) (Sizes, error)
+
+// MeasureDirs walks each directory summing the size of its files.
+func MeasureDirs(dirs ...string) (Sizes, error) {
+	sizes := make(Sizes)
+	for _, dir := range dirs {
+		size, err := measureDir(dir)
+		if err != nil {
+			return nil, xerrors.Errorf("measuring %s: %w", dir, err)
+		}
+
+		sizes[dir] = size
+	}
+
+	return sizes, nil
+}
+
+func measureDir(dir string) (Size, error) {
+	var size Size
+	err := fs.WalkDir(utils.System.DirFS(dir), ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		bytes := uint64(info.Size())
+		switch {
+		case isWAL(path):
+			size.WAL += bytes
+		case isUserRelfile(entry.Name()):
+			size.Relfiles += bytes
+		default:
+			size.Catalog += bytes
+		}
+
+		return nil
+	})
+
+	return size, err
+}
+
+func isWAL(path string) bool {
+	top, _, _ := strings.Cut(filepath.ToSlash(path), "/")
+	return top == "pg_xlog" || top == "pg_wal"
+}
+
+// isUserRelfile returns true for relation files such as 16385, 16385.1, and
+// 16385_fsm whose relfilenode is not in the system catalog range.
+func isUserRelfile(name string) bool {
+	name, _, _ = strings.Cut(name, ".")
+	name, _, _ = strings.Cut(name, "_")
+
+	relfilenode, err := strconv.ParseUint(name, 10, 32)
+	if err != nil {
+		return false
+	}
+
+	return relfilenode >= FirstNormalObjectId
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package disk_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"testing/fstest"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+func TestMeasureDirs(t *testing.T) {
+	t.Run("measures user relation files, catalog, and WAL", func(t *testing.T) {
+		utils.System.DirFS = func(dir string) fs.FS {
+			switch dir {
+			case "/data/dbfast/seg1":
+				return fstest.MapFS{
+					"PG_VERSION":             {Data: make([]byte, 3)},
+					"global/1262":            {Data: make([]byte, 10)},
+					"base/1/1259":            {Data: make([]byte, 20)},
+					"base/16384/16385":       {Data: make([]byte, 100)},
+					"base/16384/16385.1":     {Data: make([]byte, 200)},
+					"base/16384/16385_fsm":   {Data: make([]byte, 30)},
+					"base/16384/pg_filenode": {Data: make([]byte, 4)},
+					"pg_xlog/000000010000":   {Data: make([]byte, 1000)},
+				}
+			case "/tmp/user_ts/p1/16384":
+				return fstest.MapFS{
+					"GPDB_6_301908232/16384/16390":   {Data: make([]byte, 500)},
+					"GPDB_6_301908232/16384/PG_LINK": {Data: make([]byte, 5)},
+				}
+			}
+
+			return fstest.MapFS{}
+		}
+		defer utils.ResetSystemFunctions()
+
+		sizes, err := disk.MeasureDirs("/data/dbfast/seg1", "/tmp/user_ts/p1/16384")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := disk.Sizes{
+			"/data/dbfast/seg1":     {Relfiles: 330, Catalog: 37, WAL: 1000},
+			"/tmp/user_ts/p1/16384": {Relfiles: 500, Catalog: 5},
+		}
+		if !reflect.DeepEqual(sizes, expected) {
+			t.Errorf("got %+v want %+v", sizes, expected)
+		}
+
+		if sizes.Total() != 1872 {
+			t.Errorf("got total %d want %d", sizes.Total(), 1872)
+		}
+	})
+
+	t.Run("errors when failing to walk a directory", func(t *testing.T) {
+		utils.System.DirFS = func(dir string) fs.FS {
+			return errorFS{os.ErrPermission}
+		}
+		defer utils.ResetSystemFunctions()
+
+		_, err := disk.MeasureDirs("/data/dbfast/seg1")
+		if !errors.Is(err, os.ErrPermission) {
+			t.Errorf("got error %#v want %#v", err, os.ErrPermission)
+		}
+	})
+}
+
+func TestRequirements(t *testing.T) {
+	sizes := disk.Sizes{
+		"/data/dbfast/seg1":     {Relfiles: 300, Catalog: 20, WAL: 1000},
+		"/tmp/user_ts/p1/16384": {Relfiles: 500, Catalog: 5},
+	}
+
+	cases := []struct {
+		mode     idl.Mode
+		expected []disk.Requirement
+	}{
+		{
+			mode: idl.Mode_copy,
+			expected: []disk.Requirement{
+				{Path: "/data/dbfast/seg1", Bytes: 1320},
+				{Path: "/tmp/user_ts/p1/16384", Bytes: 505},
+			},
+		},
+		{
+			mode: idl.Mode_link,
+			expected: []disk.Requirement{
+				{Path: "/data/dbfast/seg1", Bytes: 1020},
+				{Path: "/tmp/user_ts/p1/16384", Bytes: 5},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run("computes required space for "+c.mode.String()+" mode", func(t *testing.T) {
+			requirements := sizes.Requirements(c.mode)
+			sort.Slice(requirements, func(i, j int) bool {
+				return requirements[i].Path < requirements[j].Path
+			})
+
+			if !reflect.DeepEqual(requirements, c.expected) {
+				t.Errorf("got %+v want %+v", requirements, c.expected)
+			}
+		})
+	}
+}
+
+type errorFS struct {
+	err error
+}
+
+func (e errorFS) Open(name string) (fs.File, error) {
+	return nil, e.err
+}
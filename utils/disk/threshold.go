@@ -0,0 +1,96 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package disk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Threshold is the free space of a filesystem below which the watchdog acts.
+// It is either a ratio of the space and inodes of the filesystem, or an
+// absolute amount of space in KB. A zero threshold is never crossed.
+type Threshold struct {
+	Ratio float64
+	KB    uint64
+}
+
+// Thresholds are the free space below which the watchdog warns, and below
+// which the agents abort pg_upgrade and rsync.
+type Thresholds struct {
+	Warn  Threshold
+	Abort Threshold
+}
+
+// DefaultThresholds leave headroom for Greenplum itself when aborting so the
+// clusters remain usable for revert.
+var DefaultThresholds = Thresholds{
+	Warn:  Threshold{Ratio: 0.10},
+	Abort: Threshold{Ratio: 0.02},
+}
+
+// sizeUnits are decimal to match FormatBytes.
+var sizeUnits = map[string]uint64{
+	"KB": 1,
+	"MB": 1000,
+	"GB": 1000 * 1000,
+	"TB": 1000 * 1000 * 1000,
+}
+
+// ParseThreshold parses either a percentage such as "10%" or a size such as
+// "50GB".
+func ParseThreshold(input string) (Threshold, error) {
+	input = strings.TrimSpace(input)
+
+	if strings.HasSuffix(input, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(input, "%")), 64)
+		if err != nil || percent < 0 || percent > 100 {
+			return Threshold{}, xerrors.Errorf("invalid disk threshold %q. Expected a percentage between 0%% and 100%%.", input)
+		}
+
+		return Threshold{Ratio: percent / 100}, nil
+	}
+
+	upper := strings.ToUpper(input)
+	for unit, kb := range sizeUnits {
+		if !strings.HasSuffix(upper, unit) {
+			continue
+		}
+
+		size, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(upper, unit)), 64)
+		if err != nil || size < 0 {
+			return Threshold{}, xerrors.Errorf("invalid disk threshold %q. Expected a size such as 50GB.", input)
+		}
+
+		return Threshold{KB: uint64(size * float64(kb))}, nil
+	}
+
+	return Threshold{}, xerrors.Errorf("invalid disk threshold %q. Expected either a percentage such as 10%% or a size in KB, MB, GB, or TB such as 50GB.", input)
+}
+
+func (t Threshold) String() string {
+	if t.KB > 0 {
+		return FormatBytes(t.KB)
+	}
+
+	return strconv.FormatFloat(t.Ratio*100, 'g', -1, 64) + "%"
+}
+
+// crossed returns whether the filesystem has less free space than the
+// threshold. Ratios apply to both space and inodes, while sizes only apply to
+// space.
+func (t Threshold) crossed(available uint64, ratio float64) bool {
+	if t.KB > 0 {
+		return available < t.KB
+	}
+
+	return ratio < t.Ratio
+}
+
+func (t Thresholds) String() string {
+	return fmt.Sprintf("warn below %s and abort below %s free", t.Warn, t.Abort)
+}
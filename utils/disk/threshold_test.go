@@ -0,0 +1,65 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package disk_test
+
+import (
+	"testing"
+
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+func TestParseThreshold(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected disk.Threshold
+	}{
+		{"10%", disk.Threshold{Ratio: 0.10}},
+		{" 2.5 % ", disk.Threshold{Ratio: 0.025}},
+		{"0%", disk.Threshold{}},
+		{"100KB", disk.Threshold{KB: 100}},
+		{"50GB", disk.Threshold{KB: 50 * 1000 * 1000}},
+		{"1.5tb", disk.Threshold{KB: 1500 * 1000 * 1000}},
+		{"500 MB", disk.Threshold{KB: 500 * 1000}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			threshold, err := disk.ParseThreshold(c.input)
+			if err != nil {
+				t.Fatalf("unexpected error %#v", err)
+			}
+
+			if threshold != c.expected {
+				t.Errorf("got %+v want %+v", threshold, c.expected)
+			}
+		})
+	}
+
+	errCases := []string{"", "0.1", "10", "abc%", "101%", "-1%", "-5GB", "5PB"}
+	for _, input := range errCases {
+		t.Run("errors on "+input, func(t *testing.T) {
+			_, err := disk.ParseThreshold(input)
+			if err == nil {
+				t.Errorf("expected error for %q", input)
+			}
+		})
+	}
+}
+
+func TestThresholdString(t *testing.T) {
+	cases := []struct {
+		threshold disk.Threshold
+		expected  string
+	}{
+		{disk.Threshold{Ratio: 0.10}, "10%"},
+		{disk.Threshold{Ratio: 0.025}, "2.5%"},
+		{disk.Threshold{KB: 50 * 1000 * 1000}, "50 GB"},
+	}
+
+	for _, c := range cases {
+		if c.threshold.String() != c.expected {
+			t.Errorf("got %q want %q", c.threshold.String(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package disk
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+var ErrLowDiskSpace = errors.New("low disk space")
+
+type FilesystemStatusType func(d Disk, paths ...string) ([]*idl.WatchDiskSpaceReply, error)
+
+// FilesystemStatus returns the free space and inodes of each filesystem
+// holding the given paths. Paths that do not exist yet, such as target data
+// directories that have not been created, use their closest existing parent.
+// Available and total space are reported in KB and the level is left unset.
+func FilesystemStatus(d Disk, paths ...string) ([]*idl.WatchDiskSpaceReply, error) {
+	hostname, err := utils.System.Hostname()
+	if err != nil {
+		return nil, xerrors.Errorf("determining hostname: %w", err)
+	}
+
+	fsByID, err := filesystemsByID(d, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []*idl.WatchDiskSpaceReply
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		path, dev, err := existingPath(d, path)
+		if err != nil {
+			return nil, err
+		}
+
+		fs, ok := fsByID[dev]
+		if !ok {
+			fs = path
+		}
+
+		if seen[fs] {
+			continue
+		}
+		seen[fs] = true
+
+		usage, err := d.Usage(path)
+		if err != nil {
+			return nil, xerrors.Errorf("getting fs usage for %s: %w", path, err)
+		}
+
+		statuses = append(statuses, &idl.WatchDiskSpaceReply{
+			Fs:              fs,
+			Host:            hostname,
+			Available:       usage.Avail,
+			Total:           usage.Total,
+			AvailableInodes: usage.FreeFiles,
+			TotalInodes:     usage.Files,
+		})
+	}
+
+	return statuses, nil
+}
+
+func existingPath(d Disk, path string) (string, uint64, error) {
+	for {
+		stat, err := d.Stat(path)
+		if err == nil {
+			return path, uint64(stat.Dev), nil
+		}
+
+		parent := filepath.Dir(path)
+		if !errors.Is(err, os.ErrNotExist) || parent == path {
+			return "", 0, xerrors.Errorf("stat'ing %s: %w", path, err)
+		}
+
+		path = parent
+	}
+}
+
+// Level compares the free space of the filesystem against the given
+// thresholds. Filesystems that do not report inodes are judged on space alone.
+func Level(status *idl.WatchDiskSpaceReply, warn Threshold, abort Threshold) idl.WatchDiskSpaceReply_Level {
+	ratio := freeRatio(status.GetAvailable(), status.GetTotal())
+	if inodes := freeRatio(status.GetAvailableInodes(), status.GetTotalInodes()); inodes < ratio {
+		ratio = inodes
+	}
+
+	switch {
+	case abort.crossed(status.GetAvailable(), ratio):
+		return idl.WatchDiskSpaceReply_abort
+	case warn.crossed(status.GetAvailable(), ratio):
+		return idl.WatchDiskSpaceReply_warn
+	default:
+		return idl.WatchDiskSpaceReply_ok
+	}
+}
+
+func freeRatio(available uint64, total uint64) float64 {
+	if total == 0 {
+		return 1
+	}
+
+	return float64(available) / float64(total)
+}
+
+// DescribeStatus returns a human readable summary of the filesystem's free
+// space and inodes.
+func DescribeStatus(status *idl.WatchDiskSpaceReply) string {
+	return fmt.Sprintf("filesystem %s on host %s has %s of %s and %d of %d inodes free",
+		status.GetFs(), status.GetHost(),
+		FormatBytes(status.GetAvailable()), FormatBytes(status.GetTotal()),
+		status.GetAvailableInodes(), status.GetTotalInodes())
+}
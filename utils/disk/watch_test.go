@@ -0,0 +1,131 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package disk_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	sigar "github.com/cloudfoundry/gosigar"
+	"golang.org/x/sys/unix"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils"
+	"github.com/greenplum-db/gpupgrade/utils/disk"
+)
+
+func TestFilesystemStatus(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	utils.System.Hostname = func() (string, error) {
+		return "sdw1", nil
+	}
+	defer utils.ResetSystemFunctions()
+
+	d := testDisk{
+		filesystems: func() (sigar.FileSystemList, error) {
+			return sigar.FileSystemList{List: []sigar.FileSystem{
+				{DirName: "/"},
+				{DirName: "/data"},
+			}}, nil
+		},
+
+		usage: func(path string) (sigar.FileSystemUsage, error) {
+			if strings.HasPrefix(path, "/data") {
+				return sigar.FileSystemUsage{Total: 1000, Avail: 50, Files: 100, FreeFiles: 90}, nil
+			}
+
+			return sigar.FileSystemUsage{Total: 2000, Avail: 1500, Files: 200, FreeFiles: 10}, nil
+		},
+
+		stat: func(path string) (*unix.Stat_t, error) {
+			switch {
+			case path == "/data/dbfast1/seg.HqtFHX54y0o.1":
+				return nil, os.ErrNotExist
+			case strings.HasPrefix(path, "/data"):
+				return &unix.Stat_t{Dev: 2}, nil
+			default:
+				return &unix.Stat_t{Dev: 1}, nil
+			}
+		},
+	}
+
+	t.Run("returns the status of each filesystem once", func(t *testing.T) {
+		statuses, err := disk.FilesystemStatus(d, "/data/dbfast1/seg1", "/data/dbfast1/seg.HqtFHX54y0o.1", "/tmp/user_ts/p1/16384")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []*idl.WatchDiskSpaceReply{
+			{Fs: "/data", Host: "sdw1", Available: 50, Total: 1000, AvailableInodes: 90, TotalInodes: 100},
+			{Fs: "/", Host: "sdw1", Available: 1500, Total: 2000, AvailableInodes: 10, TotalInodes: 200},
+		}
+		if !reflect.DeepEqual(statuses, expected) {
+			t.Errorf("got %v want %v", statuses, expected)
+		}
+	})
+
+	t.Run("errors when failing to stat a path", func(t *testing.T) {
+		d := d
+		d.stat = func(path string) (*unix.Stat_t, error) {
+			if path == "/data/dbfast1/seg1" {
+				return nil, os.ErrPermission
+			}
+
+			return &unix.Stat_t{Dev: 1}, nil
+		}
+
+		_, err := disk.FilesystemStatus(d, "/data/dbfast1/seg1")
+		if !errors.Is(err, os.ErrPermission) {
+			t.Errorf("got error %#v want %#v", err, os.ErrPermission)
+		}
+	})
+}
+
+func TestLevel(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   *idl.WatchDiskSpaceReply
+		expected idl.WatchDiskSpaceReply_Level
+	}{
+		{"ok with plenty of space and inodes", &idl.WatchDiskSpaceReply{Available: 50, Total: 100, AvailableInodes: 50, TotalInodes: 100}, idl.WatchDiskSpaceReply_ok},
+		{"warns when low on space", &idl.WatchDiskSpaceReply{Available: 5, Total: 100, AvailableInodes: 50, TotalInodes: 100}, idl.WatchDiskSpaceReply_warn},
+		{"warns when low on inodes", &idl.WatchDiskSpaceReply{Available: 50, Total: 100, AvailableInodes: 5, TotalInodes: 100}, idl.WatchDiskSpaceReply_warn},
+		{"aborts when almost out of space", &idl.WatchDiskSpaceReply{Available: 1, Total: 100, AvailableInodes: 50, TotalInodes: 100}, idl.WatchDiskSpaceReply_abort},
+		{"aborts when almost out of inodes", &idl.WatchDiskSpaceReply{Available: 50, Total: 100, AvailableInodes: 1, TotalInodes: 100}, idl.WatchDiskSpaceReply_abort},
+		{"ignores inodes on filesystems that do not report them", &idl.WatchDiskSpaceReply{Available: 50, Total: 100}, idl.WatchDiskSpaceReply_ok},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			level := disk.Level(c.status, disk.Threshold{Ratio: 0.10}, disk.Threshold{Ratio: 0.02})
+			if level != c.expected {
+				t.Errorf("got %s want %s", level, c.expected)
+			}
+		})
+	}
+
+	sizeCases := []struct {
+		name     string
+		status   *idl.WatchDiskSpaceReply
+		expected idl.WatchDiskSpaceReply_Level
+	}{
+		{"ok with more free space than the sizes", &idl.WatchDiskSpaceReply{Available: 2000, Total: 1000000, AvailableInodes: 1, TotalInodes: 100}, idl.WatchDiskSpaceReply_ok},
+		{"warns when below the warn size", &idl.WatchDiskSpaceReply{Available: 500, Total: 1000000, AvailableInodes: 50, TotalInodes: 100}, idl.WatchDiskSpaceReply_warn},
+		{"aborts when below the abort size", &idl.WatchDiskSpaceReply{Available: 50, Total: 100, AvailableInodes: 50, TotalInodes: 100}, idl.WatchDiskSpaceReply_abort},
+	}
+
+	for _, c := range sizeCases {
+		t.Run(c.name, func(t *testing.T) {
+			level := disk.Level(c.status, disk.Threshold{KB: 1000}, disk.Threshold{KB: 100})
+			if level != c.expected {
+				t.Errorf("got %s want %s", level, c.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+// Package environment reports the parts of a host's environment that affect
+// the Greenplum utilities run during an upgrade, and evaluates those reports
+// against a set of rules.
+package environment
+
+import (
+	"bufio"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"golang.org/x/xerrors"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/utils"
+)
+
+// Variables are the environment variables included in the report. Other
+// variables are omitted such that secrets are not sent to the hub.
+var Variables = []string{
+	"PATH",
+	"LD_LIBRARY_PATH",
+	"PYTHONPATH",
+	"GPHOME",
+	"PGPORT",
+	"COORDINATOR_DATA_DIRECTORY",
+	"MASTER_DATA_DIRECTORY",
+	"LANG",
+	"LC_ALL",
+	"LC_CTYPE",
+}
+
+const (
+	OpenFiles     = "open files"
+	UserProcesses = "max user processes"
+)
+
+var ulimits = map[string]int{
+	OpenFiles:     unix.RLIMIT_NOFILE,
+	UserProcesses: unix.RLIMIT_NPROC,
+}
+
+var execCommand = exec.Command
+
+// XXX: for internal testing only
+func SetExecCommand(command exectest.Command) {
+	execCommand = command
+}
+
+// XXX: for internal testing only
+func ResetExecCommand() {
+	execCommand = exec.Command
+}
+
+// Collect reports the environment of the local host along with whether each
+// GPHOME is reachable. Parts of the report that cannot be collected are listed
+// in its errors rather than failing the entire report.
+//
+// The environment is collected afresh on each call rather than taken from the
+// long running agent or hub, such that changes to the user's shell startup
+// files are reported without restarting them.
+func Collect(gphomes ...string) *idl.EnvironmentReport {
+	report := &idl.EnvironmentReport{}
+
+	var err error
+	report.Hostname, err = utils.System.Hostname()
+	if err != nil {
+		report.Errors = append(report.Errors, xerrors.Errorf("hostname: %w", err).Error())
+	}
+
+	report.Environment, err = sshEnvironment()
+	if err != nil {
+		report.Errors = append(report.Errors, xerrors.Errorf("shell environment: %w", err).Error())
+	}
+
+	report.LoginEnvironment, err = loginEnvironment()
+	if err != nil {
+		report.Errors = append(report.Errors, xerrors.Errorf("login shell environment: %w", err).Error())
+	}
+
+	report.Ulimits, err = getUlimits()
+	if err != nil {
+		report.Errors = append(report.Errors, xerrors.Errorf("ulimits: %w", err).Error())
+	}
+
+	report.Locale = Locale(report.Environment)
+
+	report.RsyncVersion = version("rsync", "--version")
+	report.SshVersion = version("ssh", "-V")
+
+	for _, gphome := range gphomes {
+		report.Gphomes = append(report.Gphomes, checkGPHome(gphome))
+	}
+
+	// Take the time last since the hub compares it to when the report is
+	// received.
+	report.UnixNano = time.Now().UnixNano()
+
+	return report
+}
+
+func selectVariables(environ []string) map[string]string {
+	selected := make(map[string]string)
+	for _, variable := range environ {
+		name, value, found := strings.Cut(variable, "=")
+		if !found {
+			continue
+		}
+
+		for _, v := range Variables {
+			if name == v {
+				selected[name] = value
+			}
+		}
+	}
+
+	return selected
+}
+
+// sshEnvironment starts a non-interactive shell with an otherwise empty
+// environment the same as running a command over ssh, which is how the
+// Greenplum utilities run commands on other hosts. bash reads ~/.bashrc in
+// that case, but only since it detects being run by sshd, so it is sourced
+// explicitly. BASH_ENV is not used since the scripts ~/.bashrc runs would
+// inherit it and source ~/.bashrc again.
+func sshEnvironment() (map[string]string, error) {
+	cmd := execCommand("bash", "-c", sshEnvironmentScript)
+	cmd.Env = cleanEnvironment()
+
+	return shellEnvironment(cmd)
+}
+
+const sshEnvironmentScript = `if [ -f ~/.bashrc ]; then . ~/.bashrc; fi >/dev/null 2>&1 </dev/null; env`
+
+// loginEnvironment starts a login shell with an otherwise empty environment
+// such that the result reflects the user's profile and shell startup files.
+func loginEnvironment() (map[string]string, error) {
+	cmd := execCommand("bash", "-l", "-c", "env")
+	cmd.Env = cleanEnvironment()
+
+	return shellEnvironment(cmd)
+}
+
+func cleanEnvironment() []string {
+	return []string{"HOME=" + os.Getenv("HOME"), "USER=" + os.Getenv("USER"), "LOGNAME=" + os.Getenv("LOGNAME")}
+}
+
+func shellEnvironment(cmd *exec.Cmd) (map[string]string, error) {
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, xerrors.Errorf("%q: %w", cmd.String(), err)
+	}
+
+	var environ []string
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		environ = append(environ, scanner.Text())
+	}
+
+	return selectVariables(environ), nil
+}
+
+func getUlimits() ([]*idl.EnvironmentReport_Ulimit, error) {
+	names := make([]string, 0, len(ulimits))
+	for name := range ulimits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []*idl.EnvironmentReport_Ulimit
+	for _, name := range names {
+		var limit unix.Rlimit
+		if err := unix.Getrlimit(ulimits[name], &limit); err != nil {
+			return nil, xerrors.Errorf("%s: %w", name, err)
+		}
+
+		result = append(result, &idl.EnvironmentReport_Ulimit{Name: name, Soft: uint64(limit.Cur), Hard: uint64(limit.Max)})
+	}
+
+	return result, nil
+}
+
+// Locale returns the locale used for character classification following the
+// precedence of LC_ALL, LC_CTYPE, and LANG.
+func Locale(environment map[string]string) string {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if environment[name] != "" {
+			return environment[name]
+		}
+	}
+
+	return "C"
+}
+
+// version returns the first line of the utility's version output, or empty if
+// the utility is not installed.
+func version(utility string, args ...string) string {
+	cmd := execCommand(utility, args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		log.Printf("%q failed with %q: %v", cmd.String(), string(output), err)
+		return ""
+	}
+
+	line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return line
+}
+
+func checkGPHome(gphome string) *idl.EnvironmentReport_Gphome {
+	result := &idl.EnvironmentReport_Gphome{Path: gphome}
+
+	postgres := filepath.Join(gphome, "bin", "postgres")
+	info, err := utils.System.Stat(postgres)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if info.Mode()&0111 == 0 {
+		result.Error = xerrors.Errorf("%q is not executable", postgres).Error()
+		return result
+	}
+
+	result.Reachable = true
+	return result
+}
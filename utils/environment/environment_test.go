@@ -0,0 +1,162 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package environment_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpupgrade/testutils"
+	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/testutils/testlog"
+	"github.com/greenplum-db/gpupgrade/utils/environment"
+)
+
+// Prints a login or non-interactive shell environment for "bash", and a
+// version for the other utilities.
+func UtilitiesMain() {
+	if os.Args[0] == "bash" && os.Args[1] == "-l" {
+		fmt.Println("PATH=/usr/local/greenplum-db-source/bin:/usr/bin")
+		fmt.Println("PGPORT=5432")
+		fmt.Println("SECRET=hunter2")
+		return
+	}
+
+	if os.Args[0] == "bash" {
+		if !strings.Contains(os.Args[2], ". ~/.bashrc") || os.Getenv("PATH") != "" {
+			fmt.Fprintf(os.Stderr, "unexpected command %q with environment %q", os.Args, os.Environ())
+			os.Exit(1)
+		}
+
+		fmt.Println("PATH=/usr/bin")
+		fmt.Println("LANG=en_US.UTF-8")
+		return
+	}
+
+	fmt.Printf("%s version 1.0\nmore details\n", os.Args[0])
+}
+
+func Failure() {
+	os.Stderr.WriteString("command not found")
+	os.Exit(127)
+}
+
+func init() {
+	exectest.RegisterMains(
+		UtilitiesMain,
+		Failure,
+	)
+}
+
+// Enable exectest.NewCommand mocking.
+func TestMain(m *testing.M) {
+	os.Exit(exectest.Run(m))
+}
+
+func TestCollect(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	gphome := testutils.GetTempDir(t, "")
+	defer testutils.MustRemoveAll(t, gphome)
+
+	if err := os.Mkdir(filepath.Join(gphome, "bin"), 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutils.MustWriteToFile(t, filepath.Join(gphome, "bin", "postgres"), "")
+	if err := os.Chmod(filepath.Join(gphome, "bin", "postgres"), 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The environment of the process itself is not reported.
+	resetPythonPath := testutils.SetEnv(t, "PYTHONPATH", "/usr/local/greenplum-db-source/lib/python")
+	defer resetPythonPath()
+
+	resetLcAll := testutils.SetEnv(t, "LC_ALL", "C")
+	defer resetLcAll()
+
+	t.Run("reports the environment of the host", func(t *testing.T) {
+		environment.SetExecCommand(exectest.NewCommand(UtilitiesMain))
+		defer environment.ResetExecCommand()
+
+		start := time.Now()
+		report := environment.Collect(gphome, "/does/not/exist")
+
+		expected := map[string]string{"PATH": "/usr/bin", "LANG": "en_US.UTF-8"}
+		if !reflect.DeepEqual(report.GetEnvironment(), expected) {
+			t.Errorf("got environment %v want %v", report.GetEnvironment(), expected)
+		}
+
+		if report.GetLocale() != "en_US.UTF-8" {
+			t.Errorf("got locale %q want %q", report.GetLocale(), "en_US.UTF-8")
+		}
+
+		expected = map[string]string{"PATH": "/usr/local/greenplum-db-source/bin:/usr/bin", "PGPORT": "5432"}
+		if !reflect.DeepEqual(report.GetLoginEnvironment(), expected) {
+			t.Errorf("got login environment %v want %v", report.GetLoginEnvironment(), expected)
+		}
+
+		if report.GetRsyncVersion() != "rsync version 1.0" || report.GetSshVersion() != "ssh version 1.0" {
+			t.Errorf("got versions %q and %q", report.GetRsyncVersion(), report.GetSshVersion())
+		}
+
+		if len(report.GetUlimits()) != 2 {
+			t.Errorf("got ulimits %v", report.GetUlimits())
+		}
+
+		gphomes := report.GetGphomes()
+		if len(gphomes) != 2 || !gphomes[0].GetReachable() || gphomes[1].GetReachable() || gphomes[1].GetError() == "" {
+			t.Errorf("got gphomes %v", gphomes)
+		}
+
+		if time.Unix(0, report.GetUnixNano()).Before(start) {
+			t.Errorf("got report time %d before %s", report.GetUnixNano(), start)
+		}
+
+		if len(report.GetErrors()) != 0 {
+			t.Errorf("unexpected errors %v", report.GetErrors())
+		}
+	})
+
+	t.Run("reports what could not be collected", func(t *testing.T) {
+		environment.SetExecCommand(exectest.NewCommand(Failure))
+		defer environment.ResetExecCommand()
+
+		report := environment.Collect()
+
+		if report.GetRsyncVersion() != "" || report.GetSshVersion() != "" {
+			t.Errorf("got versions %q and %q want none", report.GetRsyncVersion(), report.GetSshVersion())
+		}
+
+		errs := report.GetErrors()
+		if len(errs) != 2 || !strings.Contains(errs[0], "shell environment") || !strings.Contains(errs[1], "login shell environment") {
+			t.Errorf("got errors %v", errs)
+		}
+	})
+}
+
+func TestLocale(t *testing.T) {
+	cases := []struct {
+		environment map[string]string
+		expected    string
+	}{
+		{environment: map[string]string{"LC_ALL": "C", "LC_CTYPE": "en_US.UTF-8", "LANG": "de_DE.UTF-8"}, expected: "C"},
+		{environment: map[string]string{"LC_CTYPE": "en_US.UTF-8", "LANG": "de_DE.UTF-8"}, expected: "en_US.UTF-8"},
+		{environment: map[string]string{"LANG": "de_DE.UTF-8"}, expected: "de_DE.UTF-8"},
+		{environment: map[string]string{}, expected: "C"},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("returns %s given %v", c.expected, c.environment), func(t *testing.T) {
+			locale := environment.Locale(c.environment)
+			if locale != c.expected {
+				t.Errorf("got %q want %q", locale, c.expected)
+			}
+		})
+	}
+}
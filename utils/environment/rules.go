@@ -0,0 +1,250 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package environment
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+)
+
+const (
+	MinOpenFiles     = 65536
+	MinUserProcesses = 131072
+	MaxClockOffset   = time.Second
+)
+
+// HostReport is the environment report of a host along with how far its clock
+// is ahead of the hub's clock.
+type HostReport struct {
+	Host        string
+	Report      *idl.EnvironmentReport
+	ClockOffset time.Duration
+}
+
+// Rule checks a host's report and returns a message for each problem found.
+// Problems found by warning rules are reported without failing the check.
+type Rule struct {
+	Name       string
+	Warning    bool
+	NextAction string
+	Check      func(host HostReport) []string
+}
+
+type Finding struct {
+	Host    string
+	Rule    string
+	Message string
+	Warning bool
+}
+
+func (f Finding) Error() string {
+	return fmt.Sprintf("on host %s %s", f.Host, f.Message)
+}
+
+// Rules returns the rules for upgrading from sourceGPHome to targetGPHome.
+// Locales are compared against the coordinator's report.
+func Rules(sourceGPHome string, targetGPHome string, coordinator *idl.EnvironmentReport) []Rule {
+	gphomes := []string{sourceGPHome, targetGPHome}
+
+	return []Rule{
+		{
+			Name: "gphome_in_environment",
+			NextAction: `On all segments remove sourcing greenplum_path.sh and setting any Greenplum variables
+in .bashrc or .bash_profile. In a fresh shell re-run gpupgrade.`,
+			Check: func(host HostReport) []string {
+				var messages []string
+				for _, variable := range []string{"PATH", "LD_LIBRARY_PATH", "PYTHONPATH"} {
+					messages = append(messages, gphomeEntries("", variable, host.Report.GetEnvironment()[variable], gphomes)...)
+					messages = append(messages, gphomeEntries("login shell ", variable, host.Report.GetLoginEnvironment()[variable], gphomes)...)
+				}
+
+				return messages
+			},
+		},
+		{
+			Name:    "greenplum_variables",
+			Warning: true,
+			Check: func(host HostReport) []string {
+				var messages []string
+				for _, variable := range []string{"PGPORT", "COORDINATOR_DATA_DIRECTORY", "MASTER_DATA_DIRECTORY"} {
+					if value, ok := host.Report.GetEnvironment()[variable]; ok {
+						messages = append(messages, fmt.Sprintf("%s is set to %q", variable, value))
+					}
+
+					if value, ok := host.Report.GetLoginEnvironment()[variable]; ok {
+						messages = append(messages, fmt.Sprintf("login shell %s is set to %q", variable, value))
+					}
+				}
+
+				return messages
+			},
+		},
+		{
+			Name:       "gphome_reachable",
+			NextAction: "Install the source and target Greenplum versions in the same GPHOME on all hosts.",
+			Check: func(host HostReport) []string {
+				var messages []string
+				for _, gphome := range host.Report.GetGphomes() {
+					if !gphome.GetReachable() {
+						messages = append(messages, fmt.Sprintf("GPHOME %q is not reachable: %s", gphome.GetPath(), gphome.GetError()))
+					}
+				}
+
+				return messages
+			},
+		},
+		{
+			Name:       "rsync_installed",
+			NextAction: "Install rsync on all hosts.",
+			Check: func(host HostReport) []string {
+				if host.Report.GetRsyncVersion() == "" {
+					return []string{"rsync is not installed"}
+				}
+
+				return nil
+			},
+		},
+		{
+			Name:       "ssh_installed",
+			NextAction: "Install ssh on all hosts.",
+			Check: func(host HostReport) []string {
+				if host.Report.GetSshVersion() == "" {
+					return []string{"ssh is not installed"}
+				}
+
+				return nil
+			},
+		},
+		{
+			Name:    "ulimits",
+			Warning: true,
+			Check: func(host HostReport) []string {
+				minimums := map[string]uint64{OpenFiles: MinOpenFiles, UserProcesses: MinUserProcesses}
+
+				var messages []string
+				for _, ulimit := range host.Report.GetUlimits() {
+					if minimum, ok := minimums[ulimit.GetName()]; ok && ulimit.GetSoft() < minimum {
+						messages = append(messages, fmt.Sprintf("%s ulimit %s is less than the recommended %d", ulimit.GetName(), FormatLimit(ulimit.GetSoft()), minimum))
+					}
+				}
+
+				return messages
+			},
+		},
+		{
+			Name:    "locale",
+			Warning: true,
+			Check: func(host HostReport) []string {
+				if coordinator == nil || host.Report.GetLocale() == coordinator.GetLocale() {
+					return nil
+				}
+
+				return []string{fmt.Sprintf("locale %q differs from the coordinator locale %q", host.Report.GetLocale(), coordinator.GetLocale())}
+			},
+		},
+		{
+			Name:    "clock_offset",
+			Warning: true,
+			Check: func(host HostReport) []string {
+				offset := host.ClockOffset
+				if offset < 0 {
+					offset = -offset
+				}
+
+				if offset <= MaxClockOffset {
+					return nil
+				}
+
+				return []string{fmt.Sprintf("clock is offset from the coordinator by %s", host.ClockOffset.Round(time.Millisecond))}
+			},
+		},
+		{
+			Name:    "incomplete_report",
+			Warning: true,
+			Check: func(host HostReport) []string {
+				var messages []string
+				for _, err := range host.Report.GetErrors() {
+					messages = append(messages, "failed to report "+err)
+				}
+
+				return messages
+			},
+		},
+	}
+}
+
+// gphomeEntries returns a message for each entry of the path list which is
+// within one of the gphomes.
+func gphomeEntries(prefix string, variable string, pathList string, gphomes []string) []string {
+	var messages []string
+	for _, entry := range filepath.SplitList(pathList) {
+		for _, gphome := range gphomes {
+			if gphome == "" {
+				continue
+			}
+
+			if entry == gphome || strings.HasPrefix(entry, strings.TrimSuffix(gphome, "/")+"/") {
+				messages = append(messages, fmt.Sprintf("%s%s entry %q is within GPHOME %q", prefix, variable, entry, gphome))
+			}
+		}
+	}
+
+	return messages
+}
+
+// FormatLimit formats a ulimit the same as the ulimit utility.
+func FormatLimit(limit uint64) string {
+	if limit == uint64(unix.RLIM_INFINITY) {
+		return "unlimited"
+	}
+
+	return fmt.Sprintf("%d", limit)
+}
+
+// Evaluate checks each host's report against the rules and returns the
+// findings ordered by host.
+func Evaluate(hosts []HostReport, rules []Rule) []Finding {
+	sorted := append([]HostReport(nil), hosts...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Host < sorted[j].Host
+	})
+
+	var findings []Finding
+	for _, host := range sorted {
+		for _, rule := range rules {
+			for _, message := range rule.Check(host) {
+				findings = append(findings, Finding{Host: host.Host, Rule: rule.Name, Message: message, Warning: rule.Warning})
+			}
+		}
+	}
+
+	return findings
+}
+
+// NextActions returns the next action of each rule that failed, without
+// duplicates.
+func NextActions(findings []Finding, rules []Rule) []string {
+	failed := make(map[string]bool)
+	for _, finding := range findings {
+		if !finding.Warning {
+			failed[finding.Rule] = true
+		}
+	}
+
+	var nextActions []string
+	for _, rule := range rules {
+		if failed[rule.Name] && rule.NextAction != "" {
+			nextActions = append(nextActions, rule.NextAction)
+		}
+	}
+
+	return nextActions
+}
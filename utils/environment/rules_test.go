@@ -0,0 +1,179 @@
+// Copyright (c) 2017-2023 VMware, Inc. or its affiliates
+// SPDX-License-Identifier: Apache-2.0
+
+package environment_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpupgrade/idl"
+	"github.com/greenplum-db/gpupgrade/utils/environment"
+)
+
+const (
+	sourceGPHome = "/usr/local/greenplum-db-source"
+	targetGPHome = "/usr/local/greenplum-db-target"
+)
+
+func cleanReport() *idl.EnvironmentReport {
+	return &idl.EnvironmentReport{
+		Environment:      map[string]string{"PATH": "/usr/local/bin:/usr/bin"},
+		LoginEnvironment: map[string]string{"PATH": "/usr/local/bin:/usr/bin"},
+		Ulimits: []*idl.EnvironmentReport_Ulimit{
+			{Name: environment.OpenFiles, Soft: environment.MinOpenFiles, Hard: environment.MinOpenFiles},
+			{Name: environment.UserProcesses, Soft: environment.MinUserProcesses, Hard: environment.MinUserProcesses},
+		},
+		Locale:       "en_US.UTF-8",
+		RsyncVersion: "rsync  version 3.1.2  protocol version 31",
+		SshVersion:   "OpenSSH_7.4p1",
+		Gphomes: []*idl.EnvironmentReport_Gphome{
+			{Path: sourceGPHome, Reachable: true},
+			{Path: targetGPHome, Reachable: true},
+		},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	coordinator := cleanReport()
+	rules := environment.Rules(sourceGPHome, targetGPHome, coordinator)
+
+	t.Run("finds nothing for clean hosts", func(t *testing.T) {
+		findings := environment.Evaluate([]environment.HostReport{
+			{Host: "cdw", Report: coordinator},
+			{Host: "sdw1", Report: cleanReport(), ClockOffset: -environment.MaxClockOffset},
+		}, rules)
+
+		if len(findings) != 0 {
+			t.Errorf("unexpected findings %v", findings)
+		}
+	})
+
+	cases := []struct {
+		name     string
+		modify   func(host *environment.HostReport)
+		expected []environment.Finding
+	}{
+		{
+			name: "PATH entries within a GPHOME",
+			modify: func(host *environment.HostReport) {
+				host.Report.Environment["PATH"] = targetGPHome + "/bin:/usr/local/greenplum-db-source-tools/bin:/usr/bin"
+				host.Report.LoginEnvironment["LD_LIBRARY_PATH"] = sourceGPHome + "/lib"
+			},
+			expected: []environment.Finding{
+				{Host: "sdw1", Rule: "gphome_in_environment", Message: `PATH entry "/usr/local/greenplum-db-target/bin" is within GPHOME "/usr/local/greenplum-db-target"`},
+				{Host: "sdw1", Rule: "gphome_in_environment", Message: `login shell LD_LIBRARY_PATH entry "/usr/local/greenplum-db-source/lib" is within GPHOME "/usr/local/greenplum-db-source"`},
+			},
+		},
+		{
+			name: "Greenplum variables",
+			modify: func(host *environment.HostReport) {
+				host.Report.Environment["COORDINATOR_DATA_DIRECTORY"] = "/data/qddir/demoDataDir-1"
+			},
+			expected: []environment.Finding{
+				{Host: "sdw1", Rule: "greenplum_variables", Message: `COORDINATOR_DATA_DIRECTORY is set to "/data/qddir/demoDataDir-1"`, Warning: true},
+			},
+		},
+		{
+			name: "unreachable GPHOMEs",
+			modify: func(host *environment.HostReport) {
+				host.Report.Gphomes[1] = &idl.EnvironmentReport_Gphome{Path: targetGPHome, Error: "permission denied"}
+			},
+			expected: []environment.Finding{
+				{Host: "sdw1", Rule: "gphome_reachable", Message: `GPHOME "/usr/local/greenplum-db-target" is not reachable: permission denied`},
+			},
+		},
+		{
+			name: "missing utilities",
+			modify: func(host *environment.HostReport) {
+				host.Report.RsyncVersion = ""
+				host.Report.SshVersion = ""
+			},
+			expected: []environment.Finding{
+				{Host: "sdw1", Rule: "rsync_installed", Message: "rsync is not installed"},
+				{Host: "sdw1", Rule: "ssh_installed", Message: "ssh is not installed"},
+			},
+		},
+		{
+			name: "low ulimits",
+			modify: func(host *environment.HostReport) {
+				host.Report.Ulimits[0].Soft = 1024
+			},
+			expected: []environment.Finding{
+				{Host: "sdw1", Rule: "ulimits", Message: "open files ulimit 1024 is less than the recommended 65536", Warning: true},
+			},
+		},
+		{
+			name: "a locale differing from the coordinator",
+			modify: func(host *environment.HostReport) {
+				host.Report.Locale = "C"
+			},
+			expected: []environment.Finding{
+				{Host: "sdw1", Rule: "locale", Message: `locale "C" differs from the coordinator locale "en_US.UTF-8"`, Warning: true},
+			},
+		},
+		{
+			name: "clock offsets",
+			modify: func(host *environment.HostReport) {
+				host.ClockOffset = -3 * time.Second
+			},
+			expected: []environment.Finding{
+				{Host: "sdw1", Rule: "clock_offset", Message: "clock is offset from the coordinator by -3s", Warning: true},
+			},
+		},
+		{
+			name: "incomplete reports",
+			modify: func(host *environment.HostReport) {
+				host.Report.Errors = []string{"ulimits: operation not permitted"}
+			},
+			expected: []environment.Finding{
+				{Host: "sdw1", Rule: "incomplete_report", Message: "failed to report ulimits: operation not permitted", Warning: true},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run("finds "+c.name, func(t *testing.T) {
+			host := environment.HostReport{Host: "sdw1", Report: cleanReport()}
+			c.modify(&host)
+
+			findings := environment.Evaluate([]environment.HostReport{{Host: "cdw", Report: coordinator}, host}, rules)
+			if !reflect.DeepEqual(findings, c.expected) {
+				t.Errorf("got findings %v want %v", findings, c.expected)
+			}
+		})
+	}
+
+	t.Run("orders findings by host", func(t *testing.T) {
+		sdw2 := cleanReport()
+		sdw2.SshVersion = ""
+		sdw1 := cleanReport()
+		sdw1.SshVersion = ""
+
+		findings := environment.Evaluate([]environment.HostReport{{Host: "sdw2", Report: sdw2}, {Host: "sdw1", Report: sdw1}}, rules)
+		if len(findings) != 2 || findings[0].Host != "sdw1" || findings[1].Host != "sdw2" {
+			t.Errorf("got findings %v", findings)
+		}
+
+		if findings[0].Error() != "on host sdw1 ssh is not installed" {
+			t.Errorf("got %q", findings[0].Error())
+		}
+	})
+}
+
+func TestNextActions(t *testing.T) {
+	rules := environment.Rules(sourceGPHome, targetGPHome, nil)
+
+	findings := []environment.Finding{
+		{Host: "sdw1", Rule: "rsync_installed"},
+		{Host: "sdw2", Rule: "rsync_installed"},
+		{Host: "sdw2", Rule: "locale", Warning: true},
+	}
+
+	expected := []string{"Install rsync on all hosts."}
+	nextActions := environment.NextActions(findings, rules)
+	if !reflect.DeepEqual(nextActions, expected) {
+		t.Errorf("got %v want %v", nextActions, expected)
+	}
+}
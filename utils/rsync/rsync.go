@@ -4,14 +4,17 @@
 package rsync
 
 import (
+	"context"
 	"log"
 	"os/exec"
 	"runtime"
 
 	"github.com/pkg/errors"
 
+	"github.com/greenplum-db/gpupgrade/fault"
 	"github.com/greenplum-db/gpupgrade/step"
 	"github.com/greenplum-db/gpupgrade/testutils/exectest"
+	"github.com/greenplum-db/gpupgrade/utils"
 )
 
 var Options = []string{"--archive", "--compress", "--stats"}
@@ -38,7 +41,7 @@ func Rsync(options ...Option) error {
 
 	dstPath := opts.destination
 	if opts.hasDestinationHost {
-		dstPath = opts.destinationHost + ":" + opts.destination
+		dstPath = utils.JoinHostPath(opts.destinationHost, opts.destination)
 	}
 
 	srcPath := opts.sources
@@ -48,7 +51,7 @@ func Rsync(options ...Option) error {
 		if len(opts.sources) != 1 {
 			return ErrInvalidRsyncSourcePath
 		}
-		srcPath = []string{opts.sourceHost + ":" + opts.sources[0]}
+		srcPath = []string{utils.JoinHostPath(opts.sourceHost, opts.sources[0])}
 	}
 
 	var args []string
@@ -76,9 +79,14 @@ func Rsync(options ...Option) error {
 		cmd.Stderr = opts.stream.Stderr()
 	}
 
+	cmd, err := fault.Command("rsync", cmd)
+	if err != nil {
+		return err
+	}
+
 	log.Printf("Executing: %q", cmd.String())
 
-	err := cmd.Run()
+	err = utils.RunContext(opts.ctx, cmd)
 	if err != nil {
 		errorText := err.Error()
 
@@ -157,6 +165,13 @@ func WithExcludedFiles(files ...string) Option {
 	}
 }
 
+// WithContext terminates rsync when the context is done.
+func WithContext(ctx context.Context) Option {
+	return func(options *optionList) {
+		options.ctx = ctx
+	}
+}
+
 func WithStream(stream step.OutStreams) Option {
 	return func(options *optionList) {
 		options.stream = stream
@@ -175,10 +190,11 @@ type optionList struct {
 	excludedFiles      []string
 	useStream          bool
 	stream             step.OutStreams
+	ctx                context.Context
 }
 
 func newOptionList(opts ...Option) *optionList {
-	o := new(optionList)
+	o := &optionList{ctx: context.Background()}
 	for _, option := range opts {
 		option(o)
 	}
@@ -4,6 +4,7 @@
 package rsync_test
 
 import (
+	"context"
 	"errors"
 	"os"
 	"os/exec"
@@ -373,6 +374,36 @@ func TestRsync(t *testing.T) {
 		}
 	})
 
+	t.Run("encloses IPv6 remote hosts in brackets", func(t *testing.T) {
+		sourceDir := "/data/qddir/seg-1/"
+		targetDir := "/data/standby"
+
+		cmd := exectest.NewCommandWithVerifier(Success, func(name string, args ...string) {
+			expectedSourcePath := "[fd00::1]:" + sourceDir
+			if args[0] != expectedSourcePath {
+				t.Errorf("got %q, want %q", args[0], expectedSourcePath)
+			}
+
+			expectedDestination := "[fd00::2]:" + targetDir
+			if args[1] != expectedDestination {
+				t.Errorf("got %q, want %q", args[1], expectedDestination)
+			}
+		})
+
+		rsync.SetRsyncCommand(cmd)
+		defer rsync.ResetRsyncCommand()
+
+		err := rsync.Rsync(
+			rsync.WithSources(sourceDir),
+			rsync.WithSourceHost("fd00::1"),
+			rsync.WithDestinationHost("fd00::2"),
+			rsync.WithDestination(targetDir),
+		)
+		if err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
 	t.Run("rsync for multiple path from remote host fails", func(t *testing.T) {
 		sourceDir := "/data/qddir/seg-1"
 		sourceHost := "localhost"
@@ -395,3 +426,30 @@ func TestRsync(t *testing.T) {
 		}
 	})
 }
+
+func TestRsyncWithContext(t *testing.T) {
+	testlog.SetupTestLogger()
+
+	t.Run("returns the cause when the context is done", func(t *testing.T) {
+		rsync.SetRsyncCommand(exectest.NewCommand(Success))
+		defer rsync.ResetRsyncCommand()
+
+		expected := errors.New("filesystem is almost full")
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(expected)
+
+		err := rsync.Rsync(
+			rsync.WithSources("/data/source/"),
+			rsync.WithDestination("/data/destination"),
+			rsync.WithContext(ctx),
+		)
+		if !errors.Is(err, expected) {
+			t.Errorf("got error %#v want %#v", err, expected)
+		}
+
+		var rsyncErr rsync.RsyncError
+		if !errors.As(err, &rsyncErr) {
+			t.Errorf("got error %T want %T", err, rsyncErr)
+		}
+	})
+}